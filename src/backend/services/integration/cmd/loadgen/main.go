@@ -0,0 +1,371 @@
+// Command loadgen drives the integration service's send endpoints with concurrent
+// traffic and reports throughput and latency, optionally standing up mock
+// Slack and Jira providers so the adapters can be exercised without real
+// credentials. It exits non-zero when the configured error-rate or latency
+// thresholds are breached, so it can gate performance regressions in CI.
+//
+// Examples:
+//
+//	loadgen -mock :9090                                  # mock providers only
+//	loadgen -target http://localhost:8080 -duration 30s  # load the running service
+//	loadgen -target http://localhost:8080 -mock :9090 -max-p99 250ms -json
+package main
+
+import (
+	// go1.21 - Request payloads and the machine-readable report
+	"bytes"
+	"encoding/json"
+	// go1.21 - Command-line flags and output
+	"flag"
+	"fmt"
+	"os"
+	// go1.21 - HTTP load client and mock provider servers
+	"context"
+	"crypto/tls"
+	"io"
+	"math/rand"
+	"net/http"
+	"os/signal"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	// v0.5.0 - Open-loop request pacing when a target rate is set
+	"golang.org/x/time/rate"
+)
+
+// options holds the parsed command-line flags.
+type options struct {
+	target       string
+	endpoint     string
+	integration  string
+	message      string
+	token        string
+	concurrency  int
+	duration     time.Duration
+	rps          float64
+	wait         string
+	insecure     bool
+	jsonOutput   bool
+	maxErrorRate float64
+	maxP99       time.Duration
+
+	mockAddr         string
+	mockLatency      time.Duration
+	mockErrorRate    float64
+	mockThrottleRate float64
+}
+
+// report summarizes a load run.
+type report struct {
+	Requests    int64            `json:"requests"`
+	Errors      int64            `json:"errors"`
+	ErrorRate   float64          `json:"errorRate"`
+	Duration    time.Duration    `json:"durationNs"`
+	Throughput  float64          `json:"throughputRps"`
+	StatusCodes map[string]int64 `json:"statusCodes"`
+	LatencyP50  time.Duration    `json:"latencyP50Ns"`
+	LatencyP90  time.Duration    `json:"latencyP90Ns"`
+	LatencyP99  time.Duration    `json:"latencyP99Ns"`
+	LatencyMax  time.Duration    `json:"latencyMaxNs"`
+}
+
+// sample is the outcome of one request.
+type sample struct {
+	latency time.Duration
+	status  int
+}
+
+func main() {
+	opts := parseFlags()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	// 1. Start mock providers when requested.
+	if opts.mockAddr != "" {
+		mock := &http.Server{Addr: opts.mockAddr, Handler: newMockProvider(opts), ReadHeaderTimeout: 5 * time.Second}
+		go func() {
+			if err := mock.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				fmt.Fprintf(os.Stderr, "mock provider failed: %v\n", err)
+				os.Exit(2)
+			}
+		}()
+		defer mock.Close()
+		fmt.Fprintf(os.Stderr, "mock providers listening on %s\n", opts.mockAddr)
+
+		// Without a target, serve mocks until interrupted.
+		if opts.target == "" {
+			<-ctx.Done()
+			return
+		}
+	}
+	if opts.target == "" {
+		fmt.Fprintln(os.Stderr, "either -target or -mock is required")
+		os.Exit(2)
+	}
+
+	// 2. Generate load and summarize.
+	runCtx, cancel := context.WithTimeout(ctx, opts.duration)
+	defer cancel()
+	result := run(runCtx, opts)
+
+	// 3. Print the report and enforce thresholds.
+	if opts.jsonOutput {
+		_ = json.NewEncoder(os.Stdout).Encode(result)
+	} else {
+		printReport(result)
+	}
+	if breached := checkThresholds(result, opts); len(breached) > 0 {
+		for _, msg := range breached {
+			fmt.Fprintln(os.Stderr, "threshold breached:", msg)
+		}
+		os.Exit(1)
+	}
+}
+
+// parseFlags reads and validates the command-line flags.
+func parseFlags() options {
+	var opts options
+	flag.StringVar(&opts.target, "target", "", "Base URL of the integration service, e.g. http://localhost:8080")
+	flag.StringVar(&opts.endpoint, "endpoint", "/api/v1/slack/post", "Send endpoint path to exercise")
+	flag.StringVar(&opts.integration, "integration", "slack", "Integration name placed in the request body")
+	flag.StringVar(&opts.message, "message", "loadgen message", "Message body to send")
+	flag.StringVar(&opts.token, "token", os.Getenv("LOADGEN_TOKEN"), "Bearer token (defaults to $LOADGEN_TOKEN)")
+	flag.IntVar(&opts.concurrency, "concurrency", 16, "Number of concurrent workers")
+	flag.DurationVar(&opts.duration, "duration", 30*time.Second, "How long to generate load")
+	flag.Float64Var(&opts.rps, "rps", 0, "Target requests per second across all workers (0 = as fast as possible)")
+	flag.StringVar(&opts.wait, "wait", "", "Value for the ?wait= query parameter, e.g. 2s, to wait for delivery")
+	flag.BoolVar(&opts.insecure, "insecure", false, "Skip TLS certificate verification for the target")
+	flag.BoolVar(&opts.jsonOutput, "json", false, "Print the report as JSON")
+	flag.Float64Var(&opts.maxErrorRate, "max-error-rate", 0.01, "Fail when the error rate exceeds this fraction")
+	flag.DurationVar(&opts.maxP99, "max-p99", 0, "Fail when p99 latency exceeds this duration (0 = disabled)")
+	flag.StringVar(&opts.mockAddr, "mock", "", "Listen address for mock Slack and Jira providers, e.g. :9090")
+	flag.DurationVar(&opts.mockLatency, "mock-latency", 20*time.Millisecond, "Simulated provider latency")
+	flag.Float64Var(&opts.mockErrorRate, "mock-error-rate", 0, "Fraction of provider calls answered with 500")
+	flag.Float64Var(&opts.mockThrottleRate, "mock-throttle-rate", 0, "Fraction of provider calls answered with 429 and Retry-After")
+	flag.Parse()
+
+	if opts.concurrency <= 0 || opts.duration <= 0 || opts.rps < 0 {
+		fmt.Fprintln(os.Stderr, "concurrency and duration must be positive and rps non-negative")
+		os.Exit(2)
+	}
+	return opts
+}
+
+// run drives the target with opts.concurrency workers until ctx ends.
+func run(ctx context.Context, opts options) report {
+	client := &http.Client{
+		Timeout: 30 * time.Second,
+		Transport: &http.Transport{
+			MaxIdleConns:        opts.concurrency,
+			MaxIdleConnsPerHost: opts.concurrency,
+			IdleConnTimeout:     90 * time.Second,
+			TLSClientConfig:     &tls.Config{InsecureSkipVerify: opts.insecure}, //nolint:gosec // opt-in for test targets
+		},
+	}
+
+	url := opts.target + opts.endpoint
+	if opts.wait != "" {
+		url += "?wait=" + opts.wait
+	}
+	body, _ := json.Marshal(map[string]string{
+		"integrationName": opts.integration,
+		"message":         opts.message,
+	})
+
+	var limiter *rate.Limiter
+	if opts.rps > 0 {
+		limiter = rate.NewLimiter(rate.Limit(opts.rps), 1)
+	}
+
+	var (
+		mu      sync.Mutex
+		samples []sample
+		wg      sync.WaitGroup
+	)
+	start := time.Now()
+	for n := 0; n < opts.concurrency; n++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			local := make([]sample, 0, 1024)
+			for ctx.Err() == nil {
+				if limiter != nil && limiter.Wait(ctx) != nil {
+					break
+				}
+				local = append(local, doRequest(ctx, client, url, opts.token, body))
+			}
+			mu.Lock()
+			samples = append(samples, local...)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	return summarize(samples, time.Since(start))
+}
+
+// doRequest sends one request and records its latency and status. Transport
+// errors are recorded with status 0; requests cut off by the end of the run are
+// not counted.
+func doRequest(ctx context.Context, client *http.Client, url, token string, body []byte) sample {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return sample{}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	begin := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return sample{status: -1}
+		}
+		return sample{latency: time.Since(begin)}
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	return sample{latency: time.Since(begin), status: resp.StatusCode}
+}
+
+// summarize computes throughput, status counts, and latency percentiles.
+func summarize(samples []sample, elapsed time.Duration) report {
+	r := report{Duration: elapsed, StatusCodes: make(map[string]int64)}
+	latencies := make([]time.Duration, 0, len(samples))
+	for _, s := range samples {
+		if s.status < 0 {
+			continue
+		}
+		r.Requests++
+		label := "error"
+		if s.status > 0 {
+			label = strconv.Itoa(s.status)
+		}
+		r.StatusCodes[label]++
+		if s.status == 0 || s.status >= 400 {
+			r.Errors++
+		}
+		latencies = append(latencies, s.latency)
+	}
+	if r.Requests == 0 {
+		return r
+	}
+
+	sort.Slice(latencies, func(a, b int) bool { return latencies[a] < latencies[b] })
+	r.ErrorRate = float64(r.Errors) / float64(r.Requests)
+	r.Throughput = float64(r.Requests) / elapsed.Seconds()
+	r.LatencyP50 = percentile(latencies, 0.50)
+	r.LatencyP90 = percentile(latencies, 0.90)
+	r.LatencyP99 = percentile(latencies, 0.99)
+	r.LatencyMax = latencies[len(latencies)-1]
+	return r
+}
+
+// percentile returns the q-th quantile of sorted latencies (nearest rank).
+func percentile(sorted []time.Duration, q float64) time.Duration {
+	idx := int(q*float64(len(sorted)) + 0.5)
+	if idx >= len(sorted) {
+		idx = len(sorted) - 1
+	}
+	return sorted[idx]
+}
+
+// printReport writes a human-readable summary.
+func printReport(r report) {
+	fmt.Printf("requests:    %d in %s\n", r.Requests, r.Duration.Round(time.Millisecond))
+	fmt.Printf("throughput:  %.1f req/s\n", r.Throughput)
+	fmt.Printf("errors:      %d (%.2f%%)\n", r.Errors, r.ErrorRate*100)
+	fmt.Printf("latency:     p50=%s p90=%s p99=%s max=%s\n",
+		r.LatencyP50.Round(time.Microsecond), r.LatencyP90.Round(time.Microsecond),
+		r.LatencyP99.Round(time.Microsecond), r.LatencyMax.Round(time.Microsecond))
+
+	codes := make([]string, 0, len(r.StatusCodes))
+	for code := range r.StatusCodes {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	for _, code := range codes {
+		fmt.Printf("status %-5s %d\n", code, r.StatusCodes[code])
+	}
+}
+
+// checkThresholds returns a message for every breached threshold.
+func checkThresholds(r report, opts options) []string {
+	var breached []string
+	if r.Requests == 0 {
+		return append(breached, "no requests completed")
+	}
+	if r.ErrorRate > opts.maxErrorRate {
+		breached = append(breached, fmt.Sprintf("error rate %.4f > %.4f", r.ErrorRate, opts.maxErrorRate))
+	}
+	if opts.maxP99 > 0 && r.LatencyP99 > opts.maxP99 {
+		breached = append(breached, fmt.Sprintf("p99 latency %s > %s", r.LatencyP99, opts.maxP99))
+	}
+	return breached
+}
+
+// newMockProvider serves minimal Slack and Jira endpoints used by the adapters,
+// with configurable latency, failures, and 429 throttling.
+func newMockProvider(opts options) http.Handler {
+	var issueSeq atomic.Int64
+	mux := http.NewServeMux()
+
+	// respond applies the simulated latency and fault injection, returning false
+	// when the request was answered with a fault.
+	respond := func(w http.ResponseWriter, r *http.Request) bool {
+		if opts.mockLatency > 0 {
+			select {
+			case <-time.After(opts.mockLatency):
+			case <-r.Context().Done():
+				return false
+			}
+		}
+		roll := rand.Float64()
+		switch {
+		case roll < opts.mockThrottleRate:
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return false
+		case roll < opts.mockThrottleRate+opts.mockErrorRate:
+			w.WriteHeader(http.StatusInternalServerError)
+			return false
+		}
+		w.Header().Set("Content-Type", "application/json")
+		return true
+	}
+
+	// Slack Web API
+	mux.HandleFunc("/api/auth.test", func(w http.ResponseWriter, r *http.Request) {
+		if respond(w, r) {
+			_, _ = io.WriteString(w, `{"ok":true,"team":"loadgen","user":"loadgen-bot"}`)
+		}
+	})
+	mux.HandleFunc("/api/chat.postMessage", func(w http.ResponseWriter, r *http.Request) {
+		if respond(w, r) {
+			ts := strconv.FormatInt(time.Now().UnixNano(), 10)
+			_, _ = io.WriteString(w, `{"ok":true,"channel":"C0LOADGEN","ts":"`+ts[:10]+"."+ts[10:16]+`"}`)
+		}
+	})
+
+	// Jira REST API
+	mux.HandleFunc("/rest/api/2/myself", func(w http.ResponseWriter, r *http.Request) {
+		if respond(w, r) {
+			_, _ = io.WriteString(w, `{"name":"loadgen","active":true}`)
+		}
+	})
+	mux.HandleFunc("/rest/api/2/issue", func(w http.ResponseWriter, r *http.Request) {
+		if respond(w, r) {
+			id := issueSeq.Add(1)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = fmt.Fprintf(w, `{"id":"%d","key":"LOAD-%d","self":"http://%s/rest/api/2/issue/%d"}`, id, id, r.Host, id)
+		}
+	})
+	return mux
+}
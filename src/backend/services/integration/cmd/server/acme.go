@@ -0,0 +1,60 @@
+package main
+
+import (
+	// go1.21 - HTTP-01 challenge listener
+	"net/http"
+
+	// v0.17.0 - ACME client and automatic certificate management
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
+
+	// Internal package for the server section of the configuration
+	"src/backend/services/integration/internal/config"
+)
+
+// newACMEManager builds the manager that obtains and renews the server
+// certificate from the configured ACME CA, or returns nil when ACME is not
+// enabled. Steps:
+//  1. Accept the CA's terms of service, as an unattended service must.
+//  2. Keep the account key and certificates in the cache directory.
+//  3. Only request certificates for the configured domains.
+//  4. Use the configured directory instead of Let's Encrypt production, if any.
+func newACMEManager(serverCfg *config.ServerConfig) *autocert.Manager {
+	if !serverCfg.TLSEnabled() || !serverCfg.TLS.ACMEEnabled() {
+		return nil
+	}
+	acmeCfg := serverCfg.TLS.ACME
+
+	// 1-3. Unattended issuance for the configured domains only.
+	manager := &autocert.Manager{
+		Prompt:      autocert.AcceptTOS,
+		Cache:       autocert.DirCache(acmeCfg.CacheDir),
+		HostPolicy:  autocert.HostWhitelist(acmeCfg.Domains...),
+		Email:       acmeCfg.Email,
+		RenewBefore: acmeCfg.RenewBefore,
+	}
+
+	// 4. E.g. the Let's Encrypt staging directory while testing.
+	if acmeCfg.DirectoryURL != "" {
+		manager.Client = &acme.Client{DirectoryURL: acmeCfg.DirectoryURL}
+	}
+	return manager
+}
+
+// acmeChallengeServer returns the plaintext server that answers HTTP-01
+// challenges and redirects every other request to HTTPS, or nil unless the
+// HTTP-01 challenge is configured.
+func acmeChallengeServer(manager *autocert.Manager, serverCfg *config.ServerConfig) *http.Server {
+	if manager == nil || !serverCfg.TLS.ACME.HTTPChallenge() {
+		return nil
+	}
+	timeouts := serverCfg.ServerTimeouts()
+	return &http.Server{
+		Addr:              serverCfg.TLS.ACME.HTTPAddr,
+		Handler:           manager.HTTPHandler(nil),
+		ReadHeaderTimeout: timeouts.ReadHeader,
+		ReadTimeout:       timeouts.Read,
+		WriteTimeout:      timeouts.Write,
+		IdleTimeout:       timeouts.Idle,
+	}
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	// go1.21 - Environment fallbacks for flags
+	"os"
+	// go1.21 - Port validation
+	"strconv"
+	"strings"
+
+	// v1.8.0 - Command-line interface with usage and help output
+	"github.com/spf13/cobra"
+	// v1.0.5 - POSIX-style flag sets backing the cobra commands
+	"github.com/spf13/pflag"
+
+	// v1.24.0 - Log level parsing
+	"go.uber.org/zap/zapcore"
+
+	// Internal build information for --version
+	"src/backend/services/integration/internal/buildinfo"
+)
+
+// defaultLogLevel is the log level used when neither --log-level nor LOG_LEVEL is set.
+const defaultLogLevel = "info"
+
+// serverOptions holds the command-line options of the service.
+type serverOptions struct {
+	// configPath is the service configuration file.
+	configPath string
+
+	// port is the listen address, either a bare port ("8080") or host:port.
+	port string
+
+	// logLevel is the minimum level logged (debug, info, warn, error).
+	logLevel string
+
+	// dev switches to human-readable development logging.
+	dev bool
+
+	// requireAllIntegrations fails startup when any configured integration does
+	// not initialize and report connected during warm-up.
+	requireAllIntegrations bool
+}
+
+// envFallbacks maps flags to the environment variables consulted when the flag
+// is not given on the command line. These are the variables the service read
+// before it had a CLI, so existing deployments keep working.
+var envFallbacks = []struct {
+	flag string
+	env  string
+}{
+	{flag: "config", env: "INTEGRATION_CONFIG_PATH"},
+	{flag: "port", env: "SERVICE_PORT"},
+	{flag: "log-level", env: "LOG_LEVEL"},
+	{flag: "dev", env: "DEV_MODE"},
+	{flag: "require-all-integrations", env: "REQUIRE_ALL_INTEGRATIONS"},
+}
+
+// newRootCommand builds the service's command tree. Run without a subcommand,
+// the binary serves as it always has, so existing deployments need no changes.
+func newRootCommand() *cobra.Command {
+	opts := &serverOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "integration-service",
+		Short: "TaskStream AI integration service",
+		Long: `Runs the TaskStream AI integration service, which relays messages to Slack,
+Jira, email and other providers. Without a subcommand it behaves like "serve".
+
+Every flag falls back to an environment variable when not given:
+  --config     INTEGRATION_CONFIG_PATH
+  --port       SERVICE_PORT
+  --log-level  LOG_LEVEL
+  --dev        DEV_MODE
+  --require-all-integrations  REQUIRE_ALL_INTEGRATIONS`,
+		Version:      versionString(),
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := applyEnvFallbacks(cmd.Flags()); err != nil {
+				return err
+			}
+			return opts.validate()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return serve(opts)
+		},
+	}
+
+	flags := cmd.PersistentFlags()
+	flags.StringVarP(&opts.configPath, "config", "c", defaultConfigPath, "path to the service configuration file")
+	flags.StringVar(&opts.logLevel, "log-level", defaultLogLevel, "minimum log level: debug, info, warn or error")
+	flags.BoolVar(&opts.dev, "dev", false, "use human-readable development logging")
+	addServeFlags(cmd.Flags(), opts)
+
+	cmd.AddCommand(
+		newServeCommand(opts),
+		newSendCommand(opts),
+		newIntegrationsCommand(opts),
+		newConfigCommand(opts),
+	)
+	return cmd
+}
+
+// versionString renders build information for --version.
+func versionString() string {
+	b := buildinfo.Get()
+	return b.Version + " (commit " + b.Commit + ", built " + b.BuildTime + ", " + b.GoVersion + " " + b.Platform + ")"
+}
+
+// newServeCommand builds "serve", which runs the HTTP service until signalled.
+func newServeCommand(opts *serverOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Run the integration service",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return serve(opts)
+		},
+	}
+	addServeFlags(cmd.Flags(), opts)
+	return cmd
+}
+
+// addServeFlags registers the flags that only apply when serving.
+func addServeFlags(flags *pflag.FlagSet, opts *serverOptions) {
+	flags.StringVarP(&opts.port, "port", "p", defaultPort, `listen address, a port ("8080") or host:port`)
+	flags.BoolVar(&opts.requireAllIntegrations, "require-all-integrations", false,
+		"exit if any configured integration fails to warm up at startup")
+}
+
+// applyEnvFallbacks sets each flag not given on the command line from its
+// environment variable, if set. Values are parsed like flag values, so an
+// invalid DEV_MODE fails the same way an invalid --dev would.
+func applyEnvFallbacks(flags *pflag.FlagSet) error {
+	for _, fb := range envFallbacks {
+		// Skip flags the running command does not have, e.g. --port for "send".
+		if flags.Lookup(fb.flag) == nil || flags.Changed(fb.flag) {
+			continue
+		}
+		value, ok := os.LookupEnv(fb.env)
+		if !ok || value == "" {
+			continue
+		}
+		if err := flags.Set(fb.flag, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validate normalizes the listen address and checks the log level.
+func (o *serverOptions) validate() error {
+	if o.port == "" {
+		o.port = defaultPort
+	}
+	// A bare port number is shorthand for listening on all interfaces.
+	if n, err := strconv.Atoi(o.port); err == nil {
+		if n < 0 || n > 65535 {
+			return &optionError{option: "port", value: o.port}
+		}
+		o.port = ":" + o.port
+	} else if !strings.Contains(o.port, ":") {
+		return &optionError{option: "port", value: o.port}
+	}
+
+	if _, err := zapcore.ParseLevel(o.logLevel); err != nil {
+		return &optionError{option: "log-level", value: o.logLevel}
+	}
+	return nil
+}
+
+// optionError reports an invalid command-line option.
+type optionError struct {
+	option string
+	value  string
+}
+
+// Error implements the error interface.
+func (e *optionError) Error() string {
+	return "invalid value " + strconv.Quote(e.value) + " for --" + e.option
+}
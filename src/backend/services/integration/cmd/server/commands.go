@@ -0,0 +1,140 @@
+package main
+
+import (
+	// go1.21 - Deadlines for one-shot sends
+	"context"
+	"fmt"
+	// go1.21 - Tabular output for integration listings
+	"text/tabwriter"
+	"time"
+
+	// v1.8.0 - Command-line interface with usage and help output
+	"github.com/spf13/cobra"
+
+	// Internal packages for configuration, the shared outbound transport and adapter interfaces
+	"src/backend/services/integration/internal/config"
+	"src/backend/services/integration/internal/httpclient"
+	"src/backend/services/integration/internal/models"
+)
+
+// defaultSendTimeout bounds a one-shot send, including adapter initialization.
+const defaultSendTimeout = 30 * time.Second
+
+// newSendCommand builds "send", which delivers one message through a single
+// integration without starting the server. It is meant for manual checks of
+// credentials and connectivity.
+func newSendCommand(opts *serverOptions) *cobra.Command {
+	var (
+		name    string
+		msg     manualMessage
+		timeout time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "send",
+		Short: "Send one message through an integration",
+		Example: `  integration-service send --integration slack --message "Deploy finished"
+  integration-service send -i jira -m "Details..." --subject "Nightly build failed"
+  integration-service send -i email -m "Hello" --subject "Test" --to ops@example.com`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			def, err := lookupIntegration(name)
+			if err != nil {
+				return err
+			}
+			cfg, err := config.LoadConfig(opts.configPath)
+			if err != nil {
+				return err
+			}
+			if !def.configured(cfg) {
+				return fmt.Errorf("integration %q is not configured in %s", name, opts.configPath)
+			}
+
+			// Adapters use the shared outbound transport, as they do when serving.
+			factory, err := httpclient.NewFactory(cfg.HTTPClient)
+			if err != nil {
+				return err
+			}
+			httpclient.SetDefault(factory)
+			defer factory.CloseIdleConnections()
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+			defer cancel()
+			integration, err := def.build(ctx, cfg)
+			if err != nil {
+				return fmt.Errorf("failed to initialize %s: %w", name, err)
+			}
+			if closer, ok := integration.(models.Closer); ok {
+				defer closer.Close(context.Background())
+			}
+			if err := def.send(ctx, integration, &msg); err != nil {
+				return fmt.Errorf("send through %s failed: %w", name, err)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Message sent through %s\n", name)
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&name, "integration", "i", "", "integration to send through (see \"integrations list\")")
+	flags.StringVarP(&msg.text, "message", "m", "", "message text")
+	flags.StringVar(&msg.subject, "subject", "", "email subject or Jira summary (defaults to the message)")
+	flags.StringSliceVar(&msg.to, "to", nil, "email recipients or push devices (platform:token), repeated or comma-separated")
+	flags.DurationVar(&timeout, "timeout", defaultSendTimeout, "deadline for the send, including connecting")
+	_ = cmd.MarkFlagRequired("integration")
+	_ = cmd.MarkFlagRequired("message")
+	return cmd
+}
+
+// newIntegrationsCommand builds "integrations" and its "list" subcommand.
+func newIntegrationsCommand(opts *serverOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "integrations",
+		Short: "Inspect the integrations the service supports",
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "list",
+		Short: "List integrations and whether they are configured",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.LoadConfig(opts.configPath)
+			if err != nil {
+				return err
+			}
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+			fmt.Fprintln(w, "NAME\tTYPE\tCONFIGURED\tDESCRIPTION")
+			for _, def := range knownIntegrations {
+				fmt.Fprintf(w, "%s\t%s\t%t\t%s\n", def.name, def.kind, def.configured(cfg), def.description)
+			}
+			return w.Flush()
+		},
+	})
+	return cmd
+}
+
+// newConfigCommand builds "config" and its "validate" subcommand.
+func newConfigCommand(opts *serverOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Work with the service configuration",
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "validate",
+		Short: "Load and validate the configuration file",
+		Long: `Loads the configuration file with defaults and environment overrides applied,
+runs the same validation as the service at startup, and exits non-zero on error.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.LoadConfig(opts.configPath)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Configuration %s is valid (version %s)\n", opts.configPath, cfg.Version)
+			return nil
+		},
+	})
+	return cmd
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	// go1.21 - HTTP server and TLS protocol negotiation
+	"crypto/tls"
+	"net"
+	"net/http"
+
+	// v0.17.0 - HTTP/2 server tuning, cleartext h2c, and connection limiting
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+	"golang.org/x/net/netutil"
+
+	// Internal package for the server section of the configuration
+	"src/backend/services/integration/internal/config"
+)
+
+// configureHTTP2 sets up HTTP/2 on srv according to the server configuration.
+// Steps:
+//  1. With HTTP/2 disabled, clear TLSNextProto so ALPN only offers HTTP/1.1.
+//  2. Build the HTTP/2 server settings (stream limit, idle timeout).
+//  3. Over TLS, register h2 for ALPN negotiation.
+//  4. Over plaintext with h2c enabled, wrap the handler to accept cleartext HTTP/2.
+//
+// It must be called after srv.Handler and srv.TLSConfig are set.
+func configureHTTP2(srv *http.Server, serverCfg *config.ServerConfig) error {
+	// 1. A non-nil, empty map disables the automatic HTTP/2 upgrade in net/http.
+	if !serverCfg.HTTP2Enabled() {
+		srv.TLSNextProto = map[string]func(*http.Server, *tls.Conn, http.Handler){}
+		return nil
+	}
+
+	// 2. Settings shared by TLS and cleartext HTTP/2.
+	h2s := &http2.Server{
+		MaxConcurrentStreams: serverCfg.HTTP2.MaxConcurrentStreams,
+		IdleTimeout:          serverCfg.HTTP2.IdleTimeout,
+	}
+
+	// 3. ConfigureServer adds "h2" to the TLS NextProtos and installs the handler.
+	if srv.TLSConfig != nil {
+		return http2.ConfigureServer(srv, h2s)
+	}
+
+	// 4. Plaintext h2c for internal traffic; HTTP/1.1 clients are unaffected.
+	if serverCfg.H2CEnabled() {
+		srv.Handler = h2c.NewHandler(srv.Handler, h2s)
+	}
+	return nil
+}
+
+// limitListener caps the number of connections the server accepts at once.
+// Connections beyond the limit wait in the kernel's accept backlog. A limit of
+// zero or less returns the listener unchanged.
+func limitListener(listener net.Listener, limit int) net.Listener {
+	if limit <= 0 {
+		return listener
+	}
+	return netutil.LimitListener(listener, limit)
+}
@@ -0,0 +1,410 @@
+package main
+
+import (
+	// go1.21 - Deadlines for one-shot sends
+	"context"
+	"errors"
+	"fmt"
+
+	// Internal packages for configuration and provider adapters
+	"src/backend/services/integration/internal/adapters"
+	"src/backend/services/integration/internal/config"
+	"src/backend/services/integration/internal/models"
+)
+
+// errRecipientsRequired is returned when an email send names no recipients.
+var errRecipientsRequired = errors.New("email requires at least one --to recipient")
+
+// errDevicesRequired is returned when a push send names no devices.
+var errDevicesRequired = errors.New("push requires at least one --to platform:token device")
+
+// manualMessage is a message sent from the command line.
+type manualMessage struct {
+	// text is the message body.
+	text string
+
+	// subject is the email subject or Jira summary; it defaults to text.
+	subject string
+
+	// to lists email recipients, or push devices as platform:token.
+	to []string
+}
+
+// summary returns the subject, falling back to the message text.
+func (m *manualMessage) summary() string {
+	if m.subject != "" {
+		return m.subject
+	}
+	return m.text
+}
+
+// integrationDef describes an integration the binary knows how to build.
+type integrationDef struct {
+	name        string
+	kind        string
+	description string
+
+	// configured reports whether the configuration has settings for this integration.
+	configured func(cfg *config.Config) bool
+
+	// build creates an adapter and initializes it from its configuration section,
+	// which verifies credentials and connectivity.
+	build func(ctx context.Context, cfg *config.Config) (models.Integration, error)
+
+	// send delivers one message through an adapter created by build.
+	send func(ctx context.Context, integration models.Integration, msg *manualMessage) error
+}
+
+// knownIntegrations lists the integrations available to the CLI, in display order.
+var knownIntegrations = []integrationDef{
+	{
+		name:        "slack",
+		kind:        "chat",
+		description: "Posts messages to the default Slack channel",
+		configured: func(cfg *config.Config) bool {
+			return cfg.Slack != nil && cfg.Slack.APIToken != ""
+		},
+		build: func(ctx context.Context, cfg *config.Config) (models.Integration, error) {
+			adapter := adapters.NewSlackAdapter(nil)
+			if err := adapter.Initialize(cfg.Slack); err != nil {
+				return nil, err
+			}
+			return adapter, nil
+		},
+		send: func(ctx context.Context, integration models.Integration, msg *manualMessage) error {
+			return integration.Send(msg.text)
+		},
+	},
+	{
+		name:        "jira",
+		kind:        "project_management",
+		description: "Creates an issue in the default Jira project",
+		configured: func(cfg *config.Config) bool {
+			return cfg.Jira != nil && cfg.Jira.URL != ""
+		},
+		build: func(ctx context.Context, cfg *config.Config) (models.Integration, error) {
+			adapter := adapters.NewJiraAdapter(cfg.Jira)
+			if err := adapter.InitializeWithContext(ctx, cfg.Jira); err != nil {
+				return nil, err
+			}
+			return adapter, nil
+		},
+		send: func(ctx context.Context, integration models.Integration, msg *manualMessage) error {
+			return integration.Send(map[string]interface{}{
+				"summary":     msg.summary(),
+				"description": msg.text,
+			})
+		},
+	},
+	{
+		name:        "email",
+		kind:        "email",
+		description: "Sends an email through the configured SMTP server or email API",
+		configured: func(cfg *config.Config) bool {
+			if cfg.Email == nil {
+				return false
+			}
+			switch cfg.Email.Provider {
+			case config.EmailProviderSendGrid:
+				return cfg.Email.SendGrid != nil && cfg.Email.SendGrid.APIKey != ""
+			case config.EmailProviderSES:
+				return cfg.Email.FromAddress != ""
+			}
+			return cfg.Email.Host != ""
+		},
+		build: func(ctx context.Context, cfg *config.Config) (models.Integration, error) {
+			switch cfg.Email.Provider {
+			case config.EmailProviderSendGrid:
+				adapter := adapters.NewSendGridAdapter()
+				if err := adapter.InitializeWithContext(ctx, cfg.Email); err != nil {
+					return nil, err
+				}
+				return adapter, nil
+			case config.EmailProviderSES:
+				adapter := adapters.NewSESAdapter()
+				if err := adapter.InitializeWithContext(ctx, cfg.Email); err != nil {
+					return nil, err
+				}
+				return adapter, nil
+			}
+			adapter, err := adapters.NewEmailAdapter(cfg.Email, nil)
+			if err != nil {
+				return nil, err
+			}
+			if err := adapter.Initialize(ctx); err != nil {
+				return nil, err
+			}
+			return adapter, nil
+		},
+		send: func(ctx context.Context, integration models.Integration, msg *manualMessage) error {
+			if len(msg.to) == 0 {
+				return errRecipientsRequired
+			}
+			return integration.Send(struct {
+				Ctx     context.Context
+				Payload *adapters.EmailPayload
+			}{
+				Ctx: ctx,
+				Payload: &adapters.EmailPayload{
+					Subject: msg.summary(),
+					Body:    msg.text,
+					To:      msg.to,
+				},
+			})
+		},
+	},
+	{
+		name:        "matrix",
+		kind:        "chat",
+		description: "Posts messages to the default Matrix room",
+		configured: func(cfg *config.Config) bool {
+			return cfg.Matrix != nil && cfg.Matrix.HomeserverURL != ""
+		},
+		build: func(ctx context.Context, cfg *config.Config) (models.Integration, error) {
+			adapter := adapters.NewMatrixAdapter()
+			if err := adapter.InitializeWithContext(ctx, cfg.Matrix); err != nil {
+				return nil, err
+			}
+			return adapter, nil
+		},
+		send: func(ctx context.Context, integration models.Integration, msg *manualMessage) error {
+			return integration.Send(msg.text)
+		},
+	},
+	{
+		name:        "azuredevops",
+		kind:        "project_management",
+		description: "Creates a work item in the configured Azure DevOps project",
+		configured: func(cfg *config.Config) bool {
+			return cfg.AzureDevOps != nil && cfg.AzureDevOps.OrganizationURL != ""
+		},
+		build: func(ctx context.Context, cfg *config.Config) (models.Integration, error) {
+			adapter := adapters.NewAzureDevOpsAdapter()
+			if err := adapter.InitializeWithContext(ctx, cfg.AzureDevOps); err != nil {
+				return nil, err
+			}
+			return adapter, nil
+		},
+		send: func(ctx context.Context, integration models.Integration, msg *manualMessage) error {
+			return integration.Send(&adapters.AzureDevOpsWorkItem{
+				Title:       msg.summary(),
+				Description: msg.text,
+			})
+		},
+	},
+	{
+		name:        "asana",
+		kind:        "project_management",
+		description: "Creates a task in the default Asana project",
+		configured: func(cfg *config.Config) bool {
+			return cfg.Asana != nil && cfg.Asana.PersonalAccessToken != ""
+		},
+		build: func(ctx context.Context, cfg *config.Config) (models.Integration, error) {
+			adapter := adapters.NewAsanaAdapter()
+			if err := adapter.InitializeWithContext(ctx, cfg.Asana); err != nil {
+				return nil, err
+			}
+			return adapter, nil
+		},
+		send: func(ctx context.Context, integration models.Integration, msg *manualMessage) error {
+			return integration.Send(&adapters.AsanaTask{
+				Name:  msg.summary(),
+				Notes: msg.text,
+			})
+		},
+	},
+	{
+		name:        "trello",
+		kind:        "project_management",
+		description: "Adds a card to the default Trello list",
+		configured: func(cfg *config.Config) bool {
+			return cfg.Trello != nil && cfg.Trello.APIKey != ""
+		},
+		build: func(ctx context.Context, cfg *config.Config) (models.Integration, error) {
+			adapter := adapters.NewTrelloAdapter()
+			if err := adapter.InitializeWithContext(ctx, cfg.Trello); err != nil {
+				return nil, err
+			}
+			return adapter, nil
+		},
+		send: func(ctx context.Context, integration models.Integration, msg *manualMessage) error {
+			return integration.Send(&adapters.TrelloCard{
+				Name:        msg.summary(),
+				Description: msg.text,
+			})
+		},
+	},
+	{
+		name:        "monday",
+		kind:        "project_management",
+		description: "Creates an item on the default monday.com board",
+		configured: func(cfg *config.Config) bool {
+			return cfg.Monday != nil && cfg.Monday.APIToken != ""
+		},
+		build: func(ctx context.Context, cfg *config.Config) (models.Integration, error) {
+			adapter := adapters.NewMondayAdapter()
+			if err := adapter.InitializeWithContext(ctx, cfg.Monday); err != nil {
+				return nil, err
+			}
+			return adapter, nil
+		},
+		send: func(ctx context.Context, integration models.Integration, msg *manualMessage) error {
+			return integration.Send(&adapters.MondayItem{
+				Name:        msg.summary(),
+				Description: msg.text,
+			})
+		},
+	},
+	{
+		name:        "servicenow",
+		kind:        "itsm",
+		description: "Creates a record in the default ServiceNow table",
+		configured: func(cfg *config.Config) bool {
+			return cfg.ServiceNow != nil && cfg.ServiceNow.InstanceURL != ""
+		},
+		build: func(ctx context.Context, cfg *config.Config) (models.Integration, error) {
+			adapter := adapters.NewServiceNowAdapter()
+			if err := adapter.InitializeWithContext(ctx, cfg.ServiceNow); err != nil {
+				return nil, err
+			}
+			return adapter, nil
+		},
+		send: func(ctx context.Context, integration models.Integration, msg *manualMessage) error {
+			return integration.Send(&adapters.ServiceNowRecord{
+				ShortDescription: msg.summary(),
+				Description:      msg.text,
+			})
+		},
+	},
+	{
+		name:        "webhook",
+		kind:        "webhook",
+		description: "Posts a signed JSON message to the default webhook endpoint",
+		configured: func(cfg *config.Config) bool {
+			return cfg.Webhooks != nil && len(cfg.Webhooks.Endpoints) > 0
+		},
+		build: func(ctx context.Context, cfg *config.Config) (models.Integration, error) {
+			adapter := adapters.NewWebhookAdapter()
+			if err := adapter.InitializeWithContext(ctx, cfg.Webhooks); err != nil {
+				return nil, err
+			}
+			return adapter, nil
+		},
+		send: func(ctx context.Context, integration models.Integration, msg *manualMessage) error {
+			return integration.Send(&adapters.WebhookMessage{
+				Payload: map[string]string{
+					"summary": msg.summary(),
+					"text":    msg.text,
+				},
+			})
+		},
+	},
+	{
+		name:        "pubsub",
+		kind:        "messaging",
+		description: "Publishes a message to the default Pub/Sub topic",
+		configured: func(cfg *config.Config) bool {
+			return cfg.PubSub != nil && cfg.PubSub.DefaultTopic != ""
+		},
+		build: func(ctx context.Context, cfg *config.Config) (models.Integration, error) {
+			adapter := adapters.NewPubSubAdapter()
+			if err := adapter.InitializeWithContext(ctx, cfg.PubSub); err != nil {
+				return nil, err
+			}
+			return adapter, nil
+		},
+		send: func(ctx context.Context, integration models.Integration, msg *manualMessage) error {
+			return integration.Send(&adapters.PubSubMessage{
+				Data:       msg.text,
+				Attributes: map[string]string{"subject": msg.summary()},
+			})
+		},
+	},
+	{
+		name:        "push",
+		kind:        "push",
+		description: "Sends a mobile push notification through FCM or APNs",
+		configured: func(cfg *config.Config) bool {
+			return cfg.Push.Enabled()
+		},
+		build: func(ctx context.Context, cfg *config.Config) (models.Integration, error) {
+			adapter := adapters.NewPushAdapter()
+			if err := adapter.InitializeWithContext(ctx, cfg.Push); err != nil {
+				return nil, err
+			}
+			return adapter, nil
+		},
+		send: func(ctx context.Context, integration models.Integration, msg *manualMessage) error {
+			if len(msg.to) == 0 {
+				return errDevicesRequired
+			}
+			push := &adapters.PushMessage{Title: msg.summary(), Body: msg.text}
+			for _, to := range msg.to {
+				device, err := adapters.ParsePushDevice(to)
+				if err != nil {
+					return err
+				}
+				push.Devices = append(push.Devices, device)
+			}
+			return integration.Send(push)
+		},
+	},
+	{
+		name:        "graph",
+		kind:        "email",
+		description: "Sends Outlook mail from the default mailbox through Microsoft Graph",
+		configured: func(cfg *config.Config) bool {
+			return cfg.Graph != nil && cfg.Graph.TenantID != ""
+		},
+		build: func(ctx context.Context, cfg *config.Config) (models.Integration, error) {
+			adapter := adapters.NewGraphAdapter()
+			if err := adapter.InitializeWithContext(ctx, cfg.Graph); err != nil {
+				return nil, err
+			}
+			return adapter, nil
+		},
+		send: func(ctx context.Context, integration models.Integration, msg *manualMessage) error {
+			if len(msg.to) == 0 {
+				return errRecipientsRequired
+			}
+			return integration.Send(&adapters.GraphMessage{
+				To:      msg.to,
+				Subject: msg.summary(),
+				Body:    msg.text,
+			})
+		},
+	},
+	{
+		name:        "splunk",
+		kind:        "siem",
+		description: "Posts an event to the Splunk HTTP Event Collector",
+		configured: func(cfg *config.Config) bool {
+			return cfg.Splunk != nil && cfg.Splunk.URL != ""
+		},
+		build: func(ctx context.Context, cfg *config.Config) (models.Integration, error) {
+			adapter := adapters.NewSplunkHECAdapter()
+			if err := adapter.InitializeWithContext(ctx, cfg.Splunk); err != nil {
+				return nil, err
+			}
+			return adapter, nil
+		},
+		send: func(ctx context.Context, integration models.Integration, msg *manualMessage) error {
+			return integration.Send(&adapters.SplunkEvent{
+				Event: map[string]string{
+					"summary": msg.summary(),
+					"text":    msg.text,
+				},
+			})
+		},
+	},
+}
+
+// lookupIntegration returns the definition for name.
+func lookupIntegration(name string) (*integrationDef, error) {
+	for i := range knownIntegrations {
+		if knownIntegrations[i].name == name {
+			return &knownIntegrations[i], nil
+		}
+	}
+	return nil, fmt.Errorf("unknown integration %q; run \"integrations list\" to see the available ones", name)
+}
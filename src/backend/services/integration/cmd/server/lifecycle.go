@@ -0,0 +1,61 @@
+package main
+
+import (
+	// go1.21 - Watchdog lifetime
+	"context"
+	"time"
+
+	// v22.5.0 - sd_notify readiness and watchdog protocol
+	"github.com/coreos/go-systemd/v22/daemon"
+)
+
+// serviceManager reports the service's lifecycle to whatever supervises the
+// process: systemd via sd_notify, or the Windows service control manager.
+// Under Kubernetes or a plain shell every call is a no-op.
+type serviceManager interface {
+	// Ready reports that adapters are initialized and the listener is serving.
+	Ready() error
+
+	// Stopping reports that graceful shutdown has begun.
+	Stopping() error
+
+	// Watchdog feeds the manager's liveness watchdog until ctx ends. It returns
+	// immediately when the manager has no watchdog.
+	Watchdog(ctx context.Context)
+}
+
+// systemdManager implements serviceManager with sd_notify. Without NOTIFY_SOCKET
+// (i.e. not started by systemd with Type=notify) notifications are dropped.
+type systemdManager struct{}
+
+// Ready sends READY=1.
+func (systemdManager) Ready() error {
+	_, err := daemon.SdNotify(false, daemon.SdNotifyReady)
+	return err
+}
+
+// Stopping sends STOPPING=1.
+func (systemdManager) Stopping() error {
+	_, err := daemon.SdNotify(false, daemon.SdNotifyStopping)
+	return err
+}
+
+// Watchdog sends WATCHDOG=1 at half the unit's WatchdogSec, so a hung process
+// is restarted by systemd. It is a no-op unless WATCHDOG_USEC is set for this process.
+func (systemdManager) Watchdog(ctx context.Context) {
+	interval, err := daemon.SdWatchdogEnabled(false)
+	if err != nil || interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_, _ = daemon.SdNotify(false, daemon.SdNotifyWatchdog)
+		}
+	}
+}
@@ -4,14 +4,28 @@ import (
 	// go1.21 - System and environment interaction
 	"os"
 
+	// go1.21 - Error wrapping for startup failures
+	"fmt"
+
 	// go1.21 - Enhanced context management for graceful shutdown
 	"context"
 
 	// go1.21 - HTTP server with TLS and timeouts
 	"net/http"
 
+	// go1.21 - Listener bound before serving so bind errors surface at startup
+	"net"
+
+	// go1.21 - TLS and mutual TLS configuration for the HTTP server
+	"crypto/tls"
+
+	// v0.17.0 - ACME TLS-ALPN protocol and automatic certificate management
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
+
 	// v1.24.0 - Structured logging with correlation IDs and production settings
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 
 	// v0.3.0 - Errgroup for concurrent operations and error handling
 	"golang.org/x/sync/errgroup"
@@ -25,6 +39,30 @@ import (
 	// Internal package for creating router and integration handler
 	"src/backend/services/integration/internal/api"
 
+	// Internal package for Prometheus/OTLP metric export setup
+	"src/backend/services/integration/internal/telemetry"
+
+	// Internal package for client CA loading used by mutual TLS
+	"src/backend/services/integration/internal/auth"
+
+	// Internal package for log field redaction
+	"src/backend/services/integration/internal/logging"
+
+	// Internal package for the credential rotation watcher
+	"src/backend/services/integration/internal/services"
+
+	// Internal package for the shared outbound HTTP transport
+	"src/backend/services/integration/internal/httpclient"
+
+	// Durable spool for store-and-forward
+	"src/backend/services/integration/internal/queue"
+
+	// IMAP poller feeding replies and bounces to the email adapter
+	"src/backend/services/integration/internal/adapters"
+
+	// Build information logged at startup
+	"src/backend/services/integration/internal/buildinfo"
+
 	// go1.21 - Signal handling for graceful shutdown
 	"os/signal"
 	// go1.21 - Syscall for capturing SIGINT/SIGTERM
@@ -36,47 +74,54 @@ import (
 )
 
 // Global defaults derived from JSON specification.
-// These can be overridden by environment variables or passed flags if desired.
+// The port and config path can be overridden by flags or environment variables (see cli.go).
 const (
 	defaultPort          = ":8080"
 	defaultConfigPath    = "/etc/taskstream/config.yaml"
-	shutdownTimeout      = "30s"
-	healthCheckInterval  = "15s"
 )
 
-// main is the enhanced entry point of the integration service with comprehensive
+// main parses the command line and runs the service; see newRootCommand.
+func main() {
+	if err := newRootCommand().Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+// runServer is the enhanced entry point of the integration service with comprehensive
 // monitoring, reliability, and security features. It follows these steps:
 // 1. Initialize structured logger with correlation ID support
 // 2. Load and validate configuration with secure defaults
 // 3. Initialize Prometheus metrics collector
-// 4. Create integration handler with circuit breaker
+// 4. Create integration handler with circuit breaker, then warm up integrations
 // 5. Set up HTTP router with metrics middleware
 // 6. Configure TLS and timeouts
 // 7. Initialize health check monitor
 // 8. Configure enhanced graceful shutdown
 // 9. Start HTTP server with connection draining
-// 10. Monitor service health
-// 11. Wait for shutdown signal
-// 12. Perform graceful shutdown with connection draining
-func main() {
+// 10. Notify the service manager (systemd, Windows SCM) and feed its watchdog
+// 11. Wait for shutdown signal or service stop request
+// 12. Run the shutdown hooks: drain servers and queues, close adapters, flush exporters
+func runServer(parent context.Context, opts *serverOptions, manager serviceManager) error {
 	// STEP 1: Initialize structured logger with correlation ID support
-	logger, err := setupLogger()
+	logger, logLevel, err := setupLogger(opts)
 	if err != nil {
-		panic("Failed to initialize logger: " + err.Error())
+		return fmt.Errorf("failed to initialize logger: %w", err)
 	}
 	defer func() {
 		_ = logger.Sync() // Ensure all logs are flushed on exit
 	}()
 
-	logger.Info("Starting Integration Service - TaskStream AI")
+	build := buildinfo.Get()
+	logger.Info("Starting Integration Service - TaskStream AI",
+		zap.String("version", build.Version),
+		zap.String("commit", build.Commit),
+		zap.String("buildTime", build.BuildTime),
+		zap.String("goVersion", build.GoVersion),
+		zap.String("platform", build.Platform),
+	)
 
 	// STEP 2: Load and validate configuration with secure defaults
-	configPath := os.Getenv("INTEGRATION_CONFIG_PATH")
-	if configPath == "" {
-		configPath = defaultConfigPath
-	}
-
-	cfg, err := config.LoadConfig(configPath)
+	cfg, err := config.LoadConfig(opts.configPath)
 	if err != nil {
 		logger.Fatal("Failed to load service configuration", zap.Error(err))
 	}
@@ -90,89 +135,339 @@ func main() {
 	promRegistry := prometheus.NewRegistry()
 	logger.Info("Prometheus registry initialized")
 
+	// STEP 3a: Start OTLP metric push alongside Prometheus scrape if configured.
+	shutdownMetrics, err := telemetry.SetupMetrics(context.Background(), cfg.Telemetry, promRegistry, logger)
+	if err != nil {
+		logger.Fatal("Failed to initialize metrics exporters", zap.Error(err))
+	}
+
+	// STEP 3b: Build the shared outbound HTTP transport used by REST adapters.
+	httpFactory, err := httpclient.NewFactory(cfg.HTTPClient)
+	if err != nil {
+		logger.Fatal("Failed to configure outbound HTTP transport", zap.Error(err))
+	}
+	httpclient.SetDefault(httpFactory)
+
+	// STEP 3c: Create the API metrics on the service registry.
+	apiMetrics, err := api.NewMetrics(promRegistry)
+	if err != nil {
+		logger.Fatal("Failed to register API metrics", zap.Error(err))
+	}
+
 	// STEP 4: Create integration handler with circuit breaker and rate limiter
-	handler, err := api.NewIntegrationHandler(cfg, logger, &prometheus.Collector(nil))
+	handler, err := api.NewIntegrationHandler(cfg, logger, apiMetrics)
 	if err != nil {
 		logger.Fatal("Failed to create integration handler", zap.Error(err))
 	}
 	logger.Info("Integration handler created successfully")
 
+	// STEP 4b: Let operators change the log level and reload the configuration
+	// through the admin endpoints.
+	handler.SetLogLevel(logLevel)
+	handler.SetConfigLoader(func() (*config.Config, error) {
+		return config.LoadConfig(opts.configPath)
+	})
+
+	// STEP 4b-i: Keep rate limit counters in the configured store. With Redis,
+	// API quotas and provider call rates hold across every replica, so adapters
+	// are handed the shared limiter before the warm-up registers them.
+	rateLimitStore, err := services.NewRateLimitStore(cfg.RateLimit.Store)
+	if err != nil {
+		logger.Fatal("Failed to open rate limit store", zap.Error(err))
+	}
+	handler.SetRateLimitStore(rateLimitStore)
+	if cfg.RateLimit.Store.Distributed() {
+		handler.SyncManager().SetSharedLimiters(services.NewSharedLimiters(rateLimitStore, logger))
+		handler.SyncManager().AddDependencyProbe("rateLimitStore", rateLimitStore.Ping)
+		logger.Info("Rate limits shared through Redis",
+			zap.String("address", cfg.RateLimit.Store.Redis.Address))
+	}
+
+	// STEP 4b-ii: Forward panics recovered while serving requests to the
+	// configured error-reporting backend, in addition to logging them.
+	if cfg.ErrorReporting.ReportingEnabled() {
+		handler.SetPanicReporter(api.NewWebhookPanicReporter(cfg.ErrorReporting,
+			httpFactory.ClientWithTimeout(cfg.ErrorReporting.Timeout), logger))
+		logger.Info("Recovered panics are forwarded for error reporting")
+	}
+
+	// STEP 4a: Warm up every configured integration before anything reports the
+	// service ready: adapters are initialized, verified and registered here, and
+	// the health monitor and service manager are only started afterwards.
+	warmUpResults := warmUpIntegrations(parent, cfg, handler.SyncManager(), logger)
+	if failed := warmUpFailures(warmUpResults); len(failed) > 0 && opts.requireAllIntegrations {
+		logger.Fatal("Integrations failed to warm up and --require-all-integrations is set",
+			zap.Strings("integrations", failed))
+	}
+
 	// STEP 5: Set up HTTP router with metrics middleware
-	// We assume NewMetricsMiddleware is an internal function that returns a mux.MiddlewareFunc
-	// for instrumentation, integrating with the registry if needed.
-	metricsMiddleware := api.NewMetricsMiddleware(promRegistry)
+	// The middleware records request counts and latency on the API metrics.
+	// /ping is answered ahead of everything, so probes are neither counted nor
+	// subject to auth, rate limiting or the circuit breaker.
+	metricsMiddleware := api.NewMetricsMiddleware(apiMetrics)
+	// NewRouter returns the router wrapped in its full middleware chain
+	// (recovery, security headers, breaker, tracing and access log), which is
+	// what the server must serve.
 	router := api.NewRouter(handler)
-	routerWithMetrics := metricsMiddleware(router)
+	routerWithMetrics := api.WithPing(metricsMiddleware(router))
 	logger.Info("Router set up with metrics middleware")
 
 	// STEP 6: Configure TLS and timeouts for the HTTP server
-	// We allow environment override for port; if not set, default to ":8080".
-	port := os.Getenv("SERVICE_PORT")
-	if port == "" {
-		port = defaultPort
+	// The listen address comes from --port (or SERVICE_PORT), defaulting to ":8080".
+	// With ACME, the certificate is obtained and renewed automatically.
+	acmeManager := newACMEManager(cfg.Server)
+	tlsConfig, err := setupTLS(cfg.Server, acmeManager)
+	if err != nil {
+		logger.Fatal("Failed to configure TLS", zap.Error(err))
 	}
+	timeouts := cfg.Server.ServerTimeouts()
 	srv := &http.Server{
-		Addr:              port,
+		Addr:              opts.port,
 		Handler:           routerWithMetrics,
-		ReadHeaderTimeout: 15 * time.Second,
-		WriteTimeout:      30 * time.Second,
-		IdleTimeout:       60 * time.Second,
+		TLSConfig:         tlsConfig,
+		ReadHeaderTimeout: timeouts.ReadHeader,
+		ReadTimeout:       timeouts.Read,
+		WriteTimeout:      timeouts.Write,
+		IdleTimeout:       timeouts.Idle,
+		MaxHeaderBytes:    cfg.Server.HeaderBytesLimit(),
+	}
+	if err := configureHTTP2(srv, cfg.Server); err != nil {
+		logger.Fatal("Failed to configure HTTP/2", zap.Error(err))
 	}
 
 	logger.Info("HTTP server configured",
 		zap.String("addr", srv.Addr),
 		zap.Duration("readHeaderTimeout", srv.ReadHeaderTimeout),
+		zap.Duration("readTimeout", srv.ReadTimeout),
 		zap.Duration("writeTimeout", srv.WriteTimeout),
 		zap.Duration("idleTimeout", srv.IdleTimeout),
+		zap.Duration("shutdownTimeout", timeouts.Shutdown),
+		zap.Int("maxHeaderBytes", srv.MaxHeaderBytes),
+		zap.Bool("tls", srv.TLSConfig != nil),
+		zap.Bool("acme", acmeManager != nil),
+		zap.Bool("http2", cfg.Server.HTTP2Enabled()),
+		zap.Bool("h2c", cfg.Server.H2CEnabled()),
 	)
 
-	// STEP 7: Initialize health check monitor in a separate goroutine.
-	healthInterval, _ := time.ParseDuration(healthCheckInterval)
-	go func() {
-		ticker := time.NewTicker(healthInterval)
-		defer ticker.Stop()
-
-		for range ticker.C {
-			// In a production scenario, we might actively ping the internal health
-			// or external dependencies via the config.IsHealthy() or handler-specific checks.
-			// For demonstration, we log a debug message to confirm we're alive.
-			if cfg.Debug {
-				logger.Debug("Health monitor is running")
-			}
-		}
-	}()
+	// STEP 7: Create the health monitor. It checks every integration on its
+	// interval, drives /readyz, and records health transitions as metrics; it is
+	// started below once the shutdown context exists.
+	healthMonitor := services.NewHealthMonitor(cfg.HealthMonitor, handler.SyncManager(), logger)
+	handler.SyncManager().SetHealthMonitor(healthMonitor)
 
 	// STEP 8: Setup enhanced graceful shutdown
 	// Capture signals for termination.
-	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	// The parent context is also canceled by the Windows service control manager.
+	ctx, stop := signal.NotifyContext(parent, syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
 
-	// STEP 9 & 10: We will start the HTTP server with connection draining and monitor for errors.
-	// We'll run the server in an errgroup such that we can manage concurrency with a separate
-	// goroutine for waiting on signals.
-	var g errgroup.Group
+	go handler.SyncManager().Supervise(ctx, "health-monitor", healthMonitor.Run)
+
+	// STEP 8a: Watch the secrets directory so rotated integration credentials are
+	// picked up without a restart.
+	if cfg.Credentials.WatchEnabled() {
+		watcher := services.NewCredentialWatcher(cfg.Credentials.Watch, handler.SyncManager(), logger)
+		go handler.SyncManager().Supervise(ctx, "credential-watch", watcher.Run)
+		logger.Info("Credential watch started", zap.String("dir", cfg.Credentials.Watch.Dir))
+	}
+
+	// STEP 8b: Divert messages for integrations with an open circuit to the spool
+	// and replay them once the integration recovers.
+	if cfg.Queue.StoreAndForwardEnabled() {
+		spool, err := queue.NewSpoolFromConfig(ctx, cfg.Queue.Spool)
+		if err != nil {
+			logger.Fatal("Failed to open spool for store-and-forward", zap.Error(err))
+		}
+		sf := services.NewStoreAndForward(cfg.Queue.StoreAndForward, spool, handler.SyncManager(), logger)
+		handler.SyncManager().SetStoreAndForward(sf)
+		go handler.SyncManager().Supervise(ctx, "store-and-forward", sf.Run)
+		logger.Info("Store-and-forward enabled", zap.String("dir", cfg.Queue.Spool.Dir))
+	}
+
+	// STEP 8b-i: Record every delivery attempt so support can search them
+	// through /api/v1/messages.
+	if cfg.History.HistoryEnabled() {
+		history, err := services.NewMessageHistory(cfg.History, logger)
+		if err != nil {
+			logger.Fatal("Failed to open message history", zap.Error(err))
+		}
+		handler.SyncManager().SetMessageHistory(history)
+		logger.Info("Message history enabled",
+			zap.String("dir", cfg.History.Dir),
+			zap.Duration("retention", cfg.History.Retention),
+		)
+	}
+
+	// STEP 8c: Read replies and bounces from the inbound mailbox and
+	// correlate them with sent email.
+	if cfg.Email.UsesSMTP() && cfg.Email.Inbound.Enabled() {
+		poller := services.NewInboundPoller(cfg.Email.Inbound, adapters.NewIMAPPoller(cfg.Email.Inbound), handler.SyncManager(), logger)
+		go handler.SyncManager().Supervise(ctx, "inbound-mail", poller.Run)
+		logger.Info("Inbound mail polling started",
+			zap.String("host", cfg.Email.Inbound.Host),
+			zap.String("mailbox", cfg.Email.Inbound.Mailbox),
+		)
+	}
+
+	// STEP 8d: Deliver inbound provider events, such as Slack messages and
+	// reactions, to their subscribers.
+	go handler.SyncManager().Supervise(ctx, "event-bus", handler.Events().Run)
+
+	// STEP 9: Bind the listen address before blocking on signals, so that a port
+	// conflict or permission error fails startup immediately instead of leaving the
+	// process running without a server.
+	listener, err := net.Listen("tcp", srv.Addr)
+	if err != nil {
+		logger.Fatal("Failed to bind HTTP listener", zap.String("addr", srv.Addr), zap.Error(err))
+	}
+	if cfg.Server != nil {
+		listener = limitListener(listener, cfg.Server.MaxConnections)
+	}
+
+	// STEP 9a: Bind the admin listener that serves metrics, probes, pprof and the
+	// operator endpoints, so they are never reachable through the public ingress.
+	var adminSrv *http.Server
+	var adminListener net.Listener
+	if cfg.Server.AdminEnabled() {
+		adminSrv = &http.Server{
+			Addr:              cfg.Server.Admin.Addr,
+			Handler:           api.NewAdminRouter(handler),
+			ReadHeaderTimeout: timeouts.ReadHeader,
+			IdleTimeout:       timeouts.Idle,
+			MaxHeaderBytes:    srv.MaxHeaderBytes,
+		}
+		adminListener, err = net.Listen("tcp", adminSrv.Addr)
+		if err != nil {
+			logger.Fatal("Failed to bind admin listener", zap.String("addr", adminSrv.Addr), zap.Error(err))
+		}
+		logger.Info("Admin listener configured",
+			zap.String("addr", adminSrv.Addr),
+			zap.Bool("pprof", cfg.Server.Admin.Pprof),
+		)
+	}
+
+	// STEP 9b: Bind the HTTP-01 challenge listener when ACME answers challenges
+	// over plaintext HTTP; it redirects every other request to HTTPS.
+	acmeSrv := acmeChallengeServer(acmeManager, cfg.Server)
+	var acmeListener net.Listener
+	if acmeSrv != nil {
+		acmeListener, err = net.Listen("tcp", acmeSrv.Addr)
+		if err != nil {
+			logger.Fatal("Failed to bind ACME challenge listener", zap.String("addr", acmeSrv.Addr), zap.Error(err))
+		}
+		logger.Info("ACME challenge listener configured", zap.String("addr", acmeSrv.Addr))
+	}
+
+	// STEP 10: Serve in an errgroup whose context is canceled either by a shutdown
+	// signal or by the server returning an error.
+	g, serveCtx := errgroup.WithContext(ctx)
 	g.Go(func() error {
-		return startServer(srv, logger)
+		return startServer(srv, listener, logger)
 	})
+	if adminSrv != nil {
+		g.Go(func() error {
+			return startServer(adminSrv, adminListener, logger)
+		})
+	}
+	if acmeSrv != nil {
+		g.Go(func() error {
+			return startServer(acmeSrv, acmeListener, logger)
+		})
+	}
 
-	// STEP 11: Wait for shutdown signal (SIGINT, SIGTERM). Once caught, proceed to graceful shutdown.
-	<-ctx.Done()
-	logger.Info("Received shutdown signal, initiating graceful shutdown procedure")
+	// STEP 10a: Adapters are initialized and the listener is serving; pass the
+	// startup probe, tell the service manager (systemd READY=1, Windows Running)
+	// and feed its watchdog for as long as the server runs.
+	handler.MarkStarted()
+	if err := manager.Ready(); err != nil {
+		logger.Warn("Failed to notify service manager of readiness", zap.Error(err))
+	}
+	go manager.Watchdog(serveCtx)
+
+	// STEP 11: Wait for a shutdown signal (SIGINT, SIGTERM) or a server failure.
+	<-serveCtx.Done()
+	if ctx.Err() != nil {
+		logger.Info("Received shutdown request, initiating graceful shutdown procedure")
+	} else {
+		logger.Error("HTTP server stopped unexpectedly, initiating shutdown")
+	}
 
 	// STEP 12: Perform graceful shutdown with connection draining
-	shutdownCtx, cancelFunc := context.WithTimeout(context.Background(), parseDurationOrDefault(shutdownTimeout, 30*time.Second))
+	if err := manager.Stopping(); err != nil {
+		logger.Warn("Failed to notify service manager of shutdown", zap.Error(err))
+	}
+	shutdownCtx, cancelFunc := context.WithTimeout(context.Background(), timeouts.Shutdown)
 	defer cancelFunc()
 
-	if err := setupGracefulShutdown(shutdownCtx, srv, logger); err != nil {
-		logger.Error("Error during graceful shutdown", zap.Error(err))
+	// Each step is bounded by its own timeout and by the overall shutdown deadline;
+	// a failed step is logged and the remaining steps still run.
+	var hooks shutdownHooks
+	// Fail /readyz first and keep serving for the drain delay, so load
+	// balancers stop routing new requests before the listeners close.
+	hooks.register("readiness drain", 0, func(ctx context.Context) error {
+		healthMonitor.Drain()
+		if timeouts.DrainDelay <= 0 || ctx.Err() != nil {
+			return nil
+		}
+		timer := time.NewTimer(timeouts.DrainDelay)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+			return nil
+		}
+	})
+	hooks.register("http server", 0, func(ctx context.Context) error {
+		return setupGracefulShutdown(ctx, srv, logger)
+	})
+	// The admin listener stays up while the public server drains, so metrics and
+	// probes remain available until traffic has stopped.
+	if adminSrv != nil {
+		hooks.register("admin listener", 0, func(ctx context.Context) error {
+			return setupGracefulShutdown(ctx, adminSrv, logger)
+		})
+	}
+	if acmeSrv != nil {
+		hooks.register("acme challenge listener", 0, func(ctx context.Context) error {
+			return setupGracefulShutdown(ctx, acmeSrv, logger)
+		})
+	}
+	// Deliver sends still queued in the dispatch pipeline before releasing
+	// connections, for at most dispatch.drainTimeout, then store the rest for
+	// replay after the restart rather than drop them. Stopping the sync manager
+	// cancels in-flight sends, so it comes after both.
+	hooks.register("dispatch queue", cfg.Dispatch.ShutdownDrainTimeout(), handler.SyncManager().DrainDispatch)
+	hooks.register("queued sends", 0, handler.SyncManager().StoreQueuedSends)
+	hooks.register("sync manager", 5*time.Second, func(ctx context.Context) error {
+		return handler.SyncManager().StopSync()
+	})
+	hooks.register("integrations", 10*time.Second, handler.SyncManager().CloseIntegrations)
+	// Close the rate limit store once no integration can call its provider.
+	hooks.register("rate limit store", 0, func(ctx context.Context) error {
+		return rateLimitStore.Close()
+	})
+	// Close the message history once nothing can deliver any more.
+	hooks.register("message history", 0, handler.SyncManager().CloseMessageHistory)
+	// Release pooled outbound connections.
+	hooks.register("outbound transport", 0, func(ctx context.Context) error {
+		httpFactory.CloseIdleConnections()
+		return nil
+	})
+	// Flush any pending OTLP metric exports before exiting.
+	hooks.register("metrics exporters", 10*time.Second, shutdownMetrics)
+
+	if err := hooks.run(shutdownCtx, logger); err != nil {
+		logger.Error("Graceful shutdown completed with errors", zap.Error(err))
 	}
 
-	// Final step: wait for any errors from the server goroutine
+	// Final step: wait for the server goroutine and exit non-zero if it failed.
 	if err := g.Wait(); err != nil {
-		logger.Error("Server encountered an error", zap.Error(err))
+		logger.Fatal("Server encountered an error", zap.Error(err))
 	}
 
 	logger.Info("Integration Service has shut down cleanly")
+	return nil
 }
 
 // setupLogger initializes the zap logger with correlation IDs and production settings.
@@ -180,11 +475,13 @@ func main() {
 // 1. Create production logger config with sampling
 // 2. Enable correlation ID tracking
 // 3. Configure log rotation and retention (placeholder for advanced usage)
-// 4. Set up development mode if configured
+// 4. Set up development mode and log level from the command line
 // 5. Initialize logger with security considerations
 // 6. Set global logger instance
 // 7. Configure error reporting integration (placeholder for advanced usage)
-func setupLogger() (*zap.Logger, error) {
+//
+// The returned level is the logger's own, so changing it takes effect at once.
+func setupLogger(opts *serverOptions) (*zap.Logger, zap.AtomicLevel, error) {
 	// 1. Create production config with sampling
 	cfg := zap.NewProductionConfig()
 	cfg.Sampling = &zap.SamplingConfig{
@@ -192,12 +489,19 @@ func setupLogger() (*zap.Logger, error) {
 		Thereafter: 100,
 	}
 
-	// For demonstration: if we wanted to allow dev mode, we might read an env or set a param.
-	devMode := os.Getenv("DEV_MODE")
-	if devMode == "true" {
-		// 4. Switch to a development style logger if configured
-		return zap.NewDevelopment()
+	// 4. Switch to a development style logger if requested (--dev or DEV_MODE).
+	if opts.dev {
+		cfg = zap.NewDevelopmentConfig()
+	}
+	level, err := zapcore.ParseLevel(opts.logLevel)
+	if err != nil {
+		return nil, zap.AtomicLevel{}, err
 	}
+	cfg.Level = zap.NewAtomicLevelAt(level)
+
+	// 5. Mask credentials (password, token, apiToken, authorization, ...) in every
+	// field, including nested configs and payloads, before they are encoded.
+	redactor := logging.NewRedactor().WrapCore()
 
 	// 2 & 5. We can embed correlation ID logic in the future, hooking into the context or request.
 	// 3. Log rotation/retention is typically performed externally or by specifying a file output with rotation.
@@ -205,15 +509,15 @@ func setupLogger() (*zap.Logger, error) {
 	// 7. Error reporting integration is also a placeholder.
 
 	// Finally, build the logger
-	logger, err := cfg.Build()
+	logger, err := cfg.Build(redactor)
 	if err != nil {
-		return nil, err
+		return nil, zap.AtomicLevel{}, err
 	}
 
 	// 6. Setup global logger (optional, depending on if we want to rely on zap's global facility)
 	zap.ReplaceGlobals(logger)
 
-	return logger, nil
+	return logger, cfg.Level, nil
 }
 
 // startServer starts the HTTP server with enhanced monitoring and security. It follows these steps:
@@ -223,23 +527,29 @@ func setupLogger() (*zap.Logger, error) {
 // 4. Enable TLS with secure configuration (placeholder if needed, or ListenAndServeTLS)
 // 5. Start health check monitoring (already done, but we ensure it remains active)
 // 6. Log server startup with correlation ID
-// 7. Start HTTP server with timeouts
+// 7. Serve on the pre-bound listener with timeouts
 // 8. Monitor server health metrics (the main's health monitor step covers this real-time check)
 // 9. Handle server errors with logging
-func startServer(server *http.Server, logger *zap.Logger) error {
+func startServer(server *http.Server, listener net.Listener, logger *zap.Logger) error {
 	// 1. Reaffirm that Prometheus metrics are already registered
 	logger.Info("Prometheus metrics and router are ready to serve")
 
-	// 4. For enabling TLS, if we had certificates, we might do: server.ListenAndServeTLS("cert.pem", "key.pem")
-	// As a placeholder, we'll do a plain text server. Production usage demands HTTPS with a secure TLS config.
+	// 4. Serve HTTPS when a TLS config was built by setupTLS. Certificates are already
+	// loaded into server.TLSConfig, so no file paths are passed to ListenAndServeTLS.
+	// The listener is bound by the caller so that bind errors surface before startup completes.
+	listen := func() error { return server.Serve(listener) }
+	if server.TLSConfig != nil {
+		listen = func() error { return server.ServeTLS(listener, "", "") }
+	}
 
 	// 6. Log server startup. In a production environment, correlation IDs can be attached to this log.
 	logger.Info("Starting HTTP server",
-		zap.String("address", server.Addr),
+		zap.String("address", listener.Addr().String()),
+		zap.Bool("tls", server.TLSConfig != nil),
 	)
 
 	// 7, 8, 9. Begin the server's main listen loop, and handle any top-level error.
-	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+	if err := listen(); err != nil && err != http.ErrServerClosed {
 		logger.Error("HTTP server failed unexpectedly", zap.Error(err))
 		return err
 	}
@@ -263,7 +573,12 @@ func setupGracefulShutdown(ctx context.Context, srv *http.Server, logger *zap.Lo
 	// 2. If we had a separate health endpoint or a manager, we could mark the service as unhealthy.
 	//    For instance, we might call: manager.SetHealthy(false)
 
-	// 3, 4. Attempt a graceful shutdown which stops new requests and allows in-flight requests to complete.
+	// 3. Stop keeping connections alive first: idle connections are closed and
+	//    responses still in flight carry "Connection: close", so clients move to
+	//    another instance instead of reusing a connection that is about to go away.
+	srv.SetKeepAlivesEnabled(false)
+
+	// 4. Attempt a graceful shutdown which stops new requests and allows in-flight requests to complete.
 	logger.Info("Shutting down HTTP server gracefully")
 	err := srv.Shutdown(ctx)
 	if err != nil {
@@ -280,6 +595,56 @@ func setupGracefulShutdown(ctx context.Context, srv *http.Server, logger *zap.Lo
 	return nil
 }
 
+// setupTLS builds the server TLS configuration, including mutual TLS, from the
+// server section of the configuration. It returns nil when TLS is disabled.
+// The steps are:
+// 1. Load the server certificate and key, or get them from the ACME manager
+// 2. Enforce TLS 1.2 as the minimum protocol version
+// 3. Load the client CA bundles and apply the client certificate policy
+func setupTLS(serverCfg *config.ServerConfig, acmeManager *autocert.Manager) (*tls.Config, error) {
+	if !serverCfg.TLSEnabled() {
+		return nil, nil
+	}
+	tlsCfg := serverCfg.TLS
+
+	// 1, 2. Refuse legacy protocol versions. With ACME, certificates are
+	// obtained on the first handshake for each domain and renewed in the
+	// background; TLS-ALPN-01 challenges are answered on this listener.
+	result := &tls.Config{MinVersion: tls.VersionTLS12}
+	if acmeManager != nil {
+		result.GetCertificate = acmeManager.GetCertificate
+		if !tlsCfg.ACME.HTTPChallenge() {
+			// HTTP/1.1 is listed so that regular clients still negotiate a
+			// protocol; configureHTTP2 adds h2 ahead of it.
+			result.NextProtos = []string{"http/1.1", acme.ALPNProto}
+		}
+	} else {
+		cert, err := tls.LoadX509KeyPair(tlsCfg.CertFile, tlsCfg.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		result.Certificates = []tls.Certificate{cert}
+	}
+
+	// 3. Configure client certificate verification for mTLS deployments.
+	switch tlsCfg.ClientAuth {
+	case config.ClientAuthOptional, config.ClientAuthRequired:
+		pool, err := auth.LoadClientCAPool(tlsCfg.ClientCABundles()...)
+		if err != nil {
+			return nil, err
+		}
+		result.ClientCAs = pool
+		result.ClientAuth = tls.VerifyClientCertIfGiven
+		if tlsCfg.ClientAuth == config.ClientAuthRequired {
+			result.ClientAuth = tls.RequireAndVerifyClientCert
+		}
+	default:
+		result.ClientAuth = tls.NoClientCert
+	}
+
+	return result, nil
+}
+
 // parseDurationOrDefault attempts to parse a duration string. If the parse fails, it returns
 // the provided fallback duration. This ensures robust handling of environment variables that
 // may not be well-formed.
@@ -0,0 +1,14 @@
+//go:build !windows
+
+package main
+
+import (
+	// go1.21 - Root context for the service
+	"context"
+)
+
+// serve runs the service in the foreground, reporting readiness to systemd when
+// started as a Type=notify unit.
+func serve(opts *serverOptions) error {
+	return runServer(context.Background(), opts, systemdManager{})
+}
@@ -0,0 +1,117 @@
+//go:build windows
+
+package main
+
+import (
+	// go1.21 - Cancelling the service on Stop/Shutdown requests
+	"context"
+	// go1.21 - Guards the last reported service state
+	"sync"
+
+	// v0.13.0 - Windows service control manager integration
+	"golang.org/x/sys/windows/svc"
+)
+
+// windowsServiceName is the name the service is registered under.
+const windowsServiceName = "TaskStreamIntegration"
+
+// windowsExitServiceError is the service-specific exit code reported when the
+// server fails.
+const windowsExitServiceError = 1
+
+// serve runs under the Windows service control manager when started by it, and
+// in the foreground otherwise.
+func serve(opts *serverOptions) error {
+	isService, err := svc.IsWindowsService()
+	if err != nil {
+		return err
+	}
+	if !isService {
+		return runServer(context.Background(), opts, systemdManager{})
+	}
+
+	service := &windowsService{opts: opts}
+	if err := svc.Run(windowsServiceName, service); err != nil {
+		return err
+	}
+	return service.err
+}
+
+// windowsService adapts runServer to the svc.Handler lifecycle.
+type windowsService struct {
+	opts *serverOptions
+	err  error
+}
+
+// Execute implements svc.Handler. It starts the server, reports Running once it
+// is ready, and turns Stop and Shutdown requests into a graceful shutdown.
+func (s *windowsService) Execute(args []string, requests <-chan svc.ChangeRequest, changes chan<- svc.Status) (bool, uint32) {
+	manager := &windowsManager{changes: changes}
+	manager.set(svc.Status{State: svc.StartPending})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		done <- runServer(ctx, s.opts, manager)
+	}()
+
+	for {
+		select {
+		case err := <-done:
+			manager.set(svc.Status{State: svc.Stopped})
+			if err != nil {
+				s.err = err
+				return true, windowsExitServiceError
+			}
+			return false, 0
+		case req := <-requests:
+			switch req.Cmd {
+			case svc.Interrogate:
+				changes <- manager.current()
+			case svc.Stop, svc.Shutdown:
+				manager.set(svc.Status{State: svc.StopPending})
+				cancel()
+			}
+		}
+	}
+}
+
+// windowsManager implements serviceManager by reporting state changes to the
+// service control manager.
+type windowsManager struct {
+	changes chan<- svc.Status
+
+	mu     sync.Mutex
+	status svc.Status
+}
+
+// Ready reports Running and starts accepting Stop and Shutdown requests.
+func (m *windowsManager) Ready() error {
+	m.set(svc.Status{State: svc.Running, Accepts: svc.AcceptStop | svc.AcceptShutdown})
+	return nil
+}
+
+// Stopping reports StopPending.
+func (m *windowsManager) Stopping() error {
+	m.set(svc.Status{State: svc.StopPending})
+	return nil
+}
+
+// Watchdog is a no-op; the service control manager has no liveness watchdog.
+func (m *windowsManager) Watchdog(ctx context.Context) {}
+
+// set records and reports a new status.
+func (m *windowsManager) set(status svc.Status) {
+	m.mu.Lock()
+	m.status = status
+	m.mu.Unlock()
+	m.changes <- status
+}
+
+// current returns the last reported status.
+func (m *windowsManager) current() svc.Status {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.status
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	// go1.21 - Per-hook deadlines
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	// v1.24.0 - Structured logging of each shutdown step
+	"go.uber.org/zap"
+)
+
+// shutdownHook is one step of graceful shutdown.
+type shutdownHook struct {
+	// name identifies the step in logs and errors.
+	name string
+
+	// timeout bounds the step; 0 lets it use whatever remains of the overall
+	// shutdown deadline.
+	timeout time.Duration
+
+	// fn performs the step.
+	fn func(ctx context.Context) error
+}
+
+// shutdownHooks runs registered shutdown steps in registration order, so
+// callers register them in the order resources must be released: stop taking
+// traffic first, flush exporters last.
+type shutdownHooks struct {
+	hooks []shutdownHook
+}
+
+// register appends a step to run during shutdown.
+func (s *shutdownHooks) register(name string, timeout time.Duration, fn func(ctx context.Context) error) {
+	s.hooks = append(s.hooks, shutdownHook{name: name, timeout: timeout, fn: fn})
+}
+
+// run executes every step in order, each bounded by its own timeout and by ctx.
+// A failing or timed-out step is logged and does not prevent later steps from
+// running; all failures are returned joined.
+func (s *shutdownHooks) run(ctx context.Context, logger *zap.Logger) error {
+	var errs []error
+	for _, hook := range s.hooks {
+		hookCtx, cancel := ctx, context.CancelFunc(func() {})
+		if hook.timeout > 0 {
+			hookCtx, cancel = context.WithTimeout(ctx, hook.timeout)
+		}
+
+		start := time.Now()
+		err := hook.fn(hookCtx)
+		cancel()
+		if err != nil {
+			logger.Error("Shutdown step failed",
+				zap.String("step", hook.name),
+				zap.Duration("elapsed", time.Since(start)),
+				zap.Error(err))
+			errs = append(errs, fmt.Errorf("%s: %w", hook.name, err))
+			continue
+		}
+		logger.Debug("Shutdown step completed",
+			zap.String("step", hook.name),
+			zap.Duration("elapsed", time.Since(start)))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	// go1.21 - Per-integration deadlines
+	"context"
+	"errors"
+	"time"
+
+	// v1.24.0 - Startup log of warm-up results
+	"go.uber.org/zap"
+
+	// v0.3.0 - Bounded parallel warm-up
+	"golang.org/x/sync/errgroup"
+
+	// Internal packages for configuration, adapter interfaces and the sync manager
+	"src/backend/services/integration/internal/config"
+	"src/backend/services/integration/internal/models"
+	"src/backend/services/integration/internal/services"
+)
+
+// Fallbacks applied when the warm-up configuration is absent.
+const (
+	defaultWarmUpTimeout     = 15 * time.Second
+	defaultWarmUpParallelism = 4
+)
+
+// errIntegrationDisconnected is reported for an integration that initialized but
+// whose status check reported it disconnected.
+var errIntegrationDisconnected = errors.New("integration reports disconnected")
+
+// warmUpResult is the outcome of warming up one integration.
+type warmUpResult struct {
+	name    string
+	elapsed time.Duration
+
+	// registered is true when the adapter initialized and was added to the
+	// sync manager, even if its status check then failed.
+	registered bool
+
+	// err is nil when the integration initialized and reported connected.
+	err error
+}
+
+// warmUpIntegrations initializes and verifies every configured integration
+// before the service starts serving, so the first health check and the first
+// sends see warm adapters.
+// Steps:
+//  1. Select the integrations that have configuration.
+//  2. Warm them up with bounded parallelism, each within its own timeout.
+//  3. Register every adapter that initialized with the sync manager.
+//  4. Log one line per integration and return the results in display order.
+//
+// An integration that fails to initialize is not registered; one that
+// initializes but reports disconnected is registered and left to the health
+// monitor.
+func warmUpIntegrations(ctx context.Context, cfg *config.Config, manager *services.SyncManager, logger *zap.Logger) []warmUpResult {
+	timeout, parallelism := defaultWarmUpTimeout, defaultWarmUpParallelism
+	if cfg.WarmUp != nil {
+		timeout, parallelism = cfg.WarmUp.Timeout, cfg.WarmUp.Parallelism
+	}
+
+	// 1. Only configured integrations are warmed up.
+	var defs []*integrationDef
+	for i := range knownIntegrations {
+		if knownIntegrations[i].configured(cfg) {
+			defs = append(defs, &knownIntegrations[i])
+		}
+	}
+
+	// 2, 3. Each goroutine writes only its own result slot.
+	results := make([]warmUpResult, len(defs))
+	var g errgroup.Group
+	g.SetLimit(parallelism)
+	for i, def := range defs {
+		i, def := i, def
+		g.Go(func() error {
+			results[i] = warmUp(ctx, def, cfg, manager, timeout)
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	// 4. Report.
+	for _, r := range results {
+		fields := []zap.Field{
+			zap.String("integration", r.name),
+			zap.Duration("elapsed", r.elapsed),
+			zap.Bool("registered", r.registered),
+		}
+		if r.err != nil {
+			logger.Warn("Integration warm-up failed", append(fields, zap.Error(r.err))...)
+			continue
+		}
+		logger.Info("Integration warmed up", fields...)
+	}
+	return results
+}
+
+// warmUp initializes, registers and verifies one integration within timeout.
+func warmUp(ctx context.Context, def *integrationDef, cfg *config.Config, manager *services.SyncManager, timeout time.Duration) warmUpResult {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	result := warmUpResult{name: def.name}
+
+	integration, err := withDeadline(ctx, func() (models.Integration, error) {
+		return def.build(ctx, cfg)
+	})
+	if err != nil {
+		result.err = err
+		result.elapsed = time.Since(start)
+		return result
+	}
+	if err := manager.AddInitialized(def.name, integration); err != nil {
+		result.err = err
+		result.elapsed = time.Since(start)
+		return result
+	}
+	result.registered = true
+
+	status, err := withDeadline(ctx, integration.Status)
+	switch {
+	case err != nil:
+		result.err = err
+	case !status.Connected:
+		result.err = errIntegrationDisconnected
+	}
+	result.elapsed = time.Since(start)
+	return result
+}
+
+// withDeadline runs fn and returns its result, or ctx's error if ctx ends first.
+// Adapter calls that take no context (Slack initialization, status checks) are
+// bounded this way; fn keeps running in the background after a timeout.
+func withDeadline[T any](ctx context.Context, fn func() (T, error)) (T, error) {
+	type outcome struct {
+		value T
+		err   error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		value, err := fn()
+		done <- outcome{value, err}
+	}()
+	select {
+	case o := <-done:
+		return o.value, o.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// warmUpFailures returns the names of integrations that did not warm up cleanly.
+func warmUpFailures(results []warmUpResult) []string {
+	var failed []string
+	for _, r := range results {
+		if r.err != nil {
+			failed = append(failed, r.name)
+		}
+	}
+	return failed
+}
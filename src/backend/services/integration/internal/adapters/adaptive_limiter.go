@@ -0,0 +1,209 @@
+package adapters
+
+import (
+	// go1.21 - Context for cancellable waits
+	"context"
+	// go1.21 - Retry-After header parsing
+	"net/http"
+	"strconv"
+	// go1.21 - Guards limiter adjustments
+	"sync"
+	"time"
+
+	// v0.5.0 - Token bucket the adaptive limiter adjusts
+	"golang.org/x/time/rate"
+
+	// Internal cluster-wide limiter contract
+	"src/backend/services/integration/internal/models"
+)
+
+// Tuning for the AIMD (additive increase, multiplicative decrease) controller.
+const (
+	// adaptiveDecreaseFactor scales the rate down on every 429.
+	adaptiveDecreaseFactor = 0.5
+
+	// adaptiveIncreaseStep is the fraction of the ceiling restored per recovery step.
+	adaptiveIncreaseStep = 0.1
+
+	// adaptiveRecoveryInterval is the quiet period required between increases, so
+	// that the rate creeps back up rather than snapping to the ceiling.
+	adaptiveRecoveryInterval = 10 * time.Second
+
+	// defaultThrottlePause applies when a 429 carries no usable Retry-After.
+	defaultThrottlePause = time.Second
+)
+
+// AdaptiveLimiterStats is a snapshot of an adaptive limiter for status reporting.
+type AdaptiveLimiterStats struct {
+	// Limit is the current rate in requests per second.
+	Limit float64 `json:"limit"`
+
+	// Ceiling is the configured maximum rate in requests per second.
+	Ceiling float64 `json:"ceiling"`
+
+	// Burst is the token bucket size.
+	Burst int `json:"burst"`
+
+	// Throttled counts 429 responses observed from the provider.
+	Throttled uint64 `json:"throttled"`
+
+	// PausedUntil is when calls may resume after the last Retry-After, if in the future.
+	PausedUntil time.Time `json:"pausedUntil,omitempty"`
+}
+
+// adaptiveLimiter wraps a token bucket whose rate follows the provider's actual
+// capacity: each 429 halves the rate and pauses all callers for the Retry-After
+// period, and each quiet interval with successful calls restores a step of the
+// rate, keeping throughput near the provider's ceiling without tripping bans.
+type adaptiveLimiter struct {
+	limiter *rate.Limiter
+	ceiling rate.Limit
+	floor   rate.Limit
+
+	mu          sync.Mutex
+	pausedUntil time.Time
+	lastAdjust  time.Time
+	throttled   uint64
+
+	// shared, when set, also holds calls to the ceiling across every replica,
+	// counting them under sharedKey.
+	shared    models.SharedLimiter
+	sharedKey string
+}
+
+// newAdaptiveLimiter creates a limiter that starts at, and never exceeds, ceiling
+// and never drops below floor.
+func newAdaptiveLimiter(ceiling rate.Limit, burst int, floor rate.Limit) *adaptiveLimiter {
+	return &adaptiveLimiter{
+		limiter: rate.NewLimiter(ceiling, burst),
+		ceiling: ceiling,
+		floor:   floor,
+	}
+}
+
+// share makes the limiter also wait on shared, so that the replicas of the
+// service together stay within the ceiling. A nil shared limiter removes it.
+func (l *adaptiveLimiter) share(shared models.SharedLimiter, key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.shared = shared
+	l.sharedKey = key
+}
+
+// Wait blocks until any Retry-After pause has elapsed and a token is available,
+// locally and, when shared, cluster-wide.
+func (l *adaptiveLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	pause := time.Until(l.pausedUntil)
+	shared, sharedKey := l.shared, l.sharedKey
+	l.mu.Unlock()
+
+	if pause > 0 {
+		timer := time.NewTimer(pause)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	if err := l.limiter.Wait(ctx); err != nil {
+		return err
+	}
+	if shared != nil {
+		return shared.Wait(ctx, sharedKey, float64(l.ceiling))
+	}
+	return nil
+}
+
+// OnThrottled records a 429 from the provider: the rate is cut multiplicatively
+// and callers are paused for retryAfter.
+func (l *adaptiveLimiter) OnThrottled(retryAfter time.Duration) {
+	if retryAfter <= 0 {
+		retryAfter = defaultThrottlePause
+	}
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.throttled++
+	if until := now.Add(retryAfter); until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+	reduced := l.limiter.Limit() * adaptiveDecreaseFactor
+	if reduced < l.floor {
+		reduced = l.floor
+	}
+	l.limiter.SetLimitAt(now, reduced)
+	l.lastAdjust = now
+}
+
+// OnSuccess records a successful call and, once per recovery interval, raises
+// the rate additively toward the ceiling.
+func (l *adaptiveLimiter) OnSuccess() {
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	current := l.limiter.Limit()
+	if current >= l.ceiling || now.Sub(l.lastAdjust) < adaptiveRecoveryInterval {
+		return
+	}
+	raised := current + l.ceiling*adaptiveIncreaseStep
+	if raised > l.ceiling {
+		raised = l.ceiling
+	}
+	l.limiter.SetLimitAt(now, raised)
+	l.lastAdjust = now
+}
+
+// Limit returns the current rate.
+func (l *adaptiveLimiter) Limit() rate.Limit {
+	return l.limiter.Limit()
+}
+
+// Burst returns the token bucket size.
+func (l *adaptiveLimiter) Burst() int {
+	return l.limiter.Burst()
+}
+
+// Tokens returns the number of tokens currently available.
+func (l *adaptiveLimiter) Tokens() float64 {
+	return l.limiter.Tokens()
+}
+
+// Stats returns a snapshot of the limiter for status metadata.
+func (l *adaptiveLimiter) Stats() AdaptiveLimiterStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	stats := AdaptiveLimiterStats{
+		Limit:     float64(l.limiter.Limit()),
+		Ceiling:   float64(l.ceiling),
+		Burst:     l.limiter.Burst(),
+		Throttled: l.throttled,
+	}
+	if l.pausedUntil.After(time.Now()) {
+		stats.PausedUntil = l.pausedUntil
+	}
+	return stats
+}
+
+// parseRetryAfter interprets a Retry-After header given either as delay seconds
+// or as an HTTP date. It returns zero when the header is absent or invalid.
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		if d := time.Until(at); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
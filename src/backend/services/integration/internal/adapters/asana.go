@@ -0,0 +1,343 @@
+package adapters
+
+import (
+	// go1.21 - Context for cancellations and timeouts
+	"context"
+	// go1.21 - Sentinel errors and error wrapping
+	"errors"
+	"fmt"
+	// go1.21 - Request construction
+	"net/http"
+	"strings"
+	// go1.21 - Guards the client during credential rotation; due date parsing
+	"sync"
+	"time"
+
+	// v0.1.0 - Token bucket rate limiting under Asana's per-minute quota
+	"golang.org/x/time/rate"
+
+	// Internal imports for configuration, transport and adapter contracts
+	"src/backend/services/integration/internal/config"
+	"src/backend/services/integration/internal/httpclient"
+	"src/backend/services/integration/internal/models"
+)
+
+// ErrInvalidAsanaConfig indicates that the Asana configuration is missing the
+// base URL or personal access token.
+var ErrInvalidAsanaConfig = errors.New("invalid asana configuration or missing required fields")
+
+// ErrAsanaProjectRequired is returned for a task that names no project when no
+// default project is configured.
+var ErrAsanaProjectRequired = errors.New("asana task requires a project and no default project is configured")
+
+// AsanaTask is a task to create. Send also accepts the map payloads used for
+// Jira ("summary", "description").
+type AsanaTask struct {
+	// Name is required; Summary is accepted as the Jira-style alias.
+	Name    string `json:"name"`
+	Summary string `json:"summary,omitempty"`
+
+	// Notes is the plain-text description; Description is the Jira-style alias.
+	Notes       string `json:"notes,omitempty"`
+	Description string `json:"description,omitempty"`
+
+	// Project is a project GID; it defaults to the configured project.
+	Project string `json:"project,omitempty"`
+
+	// Section is a section name from the configured mapping, or a GID; it
+	// defaults to the configured section when the task goes to the default project.
+	Section string `json:"section,omitempty"`
+
+	// Assignee is a TaskStream user from the configured mapping, or an Asana
+	// user GID or email.
+	Assignee string `json:"assignee,omitempty"`
+
+	// DueDate is a date ("2024-05-01"), set as the due day, or an RFC 3339
+	// time, set as the due time.
+	DueDate string `json:"dueDate,omitempty"`
+}
+
+// asanaTaskData is the body of a task create request.
+type asanaTaskData struct {
+	Name        string            `json:"name"`
+	Notes       string            `json:"notes,omitempty"`
+	Projects    []string          `json:"projects"`
+	Memberships []asanaMembership `json:"memberships,omitempty"`
+	Assignee    string            `json:"assignee,omitempty"`
+	DueOn       string            `json:"due_on,omitempty"`
+	DueAt       string            `json:"due_at,omitempty"`
+}
+
+// asanaMembership places a new task in a section of a project.
+type asanaMembership struct {
+	Project string `json:"project"`
+	Section string `json:"section"`
+}
+
+// AsanaAdapter implements the Integration interface for Asana, creating tasks
+// in projects and sections through the Asana REST API.
+type AsanaAdapter struct {
+	// mu guards the fields below, which change on initialization and rotation.
+	mu          sync.RWMutex
+	config      *config.AsanaConfig
+	client      *restClient
+	user        string
+	connected   bool
+	lastSync    time.Time
+	lastCreated string
+	initialized bool
+
+	// guard applies the circuit breaker, rate limiter and retries to each call.
+	guard *restGuard
+}
+
+// Compile-time check to ensure AsanaAdapter implements the Integration interface.
+var _ models.Integration = (*AsanaAdapter)(nil)
+
+// Compile-time check to ensure AsanaAdapter supports credential rotation.
+var _ models.CredentialRotator = (*AsanaAdapter)(nil)
+
+// Compile-time check to ensure AsanaAdapter reports its circuit state.
+var _ models.CircuitReporter = (*AsanaAdapter)(nil)
+
+// Compile-time check to ensure AsanaAdapter's circuit can be reset by operators.
+var _ models.CircuitResetter = (*AsanaAdapter)(nil)
+
+// Compile-time check to ensure AsanaAdapter reports its circuit's state and counters.
+var _ models.CircuitInspector = (*AsanaAdapter)(nil)
+
+// Compile-time check to ensure AsanaAdapter accepts a retry budget.
+var _ models.RetryBudgetUser = (*AsanaAdapter)(nil)
+
+// NewAsanaAdapter creates an uninitialized Asana adapter. Its limiter allows
+// two calls per second with a burst of five, under the 150 requests per minute
+// of free workspaces, backing off to one every ten seconds on 429 responses.
+func NewAsanaAdapter() *AsanaAdapter {
+	return &AsanaAdapter{
+		guard: newRESTGuard(newAdaptiveLimiter(rate.Limit(2), 5, rate.Every(10*time.Second))),
+	}
+}
+
+// Initialize implements the Integration interface, bridging to
+// InitializeWithContext with a background context.
+func (aa *AsanaAdapter) Initialize(cfg interface{}) error {
+	return aa.InitializeWithContext(context.Background(), cfg)
+}
+
+// InitializeWithContext configures the adapter from a *config.AsanaConfig.
+// Steps:
+//  1. Validate the base URL and token.
+//  2. Build a client on the shared transport with the token as bearer credentials.
+//  3. Verify the token by fetching the authenticated user.
+func (aa *AsanaAdapter) InitializeWithContext(ctx context.Context, cfg interface{}) error {
+	// 1. Configuration.
+	ac, ok := cfg.(*config.AsanaConfig)
+	if !ok || ac == nil || ac.BaseURL == "" || ac.PersonalAccessToken == "" {
+		return ErrInvalidAsanaConfig
+	}
+
+	// 2. Client.
+	client := newAsanaClient(ac, ac.PersonalAccessToken)
+
+	// 3. Connectivity.
+	user, err := asanaMe(ctx, client)
+	if err != nil {
+		aa.mu.Lock()
+		aa.connected = false
+		aa.mu.Unlock()
+		return fmt.Errorf("%w: asana users/me: %v", models.ErrConnectionFailed, err)
+	}
+
+	aa.mu.Lock()
+	defer aa.mu.Unlock()
+	aa.config = ac
+	aa.client = client
+	aa.user = user
+	aa.connected = true
+	aa.initialized = true
+	aa.lastSync = time.Now()
+	return nil
+}
+
+// RotateCredentials replaces the personal access token without a restart. The
+// new token is verified before it replaces the active client; on failure the
+// adapter keeps its previous client.
+func (aa *AsanaAdapter) RotateCredentials(ctx context.Context, creds models.Credentials) error {
+	if creds.Secret == "" {
+		return models.ErrInvalidPayload
+	}
+
+	aa.mu.RLock()
+	ac := aa.config
+	aa.mu.RUnlock()
+	if ac == nil {
+		return models.ErrInitializationFailed
+	}
+
+	client := newAsanaClient(ac, creds.Secret)
+	user, err := asanaMe(ctx, client)
+	if err != nil {
+		return fmt.Errorf("rotated asana token rejected: %w", err)
+	}
+
+	aa.mu.Lock()
+	defer aa.mu.Unlock()
+	aa.client = client
+	aa.user = user
+	aa.connected = true
+	return nil
+}
+
+// Send implements the Integration interface, bridging to SendWithContext with a
+// background context.
+func (aa *AsanaAdapter) Send(payload interface{}) error {
+	return aa.SendWithContext(context.Background(), payload)
+}
+
+// SendWithContext creates a task. The payload is an AsanaTask (or pointer), or
+// an equivalent map.
+// Steps:
+//  1. Decode the payload and map its project, section, assignee and due date.
+//  2. POST the task.
+func (aa *AsanaAdapter) SendWithContext(ctx context.Context, payload interface{}) error {
+	aa.mu.RLock()
+	client, ac, initialized := aa.client, aa.config, aa.initialized
+	aa.mu.RUnlock()
+	if !initialized {
+		return models.ErrInitializationFailed
+	}
+
+	// 1. Task.
+	var task AsanaTask
+	if err := decodePayload(payload, &task); err != nil {
+		return err
+	}
+	data, err := buildAsanaTask(ac, &task)
+	if err != nil {
+		return err
+	}
+
+	// 2. Create.
+	var created struct {
+		Data struct {
+			GID string `json:"gid"`
+		} `json:"data"`
+	}
+	err = aa.guard.create(ctx, func(ctx context.Context) error {
+		return client.doJSON(ctx, http.MethodPost, "/tasks", map[string]interface{}{"data": data}, &created)
+	})
+	if err != nil {
+		return fmt.Errorf("asana create task: %w", err)
+	}
+
+	aa.mu.Lock()
+	aa.lastSync = time.Now()
+	aa.lastCreated = created.Data.GID
+	aa.connected = true
+	aa.mu.Unlock()
+	return nil
+}
+
+// buildAsanaTask validates task and converts it into a create request body.
+func buildAsanaTask(ac *config.AsanaConfig, task *AsanaTask) (*asanaTaskData, error) {
+	name := firstNonEmpty(task.Name, task.Summary)
+	if strings.TrimSpace(name) == "" {
+		return nil, fmt.Errorf("%w: missing required 'name' field in payload", models.ErrInvalidPayload)
+	}
+
+	project := firstNonEmpty(task.Project, ac.DefaultProject)
+	if project == "" {
+		return nil, ErrAsanaProjectRequired
+	}
+
+	data := &asanaTaskData{
+		Name:     name,
+		Notes:    firstNonEmpty(task.Notes, task.Description),
+		Projects: []string{project},
+		Assignee: mappedValue(ac.Assignees, task.Assignee, ""),
+	}
+
+	// The default section belongs to the default project only.
+	sectionFallback := ""
+	if project == ac.DefaultProject {
+		sectionFallback = ac.DefaultSection
+	}
+	if section := mappedValue(ac.Sections, task.Section, sectionFallback); section != "" {
+		data.Memberships = []asanaMembership{{Project: project, Section: section}}
+	}
+
+	if task.DueDate != "" {
+		if _, err := time.Parse(time.DateOnly, task.DueDate); err == nil {
+			data.DueOn = task.DueDate
+		} else if at, err := time.Parse(time.RFC3339, task.DueDate); err == nil {
+			data.DueAt = at.UTC().Format(time.RFC3339)
+		} else {
+			return nil, fmt.Errorf("%w: dueDate %q is neither a date nor an RFC 3339 time",
+				models.ErrInvalidPayload, task.DueDate)
+		}
+	}
+	return data, nil
+}
+
+// Status implements the Integration interface, reporting connectivity as of the
+// last call along with the circuit, limiter and call metrics.
+func (aa *AsanaAdapter) Status() (models.IntegrationStatus, error) {
+	aa.mu.RLock()
+	status := models.IntegrationStatus{
+		Connected: aa.connected,
+		Name:      "AsanaIntegration",
+		Type:      "project_management",
+		LastSync:  aa.lastSync,
+	}
+	ac, user, lastCreated := aa.config, aa.user, aa.lastCreated
+	aa.mu.RUnlock()
+
+	aa.guard.fillStatus(&status)
+	status.Metadata["user"] = user
+	status.Metadata["lastTaskGid"] = lastCreated
+	if ac != nil {
+		status.Metadata["defaultProject"] = ac.DefaultProject
+	}
+	return status, nil
+}
+
+// SetRetryBudget implements models.RetryBudgetUser.
+func (aa *AsanaAdapter) SetRetryBudget(budget models.RetryBudget) {
+	aa.guard.budget = budget
+}
+
+// CircuitOpen implements models.CircuitReporter.
+func (aa *AsanaAdapter) CircuitOpen() bool {
+	return aa.guard.breaker.IsOpen()
+}
+
+// CircuitSnapshot implements models.CircuitInspector.
+func (aa *AsanaAdapter) CircuitSnapshot() models.CircuitSnapshot {
+	return aa.guard.breaker.Snapshot()
+}
+
+// ResetCircuit implements models.CircuitResetter.
+func (aa *AsanaAdapter) ResetCircuit() {
+	aa.guard.breaker.Reset()
+}
+
+// newAsanaClient creates a client for the Asana API authenticated with token.
+func newAsanaClient(ac *config.AsanaConfig, token string) *restClient {
+	return newRESTClient(ac.BaseURL, httpclient.Default().ClientWithTimeout(ac.Timeout), http.Header{
+		"Authorization": {"Bearer " + token},
+	})
+}
+
+// asanaMe returns the name of the user the client's token belongs to.
+func asanaMe(ctx context.Context, client *restClient) (string, error) {
+	var resp struct {
+		Data struct {
+			GID  string `json:"gid"`
+			Name string `json:"name"`
+		} `json:"data"`
+	}
+	if err := client.doJSON(ctx, http.MethodGet, "/users/me", nil, &resp); err != nil {
+		return "", err
+	}
+	return resp.Data.Name, nil
+}
@@ -0,0 +1,379 @@
+package adapters
+
+import (
+	// go1.21 - Context for cancellations and timeouts
+	"context"
+	// go1.21 - PAT basic credentials
+	"encoding/base64"
+	// go1.21 - Sentinel errors and error wrapping
+	"errors"
+	"fmt"
+	// go1.21 - Request construction and path escaping
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	// go1.21 - Guards the client during credential rotation
+	"sync"
+	"time"
+
+	// v0.1.0 - Token bucket rate limiting under Azure DevOps throttling
+	"golang.org/x/time/rate"
+
+	// Internal imports for configuration, transport and adapter contracts
+	"src/backend/services/integration/internal/config"
+	"src/backend/services/integration/internal/httpclient"
+	"src/backend/services/integration/internal/models"
+)
+
+// ErrInvalidAzureDevOpsConfig indicates that the Azure DevOps configuration is
+// missing the organization URL, project or personal access token.
+var ErrInvalidAzureDevOpsConfig = errors.New("invalid azure devops configuration or missing required fields")
+
+// azureDevOpsAPIVersion is the REST API version requested on every call.
+const azureDevOpsAPIVersion = "7.0"
+
+// azureDevOpsPatchContentType is required by the work item create endpoint.
+const azureDevOpsPatchContentType = "application/json-patch+json"
+
+// azureDevOpsPriorities maps Jira priority names to Azure DevOps priorities
+// (1 is highest), so Jira-style payloads keep their meaning.
+var azureDevOpsPriorities = map[string]int{
+	"highest":  1,
+	"critical": 1,
+	"high":     2,
+	"medium":   3,
+	"low":      4,
+	"lowest":   4,
+}
+
+// AzureDevOpsWorkItem is a work item to create. Send also accepts the map
+// payloads used for Jira ("summary", "description", "issueType", "priority"),
+// so existing callers get parity without changes.
+type AzureDevOpsWorkItem struct {
+	// Title is required; Summary is accepted as the Jira-style alias.
+	Title   string `json:"title"`
+	Summary string `json:"summary,omitempty"`
+
+	// Description is HTML, as shown in the work item form.
+	Description string `json:"description,omitempty"`
+
+	// Type is the work item type; IssueType is the Jira-style alias. It
+	// defaults to the configured type.
+	Type      string `json:"type,omitempty"`
+	IssueType string `json:"issueType,omitempty"`
+
+	// Area and Iteration are names from the configured mappings, or paths.
+	Area      string `json:"area,omitempty"`
+	Iteration string `json:"iteration,omitempty"`
+
+	// Priority is 1 (highest) to 4, or a Jira priority name.
+	Priority interface{} `json:"priority,omitempty"`
+
+	// AssignedTo is the assignee's display name or email.
+	AssignedTo string `json:"assignedTo,omitempty"`
+
+	// Tags are added to the work item.
+	Tags []string `json:"tags,omitempty"`
+
+	// Fields sets further fields by reference name, e.g.
+	// "Microsoft.VSTS.Scheduling.StoryPoints".
+	Fields map[string]interface{} `json:"fields,omitempty"`
+}
+
+// azureDevOpsPatchOp is one JSON Patch operation of a work item create.
+type azureDevOpsPatchOp struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value"`
+}
+
+// AzureDevOpsAdapter implements the Integration interface for Azure DevOps,
+// creating work items through the Work Item Tracking REST API.
+type AzureDevOpsAdapter struct {
+	// mu guards the fields below, which change on initialization and rotation.
+	mu          sync.RWMutex
+	config      *config.AzureDevOpsConfig
+	client      *restClient
+	connected   bool
+	lastSync    time.Time
+	lastCreated int
+	initialized bool
+
+	// guard applies the circuit breaker, rate limiter and retries to each call.
+	guard *restGuard
+}
+
+// Compile-time check to ensure AzureDevOpsAdapter implements the Integration interface.
+var _ models.Integration = (*AzureDevOpsAdapter)(nil)
+
+// Compile-time check to ensure AzureDevOpsAdapter supports credential rotation.
+var _ models.CredentialRotator = (*AzureDevOpsAdapter)(nil)
+
+// Compile-time check to ensure AzureDevOpsAdapter reports its circuit state.
+var _ models.CircuitReporter = (*AzureDevOpsAdapter)(nil)
+
+// Compile-time check to ensure AzureDevOpsAdapter's circuit can be reset by operators.
+var _ models.CircuitResetter = (*AzureDevOpsAdapter)(nil)
+
+// Compile-time check to ensure AzureDevOpsAdapter reports its circuit's state and counters.
+var _ models.CircuitInspector = (*AzureDevOpsAdapter)(nil)
+
+// Compile-time check to ensure AzureDevOpsAdapter accepts a retry budget.
+var _ models.RetryBudgetUser = (*AzureDevOpsAdapter)(nil)
+
+// NewAzureDevOpsAdapter creates an uninitialized Azure DevOps adapter. Its
+// limiter allows five calls per second with a burst of ten, backing off to one
+// every ten seconds while Azure DevOps throttles the account.
+func NewAzureDevOpsAdapter() *AzureDevOpsAdapter {
+	return &AzureDevOpsAdapter{
+		guard: newRESTGuard(newAdaptiveLimiter(rate.Limit(5), 10, rate.Every(10*time.Second))),
+	}
+}
+
+// Initialize implements the Integration interface, bridging to
+// InitializeWithContext with a background context.
+func (aa *AzureDevOpsAdapter) Initialize(cfg interface{}) error {
+	return aa.InitializeWithContext(context.Background(), cfg)
+}
+
+// InitializeWithContext configures the adapter from a *config.AzureDevOpsConfig.
+// Steps:
+//  1. Validate the organization URL, project and token.
+//  2. Build a client on the shared transport authenticated with the PAT.
+//  3. Verify the token and project with a project lookup.
+func (aa *AzureDevOpsAdapter) InitializeWithContext(ctx context.Context, cfg interface{}) error {
+	// 1. Configuration.
+	ac, ok := cfg.(*config.AzureDevOpsConfig)
+	if !ok || ac == nil || ac.OrganizationURL == "" || ac.Project == "" || ac.PersonalAccessToken == "" {
+		return ErrInvalidAzureDevOpsConfig
+	}
+
+	// 2. Client.
+	client := newAzureDevOpsClient(ac, ac.PersonalAccessToken)
+
+	// 3. Connectivity.
+	if err := azureDevOpsProject(ctx, client, ac.Project); err != nil {
+		aa.mu.Lock()
+		aa.connected = false
+		aa.mu.Unlock()
+		return fmt.Errorf("%w: azure devops project %s: %v", models.ErrConnectionFailed, ac.Project, err)
+	}
+
+	aa.mu.Lock()
+	defer aa.mu.Unlock()
+	aa.config = ac
+	aa.client = client
+	aa.connected = true
+	aa.initialized = true
+	aa.lastSync = time.Now()
+	return nil
+}
+
+// RotateCredentials replaces the personal access token without a restart. The
+// new token is verified with a project lookup before it replaces the active
+// client; on failure the adapter keeps its previous client.
+func (aa *AzureDevOpsAdapter) RotateCredentials(ctx context.Context, creds models.Credentials) error {
+	if creds.Secret == "" {
+		return models.ErrInvalidPayload
+	}
+
+	aa.mu.RLock()
+	ac := aa.config
+	aa.mu.RUnlock()
+	if ac == nil {
+		return models.ErrInitializationFailed
+	}
+
+	client := newAzureDevOpsClient(ac, creds.Secret)
+	if err := azureDevOpsProject(ctx, client, ac.Project); err != nil {
+		return fmt.Errorf("rotated azure devops token rejected: %w", err)
+	}
+
+	aa.mu.Lock()
+	defer aa.mu.Unlock()
+	aa.client = client
+	aa.connected = true
+	return nil
+}
+
+// Send implements the Integration interface, bridging to SendWithContext with a
+// background context.
+func (aa *AzureDevOpsAdapter) Send(payload interface{}) error {
+	return aa.SendWithContext(context.Background(), payload)
+}
+
+// SendWithContext creates a work item. The payload is an AzureDevOpsWorkItem
+// (or pointer), or an equivalent map.
+// Steps:
+//  1. Decode the payload and build the JSON Patch document, applying the
+//     default type and the area and iteration mappings.
+//  2. POST it to the work item create endpoint for the type.
+func (aa *AzureDevOpsAdapter) SendWithContext(ctx context.Context, payload interface{}) error {
+	aa.mu.RLock()
+	client, ac, initialized := aa.client, aa.config, aa.initialized
+	aa.mu.RUnlock()
+	if !initialized {
+		return models.ErrInitializationFailed
+	}
+
+	// 1. Work item.
+	var item AzureDevOpsWorkItem
+	if err := decodePayload(payload, &item); err != nil {
+		return err
+	}
+	workItemType, patch, err := buildAzureDevOpsPatch(ac, &item)
+	if err != nil {
+		return err
+	}
+
+	// 2. Create.
+	path := "/" + url.PathEscape(ac.Project) + "/_apis/wit/workitems/$" + url.PathEscape(workItemType) +
+		"?api-version=" + azureDevOpsAPIVersion
+	var created struct {
+		ID int `json:"id"`
+	}
+	err = aa.guard.create(ctx, func(ctx context.Context) error {
+		return client.do(ctx, restRequest{
+			method:      http.MethodPost,
+			path:        path,
+			contentType: azureDevOpsPatchContentType,
+			body:        patch,
+		}, &created)
+	})
+	if err != nil {
+		return fmt.Errorf("azure devops create %s: %w", workItemType, err)
+	}
+
+	aa.mu.Lock()
+	aa.lastSync = time.Now()
+	aa.lastCreated = created.ID
+	aa.connected = true
+	aa.mu.Unlock()
+	return nil
+}
+
+// buildAzureDevOpsPatch validates item and returns its work item type and the
+// JSON Patch document that creates it.
+func buildAzureDevOpsPatch(ac *config.AzureDevOpsConfig, item *AzureDevOpsWorkItem) (string, []azureDevOpsPatchOp, error) {
+	title := item.Title
+	if title == "" {
+		title = item.Summary
+	}
+	if strings.TrimSpace(title) == "" {
+		return "", nil, fmt.Errorf("%w: missing required 'title' field in payload", models.ErrInvalidPayload)
+	}
+
+	workItemType := firstNonEmpty(item.Type, item.IssueType, ac.DefaultWorkItemType, "Task")
+
+	patch := []azureDevOpsPatchOp{azureDevOpsField("System.Title", title)}
+	if item.Description != "" {
+		patch = append(patch, azureDevOpsField("System.Description", item.Description))
+	}
+	if area := mappedValue(ac.AreaPaths, item.Area, ac.DefaultAreaPath); area != "" {
+		patch = append(patch, azureDevOpsField("System.AreaPath", area))
+	}
+	if iteration := mappedValue(ac.IterationPaths, item.Iteration, ac.DefaultIterationPath); iteration != "" {
+		patch = append(patch, azureDevOpsField("System.IterationPath", iteration))
+	}
+	if item.Priority != nil {
+		priority, ok := azureDevOpsPriority(item.Priority)
+		if !ok {
+			return "", nil, fmt.Errorf("%w: unknown priority %v", models.ErrInvalidPayload, item.Priority)
+		}
+		patch = append(patch, azureDevOpsField("Microsoft.VSTS.Common.Priority", priority))
+	}
+	if item.AssignedTo != "" {
+		patch = append(patch, azureDevOpsField("System.AssignedTo", item.AssignedTo))
+	}
+	if len(item.Tags) > 0 {
+		patch = append(patch, azureDevOpsField("System.Tags", strings.Join(item.Tags, "; ")))
+	}
+	for name, value := range item.Fields {
+		patch = append(patch, azureDevOpsField(name, value))
+	}
+	return workItemType, patch, nil
+}
+
+// azureDevOpsField returns the patch operation that sets the named field.
+func azureDevOpsField(referenceName string, value interface{}) azureDevOpsPatchOp {
+	return azureDevOpsPatchOp{Op: "add", Path: "/fields/" + referenceName, Value: value}
+}
+
+// azureDevOpsPriority converts a payload priority to 1-4.
+func azureDevOpsPriority(value interface{}) (int, bool) {
+	var n int
+	switch v := value.(type) {
+	case float64:
+		n = int(v)
+	case string:
+		if p, ok := azureDevOpsPriorities[strings.ToLower(v)]; ok {
+			return p, true
+		}
+		parsed, err := strconv.Atoi(v)
+		if err != nil {
+			return 0, false
+		}
+		n = parsed
+	default:
+		return 0, false
+	}
+	return n, n >= 1 && n <= 4
+}
+
+// Status implements the Integration interface, reporting connectivity as of the
+// last call along with the circuit, limiter and call metrics.
+func (aa *AzureDevOpsAdapter) Status() (models.IntegrationStatus, error) {
+	aa.mu.RLock()
+	status := models.IntegrationStatus{
+		Connected: aa.connected,
+		Name:      "AzureDevOpsIntegration",
+		Type:      "project_management",
+		LastSync:  aa.lastSync,
+	}
+	ac, lastCreated := aa.config, aa.lastCreated
+	aa.mu.RUnlock()
+
+	aa.guard.fillStatus(&status)
+	status.Metadata["lastWorkItemId"] = lastCreated
+	if ac != nil {
+		status.Metadata["organization"] = ac.OrganizationURL
+		status.Metadata["project"] = ac.Project
+	}
+	return status, nil
+}
+
+// SetRetryBudget implements models.RetryBudgetUser.
+func (aa *AzureDevOpsAdapter) SetRetryBudget(budget models.RetryBudget) {
+	aa.guard.budget = budget
+}
+
+// CircuitOpen implements models.CircuitReporter.
+func (aa *AzureDevOpsAdapter) CircuitOpen() bool {
+	return aa.guard.breaker.IsOpen()
+}
+
+// CircuitSnapshot implements models.CircuitInspector.
+func (aa *AzureDevOpsAdapter) CircuitSnapshot() models.CircuitSnapshot {
+	return aa.guard.breaker.Snapshot()
+}
+
+// ResetCircuit implements models.CircuitResetter.
+func (aa *AzureDevOpsAdapter) ResetCircuit() {
+	aa.guard.breaker.Reset()
+}
+
+// newAzureDevOpsClient creates a client for the organization authenticated with
+// a personal access token, sent as the password of Basic credentials.
+func newAzureDevOpsClient(ac *config.AzureDevOpsConfig, token string) *restClient {
+	credentials := base64.StdEncoding.EncodeToString([]byte(":" + token))
+	return newRESTClient(ac.OrganizationURL, httpclient.Default().ClientWithTimeout(ac.Timeout), http.Header{
+		"Authorization": {"Basic " + credentials},
+	})
+}
+
+// azureDevOpsProject verifies that the client can read the project.
+func azureDevOpsProject(ctx context.Context, client *restClient, project string) error {
+	return client.doJSON(ctx, http.MethodGet,
+		"/_apis/projects/"+url.PathEscape(project)+"?api-version="+azureDevOpsAPIVersion, nil, nil)
+}
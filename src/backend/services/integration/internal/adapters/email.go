@@ -4,11 +4,29 @@ import (
 	// go1.21 - Context management for operations
 	"context"
 
+	// go1.21 - Error inspection for pool acquisition failures
+	"errors"
+
+	// go1.21 - Wrapping of budget-skipped retries
+	"fmt"
+
+	// go1.21 - RFC 2047 encoding of the subject header
+	"mime"
+
 	// go1.21 - SMTP client implementation
 	"net/smtp"
 
-	// go1.21 - TLS encryption support
+	// go1.21 - TLS encryption support, CA bundles and public key pinning
+	"crypto/sha256"
 	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+
+	// go1.21 - Dialing, CA file reading and sender address validation
+	"net"
+	"net/mail"
+	"os"
+	"strings"
 
 	// go1.21 - Synchronization primitives for connection pooling
 	"sync"
@@ -23,6 +41,18 @@ import (
 	"src/backend/services/integration/internal/models"
 )
 
+// ErrInvalidEmailConfig indicates that the email configuration is missing or
+// has an invalid host, port or sender address.
+var ErrInvalidEmailConfig = errors.New("invalid email configuration or missing required fields")
+
+// ErrSTARTTLSUnsupported is returned when STARTTLS is required but the SMTP
+// server does not offer it.
+var ErrSTARTTLSUnsupported = errors.New("smtp server does not support STARTTLS")
+
+// ErrSMTPPinMismatch is returned when no certificate in the SMTP server's chain
+// matches a pinned public key.
+var ErrSMTPPinMismatch = errors.New("smtp server certificate does not match a pinned public key")
+
 // defaultContentType sets the MIME Content-Type header for outgoing emails if none is provided.
 const defaultContentType = "text/plain"
 
@@ -38,22 +68,62 @@ type EmailPayload struct {
 	// Subject is the title or topic of the email.
 	Subject string
 
-	// Body is the textual or HTML content of the email.
+	// Body is the plain-text content of the email. With HTML set and Body empty,
+	// a plain-text rendering of the HTML is sent.
 	Body string
 
+	// HTML is the HTML rendering of the email, sent alongside a plain-text
+	// alternative as multipart/alternative.
+	HTML string
+
 	// To is a list of recipients' email addresses.
 	To []string
 
-	// ContentType specifies the email's MIME Content-Type (e.g., "text/plain" or "text/html").
-	// Defaults to defaultContentType if left empty.
+	// Cc recipients are listed in the Cc header; Bcc recipients receive the
+	// message without appearing in any header.
+	Cc  []string
+	Bcc []string
+
+	// ReplyTo, when set, is the address replies are directed to.
+	ReplyTo string
+
+	// Headers are additional message headers, such as List-Unsubscribe.
+	// Headers the message is built with, such as From, Subject and the
+	// Content- headers, cannot be set here.
+	Headers map[string]string
+
+	// ContentType specifies the MIME Content-Type of Body (e.g., "text/plain").
+	// Defaults to defaultContentType if left empty. "text/html" is accepted for
+	// compatibility and treated as if Body were set as HTML.
 	ContentType string
+
+	// Attachments are sent with the body; inline attachments can be referenced
+	// from an HTML body by their content ID.
+	Attachments []EmailAttachment
+
+	// DropRejectedRecipients sends to the recipients within the configured
+	// allowed domains, skipping the rest, instead of failing the whole send.
+	// The send still fails when no recipient is allowed.
+	DropRejectedRecipients bool
+
+	// RejectedRecipients is set by the send to the recipients outside the
+	// configured allowed domains.
+	RejectedRecipients []string
+
+	// MessageID is the Message-ID header, angle brackets included. When empty
+	// the send generates one and sets it here, so replies and bounces can be
+	// correlated with the message.
+	MessageID string
 }
 
 // EmailAdapter implements the models.Integration interface for secure and monitored
 // email communication, leveraging connection pooling for efficient SMTP usage.
 type EmailAdapter struct {
-	// clientPool is a pool of reusable SMTP connections for improved performance.
-	clientPool *sync.Pool
+	// clientPool is a bounded, health-checked pool of reusable SMTP connections.
+	clientPool *smtpPool
+
+	// failover dials the pool's connections to the first reachable server.
+	failover *smtpFailover
 
 	// config holds SMTP host, port, authentication, and domain restrictions.
 	config *config.EmailConfig
@@ -69,38 +139,93 @@ type EmailAdapter struct {
 
 	// mu is a mutex used to ensure thread-safe updates to adapter state, including lastSync.
 	mu *sync.Mutex
+
+	// retryBudget bounds resends after failed attempts; set at registration.
+	retryBudget models.RetryBudget
+
+	// sent remembers sent Message-IDs for correlating replies and bounces read
+	// from the inbound mailbox; nil when no mailbox is configured.
+	sent *sentMessageLog
+}
+
+// Compile-time check to ensure EmailAdapter reports the Message-ID of sends.
+var _ models.ResultSender = (*EmailAdapter)(nil)
+
+// Compile-time check to ensure EmailAdapter correlates replies and bounces.
+var _ models.InboundEmailReceiver = (*EmailAdapter)(nil)
+
+// Compile-time check to ensure EmailAdapter accepts a retry budget.
+var _ models.RetryBudgetUser = (*EmailAdapter)(nil)
+
+// Compile-time check to ensure EmailAdapter can test its SMTP login on demand.
+var _ models.ConnectionTester = (*EmailAdapter)(nil)
+
+// Compile-time check to ensure EmailAdapter releases its pool on shutdown.
+var _ models.Closer = (*EmailAdapter)(nil)
+
+// SetRetryBudget implements models.RetryBudgetUser.
+func (e *EmailAdapter) SetRetryBudget(budget models.RetryBudget) {
+	e.retryBudget = budget
 }
 
 // NewEmailAdapter is the exported constructor function that creates a new instance
 // of EmailAdapter with secure configuration, connection pooling, and thread-safety mechanisms.
-func NewEmailAdapter(cfg *config.EmailConfig, tlsCfg *config.TLSConfig) *EmailAdapter {
-	// Validate configuration parameters before proceeding. In a production environment,
-	// more thorough checks (e.g., domain restrictions, credential validation) could be implemented.
-	if cfg == nil {
-		// In practice, you might want to handle the nil config more gracefully or log a critical error.
-		panic("EmailConfig cannot be nil")
+// It returns an error wrapping ErrInvalidEmailConfig when the configuration is
+// missing or invalid; no connection is made until the adapter is initialized.
+func NewEmailAdapter(cfg *config.EmailConfig, tlsCfg *config.TLSConfig) (*EmailAdapter, error) {
+	// Validate configuration parameters before proceeding, so a bad configuration
+	// fails startup with an error instead of a panic or a failure on first send.
+	if err := validateEmailConfig(cfg); err != nil {
+		return nil, err
 	}
 
-	// Construct a sync.Pool to manage SMTP clients. The New field
-	// is lazily invoked to create new connections when the pool is empty.
-	pool := &sync.Pool{
-		New: func() interface{} {
-			return newSMTPClientConnection(cfg, tlsCfg)
-		},
+	// The TLS settings default to the email configuration's own.
+	if tlsCfg == nil {
+		tlsCfg = cfg.TLS
 	}
 
+	// Construct a bounded pool to manage SMTP clients. Connections are dialled
+	// lazily when no idle session is available.
+	pool, failover := newSMTPClientPool(cfg, tlsCfg)
+
 	// Initialize the mutex for concurrency safety.
 	adapterMutex := &sync.Mutex{}
 
+	// Remember sent messages only when replies and bounces are read back.
+	var sent *sentMessageLog
+	if cfg.Inbound.Enabled() {
+		sent = newSentMessageLog(cfg.Inbound.Retention)
+	}
+
 	// Create and return a fully-initialized EmailAdapter structure.
 	return &EmailAdapter{
 		clientPool:  pool,
+		failover:    failover,
 		config:      cfg,
 		tlsConfig:   tlsCfg,
 		initialized: false,
 		lastSync:    time.Time{},
 		mu:          adapterMutex,
+		sent:        sent,
+	}, nil
+}
+
+// validateEmailConfig checks the settings the adapter needs to connect and
+// send: the SMTP host, a port in range, and a well-formed sender address.
+func validateEmailConfig(cfg *config.EmailConfig) error {
+	if cfg == nil {
+		return fmt.Errorf("%w: configuration is nil", ErrInvalidEmailConfig)
+	}
+	if strings.TrimSpace(cfg.Host) == "" {
+		return fmt.Errorf("%w: host is not set", ErrInvalidEmailConfig)
+	}
+	if cfg.Port < 1 || cfg.Port > 65535 {
+		return fmt.Errorf("%w: port %d is out of range", ErrInvalidEmailConfig, cfg.Port)
 	}
+	if _, err := mail.ParseAddress(cfg.FromAddress); err != nil {
+		return fmt.Errorf("%w: fromAddress %q: %v", ErrInvalidEmailConfig, cfg.FromAddress, err)
+	}
+	return nil
 }
 
 // Initialize satisfies the models.Integration interface method signature,
@@ -148,8 +273,8 @@ func (e *EmailAdapter) initializeContext(ctx context.Context) error {
 	// Step 2: Setup TLS configuration (placeholder).
 	// (Detailed certificate verification, root CA checks, and ciphers can be implemented here.)
 
-	// Step 3: The sync.Pool has already been initialized in the constructor. We do not re-initialize it.
-	// Instead, we confirm that the pool's New function can create valid connections.
+	// Step 3: The pool has already been initialized in the constructor. We do not re-initialize it.
+	// Instead, we confirm that the pool's dialer can create valid connections.
 
 	// Step 4: Test the connection with a defined timeout to confirm connectivity.
 	connTestCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
@@ -165,6 +290,92 @@ func (e *EmailAdapter) initializeContext(ctx context.Context) error {
 	return nil
 }
 
+// RotateCredentials switches SMTP authentication to a new username/password,
+// or with XOAUTH2 to a new refresh token or client secret, without a restart.
+// A connection is dialled and authenticated with the new credentials before
+// the swap; on success the connection pool is replaced, so
+// subsequent sends re-dial with the new credentials while sends in flight finish
+// on their existing connections, which are then closed rather than pooled.
+// The credentials are verified against the primary server; fallback servers
+// with credentials of their own keep them.
+//
+// Steps:
+// 1. Derive the rotated configuration from the current one
+// 2. Dial and authenticate a test connection with the new credentials
+// 3. Replace the configuration and connection pool
+func (e *EmailAdapter) RotateCredentials(ctx context.Context, creds models.Credentials) error {
+	if creds.Secret == "" {
+		return models.ErrInvalidPayload
+	}
+
+	// Step 1: Copy the configuration; the shared service config is not mutated.
+	e.mu.Lock()
+	rotated := *e.config
+	tlsCfg := e.tlsConfig
+	e.mu.Unlock()
+
+	if creds.Username != "" {
+		rotated.Username = creds.Username
+	}
+	if rotated.OAuth2 != nil {
+		// With XOAUTH2 the secret replaces the refresh token, or the client
+		// secret when tokens come from the client-credentials grant.
+		oauth := *rotated.OAuth2
+		if oauth.RefreshToken != "" {
+			oauth.RefreshToken = creds.Secret
+		} else {
+			oauth.ClientSecret = creds.Secret
+		}
+		rotated.OAuth2 = &oauth
+	} else {
+		rotated.Password = creds.Secret
+	}
+
+	// Step 2: Verify the new credentials within the connection timeout.
+	testCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		client, err := newSMTPClientConnection(&rotated, tlsCfg, newSMTPAuth(&rotated))
+		if err == nil {
+			_ = client.Quit()
+		}
+		done <- err
+	}()
+	select {
+	case <-testCtx.Done():
+		return testCtx.Err()
+	case err := <-done:
+		if err != nil {
+			return err
+		}
+	}
+
+	// Step 3: Swap in the configuration and a fresh pool bound to it, then close the
+	// old pool so its sessions, authenticated with the old credentials, are dropped.
+	e.mu.Lock()
+	e.config = &rotated
+	previous := e.clientPool
+	e.clientPool, e.failover = newSMTPClientPool(&rotated, tlsCfg)
+	e.mu.Unlock()
+	previous.Close()
+	return nil
+}
+
+// currentPool returns the connection pool for the active credentials.
+func (e *EmailAdapter) currentPool() *smtpPool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.clientPool
+}
+
+// Close closes the SMTP connection pool, ending each idle session. Connections
+// in use are closed as their sends finish.
+func (e *EmailAdapter) Close(ctx context.Context) error {
+	e.currentPool().Close()
+	return nil
+}
+
 // Send satisfies the models.Integration interface method signature,
 // serving as a wrapper that expects an arbitrary payload parameter.
 // Per the TaskStream AI specification, we internally call a context-based
@@ -183,10 +394,32 @@ func (e *EmailAdapter) Send(payload interface{}) error {
 	return e.sendEmailWithContext(container.Ctx, container.Payload)
 }
 
+// SendWithResult implements models.ResultSender, returning the Message-ID of
+// the sent email. The payload is an *EmailPayload, or the context wrapper
+// accepted by Send, whose context then bounds the send instead of ctx.
+func (e *EmailAdapter) SendWithResult(ctx context.Context, payload interface{}) (models.SendResult, error) {
+	var ep *EmailPayload
+	switch p := payload.(type) {
+	case *EmailPayload:
+		ep = p
+	case struct {
+		Ctx     context.Context
+		Payload *EmailPayload
+	}:
+		ctx, ep = p.Ctx, p.Payload
+	default:
+		return models.SendResult{}, models.ErrInvalidPayload
+	}
+	if err := e.sendEmailWithContext(ctx, ep); err != nil {
+		return models.SendResult{}, err
+	}
+	return models.SendResult{MessageID: ep.MessageID}, nil
+}
+
 // sendEmailWithContext sends one or more emails using the connection pool and retry logic.
 //
 // Steps:
-// 1. Validate context and payload
+// 1. Validate context, payload and recipient domains
 // 2. Get connection from pool
 // 3. Apply rate limiting (placeholder step)
 // 4. Format email with headers
@@ -202,36 +435,58 @@ func (e *EmailAdapter) sendEmailWithContext(ctx context.Context, ep *EmailPayloa
 	if err := ctx.Err(); err != nil {
 		return err
 	}
-	if ep == nil || len(ep.To) == 0 {
+	if ep == nil || len(ep.envelopeRecipients()) == 0 {
 		return models.ErrInvalidPayload
 	}
+	if ep.MessageID == "" {
+		ep.MessageID = newMessageID(e.config.FromAddress)
+	}
 
-	// Step 2: Get a connection from the sync.Pool.
-	conn := e.clientPool.Get()
-	smtpClient, ok := conn.(*smtp.Client)
-	if !ok || smtpClient == nil {
-		return models.ErrConnectionFailed
+	// Restrict recipients to the allowed domains, dropping those outside them
+	// when the payload asks for it.
+	allowed := *ep
+	var rejected []string
+	for _, list := range []*[]string{&allowed.To, &allowed.Cc, &allowed.Bcc} {
+		permitted, outside := partitionAllowedDomains(e.config.AllowedDomains, *list)
+		*list = permitted
+		rejected = append(rejected, outside...)
 	}
-	defer func() {
-		// Return connection to pool after send is done or even if we fail.
-		e.clientPool.Put(smtpClient)
-	}()
+	ep.RejectedRecipients = rejected
+	if len(rejected) > 0 {
+		if !ep.DropRejectedRecipients || len(allowed.envelopeRecipients()) == 0 {
+			return &RecipientDomainError{Recipients: rejected}
+		}
+		ep = &allowed
+	}
+
+	// Step 2: Capture the connection pool once so that a credential rotation during
+	// the send does not mix connections between pools; each attempt below checks a
+	// connection out of it.
+	pool := e.currentPool()
 
-	// Step 3: Apply rate limiting is not fully implemented. This is a placeholder step
-	// (In production, you'd implement a token bucket, a channel-based throttle, or external rate-limiting.)
+	// Step 3: Rate limiting is bounded by the pool itself: at most MaxConnections
+	// sends run concurrently and further sends wait up to the acquire timeout.
 
 	// Step 4: Format email with headers. If ep.ContentType is empty, use defaultContentType.
-	mimeBoundary := defaultContentType
+	contentType := defaultContentType
 	if ep.ContentType != "" {
-		mimeBoundary = ep.ContentType
+		contentType = ep.ContentType
 	}
 
-	// Build a basic message with headers. A more robust approach might include
-	// advanced MIME formatting, attachments, multi-part content, etc.
-	msg := buildSMTPMessage(ep, mimeBoundary, e.config.FromAddress)
+	// Tag the message with the API request that sent it.
+	if requestID := models.RequestIDFrom(ctx); requestID != "" {
+		ep = withRequestIDHeader(ep, requestID)
+	}
+
+	// Build the MIME message, with its body and attachments.
+	msg, err := buildSMTPMessage(ep, contentType, e.config.FromAddress)
+	if err != nil {
+		return err
+	}
 
 	// Step 5: Send with retry mechanism. We'll attempt up to maxRetries times,
-	// subject to context cancellation.
+	// subject to context cancellation. A failed attempt discards its connection,
+	// so the next attempt runs on a fresh or known-healthy session.
 	var sendErr error
 	for i := 0; i < maxRetries; i++ {
 		if ctx.Err() != nil {
@@ -239,32 +494,24 @@ func (e *EmailAdapter) sendEmailWithContext(ctx context.Context, ep *EmailPayloa
 			sendErr = ctx.Err()
 			break
 		}
+		if i > 0 && !allowRetry(e.retryBudget) {
+			// Retrying now would amplify load on a struggling server; fail fast.
+			sendErr = fmt.Errorf("%w: %w", models.ErrRetryBudgetExhausted, sendErr)
+			break
+		}
 
-		// Re-verify that the smtpClient is indeed connected here if needed,
-		// or re-dial if a persistent connection is lost.
-		sendErr = smtpClient.Mail(e.config.FromAddress)
-		if sendErr == nil {
-			// Loop through recipients
-			for _, recipient := range ep.To {
-				if err := smtpClient.Rcpt(recipient); err != nil {
-					sendErr = err
-					break
-				}
-			}
-			if sendErr == nil {
-				writer, dataErr := smtpClient.Data()
-				if dataErr != nil {
-					sendErr = dataErr
-				} else {
-					_, writeErr := writer.Write([]byte(msg))
-					closeErr := writer.Close()
-					if writeErr != nil {
-						sendErr = writeErr
-					} else if closeErr != nil {
-						sendErr = closeErr
-					}
-				}
+		conn, acquireErr := pool.acquire(ctx)
+		if acquireErr != nil {
+			sendErr = acquireErr
+			if errors.Is(acquireErr, ErrSMTPPoolClosed) {
+				// The pool was replaced by a credential rotation; retry on the new one.
+				pool = e.currentPool()
+				continue
 			}
+		} else {
+			sendErr = pool.send(conn, e.config.FromAddress, ep.envelopeRecipients(), msg)
+			// Step 6: Return the connection to the pool; failed sessions are closed.
+			pool.release(conn, sendErr == nil)
 		}
 
 		// If sendErr is nil at this point, it indicates success, so we can break.
@@ -274,19 +521,25 @@ func (e *EmailAdapter) sendEmailWithContext(ctx context.Context, ep *EmailPayloa
 		time.Sleep(500 * time.Millisecond) // brief backoff
 	}
 
-	// Step 6: The connection is deferred to be returned to pool above.
 
 	// Step 7: Update metrics and status if sending was successful.
 	if sendErr == nil {
 		e.mu.Lock()
 		e.lastSync = time.Now()
 		e.mu.Unlock()
+		e.sent.record(ep.MessageID)
 	}
 
 	// Step 8: Return success or last encountered error.
 	return sendErr
 }
 
+// ReceiveInboundEmail implements models.InboundEmailReceiver, matching a reply
+// or bounce read from the inbound mailbox to a message this adapter sent.
+func (e *EmailAdapter) ReceiveInboundEmail(ctx context.Context, email *models.InboundEmail) bool {
+	return e.sent.correlate(email)
+}
+
 // Status satisfies the models.Integration interface method signature,
 // returning a high-level status about the adapter's health. Because
 // the specification calls for a context-based approach, we internally
@@ -309,9 +562,9 @@ func (e *EmailAdapter) Status() (models.IntegrationStatus, error) {
 // 5. Include performance metrics
 // 6. Return status and error if any
 func (e *EmailAdapter) statusWithContext(ctx context.Context) (models.IntegrationStatus, error) {
-	// Steps 1 & 2: Basic sync.Pool does not track usage counts natively, so we demonstrate
-	// a placeholder approach. We'll claim the integration is "Connected" if it has been
-	// initialized and no context errors are present.
+	// Steps 1 & 2: We'll claim the integration is "Connected" if it has been
+	// initialized and no context errors are present; pool occupancy and
+	// per-connection send counters are reported in the metadata below.
 	status := models.IntegrationStatus{
 		Connected: true,
 		Name:      "EmailAdapter",
@@ -324,17 +577,33 @@ func (e *EmailAdapter) statusWithContext(ctx context.Context) (models.Integratio
 		status.Connected = false
 	}
 
-	// Step 3: Check TLS status. If UseTLS is disabled, we note that in the metadata.
-	tlsActive := e.config.UseTLS
-	// If additional TLS checks or advanced certificate verifications are performed,
-	// they should be reflected here. For demonstration, we store a simple boolean.
+	// Step 3: Check TLS status: the transport security mode connections use.
+	tlsMode := e.config.SMTPTLSMode()
 	status.Metadata = map[string]interface{}{
-		"tlsActive": tlsActive,
+		"tlsActive": tlsMode != config.SMTPTLSNone,
+		"tlsMode":   tlsMode,
 	}
 
 	// Potentially attach a stub for ConnectionMetadata from the specification.
 	// In a real scenario, we might store connection details or usage statistics.
 	status.Metadata["connection"] = models.ConnectionMetadata{}
+	status.Metadata["smtpPool"] = e.currentPool().Stats()
+
+	// Report the server new connections go to and, after a failover, why the
+	// servers before it were skipped.
+	e.mu.Lock()
+	failover := e.failover
+	e.mu.Unlock()
+	activeHost, skipped := failover.activeHost()
+	status.Metadata["activeHost"] = activeHost
+	if skipped != nil {
+		status.Metadata["failoverReason"] = skipped.Error()
+	}
+
+	// Replies and bounces correlated from the inbound mailbox.
+	if e.sent != nil {
+		status.Metadata["inbound"] = e.sent.stats()
+	}
 
 	// Step 4: Required fields are partially set. We'll enforce a healthy or unhealthy state
 	// based on the context state or other internal checks.
@@ -362,12 +631,12 @@ func (e *EmailAdapter) testConnectionWithTimeout(ctx context.Context) error {
 	done := make(chan error, 1)
 
 	go func() {
-		// Build a trial connection by calling our sync.Pool's New function directly,
+		// Build a trial connection by calling the pool's dialer directly,
 		// ensuring that we can dial the SMTP server and authenticate if needed.
-		rawConn := e.clientPool.New()
-		client, ok := rawConn.(*smtp.Client)
-		if !ok || client == nil {
-			done <- models.ErrConnectionFailed
+		// The caller holds e.mu, so the pool is read directly.
+		client, err := e.clientPool.dial()
+		if err != nil {
+			done <- err
 			return
 		}
 		// Immediately close the client after successful creation/test to keep the pool minimal.
@@ -385,69 +654,227 @@ func (e *EmailAdapter) testConnectionWithTimeout(ctx context.Context) error {
 	}
 }
 
-// newSMTPClientConnection is invoked by the sync.Pool to create a brand-new SMTP connection.
-// It accounts for TLS usage, authentication, and other advanced configuration details.
-func newSMTPClientConnection(cfg *config.EmailConfig, tlsCfg *config.TLSConfig) *smtp.Client {
-	address := cfg.Host
-	if cfg.Port != 0 {
-		address = address + ":" + intToString(cfg.Port)
+// TestConnection implements models.ConnectionTester, opening a new session to
+// the SMTP server, logging in and sending NOOP. The session is dialled fresh,
+// as pooled sessions were authenticated before any rotation.
+func (e *EmailAdapter) TestConnection(ctx context.Context) (map[string]interface{}, error) {
+	e.mu.Lock()
+	failover := e.failover
+	e.mu.Unlock()
+
+	done := make(chan error, 1)
+	go func() {
+		client, err := failover.dial()
+		if err != nil {
+			done <- err
+			return
+		}
+		err = client.Noop()
+		_ = client.Quit()
+		done <- err
+	}()
+
+	var err error
+	select {
+	case <-ctx.Done():
+		err = ctx.Err()
+	case err = <-done:
+	}
+
+	server, skipped := failover.activeHost()
+	details := map[string]interface{}{"server": server}
+	if skipped != nil {
+		details["skippedServers"] = skipped.Error()
+	}
+	if err != nil {
+		return details, fmt.Errorf("%w: %v", models.ErrConnectionFailed, err)
 	}
+	return details, nil
+}
 
-	// In a real enterprise-grade solution, we'd handle STARTTLS or wrap the connection
-	// with appropriate certificate checks. The placeholder below demonstrates a simple case.
-	var connErr error
-	var tlsConn *tls.Conn
-	var tcpConn interface{}
+// newSMTPClientPool creates a bounded pool whose connections are dialled and
+// authenticated with the given configuration, failing over from the primary
+// server to the configured fallbacks, along with the dialer reporting which
+// server is in use.
+func newSMTPClientPool(cfg *config.EmailConfig, tlsCfg *config.TLSConfig) (*smtpPool, *smtpFailover) {
+	failover := newSMTPFailover(cfg, tlsCfg)
+	return newSMTPPool(cfg.Pool, failover.dial), failover
+}
 
-	if cfg.UseTLS {
-		tlsConfig := &tls.Config{
-			InsecureSkipVerify: false, // Should be used carefully in production
-			ServerName:         cfg.Host,
-		}
-		if tlsCfg != nil {
-			// Additional fields from tlsCfg can be integrated here if provided.
+// newSMTPClientConnection is invoked by the pool's dialer to create a brand-new SMTP connection.
+// It secures the connection according to the TLS mode (implicit TLS, STARTTLS, or none)
+// and authenticates with auth when it is not nil.
+func newSMTPClientConnection(cfg *config.EmailConfig, tlsCfg *config.TLSConfig, auth smtp.Auth) (*smtp.Client, error) {
+	address := net.JoinHostPort(cfg.Host, intToString(cfg.Port))
+	mode := cfg.SMTPTLSMode()
+
+	var tlsConfig *tls.Config
+	if mode != config.SMTPTLSNone {
+		var err error
+		if tlsConfig, err = buildSMTPTLSConfig(cfg.Host, tlsCfg); err != nil {
+			return nil, err
 		}
-		tlsConn, connErr = tls.Dial("tcp", address, tlsConfig)
-		if connErr != nil {
-			return nil
+	}
+
+	conn, err := net.DialTimeout("tcp", address, defaultTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", models.ErrConnectionFailed, err)
+	}
+	if mode == config.SMTPTLSImplicit {
+		tlsConn := tls.Client(conn, tlsConfig)
+		_ = tlsConn.SetDeadline(time.Now().Add(defaultTimeout))
+		if err := tlsConn.Handshake(); err != nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("%w: tls handshake: %v", models.ErrConnectionFailed, err)
 		}
-		tcpConn = tlsConn
-	} else {
-		// For non-TLS usage, an unencrypted connection would be established;
-		// this is generally not recommended in production environments.
-		// We'll skip actual dial logic here for brevity, but normally you would net.Dial.
-		return nil
+		_ = tlsConn.SetDeadline(time.Time{})
+		conn = tlsConn
 	}
 
 	// Create an SMTP client from the established connection.
-	client, err := smtp.NewClient(tcpConn.(interface{ Close() error }), cfg.Host)
+	client, err := smtp.NewClient(conn, cfg.Host)
 	if err != nil {
-		return nil
+		_ = conn.Close()
+		return nil, fmt.Errorf("%w: %v", models.ErrConnectionFailed, err)
+	}
+
+	// Upgrade with STARTTLS before any credentials are sent. A server that does
+	// not offer it is refused rather than silently used in plaintext.
+	if mode == config.SMTPTLSStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			_ = client.Close()
+			return nil, ErrSTARTTLSUnsupported
+		}
+		if err := client.StartTLS(tlsConfig); err != nil {
+			_ = client.Close()
+			return nil, fmt.Errorf("%w: starttls: %v", models.ErrConnectionFailed, err)
+		}
 	}
 
 	// If the server requires authentication, set it up.
-	if cfg.RequireAuth {
-		auth := smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+	if auth != nil {
 		if err = client.Auth(auth); err != nil {
 			_ = client.Close()
-			return nil
+			return nil, fmt.Errorf("%w: smtp auth: %v", models.ErrConnectionFailed, err)
+		}
+	}
+	return client, nil
+}
+
+// buildSMTPTLSConfig creates the TLS configuration for connections to host:
+// TLS 1.2 or the configured minimum, verification against the system roots
+// plus any configured CA bundle, and the configured public key pins.
+func buildSMTPTLSConfig(host string, tlsCfg *config.TLSConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		ServerName: host,
+		MinVersion: tls.VersionTLS12,
+	}
+	if tlsCfg == nil {
+		return tlsConfig, nil
+	}
+
+	if tlsCfg.ServerName != "" {
+		tlsConfig.ServerName = tlsCfg.ServerName
+	}
+	if tlsCfg.MinVersion == "1.3" {
+		tlsConfig.MinVersion = tls.VersionTLS13
+	}
+	if tlsCfg.CAFile != "" {
+		data, err := os.ReadFile(tlsCfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("read smtp ca file: %w", err)
+		}
+		roots, err := x509.SystemCertPool()
+		if err != nil {
+			roots = x509.NewCertPool()
+		}
+		if !roots.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("smtp ca file %s holds no PEM certificates", tlsCfg.CAFile)
+		}
+		tlsConfig.RootCAs = roots
+	}
+	if len(tlsCfg.PinnedPublicKeys) > 0 {
+		pins := make(map[string]bool, len(tlsCfg.PinnedPublicKeys))
+		for _, pin := range tlsCfg.PinnedPublicKeys {
+			pins[pin] = true
+		}
+		// VerifyConnection runs after normal verification, so pinning narrows
+		// the trusted certificates rather than replacing verification.
+		tlsConfig.VerifyConnection = func(state tls.ConnectionState) error {
+			for _, cert := range state.PeerCertificates {
+				digest := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+				if pins[base64.StdEncoding.EncodeToString(digest[:])] {
+					return nil
+				}
+			}
+			return ErrSMTPPinMismatch
 		}
 	}
-	return client
-}
-
-// buildSMTPMessage constructs a raw email message string, including
-// minimal headers (From, To, Subject) and the body content. A robust
-// implementation would handle MIME boundaries, attachments, etc.
-func buildSMTPMessage(ep *EmailPayload, contentType string, fromAddress string) string {
-	// Basic RFC5322 headers
-	message := "From: " + fromAddress + "\r\n"
-	message += "To: " + sliceToCommaString(ep.To) + "\r\n"
-	message += "Subject: " + ep.Subject + "\r\n"
-	message += "MIME-Version: 1.0\r\n"
-	message += "Content-Type: " + contentType + "; charset=\"UTF-8\"\r\n"
-	message += "\r\n" + ep.Body
-	return message
+	return tlsConfig, nil
+}
+
+// buildSMTPMessage constructs a raw email message, including the From, To
+// and Subject headers (non-ASCII subjects are RFC 2047 encoded) and a MIME
+// body: a single part when there are no attachments, otherwise multipart/mixed
+// and multipart/related entities around the body. HTML bodies are always
+// preceded by a plain-text alternative, which strict clients and spam filters
+// expect, generated from the HTML when the payload supplies none.
+func buildSMTPMessage(ep *EmailPayload, contentType string, fromAddress string) ([]byte, error) {
+	text, htmlBody := ep.Body, ep.HTML
+	if htmlBody == "" && isHTMLContentType(contentType) {
+		text, htmlBody = "", ep.Body
+	}
+	if text == "" && htmlBody != "" {
+		text = htmlToText(htmlBody)
+		contentType = defaultContentType
+	}
+
+	plain, err := textEntity(contentType, text)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", models.ErrInvalidPayload, err)
+	}
+	alternatives := []mimeEntity{plain}
+	if htmlBody != "" {
+		rich, err := textEntity("text/html", htmlBody)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", models.ErrInvalidPayload, err)
+		}
+		alternatives = append(alternatives, rich)
+	}
+	body, err := bodyEntity(alternatives)
+	if err != nil {
+		return nil, err
+	}
+	entity, err := messageEntity(body, ep.Attachments)
+	if err != nil {
+		return nil, err
+	}
+
+	// RFC 5322 headers. Bcc recipients are only in the envelope; a message
+	// with none in To or Cc is addressed to an empty group, as mail clients do.
+	to := sliceToCommaString(ep.To)
+	if to == "" && len(ep.Cc) == 0 {
+		to = "undisclosed-recipients:;"
+	}
+	headers := [][2]string{{"From", fromAddress}}
+	if to != "" {
+		headers = append(headers, [2]string{"To", to})
+	}
+	if len(ep.Cc) > 0 {
+		headers = append(headers, [2]string{"Cc", sliceToCommaString(ep.Cc)})
+	}
+	if ep.ReplyTo != "" {
+		headers = append(headers, [2]string{"Reply-To", ep.ReplyTo})
+	}
+	headers = append(headers,
+		[2]string{"Subject", mime.QEncoding.Encode("UTF-8", ep.Subject)},
+		[2]string{"Message-ID", ep.MessageID},
+	)
+	extra, err := customHeaders(ep.Headers)
+	if err != nil {
+		return nil, err
+	}
+	return writeMessage(append(headers, extra...), entity), nil
 }
 
 // sliceToCommaString is a helper function that joins a string slice
@@ -0,0 +1,291 @@
+package adapters
+
+import (
+	// go1.21 - Entity assembly and transfer encodings
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/http"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	// Internal models for payload errors
+	"src/backend/services/integration/internal/models"
+)
+
+// mimeLineLength is the line length base64 parts are wrapped at (RFC 2045).
+const mimeLineLength = 76
+
+// EmailAttachment is a file sent with an email. Inline attachments, typically
+// images, are referenced from an HTML body as "cid:" followed by ContentID.
+type EmailAttachment struct {
+	// Filename is the name shown to the recipient.
+	Filename string
+
+	// ContentType is the attachment's MIME type; it is inferred from the
+	// filename's extension, or else the content, when empty.
+	ContentType string
+
+	// Data is the attachment's content.
+	Data []byte
+
+	// Inline displays the attachment within the body rather than as a download.
+	Inline bool
+
+	// ContentID identifies an inline attachment; it defaults to the filename.
+	ContentID string
+}
+
+// AttachFile reads the file at path and attaches it under its base name.
+func (ep *EmailPayload) AttachFile(path string, inline bool) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("attach %s: %w", path, err)
+	}
+	ep.Attachments = append(ep.Attachments, EmailAttachment{
+		Filename: filepath.Base(path),
+		Data:     data,
+		Inline:   inline,
+	})
+	return nil
+}
+
+// envelopeRecipients returns every recipient the message is delivered to: To,
+// Cc and Bcc.
+func (ep *EmailPayload) envelopeRecipients() []string {
+	recipients := make([]string, 0, len(ep.To)+len(ep.Cc)+len(ep.Bcc))
+	recipients = append(recipients, ep.To...)
+	recipients = append(recipients, ep.Cc...)
+	return append(recipients, ep.Bcc...)
+}
+
+// reservedHeaders are set when the message is built and cannot be overridden
+// by EmailPayload.Headers.
+var reservedHeaders = map[string]bool{
+	"Bcc": true, "Cc": true, "Date": true, "From": true, "Message-Id": true,
+	"Mime-Version": true, "Reply-To": true, "Subject": true, "To": true,
+}
+
+// customHeaders validates the payload's additional headers and returns them
+// in name order, encoding non-ASCII values. Values with line breaks are
+// rejected, since they could inject headers.
+func customHeaders(headers map[string]string) ([][2]string, error) {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := make([][2]string, 0, len(names))
+	for _, name := range names {
+		canonical := textproto.CanonicalMIMEHeaderKey(name)
+		if !validHeaderName(name) || reservedHeaders[canonical] || strings.HasPrefix(canonical, "Content-") {
+			return nil, fmt.Errorf("%w: header %q cannot be set", models.ErrInvalidPayload, name)
+		}
+		value := headers[name]
+		if strings.ContainsAny(value, "\r\n") {
+			return nil, fmt.Errorf("%w: header %q contains a line break", models.ErrInvalidPayload, name)
+		}
+		result = append(result, [2]string{canonical, mime.QEncoding.Encode("UTF-8", value)})
+	}
+	return result, nil
+}
+
+// withRequestIDHeader returns a copy of ep with an X-Request-ID header naming
+// requestID, or ep itself when its headers already set one.
+func withRequestIDHeader(ep *EmailPayload, requestID string) *EmailPayload {
+	for name := range ep.Headers {
+		if textproto.CanonicalMIMEHeaderKey(name) == textproto.CanonicalMIMEHeaderKey(models.RequestIDHeader) {
+			return ep
+		}
+	}
+	tagged := *ep
+	tagged.Headers = make(map[string]string, len(ep.Headers)+1)
+	for name, value := range ep.Headers {
+		tagged.Headers[name] = value
+	}
+	tagged.Headers[models.RequestIDHeader] = requestID
+	return &tagged
+}
+
+// validHeaderName reports whether name is a valid header field name: printable
+// ASCII other than space and colon (RFC 5322).
+func validHeaderName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		if c := name[i]; c <= ' ' || c > '~' || c == ':' {
+			return false
+		}
+	}
+	return true
+}
+
+// richestBody returns the payload's HTML when set, and otherwise its Body and
+// ContentType, for providers that take a single body and a type.
+func (ep *EmailPayload) richestBody() (string, string) {
+	if ep.HTML != "" {
+		return ep.HTML, "text/html"
+	}
+	return ep.Body, ep.ContentType
+}
+
+// mimeEntity is a MIME entity: its headers and encoded body.
+type mimeEntity struct {
+	header textproto.MIMEHeader
+	body   []byte
+}
+
+// bodyEntity builds the message body from its alternative renderings, ordered
+// from plainest to richest: a single rendering is sent as is, several as
+// multipart/alternative.
+func bodyEntity(alternatives []mimeEntity) (mimeEntity, error) {
+	if len(alternatives) == 1 {
+		return alternatives[0], nil
+	}
+	return multipartEntity("alternative", alternatives)
+}
+
+// isHTMLContentType reports whether contentType, which may carry parameters,
+// is text/html.
+func isHTMLContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && mediaType == "text/html"
+}
+
+// textEntity encodes text content of the given type as quoted-printable UTF-8.
+func textEntity(contentType, content string) (mimeEntity, error) {
+	var buf bytes.Buffer
+	qp := quotedprintable.NewWriter(&buf)
+	if _, err := qp.Write([]byte(content)); err != nil {
+		return mimeEntity{}, err
+	}
+	if err := qp.Close(); err != nil {
+		return mimeEntity{}, err
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = defaultContentType
+	}
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Type", mime.FormatMediaType(mediaType, map[string]string{"charset": "UTF-8"}))
+	header.Set("Content-Transfer-Encoding", "quoted-printable")
+	return mimeEntity{header: header, body: buf.Bytes()}, nil
+}
+
+// attachmentEntity encodes an attachment as base64 with its disposition.
+func attachmentEntity(a *EmailAttachment) (mimeEntity, error) {
+	if a.Filename == "" {
+		return mimeEntity{}, fmt.Errorf("%w: attachment has no filename", models.ErrInvalidPayload)
+	}
+	contentType := a.ContentType
+	if contentType == "" {
+		contentType = mime.TypeByExtension(filepath.Ext(a.Filename))
+	}
+	if contentType == "" {
+		contentType = http.DetectContentType(a.Data)
+	}
+
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Type", contentType)
+	header.Set("Content-Transfer-Encoding", "base64")
+	disposition := "attachment"
+	if a.Inline {
+		disposition = "inline"
+		header.Set("Content-ID", "<"+firstNonEmpty(a.ContentID, a.Filename)+">")
+	}
+	header.Set("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{"filename": a.Filename}))
+
+	encoded := base64.StdEncoding.EncodeToString(a.Data)
+	var buf bytes.Buffer
+	for len(encoded) > mimeLineLength {
+		buf.WriteString(encoded[:mimeLineLength])
+		buf.WriteString("\r\n")
+		encoded = encoded[mimeLineLength:]
+	}
+	buf.WriteString(encoded)
+	return mimeEntity{header: header, body: buf.Bytes()}, nil
+}
+
+// multipartEntity joins parts into a multipart entity of the given subtype.
+func multipartEntity(subtype string, parts []mimeEntity) (mimeEntity, error) {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	for _, part := range parts {
+		w, err := mw.CreatePart(part.header)
+		if err != nil {
+			return mimeEntity{}, err
+		}
+		if _, err := w.Write(part.body); err != nil {
+			return mimeEntity{}, err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return mimeEntity{}, err
+	}
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Type", mime.FormatMediaType("multipart/"+subtype, map[string]string{"boundary": mw.Boundary()}))
+	return mimeEntity{header: header, body: buf.Bytes()}, nil
+}
+
+// messageEntity nests the body and attachments as mail clients expect:
+// inline attachments beside the body in multipart/related, and other
+// attachments beside that in multipart/mixed.
+func messageEntity(body mimeEntity, attachments []EmailAttachment) (mimeEntity, error) {
+	var inline, attached []mimeEntity
+	for i := range attachments {
+		part, err := attachmentEntity(&attachments[i])
+		if err != nil {
+			return mimeEntity{}, err
+		}
+		if attachments[i].Inline {
+			inline = append(inline, part)
+		} else {
+			attached = append(attached, part)
+		}
+	}
+
+	entity := body
+	var err error
+	if len(inline) > 0 {
+		if entity, err = multipartEntity("related", append([]mimeEntity{entity}, inline...)); err != nil {
+			return mimeEntity{}, err
+		}
+	}
+	if len(attached) > 0 {
+		if entity, err = multipartEntity("mixed", append([]mimeEntity{entity}, attached...)); err != nil {
+			return mimeEntity{}, err
+		}
+	}
+	return entity, nil
+}
+
+// writeMessage renders the top-level headers followed by entity.
+func writeMessage(headers [][2]string, entity mimeEntity) []byte {
+	var buf bytes.Buffer
+	for _, h := range headers {
+		buf.WriteString(h[0] + ": " + h[1] + "\r\n")
+	}
+	buf.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	buf.WriteString("MIME-Version: 1.0\r\n")
+
+	keys := make([]string, 0, len(entity.header))
+	for key := range entity.header {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		buf.WriteString(key + ": " + strings.Join(entity.header[key], ", ") + "\r\n")
+	}
+	buf.WriteString("\r\n")
+	buf.Write(entity.body)
+	return buf.Bytes()
+}
@@ -0,0 +1,112 @@
+package adapters
+
+import (
+	// go1.21 - Token source context and error wrapping
+	"context"
+	"errors"
+	"fmt"
+	// go1.21 - SASL mechanism interface and loopback detection
+	"net"
+	"net/smtp"
+
+	// v0.13.0 - Access token acquisition and refresh
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+
+	// Internal imports for configuration and transport
+	"src/backend/services/integration/internal/config"
+	"src/backend/services/integration/internal/httpclient"
+)
+
+// ErrSMTPAuthUnencrypted is returned when credentials would be sent to a
+// remote SMTP server over a connection without TLS.
+var ErrSMTPAuthUnencrypted = errors.New("smtp: refusing to authenticate over an unencrypted connection")
+
+// newSMTPAuth returns the authentication for connections made with cfg: none
+// unless the server requires it, XOAUTH2 when OAuth2 is configured, and PLAIN
+// with the password otherwise. The returned value is shared by every
+// connection of a pool, so XOAUTH2 access tokens are reused until they expire.
+func newSMTPAuth(cfg *config.EmailConfig) smtp.Auth {
+	switch {
+	case !cfg.RequireAuth:
+		return nil
+	case cfg.OAuth2 != nil:
+		return &xoauth2Auth{
+			username: cfg.Username,
+			host:     cfg.Host,
+			tokens:   newSMTPTokenSource(cfg.OAuth2),
+		}
+	default:
+		return smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+	}
+}
+
+// newSMTPTokenSource returns a caching source of access tokens for oc: tokens
+// are refreshed from the refresh token when one is configured, and otherwise
+// requested with the client-credentials grant, shortly before each expires.
+func newSMTPTokenSource(oc *config.SMTPOAuth2Config) oauth2.TokenSource {
+	// Token requests happen as connections are dialled, outliving any one
+	// caller's context, so the source gets a context of its own.
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, httpclient.Default().ClientWithTimeout(defaultTimeout))
+	if oc.RefreshToken != "" {
+		oauthConfig := &oauth2.Config{
+			ClientID:     oc.ClientID,
+			ClientSecret: oc.ClientSecret,
+			Endpoint:     oauth2.Endpoint{TokenURL: oc.TokenURL},
+			Scopes:       oc.Scopes,
+		}
+		return oauthConfig.TokenSource(ctx, &oauth2.Token{RefreshToken: oc.RefreshToken})
+	}
+	ccConfig := &clientcredentials.Config{
+		ClientID:     oc.ClientID,
+		ClientSecret: oc.ClientSecret,
+		TokenURL:     oc.TokenURL,
+		Scopes:       oc.Scopes,
+		AuthStyle:    oauth2.AuthStyleInParams,
+	}
+	return ccConfig.TokenSource(ctx)
+}
+
+// xoauth2Auth implements the XOAUTH2 SASL mechanism used by Gmail and
+// Office 365, presenting an OAuth access token for username.
+type xoauth2Auth struct {
+	username string
+	host     string
+	tokens   oauth2.TokenSource
+}
+
+// Start implements smtp.Auth. Like PLAIN authentication, the token is only
+// sent over TLS or to localhost.
+func (a *xoauth2Auth) Start(server *smtp.ServerInfo) (string, []byte, error) {
+	if !server.TLS && !isLoopbackHost(server.Name) {
+		return "", nil, ErrSMTPAuthUnencrypted
+	}
+	if server.Name != a.host {
+		return "", nil, errors.New("smtp: wrong host name")
+	}
+	token, err := a.tokens.Token()
+	if err != nil {
+		return "", nil, fmt.Errorf("smtp oauth token: %w", err)
+	}
+	resp := "user=" + a.username + "\x01auth=" + token.Type() + " " + token.AccessToken + "\x01\x01"
+	return "XOAUTH2", []byte(resp), nil
+}
+
+// Next implements smtp.Auth. A challenge after the initial response carries
+// the server's error details; an empty reply lets the server complete the
+// exchange with its failure status, which the client returns.
+func (a *xoauth2Auth) Next(fromServer []byte, more bool) ([]byte, error) {
+	if more {
+		return []byte{}, nil
+	}
+	return nil, nil
+}
+
+// isLoopbackHost reports whether host names this machine.
+func isLoopbackHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,147 @@
+package adapters
+
+import (
+	// go1.21 - Text assembly
+	"strings"
+
+	// v0.17.0 - HTML tokenization for the plain-text alternative
+	"golang.org/x/net/html"
+)
+
+// htmlBlockElements start a new line in the plain-text rendering.
+var htmlBlockElements = map[string]bool{
+	"address": true, "article": true, "aside": true, "blockquote": true, "div": true,
+	"dl": true, "dt": true, "dd": true, "footer": true, "form": true, "h1": true,
+	"h2": true, "h3": true, "h4": true, "h5": true, "h6": true, "header": true,
+	"hr": true, "li": true, "main": true, "nav": true, "ol": true, "p": true,
+	"pre": true, "section": true, "table": true, "tr": true, "ul": true,
+}
+
+// htmlSkippedElements have content that is not shown to readers.
+var htmlSkippedElements = map[string]bool{
+	"head": true, "script": true, "style": true, "template": true, "title": true,
+}
+
+// htmlToText renders an HTML body as plain text for the text/plain
+// alternative: block elements and line breaks become newlines, list items are
+// bulleted, links are followed by their URL, images are replaced by their alt
+// text, and other whitespace is collapsed.
+func htmlToText(source string) string {
+	var out strings.Builder
+	var links []string
+	skip, pre := 0, 0
+	newline := func() {
+		text := out.String()
+		if text != "" && !strings.HasSuffix(text, "\n") {
+			out.WriteByte('\n')
+		}
+	}
+	space := func() {
+		text := out.String()
+		if text != "" && !strings.HasSuffix(text, " ") && !strings.HasSuffix(text, "\n") {
+			out.WriteByte(' ')
+		}
+	}
+
+	z := html.NewTokenizer(strings.NewReader(source))
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			break
+		}
+		token := z.Token()
+		switch tt {
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name := token.Data
+			if htmlSkippedElements[name] {
+				if tt == html.StartTagToken {
+					skip++
+				}
+				continue
+			}
+			switch {
+			case name == "br":
+				out.WriteByte('\n')
+			case name == "li":
+				newline()
+				out.WriteString("- ")
+			case name == "img":
+				if alt := htmlAttr(token, "alt"); alt != "" {
+					space()
+					out.WriteString(alt)
+				}
+			case name == "a":
+				links = append(links, htmlAttr(token, "href"))
+			case name == "pre":
+				newline()
+				pre++
+			case name == "td" || name == "th":
+				space()
+			case htmlBlockElements[name]:
+				newline()
+			}
+		case html.EndTagToken:
+			name := token.Data
+			switch {
+			case htmlSkippedElements[name]:
+				if skip > 0 {
+					skip--
+				}
+			case name == "a" && len(links) > 0:
+				href := links[len(links)-1]
+				links = links[:len(links)-1]
+				if href != "" && !strings.HasPrefix(href, "#") && !strings.HasPrefix(href, "mailto:") {
+					out.WriteString(" (" + href + ")")
+				}
+			case name == "pre":
+				if pre > 0 {
+					pre--
+				}
+				newline()
+			case htmlBlockElements[name]:
+				newline()
+			}
+		case html.TextToken:
+			if skip > 0 {
+				continue
+			}
+			if pre > 0 {
+				out.WriteString(token.Data)
+				continue
+			}
+			words := strings.Fields(token.Data)
+			if len(words) == 0 {
+				continue
+			}
+			if strings.TrimLeft(token.Data, " \t\r\n") != token.Data {
+				space()
+			}
+			out.WriteString(strings.Join(words, " "))
+			if strings.TrimRight(token.Data, " \t\r\n") != token.Data {
+				out.WriteByte(' ')
+			}
+		}
+	}
+
+	// Trim trailing spaces from each line and collapse runs of blank lines.
+	lines := strings.Split(out.String(), "\n")
+	kept := make([]string, 0, len(lines))
+	for _, line := range lines {
+		line = strings.TrimRight(line, " ")
+		if line == "" && (len(kept) == 0 || kept[len(kept)-1] == "") {
+			continue
+		}
+		kept = append(kept, line)
+	}
+	return strings.TrimSpace(strings.Join(kept, "\n"))
+}
+
+// htmlAttr returns the value of the named attribute of token.
+func htmlAttr(token html.Token, name string) string {
+	for _, attr := range token.Attr {
+		if attr.Key == name {
+			return attr.Val
+		}
+	}
+	return ""
+}
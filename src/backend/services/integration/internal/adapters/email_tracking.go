@@ -0,0 +1,143 @@
+package adapters
+
+import (
+	// go1.21 - Message-ID generation
+	"crypto/rand"
+	"encoding/hex"
+	"os"
+	"strings"
+	// go1.21 - Guards the log shared by concurrent sends and the poller
+	"sync"
+	"time"
+
+	// Internal models for the inbound email contract
+	"src/backend/services/integration/internal/models"
+)
+
+// maxTrackedMessages caps the sent messages remembered for correlation; the
+// oldest are forgotten first.
+const maxTrackedMessages = 100000
+
+// newMessageID returns a unique Message-ID in the sender's domain, or the
+// host name when the sender has none.
+func newMessageID(from string) string {
+	var random [16]byte
+	_, _ = rand.Read(random[:])
+	domain := ""
+	if at := strings.LastIndex(from, "@"); at >= 0 {
+		domain = strings.TrimSuffix(from[at+1:], ">")
+	}
+	if domain == "" {
+		domain, _ = os.Hostname()
+	}
+	return "<" + hex.EncodeToString(random[:]) + "@" + firstNonEmpty(domain, "localhost") + ">"
+}
+
+// sentMessage is a sent message remembered for correlation.
+type sentMessage struct {
+	sentAt  time.Time
+	bounced bool
+	replied bool
+}
+
+// sentMessageLog remembers the Message-IDs of sent messages for a retention
+// window, so replies and bounces read from the mailbox can be matched to
+// them, and counts the outcomes for the bounce rate.
+type sentMessageLog struct {
+	retention time.Duration
+
+	mu       sync.Mutex
+	messages map[string]*sentMessage
+	order    []string
+	sent     uint64
+	bounced  uint64
+	replied  uint64
+}
+
+// newSentMessageLog creates a log remembering messages for retention.
+func newSentMessageLog(retention time.Duration) *sentMessageLog {
+	return &sentMessageLog{
+		retention: retention,
+		messages:  make(map[string]*sentMessage),
+	}
+}
+
+// record remembers a sent message. A nil log records nothing.
+func (l *sentMessageLog) record(messageID string) {
+	if l == nil || messageID == "" {
+		return
+	}
+	now := time.Now()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.expire(now)
+	if _, exists := l.messages[messageID]; exists {
+		return
+	}
+	l.messages[messageID] = &sentMessage{sentAt: now}
+	l.order = append(l.order, messageID)
+	l.sent++
+}
+
+// expire forgets messages older than the retention window or beyond the cap.
+// The caller holds l.mu.
+func (l *sentMessageLog) expire(now time.Time) {
+	drop := 0
+	for drop < len(l.order) {
+		msg := l.messages[l.order[drop]]
+		if len(l.order)-drop < maxTrackedMessages && now.Sub(msg.sentAt) < l.retention {
+			break
+		}
+		delete(l.messages, l.order[drop])
+		drop++
+	}
+	l.order = l.order[drop:]
+}
+
+// correlate marks the sent message email references as bounced or replied
+// to, reporting whether it references one. Each message counts once towards
+// the bounce rate however many of its recipients bounce.
+func (l *sentMessageLog) correlate(email *models.InboundEmail) bool {
+	if l == nil {
+		return false
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, id := range email.References {
+		msg, exists := l.messages[id]
+		if !exists {
+			continue
+		}
+		switch email.Kind {
+		case models.InboundEmailBounce:
+			if !msg.bounced {
+				msg.bounced = true
+				l.bounced++
+			}
+		case models.InboundEmailReply:
+			if !msg.replied {
+				msg.replied = true
+				l.replied++
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// stats returns the counts reported in the adapter's status metadata.
+func (l *sentMessageLog) stats() map[string]interface{} {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	bounceRate := 0.0
+	if l.sent > 0 {
+		bounceRate = float64(l.bounced) / float64(l.sent)
+	}
+	return map[string]interface{}{
+		"tracked":    len(l.messages),
+		"sent":       l.sent,
+		"bounced":    l.bounced,
+		"replied":    l.replied,
+		"bounceRate": bounceRate,
+	}
+}
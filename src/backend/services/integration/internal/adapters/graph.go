@@ -0,0 +1,466 @@
+package adapters
+
+import (
+	// go1.21 - Context for cancellations and timeouts
+	"context"
+	// go1.21 - Sentinel errors and error wrapping
+	"errors"
+	"fmt"
+	// go1.21 - Request construction and mailbox escaping
+	"net/http"
+	"net/url"
+	"strings"
+	// go1.21 - Guards the client during rotation
+	"sync"
+	"time"
+
+	// v0.13.0 - Client-credentials tokens for the app registration
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+	// v0.1.0 - Token bucket rate limiting under Graph's mailbox limits
+	"golang.org/x/time/rate"
+
+	// Internal imports for configuration, transport and adapter contracts
+	"src/backend/services/integration/internal/config"
+	"src/backend/services/integration/internal/httpclient"
+	"src/backend/services/integration/internal/models"
+)
+
+// graphEventTimeLayout is the layout of Graph's dateTimeTimeZone values, which
+// carry the zone separately.
+const graphEventTimeLayout = "2006-01-02T15:04:05"
+
+// ErrInvalidGraphConfig indicates that the Graph configuration is missing the
+// tenant, app credentials or default mailbox.
+var ErrInvalidGraphConfig = errors.New("invalid graph configuration or missing required fields")
+
+// GraphMessage is mail to send, or with Event set, a calendar event to create.
+// Send also accepts an EmailPayload, alone or in the context wrapper used for
+// the SMTP adapter, so Graph can stand in for SMTP.
+type GraphMessage struct {
+	// Mailbox overrides the configured mailbox that sends the mail or owns the event.
+	Mailbox string `json:"mailbox,omitempty"`
+
+	// To, Cc and Bcc are the mail recipients; To is required for mail.
+	To  []string `json:"to,omitempty"`
+	Cc  []string `json:"cc,omitempty"`
+	Bcc []string `json:"bcc,omitempty"`
+
+	// Subject is required for mail; Body is the mail body.
+	Subject string `json:"subject,omitempty"`
+	Body    string `json:"body,omitempty"`
+
+	// ContentType is text/plain (the default) or text/html.
+	ContentType string `json:"contentType,omitempty"`
+
+	// Event, when set, creates a calendar event instead of sending mail.
+	Event *GraphEvent `json:"event,omitempty"`
+}
+
+// GraphEvent is a calendar event. Graph sends invitations to its attendees.
+type GraphEvent struct {
+	// Subject is required; Body is the event description.
+	Subject string `json:"subject"`
+	Body    string `json:"body,omitempty"`
+
+	// Start and End are required, and End must follow Start.
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+
+	// TimeZone overrides the configured IANA time zone of the event.
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// Location is the display name of the event's location.
+	Location string `json:"location,omitempty"`
+
+	// Attendees are invited as required attendees.
+	Attendees []string `json:"attendees,omitempty"`
+
+	// OnlineMeeting adds a Teams meeting to the event.
+	OnlineMeeting bool `json:"onlineMeeting,omitempty"`
+}
+
+// graphRecipient is a recipient or attendee in a Graph request.
+type graphRecipient struct {
+	EmailAddress struct {
+		Address string `json:"address"`
+	} `json:"emailAddress"`
+	Type string `json:"type,omitempty"`
+}
+
+// GraphAdapter implements the Integration interface for Microsoft Graph,
+// sending Outlook mail and creating calendar events as an app registration.
+// It suits tenants that block SMTP authentication.
+type GraphAdapter struct {
+	// mu guards the fields below, which change on initialization and rotation.
+	mu          sync.RWMutex
+	config      *config.GraphConfig
+	client      *restClient
+	connected   bool
+	lastSync    time.Time
+	lastEvent   string
+	mailSent    int
+	eventsMade  int
+	initialized bool
+
+	// guard applies the circuit breaker, rate limiter and retries to each call.
+	guard *restGuard
+}
+
+// Compile-time check to ensure GraphAdapter implements the Integration interface.
+var _ models.Integration = (*GraphAdapter)(nil)
+
+// Compile-time check to ensure GraphAdapter supports credential rotation.
+var _ models.CredentialRotator = (*GraphAdapter)(nil)
+
+// Compile-time check to ensure GraphAdapter reports its circuit state.
+var _ models.CircuitReporter = (*GraphAdapter)(nil)
+
+// Compile-time check to ensure GraphAdapter's circuit can be reset by operators.
+var _ models.CircuitResetter = (*GraphAdapter)(nil)
+
+// Compile-time check to ensure GraphAdapter reports its circuit's state and counters.
+var _ models.CircuitInspector = (*GraphAdapter)(nil)
+
+// Compile-time check to ensure GraphAdapter accepts a retry budget.
+var _ models.RetryBudgetUser = (*GraphAdapter)(nil)
+
+// NewGraphAdapter creates an uninitialized Graph adapter. Outlook throttles
+// each mailbox at about ten thousand requests per ten minutes, so the limiter
+// allows ten calls per second with a burst of twenty, backing off to one every
+// ten seconds on 429 responses.
+func NewGraphAdapter() *GraphAdapter {
+	return &GraphAdapter{
+		guard: newRESTGuard(newAdaptiveLimiter(rate.Limit(10), 20, rate.Every(10*time.Second))),
+	}
+}
+
+// Initialize implements the Integration interface, bridging to
+// InitializeWithContext with a background context.
+func (ga *GraphAdapter) Initialize(cfg interface{}) error {
+	return ga.InitializeWithContext(context.Background(), cfg)
+}
+
+// InitializeWithContext configures the adapter from a *config.GraphConfig.
+// Steps:
+//  1. Validate the tenant, app credentials and mailbox.
+//  2. Verify the app credentials by obtaining a token, and build the client.
+func (ga *GraphAdapter) InitializeWithContext(ctx context.Context, cfg interface{}) error {
+	// 1. Configuration.
+	gc, ok := cfg.(*config.GraphConfig)
+	if !ok || gc == nil || gc.TenantID == "" || gc.ClientID == "" || gc.ClientSecret == "" || gc.Mailbox == "" {
+		return ErrInvalidGraphConfig
+	}
+
+	// 2. Client.
+	client, err := newGraphClient(ctx, gc, gc.ClientID, gc.ClientSecret)
+	if err != nil {
+		ga.mu.Lock()
+		ga.connected = false
+		ga.mu.Unlock()
+		return fmt.Errorf("%w: graph credentials: %v", models.ErrConnectionFailed, err)
+	}
+
+	ga.mu.Lock()
+	defer ga.mu.Unlock()
+	ga.config = gc
+	ga.client = client
+	ga.connected = true
+	ga.initialized = true
+	ga.lastSync = time.Now()
+	return nil
+}
+
+// RotateCredentials replaces the app's client secret (and, when Username is
+// set, its client ID) without a restart. The new secret is verified by
+// obtaining a token before it replaces the active client; on failure the
+// adapter keeps its previous client.
+func (ga *GraphAdapter) RotateCredentials(ctx context.Context, creds models.Credentials) error {
+	if creds.Secret == "" {
+		return models.ErrInvalidPayload
+	}
+
+	ga.mu.RLock()
+	gc := ga.config
+	ga.mu.RUnlock()
+	if gc == nil {
+		return models.ErrInitializationFailed
+	}
+
+	client, err := newGraphClient(ctx, gc, firstNonEmpty(creds.Username, gc.ClientID), creds.Secret)
+	if err != nil {
+		return fmt.Errorf("rotated graph credentials rejected: %w", err)
+	}
+
+	ga.mu.Lock()
+	defer ga.mu.Unlock()
+	ga.client = client
+	ga.connected = true
+	return nil
+}
+
+// Send implements the Integration interface. The context wrapper used for the
+// SMTP adapter supplies the context; other payloads are sent with a background
+// context.
+func (ga *GraphAdapter) Send(payload interface{}) error {
+	if container, ok := payload.(struct {
+		Ctx     context.Context
+		Payload *EmailPayload
+	}); ok {
+		if container.Ctx == nil || container.Payload == nil {
+			return models.ErrInvalidPayload
+		}
+		return ga.SendWithContext(container.Ctx, container.Payload)
+	}
+	return ga.SendWithContext(context.Background(), payload)
+}
+
+// SendWithContext sends mail or creates an event. The payload is a
+// GraphMessage or EmailPayload (or a pointer to either), or a map equivalent
+// to a GraphMessage.
+// Steps:
+//  1. Decode the payload.
+//  2. Check recipients or attendees against the allowed domains, and build
+//     the request.
+//  3. POST it to the mailbox's sendMail or events endpoint.
+func (ga *GraphAdapter) SendWithContext(ctx context.Context, payload interface{}) error {
+	ga.mu.RLock()
+	client, gc, initialized := ga.client, ga.config, ga.initialized
+	ga.mu.RUnlock()
+	if !initialized {
+		return models.ErrInitializationFailed
+	}
+
+	// 1. Message.
+	var msg GraphMessage
+	switch p := payload.(type) {
+	case EmailPayload:
+		msg = graphFromEmailPayload(&p)
+	case *EmailPayload:
+		if p == nil {
+			return models.ErrInvalidPayload
+		}
+		msg = graphFromEmailPayload(p)
+	default:
+		if err := decodePayload(payload, &msg); err != nil {
+			return err
+		}
+	}
+	mailbox := "/v1.0/users/" + url.PathEscape(firstNonEmpty(msg.Mailbox, gc.Mailbox))
+
+	// 2. Request.
+	var path string
+	var body interface{}
+	if msg.Event != nil {
+		event, err := buildGraphEvent(gc, msg.Event)
+		if err != nil {
+			return err
+		}
+		path, body = mailbox+"/events", event
+	} else {
+		mail, err := buildGraphMail(gc, &msg)
+		if err != nil {
+			return err
+		}
+		path, body = mailbox+"/sendMail", mail
+	}
+
+	// 3. Send.
+	var created struct {
+		ID      string `json:"id"`
+		WebLink string `json:"webLink"`
+	}
+	err := ga.guard.create(ctx, func(ctx context.Context) error {
+		if msg.Event != nil {
+			return client.doJSON(ctx, http.MethodPost, path, body, &created)
+		}
+		return client.doJSON(ctx, http.MethodPost, path, body, nil)
+	})
+	if err != nil {
+		if msg.Event != nil {
+			return fmt.Errorf("graph create event: %w", err)
+		}
+		return fmt.Errorf("graph send mail: %w", err)
+	}
+
+	ga.mu.Lock()
+	ga.lastSync = time.Now()
+	ga.connected = true
+	if msg.Event != nil {
+		ga.eventsMade++
+		ga.lastEvent = firstNonEmpty(created.WebLink, created.ID)
+	} else {
+		ga.mailSent++
+	}
+	ga.mu.Unlock()
+	return nil
+}
+
+// graphFromEmailPayload converts the SMTP adapter's payload.
+func graphFromEmailPayload(ep *EmailPayload) GraphMessage {
+	body, contentType := ep.richestBody()
+	return GraphMessage{
+		To:          ep.To,
+		Cc:          ep.Cc,
+		Bcc:         ep.Bcc,
+		Subject:     ep.Subject,
+		Body:        body,
+		ContentType: contentType,
+	}
+}
+
+// buildGraphMail validates msg and returns the sendMail request for it.
+func buildGraphMail(gc *config.GraphConfig, msg *GraphMessage) (map[string]interface{}, error) {
+	if len(msg.To) == 0 || msg.Subject == "" || msg.Body == "" {
+		return nil, fmt.Errorf("%w: recipients, subject and body are required", models.ErrInvalidPayload)
+	}
+	if err := checkAllowedDomains(gc.AllowedDomains, msg.To, msg.Cc, msg.Bcc); err != nil {
+		return nil, err
+	}
+
+	message := map[string]interface{}{
+		"subject":      msg.Subject,
+		"body":         graphBody(msg.Body, msg.ContentType),
+		"toRecipients": graphRecipients(msg.To, ""),
+	}
+	if len(msg.Cc) > 0 {
+		message["ccRecipients"] = graphRecipients(msg.Cc, "")
+	}
+	if len(msg.Bcc) > 0 {
+		message["bccRecipients"] = graphRecipients(msg.Bcc, "")
+	}
+	return map[string]interface{}{
+		"message":         message,
+		"saveToSentItems": gc.SaveToSentItems,
+	}, nil
+}
+
+// buildGraphEvent validates event and returns the request creating it.
+func buildGraphEvent(gc *config.GraphConfig, event *GraphEvent) (map[string]interface{}, error) {
+	if event.Subject == "" || event.Start.IsZero() || event.End.IsZero() {
+		return nil, fmt.Errorf("%w: event subject, start and end are required", models.ErrInvalidPayload)
+	}
+	if !event.End.After(event.Start) {
+		return nil, fmt.Errorf("%w: event end must follow its start", models.ErrInvalidPayload)
+	}
+	if err := checkAllowedDomains(gc.AllowedDomains, event.Attendees); err != nil {
+		return nil, err
+	}
+
+	// Times are sent in the event's time zone; zones Go cannot load fall back to UTC.
+	zone := firstNonEmpty(event.TimeZone, gc.TimeZone, "UTC")
+	loc, err := time.LoadLocation(zone)
+	if err != nil {
+		loc, zone = time.UTC, "UTC"
+	}
+	request := map[string]interface{}{
+		"subject": event.Subject,
+		"start":   map[string]string{"dateTime": event.Start.In(loc).Format(graphEventTimeLayout), "timeZone": zone},
+		"end":     map[string]string{"dateTime": event.End.In(loc).Format(graphEventTimeLayout), "timeZone": zone},
+	}
+	if event.Body != "" {
+		request["body"] = graphBody(event.Body, "")
+	}
+	if event.Location != "" {
+		request["location"] = map[string]string{"displayName": event.Location}
+	}
+	if len(event.Attendees) > 0 {
+		request["attendees"] = graphRecipients(event.Attendees, "required")
+	}
+	if event.OnlineMeeting {
+		request["isOnlineMeeting"] = true
+		request["onlineMeetingProvider"] = "teamsForBusiness"
+	}
+	return request, nil
+}
+
+// graphBody converts a body and its MIME content type into a Graph itemBody.
+func graphBody(content, contentType string) map[string]string {
+	kind := "text"
+	if strings.EqualFold(contentType, "text/html") {
+		kind = "html"
+	}
+	return map[string]string{"contentType": kind, "content": content}
+}
+
+// graphRecipients converts addresses into Graph recipients, or attendees of
+// the given type.
+func graphRecipients(addresses []string, attendeeType string) []graphRecipient {
+	recipients := make([]graphRecipient, 0, len(addresses))
+	for _, address := range addresses {
+		var r graphRecipient
+		r.EmailAddress.Address = address
+		r.Type = attendeeType
+		recipients = append(recipients, r)
+	}
+	return recipients
+}
+
+// Status implements the Integration interface, reporting connectivity as of the
+// last call along with the circuit, limiter and call metrics and the mail and
+// events sent.
+func (ga *GraphAdapter) Status() (models.IntegrationStatus, error) {
+	ga.mu.RLock()
+	status := models.IntegrationStatus{
+		Connected: ga.connected,
+		Name:      "GraphIntegration",
+		Type:      "email",
+		LastSync:  ga.lastSync,
+	}
+	gc, mailSent, eventsMade, lastEvent := ga.config, ga.mailSent, ga.eventsMade, ga.lastEvent
+	ga.mu.RUnlock()
+
+	ga.guard.fillStatus(&status)
+	status.Metadata["mailSent"] = mailSent
+	status.Metadata["eventsCreated"] = eventsMade
+	status.Metadata["lastEvent"] = lastEvent
+	if gc != nil {
+		status.Metadata["tenant"] = gc.TenantID
+		status.Metadata["mailbox"] = gc.Mailbox
+	}
+	return status, nil
+}
+
+// SetRetryBudget implements models.RetryBudgetUser.
+func (ga *GraphAdapter) SetRetryBudget(budget models.RetryBudget) {
+	ga.guard.budget = budget
+}
+
+// CircuitOpen implements models.CircuitReporter.
+func (ga *GraphAdapter) CircuitOpen() bool {
+	return ga.guard.breaker.IsOpen()
+}
+
+// CircuitSnapshot implements models.CircuitInspector.
+func (ga *GraphAdapter) CircuitSnapshot() models.CircuitSnapshot {
+	return ga.guard.breaker.Snapshot()
+}
+
+// ResetCircuit implements models.CircuitResetter.
+func (ga *GraphAdapter) ResetCircuit() {
+	ga.guard.breaker.Reset()
+}
+
+// newGraphClient creates a client for the Graph API authenticating as the app
+// registration clientID with the client-credentials grant. The first token is
+// obtained here, verifying the credentials; the client requests new tokens as
+// they expire.
+func newGraphClient(ctx context.Context, gc *config.GraphConfig, clientID, clientSecret string) (*restClient, error) {
+	base := httpclient.Default().ClientWithTimeout(gc.Timeout)
+	ccConfig := &clientcredentials.Config{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		TokenURL:     strings.TrimRight(gc.AuthorityURL, "/") + "/" + url.PathEscape(gc.TenantID) + "/oauth2/v2.0/token",
+		// The .default scope requests the app's configured application permissions.
+		Scopes:    []string{strings.TrimRight(gc.BaseURL, "/") + "/.default"},
+		AuthStyle: oauth2.AuthStyleInParams,
+	}
+	if _, err := ccConfig.Token(context.WithValue(ctx, oauth2.HTTPClient, base)); err != nil {
+		return nil, fmt.Errorf("oauth token: %w", err)
+	}
+
+	// Token requests outlive ctx, so the client gets a context of its own.
+	client := ccConfig.Client(context.WithValue(context.Background(), oauth2.HTTPClient, base))
+	client.Timeout = base.Timeout
+	return newRESTClient(gc.BaseURL, client, nil), nil
+}
@@ -0,0 +1,270 @@
+package adapters
+
+import (
+	// go1.21 - Poll cancellation and message parsing
+	"bufio"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"regexp"
+	"strings"
+	"time"
+
+	// v1.2.1 - IMAP client for reading the reply and bounce mailbox
+	"github.com/emersion/go-imap"
+	"github.com/emersion/go-imap/client"
+
+	// Internal imports for configuration and the inbound email contract
+	"src/backend/services/integration/internal/config"
+	"src/backend/services/integration/internal/models"
+)
+
+// imapFetchBatch caps the messages fetched from the mailbox per poll; the
+// rest are read on the following polls.
+const imapFetchBatch = 200
+
+// messageIDPattern finds a Message-ID header in the text of bounces that do
+// not follow the delivery status notification format.
+var messageIDPattern = regexp.MustCompile(`(?im)^message-id:\s*(<[^>\s]+>)`)
+
+// IMAPPoller reads replies and bounces from an IMAP mailbox. Each poll
+// connects, reads the unseen messages, marks them seen and disconnects, so no
+// connection is held between polls. Messages that are neither replies nor
+// bounces are marked seen and skipped.
+type IMAPPoller struct {
+	cfg *config.InboundMailConfig
+}
+
+// Compile-time check to ensure IMAPPoller is an inbound email source.
+var _ models.InboundEmailSource = (*IMAPPoller)(nil)
+
+// NewIMAPPoller creates a poller for the mailbox in cfg.
+func NewIMAPPoller(cfg *config.InboundMailConfig) *IMAPPoller {
+	return &IMAPPoller{cfg: cfg}
+}
+
+// Poll implements models.InboundEmailSource.
+//
+// Steps:
+//  1. Connect, log in and select the mailbox
+//  2. Search for unseen messages
+//  3. Fetch and parse them without marking them seen
+//  4. Mark the fetched messages seen
+func (p *IMAPPoller) Poll(ctx context.Context) ([]*models.InboundEmail, error) {
+	// 1. Connection. The deadline stops a hung server from stalling the poller.
+	c, err := p.dial()
+	if err != nil {
+		return nil, fmt.Errorf("%w: imap: %v", models.ErrConnectionFailed, err)
+	}
+	defer c.Logout()
+	if deadline, ok := ctx.Deadline(); ok {
+		c.Timeout = time.Until(deadline)
+	} else {
+		c.Timeout = defaultTimeout
+	}
+	if err := c.Login(p.cfg.Username, p.cfg.Password); err != nil {
+		return nil, fmt.Errorf("imap login: %w", err)
+	}
+	if _, err := c.Select(firstNonEmpty(p.cfg.Mailbox, "INBOX"), false); err != nil {
+		return nil, fmt.Errorf("imap select: %w", err)
+	}
+
+	// 2. Unseen messages, oldest first.
+	criteria := imap.NewSearchCriteria()
+	criteria.WithoutFlags = []string{imap.SeenFlag}
+	uids, err := c.UidSearch(criteria)
+	if err != nil {
+		return nil, fmt.Errorf("imap search: %w", err)
+	}
+	if len(uids) == 0 {
+		return nil, nil
+	}
+	if len(uids) > imapFetchBatch {
+		uids = uids[:imapFetchBatch]
+	}
+	seqset := new(imap.SeqSet)
+	seqset.AddNum(uids...)
+
+	// 3. Fetch. BODY.PEEK[] leaves the messages unseen until they are parsed.
+	section := &imap.BodySectionName{Peek: true}
+	messages := make(chan *imap.Message, 16)
+	done := make(chan error, 1)
+	go func() {
+		done <- c.UidFetch(seqset, []imap.FetchItem{section.FetchItem(), imap.FetchInternalDate}, messages)
+	}()
+	var emails []*models.InboundEmail
+	for msg := range messages {
+		body := msg.GetBody(section)
+		if body == nil {
+			continue
+		}
+		email, err := parseInboundEmail(body)
+		if err != nil || email == nil {
+			continue
+		}
+		if email.Received.IsZero() {
+			email.Received = msg.InternalDate
+		}
+		emails = append(emails, email)
+	}
+	if err := <-done; err != nil {
+		return nil, fmt.Errorf("imap fetch: %w", err)
+	}
+
+	// 4. Mark seen, so the next poll skips them.
+	flags := []interface{}{imap.SeenFlag}
+	if err := c.UidStore(seqset, imap.FormatFlagsOp(imap.AddFlags, true), flags, nil); err != nil {
+		return emails, fmt.Errorf("imap store: %w", err)
+	}
+	return emails, nil
+}
+
+// dial connects to the IMAP server with implicit TLS unless plaintext is
+// configured.
+func (p *IMAPPoller) dial() (*client.Client, error) {
+	port := p.cfg.Port
+	if port == 0 {
+		port = 993
+		if p.cfg.Plaintext {
+			port = 143
+		}
+	}
+	address := net.JoinHostPort(p.cfg.Host, intToString(port))
+	if p.cfg.Plaintext {
+		return client.Dial(address)
+	}
+	return client.DialTLS(address, &tls.Config{ServerName: p.cfg.Host, MinVersion: tls.VersionTLS12})
+}
+
+// parseInboundEmail classifies a raw message as a bounce or a reply and
+// extracts the Message-IDs it references. It returns nil for other messages.
+func parseInboundEmail(r io.Reader) (*models.InboundEmail, error) {
+	msg, err := mail.ReadMessage(bufio.NewReader(r))
+	if err != nil {
+		return nil, err
+	}
+	email := &models.InboundEmail{
+		MessageID: strings.TrimSpace(msg.Header.Get("Message-Id")),
+		From:      msg.Header.Get("From"),
+	}
+	if received, err := msg.Header.Date(); err == nil {
+		email.Received = received
+	}
+
+	mediaType, params, _ := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if mediaType == "multipart/report" && strings.EqualFold(params["report-type"], "delivery-status") {
+		if parseDeliveryReport(msg.Body, params["boundary"], email) {
+			return email, nil
+		}
+		return nil, nil
+	}
+	if isBounceSender(email.From) {
+		// A bounce without a delivery status report: the original message's
+		// headers are usually quoted in the body.
+		body, err := io.ReadAll(io.LimitReader(msg.Body, 1<<20))
+		if err != nil {
+			return nil, err
+		}
+		for _, match := range messageIDPattern.FindAllSubmatch(body, -1) {
+			email.References = append(email.References, string(match[1]))
+		}
+		if len(email.References) == 0 {
+			return nil, nil
+		}
+		email.Kind = models.InboundEmailBounce
+		email.Permanent = true
+		return email, nil
+	}
+
+	email.References = messageIDList(msg.Header.Get("In-Reply-To") + " " + msg.Header.Get("References"))
+	if len(email.References) == 0 {
+		return nil, nil
+	}
+	email.Kind = models.InboundEmailReply
+	return email, nil
+}
+
+// parseDeliveryReport reads a multipart/report delivery status notification
+// (RFC 3464) into email: the failed recipients and status from the
+// message/delivery-status part, and the original Message-ID from the
+// returned message or headers. It reports false when the notification records
+// no failure, such as a delay or delivery report.
+func parseDeliveryReport(body io.Reader, boundary string, email *models.InboundEmail) bool {
+	email.Kind = models.InboundEmailBounce
+	reader := multipart.NewReader(body, boundary)
+	failed := false
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			break
+		}
+		mediaType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		switch mediaType {
+		case "message/delivery-status", "message/global-delivery-status":
+			// Per-message fields, then one block of fields per recipient.
+			tp := textproto.NewReader(bufio.NewReader(part))
+			for {
+				fields, err := tp.ReadMIMEHeader()
+				if len(fields) > 0 && strings.EqualFold(fields.Get("Action"), "failed") {
+					failed = true
+					recipient := fields.Get("Final-Recipient")
+					if i := strings.Index(recipient, ";"); i >= 0 {
+						recipient = recipient[i+1:]
+					}
+					email.Recipients = append(email.Recipients, strings.TrimSpace(recipient))
+					if status := fields.Get("Status"); email.Status == "" {
+						email.Status = status
+					}
+				}
+				if err != nil {
+					break
+				}
+			}
+		case "message/rfc822", "text/rfc822-headers", "message/global", "message/global-headers":
+			// Only the headers are needed; a truncated message still has them.
+			original, _ := textproto.NewReader(bufio.NewReader(part)).ReadMIMEHeader()
+			if id := strings.TrimSpace(original.Get("Message-Id")); id != "" {
+				email.References = append(email.References, id)
+			}
+		}
+	}
+	// Class 5 status codes are permanent failures; class 4 are transient.
+	email.Permanent = !strings.HasPrefix(email.Status, "4.")
+	return failed && len(email.References) > 0
+}
+
+// isBounceSender reports whether from is a mailer daemon address.
+func isBounceSender(from string) bool {
+	address, err := mail.ParseAddress(from)
+	if err != nil {
+		return false
+	}
+	local := strings.ToLower(address.Address)
+	if at := strings.LastIndex(local, "@"); at >= 0 {
+		local = local[:at]
+	}
+	return local == "mailer-daemon" || local == "postmaster"
+}
+
+// messageIDList extracts the angle-bracketed Message-IDs from a header value.
+func messageIDList(value string) []string {
+	var ids []string
+	for {
+		start := strings.Index(value, "<")
+		if start < 0 {
+			return ids
+		}
+		end := strings.Index(value[start:], ">")
+		if end < 0 {
+			return ids
+		}
+		ids = append(ids, value[start:start+end+1])
+		value = value[start+end+1:]
+	}
+}
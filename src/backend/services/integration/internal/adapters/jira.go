@@ -9,6 +9,12 @@ import (
 	"fmt"
 	// go1.21 - Supplies lightweight logging for runtime events and diagnostics.
 	"log"
+	// go1.21 - HTTP status codes for detecting rate limiting.
+	"net/http"
+	// go1.21 - Orders field errors reported for rejected bulk issues.
+	"sort"
+	// go1.21 - Builds browse links for created issues.
+	"strings"
 	// go1.21 - Offers concurrency-safe primitives like mutexes and RWMutex for threading.
 	"sync"
 	// go1.21 - Enables working with durations, timeouts, and rate-based logic.
@@ -21,10 +27,14 @@ import (
 	// v1.0.0 - OpenTelemetry library for tracing and observability insights.
 	"go.opentelemetry.io/otel"
 
+	// Internal TTL cache for create metadata and transitions.
+	"src/backend/services/integration/internal/cache"
 	// Named import from internal config package for JiraConfig struct and advanced config handling.
 	"src/backend/services/integration/internal/config"
 	// Named import from internal models package for Integration interface and IntegrationStatus struct.
 	"src/backend/services/integration/internal/models"
+	// Internal shared HTTP transport with pooled keep-alive connections.
+	"src/backend/services/integration/internal/httpclient"
 )
 
 // defaultIssueType represents the standard Jira issue type used if none is specified in the payload.
@@ -51,14 +61,23 @@ type CircuitBreaker struct {
 	failCount  int
 	threshold  int
 	resetTimer time.Duration
+	openedAt   time.Time
 }
 
 // Allow checks whether the circuit is open or allows an operation to proceed.
 func (cb *CircuitBreaker) Allow() bool {
 	cb.mu.Lock()
 	defer cb.mu.Unlock()
-	// If circuit is open, do not allow further calls until reset occurs (simplified logic here).
-	return !cb.open
+	// If circuit is open, do not allow further calls until the reset timer lets a
+	// trial call through (half-open); its outcome closes or re-opens the circuit.
+	return !cb.open || time.Since(cb.openedAt) >= cb.resetTimer
+}
+
+// IsOpen reports whether the circuit is open and still rejecting calls.
+func (cb *CircuitBreaker) IsOpen() bool {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	return cb.open && time.Since(cb.openedAt) < cb.resetTimer
 }
 
 // OnSuccess resets the failCount and potentially closes the circuit if it was open.
@@ -69,6 +88,35 @@ func (cb *CircuitBreaker) OnSuccess() {
 	cb.open = false
 }
 
+// Reset closes the circuit and clears the failure count.
+func (cb *CircuitBreaker) Reset() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.failCount = 0
+	cb.open = false
+	cb.openedAt = time.Time{}
+}
+
+// Snapshot returns the breaker's state and counters.
+func (cb *CircuitBreaker) Snapshot() models.CircuitSnapshot {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	snapshot := models.CircuitSnapshot{
+		State:               models.CircuitClosed,
+		ConsecutiveFailures: cb.failCount,
+		Threshold:           cb.threshold,
+	}
+	if cb.open {
+		snapshot.State = models.CircuitOpen
+		if time.Since(cb.openedAt) >= cb.resetTimer {
+			snapshot.State = models.CircuitHalfOpen
+		}
+		snapshot.OpenedAt = cb.openedAt
+		snapshot.RetryAt = cb.openedAt.Add(cb.resetTimer)
+	}
+	return snapshot
+}
+
 // OnFailure increments the failCount and opens the circuit if the threshold is reached.
 func (cb *CircuitBreaker) OnFailure() {
 	cb.mu.Lock()
@@ -76,6 +124,7 @@ func (cb *CircuitBreaker) OnFailure() {
 	cb.failCount++
 	if cb.failCount >= cb.threshold {
 		cb.open = true
+		cb.openedAt = time.Now()
 	}
 }
 
@@ -86,6 +135,7 @@ type metricsCollector struct {
 	failedCalls  int
 	lastError    time.Time
 	lastSuccess  time.Time
+	latency      latencyWindow
 }
 
 // RecordSuccess notes a successful call event in the collector.
@@ -136,14 +186,52 @@ type JiraAdapter struct {
 	connected bool
 	// mu ensures thread-safe read/write operations on shared fields.
 	mu *sync.RWMutex
-	// rateLimiter applies token-bucket based control to limit calls to Jira.
-	rateLimiter *rate.Limiter
+	// rateLimiter applies token-bucket based control to limit calls to Jira, shrinking
+	// on 429 responses and recovering gradually once Jira stops throttling.
+	rateLimiter *adaptiveLimiter
 	// circuitBreaker helps prevent repeated calls when Jira is consistently failing or unreachable.
 	circuitBreaker *CircuitBreaker
 	// metrics gathers essential operational data such as error counts and success rates.
 	metrics *metricsCollector
+	// metadata caches create metadata and workflow transitions, saving a metadata
+	// round-trip on every send.
+	metadata *cache.TTL
+	// retryBudget bounds retries of failed creates; set at registration.
+	retryBudget models.RetryBudget
+	// sharedLimiter, when set, holds calls to the rate limit across every replica.
+	sharedLimiter models.SharedLimiter
 }
 
+// jiraSharedLimiterKey counts Jira calls in the shared limiter.
+const jiraSharedLimiterKey = "api"
+
+// Compile-time check to ensure JiraAdapter exposes metadata invalidation.
+var _ models.MetadataInvalidator = (*JiraAdapter)(nil)
+
+// Compile-time check to ensure JiraAdapter reports its circuit state.
+var _ models.CircuitReporter = (*JiraAdapter)(nil)
+
+// Compile-time check to ensure JiraAdapter's circuit can be reset by operators.
+var _ models.CircuitResetter = (*JiraAdapter)(nil)
+
+// Compile-time check to ensure JiraAdapter reports its circuit's state and counters.
+var _ models.CircuitInspector = (*JiraAdapter)(nil)
+
+// Compile-time check to ensure JiraAdapter accepts a retry budget.
+var _ models.RetryBudgetUser = (*JiraAdapter)(nil)
+
+// Compile-time check to ensure JiraAdapter paces its calls cluster-wide when asked.
+var _ models.SharedLimiterUser = (*JiraAdapter)(nil)
+
+// Compile-time check to ensure JiraAdapter reports the issues it creates.
+var _ models.ResultSender = (*JiraAdapter)(nil)
+
+// Compile-time check to ensure JiraAdapter can test its credentials on demand.
+var _ models.ConnectionTester = (*JiraAdapter)(nil)
+
+// Compile-time check to ensure JiraAdapter can delete the issues it creates.
+var _ models.SendReverter = (*JiraAdapter)(nil)
+
 // NewJiraAdapter is the constructor that creates a new JiraAdapter with enterprise-level concurrency,
 // rate limiting, circuit breaker, and telemetry capabilities.
 func NewJiraAdapter(cfg *config.JiraConfig) *JiraAdapter {
@@ -152,7 +240,7 @@ func NewJiraAdapter(cfg *config.JiraConfig) *JiraAdapter {
 		open:       false,
 		failCount:  0,
 		threshold:  5,
-		resetTimer: time.Minute, // Not fully utilized in this minimal example.
+		resetTimer: time.Minute, // Open circuits admit a trial call after this long.
 	}
 	// Create a robust metrics collector.
 	mc := &metricsCollector{
@@ -168,7 +256,7 @@ func NewJiraAdapter(cfg *config.JiraConfig) *JiraAdapter {
 		lastSync:       time.Time{},
 		connected:      false,
 		mu:             &sync.RWMutex{},
-		rateLimiter:    rate.NewLimiter(rate.Every(time.Second), 3),
+		rateLimiter:    newJiraRateLimiter(),
 		circuitBreaker: cb,
 		metrics:        mc,
 	}
@@ -192,10 +280,11 @@ func (ja *JiraAdapter) InitializeWithContext(ctx context.Context, cfg interface{
 	}
 	ja.config = c
 
-	// 2. Create Jira Client with Basic Auth Transport
+	// 2. Create Jira Client with Basic Auth Transport over the shared HTTP transport
 	transport := jira.BasicAuthTransport{
-		Username: c.Username,
-		Password: c.APIToken,
+		Username:  c.Username,
+		Password:  c.APIToken,
+		Transport: httpclient.Default().Transport(),
 	}
 	client, err := jira.NewClient(transport.Client(), c.URL)
 	if err != nil {
@@ -204,6 +293,7 @@ func (ja *JiraAdapter) InitializeWithContext(ctx context.Context, cfg interface{
 	}
 
 	ja.client = client
+	ja.metadata = newMetadataCache(c.MetadataTTL)
 
 	// 3. Test Connection with Retry Logic
 	connected := false
@@ -213,7 +303,7 @@ func (ja *JiraAdapter) InitializeWithContext(ctx context.Context, cfg interface{
 			return fmt.Errorf("context canceled or timed out: %w", ctx.Err())
 		}
 
-		err = ja.testConnection(ctx)
+		err = ja.testConnection(ctx, client)
 		if err == nil {
 			connected = true
 			break
@@ -228,7 +318,8 @@ func (ja *JiraAdapter) InitializeWithContext(ctx context.Context, cfg interface{
 
 	// 4. Re-initialize Rate Limiter or CircuitBreaker if needed
 	// (For demonstration, we can re-init them with default or config-based values)
-	ja.rateLimiter = rate.NewLimiter(rate.Every(time.Second), 3)
+	ja.rateLimiter = newJiraRateLimiter()
+	ja.rateLimiter.share(ja.sharedLimiter, jiraSharedLimiterKey)
 	ja.circuitBreaker.failCount = 0
 	ja.circuitBreaker.open = false
 
@@ -240,6 +331,67 @@ func (ja *JiraAdapter) InitializeWithContext(ctx context.Context, cfg interface{
 	return nil
 }
 
+// RotateCredentials re-authenticates the Jira client with a new API token (and
+// optionally a new username) without a restart. The new credentials are verified
+// with a user lookup before they replace the active client; on failure the adapter
+// keeps its previous client. The shared configuration is not mutated.
+func (ja *JiraAdapter) RotateCredentials(ctx context.Context, creds models.Credentials) error {
+	ctx, span := otel.Tracer("integration.jira").Start(ctx, "JiraAdapter.RotateCredentials")
+	defer span.End()
+
+	if creds.Secret == "" {
+		return models.ErrInvalidPayload
+	}
+
+	// 1. Derive the new configuration from the current one.
+	ja.mu.RLock()
+	if ja.config == nil {
+		ja.mu.RUnlock()
+		return models.ErrInitializationFailed
+	}
+	rotated := *ja.config
+	ja.mu.RUnlock()
+
+	if creds.Username != "" {
+		rotated.Username = creds.Username
+	}
+	rotated.APIToken = creds.Secret
+
+	// 2. Build and verify a client with the new credentials.
+	transport := jira.BasicAuthTransport{
+		Username:  rotated.Username,
+		Password:  rotated.APIToken,
+		Transport: httpclient.Default().Transport(),
+	}
+	client, err := jira.NewClient(transport.Client(), rotated.URL)
+	if err != nil {
+		return fmt.Errorf("failed to create Jira client: %w", err)
+	}
+	if err := ja.testConnection(ctx, client); err != nil {
+		return fmt.Errorf("rotated Jira credentials rejected: %w", err)
+	}
+
+	// 3. Swap the client in; requests already in flight finish on the old one.
+	ja.mu.Lock()
+	defer ja.mu.Unlock()
+	ja.client = client
+	ja.config = &rotated
+	ja.connected = true
+
+	// Project and workflow visibility depend on the account, so drop cached metadata.
+	if ja.metadata != nil {
+		ja.metadata.InvalidatePrefix("")
+	}
+	return nil
+}
+
+// currentClient returns the active Jira client under the read lock.
+func (ja *JiraAdapter) currentClient() *jira.Client {
+	ja.mu.RLock()
+	defer ja.mu.RUnlock()
+	return ja.client
+}
+
 // Initialize implements the Integration interface, bridging to InitializeWithContext
 // by providing a background context for operations when no custom context is supplied.
 func (ja *JiraAdapter) Initialize(cfg interface{}) error {
@@ -250,29 +402,166 @@ func (ja *JiraAdapter) Initialize(cfg interface{}) error {
 // leveraging the circuit breaker and applying rate limiting. It attempts retries on transient
 // failures and updates operational metrics accordingly.
 func (ja *JiraAdapter) SendWithContext(ctx context.Context, payload interface{}) error {
+	_, err := ja.SendWithResult(ctx, payload)
+	return err
+}
+
+// SendWithResult implements models.ResultSender, creating an issue as
+// SendWithContext does and returning its key and browse URL. When the issue
+// already existed for the payload's correlation key, that issue is returned.
+func (ja *JiraAdapter) SendWithResult(ctx context.Context, payload interface{}) (models.SendResult, error) {
 	ctx, span := otel.Tracer("integration.jira").Start(ctx, "JiraAdapter.Send")
 	defer span.End()
 
 	// 1. Check Circuit Breaker
 	if !ja.circuitBreaker.Allow() {
 		ja.metrics.RecordFailure()
-		return fmt.Errorf("circuit breaker open, refusing to send request to Jira")
+		return models.SendResult{}, fmt.Errorf("circuit breaker open, refusing to send request to Jira")
 	}
 
 	// 2. Apply Rate Limiting
-	err := ja.rateLimiter.Wait(ctx, 1)
+	err := ja.rateLimiter.Wait(ctx)
 	if err != nil {
 		ja.metrics.RecordFailure()
 		ja.circuitBreaker.OnFailure()
+		return models.SendResult{}, fmt.Errorf("rate limiter prevented request: %w", err)
+	}
+
+	// 3 & 4. Validate the payload and construct the Jira issue
+	newIssue, correlationKey, err := ja.buildIssue(payload)
+	if err != nil {
+		ja.metrics.RecordFailure()
+		ja.circuitBreaker.OnFailure()
+		return models.SendResult{}, err
+	}
+
+	// 4b. Record a correlation key on the issue, so a retry whose earlier
+	// attempt was created despite failing finds that issue instead of creating
+	// another. Keys from the payload also guard retries of the whole send.
+	searchFirst := correlationKey != ""
+	if !searchFirst {
+		correlationKey = newJiraCorrelationKey()
+	}
+	ja.stampCorrelationKey(newIssue, correlationKey)
+	stampRequestID(ctx, newIssue)
+
+	// 4a. Reject issue types the project does not offer, using cached create metadata
+	if err := ja.checkIssueType(ctx, newIssue); err != nil {
+		ja.metrics.RecordFailure()
+		return models.SendResult{}, err
+	}
+
+	// 5. Attempt Operation with Retry Logic
+	var lastErr error
+	attempts := 0
+	for i := 0; i < maxRetries; i++ {
+		if ctx.Err() != nil {
+			ja.metrics.RecordFailure()
+			ja.circuitBreaker.OnFailure()
+			return models.SendResult{}, fmt.Errorf("context canceled or timed out: %w", ctx.Err())
+		}
+
+		// Later attempts must fit the retry budget, then take a fresh token, which
+		// also honors any Retry-After pause.
+		if i > 0 {
+			if !allowRetry(ja.retryBudget) {
+				lastErr = fmt.Errorf("%w: %w", models.ErrRetryBudgetExhausted, lastErr)
+				break
+			}
+			if err := ja.rateLimiter.Wait(ctx); err != nil {
+				lastErr = err
+				break
+			}
+		}
+
+		// An earlier attempt, or an earlier send with the same key, may have
+		// created the issue.
+		if i > 0 || searchFirst {
+			existing, searchErr := ja.findCorrelated(ctx, newIssue.Fields.Project.Key, correlationKey)
+			if searchErr != nil {
+				lastErr = searchErr
+				time.Sleep(retryBackoff)
+				continue
+			}
+			if existing != nil {
+				log.Printf("Jira issue %s already exists for correlation key %s; not creating another", existing.Key, correlationKey)
+				ja.rateLimiter.OnSuccess()
+				ja.metrics.RecordSuccess()
+				ja.circuitBreaker.OnSuccess()
+				ja.updateLastSync()
+				return ja.issueResult(existing.Key), nil
+			}
+		}
+
+		attempts++
+		created, resp, createErr := ja.currentClient().Issue.CreateWithContext(ctx, newIssue)
+		if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+			ja.rateLimiter.OnThrottled(parseRetryAfter(resp.Header.Get("Retry-After")))
+			lastErr = createErr
+			continue
+		}
+		if createErr == nil && resp != nil && resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			ja.rateLimiter.OnSuccess()
+			ja.metrics.RecordSuccess()
+			ja.circuitBreaker.OnSuccess()
+			ja.updateLastSync()
+			if created == nil {
+				return models.SendResult{}, nil
+			}
+			return ja.issueResult(created.Key), nil
+		}
+		lastErr = createErr
+		time.Sleep(retryBackoff)
+	}
+
+	// 6. Update Metrics on Failure
+	ja.metrics.RecordFailure()
+	ja.circuitBreaker.OnFailure()
+	return models.SendResult{}, fmt.Errorf("failed to create Jira issue after %d attempts: %w", attempts, lastErr)
+}
+
+// issueResult identifies the issue with key, linking to it on the configured
+// Jira instance.
+func (ja *JiraAdapter) issueResult(key string) models.SendResult {
+	return models.SendResult{
+		ExternalID: key,
+		URL:        strings.TrimSuffix(ja.config.URL, "/") + "/browse/" + key,
+	}
+}
+
+// RevertSend implements models.SendReverter, deleting the issue a send
+// created, or found for its correlation key.
+func (ja *JiraAdapter) RevertSend(ctx context.Context, payload interface{}, result models.SendResult) error {
+	if result.ExternalID == "" {
+		return models.ErrInvalidPayload
+	}
+	if err := ja.rateLimiter.Wait(ctx); err != nil {
 		return fmt.Errorf("rate limiter prevented request: %w", err)
 	}
+	resp, err := ja.currentClient().Issue.DeleteWithContext(ctx, result.ExternalID)
+	if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+		ja.rateLimiter.OnThrottled(parseRetryAfter(resp.Header.Get("Retry-After")))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete Jira issue %s: %w", result.ExternalID, err)
+	}
+	return nil
+}
 
+// buildIssue validates a send payload and converts it into a Jira issue. The
+// payload is a map with a required "summary" and optional "description",
+// "issueType", "priority", "projectKey" and "correlationKey". The correlation
+// key, if any, is returned for the caller to record on the issue.
+func (ja *JiraAdapter) buildIssue(payload interface{}) (*jira.Issue, string, error) {
 	// 3. Validate Payload Structure
 	data, ok := payload.(map[string]interface{})
 	if !ok {
-		ja.metrics.RecordFailure()
-		ja.circuitBreaker.OnFailure()
-		return models.ErrInvalidPayload
+		return nil, "", models.ErrInvalidPayload
+	}
+
+	correlationKey, err := payloadCorrelationKey(data)
+	if err != nil {
+		return nil, "", err
 	}
 
 	issueType := defaultIssueType
@@ -284,9 +573,7 @@ func (ja *JiraAdapter) SendWithContext(ctx context.Context, payload interface{})
 
 	summary, hasSummary := data["summary"].(string)
 	if !hasSummary || summary == "" {
-		ja.metrics.RecordFailure()
-		ja.circuitBreaker.OnFailure()
-		return fmt.Errorf("missing required 'summary' field in payload")
+		return nil, "", fmt.Errorf("missing required 'summary' field in payload")
 	}
 
 	description, _ := data["description"].(string)
@@ -306,7 +593,7 @@ func (ja *JiraAdapter) SendWithContext(ctx context.Context, payload interface{})
 	}
 
 	// 4. Construct Jira Issue
-	newIssue := &jira.Issue{
+	return &jira.Issue{
 		Fields: &jira.IssueFields{
 			Type: jira.IssueType{
 				Name: issueType,
@@ -320,32 +607,291 @@ func (ja *JiraAdapter) SendWithContext(ctx context.Context, payload interface{})
 				Name: priority,
 			},
 		},
+	}, correlationKey, nil
+}
+
+// CreateMeta returns the create metadata (issue types and their fields) for a
+// project, served from the metadata cache while fresh.
+func (ja *JiraAdapter) CreateMeta(ctx context.Context, projectKey string) (*jira.MetaProject, error) {
+	value, err := ja.metadata.GetOrLoad(ctx, metadataKeyJiraCreateMeta+projectKey, func(ctx context.Context) (interface{}, error) {
+		meta, _, err := ja.currentClient().Issue.GetCreateMetaWithContext(ctx, projectKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load Jira create metadata for %s: %w", projectKey, err)
+		}
+		project := meta.GetProjectWithKey(projectKey)
+		if project == nil {
+			return nil, fmt.Errorf("jira project %s not found in create metadata", projectKey)
+		}
+		return project, nil
+	})
+	if err != nil {
+		return nil, err
 	}
+	return value.(*jira.MetaProject), nil
+}
 
-	// 5. Attempt Operation with Retry Logic
-	var lastErr error
-	for i := 0; i < maxRetries; i++ {
-		if ctx.Err() != nil {
+// Transitions returns the workflow transitions currently available for an
+// issue, served from the metadata cache while fresh.
+func (ja *JiraAdapter) Transitions(ctx context.Context, issueKey string) ([]jira.Transition, error) {
+	value, err := ja.metadata.GetOrLoad(ctx, metadataKeyJiraTransitions+issueKey, func(ctx context.Context) (interface{}, error) {
+		transitions, _, err := ja.currentClient().Issue.GetTransitionsWithContext(ctx, issueKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load Jira transitions for %s: %w", issueKey, err)
+		}
+		return transitions, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.([]jira.Transition), nil
+}
+
+// SetRetryBudget implements models.RetryBudgetUser.
+func (ja *JiraAdapter) SetRetryBudget(budget models.RetryBudget) {
+	ja.retryBudget = budget
+}
+
+// SetSharedLimiter implements models.SharedLimiterUser.
+func (ja *JiraAdapter) SetSharedLimiter(limiter models.SharedLimiter) {
+	ja.sharedLimiter = limiter
+	ja.rateLimiter.share(limiter, jiraSharedLimiterKey)
+}
+
+// CircuitOpen implements models.CircuitReporter.
+func (ja *JiraAdapter) CircuitOpen() bool {
+	return ja.circuitBreaker.IsOpen()
+}
+
+// CircuitSnapshot implements models.CircuitInspector.
+func (ja *JiraAdapter) CircuitSnapshot() models.CircuitSnapshot {
+	return ja.circuitBreaker.Snapshot()
+}
+
+// ResetCircuit implements models.CircuitResetter.
+func (ja *JiraAdapter) ResetCircuit() {
+	ja.circuitBreaker.Reset()
+}
+
+// InvalidateMetadata implements models.MetadataInvalidator.
+func (ja *JiraAdapter) InvalidateMetadata(prefix string) int {
+	if ja.metadata == nil {
+		return 0
+	}
+	return ja.metadata.InvalidatePrefix(prefix)
+}
+
+// checkIssueType verifies that the issue's type exists in its project. Failure
+// to load metadata is not fatal; Jira then validates the issue on create.
+func (ja *JiraAdapter) checkIssueType(ctx context.Context, issue *jira.Issue) error {
+	project, err := ja.CreateMeta(ctx, issue.Fields.Project.Key)
+	if err != nil {
+		return nil
+	}
+	if project.GetIssueTypeWithName(issue.Fields.Type.Name) == nil {
+		return fmt.Errorf("%w: issue type %q is not available in project %s",
+			models.ErrInvalidPayload, issue.Fields.Type.Name, issue.Fields.Project.Key)
+	}
+	return nil
+}
+
+// jiraBulkCreateLimit is the maximum number of issues Jira accepts per bulk create call.
+const jiraBulkCreateLimit = 50
+
+// jiraBulkCreateResponse is the subset of the bulk create response used to
+// attribute created issues and failures to the payloads of a chunk. Issues
+// lists the created issues in request order, skipping the failed elements.
+type jiraBulkCreateResponse struct {
+	Issues []struct {
+		Key string `json:"key"`
+	} `json:"issues"`
+	Errors []struct {
+		FailedElementNumber int `json:"failedElementNumber"`
+		ElementErrors       struct {
+			ErrorMessages []string          `json:"errorMessages"`
+			Errors        map[string]string `json:"errors"`
+		} `json:"elementErrors"`
+	} `json:"errors"`
+}
+
+// jiraBatchIssue is one issue of a batch, with the index of its payload and
+// the correlation key stamped on it.
+type jiraBatchIssue struct {
+	index          int
+	issue          *jira.Issue
+	correlationKey string
+}
+
+// SendBatch implements models.BatchSender using Jira's bulk create endpoint,
+// creating up to jiraBulkCreateLimit issues per call, and reports each
+// payload's issue key or error. A payload that fails validation fails on its
+// own. Every issue carries a correlation key; payloads that bring their own
+// key are looked up first, so that a batch retried after a partial failure
+// does not create their issues twice, and when a bulk call fails without an
+// answer its issues are looked up before being reported as failed.
+func (ja *JiraAdapter) SendBatch(ctx context.Context, payloads []interface{}) ([]models.BatchResult, error) {
+	ctx, span := otel.Tracer("integration.jira").Start(ctx, "JiraAdapter.SendBatch")
+	defer span.End()
+
+	results := make([]models.BatchResult, len(payloads))
+	pending := make([]jiraBatchIssue, 0, len(payloads))
+	for i, payload := range payloads {
+		issue, correlationKey, err := ja.buildIssue(payload)
+		if err != nil {
 			ja.metrics.RecordFailure()
-			ja.circuitBreaker.OnFailure()
-			return fmt.Errorf("context canceled or timed out: %w", ctx.Err())
+			results[i].Err = err
+			continue
+		}
+		searchFirst := correlationKey != ""
+		if !searchFirst {
+			correlationKey = newJiraCorrelationKey()
 		}
+		ja.stampCorrelationKey(issue, correlationKey)
+		stampRequestID(ctx, issue)
+		item := jiraBatchIssue{index: i, issue: issue, correlationKey: correlationKey}
+
+		// An earlier send with the same key may have created the issue.
+		if searchFirst {
+			existing, err := ja.lookupCorrelated(ctx, item)
+			if err != nil {
+				ja.metrics.RecordFailure()
+				results[i].Err = err
+				continue
+			}
+			if existing != "" {
+				ja.metrics.RecordSuccess()
+				results[i].Result = ja.issueResult(existing)
+				continue
+			}
+		}
+		pending = append(pending, item)
+	}
 
-		_, resp, createErr := ja.client.Issue.Create(newIssue)
-		if createErr == nil && resp != nil && resp.StatusCode >= 200 && resp.StatusCode < 300 {
+	for start := 0; start < len(pending); start += jiraBulkCreateLimit {
+		end := start + jiraBulkCreateLimit
+		if end > len(pending) {
+			end = len(pending)
+		}
+		ja.bulkCreate(ctx, pending[start:end], results)
+	}
+	return results, nil
+}
+
+// bulkCreate creates one chunk of issues under the circuit breaker and rate
+// limiter, recording each issue's key or error in results.
+func (ja *JiraAdapter) bulkCreate(ctx context.Context, chunk []jiraBatchIssue, results []models.BatchResult) {
+	fail := func(err error) {
+		for _, item := range chunk {
+			ja.metrics.RecordFailure()
+			results[item.index].Err = err
+		}
+	}
+	if !ja.circuitBreaker.Allow() {
+		fail(fmt.Errorf("circuit breaker open, refusing to send request to Jira"))
+		return
+	}
+	if err := ja.rateLimiter.Wait(ctx); err != nil {
+		fail(fmt.Errorf("rate limiter prevented request: %w", err))
+		return
+	}
+
+	issues := make([]*jira.Issue, len(chunk))
+	for i, item := range chunk {
+		issues[i] = item.issue
+	}
+	client := ja.currentClient()
+	req, err := client.NewRequestWithContext(ctx, http.MethodPost, "rest/api/2/issue/bulk",
+		map[string]interface{}{"issueUpdates": issues})
+	if err != nil {
+		fail(fmt.Errorf("failed to build Jira bulk create request: %w", err))
+		return
+	}
+
+	var result jiraBulkCreateResponse
+	resp, err := client.Do(req, &result)
+	if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+		ja.rateLimiter.OnThrottled(parseRetryAfter(resp.Header.Get("Retry-After")))
+	}
+	if err != nil && len(result.Issues) == 0 {
+		ja.circuitBreaker.OnFailure()
+		err = fmt.Errorf("jira bulk create failed: %w", err)
+		// Jira rejected the whole chunk; nothing was created.
+		if resp != nil && resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests {
+			fail(err)
+			return
+		}
+		// No answer: Jira may have created some of the issues before failing.
+		for _, item := range chunk {
+			results[item.index] = ja.resolveUnanswered(ctx, item, err)
+		}
+		return
+	}
+
+	ja.rateLimiter.OnSuccess()
+	ja.circuitBreaker.OnSuccess()
+	ja.updateLastSync()
+
+	failed := make(map[int]error, len(result.Errors))
+	for _, e := range result.Errors {
+		failed[e.FailedElementNumber] = fmt.Errorf("jira bulk create rejected issue: %s", jiraElementError(e.ElementErrors.ErrorMessages, e.ElementErrors.Errors))
+	}
+	created := 0
+	for i, item := range chunk {
+		if failedErr, ok := failed[i]; ok {
+			ja.metrics.RecordFailure()
+			results[item.index].Err = failedErr
+			continue
+		}
+		if created < len(result.Issues) {
 			ja.metrics.RecordSuccess()
-			ja.circuitBreaker.OnSuccess()
-			ja.updateLastSync()
-			return nil
+			results[item.index].Result = ja.issueResult(result.Issues[created].Key)
+			created++
+			continue
 		}
-		lastErr = createErr
-		time.Sleep(retryBackoff)
+		results[item.index] = ja.resolveUnanswered(ctx, item, fmt.Errorf("jira bulk create did not report the issue"))
 	}
+}
 
-	// 6. Update Metrics on Failure
+// resolveUnanswered looks up an issue whose bulk create went unanswered by its
+// correlation key, reporting it as created when found and as failed with
+// cause otherwise.
+func (ja *JiraAdapter) resolveUnanswered(ctx context.Context, item jiraBatchIssue, cause error) models.BatchResult {
+	existing, err := ja.lookupCorrelated(ctx, item)
+	if err == nil && existing != "" {
+		ja.metrics.RecordSuccess()
+		return models.BatchResult{Result: ja.issueResult(existing)}
+	}
 	ja.metrics.RecordFailure()
-	ja.circuitBreaker.OnFailure()
-	return fmt.Errorf("failed to create Jira issue after %d attempts: %w", maxRetries, lastErr)
+	return models.BatchResult{Err: cause}
+}
+
+// lookupCorrelated returns the key of the issue already carrying item's
+// correlation key, or an empty string when there is none.
+func (ja *JiraAdapter) lookupCorrelated(ctx context.Context, item jiraBatchIssue) (string, error) {
+	if err := ja.rateLimiter.Wait(ctx); err != nil {
+		return "", fmt.Errorf("rate limiter prevented request: %w", err)
+	}
+	existing, err := ja.findCorrelated(ctx, item.issue.Fields.Project.Key, item.correlationKey)
+	if err != nil || existing == nil {
+		return "", err
+	}
+	return existing.Key, nil
+}
+
+// jiraElementError joins the messages Jira gave for one rejected issue.
+func jiraElementError(messages []string, fields map[string]string) string {
+	parts := append([]string(nil), messages...)
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		parts = append(parts, name+": "+fields[name])
+	}
+	if len(parts) == 0 {
+		return "no reason given"
+	}
+	return strings.Join(parts, "; ")
 }
 
 // Send implements the Integration interface, bridging to SendWithContext by using
@@ -376,6 +922,8 @@ func (ja *JiraAdapter) StatusWithContext(ctx context.Context) (models.Integratio
 			"failCount":          ja.circuitBreaker.failCount,
 			"rateLimiterBurst":   ja.rateLimiter.Burst(),
 			"rateLimiterLimit":   ja.rateLimiter.Limit(),
+			"rateLimiter":        ja.rateLimiter.Stats(),
+			"metadataCache":      ja.metadata.Stats(),
 			"username":           ja.config.Username,
 			"useCloud":           ja.config.UseCloud,
 		},
@@ -389,9 +937,15 @@ func (ja *JiraAdapter) Status() (models.IntegrationStatus, error) {
 	return ja.StatusWithContext(context.Background())
 }
 
+// newJiraRateLimiter creates the adaptive limiter for Jira calls: at most one
+// request per second with a burst of three, backing off to one every ten seconds.
+func newJiraRateLimiter() *adaptiveLimiter {
+	return newAdaptiveLimiter(rate.Every(time.Second), 3, rate.Every(10*time.Second))
+}
+
 // testConnection performs a simple Jira user lookup to confirm valid credentials and connectivity.
-func (ja *JiraAdapter) testConnection(ctx context.Context) error {
-	user, resp, err := ja.client.User.GetSelf()
+func (ja *JiraAdapter) testConnection(ctx context.Context, client *jira.Client) error {
+	user, resp, err := client.User.GetSelf()
 	if err != nil {
 		return fmt.Errorf("jira connection test failed: %w", err)
 	}
@@ -402,6 +956,22 @@ func (ja *JiraAdapter) testConnection(ctx context.Context) error {
 	return nil
 }
 
+// TestConnection implements models.ConnectionTester, looking up the user the
+// configured credentials authenticate as.
+func (ja *JiraAdapter) TestConnection(ctx context.Context) (map[string]interface{}, error) {
+	details := map[string]interface{}{"server": ja.config.URL}
+	user, resp, err := ja.currentClient().User.GetSelfWithContext(ctx)
+	if resp != nil {
+		details["statusCode"] = resp.StatusCode
+	}
+	if err != nil {
+		return details, fmt.Errorf("%w: %v", models.ErrConnectionFailed, err)
+	}
+	details["accountId"] = user.AccountID
+	details["displayName"] = user.DisplayName
+	return details, nil
+}
+
 // updateLastSync is a concurrency-safe way to record a successful synchronization timestamp.
 func (ja *JiraAdapter) updateLastSync() {
 	ja.mu.Lock()
@@ -0,0 +1,109 @@
+package adapters
+
+import (
+	// go1.21 - Correlation key generation and JQL assembly
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+
+	// v1.16.0 - Jira issue search
+	jira "github.com/andygrunwald/go-jira"
+
+	// Internal models for payload errors
+	"src/backend/services/integration/internal/models"
+)
+
+// jiraCorrelationLabelPrefix prefixes the label that records an issue's
+// correlation key when no custom field is configured for it.
+const jiraCorrelationLabelPrefix = "taskstream-"
+
+// jiraCorrelationKeyPattern restricts correlation keys to characters that are
+// valid in labels and need no escaping in JQL.
+var jiraCorrelationKeyPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,200}$`)
+
+// newJiraCorrelationKey returns a random correlation key for a send whose
+// payload carries none, which guards the send's own retries.
+func newJiraCorrelationKey() string {
+	var random [16]byte
+	_, _ = rand.Read(random[:])
+	return hex.EncodeToString(random[:])
+}
+
+// payloadCorrelationKey returns the payload's "correlationKey", validated.
+func payloadCorrelationKey(data map[string]interface{}) (string, error) {
+	key, _ := data["correlationKey"].(string)
+	if key == "" {
+		return "", nil
+	}
+	if !jiraCorrelationKeyPattern.MatchString(key) {
+		return "", fmt.Errorf("%w: correlationKey may only contain letters, digits and . _ : -", models.ErrInvalidPayload)
+	}
+	return key, nil
+}
+
+// stampCorrelationKey records key on issue, in the configured custom field or
+// else as a label.
+func (ja *JiraAdapter) stampCorrelationKey(issue *jira.Issue, key string) {
+	if field := ja.config.CorrelationField; field != "" {
+		if issue.Fields.Unknowns == nil {
+			issue.Fields.Unknowns = map[string]interface{}{}
+		}
+		issue.Fields.Unknowns[field] = key
+		return
+	}
+	issue.Fields.Labels = append(issue.Fields.Labels, jiraCorrelationLabelPrefix+key)
+}
+
+// jiraRequestLabelPrefix prefixes the label that records the X-Request-ID of
+// the API request an issue was created for.
+const jiraRequestLabelPrefix = "taskstream-request-"
+
+// stampRequestID labels issue with the request ID carried by ctx, if any, so
+// the issue can be found from the request that created it.
+func stampRequestID(ctx context.Context, issue *jira.Issue) {
+	if requestID := models.RequestIDFrom(ctx); requestID != "" {
+		issue.Fields.Labels = append(issue.Fields.Labels, jiraRequestLabelPrefix+requestID)
+	}
+}
+
+// findCorrelated searches the issue's project for an issue already carrying
+// key, returning nil when there is none. Jira's search index can lag a create
+// by a moment, so an issue created immediately before may not be found yet.
+func (ja *JiraAdapter) findCorrelated(ctx context.Context, projectKey, key string) (*jira.Issue, error) {
+	field := ja.config.CorrelationField
+	var jql string
+	options := &jira.SearchOptions{MaxResults: 5, Fields: []string{"key"}}
+	if field != "" {
+		// Text fields only support a contains match, so matches are checked exactly below.
+		jql = fmt.Sprintf(`project = "%s" AND %s ~ "%s"`, projectKey, jqlFieldName(field), key)
+		options.Fields = append(options.Fields, field)
+	} else {
+		jql = fmt.Sprintf(`project = "%s" AND labels = "%s"`, projectKey, jiraCorrelationLabelPrefix+key)
+	}
+
+	issues, _, err := ja.currentClient().Issue.SearchWithContext(ctx, jql, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search Jira for correlation key %s: %w", key, err)
+	}
+	for i := range issues {
+		if field == "" {
+			return &issues[i], nil
+		}
+		if issues[i].Fields != nil && issues[i].Fields.Unknowns[field] == key {
+			return &issues[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// jqlFieldName converts a custom field ID such as "customfield_10050" to its
+// JQL form, "cf[10050]".
+func jqlFieldName(field string) string {
+	if id := strings.TrimPrefix(field, "customfield_"); id != field {
+		return "cf[" + id + "]"
+	}
+	return field
+}
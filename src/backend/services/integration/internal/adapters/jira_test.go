@@ -0,0 +1,107 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"golang.org/x/time/rate"
+
+	"src/backend/services/integration/internal/config"
+)
+
+// newMockJira serves the Jira REST endpoints the adapter uses, creating
+// issues in project LOAD, as cmd/loadgen's mock provider does.
+func newMockJira(tb testing.TB) *httptest.Server {
+	tb.Helper()
+	var issueSeq atomic.Int64
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/2/myself", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"name":"loadgen","displayName":"Load Generator","active":true}`)
+	})
+	mux.HandleFunc("/rest/api/2/issue/createmeta", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"projects":[{"key":"LOAD","issuetypes":[{"name":"Task"},{"name":"Bug"}]}]}`)
+	})
+	mux.HandleFunc("/rest/api/2/issue", func(w http.ResponseWriter, r *http.Request) {
+		id := issueSeq.Add(1)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprintf(w, `{"id":"%d","key":"LOAD-%d"}`, id, id)
+	})
+	mux.HandleFunc("/rest/api/2/issue/bulk", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			IssueUpdates []json.RawMessage `json:"issueUpdates"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		keys := make([]string, len(req.IssueUpdates))
+		for i := range keys {
+			keys[i] = fmt.Sprintf(`{"key":"LOAD-%d"}`, issueSeq.Add(1))
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprintf(w, `{"issues":[%s],"errors":[]}`, strings.Join(keys, ","))
+	})
+	srv := httptest.NewServer(mux)
+	tb.Cleanup(srv.Close)
+	return srv
+}
+
+// newBenchJiraAdapter connects an adapter to the mock. Its rate limiter is
+// lifted so benchmarks measure the adapter rather than Jira's quota.
+func newBenchJiraAdapter(b *testing.B) *JiraAdapter {
+	b.Helper()
+	srv := newMockJira(b)
+	cfg := &config.JiraConfig{URL: srv.URL, Username: "loadgen", APIToken: "token", ProjectKey: "LOAD"}
+	ja := NewJiraAdapter(cfg)
+	if err := ja.InitializeWithContext(context.Background(), cfg); err != nil {
+		b.Fatalf("initialize: %v", err)
+	}
+	ja.rateLimiter = newAdaptiveLimiter(rate.Inf, 1, rate.Inf)
+	return ja
+}
+
+// BenchmarkJiraSend creates one issue per send.
+func BenchmarkJiraSend(b *testing.B) {
+	ja := newBenchJiraAdapter(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			payload := map[string]interface{}{"summary": "loadgen issue", "description": "created by a benchmark"}
+			if _, err := ja.SendWithResult(context.Background(), payload); err != nil {
+				b.Error(err)
+				return
+			}
+		}
+	})
+}
+
+// BenchmarkJiraSendBatch creates issues in bulk, a full bulk create call per
+// operation.
+func BenchmarkJiraSendBatch(b *testing.B) {
+	ja := newBenchJiraAdapter(b)
+	payloads := make([]interface{}, jiraBulkCreateLimit)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for n := range payloads {
+			payloads[n] = map[string]interface{}{"summary": "loadgen issue", "description": "created by a benchmark"}
+		}
+		results, err := ja.SendBatch(context.Background(), payloads)
+		if err != nil {
+			b.Fatal(err)
+		}
+		for _, result := range results {
+			if result.Err != nil {
+				b.Fatal(result.Err)
+			}
+		}
+	}
+	b.ReportMetric(float64(jiraBulkCreateLimit), "issues/op")
+}
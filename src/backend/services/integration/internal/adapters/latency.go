@@ -0,0 +1,78 @@
+package adapters
+
+import (
+	// go1.21 - Percentile selection and durations
+	"sort"
+	"time"
+)
+
+// latencyWindowSize is the number of recent samples percentiles are computed over.
+const latencyWindowSize = 512
+
+// latencyWindow keeps call latencies for a metricsCollector: a running count,
+// total and maximum, and the most recent samples for percentiles. It is
+// guarded by the collector's mutex.
+type latencyWindow struct {
+	count   int
+	total   time.Duration
+	max     time.Duration
+	samples []time.Duration
+	next    int
+}
+
+// LatencySummary reports the latencies recorded by a metricsCollector, in
+// milliseconds. Percentiles cover the most recent latencyWindowSize calls.
+type LatencySummary struct {
+	Count int     `json:"count"`
+	AvgMs float64 `json:"avgMs"`
+	P50Ms float64 `json:"p50Ms"`
+	P95Ms float64 `json:"p95Ms"`
+	P99Ms float64 `json:"p99Ms"`
+	MaxMs float64 `json:"maxMs"`
+}
+
+// RecordLatency notes how long a call took, whether or not it succeeded.
+func (mc *metricsCollector) RecordLatency(d time.Duration) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	w := &mc.latency
+	w.count++
+	w.total += d
+	if d > w.max {
+		w.max = d
+	}
+	if len(w.samples) < latencyWindowSize {
+		w.samples = append(w.samples, d)
+		return
+	}
+	w.samples[w.next] = d
+	w.next = (w.next + 1) % latencyWindowSize
+}
+
+// Latency summarizes the latencies recorded so far.
+func (mc *metricsCollector) Latency() LatencySummary {
+	mc.mu.Lock()
+	w := mc.latency
+	sorted := append([]time.Duration(nil), w.samples...)
+	mc.mu.Unlock()
+
+	summary := LatencySummary{Count: w.count, MaxMs: milliseconds(w.max)}
+	if w.count == 0 {
+		return summary
+	}
+	summary.AvgMs = milliseconds(w.total / time.Duration(w.count))
+
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	percentile := func(p float64) float64 {
+		return milliseconds(sorted[int(p*float64(len(sorted)-1))])
+	}
+	summary.P50Ms = percentile(0.50)
+	summary.P95Ms = percentile(0.95)
+	summary.P99Ms = percentile(0.99)
+	return summary
+}
+
+// milliseconds converts d to fractional milliseconds.
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,385 @@
+package adapters
+
+import (
+	// go1.21 - Context for cancellations and timeouts
+	"context"
+	// go1.21 - Sentinel errors and error wrapping
+	"errors"
+	"fmt"
+	// go1.21 - Request construction and room path escaping
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	// go1.21 - Guards the client during credential rotation; unique transaction IDs
+	"sync"
+	"sync/atomic"
+	"time"
+
+	// v0.1.0 - Token bucket rate limiting under the homeserver's message limits
+	"golang.org/x/time/rate"
+
+	// Internal imports for configuration, caching, transport and adapter contracts
+	"src/backend/services/integration/internal/cache"
+	"src/backend/services/integration/internal/config"
+	"src/backend/services/integration/internal/httpclient"
+	"src/backend/services/integration/internal/models"
+)
+
+// ErrInvalidMatrixConfig indicates that the Matrix configuration is missing the
+// homeserver URL or access token.
+var ErrInvalidMatrixConfig = errors.New("invalid matrix configuration or missing required fields")
+
+// ErrMatrixRoomRequired is returned for a message that names no room when no
+// default room is configured.
+var ErrMatrixRoomRequired = errors.New("matrix message requires a room and no default room is configured")
+
+// matrixStatusTimeout bounds the whoami call made by Status.
+const matrixStatusTimeout = 5 * time.Second
+
+// Matrix client-server API paths.
+const (
+	matrixPathWhoami    = "/_matrix/client/v3/account/whoami"
+	matrixPathDirectory = "/_matrix/client/v3/directory/room/"
+	matrixPathRooms     = "/_matrix/client/v3/rooms/"
+)
+
+// MatrixMessage is a message for a Matrix room. Send also accepts a plain
+// string, which is posted as text to the default room.
+type MatrixMessage struct {
+	// Room is a room ID ("!abc:example.org") or alias ("#ops:example.org");
+	// it defaults to the configured default room.
+	Room string `json:"room"`
+
+	// Body is the plain-text message, shown by clients that do not render HTML.
+	Body string `json:"body"`
+
+	// FormattedBody is an optional HTML rendering of Body.
+	FormattedBody string `json:"formattedBody,omitempty"`
+
+	// Notice posts the message as m.notice, which clients show as bot output
+	// and other bots ignore.
+	Notice bool `json:"notice,omitempty"`
+}
+
+// matrixRoomEvent is the content of an m.room.message event.
+type matrixRoomEvent struct {
+	MsgType       string `json:"msgtype"`
+	Body          string `json:"body"`
+	Format        string `json:"format,omitempty"`
+	FormattedBody string `json:"formatted_body,omitempty"`
+}
+
+// MatrixAdapter implements the Integration interface for Matrix rooms, posting
+// messages through a homeserver's client-server API as a bot account.
+type MatrixAdapter struct {
+	// mu guards the fields below, which change on initialization and rotation.
+	mu          sync.RWMutex
+	config      *config.MatrixConfig
+	client      *restClient
+	userID      string
+	connected   bool
+	lastSync    time.Time
+	initialized bool
+
+	// guard applies the circuit breaker, rate limiter and retries to each call.
+	guard *restGuard
+
+	// rooms caches alias to room ID lookups.
+	rooms *cache.TTL
+
+	// txnCounter makes transaction IDs unique within this process; retries of a
+	// send reuse its ID, so the homeserver delivers the message once.
+	txnCounter atomic.Uint64
+}
+
+// Compile-time check to ensure MatrixAdapter implements the Integration interface.
+var _ models.Integration = (*MatrixAdapter)(nil)
+
+// Compile-time check to ensure MatrixAdapter supports credential rotation.
+var _ models.CredentialRotator = (*MatrixAdapter)(nil)
+
+// Compile-time check to ensure MatrixAdapter exposes metadata invalidation.
+var _ models.MetadataInvalidator = (*MatrixAdapter)(nil)
+
+// Compile-time check to ensure MatrixAdapter reports its circuit state.
+var _ models.CircuitReporter = (*MatrixAdapter)(nil)
+
+// Compile-time check to ensure MatrixAdapter's circuit can be reset by operators.
+var _ models.CircuitResetter = (*MatrixAdapter)(nil)
+
+// Compile-time check to ensure MatrixAdapter reports its circuit's state and counters.
+var _ models.CircuitInspector = (*MatrixAdapter)(nil)
+
+// Compile-time check to ensure MatrixAdapter accepts a retry budget.
+var _ models.RetryBudgetUser = (*MatrixAdapter)(nil)
+
+// NewMatrixAdapter creates an uninitialized Matrix adapter. Its limiter allows
+// two messages per second with a burst of ten, backing off to one every ten
+// seconds while the homeserver throttles; bot accounts are often exempt from
+// homeserver rate limits, in which case the limiter never shrinks.
+func NewMatrixAdapter() *MatrixAdapter {
+	return &MatrixAdapter{
+		guard: newRESTGuard(newAdaptiveLimiter(rate.Limit(2), 10, rate.Every(10*time.Second))),
+		rooms: newMetadataCache(0),
+	}
+}
+
+// Initialize implements the Integration interface, bridging to
+// InitializeWithContext with a background context.
+func (ma *MatrixAdapter) Initialize(cfg interface{}) error {
+	return ma.InitializeWithContext(context.Background(), cfg)
+}
+
+// InitializeWithContext configures the adapter from a *config.MatrixConfig.
+// Steps:
+//  1. Validate the homeserver URL and access token.
+//  2. Build a client on the shared transport with the token as bearer credentials.
+//  3. Verify the token with a whoami call, recording the bot's user ID.
+func (ma *MatrixAdapter) InitializeWithContext(ctx context.Context, cfg interface{}) error {
+	// 1. Configuration.
+	mc, ok := cfg.(*config.MatrixConfig)
+	if !ok || mc == nil || mc.HomeserverURL == "" || mc.AccessToken == "" {
+		return ErrInvalidMatrixConfig
+	}
+
+	// 2. Client.
+	client := newMatrixClient(mc, mc.AccessToken)
+
+	// 3. Connectivity.
+	userID, err := matrixWhoami(ctx, client)
+	if err != nil {
+		ma.mu.Lock()
+		ma.connected = false
+		ma.mu.Unlock()
+		return fmt.Errorf("%w: matrix whoami: %v", models.ErrConnectionFailed, err)
+	}
+
+	ma.mu.Lock()
+	defer ma.mu.Unlock()
+	ma.config = mc
+	ma.client = client
+	ma.userID = userID
+	ma.connected = true
+	ma.initialized = true
+	ma.lastSync = time.Now()
+	ma.rooms = newMetadataCache(mc.MetadataTTL)
+	return nil
+}
+
+// RotateCredentials replaces the access token without a restart. The new token
+// is verified with a whoami call before it replaces the active client; on
+// failure the adapter keeps its previous client.
+func (ma *MatrixAdapter) RotateCredentials(ctx context.Context, creds models.Credentials) error {
+	if creds.Secret == "" {
+		return models.ErrInvalidPayload
+	}
+
+	ma.mu.RLock()
+	mc := ma.config
+	ma.mu.RUnlock()
+	if mc == nil {
+		return models.ErrInitializationFailed
+	}
+
+	client := newMatrixClient(mc, creds.Secret)
+	userID, err := matrixWhoami(ctx, client)
+	if err != nil {
+		return fmt.Errorf("rotated matrix access token rejected: %w", err)
+	}
+
+	ma.mu.Lock()
+	defer ma.mu.Unlock()
+	ma.client = client
+	ma.userID = userID
+	ma.connected = true
+	return nil
+}
+
+// Send implements the Integration interface, bridging to SendWithContext with a
+// background context.
+func (ma *MatrixAdapter) Send(payload interface{}) error {
+	return ma.SendWithContext(context.Background(), payload)
+}
+
+// SendWithContext posts a message to a Matrix room. The payload is a string,
+// a MatrixMessage (or pointer), or an equivalent map.
+// Steps:
+//  1. Decode and validate the message, defaulting the room.
+//  2. Resolve a room alias to its room ID.
+//  3. PUT the m.room.message event under a transaction ID shared by retries.
+func (ma *MatrixAdapter) SendWithContext(ctx context.Context, payload interface{}) error {
+	ma.mu.RLock()
+	client, mc, initialized := ma.client, ma.config, ma.initialized
+	ma.mu.RUnlock()
+	if !initialized {
+		return models.ErrInitializationFailed
+	}
+
+	// 1. Message.
+	var msg MatrixMessage
+	if text, ok := payload.(string); ok {
+		msg.Body = text
+	} else if err := decodePayload(payload, &msg); err != nil {
+		return err
+	}
+	if strings.TrimSpace(msg.Body) == "" {
+		return fmt.Errorf("%w: matrix message body is empty", models.ErrInvalidPayload)
+	}
+	if msg.Room == "" {
+		msg.Room = mc.DefaultRoom
+	}
+	if msg.Room == "" {
+		return ErrMatrixRoomRequired
+	}
+
+	// 2. Room.
+	roomID, err := ma.resolveRoom(ctx, client, msg.Room)
+	if err != nil {
+		return err
+	}
+
+	// 3. Event.
+	event := matrixRoomEvent{MsgType: "m.text", Body: msg.Body}
+	if msg.Notice {
+		event.MsgType = "m.notice"
+	}
+	if msg.FormattedBody != "" {
+		event.Format = "org.matrix.custom.html"
+		event.FormattedBody = msg.FormattedBody
+	}
+	path := matrixPathRooms + url.PathEscape(roomID) + "/send/m.room.message/" + ma.nextTxnID()
+
+	err = ma.guard.call(ctx, func(ctx context.Context) error {
+		return client.doJSON(ctx, http.MethodPut, path, event, nil)
+	})
+	if err != nil {
+		return fmt.Errorf("matrix send to %s: %w", msg.Room, err)
+	}
+	ma.updateLastSync()
+	return nil
+}
+
+// resolveRoom returns the room ID for a room ID or alias, caching alias lookups.
+func (ma *MatrixAdapter) resolveRoom(ctx context.Context, client *restClient, room string) (string, error) {
+	if !strings.HasPrefix(room, "#") {
+		return room, nil
+	}
+	ma.mu.RLock()
+	rooms := ma.rooms
+	ma.mu.RUnlock()
+
+	value, err := rooms.GetOrLoad(ctx, metadataKeyMatrixRooms+room, func(ctx context.Context) (interface{}, error) {
+		var resp struct {
+			RoomID string `json:"room_id"`
+		}
+		if err := client.doJSON(ctx, http.MethodGet, matrixPathDirectory+url.PathEscape(room), nil, &resp); err != nil {
+			return nil, fmt.Errorf("matrix room alias %s: %w", room, err)
+		}
+		return resp.RoomID, nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return value.(string), nil
+}
+
+// nextTxnID returns a transaction ID unique to this process run.
+func (ma *MatrixAdapter) nextTxnID() string {
+	return "ts" + strconv.FormatInt(time.Now().UnixNano(), 36) + "." + strconv.FormatUint(ma.txnCounter.Add(1), 36)
+}
+
+// Status implements the Integration interface. It verifies the access token
+// end to end with a whoami call, so a revoked token or unreachable homeserver
+// reports disconnected.
+func (ma *MatrixAdapter) Status() (models.IntegrationStatus, error) {
+	ma.mu.RLock()
+	client, mc := ma.client, ma.config
+	ma.mu.RUnlock()
+
+	status := models.IntegrationStatus{
+		Name: "MatrixIntegration",
+		Type: "chat",
+	}
+	var checkErr error
+	if client != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), matrixStatusTimeout)
+		_, checkErr = matrixWhoami(ctx, client)
+		cancel()
+	}
+
+	ma.mu.Lock()
+	if client != nil {
+		ma.connected = checkErr == nil
+	}
+	status.Connected = ma.connected
+	status.LastSync = ma.lastSync
+	userID := ma.userID
+	ma.mu.Unlock()
+
+	ma.guard.fillStatus(&status)
+	status.Metadata["userId"] = userID
+	status.Metadata["roomCache"] = ma.rooms.Stats()
+	if mc != nil {
+		status.Metadata["homeserver"] = mc.HomeserverURL
+		status.Metadata["defaultRoom"] = mc.DefaultRoom
+	}
+	if checkErr != nil {
+		status.Metadata["lastCheckError"] = checkErr.Error()
+	}
+	return status, nil
+}
+
+// SetRetryBudget implements models.RetryBudgetUser.
+func (ma *MatrixAdapter) SetRetryBudget(budget models.RetryBudget) {
+	ma.guard.budget = budget
+}
+
+// CircuitOpen implements models.CircuitReporter.
+func (ma *MatrixAdapter) CircuitOpen() bool {
+	return ma.guard.breaker.IsOpen()
+}
+
+// CircuitSnapshot implements models.CircuitInspector.
+func (ma *MatrixAdapter) CircuitSnapshot() models.CircuitSnapshot {
+	return ma.guard.breaker.Snapshot()
+}
+
+// ResetCircuit implements models.CircuitResetter.
+func (ma *MatrixAdapter) ResetCircuit() {
+	ma.guard.breaker.Reset()
+}
+
+// InvalidateMetadata implements models.MetadataInvalidator.
+func (ma *MatrixAdapter) InvalidateMetadata(prefix string) int {
+	ma.mu.RLock()
+	defer ma.mu.RUnlock()
+	return ma.rooms.InvalidatePrefix(prefix)
+}
+
+// updateLastSync records a successful send.
+func (ma *MatrixAdapter) updateLastSync() {
+	ma.mu.Lock()
+	defer ma.mu.Unlock()
+	ma.lastSync = time.Now()
+	ma.connected = true
+}
+
+// newMatrixClient creates a client for the configured homeserver authenticated
+// with token.
+func newMatrixClient(mc *config.MatrixConfig, token string) *restClient {
+	return newRESTClient(mc.HomeserverURL, httpclient.Default().ClientWithTimeout(mc.Timeout), http.Header{
+		"Authorization": {"Bearer " + token},
+	})
+}
+
+// matrixWhoami returns the user ID the client's token belongs to.
+func matrixWhoami(ctx context.Context, client *restClient) (string, error) {
+	var resp struct {
+		UserID string `json:"user_id"`
+	}
+	if err := client.doJSON(ctx, http.MethodGet, matrixPathWhoami, nil, &resp); err != nil {
+		return "", err
+	}
+	return resp.UserID, nil
+}
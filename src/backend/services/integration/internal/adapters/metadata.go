@@ -0,0 +1,27 @@
+package adapters
+
+import (
+	// go1.21 - Cache lifetimes
+	"time"
+
+	// Internal TTL cache for provider metadata
+	"src/backend/services/integration/internal/cache"
+)
+
+// metadataCacheMaxEntries bounds each adapter's metadata cache, e.g. the number
+// of Jira projects or issues whose metadata is kept.
+const metadataCacheMaxEntries = 256
+
+// Metadata cache keys. Keys with a trailing slash are prefixes completed by a
+// project key or issue key, so they can be invalidated as a group.
+const (
+	metadataKeySlackChannels   = "channels"
+	metadataKeyJiraCreateMeta  = "createmeta/"
+	metadataKeyJiraTransitions = "transitions/"
+	metadataKeyMatrixRooms     = "rooms/"
+)
+
+// newMetadataCache creates an adapter's metadata cache. A zero ttl disables caching.
+func newMetadataCache(ttl time.Duration) *cache.TTL {
+	return cache.NewTTL(ttl, metadataCacheMaxEntries)
+}
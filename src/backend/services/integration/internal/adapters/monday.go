@@ -0,0 +1,472 @@
+package adapters
+
+import (
+	// go1.21 - Context for cancellations and timeouts
+	"context"
+	// go1.21 - Column values are sent as a JSON-encoded string
+	"encoding/json"
+	// go1.21 - Sentinel errors and error wrapping
+	"errors"
+	"fmt"
+	// go1.21 - Request construction
+	"net/http"
+	"strconv"
+	"strings"
+	// go1.21 - Guards the client during credential rotation; date parsing
+	"sync"
+	"time"
+
+	// v0.1.0 - Token bucket rate limiting under monday.com's per-minute quota
+	"golang.org/x/time/rate"
+
+	// Internal imports for configuration, transport and adapter contracts
+	"src/backend/services/integration/internal/config"
+	"src/backend/services/integration/internal/httpclient"
+	"src/backend/services/integration/internal/models"
+)
+
+// ErrInvalidMondayConfig indicates that the monday.com configuration is missing
+// the API URL or token.
+var ErrInvalidMondayConfig = errors.New("invalid monday configuration or missing required fields")
+
+// ErrMondayBoardRequired is returned for an item that names no board when no
+// default board is configured.
+var ErrMondayBoardRequired = errors.New("monday item requires a board and no default board is configured")
+
+// mondayCreateItem creates an item; column values are passed as a JSON string.
+const mondayCreateItem = `mutation ($board: ID!, $group: String, $name: String!, $columns: JSON) {
+  create_item(board_id: $board, group_id: $group, item_name: $name, column_values: $columns) { id }
+}`
+
+// mondayMe identifies the user the token belongs to.
+const mondayMe = `query { me { id name } }`
+
+// MondayItem is an item to create. Send also accepts the map payloads used for
+// Jira ("summary", "description").
+type MondayItem struct {
+	// Name is required; Summary is accepted as the Jira-style alias.
+	Name    string `json:"name"`
+	Summary string `json:"summary,omitempty"`
+
+	// Board is a board ID; it defaults to the configured board.
+	Board string `json:"board,omitempty"`
+
+	// Group is a group ID; it defaults to the configured group when the item
+	// goes to the default board.
+	Group string `json:"group,omitempty"`
+
+	// Values are column values keyed by the names in the configured column
+	// mapping. Names without a mapping are rejected.
+	Values map[string]interface{} `json:"values,omitempty"`
+
+	// Description is written to the column mapped as "description", if any.
+	Description string `json:"description,omitempty"`
+}
+
+// mondayResponse is the envelope of a GraphQL response. monday.com reports
+// most failures with a 200 status and the errors in the body.
+type mondayResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message    string `json:"message"`
+		Extensions struct {
+			Code           string  `json:"code"`
+			RetryInSeconds float64 `json:"retry_in_seconds"`
+		} `json:"extensions"`
+	} `json:"errors"`
+	ErrorCode    string `json:"error_code"`
+	ErrorMessage string `json:"error_message"`
+}
+
+// MondayAdapter implements the Integration interface for monday.com, creating
+// board items through the GraphQL API.
+type MondayAdapter struct {
+	// mu guards the fields below, which change on initialization and rotation.
+	mu          sync.RWMutex
+	config      *config.MondayConfig
+	client      *restClient
+	user        string
+	connected   bool
+	lastSync    time.Time
+	lastCreated string
+	initialized bool
+
+	// guard applies the circuit breaker, rate limiter and retries to each call.
+	guard *restGuard
+}
+
+// Compile-time check to ensure MondayAdapter implements the Integration interface.
+var _ models.Integration = (*MondayAdapter)(nil)
+
+// Compile-time check to ensure MondayAdapter supports credential rotation.
+var _ models.CredentialRotator = (*MondayAdapter)(nil)
+
+// Compile-time check to ensure MondayAdapter reports its circuit state.
+var _ models.CircuitReporter = (*MondayAdapter)(nil)
+
+// Compile-time check to ensure MondayAdapter's circuit can be reset by operators.
+var _ models.CircuitResetter = (*MondayAdapter)(nil)
+
+// Compile-time check to ensure MondayAdapter reports its circuit's state and counters.
+var _ models.CircuitInspector = (*MondayAdapter)(nil)
+
+// Compile-time check to ensure MondayAdapter accepts a retry budget.
+var _ models.RetryBudgetUser = (*MondayAdapter)(nil)
+
+// NewMondayAdapter creates an uninitialized monday.com adapter. Its limiter
+// allows two calls per second with a burst of five, well under the per-minute
+// request limit, backing off to one every ten seconds when throttled.
+func NewMondayAdapter() *MondayAdapter {
+	return &MondayAdapter{
+		guard: newRESTGuard(newAdaptiveLimiter(rate.Limit(2), 5, rate.Every(10*time.Second))),
+	}
+}
+
+// Initialize implements the Integration interface, bridging to
+// InitializeWithContext with a background context.
+func (ma *MondayAdapter) Initialize(cfg interface{}) error {
+	return ma.InitializeWithContext(context.Background(), cfg)
+}
+
+// InitializeWithContext configures the adapter from a *config.MondayConfig.
+// Steps:
+//  1. Validate the API URL and token.
+//  2. Build a client on the shared transport with the token and API version headers.
+//  3. Verify the token by querying the authenticated user.
+func (ma *MondayAdapter) InitializeWithContext(ctx context.Context, cfg interface{}) error {
+	// 1. Configuration.
+	mc, ok := cfg.(*config.MondayConfig)
+	if !ok || mc == nil || mc.APIURL == "" || mc.APIToken == "" {
+		return ErrInvalidMondayConfig
+	}
+
+	// 2. Client.
+	client := newMondayClient(mc, mc.APIToken)
+
+	// 3. Connectivity.
+	user, err := mondayWhoAmI(ctx, client)
+	if err != nil {
+		ma.mu.Lock()
+		ma.connected = false
+		ma.mu.Unlock()
+		return fmt.Errorf("%w: monday me query: %v", models.ErrConnectionFailed, err)
+	}
+
+	ma.mu.Lock()
+	defer ma.mu.Unlock()
+	ma.config = mc
+	ma.client = client
+	ma.user = user
+	ma.connected = true
+	ma.initialized = true
+	ma.lastSync = time.Now()
+	return nil
+}
+
+// RotateCredentials replaces the API token without a restart. The new token is
+// verified before it replaces the active client; on failure the adapter keeps
+// its previous client.
+func (ma *MondayAdapter) RotateCredentials(ctx context.Context, creds models.Credentials) error {
+	if creds.Secret == "" {
+		return models.ErrInvalidPayload
+	}
+
+	ma.mu.RLock()
+	mc := ma.config
+	ma.mu.RUnlock()
+	if mc == nil {
+		return models.ErrInitializationFailed
+	}
+
+	client := newMondayClient(mc, creds.Secret)
+	user, err := mondayWhoAmI(ctx, client)
+	if err != nil {
+		return fmt.Errorf("rotated monday token rejected: %w", err)
+	}
+
+	ma.mu.Lock()
+	defer ma.mu.Unlock()
+	ma.client = client
+	ma.user = user
+	ma.connected = true
+	return nil
+}
+
+// Send implements the Integration interface, bridging to SendWithContext with a
+// background context.
+func (ma *MondayAdapter) Send(payload interface{}) error {
+	return ma.SendWithContext(context.Background(), payload)
+}
+
+// SendWithContext creates an item. The payload is a MondayItem (or pointer), or
+// an equivalent map.
+// Steps:
+//  1. Decode the payload and encode its values through the column mapping.
+//  2. Run the create_item mutation.
+func (ma *MondayAdapter) SendWithContext(ctx context.Context, payload interface{}) error {
+	ma.mu.RLock()
+	client, mc, initialized := ma.client, ma.config, ma.initialized
+	ma.mu.RUnlock()
+	if !initialized {
+		return models.ErrInitializationFailed
+	}
+
+	// 1. Item.
+	var item MondayItem
+	if err := decodePayload(payload, &item); err != nil {
+		return err
+	}
+	variables, err := buildMondayItem(mc, &item)
+	if err != nil {
+		return err
+	}
+
+	// 2. Create.
+	var created struct {
+		CreateItem struct {
+			ID string `json:"id"`
+		} `json:"create_item"`
+	}
+	err = ma.guard.create(ctx, func(ctx context.Context) error {
+		return mondayQuery(ctx, client, mondayCreateItem, variables, &created)
+	})
+	if err != nil {
+		return fmt.Errorf("monday create item: %w", err)
+	}
+
+	ma.mu.Lock()
+	ma.lastSync = time.Now()
+	ma.lastCreated = created.CreateItem.ID
+	ma.connected = true
+	ma.mu.Unlock()
+	return nil
+}
+
+// buildMondayItem validates item and converts it into the create_item mutation
+// variables.
+func buildMondayItem(mc *config.MondayConfig, item *MondayItem) (map[string]interface{}, error) {
+	name := firstNonEmpty(item.Name, item.Summary)
+	if strings.TrimSpace(name) == "" {
+		return nil, fmt.Errorf("%w: missing required 'name' field in payload", models.ErrInvalidPayload)
+	}
+
+	board := firstNonEmpty(item.Board, mc.DefaultBoard)
+	if board == "" {
+		return nil, ErrMondayBoardRequired
+	}
+	variables := map[string]interface{}{
+		"board": board,
+		"name":  name,
+	}
+
+	// The default group belongs to the default board only.
+	group := item.Group
+	if group == "" && board == mc.DefaultBoard {
+		group = mc.DefaultGroup
+	}
+	if group != "" {
+		variables["group"] = group
+	}
+
+	values := make(map[string]interface{}, len(item.Values)+1)
+	for key, value := range item.Values {
+		values[key] = value
+	}
+	if _, mapped := mc.Columns["description"]; mapped && item.Description != "" {
+		if _, set := values["description"]; !set {
+			values["description"] = item.Description
+		}
+	}
+
+	columns := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		column, ok := mc.Columns[strings.ToLower(key)]
+		if !ok {
+			return nil, fmt.Errorf("%w: no monday column is mapped for value %q", models.ErrInvalidPayload, key)
+		}
+		encoded, err := mondayColumnValue(column.Type, value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: value %q: %v", models.ErrInvalidPayload, key, err)
+		}
+		columns[column.ID] = encoded
+	}
+	if len(columns) > 0 {
+		data, err := json.Marshal(columns)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", models.ErrInvalidPayload, err)
+		}
+		variables["columns"] = string(data)
+	}
+	return variables, nil
+}
+
+// mondayColumnValue encodes value in the form monday.com expects for a column
+// of the given type.
+func mondayColumnValue(columnType string, value interface{}) (interface{}, error) {
+	text := strings.TrimSpace(fmt.Sprint(value))
+	switch columnType {
+	case config.MondayColumnText:
+		return text, nil
+	case config.MondayColumnLongText:
+		return map[string]string{"text": text}, nil
+	case config.MondayColumnNumbers:
+		if _, err := strconv.ParseFloat(text, 64); err != nil {
+			return nil, fmt.Errorf("%q is not a number", text)
+		}
+		return text, nil
+	case config.MondayColumnStatus:
+		return map[string]string{"label": text}, nil
+	case config.MondayColumnEmail:
+		return map[string]string{"email": text, "text": text}, nil
+	case config.MondayColumnDate:
+		if _, err := time.Parse(time.DateOnly, text); err == nil {
+			return map[string]string{"date": text}, nil
+		}
+		at, err := time.Parse(time.RFC3339, text)
+		if err != nil {
+			return nil, fmt.Errorf("%q is neither a date nor an RFC 3339 time", text)
+		}
+		at = at.UTC()
+		return map[string]string{"date": at.Format(time.DateOnly), "time": at.Format(time.TimeOnly)}, nil
+	case config.MondayColumnDropdown:
+		return map[string][]string{"labels": mondayList(value)}, nil
+	case config.MondayColumnPeople:
+		people := make([]map[string]interface{}, 0)
+		for _, id := range mondayList(value) {
+			n, err := strconv.ParseInt(id, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%q is not a monday user ID", id)
+			}
+			people = append(people, map[string]interface{}{"id": n, "kind": "person"})
+		}
+		return map[string]interface{}{"personsAndTeams": people}, nil
+	default:
+		return nil, fmt.Errorf("unsupported column type %q", columnType)
+	}
+}
+
+// mondayList returns value as a list of strings: a JSON array's elements, or a
+// comma-separated string's parts.
+func mondayList(value interface{}) []string {
+	var items []string
+	if list, ok := value.([]interface{}); ok {
+		for _, v := range list {
+			items = append(items, strings.TrimSpace(fmt.Sprint(v)))
+		}
+		return items
+	}
+	for _, v := range strings.Split(fmt.Sprint(value), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			items = append(items, v)
+		}
+	}
+	return items
+}
+
+// Status implements the Integration interface, reporting connectivity as of the
+// last call along with the circuit, limiter and call metrics.
+func (ma *MondayAdapter) Status() (models.IntegrationStatus, error) {
+	ma.mu.RLock()
+	status := models.IntegrationStatus{
+		Connected: ma.connected,
+		Name:      "MondayIntegration",
+		Type:      "project_management",
+		LastSync:  ma.lastSync,
+	}
+	mc, user, lastCreated := ma.config, ma.user, ma.lastCreated
+	ma.mu.RUnlock()
+
+	ma.guard.fillStatus(&status)
+	status.Metadata["user"] = user
+	status.Metadata["lastItemId"] = lastCreated
+	if mc != nil {
+		status.Metadata["defaultBoard"] = mc.DefaultBoard
+	}
+	return status, nil
+}
+
+// SetRetryBudget implements models.RetryBudgetUser.
+func (ma *MondayAdapter) SetRetryBudget(budget models.RetryBudget) {
+	ma.guard.budget = budget
+}
+
+// CircuitOpen implements models.CircuitReporter.
+func (ma *MondayAdapter) CircuitOpen() bool {
+	return ma.guard.breaker.IsOpen()
+}
+
+// CircuitSnapshot implements models.CircuitInspector.
+func (ma *MondayAdapter) CircuitSnapshot() models.CircuitSnapshot {
+	return ma.guard.breaker.Snapshot()
+}
+
+// ResetCircuit implements models.CircuitResetter.
+func (ma *MondayAdapter) ResetCircuit() {
+	ma.guard.breaker.Reset()
+}
+
+// newMondayClient creates a client for the monday.com API authenticated with
+// token. The API takes the token itself as the Authorization header.
+func newMondayClient(mc *config.MondayConfig, token string) *restClient {
+	header := http.Header{"Authorization": {token}}
+	if mc.APIVersion != "" {
+		header.Set("API-Version", mc.APIVersion)
+	}
+	return newRESTClient(mc.APIURL, httpclient.Default().ClientWithTimeout(mc.Timeout), header)
+}
+
+// mondayQuery runs a GraphQL query and decodes its data into out. Errors
+// reported in the body are returned as *restError so the guard treats them
+// like HTTP failures: exhausted complexity or rate budgets as 429, anything
+// else as a rejected request.
+func mondayQuery(ctx context.Context, client *restClient, query string, variables map[string]interface{}, out interface{}) error {
+	body := map[string]interface{}{"query": query}
+	if variables != nil {
+		body["variables"] = variables
+	}
+
+	var resp mondayResponse
+	if err := client.doJSON(ctx, http.MethodPost, "", body, &resp); err != nil {
+		return err
+	}
+
+	if len(resp.Errors) > 0 || resp.ErrorMessage != "" {
+		rejected := &restError{StatusCode: http.StatusUnprocessableEntity, Body: resp.ErrorMessage}
+		for _, e := range resp.Errors {
+			switch e.Extensions.Code {
+			case "ComplexityException", "COMPLEXITY_BUDGET_EXHAUSTED", "RATE_LIMIT_EXCEEDED":
+				rejected.StatusCode = http.StatusTooManyRequests
+				rejected.RetryAfter = time.Duration(e.Extensions.RetryInSeconds * float64(time.Second))
+			}
+			if rejected.Body == "" {
+				rejected.Body = e.Message
+			}
+		}
+		if resp.ErrorCode == "ComplexityException" {
+			rejected.StatusCode = http.StatusTooManyRequests
+		}
+		return rejected
+	}
+
+	if out == nil || len(resp.Data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(resp.Data, out); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	return nil
+}
+
+// mondayWhoAmI returns the name of the user the client's token belongs to.
+func mondayWhoAmI(ctx context.Context, client *restClient) (string, error) {
+	var resp struct {
+		Me struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		} `json:"me"`
+	}
+	if err := mondayQuery(ctx, client, mondayMe, nil, &resp); err != nil {
+		return "", err
+	}
+	return resp.Me.Name, nil
+}
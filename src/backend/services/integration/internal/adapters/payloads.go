@@ -0,0 +1,18 @@
+package adapters
+
+import (
+	// Internal models for the payload type registry
+	"src/backend/services/integration/internal/models"
+)
+
+// init registers the typed payloads adapters accept, so that sends spilled from
+// the dispatch queue or stored for replay decode back into the same types
+// rather than into maps.
+func init() {
+	models.RegisterPayloadType("email", EmailPayload{})
+	models.RegisterPayloadType("slack", SlackMessage{})
+	models.RegisterPayloadType("splunk", SplunkEvent{})
+	models.RegisterPayloadType("webhook", WebhookMessage{})
+	models.RegisterPayloadType("push", PushMessage{})
+	models.RegisterPayloadType("matrix", MatrixMessage{})
+}
@@ -0,0 +1,417 @@
+package adapters
+
+import (
+	// go1.21 - Context for cancellations and timeouts
+	"context"
+	// go1.21 - Message data encoding
+	"encoding/base64"
+	"encoding/json"
+	// go1.21 - Sentinel errors and error wrapping
+	"errors"
+	"fmt"
+	// go1.21 - Request construction and topic path escaping
+	"net/http"
+	"net/url"
+	"os"
+	// go1.21 - Guards the client and ordering keys; latency measurement
+	"sync"
+	"time"
+
+	// v0.13.0 - Application Default Credentials and service account tokens
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+	// v0.1.0 - Token bucket rate limiting of publish calls
+	"golang.org/x/time/rate"
+
+	// Internal imports for configuration, transport and adapter contracts
+	"src/backend/services/integration/internal/config"
+	"src/backend/services/integration/internal/httpclient"
+	"src/backend/services/integration/internal/models"
+)
+
+// pubSubScope is the OAuth scope for publishing.
+const pubSubScope = "https://www.googleapis.com/auth/pubsub"
+
+// pubSubMaxMessageBytes is Pub/Sub's limit on a message's data.
+const pubSubMaxMessageBytes = 10 << 20
+
+// ErrInvalidPubSubConfig indicates that the Pub/Sub configuration is missing
+// the endpoint or default topic, or that no project could be determined.
+var ErrInvalidPubSubConfig = errors.New("invalid pubsub configuration or missing required fields")
+
+// ErrOrderingKeyPaused is returned for a message whose ordering key is paused
+// after an earlier publish with that key failed. Publishing later messages
+// would deliver them ahead of the failed one; ResumeOrderingKey (or a circuit
+// reset) resumes the key once the failed message has been dealt with.
+var ErrOrderingKeyPaused = errors.New("pubsub ordering key paused after a failed publish")
+
+// PubSubMessage is a message to publish. Send also accepts the map payloads
+// used for other adapters: a payload without data is published whole as JSON.
+type PubSubMessage struct {
+	// Topic is a topic name from the configured mapping, or a topic ID; it
+	// defaults to the configured topic.
+	Topic string `json:"topic,omitempty"`
+
+	// Data is the message body: strings are sent as is, other values as JSON.
+	Data interface{} `json:"data,omitempty"`
+
+	// Attributes are the message's string attributes.
+	Attributes map[string]string `json:"attributes,omitempty"`
+
+	// OrderingKey delivers messages sharing it in publish order to subscriptions
+	// with message ordering enabled.
+	OrderingKey string `json:"orderingKey,omitempty"`
+}
+
+// pubSubPublishMessage is one message in a publish request.
+type pubSubPublishMessage struct {
+	Data        string            `json:"data"`
+	Attributes  map[string]string `json:"attributes,omitempty"`
+	OrderingKey string            `json:"orderingKey,omitempty"`
+}
+
+// PubSubAdapter implements the Integration interface for Google Cloud Pub/Sub,
+// publishing to topics through the REST API with Application Default
+// Credentials or a service account key.
+type PubSubAdapter struct {
+	// mu guards the fields below, which change on initialization and rotation.
+	mu            sync.RWMutex
+	config        *config.PubSubConfig
+	client        *restClient
+	project       string
+	connected     bool
+	lastSync      time.Time
+	lastMessageID string
+	initialized   bool
+
+	// orderingMu guards ordering and paused. Messages sharing an ordering key
+	// are published one at a time, under the key's lock.
+	orderingMu sync.Mutex
+	ordering   map[string]*sync.Mutex
+	paused     map[string]bool
+
+	// guard applies the circuit breaker, rate limiter and retries to each call;
+	// its metrics collector also records publish latency.
+	guard *restGuard
+}
+
+// Compile-time check to ensure PubSubAdapter implements the Integration interface.
+var _ models.Integration = (*PubSubAdapter)(nil)
+
+// Compile-time check to ensure PubSubAdapter supports credential rotation.
+var _ models.CredentialRotator = (*PubSubAdapter)(nil)
+
+// Compile-time check to ensure PubSubAdapter reports its circuit state.
+var _ models.CircuitReporter = (*PubSubAdapter)(nil)
+
+// Compile-time check to ensure PubSubAdapter's circuit can be reset by operators.
+var _ models.CircuitResetter = (*PubSubAdapter)(nil)
+
+// Compile-time check to ensure PubSubAdapter reports its circuit's state and counters.
+var _ models.CircuitInspector = (*PubSubAdapter)(nil)
+
+// Compile-time check to ensure PubSubAdapter accepts a retry budget.
+var _ models.RetryBudgetUser = (*PubSubAdapter)(nil)
+
+// NewPubSubAdapter creates an uninitialized Pub/Sub adapter. Publish quotas are
+// generous, so the limiter allows fifty calls per second with a burst of one
+// hundred, backing off to one per second on 429 responses.
+func NewPubSubAdapter() *PubSubAdapter {
+	return &PubSubAdapter{
+		ordering: make(map[string]*sync.Mutex),
+		paused:   make(map[string]bool),
+		guard:    newRESTGuard(newAdaptiveLimiter(rate.Limit(50), 100, rate.Every(time.Second))),
+	}
+}
+
+// Initialize implements the Integration interface, bridging to
+// InitializeWithContext with a background context.
+func (pa *PubSubAdapter) Initialize(cfg interface{}) error {
+	return pa.InitializeWithContext(context.Background(), cfg)
+}
+
+// InitializeWithContext configures the adapter from a *config.PubSubConfig.
+// Steps:
+//  1. Validate the endpoint and default topic.
+//  2. Load the service account key file, or find Application Default Credentials.
+//  3. Verify the credentials by obtaining a token, and build the client.
+func (pa *PubSubAdapter) InitializeWithContext(ctx context.Context, cfg interface{}) error {
+	// 1. Configuration.
+	pc, ok := cfg.(*config.PubSubConfig)
+	if !ok || pc == nil || pc.Endpoint == "" || pc.DefaultTopic == "" {
+		return ErrInvalidPubSubConfig
+	}
+
+	// 2. Credentials.
+	var keyJSON []byte
+	if pc.CredentialsFile != "" {
+		data, err := os.ReadFile(pc.CredentialsFile)
+		if err != nil {
+			return fmt.Errorf("%w: read credentials file: %v", ErrInvalidPubSubConfig, err)
+		}
+		keyJSON = data
+	}
+
+	// 3. Client.
+	client, project, err := newPubSubClient(pc, keyJSON)
+	if err != nil {
+		pa.mu.Lock()
+		pa.connected = false
+		pa.mu.Unlock()
+		return fmt.Errorf("%w: pubsub credentials: %v", models.ErrConnectionFailed, err)
+	}
+	if project == "" {
+		return fmt.Errorf("%w: projectID is not set and the credentials name no project", ErrInvalidPubSubConfig)
+	}
+
+	pa.mu.Lock()
+	defer pa.mu.Unlock()
+	pa.config = pc
+	pa.client = client
+	pa.project = project
+	pa.connected = true
+	pa.initialized = true
+	pa.lastSync = time.Now()
+	return nil
+}
+
+// RotateCredentials replaces the service account key without a restart.
+// Secret is the new key file's JSON. The key is verified by obtaining a token
+// before it replaces the active client; on failure the adapter keeps its
+// previous client.
+func (pa *PubSubAdapter) RotateCredentials(ctx context.Context, creds models.Credentials) error {
+	if creds.Secret == "" {
+		return models.ErrInvalidPayload
+	}
+
+	pa.mu.RLock()
+	pc := pa.config
+	pa.mu.RUnlock()
+	if pc == nil {
+		return models.ErrInitializationFailed
+	}
+
+	client, _, err := newPubSubClient(pc, []byte(creds.Secret))
+	if err != nil {
+		return fmt.Errorf("rotated pubsub credentials rejected: %w", err)
+	}
+
+	pa.mu.Lock()
+	defer pa.mu.Unlock()
+	pa.client = client
+	pa.connected = true
+	return nil
+}
+
+// Send implements the Integration interface, bridging to SendWithContext with a
+// background context.
+func (pa *PubSubAdapter) Send(payload interface{}) error {
+	return pa.SendWithContext(context.Background(), payload)
+}
+
+// SendWithContext publishes a message. The payload is a PubSubMessage (or
+// pointer), or an equivalent map.
+// Steps:
+//  1. Decode the payload and encode its data.
+//  2. For an ordered message, take the key's lock and refuse a paused key.
+//  3. Publish, recording the latency of the call; a failed ordered publish
+//     pauses its key.
+func (pa *PubSubAdapter) SendWithContext(ctx context.Context, payload interface{}) error {
+	pa.mu.RLock()
+	client, pc, project, initialized := pa.client, pa.config, pa.project, pa.initialized
+	pa.mu.RUnlock()
+	if !initialized {
+		return models.ErrInitializationFailed
+	}
+
+	// 1. Message.
+	var msg PubSubMessage
+	if err := decodePayload(payload, &msg); err != nil {
+		return err
+	}
+	if msg.Data == nil {
+		msg.Data = payload
+	}
+	data, err := pubSubData(msg.Data)
+	if err != nil {
+		return err
+	}
+	topic := mappedValue(pc.Topics, msg.Topic, pc.DefaultTopic)
+
+	// 2. Ordering.
+	if msg.OrderingKey != "" {
+		unlock, err := pa.lockOrderingKey(msg.OrderingKey)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+	}
+
+	// 3. Publish.
+	var published struct {
+		MessageIDs []string `json:"messageIds"`
+	}
+	body := map[string]interface{}{
+		"messages": []pubSubPublishMessage{{
+			Data:        base64.StdEncoding.EncodeToString(data),
+			Attributes:  msg.Attributes,
+			OrderingKey: msg.OrderingKey,
+		}},
+	}
+	path := "/v1/projects/" + url.PathEscape(project) + "/topics/" + url.PathEscape(topic) + ":publish"
+	err = pa.guard.call(ctx, func(ctx context.Context) error {
+		start := time.Now()
+		err := client.doJSON(ctx, http.MethodPost, path, body, &published)
+		pa.guard.metrics.RecordLatency(time.Since(start))
+		return err
+	})
+	if err != nil {
+		if msg.OrderingKey != "" {
+			pa.orderingMu.Lock()
+			pa.paused[msg.OrderingKey] = true
+			pa.orderingMu.Unlock()
+		}
+		return fmt.Errorf("pubsub publish to %s: %w", topic, err)
+	}
+
+	pa.mu.Lock()
+	pa.lastSync = time.Now()
+	if len(published.MessageIDs) > 0 {
+		pa.lastMessageID = published.MessageIDs[0]
+	}
+	pa.connected = true
+	pa.mu.Unlock()
+	return nil
+}
+
+// pubSubData encodes a message's data: strings as is, other values as JSON.
+func pubSubData(value interface{}) ([]byte, error) {
+	var data []byte
+	if s, ok := value.(string); ok {
+		data = []byte(s)
+	} else {
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", models.ErrInvalidPayload, err)
+		}
+		data = encoded
+	}
+	if len(data) > pubSubMaxMessageBytes {
+		return nil, fmt.Errorf("%w: message data is %d bytes, over the %d byte limit",
+			models.ErrInvalidPayload, len(data), pubSubMaxMessageBytes)
+	}
+	return data, nil
+}
+
+// lockOrderingKey takes key's lock, returning the function that releases it,
+// or ErrOrderingKeyPaused for a paused key.
+func (pa *PubSubAdapter) lockOrderingKey(key string) (func(), error) {
+	pa.orderingMu.Lock()
+	keyMu, ok := pa.ordering[key]
+	if !ok {
+		keyMu = &sync.Mutex{}
+		pa.ordering[key] = keyMu
+	}
+	pa.orderingMu.Unlock()
+
+	keyMu.Lock()
+	pa.orderingMu.Lock()
+	paused := pa.paused[key]
+	pa.orderingMu.Unlock()
+	if paused {
+		keyMu.Unlock()
+		return nil, fmt.Errorf("%w: %s", ErrOrderingKeyPaused, key)
+	}
+	return keyMu.Unlock, nil
+}
+
+// ResumeOrderingKey resumes publishing with a key paused by a failed publish.
+func (pa *PubSubAdapter) ResumeOrderingKey(key string) {
+	pa.orderingMu.Lock()
+	defer pa.orderingMu.Unlock()
+	delete(pa.paused, key)
+}
+
+// Status implements the Integration interface, reporting connectivity as of the
+// last call along with the circuit, limiter and call metrics, publish latency,
+// and paused ordering keys.
+func (pa *PubSubAdapter) Status() (models.IntegrationStatus, error) {
+	pa.mu.RLock()
+	status := models.IntegrationStatus{
+		Connected: pa.connected,
+		Name:      "PubSubIntegration",
+		Type:      "messaging",
+		LastSync:  pa.lastSync,
+	}
+	pc, project, lastMessageID := pa.config, pa.project, pa.lastMessageID
+	pa.mu.RUnlock()
+
+	pa.orderingMu.Lock()
+	paused := make([]string, 0, len(pa.paused))
+	for key := range pa.paused {
+		paused = append(paused, key)
+	}
+	pa.orderingMu.Unlock()
+
+	pa.guard.fillStatus(&status)
+	status.Metadata["project"] = project
+	status.Metadata["lastMessageId"] = lastMessageID
+	status.Metadata["publishLatency"] = pa.guard.metrics.Latency()
+	status.Metadata["pausedOrderingKeys"] = paused
+	if pc != nil {
+		status.Metadata["defaultTopic"] = pc.DefaultTopic
+	}
+	return status, nil
+}
+
+// SetRetryBudget implements models.RetryBudgetUser.
+func (pa *PubSubAdapter) SetRetryBudget(budget models.RetryBudget) {
+	pa.guard.budget = budget
+}
+
+// CircuitOpen implements models.CircuitReporter.
+func (pa *PubSubAdapter) CircuitOpen() bool {
+	return pa.guard.breaker.IsOpen()
+}
+
+// CircuitSnapshot implements models.CircuitInspector.
+func (pa *PubSubAdapter) CircuitSnapshot() models.CircuitSnapshot {
+	return pa.guard.breaker.Snapshot()
+}
+
+// ResetCircuit implements models.CircuitResetter. Paused ordering keys are
+// resumed too, since they were usually paused by the same outage.
+func (pa *PubSubAdapter) ResetCircuit() {
+	pa.guard.breaker.Reset()
+	pa.orderingMu.Lock()
+	pa.paused = make(map[string]bool)
+	pa.orderingMu.Unlock()
+}
+
+// newPubSubClient creates a client for the Pub/Sub API. keyJSON is a service
+// account key; when nil, Application Default Credentials are used. It returns
+// the configured project, or the credentials' project when none is configured.
+func newPubSubClient(pc *config.PubSubConfig, keyJSON []byte) (*restClient, string, error) {
+	// Token sources keep this context for refreshes, so it must outlive the
+	// caller's; the base client's timeout bounds each token request.
+	base := httpclient.Default().ClientWithTimeout(pc.Timeout)
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, base)
+
+	var creds *google.Credentials
+	var err error
+	if keyJSON != nil {
+		creds, err = google.CredentialsFromJSON(ctx, keyJSON, pubSubScope)
+	} else {
+		creds, err = google.FindDefaultCredentials(ctx, pubSubScope)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	if _, err := creds.TokenSource.Token(); err != nil {
+		return nil, "", fmt.Errorf("obtain token: %w", err)
+	}
+
+	client := oauth2.NewClient(ctx, creds.TokenSource)
+	client.Timeout = base.Timeout
+	return newRESTClient(pc.Endpoint, client, nil), firstNonEmpty(pc.ProjectID, creds.ProjectID), nil
+}
@@ -0,0 +1,722 @@
+package adapters
+
+import (
+	// go1.21 - Context for cancellations and timeouts
+	"context"
+	// go1.21 - APNs provider token signing
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	// go1.21 - Sentinel errors and error wrapping
+	"errors"
+	"fmt"
+	// go1.21 - Request construction and device token escaping
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	// go1.21 - Guards the clients and invalidated tokens
+	"sync"
+	"time"
+
+	// v0.13.0 - Application Default Credentials and service account tokens
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+	// v0.1.0 - Token bucket rate limiting of push calls
+	"golang.org/x/time/rate"
+
+	// Internal imports for configuration, transport and adapter contracts
+	"src/backend/services/integration/internal/config"
+	"src/backend/services/integration/internal/httpclient"
+	"src/backend/services/integration/internal/models"
+)
+
+// Push platforms for PushDevice.Platform.
+const (
+	PushPlatformFCM  = "fcm"
+	PushPlatformAPNs = "apns"
+)
+
+// Push service endpoints and scopes.
+const (
+	fcmDefaultEndpoint     = "https://fcm.googleapis.com"
+	fcmScope               = "https://www.googleapis.com/auth/firebase.messaging"
+	apnsProductionEndpoint = "https://api.push.apple.com"
+	apnsSandboxEndpoint    = "https://api.sandbox.push.apple.com"
+)
+
+// apnsTokenLifetime is how long an APNs provider token is reused. APNs rejects
+// tokens older than an hour and throttles tokens refreshed more often than
+// every twenty minutes.
+const apnsTokenLifetime = 50 * time.Minute
+
+// ErrInvalidPushConfig indicates that the push configuration enables no
+// platform, or a platform's credentials cannot be loaded.
+var ErrInvalidPushConfig = errors.New("invalid push configuration or missing platform credentials")
+
+// ErrPushPlatformNotConfigured is returned for a device on a platform without
+// a configuration block.
+var ErrPushPlatformNotConfigured = errors.New("push platform not configured")
+
+// ErrDeviceTokenInvalid is returned for a device whose token the platform
+// reports as unregistered or malformed. The token is reported to the feedback
+// URL and refused from then on.
+var ErrDeviceTokenInvalid = errors.New("device token is no longer valid")
+
+// PushDevice is a device to notify.
+type PushDevice struct {
+	// Platform is PushPlatformFCM or PushPlatformAPNs.
+	Platform string `json:"platform"`
+
+	// Token is the registration token (FCM) or device token (APNs).
+	Token string `json:"token"`
+}
+
+// ParsePushDevice parses a device written as "platform:token".
+func ParsePushDevice(s string) (PushDevice, error) {
+	platform, token, ok := strings.Cut(s, ":")
+	if !ok || token == "" {
+		return PushDevice{}, fmt.Errorf("%w: push device %q is not platform:token", models.ErrInvalidPayload, s)
+	}
+	return PushDevice{Platform: strings.ToLower(platform), Token: token}, nil
+}
+
+// PushMessage is a notification to deliver to one or more devices. A message
+// without a title or body is sent as a background (data-only) notification.
+type PushMessage struct {
+	// Devices are the recipients; at least one is required.
+	Devices []PushDevice `json:"devices"`
+
+	// Title and Body are the visible alert.
+	Title string `json:"title,omitempty"`
+	Body  string `json:"body,omitempty"`
+
+	// Data is delivered to the app with the notification.
+	Data map[string]string `json:"data,omitempty"`
+
+	// Badge sets the app icon badge on iOS; Sound names the alert sound.
+	Badge *int   `json:"badge,omitempty"`
+	Sound string `json:"sound,omitempty"`
+
+	// Priority is "high" (the default) or "normal".
+	Priority string `json:"priority,omitempty"`
+
+	// TTLSeconds is how long the platform keeps trying to deliver the
+	// notification to an offline device; zero uses the platform default.
+	TTLSeconds int `json:"ttlSeconds,omitempty"`
+
+	// CollapseKey replaces an undelivered notification with the same key.
+	CollapseKey string `json:"collapseKey,omitempty"`
+
+	// Topic overrides the configured APNs topic (bundle ID).
+	Topic string `json:"topic,omitempty"`
+}
+
+// pushFeedback is the body posted to the feedback URL for an invalid token.
+type pushFeedback struct {
+	Platform string    `json:"platform"`
+	Token    string    `json:"token"`
+	Reason   string    `json:"reason"`
+	Time     time.Time `json:"time"`
+}
+
+// apnsSigner issues APNs provider tokens, reusing each for apnsTokenLifetime.
+type apnsSigner struct {
+	teamID string
+	keyID  string
+	key    *ecdsa.PrivateKey
+
+	mu       sync.Mutex
+	token    string
+	issuedAt time.Time
+}
+
+// PushAdapter implements the Integration interface for mobile push
+// notifications, delivering through the FCM HTTP v1 API and APNs token-based
+// HTTP/2 API. Tokens a platform reports as invalid are reported to the
+// configured feedback URL and refused afterwards.
+type PushAdapter struct {
+	// mu guards the fields below, which change on initialization, rotation and
+	// sends.
+	mu          sync.RWMutex
+	config      *config.PushConfig
+	fcm         *restClient
+	fcmProject  string
+	apns        *restClient
+	apnsSigner  *apnsSigner
+	feedback    *restClient
+	invalid     map[string]time.Time
+	feedbackErr int
+	sent        map[string]int
+	connected   bool
+	lastSync    time.Time
+	initialized bool
+
+	// guard applies the circuit breaker, rate limiter and retries to each call.
+	guard *restGuard
+}
+
+// Compile-time check to ensure PushAdapter implements the Integration interface.
+var _ models.Integration = (*PushAdapter)(nil)
+
+// Compile-time check to ensure PushAdapter supports credential rotation.
+var _ models.CredentialRotator = (*PushAdapter)(nil)
+
+// Compile-time check to ensure PushAdapter reports its circuit state.
+var _ models.CircuitReporter = (*PushAdapter)(nil)
+
+// Compile-time check to ensure PushAdapter's circuit can be reset by operators.
+var _ models.CircuitResetter = (*PushAdapter)(nil)
+
+// Compile-time check to ensure PushAdapter reports its circuit's state and counters.
+var _ models.CircuitInspector = (*PushAdapter)(nil)
+
+// Compile-time check to ensure PushAdapter accepts a retry budget.
+var _ models.RetryBudgetUser = (*PushAdapter)(nil)
+
+// NewPushAdapter creates an uninitialized push adapter. Each device is one
+// call, so the limiter allows one hundred calls per second with a burst of two
+// hundred, backing off to one per second on 429 responses.
+func NewPushAdapter() *PushAdapter {
+	return &PushAdapter{
+		invalid: make(map[string]time.Time),
+		sent:    make(map[string]int),
+		guard:   newRESTGuard(newAdaptiveLimiter(rate.Limit(100), 200, rate.Every(time.Second))),
+	}
+}
+
+// Initialize implements the Integration interface, bridging to
+// InitializeWithContext with a background context.
+func (pa *PushAdapter) Initialize(cfg interface{}) error {
+	return pa.InitializeWithContext(context.Background(), cfg)
+}
+
+// InitializeWithContext configures the adapter from a *config.PushConfig.
+// Steps:
+//  1. Check that at least one platform is configured.
+//  2. For FCM, load the service account key or Application Default
+//     Credentials and verify them by obtaining a token.
+//  3. For APNs, load the .p8 key and sign a provider token with it.
+//  4. Build the feedback client when a feedback URL is set.
+func (pa *PushAdapter) InitializeWithContext(ctx context.Context, cfg interface{}) error {
+	// 1. Configuration.
+	pc, ok := cfg.(*config.PushConfig)
+	if !ok || !pc.Enabled() {
+		return ErrInvalidPushConfig
+	}
+
+	// 2. FCM.
+	var fcm *restClient
+	var project string
+	if pc.FCM != nil {
+		var keyJSON []byte
+		if pc.FCM.CredentialsFile != "" {
+			data, err := os.ReadFile(pc.FCM.CredentialsFile)
+			if err != nil {
+				return fmt.Errorf("%w: read fcm credentials file: %v", ErrInvalidPushConfig, err)
+			}
+			keyJSON = data
+		}
+		client, p, err := newFCMClient(pc, keyJSON)
+		if err != nil {
+			pa.mu.Lock()
+			pa.connected = false
+			pa.mu.Unlock()
+			return fmt.Errorf("%w: fcm credentials: %v", models.ErrConnectionFailed, err)
+		}
+		if p == "" {
+			return fmt.Errorf("%w: fcm projectID is not set and the credentials name no project", ErrInvalidPushConfig)
+		}
+		fcm, project = client, p
+	}
+
+	// 3. APNs.
+	var apns *restClient
+	var signer *apnsSigner
+	if ac := pc.APNs; ac != nil {
+		keyPEM, err := os.ReadFile(ac.PrivateKeyFile)
+		if err != nil {
+			return fmt.Errorf("%w: read apns private key: %v", ErrInvalidPushConfig, err)
+		}
+		signer, err = newAPNsSigner(ac.TeamID, ac.KeyID, keyPEM)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidPushConfig, err)
+		}
+		endpoint := ac.Endpoint
+		if endpoint == "" {
+			endpoint = apnsSandboxEndpoint
+			if ac.Production {
+				endpoint = apnsProductionEndpoint
+			}
+		}
+		apns = newRESTClient(endpoint, httpclient.Default().ClientWithTimeout(pc.Timeout), nil)
+	}
+
+	// 4. Feedback.
+	var feedback *restClient
+	if pc.FeedbackURL != "" {
+		feedback = newRESTClient(pc.FeedbackURL, httpclient.Default().ClientWithTimeout(pc.Timeout), nil)
+	}
+
+	pa.mu.Lock()
+	defer pa.mu.Unlock()
+	pa.config = pc
+	pa.fcm = fcm
+	pa.fcmProject = project
+	pa.apns = apns
+	pa.apnsSigner = signer
+	pa.feedback = feedback
+	pa.connected = true
+	pa.initialized = true
+	pa.lastSync = time.Now()
+	return nil
+}
+
+// RotateCredentials replaces one platform's credentials without a restart.
+// Username selects the platform: "fcm", with Secret the new service account
+// key's JSON, or "apns:KEYID", with Secret the new .p8 key and KEYID its key
+// ID. FCM keys are verified by obtaining a token and APNs keys by signing a
+// provider token; on failure the adapter keeps its previous credentials.
+func (pa *PushAdapter) RotateCredentials(ctx context.Context, creds models.Credentials) error {
+	if creds.Secret == "" {
+		return models.ErrInvalidPayload
+	}
+
+	pa.mu.RLock()
+	pc := pa.config
+	pa.mu.RUnlock()
+	if pc == nil {
+		return models.ErrInitializationFailed
+	}
+
+	platform, keyID, _ := strings.Cut(creds.Username, ":")
+	switch platform {
+	case PushPlatformFCM:
+		if pc.FCM == nil {
+			return ErrPushPlatformNotConfigured
+		}
+		client, _, err := newFCMClient(pc, []byte(creds.Secret))
+		if err != nil {
+			return fmt.Errorf("rotated fcm credentials rejected: %w", err)
+		}
+		pa.mu.Lock()
+		pa.fcm = client
+		pa.mu.Unlock()
+	case PushPlatformAPNs:
+		if pc.APNs == nil {
+			return ErrPushPlatformNotConfigured
+		}
+		signer, err := newAPNsSigner(pc.APNs.TeamID, firstNonEmpty(keyID, pc.APNs.KeyID), []byte(creds.Secret))
+		if err != nil {
+			return fmt.Errorf("rotated apns key rejected: %w", err)
+		}
+		pa.mu.Lock()
+		pa.apnsSigner = signer
+		pa.mu.Unlock()
+	default:
+		return fmt.Errorf("%w: username must name the platform, fcm or apns:KEYID", models.ErrInvalidPayload)
+	}
+	return nil
+}
+
+// Send implements the Integration interface, bridging to SendWithContext with a
+// background context.
+func (pa *PushAdapter) Send(payload interface{}) error {
+	return pa.SendWithContext(context.Background(), payload)
+}
+
+// SendWithContext delivers a notification to each of its devices. The payload
+// is a PushMessage (or pointer), or an equivalent map. The error joins the
+// failures of individual devices; devices that succeeded are not retried.
+// Steps:
+//  1. Decode the payload and check that it names devices.
+//  2. Send to each device through its platform, refusing invalidated tokens.
+//  3. Forget tokens the platform rejected and report them to the feedback URL.
+func (pa *PushAdapter) SendWithContext(ctx context.Context, payload interface{}) error {
+	pa.mu.RLock()
+	initialized := pa.initialized
+	pa.mu.RUnlock()
+	if !initialized {
+		return models.ErrInitializationFailed
+	}
+
+	// 1. Message.
+	var msg PushMessage
+	if err := decodePayload(payload, &msg); err != nil {
+		return err
+	}
+	if len(msg.Devices) == 0 {
+		return fmt.Errorf("%w: at least one device is required", models.ErrInvalidPayload)
+	}
+
+	// 2. Delivery.
+	var errs []error
+	var invalid []pushFeedback
+	for _, device := range msg.Devices {
+		if pa.isInvalid(device) {
+			errs = append(errs, fmt.Errorf("%s device %s: %w", device.Platform, device.Token, ErrDeviceTokenInvalid))
+			continue
+		}
+		reason, err := pa.sendDevice(ctx, device, &msg)
+		if err == nil {
+			continue
+		}
+		if reason != "" {
+			invalid = append(invalid, pushFeedback{Platform: device.Platform, Token: device.Token, Reason: reason, Time: time.Now()})
+			err = fmt.Errorf("%w (%s)", ErrDeviceTokenInvalid, reason)
+		}
+		errs = append(errs, fmt.Errorf("%s device %s: %w", device.Platform, device.Token, err))
+	}
+
+	// 3. Feedback.
+	pa.reportInvalid(ctx, invalid)
+
+	pa.mu.Lock()
+	if len(errs) < len(msg.Devices) {
+		pa.lastSync = time.Now()
+		pa.connected = true
+	}
+	pa.mu.Unlock()
+	return errors.Join(errs...)
+}
+
+// sendDevice delivers msg to one device. For a token the platform rejects as
+// invalid, it also returns the platform's reason.
+func (pa *PushAdapter) sendDevice(ctx context.Context, device PushDevice, msg *PushMessage) (string, error) {
+	pa.mu.RLock()
+	pc, fcm, project, apns, signer := pa.config, pa.fcm, pa.fcmProject, pa.apns, pa.apnsSigner
+	pa.mu.RUnlock()
+
+	var err error
+	switch device.Platform {
+	case PushPlatformFCM:
+		if fcm == nil {
+			return "", fmt.Errorf("%w: %s", ErrPushPlatformNotConfigured, device.Platform)
+		}
+		path := "/v1/projects/" + url.PathEscape(project) + "/messages:send"
+		body := map[string]interface{}{"message": fcmMessage(device.Token, msg)}
+		err = pa.guard.call(ctx, func(ctx context.Context) error {
+			return fcm.doJSON(ctx, http.MethodPost, path, body, nil)
+		})
+	case PushPlatformAPNs:
+		if apns == nil {
+			return "", fmt.Errorf("%w: %s", ErrPushPlatformNotConfigured, device.Platform)
+		}
+		header, body := apnsRequest(pc.APNs, msg)
+		err = pa.guard.call(ctx, func(ctx context.Context) error {
+			token, err := signer.providerToken()
+			if err != nil {
+				return err
+			}
+			h := header.Clone()
+			h.Set("Authorization", "bearer "+token)
+			return apns.do(ctx, restRequest{
+				method: http.MethodPost,
+				path:   "/3/device/" + url.PathEscape(device.Token),
+				header: h,
+				body:   body,
+			}, nil)
+		})
+	default:
+		return "", fmt.Errorf("%w: unknown push platform %q", models.ErrInvalidPayload, device.Platform)
+	}
+	if err != nil {
+		return invalidTokenReason(device.Platform, err), err
+	}
+
+	pa.mu.Lock()
+	pa.sent[device.Platform]++
+	pa.mu.Unlock()
+	return "", nil
+}
+
+// fcmMessage builds the FCM v1 message for token.
+func fcmMessage(token string, msg *PushMessage) map[string]interface{} {
+	m := map[string]interface{}{"token": token}
+	if msg.Title != "" || msg.Body != "" {
+		m["notification"] = map[string]string{"title": msg.Title, "body": msg.Body}
+	}
+	if len(msg.Data) > 0 {
+		m["data"] = msg.Data
+	}
+
+	android := map[string]interface{}{"priority": "HIGH"}
+	if msg.Priority == "normal" {
+		android["priority"] = "NORMAL"
+	}
+	if msg.TTLSeconds > 0 {
+		android["ttl"] = strconv.Itoa(msg.TTLSeconds) + "s"
+	}
+	if msg.CollapseKey != "" {
+		android["collapse_key"] = msg.CollapseKey
+	}
+	if msg.Sound != "" && (msg.Title != "" || msg.Body != "") {
+		android["notification"] = map[string]string{"sound": msg.Sound}
+	}
+	m["android"] = android
+	return m
+}
+
+// apnsRequest builds the headers and JSON payload of an APNs request for msg.
+// Data entries are added beside the aps dictionary.
+func apnsRequest(ac *config.APNsConfig, msg *PushMessage) (http.Header, map[string]interface{}) {
+	header := http.Header{}
+	header.Set("apns-topic", firstNonEmpty(msg.Topic, ac.Topic))
+
+	aps := map[string]interface{}{}
+	alert := msg.Title != "" || msg.Body != ""
+	if alert {
+		aps["alert"] = map[string]string{"title": msg.Title, "body": msg.Body}
+		header.Set("apns-push-type", "alert")
+		header.Set("apns-priority", "10")
+		if msg.Priority == "normal" {
+			header.Set("apns-priority", "5")
+		}
+	} else {
+		// Background notifications must be sent at low priority.
+		aps["content-available"] = 1
+		header.Set("apns-push-type", "background")
+		header.Set("apns-priority", "5")
+	}
+	if msg.Badge != nil {
+		aps["badge"] = *msg.Badge
+	}
+	if msg.Sound != "" {
+		aps["sound"] = msg.Sound
+	}
+	if msg.TTLSeconds > 0 {
+		header.Set("apns-expiration", strconv.FormatInt(time.Now().Add(time.Duration(msg.TTLSeconds)*time.Second).Unix(), 10))
+	}
+	if msg.CollapseKey != "" {
+		header.Set("apns-collapse-id", msg.CollapseKey)
+	}
+
+	body := map[string]interface{}{"aps": aps}
+	for key, value := range msg.Data {
+		if key != "aps" {
+			body[key] = value
+		}
+	}
+	return header, body
+}
+
+// invalidTokenReason returns the platform's reason when err reports the
+// device token as unregistered or malformed, and "" for other failures.
+func invalidTokenReason(platform string, err error) string {
+	var re *restError
+	if !errors.As(err, &re) {
+		return ""
+	}
+	switch platform {
+	case PushPlatformFCM:
+		var body struct {
+			Error struct {
+				Status  string `json:"status"`
+				Details []struct {
+					ErrorCode string `json:"errorCode"`
+				} `json:"details"`
+			} `json:"error"`
+		}
+		_ = json.Unmarshal([]byte(re.Body), &body)
+		for _, d := range body.Error.Details {
+			if d.ErrorCode == "UNREGISTERED" {
+				return d.ErrorCode
+			}
+		}
+		if re.StatusCode == http.StatusNotFound {
+			return firstNonEmpty(body.Error.Status, "NOT_FOUND")
+		}
+	case PushPlatformAPNs:
+		var body struct {
+			Reason string `json:"reason"`
+		}
+		_ = json.Unmarshal([]byte(re.Body), &body)
+		switch {
+		case re.StatusCode == http.StatusGone:
+			return firstNonEmpty(body.Reason, "Unregistered")
+		case body.Reason == "BadDeviceToken" || body.Reason == "DeviceTokenNotForTopic":
+			return body.Reason
+		}
+	}
+	return ""
+}
+
+// isInvalid reports whether device's token was invalidated by an earlier send.
+func (pa *PushAdapter) isInvalid(device PushDevice) bool {
+	pa.mu.RLock()
+	defer pa.mu.RUnlock()
+	_, ok := pa.invalid[device.Platform+":"+device.Token]
+	return ok
+}
+
+// reportInvalid records invalidated tokens and posts each to the feedback URL.
+// Feedback is best effort: failures are counted in Status and do not fail the
+// send.
+func (pa *PushAdapter) reportInvalid(ctx context.Context, invalid []pushFeedback) {
+	if len(invalid) == 0 {
+		return
+	}
+	pa.mu.Lock()
+	for _, f := range invalid {
+		pa.invalid[f.Platform+":"+f.Token] = f.Time
+	}
+	feedback := pa.feedback
+	pa.mu.Unlock()
+	if feedback == nil {
+		return
+	}
+
+	failures := 0
+	for _, f := range invalid {
+		if err := feedback.doJSON(ctx, http.MethodPost, "", f, nil); err != nil {
+			failures++
+		}
+	}
+	if failures > 0 {
+		pa.mu.Lock()
+		pa.feedbackErr += failures
+		pa.mu.Unlock()
+	}
+}
+
+// Status implements the Integration interface, reporting connectivity as of the
+// last call along with the circuit, limiter and call metrics, the configured
+// platforms, per-platform deliveries and invalidated tokens.
+func (pa *PushAdapter) Status() (models.IntegrationStatus, error) {
+	pa.mu.RLock()
+	status := models.IntegrationStatus{
+		Connected: pa.connected,
+		Name:      "PushIntegration",
+		Type:      "push",
+		LastSync:  pa.lastSync,
+	}
+	platforms := make([]string, 0, 2)
+	if pa.fcm != nil {
+		platforms = append(platforms, PushPlatformFCM)
+	}
+	if pa.apns != nil {
+		platforms = append(platforms, PushPlatformAPNs)
+	}
+	sent := make(map[string]int, len(pa.sent))
+	for platform, n := range pa.sent {
+		sent[platform] = n
+	}
+	invalid, feedbackErr, project := len(pa.invalid), pa.feedbackErr, pa.fcmProject
+	pa.mu.RUnlock()
+
+	pa.guard.fillStatus(&status)
+	status.Metadata["platforms"] = platforms
+	status.Metadata["delivered"] = sent
+	status.Metadata["invalidatedTokens"] = invalid
+	status.Metadata["feedbackFailures"] = feedbackErr
+	if project != "" {
+		status.Metadata["fcmProject"] = project
+	}
+	return status, nil
+}
+
+// SetRetryBudget implements models.RetryBudgetUser.
+func (pa *PushAdapter) SetRetryBudget(budget models.RetryBudget) {
+	pa.guard.budget = budget
+}
+
+// CircuitOpen implements models.CircuitReporter.
+func (pa *PushAdapter) CircuitOpen() bool {
+	return pa.guard.breaker.IsOpen()
+}
+
+// CircuitSnapshot implements models.CircuitInspector.
+func (pa *PushAdapter) CircuitSnapshot() models.CircuitSnapshot {
+	return pa.guard.breaker.Snapshot()
+}
+
+// ResetCircuit implements models.CircuitResetter.
+func (pa *PushAdapter) ResetCircuit() {
+	pa.guard.breaker.Reset()
+}
+
+// newFCMClient creates a client for the FCM v1 API. keyJSON is a service
+// account key; when nil, Application Default Credentials are used. It returns
+// the configured project, or the credentials' project when none is configured.
+func newFCMClient(pc *config.PushConfig, keyJSON []byte) (*restClient, string, error) {
+	// Token sources keep this context for refreshes, so it must outlive the
+	// caller's; the base client's timeout bounds each token request.
+	base := httpclient.Default().ClientWithTimeout(pc.Timeout)
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, base)
+
+	var creds *google.Credentials
+	var err error
+	if keyJSON != nil {
+		creds, err = google.CredentialsFromJSON(ctx, keyJSON, fcmScope)
+	} else {
+		creds, err = google.FindDefaultCredentials(ctx, fcmScope)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	if _, err := creds.TokenSource.Token(); err != nil {
+		return nil, "", fmt.Errorf("obtain token: %w", err)
+	}
+
+	client := oauth2.NewClient(ctx, creds.TokenSource)
+	client.Timeout = base.Timeout
+	endpoint := firstNonEmpty(pc.FCM.Endpoint, fcmDefaultEndpoint)
+	return newRESTClient(endpoint, client, nil), firstNonEmpty(pc.FCM.ProjectID, creds.ProjectID), nil
+}
+
+// newAPNsSigner parses a PEM-encoded .p8 key and checks that it can sign a
+// provider token.
+func newAPNsSigner(teamID, keyID string, keyPEM []byte) (*apnsSigner, error) {
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return nil, errors.New("apns private key is not PEM encoded")
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse apns private key: %w", err)
+	}
+	key, ok := parsed.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("apns private key is not an ECDSA key")
+	}
+	s := &apnsSigner{teamID: teamID, keyID: keyID, key: key}
+	if _, err := s.providerToken(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// providerToken returns the current ES256 provider token, signing a new one
+// when it is older than apnsTokenLifetime.
+func (s *apnsSigner) providerToken() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token != "" && time.Since(s.issuedAt) < apnsTokenLifetime {
+		return s.token, nil
+	}
+
+	now := time.Now()
+	header, _ := json.Marshal(map[string]string{"alg": "ES256", "kid": s.keyID})
+	claims, _ := json.Marshal(map[string]interface{}{"iss": s.teamID, "iat": now.Unix()})
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+
+	digest := sha256.Sum256([]byte(unsigned))
+	r, sig, err := ecdsa.Sign(rand.Reader, s.key, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("sign apns provider token: %w", err)
+	}
+	// ES256 signatures are the fixed-width concatenation of r and s.
+	size := (s.key.Curve.Params().BitSize + 7) / 8
+	signature := make([]byte, 2*size)
+	r.FillBytes(signature[:size])
+	sig.FillBytes(signature[size:])
+
+	s.token = unsigned + "." + base64.RawURLEncoding.EncodeToString(signature)
+	s.issuedAt = now
+	return s.token, nil
+}
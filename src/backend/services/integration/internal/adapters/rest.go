@@ -0,0 +1,370 @@
+package adapters
+
+import (
+	// go1.21 - Request encoding, response handling and deadlines for REST calls
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	// Internal models for the retry budget and status contracts
+	"src/backend/services/integration/internal/models"
+)
+
+// ErrCircuitOpen is returned when an adapter's circuit breaker rejects a call.
+var ErrCircuitOpen = errors.New("circuit breaker open, refusing to call provider")
+
+// restErrorBodyLimit bounds how much of an error response is kept in the error.
+const restErrorBodyLimit = 1024
+
+// restError reports a non-2xx response from a provider's REST API.
+type restError struct {
+	// StatusCode is the HTTP status of the response.
+	StatusCode int
+
+	// RetryAfter is the provider's requested pause, from the Retry-After header.
+	RetryAfter time.Duration
+
+	// Body is the start of the response body, which usually explains the error.
+	Body string
+}
+
+// Error implements the error interface.
+func (e *restError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("unexpected HTTP status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("unexpected HTTP status %d: %s", e.StatusCode, e.Body)
+}
+
+// retryable reports whether repeating the request may succeed: the provider
+// throttled it, timed out, or failed on its side.
+func (e *restError) retryable() bool {
+	return e.StatusCode == http.StatusTooManyRequests ||
+		e.StatusCode == http.StatusRequestTimeout ||
+		e.StatusCode >= http.StatusInternalServerError
+}
+
+// restRequest describes one call made through a restClient.
+type restRequest struct {
+	method string
+
+	// path is appended to the client's base URL; absolute URLs are used as is.
+	path string
+
+	// contentType defaults to application/json when a body is sent.
+	contentType string
+
+	// header holds per-request headers, applied after the client's own.
+	header http.Header
+
+	// body is sent as is when it is a []byte and JSON-encoded otherwise.
+	body interface{}
+}
+
+// restClient sends requests to one provider's REST API, applying the headers
+// (typically authentication) configured for the provider. Clients are immutable;
+// adapters replace them when credentials change.
+type restClient struct {
+	baseURL string
+	header  http.Header
+	http    *http.Client
+}
+
+// newRESTClient creates a client for the API at baseURL. client is usually
+// httpclient.Default().ClientWithTimeout(...), or an OAuth2 client wrapping the
+// shared transport.
+func newRESTClient(baseURL string, client *http.Client, header http.Header) *restClient {
+	return &restClient{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		header:  header,
+		http:    client,
+	}
+}
+
+// doJSON sends body JSON-encoded (when non-nil) and decodes a successful
+// response into out (when non-nil).
+func (c *restClient) doJSON(ctx context.Context, method, path string, body, out interface{}) error {
+	return c.do(ctx, restRequest{method: method, path: path, body: body}, out)
+}
+
+// do sends r and decodes a successful JSON response into out. Responses outside
+// 2xx are returned as *restError.
+func (c *restClient) do(ctx context.Context, r restRequest, out interface{}) error {
+	var reader io.Reader
+	switch b := r.body.(type) {
+	case nil:
+	case []byte:
+		reader = bytes.NewReader(b)
+	default:
+		data, err := json.Marshal(b)
+		if err != nil {
+			return fmt.Errorf("%w: %v", models.ErrInvalidPayload, err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	target := r.path
+	if !strings.HasPrefix(target, "https://") && !strings.HasPrefix(target, "http://") {
+		target = c.baseURL + target
+	}
+	req, err := http.NewRequestWithContext(ctx, r.method, target, reader)
+	if err != nil {
+		return fmt.Errorf("build request: %w", err)
+	}
+	for key, values := range c.header {
+		req.Header[key] = values
+	}
+	for key, values := range r.header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Accept", "application/json")
+	if reader != nil {
+		contentType := r.contentType
+		if contentType == "" {
+			contentType = "application/json"
+		}
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, restErrorBodyLimit))
+		return &restError{
+			StatusCode: resp.StatusCode,
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+			Body:       strings.TrimSpace(string(data)),
+		}
+	}
+	if out == nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	return nil
+}
+
+// decodePayload converts a send payload into out, a pointer to the adapter's
+// message type. Payloads decoded from API requests arrive as maps, and typed
+// payloads may be values or pointers; all are converted through JSON.
+func decodePayload(payload interface{}, out interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("%w: %v", models.ErrInvalidPayload, err)
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("%w: %v", models.ErrInvalidPayload, err)
+	}
+	return nil
+}
+
+// mappedValue resolves a name used in payloads through configured mappings,
+// whose keys are lowercase as loaded from configuration. Unmapped names are
+// returned as given, and an empty name falls back to fallback.
+func mappedValue(mappings map[string]string, name, fallback string) string {
+	if name == "" {
+		return fallback
+	}
+	if value, ok := mappings[strings.ToLower(name)]; ok {
+		return value
+	}
+	return name
+}
+
+// firstNonEmpty returns the first non-empty value.
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// restGuard applies the protections shared by the REST adapters to each call:
+// a circuit breaker, an adaptive rate limiter that backs off on 429 responses,
+// bounded retries of transient failures within the retry budget, and the call
+// metrics reported by Status.
+type restGuard struct {
+	limiter *adaptiveLimiter
+	breaker *CircuitBreaker
+	metrics *metricsCollector
+
+	// budget bounds retries; set at registration.
+	budget models.RetryBudget
+
+	// policy overrides the default attempt count and backoff.
+	policy retryPolicy
+}
+
+// retryPolicy shapes the retries of a restGuard. The zero value makes
+// maxRetries attempts, waiting retryBackoff between them.
+type retryPolicy struct {
+	// attempts is the total number of attempts, including the first.
+	attempts int
+
+	// backoff is the wait before the first retry; each further retry doubles
+	// it, up to maxBackoff. Without maxBackoff the wait stays constant.
+	backoff    time.Duration
+	maxBackoff time.Duration
+}
+
+// maxAttempts returns the number of attempts the policy allows.
+func (p retryPolicy) maxAttempts() int {
+	if p.attempts > 0 {
+		return p.attempts
+	}
+	return maxRetries
+}
+
+// delay returns the wait after the given failed attempt, counted from zero.
+func (p retryPolicy) delay(attempt int) time.Duration {
+	if p.backoff <= 0 {
+		return retryBackoff
+	}
+	if p.maxBackoff <= 0 {
+		return p.backoff
+	}
+	d := p.backoff
+	for i := 0; i < attempt && d < p.maxBackoff; i++ {
+		d *= 2
+	}
+	if d > p.maxBackoff {
+		d = p.maxBackoff
+	}
+	return d
+}
+
+// newRESTGuard creates a guard around limiter whose circuit opens after five
+// consecutive failed calls and admits a trial call after a minute.
+func newRESTGuard(limiter *adaptiveLimiter) *restGuard {
+	return &restGuard{
+		limiter: limiter,
+		breaker: &CircuitBreaker{threshold: 5, resetTimer: time.Minute},
+		metrics: &metricsCollector{},
+	}
+}
+
+// call runs fn, an idempotent request, under the guard, making the attempts its
+// retry policy allows. See run.
+func (g *restGuard) call(ctx context.Context, fn func(ctx context.Context) error) error {
+	return g.run(ctx, fn, true)
+}
+
+// create runs fn, a request that is not idempotent such as a POST creating a
+// record, under the guard. Repeating it after the provider may have acted on it
+// could create a duplicate, so only attempts that were throttled or never
+// reached the provider are retried. See run.
+func (g *restGuard) create(ctx context.Context, fn func(ctx context.Context) error) error {
+	return g.run(ctx, fn, false)
+}
+
+// run runs fn under the guard. Steps:
+//  1. Reject the call while the circuit is open.
+//  2. Wait for a rate limiter token; retries must also fit the retry budget.
+//  3. Run fn. Throttled attempts shrink the limiter and are retried at once (the
+//     limiter honors Retry-After); other transient failures are retried after the
+//     policy's backoff when fn is idempotent or the request was never sent.
+//     Requests the provider rejects outright are not retried and do not count
+//     against the circuit, since the provider is evidently reachable.
+//  4. Record the outcome on the metrics and the circuit breaker.
+func (g *restGuard) run(ctx context.Context, fn func(ctx context.Context) error, idempotent bool) error {
+	// 1. Circuit.
+	if !g.breaker.Allow() {
+		g.metrics.RecordFailure()
+		return ErrCircuitOpen
+	}
+
+	var lastErr error
+	attempts := 0
+	limit := g.policy.maxAttempts()
+retry:
+	for i := 0; i < limit; i++ {
+		// 2. Budget and rate limit.
+		if i > 0 && !allowRetry(g.budget) {
+			lastErr = fmt.Errorf("%w: %w", models.ErrRetryBudgetExhausted, lastErr)
+			break
+		}
+		if err := g.limiter.Wait(ctx); err != nil {
+			if lastErr == nil {
+				lastErr = err
+			}
+			break
+		}
+
+		// 3. Attempt.
+		attempts++
+		err := fn(ctx)
+		if err == nil {
+			g.limiter.OnSuccess()
+			g.breaker.OnSuccess()
+			g.metrics.RecordSuccess()
+			return nil
+		}
+		lastErr = err
+
+		var re *restError
+		if errors.As(err, &re) {
+			if re.StatusCode == http.StatusTooManyRequests {
+				g.limiter.OnThrottled(re.RetryAfter)
+				continue
+			}
+			if !re.retryable() {
+				g.metrics.RecordFailure()
+				return err
+			}
+		}
+		if !idempotent && !requestUnsent(err) {
+			break
+		}
+		if i < limit-1 {
+			select {
+			case <-ctx.Done():
+				lastErr = ctx.Err()
+				break retry
+			case <-time.After(g.policy.delay(i)):
+			}
+		}
+	}
+
+	// 4. Every attempt failed.
+	g.metrics.RecordFailure()
+	g.breaker.OnFailure()
+	return fmt.Errorf("failed after %d attempts: %w", attempts, lastErr)
+}
+
+// requestUnsent reports whether err shows that the request never reached the
+// provider, because no connection could be made.
+func requestUnsent(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// fillStatus copies the guard's call metrics and circuit and limiter state into
+// an adapter's status.
+func (g *restGuard) fillStatus(status *models.IntegrationStatus) {
+	g.metrics.mu.Lock()
+	status.LastError = g.metrics.lastError
+	g.metrics.mu.Unlock()
+	status.ErrorCount = g.metrics.ErrorCount()
+	status.SuccessRate = g.metrics.SuccessRate()
+
+	if status.Metadata == nil {
+		status.Metadata = make(map[string]interface{})
+	}
+	status.Metadata["circuitBreakerOpen"] = g.breaker.IsOpen()
+	status.Metadata["rateLimiter"] = g.limiter.Stats()
+}
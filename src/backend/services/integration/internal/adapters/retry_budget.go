@@ -0,0 +1,12 @@
+package adapters
+
+import (
+	// Internal models for the retry budget contract
+	"src/backend/services/integration/internal/models"
+)
+
+// allowRetry consults budget before a retry. Adapters that were never given a
+// budget retry as before.
+func allowRetry(budget models.RetryBudget) bool {
+	return budget == nil || budget.AllowRetry()
+}
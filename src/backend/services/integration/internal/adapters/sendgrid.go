@@ -0,0 +1,485 @@
+package adapters
+
+import (
+	// go1.21 - Context for cancellations and timeouts
+	"context"
+	// go1.21 - Sentinel errors and error wrapping
+	"errors"
+	"fmt"
+	// go1.21 - Request construction and recipient domain checks
+	"net/http"
+	"strings"
+	// go1.21 - Guards the client during credential rotation
+	"sync"
+	"time"
+
+	// v0.1.0 - Token bucket rate limiting of Mail Send calls
+	"golang.org/x/time/rate"
+
+	// Internal imports for configuration, transport and adapter contracts
+	"src/backend/services/integration/internal/config"
+	"src/backend/services/integration/internal/httpclient"
+	"src/backend/services/integration/internal/models"
+)
+
+// sendGridMailSendScope is the API key permission needed to send mail.
+const sendGridMailSendScope = "mail.send"
+
+// ErrInvalidSendGridConfig indicates that the email configuration does not
+// select SendGrid or is missing the API key or sender address.
+var ErrInvalidSendGridConfig = errors.New("invalid sendgrid configuration or missing required fields")
+
+// ErrSendGridScope is returned when the API key lacks the Mail Send permission.
+var ErrSendGridScope = errors.New("sendgrid api key lacks the mail.send permission")
+
+// ErrRecipientDomainNotAllowed is returned for a message addressed outside the
+// configured allowed domains.
+var ErrRecipientDomainNotAllowed = errors.New("recipient domain not allowed")
+
+// SendGridMessage is a message to send through SendGrid. Send also accepts an
+// EmailPayload, alone or in the context wrapper used for the SMTP adapter, so
+// the providers are interchangeable.
+type SendGridMessage struct {
+	// To, Cc and Bcc are the recipients; To is required.
+	To  []string `json:"to"`
+	Cc  []string `json:"cc,omitempty"`
+	Bcc []string `json:"bcc,omitempty"`
+
+	// From overrides the configured sender address.
+	From    string `json:"from,omitempty"`
+	ReplyTo string `json:"replyTo,omitempty"`
+
+	// Subject and Body are required unless a template supplies them.
+	Subject string `json:"subject,omitempty"`
+	Body    string `json:"body,omitempty"`
+
+	// ContentType is the body's MIME type; it defaults to text/plain. HTML
+	// bodies are sent with a plain-text alternative generated from them.
+	ContentType string `json:"contentType,omitempty"`
+
+	// Template is a template name from the configured mapping, or a dynamic
+	// template ID; TemplateData fills it.
+	Template     string                 `json:"template,omitempty"`
+	TemplateData map[string]interface{} `json:"templateData,omitempty"`
+
+	// Categories are added to the configured categories.
+	Categories []string `json:"categories,omitempty"`
+}
+
+// sendGridAddress is an email address in a Mail Send request.
+type sendGridAddress struct {
+	Email string `json:"email"`
+}
+
+// sendGridPersonalization addresses a Mail Send request.
+type sendGridPersonalization struct {
+	To                  []sendGridAddress      `json:"to"`
+	Cc                  []sendGridAddress      `json:"cc,omitempty"`
+	Bcc                 []sendGridAddress      `json:"bcc,omitempty"`
+	DynamicTemplateData map[string]interface{} `json:"dynamic_template_data,omitempty"`
+}
+
+// sendGridContent is one body of a Mail Send request.
+type sendGridContent struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// sendGridMail is the body of a Mail Send request.
+type sendGridMail struct {
+	Personalizations []sendGridPersonalization `json:"personalizations"`
+	From             sendGridAddress           `json:"from"`
+	ReplyTo          *sendGridAddress          `json:"reply_to,omitempty"`
+	Subject          string                    `json:"subject,omitempty"`
+	Content          []sendGridContent         `json:"content,omitempty"`
+	TemplateID       string                    `json:"template_id,omitempty"`
+	Categories       []string                  `json:"categories,omitempty"`
+	MailSettings     *sendGridMailSettings     `json:"mail_settings,omitempty"`
+}
+
+// sendGridMailSettings toggles per-message settings.
+type sendGridMailSettings struct {
+	SandboxMode struct {
+		Enable bool `json:"enable"`
+	} `json:"sandbox_mode"`
+}
+
+// SendGridAdapter implements the Integration interface for email sent through
+// the SendGrid v3 Mail Send API. It is the "sendgrid" email provider.
+type SendGridAdapter struct {
+	// mu guards the fields below, which change on initialization and rotation.
+	mu          sync.RWMutex
+	config      *config.EmailConfig
+	client      *restClient
+	connected   bool
+	lastSync    time.Time
+	initialized bool
+
+	// guard applies the circuit breaker, rate limiter and retries to each call.
+	guard *restGuard
+}
+
+// Compile-time check to ensure SendGridAdapter implements the Integration interface.
+var _ models.Integration = (*SendGridAdapter)(nil)
+
+// Compile-time check to ensure SendGridAdapter supports credential rotation.
+var _ models.CredentialRotator = (*SendGridAdapter)(nil)
+
+// Compile-time check to ensure SendGridAdapter reports its circuit state.
+var _ models.CircuitReporter = (*SendGridAdapter)(nil)
+
+// Compile-time check to ensure SendGridAdapter's circuit can be reset by operators.
+var _ models.CircuitResetter = (*SendGridAdapter)(nil)
+
+// Compile-time check to ensure SendGridAdapter reports its circuit's state and counters.
+var _ models.CircuitInspector = (*SendGridAdapter)(nil)
+
+// Compile-time check to ensure SendGridAdapter accepts a retry budget.
+var _ models.RetryBudgetUser = (*SendGridAdapter)(nil)
+
+// NewSendGridAdapter creates an uninitialized SendGrid adapter. Mail Send has
+// no fixed rate limit, so the limiter only smooths bursts: one hundred calls
+// per second with a burst of two hundred, backing off to one per second on 429
+// responses.
+func NewSendGridAdapter() *SendGridAdapter {
+	return &SendGridAdapter{
+		guard: newRESTGuard(newAdaptiveLimiter(rate.Limit(100), 200, rate.Every(time.Second))),
+	}
+}
+
+// Initialize implements the Integration interface, bridging to
+// InitializeWithContext with a background context.
+func (sa *SendGridAdapter) Initialize(cfg interface{}) error {
+	return sa.InitializeWithContext(context.Background(), cfg)
+}
+
+// InitializeWithContext configures the adapter from a *config.EmailConfig with
+// its SendGrid section.
+// Steps:
+//  1. Validate the API key and sender address.
+//  2. Build a client on the shared transport with the key as bearer credentials.
+//  3. Verify that the key is valid and may send mail.
+func (sa *SendGridAdapter) InitializeWithContext(ctx context.Context, cfg interface{}) error {
+	// 1. Configuration.
+	ec, ok := cfg.(*config.EmailConfig)
+	if !ok || ec == nil || ec.SendGrid == nil || ec.SendGrid.APIKey == "" || ec.FromAddress == "" {
+		return ErrInvalidSendGridConfig
+	}
+
+	// 2. Client.
+	client := newSendGridClient(ec.SendGrid, ec.SendGrid.APIKey)
+
+	// 3. Connectivity.
+	if err := sendGridCheckScopes(ctx, client); err != nil {
+		sa.mu.Lock()
+		sa.connected = false
+		sa.mu.Unlock()
+		return fmt.Errorf("%w: sendgrid scopes: %v", models.ErrConnectionFailed, err)
+	}
+
+	sa.mu.Lock()
+	defer sa.mu.Unlock()
+	sa.config = ec
+	sa.client = client
+	sa.connected = true
+	sa.initialized = true
+	sa.lastSync = time.Now()
+	return nil
+}
+
+// RotateCredentials replaces the API key without a restart. The new key is
+// verified before it replaces the active client; on failure the adapter keeps
+// its previous client.
+func (sa *SendGridAdapter) RotateCredentials(ctx context.Context, creds models.Credentials) error {
+	if creds.Secret == "" {
+		return models.ErrInvalidPayload
+	}
+
+	sa.mu.RLock()
+	ec := sa.config
+	sa.mu.RUnlock()
+	if ec == nil {
+		return models.ErrInitializationFailed
+	}
+
+	client := newSendGridClient(ec.SendGrid, creds.Secret)
+	if err := sendGridCheckScopes(ctx, client); err != nil {
+		return fmt.Errorf("rotated sendgrid api key rejected: %w", err)
+	}
+
+	sa.mu.Lock()
+	defer sa.mu.Unlock()
+	sa.client = client
+	sa.connected = true
+	return nil
+}
+
+// Send implements the Integration interface. The context wrapper used for the
+// SMTP adapter supplies the context; other payloads are sent with a background
+// context.
+func (sa *SendGridAdapter) Send(payload interface{}) error {
+	if container, ok := payload.(struct {
+		Ctx     context.Context
+		Payload *EmailPayload
+	}); ok {
+		if container.Ctx == nil || container.Payload == nil {
+			return models.ErrInvalidPayload
+		}
+		return sa.SendWithContext(container.Ctx, container.Payload)
+	}
+	return sa.SendWithContext(context.Background(), payload)
+}
+
+// SendWithContext sends a message. The payload is a SendGridMessage or
+// EmailPayload (or a pointer to either), or a map equivalent to a
+// SendGridMessage.
+// Steps:
+//  1. Decode the payload and check its recipients against the allowed domains.
+//  2. Build the Mail Send request, adding the configured categories and
+//     sandbox mode.
+//  3. POST it.
+func (sa *SendGridAdapter) SendWithContext(ctx context.Context, payload interface{}) error {
+	sa.mu.RLock()
+	client, ec, initialized := sa.client, sa.config, sa.initialized
+	sa.mu.RUnlock()
+	if !initialized {
+		return models.ErrInitializationFailed
+	}
+
+	// 1. Message.
+	var msg SendGridMessage
+	switch p := payload.(type) {
+	case EmailPayload:
+		msg = sendGridFromEmailPayload(&p)
+	case *EmailPayload:
+		if p == nil {
+			return models.ErrInvalidPayload
+		}
+		msg = sendGridFromEmailPayload(p)
+	default:
+		if err := decodePayload(payload, &msg); err != nil {
+			return err
+		}
+	}
+	if err := checkAllowedDomains(ec.AllowedDomains, msg.To, msg.Cc, msg.Bcc); err != nil {
+		return err
+	}
+
+	// 2. Request.
+	mail, err := buildSendGridMail(ec, &msg)
+	if err != nil {
+		return err
+	}
+
+	// 3. Send.
+	err = sa.guard.call(ctx, func(ctx context.Context) error {
+		return client.doJSON(ctx, http.MethodPost, "/v3/mail/send", mail, nil)
+	})
+	if err != nil {
+		return fmt.Errorf("sendgrid mail send: %w", err)
+	}
+
+	sa.mu.Lock()
+	sa.lastSync = time.Now()
+	sa.connected = true
+	sa.mu.Unlock()
+	return nil
+}
+
+// sendGridFromEmailPayload converts the SMTP adapter's payload.
+func sendGridFromEmailPayload(ep *EmailPayload) SendGridMessage {
+	body, contentType := ep.richestBody()
+	return SendGridMessage{
+		To:          ep.To,
+		Cc:          ep.Cc,
+		Bcc:         ep.Bcc,
+		ReplyTo:     ep.ReplyTo,
+		Subject:     ep.Subject,
+		Body:        body,
+		ContentType: contentType,
+	}
+}
+
+// buildSendGridMail validates msg and converts it into a Mail Send request.
+func buildSendGridMail(ec *config.EmailConfig, msg *SendGridMessage) (*sendGridMail, error) {
+	if len(msg.To) == 0 {
+		return nil, fmt.Errorf("%w: missing recipients", models.ErrInvalidPayload)
+	}
+	sg := ec.SendGrid
+
+	personalization := sendGridPersonalization{
+		To:                  sendGridAddresses(msg.To),
+		Cc:                  sendGridAddresses(msg.Cc),
+		Bcc:                 sendGridAddresses(msg.Bcc),
+		DynamicTemplateData: msg.TemplateData,
+	}
+	mail := &sendGridMail{
+		Personalizations: []sendGridPersonalization{personalization},
+		From:             sendGridAddress{Email: firstNonEmpty(msg.From, ec.FromAddress)},
+		Subject:          msg.Subject,
+		TemplateID:       mappedValue(sg.Templates, msg.Template, ""),
+	}
+	if msg.ReplyTo != "" {
+		mail.ReplyTo = &sendGridAddress{Email: msg.ReplyTo}
+	}
+
+	// Dynamic templates carry their own subject and body.
+	if mail.TemplateID == "" {
+		if msg.Subject == "" || msg.Body == "" {
+			return nil, fmt.Errorf("%w: a subject and body are required without a template", models.ErrInvalidPayload)
+		}
+		// HTML bodies get a plain-text alternative, which SendGrid requires first.
+		if isHTMLContentType(msg.ContentType) {
+			mail.Content = append(mail.Content, sendGridContent{Type: defaultContentType, Value: htmlToText(msg.Body)})
+		}
+		mail.Content = append(mail.Content, sendGridContent{
+			Type:  firstNonEmpty(msg.ContentType, defaultContentType),
+			Value: msg.Body,
+		})
+	}
+
+	mail.Categories = append(append([]string(nil), sg.Categories...), msg.Categories...)
+	if len(mail.Categories) > 10 {
+		return nil, fmt.Errorf("%w: SendGrid accepts at most 10 categories per message", models.ErrInvalidPayload)
+	}
+
+	if sg.SandboxMode {
+		mail.MailSettings = &sendGridMailSettings{}
+		mail.MailSettings.SandboxMode.Enable = true
+	}
+	return mail, nil
+}
+
+// sendGridAddresses converts email addresses for a Mail Send request.
+func sendGridAddresses(emails []string) []sendGridAddress {
+	if len(emails) == 0 {
+		return nil
+	}
+	addresses := make([]sendGridAddress, 0, len(emails))
+	for _, email := range emails {
+		addresses = append(addresses, sendGridAddress{Email: email})
+	}
+	return addresses
+}
+
+// RecipientDomainError lists the recipients of a message whose domains are not
+// among the configured allowed domains. It matches ErrRecipientDomainNotAllowed
+// with errors.Is.
+type RecipientDomainError struct {
+	// Recipients are the rejected addresses, in the order they were given.
+	Recipients []string
+}
+
+// Error implements the error interface.
+func (e *RecipientDomainError) Error() string {
+	return ErrRecipientDomainNotAllowed.Error() + ": " + strings.Join(e.Recipients, ", ")
+}
+
+// Unwrap returns ErrRecipientDomainNotAllowed.
+func (e *RecipientDomainError) Unwrap() error {
+	return ErrRecipientDomainNotAllowed
+}
+
+// checkAllowedDomains returns a *RecipientDomainError listing every recipient
+// outside allowed. An empty allowed list permits every domain.
+func checkAllowedDomains(allowed []string, recipients ...[]string) error {
+	var rejected []string
+	for _, list := range recipients {
+		_, outside := partitionAllowedDomains(allowed, list)
+		rejected = append(rejected, outside...)
+	}
+	if len(rejected) > 0 {
+		return &RecipientDomainError{Recipients: rejected}
+	}
+	return nil
+}
+
+// partitionAllowedDomains splits recipients into those within allowed and
+// those outside it. An empty allowed list permits every domain.
+func partitionAllowedDomains(allowed, recipients []string) (permitted, rejected []string) {
+	if len(allowed) == 0 {
+		return recipients, nil
+	}
+	for _, address := range recipients {
+		at := strings.LastIndex(address, "@")
+		domain := strings.ToLower(strings.TrimSuffix(address[at+1:], ">"))
+		ok := false
+		for _, d := range allowed {
+			if strings.EqualFold(d, domain) {
+				ok = true
+				break
+			}
+		}
+		if ok {
+			permitted = append(permitted, address)
+		} else {
+			rejected = append(rejected, address)
+		}
+	}
+	return permitted, rejected
+}
+
+// Status implements the Integration interface, reporting connectivity as of the
+// last call along with the circuit, limiter and call metrics.
+func (sa *SendGridAdapter) Status() (models.IntegrationStatus, error) {
+	sa.mu.RLock()
+	status := models.IntegrationStatus{
+		Connected: sa.connected,
+		Name:      "SendGridIntegration",
+		Type:      "email",
+		LastSync:  sa.lastSync,
+	}
+	ec := sa.config
+	sa.mu.RUnlock()
+
+	sa.guard.fillStatus(&status)
+	status.Metadata["provider"] = config.EmailProviderSendGrid
+	if ec != nil {
+		status.Metadata["sandboxMode"] = ec.SendGrid.SandboxMode
+		status.Metadata["fromAddress"] = ec.FromAddress
+	}
+	return status, nil
+}
+
+// SetRetryBudget implements models.RetryBudgetUser.
+func (sa *SendGridAdapter) SetRetryBudget(budget models.RetryBudget) {
+	sa.guard.budget = budget
+}
+
+// CircuitOpen implements models.CircuitReporter.
+func (sa *SendGridAdapter) CircuitOpen() bool {
+	return sa.guard.breaker.IsOpen()
+}
+
+// CircuitSnapshot implements models.CircuitInspector.
+func (sa *SendGridAdapter) CircuitSnapshot() models.CircuitSnapshot {
+	return sa.guard.breaker.Snapshot()
+}
+
+// ResetCircuit implements models.CircuitResetter.
+func (sa *SendGridAdapter) ResetCircuit() {
+	sa.guard.breaker.Reset()
+}
+
+// newSendGridClient creates a client for the SendGrid API authenticated with key.
+func newSendGridClient(sg *config.SendGridConfig, key string) *restClient {
+	return newRESTClient(sg.BaseURL, httpclient.Default().ClientWithTimeout(sg.Timeout), http.Header{
+		"Authorization": {"Bearer " + key},
+	})
+}
+
+// sendGridCheckScopes verifies that the client's key is valid and may send mail.
+func sendGridCheckScopes(ctx context.Context, client *restClient) error {
+	var resp struct {
+		Scopes []string `json:"scopes"`
+	}
+	if err := client.doJSON(ctx, http.MethodGet, "/v3/scopes", nil, &resp); err != nil {
+		return err
+	}
+	for _, scope := range resp.Scopes {
+		if scope == sendGridMailSendScope {
+			return nil
+		}
+	}
+	return ErrSendGridScope
+}
@@ -0,0 +1,359 @@
+package adapters
+
+import (
+	// go1.21 - Context for cancellations and timeouts
+	"context"
+	// go1.21 - Basic credentials encoding
+	"encoding/base64"
+	// go1.21 - Sentinel errors and error wrapping
+	"errors"
+	"fmt"
+	// go1.21 - Request construction and table path escaping
+	"net/http"
+	"net/url"
+	"strings"
+	// go1.21 - Guards the client during credential rotation
+	"sync"
+	"time"
+
+	// v0.13.0 - OAuth password grant and token refresh for ServiceNow instances
+	"golang.org/x/oauth2"
+	// v0.1.0 - Token bucket rate limiting under the instance's inbound REST limits
+	"golang.org/x/time/rate"
+
+	// Internal imports for configuration, transport and adapter contracts
+	"src/backend/services/integration/internal/config"
+	"src/backend/services/integration/internal/httpclient"
+	"src/backend/services/integration/internal/models"
+)
+
+// ErrInvalidServiceNowConfig indicates that the ServiceNow configuration is
+// missing the instance URL or credentials.
+var ErrInvalidServiceNowConfig = errors.New("invalid servicenow configuration or missing required fields")
+
+// ErrServiceNowTableRequired is returned for a record that names no table when
+// no default table is configured.
+var ErrServiceNowTableRequired = errors.New("servicenow record requires a table and no default table is configured")
+
+// serviceNowStatusTimeout bounds the reachability check made by Status.
+const serviceNowStatusTimeout = 5 * time.Second
+
+// ServiceNowRecord is a record (an incident, task, or any other table row) to
+// create. Send also accepts the map payloads used for Jira ("summary",
+// "description").
+type ServiceNowRecord struct {
+	// Table is a table name from the configured mapping, or a table name; it
+	// defaults to the configured table.
+	Table string `json:"table,omitempty"`
+
+	// ShortDescription is required; Summary is accepted as the Jira-style alias.
+	ShortDescription string `json:"short_description,omitempty"`
+	Summary          string `json:"summary,omitempty"`
+
+	// Description is the record's long description.
+	Description string `json:"description,omitempty"`
+
+	// Fields sets further columns, keyed by names from the configured field
+	// mapping or by column names.
+	Fields map[string]interface{} `json:"fields,omitempty"`
+}
+
+// ServiceNowAdapter implements the Integration interface for ServiceNow,
+// creating records through the Table API with basic or OAuth authentication.
+type ServiceNowAdapter struct {
+	// mu guards the fields below, which change on initialization and rotation.
+	mu          sync.RWMutex
+	config      *config.ServiceNowConfig
+	client      *restClient
+	connected   bool
+	reachable   bool
+	lastSync    time.Time
+	lastCreated string
+	initialized bool
+
+	// guard applies the circuit breaker, rate limiter and retries to each call.
+	guard *restGuard
+}
+
+// Compile-time check to ensure ServiceNowAdapter implements the Integration interface.
+var _ models.Integration = (*ServiceNowAdapter)(nil)
+
+// Compile-time check to ensure ServiceNowAdapter supports credential rotation.
+var _ models.CredentialRotator = (*ServiceNowAdapter)(nil)
+
+// Compile-time check to ensure ServiceNowAdapter reports its circuit state.
+var _ models.CircuitReporter = (*ServiceNowAdapter)(nil)
+
+// Compile-time check to ensure ServiceNowAdapter's circuit can be reset by operators.
+var _ models.CircuitResetter = (*ServiceNowAdapter)(nil)
+
+// Compile-time check to ensure ServiceNowAdapter reports its circuit's state and counters.
+var _ models.CircuitInspector = (*ServiceNowAdapter)(nil)
+
+// Compile-time check to ensure ServiceNowAdapter accepts a retry budget.
+var _ models.RetryBudgetUser = (*ServiceNowAdapter)(nil)
+
+// NewServiceNowAdapter creates an uninitialized ServiceNow adapter. Inbound
+// REST rate limits are set per instance, so the limiter is conservative: five
+// calls per second with a burst of ten, backing off to one every ten seconds
+// on 429 responses.
+func NewServiceNowAdapter() *ServiceNowAdapter {
+	return &ServiceNowAdapter{
+		guard: newRESTGuard(newAdaptiveLimiter(rate.Limit(5), 10, rate.Every(10*time.Second))),
+	}
+}
+
+// Initialize implements the Integration interface, bridging to
+// InitializeWithContext with a background context.
+func (sa *ServiceNowAdapter) Initialize(cfg interface{}) error {
+	return sa.InitializeWithContext(context.Background(), cfg)
+}
+
+// InitializeWithContext configures the adapter from a *config.ServiceNowConfig.
+// Steps:
+//  1. Validate the instance URL and credentials.
+//  2. Build a client for the configured authentication mode; in oauth mode
+//     this obtains the first access token.
+//  3. Verify the credentials with a one-row read of the default table.
+func (sa *ServiceNowAdapter) InitializeWithContext(ctx context.Context, cfg interface{}) error {
+	// 1. Configuration.
+	sc, ok := cfg.(*config.ServiceNowConfig)
+	if !ok || sc == nil || sc.InstanceURL == "" || sc.Username == "" || sc.Password == "" {
+		return ErrInvalidServiceNowConfig
+	}
+
+	// 2. Client.
+	client, err := newServiceNowClient(ctx, sc, sc.Username, sc.Password)
+	if err == nil {
+		// 3. Connectivity.
+		err = serviceNowPing(ctx, client, sc.DefaultTable)
+	}
+	if err != nil {
+		sa.mu.Lock()
+		sa.connected = false
+		sa.mu.Unlock()
+		return fmt.Errorf("%w: servicenow table api: %v", models.ErrConnectionFailed, err)
+	}
+
+	sa.mu.Lock()
+	defer sa.mu.Unlock()
+	sa.config = sc
+	sa.client = client
+	sa.connected = true
+	sa.reachable = true
+	sa.initialized = true
+	sa.lastSync = time.Now()
+	return nil
+}
+
+// RotateCredentials replaces the integration user's password (and, when
+// Username is set, the user) without a restart. In oauth mode the new password
+// is exchanged for a fresh token. The new credentials are verified before they
+// replace the active client; on failure the adapter keeps its previous client.
+func (sa *ServiceNowAdapter) RotateCredentials(ctx context.Context, creds models.Credentials) error {
+	if creds.Secret == "" {
+		return models.ErrInvalidPayload
+	}
+
+	sa.mu.RLock()
+	sc := sa.config
+	sa.mu.RUnlock()
+	if sc == nil {
+		return models.ErrInitializationFailed
+	}
+
+	client, err := newServiceNowClient(ctx, sc, firstNonEmpty(creds.Username, sc.Username), creds.Secret)
+	if err == nil {
+		err = serviceNowPing(ctx, client, sc.DefaultTable)
+	}
+	if err != nil {
+		return fmt.Errorf("rotated servicenow credentials rejected: %w", err)
+	}
+
+	sa.mu.Lock()
+	defer sa.mu.Unlock()
+	sa.client = client
+	sa.connected = true
+	return nil
+}
+
+// Send implements the Integration interface, bridging to SendWithContext with a
+// background context.
+func (sa *ServiceNowAdapter) Send(payload interface{}) error {
+	return sa.SendWithContext(context.Background(), payload)
+}
+
+// SendWithContext creates a record. The payload is a ServiceNowRecord (or
+// pointer), or an equivalent map.
+// Steps:
+//  1. Decode the payload and map its table and fields.
+//  2. POST the record to the table.
+func (sa *ServiceNowAdapter) SendWithContext(ctx context.Context, payload interface{}) error {
+	sa.mu.RLock()
+	client, sc, initialized := sa.client, sa.config, sa.initialized
+	sa.mu.RUnlock()
+	if !initialized {
+		return models.ErrInitializationFailed
+	}
+
+	// 1. Record.
+	var record ServiceNowRecord
+	if err := decodePayload(payload, &record); err != nil {
+		return err
+	}
+	table, fields, err := buildServiceNowRecord(sc, &record)
+	if err != nil {
+		return err
+	}
+
+	// 2. Create.
+	var created struct {
+		Result struct {
+			SysID  string `json:"sys_id"`
+			Number string `json:"number"`
+		} `json:"result"`
+	}
+	path := "/api/now/table/" + url.PathEscape(table) + "?sysparm_fields=sys_id,number"
+	err = sa.guard.create(ctx, func(ctx context.Context) error {
+		return client.doJSON(ctx, http.MethodPost, path, fields, &created)
+	})
+	if err != nil {
+		return fmt.Errorf("servicenow create %s record: %w", table, err)
+	}
+
+	sa.mu.Lock()
+	sa.lastSync = time.Now()
+	sa.lastCreated = firstNonEmpty(created.Result.Number, created.Result.SysID)
+	sa.connected = true
+	sa.reachable = true
+	sa.mu.Unlock()
+	return nil
+}
+
+// buildServiceNowRecord validates record and returns its table and the column
+// values to create it with.
+func buildServiceNowRecord(sc *config.ServiceNowConfig, record *ServiceNowRecord) (string, map[string]interface{}, error) {
+	shortDescription := firstNonEmpty(record.ShortDescription, record.Summary)
+	if strings.TrimSpace(shortDescription) == "" {
+		return "", nil, fmt.Errorf("%w: missing required 'short_description' field in payload", models.ErrInvalidPayload)
+	}
+
+	table := mappedValue(sc.Tables, record.Table, sc.DefaultTable)
+	if table == "" {
+		return "", nil, ErrServiceNowTableRequired
+	}
+
+	fields := make(map[string]interface{}, len(record.Fields)+2)
+	for name, value := range record.Fields {
+		fields[mappedValue(sc.Fields, name, name)] = value
+	}
+	fields["short_description"] = shortDescription
+	if record.Description != "" {
+		fields["description"] = record.Description
+	}
+	return table, fields, nil
+}
+
+// Status implements the Integration interface. It checks that the instance is
+// reachable with a one-row read of the default table, bounded by
+// serviceNowStatusTimeout; an instance that answers with an error is reachable
+// but not connected.
+func (sa *ServiceNowAdapter) Status() (models.IntegrationStatus, error) {
+	sa.mu.RLock()
+	client, sc := sa.client, sa.config
+	sa.mu.RUnlock()
+
+	status := models.IntegrationStatus{
+		Name: "ServiceNowIntegration",
+		Type: "itsm",
+	}
+	var checkErr error
+	if client != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), serviceNowStatusTimeout)
+		checkErr = serviceNowPing(ctx, client, sc.DefaultTable)
+		cancel()
+	}
+
+	sa.mu.Lock()
+	if client != nil {
+		var re *restError
+		sa.connected = checkErr == nil
+		sa.reachable = checkErr == nil || errors.As(checkErr, &re)
+	}
+	status.Connected = sa.connected
+	status.LastSync = sa.lastSync
+	reachable, lastCreated := sa.reachable, sa.lastCreated
+	sa.mu.Unlock()
+
+	sa.guard.fillStatus(&status)
+	status.Metadata["instanceReachable"] = reachable
+	status.Metadata["lastRecord"] = lastCreated
+	if sc != nil {
+		status.Metadata["instance"] = sc.InstanceURL
+		status.Metadata["authMode"] = sc.AuthMode
+		status.Metadata["defaultTable"] = sc.DefaultTable
+	}
+	if checkErr != nil {
+		status.Metadata["lastCheckError"] = checkErr.Error()
+	}
+	return status, nil
+}
+
+// SetRetryBudget implements models.RetryBudgetUser.
+func (sa *ServiceNowAdapter) SetRetryBudget(budget models.RetryBudget) {
+	sa.guard.budget = budget
+}
+
+// CircuitOpen implements models.CircuitReporter.
+func (sa *ServiceNowAdapter) CircuitOpen() bool {
+	return sa.guard.breaker.IsOpen()
+}
+
+// CircuitSnapshot implements models.CircuitInspector.
+func (sa *ServiceNowAdapter) CircuitSnapshot() models.CircuitSnapshot {
+	return sa.guard.breaker.Snapshot()
+}
+
+// ResetCircuit implements models.CircuitResetter.
+func (sa *ServiceNowAdapter) ResetCircuit() {
+	sa.guard.breaker.Reset()
+}
+
+// newServiceNowClient creates a client for the instance's REST API as the
+// given user. In basic mode the credentials are sent with every request; in
+// oauth mode they are exchanged for an access token, which the client refreshes
+// as it expires.
+func newServiceNowClient(ctx context.Context, sc *config.ServiceNowConfig, username, password string) (*restClient, error) {
+	base := httpclient.Default().ClientWithTimeout(sc.Timeout)
+	if sc.AuthMode != config.ServiceNowAuthOAuth {
+		credentials := base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+		return newRESTClient(sc.InstanceURL, base, http.Header{
+			"Authorization": {"Basic " + credentials},
+		}), nil
+	}
+
+	oauthConfig := &oauth2.Config{
+		ClientID:     sc.ClientID,
+		ClientSecret: sc.ClientSecret,
+		Endpoint: oauth2.Endpoint{
+			TokenURL:  strings.TrimRight(sc.InstanceURL, "/") + "/oauth_token.do",
+			AuthStyle: oauth2.AuthStyleInParams,
+		},
+	}
+	token, err := oauthConfig.PasswordCredentialsToken(context.WithValue(ctx, oauth2.HTTPClient, base), username, password)
+	if err != nil {
+		return nil, fmt.Errorf("oauth token: %w", err)
+	}
+
+	// Refreshes outlive ctx, so the client gets a context of its own.
+	client := oauthConfig.Client(context.WithValue(context.Background(), oauth2.HTTPClient, base), token)
+	client.Timeout = base.Timeout
+	return newRESTClient(sc.InstanceURL, client, nil), nil
+}
+
+// serviceNowPing reads at most one row of table, confirming that the instance
+// is reachable and accepts the client's credentials.
+func serviceNowPing(ctx context.Context, client *restClient, table string) error {
+	path := "/api/now/table/" + url.PathEscape(firstNonEmpty(table, "incident")) + "?sysparm_limit=1&sysparm_fields=sys_id"
+	return client.doJSON(ctx, http.MethodGet, path, nil, nil)
+}
@@ -0,0 +1,565 @@
+package adapters
+
+import (
+	// go1.21 - Context for cancellations and timeouts
+	"context"
+	// go1.21 - SES notification decoding
+	"encoding/json"
+	// go1.21 - Sentinel errors and error wrapping
+	"errors"
+	"fmt"
+	// go1.21 - Provider error classification and recipient normalization
+	"net/http"
+	"strings"
+	// go1.21 - Guards the client and suppression list
+	"sync"
+	"time"
+
+	// v1.25.0 - AWS SDK configuration, credentials and the SES v2 client
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/sesv2"
+	sestypes "github.com/aws/aws-sdk-go-v2/service/sesv2/types"
+	// v1.13.0 - API error codes returned by the AWS SDK
+	"github.com/aws/smithy-go"
+	// v0.1.0 - Token bucket rate limiting under the account's send rate
+	"golang.org/x/time/rate"
+
+	// Internal imports for configuration, transport and adapter contracts
+	"src/backend/services/integration/internal/config"
+	"src/backend/services/integration/internal/httpclient"
+	"src/backend/services/integration/internal/models"
+)
+
+// ErrInvalidSESConfig indicates that the email configuration does not select
+// SES or is missing the sender address.
+var ErrInvalidSESConfig = errors.New("invalid ses configuration or missing required fields")
+
+// ErrSESSendingDisabled is returned when SES reports that sending is disabled
+// for the account.
+var ErrSESSendingDisabled = errors.New("ses sending is disabled for the account")
+
+// ErrRecipientSuppressed is returned for a message whose every recipient is
+// suppressed after a permanent bounce or complaint.
+var ErrRecipientSuppressed = errors.New("all recipients are suppressed after bounces or complaints")
+
+// sesThrottlingCodes are the SES error codes that mean the send rate or quota
+// was exceeded.
+var sesThrottlingCodes = map[string]bool{
+	"TooManyRequestsException": true,
+	"LimitExceededException":   true,
+	"ThrottlingException":      true,
+}
+
+// SESMessage is a message to send through SES. Send also accepts an
+// EmailPayload, alone or in the context wrapper used for the SMTP adapter, so
+// the providers are interchangeable.
+type SESMessage struct {
+	// To, Cc and Bcc are the recipients; To is required.
+	To  []string `json:"to"`
+	Cc  []string `json:"cc,omitempty"`
+	Bcc []string `json:"bcc,omitempty"`
+
+	// From overrides the configured sender address; it must be a verified identity.
+	From    string   `json:"from,omitempty"`
+	ReplyTo []string `json:"replyTo,omitempty"`
+
+	// Subject and Body are required.
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+
+	// ContentType is text/plain (the default) or text/html. HTML bodies are
+	// sent with a plain-text alternative generated from them.
+	ContentType string `json:"contentType,omitempty"`
+
+	// Tags are attached as SES message tags, which configuration set event
+	// destinations can filter on.
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// sesNotification is an SES bounce or complaint notification, either from
+// identity notifications (notificationType) or configuration set event
+// publishing (eventType).
+type sesNotification struct {
+	NotificationType string `json:"notificationType"`
+	EventType        string `json:"eventType"`
+	Bounce           struct {
+		BounceType        string `json:"bounceType"`
+		BouncedRecipients []struct {
+			EmailAddress string `json:"emailAddress"`
+		} `json:"bouncedRecipients"`
+	} `json:"bounce"`
+	Complaint struct {
+		ComplainedRecipients []struct {
+			EmailAddress string `json:"emailAddress"`
+		} `json:"complainedRecipients"`
+	} `json:"complaint"`
+}
+
+// SESAdapter implements the Integration interface for email sent through the
+// Amazon SES v2 API. It is the "ses" email provider. Bounce and complaint
+// notifications delivered by SNS suppress the affected addresses.
+type SESAdapter struct {
+	// mu guards the fields below, which change on initialization, rotation and
+	// notifications.
+	mu          sync.RWMutex
+	config      *config.EmailConfig
+	client      *sesv2.Client
+	awsConfig   aws.Config
+	verifier    *snsVerifier
+	suppressed  map[string]time.Time
+	bounces     int
+	complaints  int
+	sendQuota   *sestypes.SendQuota
+	connected   bool
+	lastSync    time.Time
+	lastMessage string
+	initialized bool
+
+	// guard applies the circuit breaker, rate limiter and retries to each call.
+	guard *restGuard
+}
+
+// Compile-time check to ensure SESAdapter implements the Integration interface.
+var _ models.Integration = (*SESAdapter)(nil)
+
+// Compile-time check to ensure SESAdapter supports credential rotation.
+var _ models.CredentialRotator = (*SESAdapter)(nil)
+
+// Compile-time check to ensure SESAdapter reports its circuit state.
+var _ models.CircuitReporter = (*SESAdapter)(nil)
+
+// Compile-time check to ensure SESAdapter's circuit can be reset by operators.
+var _ models.CircuitResetter = (*SESAdapter)(nil)
+
+// Compile-time check to ensure SESAdapter reports its circuit's state and counters.
+var _ models.CircuitInspector = (*SESAdapter)(nil)
+
+// Compile-time check to ensure SESAdapter accepts a retry budget.
+var _ models.RetryBudgetUser = (*SESAdapter)(nil)
+
+// Compile-time check to ensure SESAdapter reports the SES message ID of sends.
+var _ models.ResultSender = (*SESAdapter)(nil)
+
+// Compile-time check to ensure SESAdapter accepts bounce and complaint notifications.
+var _ models.NotificationReceiver = (*SESAdapter)(nil)
+
+// NewSESAdapter creates an uninitialized SES adapter. Its limiter starts at the
+// sandbox send rate of one message per second with a burst of five, and
+// InitializeWithContext raises it to the account's maximum send rate.
+func NewSESAdapter() *SESAdapter {
+	return &SESAdapter{
+		suppressed: make(map[string]time.Time),
+		guard:      newRESTGuard(newAdaptiveLimiter(rate.Limit(1), 5, rate.Every(10*time.Second))),
+	}
+}
+
+// Initialize implements the Integration interface, bridging to
+// InitializeWithContext with a background context.
+func (sa *SESAdapter) Initialize(cfg interface{}) error {
+	return sa.InitializeWithContext(context.Background(), cfg)
+}
+
+// InitializeWithContext configures the adapter from a *config.EmailConfig with
+// its SES section.
+// Steps:
+//  1. Validate the sender address.
+//  2. Load AWS configuration from the default chain on the shared transport.
+//     The SDK's own retries are disabled; the guard retries within the budget.
+//  3. Verify the credentials with GetAccount, and size the limiter to the
+//     account's send rate.
+func (sa *SESAdapter) InitializeWithContext(ctx context.Context, cfg interface{}) error {
+	// 1. Configuration.
+	ec, ok := cfg.(*config.EmailConfig)
+	if !ok || ec == nil || ec.FromAddress == "" {
+		return ErrInvalidSESConfig
+	}
+	sc := ec.SES
+	if sc == nil {
+		sc = &config.SESConfig{}
+	}
+
+	// 2. AWS configuration.
+	opts := []func(*awsconfig.LoadOptions) error{
+		awsconfig.WithHTTPClient(httpclient.Default().ClientWithTimeout(sc.Timeout)),
+		awsconfig.WithRetryMaxAttempts(1),
+	}
+	if sc.Region != "" {
+		opts = append(opts, awsconfig.WithRegion(sc.Region))
+	}
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return fmt.Errorf("%w: load AWS configuration: %v", ErrInvalidSESConfig, err)
+	}
+	client := sesv2.NewFromConfig(awsCfg)
+
+	// 3. Connectivity.
+	account, err := sesAccount(ctx, client)
+	if err != nil {
+		sa.mu.Lock()
+		sa.connected = false
+		sa.mu.Unlock()
+		return fmt.Errorf("%w: ses get account: %v", models.ErrConnectionFailed, err)
+	}
+	if quota := account.SendQuota; quota != nil && quota.MaxSendRate > 0 {
+		sa.guard.limiter = newAdaptiveLimiter(rate.Limit(quota.MaxSendRate), int(quota.MaxSendRate)+1,
+			rate.Every(10*time.Second))
+	}
+
+	sa.mu.Lock()
+	defer sa.mu.Unlock()
+	sa.config = ec
+	sa.client = client
+	sa.awsConfig = awsCfg
+	sa.verifier = newSNSVerifier(sc.NotificationTopicARNs)
+	sa.sendQuota = account.SendQuota
+	sa.connected = true
+	sa.initialized = true
+	sa.lastSync = time.Now()
+	return nil
+}
+
+// RotateCredentials switches to static AWS credentials without a restart:
+// Username is the access key ID and Secret the secret access key. The keys are
+// verified with GetAccount before they replace the active client; on failure
+// the adapter keeps its previous client.
+func (sa *SESAdapter) RotateCredentials(ctx context.Context, creds models.Credentials) error {
+	if creds.Username == "" || creds.Secret == "" {
+		return models.ErrInvalidPayload
+	}
+
+	sa.mu.RLock()
+	awsCfg, initialized := sa.awsConfig.Copy(), sa.initialized
+	sa.mu.RUnlock()
+	if !initialized {
+		return models.ErrInitializationFailed
+	}
+
+	awsCfg.Credentials = aws.NewCredentialsCache(credentials.NewStaticCredentialsProvider(creds.Username, creds.Secret, ""))
+	client := sesv2.NewFromConfig(awsCfg)
+	if _, err := sesAccount(ctx, client); err != nil {
+		return fmt.Errorf("rotated ses credentials rejected: %w", err)
+	}
+
+	sa.mu.Lock()
+	defer sa.mu.Unlock()
+	sa.client = client
+	sa.awsConfig = awsCfg
+	sa.connected = true
+	return nil
+}
+
+// Send implements the Integration interface. The context wrapper used for the
+// SMTP adapter supplies the context; other payloads are sent with a background
+// context.
+func (sa *SESAdapter) Send(payload interface{}) error {
+	if container, ok := payload.(struct {
+		Ctx     context.Context
+		Payload *EmailPayload
+	}); ok {
+		if container.Ctx == nil || container.Payload == nil {
+			return models.ErrInvalidPayload
+		}
+		return sa.SendWithContext(container.Ctx, container.Payload)
+	}
+	return sa.SendWithContext(context.Background(), payload)
+}
+
+// SendWithContext sends a message. The payload is an SESMessage or
+// EmailPayload (or a pointer to either), or a map equivalent to an SESMessage.
+func (sa *SESAdapter) SendWithContext(ctx context.Context, payload interface{}) error {
+	_, err := sa.SendWithResult(ctx, payload)
+	return err
+}
+
+// SendWithResult implements models.ResultSender, sending a message as
+// SendWithContext does and returning the message ID SES assigned it. It also
+// accepts the context wrapper used by Send.
+// Steps:
+//  1. Decode the payload and check its recipients against the allowed domains.
+//  2. Drop suppressed recipients, failing if none remain.
+//  3. Send it with the configured configuration set.
+func (sa *SESAdapter) SendWithResult(ctx context.Context, payload interface{}) (models.SendResult, error) {
+	if container, ok := payload.(struct {
+		Ctx     context.Context
+		Payload *EmailPayload
+	}); ok {
+		if container.Ctx == nil || container.Payload == nil {
+			return models.SendResult{}, models.ErrInvalidPayload
+		}
+		ctx, payload = container.Ctx, container.Payload
+	}
+	sa.mu.RLock()
+	client, ec, initialized := sa.client, sa.config, sa.initialized
+	sa.mu.RUnlock()
+	if !initialized {
+		return models.SendResult{}, models.ErrInitializationFailed
+	}
+
+	// 1. Message.
+	var msg SESMessage
+	switch p := payload.(type) {
+	case EmailPayload:
+		msg = sesFromEmailPayload(&p)
+	case *EmailPayload:
+		if p == nil {
+			return models.SendResult{}, models.ErrInvalidPayload
+		}
+		msg = sesFromEmailPayload(p)
+	default:
+		if err := decodePayload(payload, &msg); err != nil {
+			return models.SendResult{}, err
+		}
+	}
+	if len(msg.To) == 0 || msg.Subject == "" || msg.Body == "" {
+		return models.SendResult{}, fmt.Errorf("%w: recipients, subject and body are required", models.ErrInvalidPayload)
+	}
+	if err := checkAllowedDomains(ec.AllowedDomains, msg.To, msg.Cc, msg.Bcc); err != nil {
+		return models.SendResult{}, err
+	}
+
+	// 2. Suppression.
+	destination := &sestypes.Destination{
+		ToAddresses:  sa.deliverable(msg.To),
+		CcAddresses:  sa.deliverable(msg.Cc),
+		BccAddresses: sa.deliverable(msg.Bcc),
+	}
+	if len(destination.ToAddresses)+len(destination.CcAddresses)+len(destination.BccAddresses) == 0 {
+		return models.SendResult{}, ErrRecipientSuppressed
+	}
+
+	// 3. Send.
+	input := buildSESInput(ec, &msg, destination)
+	var messageID string
+	err := sa.guard.call(ctx, func(ctx context.Context) error {
+		out, err := client.SendEmail(ctx, input)
+		if err != nil {
+			return sesError(err)
+		}
+		messageID = aws.ToString(out.MessageId)
+		return nil
+	})
+	if err != nil {
+		return models.SendResult{}, fmt.Errorf("ses send email: %w", err)
+	}
+
+	sa.mu.Lock()
+	sa.lastSync = time.Now()
+	sa.lastMessage = messageID
+	sa.connected = true
+	sa.mu.Unlock()
+	return models.SendResult{ExternalID: messageID}, nil
+}
+
+// buildSESInput converts msg into a SendEmail request to destination.
+func buildSESInput(ec *config.EmailConfig, msg *SESMessage, destination *sestypes.Destination) *sesv2.SendEmailInput {
+	body := &sestypes.Body{}
+	content := &sestypes.Content{Data: aws.String(msg.Body), Charset: aws.String("UTF-8")}
+	if isHTMLContentType(msg.ContentType) {
+		// HTML bodies get a plain-text alternative.
+		body.Html = content
+		body.Text = &sestypes.Content{Data: aws.String(htmlToText(msg.Body)), Charset: aws.String("UTF-8")}
+	} else {
+		body.Text = content
+	}
+
+	input := &sesv2.SendEmailInput{
+		FromEmailAddress: aws.String(firstNonEmpty(msg.From, ec.FromAddress)),
+		Destination:      destination,
+		ReplyToAddresses: msg.ReplyTo,
+		Content: &sestypes.EmailContent{
+			Simple: &sestypes.Message{
+				Subject: &sestypes.Content{Data: aws.String(msg.Subject), Charset: aws.String("UTF-8")},
+				Body:    body,
+			},
+		},
+	}
+	if ec.SES != nil && ec.SES.ConfigurationSet != "" {
+		input.ConfigurationSetName = aws.String(ec.SES.ConfigurationSet)
+	}
+	for name, value := range msg.Tags {
+		input.EmailTags = append(input.EmailTags, sestypes.MessageTag{Name: aws.String(name), Value: aws.String(value)})
+	}
+	return input
+}
+
+// sesFromEmailPayload converts the SMTP adapter's payload.
+func sesFromEmailPayload(ep *EmailPayload) SESMessage {
+	body, contentType := ep.richestBody()
+	msg := SESMessage{
+		To:          ep.To,
+		Cc:          ep.Cc,
+		Bcc:         ep.Bcc,
+		Subject:     ep.Subject,
+		Body:        body,
+		ContentType: contentType,
+	}
+	if ep.ReplyTo != "" {
+		msg.ReplyTo = []string{ep.ReplyTo}
+	}
+	return msg
+}
+
+// deliverable returns the addresses that are not currently suppressed.
+func (sa *SESAdapter) deliverable(addresses []string) []string {
+	if len(addresses) == 0 {
+		return nil
+	}
+	now := time.Now()
+	sa.mu.Lock()
+	defer sa.mu.Unlock()
+	kept := make([]string, 0, len(addresses))
+	for _, address := range addresses {
+		key := strings.ToLower(strings.TrimSpace(address))
+		until, suppressed := sa.suppressed[key]
+		if suppressed && !until.IsZero() && now.After(until) {
+			delete(sa.suppressed, key)
+			suppressed = false
+		}
+		if !suppressed {
+			kept = append(kept, address)
+		}
+	}
+	return kept
+}
+
+// ReceiveNotification implements models.NotificationReceiver for SNS
+// deliveries from the configured topics.
+// Steps:
+//  1. Verify the SNS message's topic and signature.
+//  2. Confirm subscription requests, so subscribing the endpoint to a topic
+//     needs no manual step.
+//  3. For notifications, suppress permanently bounced and complaining
+//     recipients; transient bounces are only counted.
+func (sa *SESAdapter) ReceiveNotification(ctx context.Context, header http.Header, body []byte) error {
+	sa.mu.RLock()
+	verifier, ec := sa.verifier, sa.config
+	sa.mu.RUnlock()
+	if verifier == nil {
+		return models.ErrInitializationFailed
+	}
+
+	// 1. Verification.
+	msg, err := verifier.parse(ctx, body)
+	if err != nil {
+		return err
+	}
+
+	// 2. Subscriptions.
+	switch msg.Type {
+	case snsTypeSubscriptionConfirmation:
+		return verifier.confirm(ctx, msg)
+	case snsTypeUnsubscribeConfirmation:
+		return nil
+	}
+
+	// 3. Bounces and complaints.
+	var n sesNotification
+	if err := json.Unmarshal([]byte(msg.Message), &n); err != nil {
+		return fmt.Errorf("%w: ses notification: %v", models.ErrInvalidPayload, err)
+	}
+	until := time.Time{}
+	if ec.SES != nil && ec.SES.SuppressionTTL > 0 {
+		until = time.Now().Add(ec.SES.SuppressionTTL)
+	}
+
+	sa.mu.Lock()
+	defer sa.mu.Unlock()
+	switch firstNonEmpty(n.NotificationType, n.EventType) {
+	case "Bounce":
+		sa.bounces++
+		if n.Bounce.BounceType == "Permanent" {
+			for _, r := range n.Bounce.BouncedRecipients {
+				sa.suppressed[strings.ToLower(r.EmailAddress)] = until
+			}
+		}
+	case "Complaint":
+		sa.complaints++
+		for _, r := range n.Complaint.ComplainedRecipients {
+			sa.suppressed[strings.ToLower(r.EmailAddress)] = until
+		}
+	}
+	return nil
+}
+
+// Status implements the Integration interface, reporting connectivity as of the
+// last call along with the circuit, limiter and call metrics, the account's
+// send quota, and bounce and complaint handling.
+func (sa *SESAdapter) Status() (models.IntegrationStatus, error) {
+	sa.mu.RLock()
+	status := models.IntegrationStatus{
+		Connected: sa.connected,
+		Name:      "SESIntegration",
+		Type:      "email",
+		LastSync:  sa.lastSync,
+	}
+	ec, quota := sa.config, sa.sendQuota
+	bounces, complaints, suppressed, lastMessage := sa.bounces, sa.complaints, len(sa.suppressed), sa.lastMessage
+	sa.mu.RUnlock()
+
+	sa.guard.fillStatus(&status)
+	status.Metadata["provider"] = config.EmailProviderSES
+	status.Metadata["lastMessageId"] = lastMessage
+	status.Metadata["bounces"] = bounces
+	status.Metadata["complaints"] = complaints
+	status.Metadata["suppressedRecipients"] = suppressed
+	if quota != nil {
+		status.Metadata["maxSendRate"] = quota.MaxSendRate
+		status.Metadata["max24HourSend"] = quota.Max24HourSend
+	}
+	if ec != nil && ec.SES != nil {
+		status.Metadata["configurationSet"] = ec.SES.ConfigurationSet
+	}
+	return status, nil
+}
+
+// SetRetryBudget implements models.RetryBudgetUser.
+func (sa *SESAdapter) SetRetryBudget(budget models.RetryBudget) {
+	sa.guard.budget = budget
+}
+
+// CircuitOpen implements models.CircuitReporter.
+func (sa *SESAdapter) CircuitOpen() bool {
+	return sa.guard.breaker.IsOpen()
+}
+
+// CircuitSnapshot implements models.CircuitInspector.
+func (sa *SESAdapter) CircuitSnapshot() models.CircuitSnapshot {
+	return sa.guard.breaker.Snapshot()
+}
+
+// ResetCircuit implements models.CircuitResetter.
+func (sa *SESAdapter) ResetCircuit() {
+	sa.guard.breaker.Reset()
+}
+
+// sesAccount fetches the account's sending status and quota, failing when
+// sending is disabled.
+func sesAccount(ctx context.Context, client *sesv2.Client) (*sesv2.GetAccountOutput, error) {
+	account, err := client.GetAccount(ctx, &sesv2.GetAccountInput{})
+	if err != nil {
+		return nil, sesError(err)
+	}
+	if !account.SendingEnabled {
+		return nil, ErrSESSendingDisabled
+	}
+	return account, nil
+}
+
+// sesError converts an SDK error into a *restError carrying the HTTP status, so
+// the guard treats throttling and rejected messages like other providers';
+// throttling error codes are reported as 429 whatever their status.
+func sesError(err error) error {
+	var respErr *awshttp.ResponseError
+	if !errors.As(err, &respErr) {
+		return err
+	}
+	re := &restError{StatusCode: respErr.HTTPStatusCode(), Body: err.Error()}
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) && sesThrottlingCodes[apiErr.ErrorCode()] {
+		re.StatusCode = http.StatusTooManyRequests
+	}
+	return re
+}
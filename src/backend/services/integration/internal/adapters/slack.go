@@ -1,21 +1,24 @@
 package adapters
 
 import (
-	"context" // go1.21 - Context for cancellations and timeouts
-	"errors"  // go1.21 - Enhanced error handling
-	"time"    // go1.21 - Time-based operations for deadlines and timeouts
+	"context"     // go1.21 - Context for cancellations and timeouts
+	"errors"      // go1.21 - Enhanced error handling
+	"fmt"         // go1.21 - Wraps Slack API errors
+	"regexp"      // go1.21 - Recognizes channel IDs
+	"sort"        // go1.21 - Orders failed workspaces in status
+	"strings"     // go1.21 - Joins coalesced batch lines
+	"sync/atomic" // go1.21 - Swaps the circuit breaker on operator reset
+	"time"        // go1.21 - Time-based operations for deadlines and timeouts
 
 	// v0.12.3 - Official Slack API client with additional security features
 	"github.com/slack-go/slack"
 
-	// v0.5.0 (example) - Rate limiting for controlling request flow
-	"golang.org/x/time/rate"
-
 	// v1.1.0 (example) - Circuit breaker for fault tolerance
 	"github.com/sony/gobreaker"
 
 	// Internal imports for integration interface and Slack configuration
 	"src/backend/services/integration/internal/config"
+	"src/backend/services/integration/internal/httpclient"
 	"src/backend/services/integration/internal/models"
 
 	// Hypothetical metrics package for reporting integration metrics
@@ -39,10 +42,69 @@ var ErrSlackClientInit = errors.New("failed to initialize slack client: authenti
 // successfully initialized before a method requiring initialization was invoked.
 var ErrSlackNotInitialized = errors.New("slack adapter not properly initialized")
 
+// slackBatchMessageLimit caps the length of a coalesced message. Slack truncates
+// messages beyond 40,000 characters and recommends staying under 4,000.
+const slackBatchMessageLimit = 4000
+
+// defaultSlackRetryAttempts caps how many times a call rate limited by Slack
+// is retried after its Retry-After pause, unless configured.
+const defaultSlackRetryAttempts = 3
+
+// defaultSlackMaxRetryAfter is the longest Retry-After a call waits out,
+// unless configured; longer pauses fail the call, leaving the limiter paused
+// for later ones.
+const defaultSlackMaxRetryAfter = time.Minute
+
 // ErrSlackSendFailed indicates that an attempt to send a message via Slack
 // failed due to an API or rate limit error.
 var ErrSlackSendFailed = errors.New("failed to send message to slack: api or rate limit error")
 
+// slackChannelIDPattern matches conversation IDs, which are used as is
+// rather than resolved by name.
+var slackChannelIDPattern = regexp.MustCompile(`^[CGD][A-Z0-9]{8,}$`)
+
+// Actions for SlackMessage.Action.
+const (
+	// SlackActionPost posts a new message, in a thread when ThreadTS is set.
+	SlackActionPost = "post"
+
+	// SlackActionUpdate replaces the text of the message at Timestamp.
+	SlackActionUpdate = "update"
+
+	// SlackActionDelete deletes the message at Timestamp.
+	SlackActionDelete = "delete"
+)
+
+// SlackMessage is a message to post, update or delete. Send also accepts a
+// plain string, which is posted to the default channel. Posts report the
+// channel ID and message timestamp in their models.SendResult, which later
+// replies, updates and deletes refer to.
+type SlackMessage struct {
+	// Action is "post" (the default), "update" or "delete".
+	Action string `json:"action,omitempty"`
+
+	// Channel is a channel ID or name, with or without "#", and defaults to
+	// the configured default channel. Names are resolved to IDs through the
+	// cached channel list.
+	Channel string `json:"channel,omitempty"`
+
+	// Text is the message text; required for posts and updates.
+	Text string `json:"text,omitempty"`
+
+	// ThreadTS posts the message as a reply in the thread of that message.
+	ThreadTS string `json:"threadTs,omitempty"`
+
+	// Broadcast also shows a thread reply in the channel.
+	Broadcast bool `json:"broadcast,omitempty"`
+
+	// Timestamp identifies the message to update or delete.
+	Timestamp string `json:"timestamp,omitempty"`
+
+	// Workspace names the configured workspace to send to; empty sends to the
+	// default workspace.
+	Workspace string `json:"workspace,omitempty"`
+}
+
 // ----------------------------------------------------------------------------
 // SlackAdapter Struct
 // ----------------------------------------------------------------------------
@@ -50,37 +112,79 @@ var ErrSlackSendFailed = errors.New("failed to send message to slack: api or rat
 // SlackAdapter implements the Integration interface for Slack communication.
 // It provides enhanced security, monitoring, and error handling features.
 type SlackAdapter struct {
-	// client is an instance of the official Slack API client, used to
-	// interact with Slack for sending messages, retrieving workspace info, etc.
-	client *slack.Client
-
-	// defaultChannel is used when no channel is explicitly specified in the payload.
-	// This is helpful for system notifications and fallback message destinations.
-	defaultChannel string
+	// workspaces are the Slack workspaces messages can be sent to, by name,
+	// each with its own client, default channel, rate limiter and channel
+	// cache. The primary token is the workspace named "default".
+	workspaces map[string]*slackWorkspace
 
 	// timeout is the maximum duration for an API call to Slack before timing out.
 	timeout time.Duration
 
+	// retryAttempts and maxRetryAfter bound the retries of rate-limited calls.
+	retryAttempts int
+	maxRetryAfter time.Duration
+
+	// retryBudget bounds retries of rate-limited calls across sends; set at
+	// registration.
+	retryBudget models.RetryBudget
+
 	// initialized signifies whether the SlackAdapter has been successfully
 	// configured and is ready to send messages or retrieve status.
 	initialized bool
 
-	// rateLimiter controls the frequency of Slack API calls, preventing
-	// excessive requests that might trip Slack's rate limits.
-	rateLimiter *rate.Limiter
-
 	// circuitBreaker provides fault tolerance by tripping
-	// if error rates or latency thresholds exceed configured limits.
-	circuitBreaker *gobreaker.CircuitBreaker
+	// if error rates or latency thresholds exceed configured limits. It is
+	// replaced with a fresh breaker when an operator resets the circuit.
+	circuitBreaker atomic.Pointer[gobreaker.CircuitBreaker]
+
+	// breakerSettings are kept to build the replacement breaker on reset.
+	breakerSettings gobreaker.Settings
 
 	// metricsReporter is responsible for collecting metrics and telemetry
 	// data about Slack calls, errors, retries, and other performance indicators.
 	metricsReporter *metrics.Reporter
+
+	// sharedLimiter, when set, holds each workspace to its rate limit across
+	// every replica of the service.
+	sharedLimiter models.SharedLimiter
 }
 
 // Compile-time check to ensure SlackAdapter implements the Integration interface.
 var _ models.Integration = (*SlackAdapter)(nil)
 
+// Compile-time check to ensure SlackAdapter reports the channel and timestamp of posts.
+var _ models.ResultSender = (*SlackAdapter)(nil)
+
+// Compile-time check to ensure SlackAdapter supports credential rotation.
+var _ models.CredentialRotator = (*SlackAdapter)(nil)
+
+// Compile-time check to ensure SlackAdapter supports batch sends.
+var _ models.BatchSender = (*SlackAdapter)(nil)
+
+// Compile-time check to ensure SlackAdapter exposes metadata invalidation.
+var _ models.MetadataInvalidator = (*SlackAdapter)(nil)
+
+// Compile-time check to ensure SlackAdapter reports its circuit state.
+var _ models.CircuitReporter = (*SlackAdapter)(nil)
+
+// Compile-time check to ensure SlackAdapter's circuit can be reset by operators.
+var _ models.CircuitResetter = (*SlackAdapter)(nil)
+
+// Compile-time check to ensure SlackAdapter reports its circuit's state and counters.
+var _ models.CircuitInspector = (*SlackAdapter)(nil)
+
+// Compile-time check to ensure SlackAdapter can test its tokens on demand.
+var _ models.ConnectionTester = (*SlackAdapter)(nil)
+
+// Compile-time check to ensure SlackAdapter can delete the messages it posts.
+var _ models.SendReverter = (*SlackAdapter)(nil)
+
+// Compile-time check to ensure SlackAdapter paces workspaces cluster-wide when asked.
+var _ models.SharedLimiterUser = (*SlackAdapter)(nil)
+
+// Compile-time check to ensure SlackAdapter retries within the integration's retry budget.
+var _ models.RetryBudgetUser = (*SlackAdapter)(nil)
+
 // ----------------------------------------------------------------------------
 // NewSlackAdapter
 // ----------------------------------------------------------------------------
@@ -95,10 +199,6 @@ func NewSlackAdapter(reporter *metrics.Reporter) *SlackAdapter {
 		initialized:     false,
 	}
 
-	// Set up a default rate limiter.
-	// Example: 5 requests per second with a burst of 10.
-	a.rateLimiter = rate.NewLimiter(rate.Limit(5), 10)
-
 	// Configure the circuit breaker settings for resilience.
 	// The example below is a simplistic approach to illustrate usage.
 	cbSettings := gobreaker.Settings{
@@ -116,8 +216,15 @@ func NewSlackAdapter(reporter *metrics.Reporter) *SlackAdapter {
 			}
 			return float64(failures)/float64(total) >= 0.5
 		},
+		// Rate limiting means Slack is up but busy; the limiter handles it, so it
+		// does not count towards tripping the circuit.
+		IsSuccessful: func(err error) bool {
+			var rateLimited *slack.RateLimitedError
+			return err == nil || errors.As(err, &rateLimited)
+		},
 	}
-	a.circuitBreaker = gobreaker.NewCircuitBreaker(cbSettings)
+	a.breakerSettings = cbSettings
+	a.circuitBreaker.Store(gobreaker.NewCircuitBreaker(cbSettings))
 
 	return a
 }
@@ -151,9 +258,6 @@ func (a *SlackAdapter) Initialize(cfg interface{}) error {
 		return ErrInvalidSlackConfig
 	}
 
-	// Set default channel if provided
-	a.defaultChannel = sc.DefaultChannel
-
 	// If a specific timeout was configured, use it; otherwise fall back to 30s
 	if sc.Timeout > 0 {
 		a.timeout = sc.Timeout
@@ -161,29 +265,45 @@ func (a *SlackAdapter) Initialize(cfg interface{}) error {
 		a.timeout = 30 * time.Second
 	}
 
-	// Initialize the Slack client with the provided API token.
-	// Additional slack.Option values could be used for custom HTTP clients,
-	// debugging flags, etc.
-	a.client = slack.New(sc.APIToken)
+	// Rate-limited calls are retried after Slack's Retry-After, within the
+	// configured policy.
+	a.retryAttempts = sc.RetryAttempts
+	if a.retryAttempts <= 0 {
+		a.retryAttempts = defaultSlackRetryAttempts
+	}
+	a.maxRetryAfter = sc.MaxRetryAfter
+	if a.maxRetryAfter <= 0 {
+		a.maxRetryAfter = defaultSlackMaxRetryAfter
+	}
+
+	// Initialize a Slack client for each workspace, starting with the provided
+	// API token as the default workspace. The clients use the shared HTTP
+	// transport so connections are pooled across adapters.
+	a.workspaces = map[string]*slackWorkspace{
+		config.SlackDefaultWorkspace: newSlackWorkspace(config.SlackDefaultWorkspace, sc.DefaultChannel, a.newClient(sc.APIToken), sc.RateLimit, sc.MetadataTTL),
+	}
+	for _, wc := range sc.Workspaces {
+		if wc.Name == "" || wc.Token == "" || a.workspaces[wc.Name] != nil {
+			return ErrInvalidSlackConfig
+		}
+		a.workspaces[wc.Name] = newSlackWorkspace(wc.Name, wc.DefaultChannel, a.newClient(wc.Token), sc.RateLimit, sc.MetadataTTL)
+	}
+
+	for name, ws := range a.workspaces {
+		ws.rateLimiter.share(a.sharedLimiter, name)
+	}
 
 	// Here, we could apply advanced Slack security or enterprise features if needed.
 	// For example, Slack allows custom HTTP client configuration for TLS settings.
 
-	// If a retry config is specified, it could be used to adjust the rate limiter or
-	// other retry strategies. For demonstration, we show placeholder logic here.
-	// sc.RetryConfig might contain fields like MaxRetries, Interval, etc.
-	// This is purely illustrative, as the exact structure is not fully defined here.
-	// Example:
-	// if sc.RetryConfig.MaxRetries > 0 {
-	// 	   // Adjust rate or circuit breaker if needed
-	// }
-
-	// Test the Slack API connectivity by making a quick "auth.test" call.
+	// Test the Slack API connectivity of every workspace by making a quick
+	// "auth.test" call.
 	ctx, cancel := context.WithTimeout(context.Background(), a.timeout)
 	defer cancel()
-	_, err := a.client.AuthTestContext(ctx)
-	if err != nil {
-		return ErrSlackClientInit
+	for name, ws := range a.workspaces {
+		if _, err := ws.currentClient().AuthTestContext(ctx); err != nil {
+			return fmt.Errorf("%w: workspace %s", ErrSlackClientInit, name)
+		}
 	}
 
 	// If we reach here, all checks passed.
@@ -198,7 +318,8 @@ func (a *SlackAdapter) Initialize(cfg interface{}) error {
 
 // Send transmits a given payload to the Slack channel. It enforces initialization,
 // ensures the payload is valid, honors rate-limiting and circuit-breaker rules,
-// and, upon success or failure, updates and reports relevant metrics.
+// and, upon success or failure, updates and reports relevant metrics. The
+// payload is a string posted to the default channel, or a SlackMessage.
 //
 // Steps performed:
 //  1. Verify initialization status.
@@ -210,62 +331,275 @@ func (a *SlackAdapter) Initialize(cfg interface{}) error {
 //  7. Record metrics and measure latency.
 //  8. Return detailed error context if the send fails.
 func (a *SlackAdapter) Send(payload interface{}) error {
+	_, err := a.SendWithResult(context.Background(), payload)
+	return err
+}
+
+// SendWithResult implements models.ResultSender. Posts and updates report
+// the channel ID and the message timestamp as the external ID.
+func (a *SlackAdapter) SendWithResult(ctx context.Context, payload interface{}) (models.SendResult, error) {
 	// Check if the adapter has been initialized
 	if !a.initialized {
-		return ErrSlackNotInitialized
+		return models.SendResult{}, ErrSlackNotInitialized
 	}
 
-	// Verify the payload is something we can send (e.g., a string).
-	message, ok := payload.(string)
-	if !ok {
-		return models.ErrInvalidPayload
+	// Verify the payload is something we can send: text for the default
+	// channel, or a message naming its action.
+	var msg SlackMessage
+	switch p := payload.(type) {
+	case string:
+		msg = SlackMessage{Text: p}
+	case SlackMessage:
+		msg = p
+	case *SlackMessage:
+		if p == nil {
+			return models.SendResult{}, models.ErrInvalidPayload
+		}
+		msg = *p
+	default:
+		if err := decodePayload(payload, &msg); err != nil {
+			return models.SendResult{}, err
+		}
+	}
+	ws, err := a.workspace(msg.Workspace)
+	if err != nil {
+		return models.SendResult{}, err
+	}
+	channel := a.resolveChannel(ctx, ws, firstNonEmpty(msg.Channel, ws.defaultChannel))
+
+	switch msg.Action {
+	case "", SlackActionPost:
+		if msg.Text == "" {
+			// Protect against empty messages if Slack usage policy prohibits them
+			return models.SendResult{}, models.ErrInvalidPayload
+		}
+		options := []slack.MsgOption{slack.MsgOptionText(msg.Text, false)}
+		if msg.ThreadTS != "" {
+			options = append(options, slack.MsgOptionTS(msg.ThreadTS))
+			if msg.Broadcast {
+				options = append(options, slack.MsgOptionBroadcast())
+			}
+		}
+		return a.postMessage(ctx, ws, channel, options...)
+	case SlackActionUpdate:
+		if msg.Text == "" || msg.Timestamp == "" {
+			return models.SendResult{}, models.ErrInvalidPayload
+		}
+		var result models.SendResult
+		err := a.call(ctx, ws, func(ctx context.Context, client *slack.Client) error {
+			updatedChannel, ts, _, err := client.UpdateMessageContext(ctx, channel, msg.Timestamp, slack.MsgOptionText(msg.Text, false))
+			result = models.SendResult{Channel: updatedChannel, ExternalID: ts}
+			return err
+		})
+		return result, err
+	case SlackActionDelete:
+		if msg.Timestamp == "" {
+			return models.SendResult{}, models.ErrInvalidPayload
+		}
+		err := a.call(ctx, ws, func(ctx context.Context, client *slack.Client) error {
+			_, _, err := client.DeleteMessageContext(ctx, channel, msg.Timestamp)
+			return err
+		})
+		return models.SendResult{Channel: channel, ExternalID: msg.Timestamp}, err
+	default:
+		return models.SendResult{}, models.ErrInvalidPayload
+	}
+}
+
+// RevertSend implements models.SendReverter, deleting the message a post
+// created. Updates and deletes cannot be reverted.
+func (a *SlackAdapter) RevertSend(ctx context.Context, payload interface{}, result models.SendResult) error {
+	var msg SlackMessage
+	switch p := payload.(type) {
+	case string:
+	case SlackMessage:
+		msg = p
+	case *SlackMessage:
+		if p != nil {
+			msg = *p
+		}
+	default:
+		if err := decodePayload(payload, &msg); err != nil {
+			return err
+		}
+	}
+	if msg.Action != "" && msg.Action != SlackActionPost {
+		return fmt.Errorf("%w: only posted Slack messages can be reverted", models.ErrNotImplemented)
 	}
-	if message == "" {
-		// Protect against empty messages if Slack usage policy prohibits them
+	if result.Channel == "" || result.ExternalID == "" {
 		return models.ErrInvalidPayload
 	}
+	ws, err := a.workspace(msg.Workspace)
+	if err != nil {
+		return err
+	}
+	return a.call(ctx, ws, func(ctx context.Context, client *slack.Client) error {
+		_, _, err := client.DeleteMessageContext(ctx, result.Channel, result.ExternalID)
+		return err
+	})
+}
 
-	// Enforce rate-limiting
-	// If Wait fails due to context cancellation, it will return an error.
-	ctx, cancel := context.WithTimeout(context.Background(), a.timeout)
-	defer cancel()
-	err := a.rateLimiter.Wait(ctx, 1)
+// SendBatch implements models.BatchSender by joining the messages, one per
+// line, into as few Slack messages as fit within slackBatchMessageLimit. Each
+// payload's result is the Slack message its line was posted in. Invalid lines
+// fail on their own; once a post fails, it and the posts after it fail.
+func (a *SlackAdapter) SendBatch(ctx context.Context, payloads []interface{}) ([]models.BatchResult, error) {
+	if !a.initialized {
+		return nil, ErrSlackNotInitialized
+	}
+	ws, err := a.workspace(config.SlackDefaultWorkspace)
 	if err != nil {
-		return ErrSlackSendFailed
+		return nil, err
+	}
+
+	// Validate every line before posting anything.
+	results := make([]models.BatchResult, len(payloads))
+	lines := make([]string, 0, len(payloads))
+	indexes := make([]int, 0, len(payloads))
+	for i, payload := range payloads {
+		message, ok := payload.(string)
+		if !ok || message == "" {
+			results[i].Err = models.ErrInvalidPayload
+			continue
+		}
+		lines = append(lines, message)
+		indexes = append(indexes, i)
+	}
+
+	texts, counts := joinSlackLines(lines, slackBatchMessageLimit)
+	var postErr error
+	next := 0
+	for m, text := range texts {
+		var posted models.SendResult
+		if postErr == nil {
+			if postErr = ctx.Err(); postErr == nil {
+				posted, postErr = a.postMessage(ctx, ws, ws.defaultChannel, slack.MsgOptionText(text, false))
+			}
+		}
+		for _, i := range indexes[next : next+counts[m]] {
+			results[i] = models.BatchResult{Result: posted, Err: postErr}
+		}
+		next += counts[m]
 	}
+	return results, nil
+}
 
-	// Circuit breaker execution to wrap the Slack post message attempt
-	_, cbErr := a.circuitBreaker.Execute(func() (interface{}, error) {
-		// Construct a specialized context for the actual Slack API call
-		apiCtx, apiCancel := context.WithTimeout(context.Background(), a.timeout)
-		defer apiCancel()
-
-		// Attempt to send the message to Slack
-		_, _, sendErr := a.client.PostMessageContext(
-			apiCtx,
-			a.defaultChannel,
-			slack.MsgOptionText(message, false),
-		)
-		if sendErr != nil {
-			return nil, sendErr
+// slackRequestEventType is the metadata event type of messages posted for an
+// API request, whose payload carries the request ID.
+const slackRequestEventType = "taskstream_request"
+
+// postMessage posts a message to channel in ws, recording metrics on success,
+// and returns the channel ID and timestamp Slack assigned it. Messages posted
+// for an API request carry its ID in their metadata.
+func (a *SlackAdapter) postMessage(ctx context.Context, ws *slackWorkspace, channel string, options ...slack.MsgOption) (models.SendResult, error) {
+	if requestID := models.RequestIDFrom(ctx); requestID != "" {
+		options = append(options, slack.MsgOptionMetadata(slack.SlackMetadata{
+			EventType:    slackRequestEventType,
+			EventPayload: map[string]interface{}{"request_id": requestID},
+		}))
+	}
+	var result models.SendResult
+	err := a.call(ctx, ws, func(ctx context.Context, client *slack.Client) error {
+		postedChannel, ts, err := client.PostMessageContext(ctx, channel, options...)
+		if err != nil {
+			return err
 		}
+		result = models.SendResult{Channel: postedChannel, ExternalID: ts}
 
 		// If successful, we can record metrics such as message count or latency.
 		if a.metricsReporter != nil {
 			a.metricsReporter.RecordSlackMessageSent()
 		}
+		return nil
+	})
+	return result, err
+}
+
+// call runs fn with the active client of ws under the workspace's rate limiter
+// and the circuit breaker, each bounded by the configured timeout.
+func (a *SlackAdapter) call(ctx context.Context, ws *slackWorkspace, fn func(ctx context.Context, client *slack.Client) error) error {
+	// Enforce rate-limiting
+	// If Wait fails due to context cancellation, it will return an error.
+	waitCtx, cancel := context.WithTimeout(ctx, a.timeout)
+	defer cancel()
+	err := ws.rateLimiter.Wait(waitCtx)
+	if err != nil {
+		return ErrSlackSendFailed
+	}
+
+	// Circuit breaker execution to wrap the Slack API call. A 429 pauses the
+	// limiter for Slack's Retry-After and the call is retried once the pause
+	// ends, unless it would outlast the caller's deadline or the retries or the
+	// retry budget run out.
+	_, cbErr := a.circuitBreaker.Load().Execute(func() (interface{}, error) {
+		for attempt := 0; ; attempt++ {
+			// Construct a specialized context for the actual Slack API call
+			apiCtx, apiCancel := context.WithTimeout(ctx, a.timeout)
+			callErr := fn(apiCtx, ws.currentClient())
+			apiCancel()
+			if callErr == nil {
+				ws.rateLimiter.OnSuccess()
+				return nil, nil
+			}
 
-		return nil, nil
+			var rateLimited *slack.RateLimitedError
+			if !errors.As(callErr, &rateLimited) {
+				return nil, callErr
+			}
+			ws.rateLimiter.OnThrottled(rateLimited.RetryAfter)
+			if attempt >= a.retryAttempts || rateLimited.RetryAfter > a.maxRetryAfter {
+				return nil, callErr
+			}
+			if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < rateLimited.RetryAfter {
+				return nil, callErr
+			}
+			if !allowRetry(a.retryBudget) {
+				return nil, fmt.Errorf("%w: %w", models.ErrRetryBudgetExhausted, callErr)
+			}
+			if err := ws.rateLimiter.Wait(ctx); err != nil {
+				return nil, callErr
+			}
+		}
 	})
 
 	if cbErr != nil {
 		// The circuit breaker or Slack API responded with an error, so wrap it.
-		return ErrSlackSendFailed
+		return fmt.Errorf("%w: %w", ErrSlackSendFailed, cbErr)
 	}
 
 	return nil
 }
 
+// joinSlackLines packs lines, separated by newlines, into messages of at most
+// limit bytes, returning each message with the number of lines it holds. A
+// single line longer than limit is sent on its own.
+func joinSlackLines(lines []string, limit int) ([]string, []int) {
+	var (
+		messages []string
+		counts   []int
+		current  strings.Builder
+		n        int
+	)
+	for _, line := range lines {
+		if current.Len() > 0 && current.Len()+1+len(line) > limit {
+			messages = append(messages, current.String())
+			counts = append(counts, n)
+			current.Reset()
+			n = 0
+		}
+		if current.Len() > 0 {
+			current.WriteByte('\n')
+		}
+		current.WriteString(line)
+		n++
+	}
+	if current.Len() > 0 {
+		messages = append(messages, current.String())
+		counts = append(counts, n)
+	}
+	return messages, counts
+}
+
 // ----------------------------------------------------------------------------
 // Status
 // ----------------------------------------------------------------------------
@@ -294,18 +628,28 @@ func (a *SlackAdapter) Status() (models.IntegrationStatus, error) {
 		return status, ErrSlackNotInitialized
 	}
 
-	// Attempt a quick check to validate Slack connectivity
+	// Attempt a quick check to validate the connectivity of each workspace,
+	// reporting every workspace independently.
 	ctx, cancel := context.WithTimeout(context.Background(), a.timeout)
 	defer cancel()
 
-	_, err := a.client.AuthTestContext(ctx)
-	if err != nil {
-		// If the test call fails, note the error condition in the status
+	workspaces := make(map[string]interface{}, len(a.workspaces))
+	var failed []string
+	for name, ws := range a.workspaces {
+		wsStatus := ws.status(ctx)
+		workspaces[name] = wsStatus
+		if wsStatus["connected"] != true {
+			failed = append(failed, name)
+		}
+	}
+	status.Metadata["workspaces"] = workspaces
+	if len(failed) > 0 {
+		// If a test call fails, note the error condition in the status
+		sort.Strings(failed)
 		status.LastError = time.Now()
 		status.ErrorCount++
-		// Optionally populate more metadata about the failure
-		status.Metadata["authTestError"] = err.Error()
-		return status, err
+		status.Metadata["failedWorkspaces"] = failed
+		return status, fmt.Errorf("%w: workspaces %s", ErrSlackClientInit, strings.Join(failed, ", "))
 	}
 
 	// Mark as connected if no errors occurred in the auth test
@@ -317,12 +661,12 @@ func (a *SlackAdapter) Status() (models.IntegrationStatus, error) {
 	status.LastSync = time.Now()
 
 	// Include circuit breaker statistics
-	cbState := a.circuitBreaker.State()
+	cbState := a.circuitBreaker.Load().State()
 	status.Metadata["circuitBreakerState"] = cbState.String()
 
 	// If the circuit breaker exposes internal failure counts,
 	// we can compute or store success rates. Here, we fetch counts as an example:
-	cbCounts := a.circuitBreaker.Counts()
+	cbCounts := a.circuitBreaker.Load().Counts()
 	status.ErrorCount = int(cbCounts.TotalFailures)
 	totalRequests := cbCounts.Requests
 	if totalRequests > 0 {
@@ -330,9 +674,12 @@ func (a *SlackAdapter) Status() (models.IntegrationStatus, error) {
 		status.SuccessRate = float64(successes) / float64(totalRequests)
 	}
 
-	// Rate limiter info: how many tokens are left in the bucket, etc.
-	tokens := a.rateLimiter.Tokens()
-	status.Metadata["rateLimiterTokens"] = tokens
+	// Rate limiter info for the default workspace: how many tokens are left in
+	// the bucket, etc. Other workspaces report theirs under "workspaces".
+	defaultWS := a.workspaces[config.SlackDefaultWorkspace]
+	status.Metadata["rateLimiterTokens"] = defaultWS.rateLimiter.Tokens()
+	status.Metadata["rateLimiter"] = defaultWS.rateLimiter.Stats()
+	status.Metadata["metadataCache"] = defaultWS.metadata.Stats()
 
 	// If we have a metrics reporter, we can gather additional Slack usage metrics
 	if a.metricsReporter != nil {
@@ -344,4 +691,227 @@ func (a *SlackAdapter) Status() (models.IntegrationStatus, error) {
 	}
 
 	return status, nil
-}
\ No newline at end of file
+}
+
+// ----------------------------------------------------------------------------
+// RotateCredentials
+// ----------------------------------------------------------------------------
+
+// RotateCredentials switches a workspace to a new Slack token without a
+// restart. The workspace is named by creds.Username, and is the default
+// workspace when it is empty. The token is verified with auth.test before it
+// replaces the active client, so a rejected token leaves the workspace on its
+// previous credentials. Calls already in flight complete on the old client.
+//
+// Steps performed:
+//  1. Validate that a token was supplied for a known workspace.
+//  2. Build a new Slack client with the token.
+//  3. Verify the token with an auth.test call.
+//  4. Swap the new client in.
+func (a *SlackAdapter) RotateCredentials(ctx context.Context, creds models.Credentials) error {
+	if creds.Secret == "" {
+		return ErrInvalidSlackConfig
+	}
+	ws, err := a.workspace(creds.Username)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSlackConfig, err)
+	}
+
+	client := a.newClient(creds.Secret)
+
+	timeout := a.timeout
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+	testCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	if _, err := client.AuthTestContext(testCtx); err != nil {
+		return ErrSlackClientInit
+	}
+
+	ws.setClient(client)
+
+	// Channel visibility depends on the token, so cached lists may be stale.
+	ws.metadata.InvalidatePrefix("")
+	return nil
+}
+
+// TestConnection implements models.ConnectionTester, calling auth.test with
+// every workspace's token and reporting the team and bot user each one
+// authenticates as.
+func (a *SlackAdapter) TestConnection(ctx context.Context) (map[string]interface{}, error) {
+	if !a.initialized {
+		return nil, ErrSlackNotInitialized
+	}
+	if a.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, a.timeout)
+		defer cancel()
+	}
+
+	workspaces := make(map[string]interface{}, len(a.workspaces))
+	var failed []string
+	for name, ws := range a.workspaces {
+		auth, err := ws.currentClient().AuthTestContext(ctx)
+		if err != nil {
+			workspaces[name] = map[string]interface{}{"error": err.Error()}
+			failed = append(failed, name)
+			continue
+		}
+		workspaces[name] = map[string]interface{}{
+			"team":   auth.Team,
+			"teamId": auth.TeamID,
+			"user":   auth.User,
+			"url":    auth.URL,
+		}
+	}
+	details := map[string]interface{}{"workspaces": workspaces}
+	if len(failed) > 0 {
+		sort.Strings(failed)
+		return details, fmt.Errorf("%w: workspaces %s", ErrSlackClientInit, strings.Join(failed, ", "))
+	}
+	return details, nil
+}
+
+// ----------------------------------------------------------------------------
+// Metadata
+// ----------------------------------------------------------------------------
+
+// Channels returns the public and private channels visible to the bot in the
+// named workspace, or the default workspace when workspace is empty, served
+// from the workspace's metadata cache while fresh.
+func (a *SlackAdapter) Channels(ctx context.Context, workspace string) ([]slack.Channel, error) {
+	if !a.initialized {
+		return nil, ErrSlackNotInitialized
+	}
+	ws, err := a.workspace(workspace)
+	if err != nil {
+		return nil, err
+	}
+	return a.channels(ctx, ws)
+}
+
+// channels returns the channel list of ws from its metadata cache.
+func (a *SlackAdapter) channels(ctx context.Context, ws *slackWorkspace) ([]slack.Channel, error) {
+	value, err := ws.metadata.GetOrLoad(ctx, metadataKeySlackChannels, func(ctx context.Context) (interface{}, error) {
+		return a.listChannels(ctx, ws)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.([]slack.Channel), nil
+}
+
+// resolveChannel returns the ID of the channel named channel, looked up in the
+// cached channel list of ws. IDs, and names the list cannot resolve, such as
+// channels created since it was cached or when it cannot be loaded, are
+// returned as given for Slack to resolve or reject.
+func (a *SlackAdapter) resolveChannel(ctx context.Context, ws *slackWorkspace, channel string) string {
+	if slackChannelIDPattern.MatchString(channel) {
+		return channel
+	}
+	channels, err := a.channels(ctx, ws)
+	if err != nil {
+		return channel
+	}
+	name := strings.TrimPrefix(channel, "#")
+	for _, c := range channels {
+		if c.Name == name {
+			return c.ID
+		}
+	}
+	return channel
+}
+
+// SetRetryBudget implements models.RetryBudgetUser.
+func (a *SlackAdapter) SetRetryBudget(budget models.RetryBudget) {
+	a.retryBudget = budget
+}
+
+// SetSharedLimiter implements models.SharedLimiterUser. Each workspace's calls
+// are counted under the workspace name.
+func (a *SlackAdapter) SetSharedLimiter(limiter models.SharedLimiter) {
+	a.sharedLimiter = limiter
+	for name, ws := range a.workspaces {
+		ws.rateLimiter.share(limiter, name)
+	}
+}
+
+// CircuitOpen implements models.CircuitReporter.
+func (a *SlackAdapter) CircuitOpen() bool {
+	return a.circuitBreaker.Load().State() == gobreaker.StateOpen
+}
+
+// CircuitSnapshot implements models.CircuitInspector. The breaker trips on its
+// failure ratio rather than a count, so no threshold is reported, and gobreaker
+// does not expose when it opened.
+func (a *SlackAdapter) CircuitSnapshot() models.CircuitSnapshot {
+	cb := a.circuitBreaker.Load()
+	counts := cb.Counts()
+	snapshot := models.CircuitSnapshot{
+		State:               models.CircuitClosed,
+		ConsecutiveFailures: int(counts.ConsecutiveFailures),
+		Requests:            int(counts.Requests),
+		TotalFailures:       int(counts.TotalFailures),
+	}
+	switch cb.State() {
+	case gobreaker.StateOpen:
+		snapshot.State = models.CircuitOpen
+	case gobreaker.StateHalfOpen:
+		snapshot.State = models.CircuitHalfOpen
+	}
+	return snapshot
+}
+
+// ResetCircuit implements models.CircuitResetter. gobreaker has no reset, so a
+// fresh, closed breaker with the same settings replaces the current one; calls
+// already running on the old breaker finish there.
+func (a *SlackAdapter) ResetCircuit() {
+	a.circuitBreaker.Store(gobreaker.NewCircuitBreaker(a.breakerSettings))
+}
+
+// InvalidateMetadata implements models.MetadataInvalidator, clearing the
+// matching entries of every workspace.
+func (a *SlackAdapter) InvalidateMetadata(prefix string) int {
+	removed := 0
+	for _, ws := range a.workspaces {
+		removed += ws.metadata.InvalidatePrefix(prefix)
+	}
+	return removed
+}
+
+// listChannels pages through conversations.list for ws.
+func (a *SlackAdapter) listChannels(ctx context.Context, ws *slackWorkspace) ([]slack.Channel, error) {
+	var (
+		channels []slack.Channel
+		cursor   string
+	)
+	for {
+		if err := ws.rateLimiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+		page, next, err := ws.currentClient().GetConversationsContext(ctx, &slack.GetConversationsParameters{
+			Cursor:          cursor,
+			ExcludeArchived: true,
+			Limit:           1000,
+			Types:           []string{"public_channel", "private_channel"},
+		})
+		if err != nil {
+			var rateLimited *slack.RateLimitedError
+			if errors.As(err, &rateLimited) {
+				ws.rateLimiter.OnThrottled(rateLimited.RetryAfter)
+			}
+			return nil, err
+		}
+		channels = append(channels, page...)
+		if next == "" {
+			return channels, nil
+		}
+		cursor = next
+	}
+}
+
+// newClient builds a Slack client for token on the shared, tuned HTTP transport.
+func (a *SlackAdapter) newClient(token string) *slack.Client {
+	return slack.New(token, slack.OptionHTTPClient(httpclient.Default().ClientWithTimeout(a.timeout)))
+}
@@ -0,0 +1,122 @@
+package adapters
+
+import (
+	// go1.21 - Workspace lookup errors and client guarding
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	// v0.12.3 - Official Slack API client
+	"github.com/slack-go/slack"
+
+	// v0.5.0 - Per-workspace rate limits
+	"golang.org/x/time/rate"
+
+	// Internal TTL cache and models for payload errors
+	"src/backend/services/integration/internal/cache"
+	"src/backend/services/integration/internal/config"
+	"src/backend/services/integration/internal/models"
+)
+
+// slackWorkspace is a Slack workspace the adapter sends to, with its own
+// client, channel list and rate limits, since Slack limits each workspace
+// separately.
+type slackWorkspace struct {
+	// name identifies the workspace in payloads and status.
+	name string
+
+	// defaultChannel is used when a message names no channel.
+	defaultChannel string
+
+	// clientMu guards client, which is replaced when credentials are rotated.
+	clientMu sync.RWMutex
+	client   *slack.Client
+
+	// rateLimiter controls the frequency of API calls to the workspace,
+	// adapting to Slack's 429 responses so throughput stays near its limit.
+	rateLimiter *adaptiveLimiter
+
+	// metadata caches the workspace channel list so that lookups do not cost a
+	// conversations.list round-trip each.
+	metadata *cache.TTL
+}
+
+// Default Slack call pacing, used for settings left unset.
+const (
+	defaultSlackRequestsPerSecond    = 5
+	defaultSlackBurst                = 10
+	defaultSlackMinRequestsPerSecond = 0.5
+)
+
+// newSlackWorkspace creates a workspace sending with client, paced by rl.
+func newSlackWorkspace(name, defaultChannel string, client *slack.Client, rl *config.SlackRateLimitConfig, metadataTTL time.Duration) *slackWorkspace {
+	return &slackWorkspace{
+		name:           name,
+		defaultChannel: defaultChannel,
+		client:         client,
+		rateLimiter:    newSlackLimiter(rl),
+		metadata:       newMetadataCache(metadataTTL),
+	}
+}
+
+// newSlackLimiter creates a workspace's limiter from rl, using the defaults
+// for unset fields.
+func newSlackLimiter(rl *config.SlackRateLimitConfig) *adaptiveLimiter {
+	ceiling, burst, floor := float64(defaultSlackRequestsPerSecond), defaultSlackBurst, defaultSlackMinRequestsPerSecond
+	if rl != nil {
+		if rl.RequestsPerSecond > 0 {
+			ceiling = rl.RequestsPerSecond
+		}
+		if rl.Burst > 0 {
+			burst = rl.Burst
+		}
+		if rl.MinRequestsPerSecond > 0 {
+			floor = rl.MinRequestsPerSecond
+		}
+	}
+	if floor > ceiling {
+		floor = ceiling
+	}
+	return newAdaptiveLimiter(rate.Limit(ceiling), burst, rate.Limit(floor))
+}
+
+// currentClient returns the workspace's active Slack client.
+func (w *slackWorkspace) currentClient() *slack.Client {
+	w.clientMu.RLock()
+	defer w.clientMu.RUnlock()
+	return w.client
+}
+
+// setClient replaces the workspace's active Slack client.
+func (w *slackWorkspace) setClient(client *slack.Client) {
+	w.clientMu.Lock()
+	w.client = client
+	w.clientMu.Unlock()
+}
+
+// status reports the workspace's connectivity, limiter and cache.
+func (w *slackWorkspace) status(ctx context.Context) map[string]interface{} {
+	status := map[string]interface{}{
+		"connected":     false,
+		"rateLimiter":   w.rateLimiter.Stats(),
+		"metadataCache": w.metadata.Stats(),
+	}
+	auth, err := w.currentClient().AuthTestContext(ctx)
+	if err != nil {
+		status["authTestError"] = err.Error()
+		return status
+	}
+	status["connected"] = true
+	status["team"] = auth.Team
+	return status
+}
+
+// workspace returns the named workspace, or the default one for an empty name.
+func (a *SlackAdapter) workspace(name string) (*slackWorkspace, error) {
+	ws, ok := a.workspaces[firstNonEmpty(name, config.SlackDefaultWorkspace)]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown slack workspace %q", models.ErrInvalidPayload, name)
+	}
+	return ws, nil
+}
@@ -0,0 +1,118 @@
+package adapters
+
+import (
+	// go1.21 - Joining per-host dial errors
+	"errors"
+	"fmt"
+	// go1.21 - Host addresses and SMTP clients
+	"net"
+	"net/smtp"
+	// go1.21 - Guards the active host
+	"sync"
+	"time"
+
+	// Internal imports for configuration
+	"src/backend/services/integration/internal/config"
+)
+
+// defaultSMTPFailbackInterval is how long new connections stay on a fallback
+// server before the primary is tried again, when the configuration sets none.
+const defaultSMTPFailbackInterval = 5 * time.Minute
+
+// smtpHost is one SMTP server with the configuration and authentication its
+// connections are dialled with.
+type smtpHost struct {
+	cfg     *config.EmailConfig
+	tlsCfg  *config.TLSConfig
+	auth    smtp.Auth
+	address string
+}
+
+// smtpFailover dials connections to the first reachable server of an ordered
+// list. Once a fallback server is in use, new connections start from it until
+// the failback interval passes, after which the primary is tried first again.
+type smtpFailover struct {
+	hosts            []smtpHost
+	failbackInterval time.Duration
+
+	mu         sync.Mutex
+	active     int
+	failbackAt time.Time
+	lastErr    error
+}
+
+// newSMTPFailover creates the dialer for the primary server in cfg and the
+// fallbacks after it. Each server shares cfg's TLS mode and pool settings;
+// the TLS server name override applies to the primary only, since the
+// fallbacks are different hosts.
+func newSMTPFailover(cfg *config.EmailConfig, tlsCfg *config.TLSConfig) *smtpFailover {
+	f := &smtpFailover{failbackInterval: defaultSMTPFailbackInterval}
+	if cfg.Failover != nil && cfg.Failover.FailbackInterval > 0 {
+		f.failbackInterval = cfg.Failover.FailbackInterval
+	}
+	for i, h := range cfg.SMTPHosts() {
+		hostCfg := *cfg
+		hostCfg.Host, hostCfg.Port = h.Host, h.Port
+		hostCfg.Username, hostCfg.Password = h.Username, h.Password
+		hostTLS := tlsCfg
+		if i > 0 && tlsCfg != nil && tlsCfg.ServerName != "" {
+			fallbackTLS := *tlsCfg
+			fallbackTLS.ServerName = ""
+			hostTLS = &fallbackTLS
+		}
+		f.hosts = append(f.hosts, smtpHost{
+			cfg:     &hostCfg,
+			tlsCfg:  hostTLS,
+			auth:    newSMTPAuth(&hostCfg),
+			address: net.JoinHostPort(h.Host, intToString(h.Port)),
+		})
+	}
+	return f
+}
+
+// dial connects to the first server that accepts a connection, starting from
+// the active server, or from the primary once the failback interval has
+// passed. A server that fails to connect, negotiate TLS or authenticate is
+// skipped; when every server fails the errors are joined.
+func (f *smtpFailover) dial() (*smtp.Client, error) {
+	f.mu.Lock()
+	start := f.active
+	if start != 0 && !time.Now().Before(f.failbackAt) {
+		start = 0
+	}
+	f.mu.Unlock()
+
+	var errs []error
+	for n := 0; n < len(f.hosts); n++ {
+		i := (start + n) % len(f.hosts)
+		host := f.hosts[i]
+		client, err := newSMTPClientConnection(host.cfg, host.tlsCfg, host.auth)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", host.address, err))
+			continue
+		}
+		f.mu.Lock()
+		if i > 0 && i != start {
+			// Newly failed over: stay on this server for the failback interval.
+			f.failbackAt = time.Now().Add(f.failbackInterval)
+		}
+		f.active = i
+		f.lastErr = errors.Join(errs...)
+		f.mu.Unlock()
+		return client, nil
+	}
+
+	err := errors.Join(errs...)
+	f.mu.Lock()
+	f.lastErr = err
+	f.mu.Unlock()
+	return nil, err
+}
+
+// activeHost returns the address of the server new connections start from and
+// the errors from servers skipped by the latest dial, if any.
+func (f *smtpFailover) activeHost() (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.hosts[f.active].address, f.lastErr
+}
@@ -0,0 +1,444 @@
+package adapters
+
+import (
+	// go1.21 - Context for bounded connection acquisition
+	"context"
+	// go1.21 - Sentinel errors for pool exhaustion and shutdown
+	"errors"
+	// go1.21 - SMTP client and raw protocol access for PIPELINING
+	"net/smtp"
+	// go1.21 - Synchronization primitives for the idle list and counters
+	"sync"
+	"time"
+
+	// Internal package holding the SMTP pool limits
+	"src/backend/services/integration/internal/config"
+)
+
+var (
+	// ErrSMTPPoolExhausted is returned when no connection becomes free within the acquire timeout.
+	ErrSMTPPoolExhausted = errors.New("smtp connection pool exhausted")
+
+	// ErrSMTPPoolClosed is returned when acquiring from a pool that has been closed.
+	ErrSMTPPoolClosed = errors.New("smtp connection pool closed")
+)
+
+// Fallback pool limits used when the email configuration carries no pool section.
+const (
+	defaultSMTPMaxConnections    = 4
+	defaultSMTPKeepaliveInterval = 30 * time.Second
+	defaultSMTPAcquireTimeout    = 10 * time.Second
+)
+
+// SMTPPoolStats is a point-in-time snapshot of the SMTP connection pool.
+type SMTPPoolStats struct {
+	// Open is the number of connections currently open, idle, in use, being
+	// probed or being dialled.
+	Open int `json:"open"`
+
+	// Idle is the number of open connections waiting to be reused.
+	Idle int `json:"idle"`
+
+	// MaxConnections is the configured cap on open connections.
+	MaxConnections int `json:"maxConnections"`
+
+	// Dialed counts connections established since the pool was created.
+	Dialed uint64 `json:"dialed"`
+
+	// Retired counts connections closed after errors, idle expiry, or the per-connection send cap.
+	Retired uint64 `json:"retired"`
+
+	// TotalSends counts messages accepted by the server across all connections.
+	TotalSends uint64 `json:"totalSends"`
+
+	// ConnectionSends maps each open connection's ID to the messages it has sent.
+	ConnectionSends map[uint64]uint64 `json:"connectionSends"`
+}
+
+// smtpConn is a pooled, authenticated SMTP session.
+type smtpConn struct {
+	id         uint64
+	client     *smtp.Client
+	pipelining bool
+	sends      uint64
+	lastUsed   time.Time
+}
+
+// smtpPool is a bounded pool of authenticated SMTP connections. Unlike a
+// sync.Pool, it caps the number of open connections, reuses sessions for many
+// messages (pipelining commands when the server advertises PIPELINING), probes
+// idle sessions with NOOP so dead connections are dropped before a send needs
+// them, and retires sessions after a configurable number of messages.
+type smtpPool struct {
+	dial              func() (*smtp.Client, error)
+	maxConns          int
+	maxSends          uint64
+	idleTimeout       time.Duration
+	keepaliveInterval time.Duration
+	acquireTimeout    time.Duration
+
+	mu    sync.Mutex
+	idle  []*smtpConn
+	inUse map[uint64]*smtpConn
+
+	// open counts every connection that holds one of the maxConns places:
+	// idle, in use, being probed or being dialled. It drops only when a
+	// connection is retired or a dial fails.
+	open int
+
+	// freed is closed and replaced whenever a connection returns to the idle
+	// list or gives up its place, waking acquirers waiting for either.
+	freed chan struct{}
+
+	closed  bool
+	nextID  uint64
+	dialed  uint64
+	retired uint64
+	sends   uint64
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// newSMTPPool creates a pool whose connections are produced by dial and starts
+// its keepalive loop. Missing or zero limits fall back to package defaults.
+func newSMTPPool(poolCfg *config.SMTPPoolConfig, dial func() (*smtp.Client, error)) *smtpPool {
+	p := &smtpPool{
+		dial:              dial,
+		maxConns:          defaultSMTPMaxConnections,
+		keepaliveInterval: defaultSMTPKeepaliveInterval,
+		acquireTimeout:    defaultSMTPAcquireTimeout,
+		inUse:             make(map[uint64]*smtpConn),
+		freed:             make(chan struct{}),
+		stop:              make(chan struct{}),
+	}
+	if poolCfg != nil {
+		if poolCfg.MaxConnections > 0 {
+			p.maxConns = poolCfg.MaxConnections
+		}
+		if poolCfg.MaxSendsPerConnection > 0 {
+			p.maxSends = uint64(poolCfg.MaxSendsPerConnection)
+		}
+		if poolCfg.KeepaliveInterval > 0 {
+			p.keepaliveInterval = poolCfg.KeepaliveInterval
+		}
+		if poolCfg.AcquireTimeout > 0 {
+			p.acquireTimeout = poolCfg.AcquireTimeout
+		}
+		p.idleTimeout = poolCfg.IdleTimeout
+	}
+
+	p.wg.Add(1)
+	go p.keepaliveLoop()
+	return p
+}
+
+// acquire returns a healthy connection, reusing the most recently used idle
+// session when possible and dialling a new one while fewer than maxConns are
+// open. Otherwise it waits for a connection to be released or retired until
+// ctx is done or the acquire timeout elapses.
+func (p *smtpPool) acquire(ctx context.Context) (*smtpConn, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.acquireTimeout)
+	defer cancel()
+
+	for {
+		p.mu.Lock()
+		if p.closed {
+			p.mu.Unlock()
+			return nil, ErrSMTPPoolClosed
+		}
+		if n := len(p.idle); n > 0 {
+			conn := p.idle[n-1]
+			p.idle = p.idle[:n-1]
+			p.mu.Unlock()
+
+			if p.idleTimeout > 0 && time.Since(conn.lastUsed) > p.idleTimeout {
+				p.retire(conn)
+				continue
+			}
+			p.markInUse(conn)
+			return conn, nil
+		}
+		if p.open < p.maxConns {
+			p.open++
+			p.mu.Unlock()
+			return p.dialConn()
+		}
+		freed := p.freed
+		p.mu.Unlock()
+
+		select {
+		case <-freed:
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return nil, ErrSMTPPoolExhausted
+			}
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// dialConn dials a connection into a place already counted in p.open, giving
+// the place up again if the dial fails.
+func (p *smtpPool) dialConn() (*smtpConn, error) {
+	client, err := p.dial()
+	if err != nil {
+		p.mu.Lock()
+		p.open--
+		p.notifyLocked()
+		p.mu.Unlock()
+		return nil, err
+	}
+	conn := &smtpConn{client: client, lastUsed: time.Now()}
+	conn.pipelining, _ = client.Extension("PIPELINING")
+
+	p.mu.Lock()
+	p.nextID++
+	conn.id = p.nextID
+	p.dialed++
+	p.mu.Unlock()
+
+	p.markInUse(conn)
+	return conn, nil
+}
+
+// release returns a connection to the pool. Connections that failed, reached
+// the per-connection send cap, or belong to a closed pool are closed instead.
+// Either way a waiting acquirer is woken, to take the connection or its place.
+func (p *smtpPool) release(conn *smtpConn, healthy bool) {
+	p.mu.Lock()
+	delete(p.inUse, conn.id)
+	reuse := healthy && !p.closed && (p.maxSends == 0 || conn.sends < p.maxSends)
+	if reuse {
+		conn.lastUsed = time.Now()
+		p.idle = append(p.idle, conn)
+		p.notifyLocked()
+	}
+	p.mu.Unlock()
+
+	if !reuse {
+		p.retire(conn)
+	}
+}
+
+// notifyLocked wakes every acquirer waiting for a connection. p.mu must be held.
+func (p *smtpPool) notifyLocked() {
+	close(p.freed)
+	p.freed = make(chan struct{})
+}
+
+// markInUse records a checked-out connection so its send counter appears in stats.
+func (p *smtpPool) markInUse(conn *smtpConn) {
+	p.mu.Lock()
+	p.inUse[conn.id] = conn
+	p.mu.Unlock()
+}
+
+// retire closes a connection that will not be reused and gives up its place.
+func (p *smtpPool) retire(conn *smtpConn) {
+	p.mu.Lock()
+	p.retired++
+	p.open--
+	p.notifyLocked()
+	p.mu.Unlock()
+	if err := conn.client.Quit(); err != nil {
+		_ = conn.client.Close()
+	}
+}
+
+// send delivers one message over conn and updates the send counters.
+func (p *smtpPool) send(conn *smtpConn, from string, to []string, msg []byte) error {
+	var err error
+	if conn.pipelining {
+		err = sendPipelined(conn.client, from, to, msg)
+	} else {
+		err = sendSequential(conn.client, from, to, msg)
+	}
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	conn.sends++
+	p.sends++
+	p.mu.Unlock()
+	return nil
+}
+
+// keepaliveLoop periodically probes idle connections until the pool is closed.
+func (p *smtpPool) keepaliveLoop() {
+	defer p.wg.Done()
+	ticker := time.NewTicker(p.keepaliveInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+			p.probeIdle()
+		}
+	}
+}
+
+// probeIdle sends NOOP on each idle connection, dropping those that fail or have
+// exceeded the idle timeout. Connections being probed keep their place in
+// p.open, so acquirers wait for them rather than dialling past the cap.
+func (p *smtpPool) probeIdle() {
+	p.mu.Lock()
+	candidates := p.idle
+	p.idle = nil
+	p.mu.Unlock()
+
+	var healthy []*smtpConn
+	for _, conn := range candidates {
+		expired := p.idleTimeout > 0 && time.Since(conn.lastUsed) > p.idleTimeout
+		if expired || conn.client.Noop() != nil {
+			p.retire(conn)
+		} else {
+			healthy = append(healthy, conn)
+		}
+	}
+	p.returnIdle(healthy)
+}
+
+// returnIdle puts probed connections back on the idle list, or closes them if
+// the pool was closed meanwhile.
+func (p *smtpPool) returnIdle(conns []*smtpConn) {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		for _, conn := range conns {
+			p.retire(conn)
+		}
+		return
+	}
+	// Probed connections go underneath any that were released during the probe,
+	// so the most recently used sessions are still handed out first.
+	p.idle = append(conns, p.idle...)
+	if len(conns) > 0 {
+		p.notifyLocked()
+	}
+	p.mu.Unlock()
+}
+
+// Stats returns a snapshot of pool occupancy and send counters.
+func (p *smtpPool) Stats() SMTPPoolStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	stats := SMTPPoolStats{
+		Open:            p.open,
+		Idle:            len(p.idle),
+		MaxConnections:  p.maxConns,
+		Dialed:          p.dialed,
+		Retired:         p.retired,
+		TotalSends:      p.sends,
+		ConnectionSends: make(map[uint64]uint64, len(p.idle)+len(p.inUse)),
+	}
+	for _, conn := range p.idle {
+		stats.ConnectionSends[conn.id] = conn.sends
+	}
+	for id, conn := range p.inUse {
+		stats.ConnectionSends[id] = conn.sends
+	}
+	return stats
+}
+
+// Close stops the keepalive loop, closes idle connections and fails waiting
+// acquirers. Connections in use are closed when they are released.
+func (p *smtpPool) Close() {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return
+	}
+	p.closed = true
+	idle := p.idle
+	p.idle = nil
+	p.notifyLocked()
+	p.mu.Unlock()
+
+	close(p.stop)
+	p.wg.Wait()
+	for _, conn := range idle {
+		p.retire(conn)
+	}
+}
+
+// sendSequential runs one SMTP transaction, waiting for each reply in turn.
+func sendSequential(client *smtp.Client, from string, to []string, msg []byte) error {
+	if err := client.Mail(from); err != nil {
+		return err
+	}
+	for _, recipient := range to {
+		if err := client.Rcpt(recipient); err != nil {
+			return err
+		}
+	}
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := writer.Write(msg); err != nil {
+		_ = writer.Close()
+		return err
+	}
+	return writer.Close()
+}
+
+// sendPipelined runs one SMTP transaction using RFC 2920 command pipelining:
+// MAIL, every RCPT, and DATA are written in a single batch and their replies
+// read afterwards, saving a round trip per recipient.
+//
+// Any error leaves the session in an undefined state, so callers must discard
+// the connection; in particular, if DATA is accepted after a rejected RCPT the
+// message body is deliberately not sent.
+func sendPipelined(client *smtp.Client, from string, to []string, msg []byte) error {
+	text := client.Text
+
+	ids := make([]uint, 0, len(to)+2)
+	id, err := text.Cmd("MAIL FROM:<%s>", from)
+	if err != nil {
+		return err
+	}
+	ids = append(ids, id)
+	for _, recipient := range to {
+		if id, err = text.Cmd("RCPT TO:<%s>", recipient); err != nil {
+			return err
+		}
+		ids = append(ids, id)
+	}
+	if id, err = text.Cmd("DATA"); err != nil {
+		return err
+	}
+	ids = append(ids, id)
+
+	// Replies arrive in command order; all of them must be consumed.
+	var firstErr error
+	for i, id := range ids {
+		expect := 25
+		if i == len(ids)-1 {
+			expect = 354
+		}
+		text.StartResponse(id)
+		_, _, err := text.ReadResponse(expect)
+		text.EndResponse(id)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	if firstErr != nil {
+		return firstErr
+	}
+
+	writer := text.DotWriter()
+	if _, err := writer.Write(msg); err != nil {
+		_ = writer.Close()
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	_, _, err = text.ReadResponse(250)
+	return err
+}
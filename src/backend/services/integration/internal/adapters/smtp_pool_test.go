@@ -0,0 +1,194 @@
+package adapters
+
+import (
+	"context"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"src/backend/services/integration/internal/config"
+)
+
+// fakeSMTPServer accepts SMTP sessions on a loopback port, advertising
+// PIPELINING and accepting every message. It counts the sessions open at once.
+type fakeSMTPServer struct {
+	listener net.Listener
+	open     atomic.Int64
+	peak     atomic.Int64
+	dials    atomic.Int64
+}
+
+func newFakeSMTPServer(tb testing.TB) *fakeSMTPServer {
+	tb.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		tb.Fatalf("listen: %v", err)
+	}
+	srv := &fakeSMTPServer{listener: listener}
+	go srv.serve()
+	tb.Cleanup(func() { listener.Close() })
+	return srv
+}
+
+func (s *fakeSMTPServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.session(conn)
+	}
+}
+
+func (s *fakeSMTPServer) session(conn net.Conn) {
+	defer conn.Close()
+	open := s.open.Add(1)
+	defer s.open.Add(-1)
+	for peak := s.peak.Load(); open > peak && !s.peak.CompareAndSwap(peak, open); peak = s.peak.Load() {
+	}
+
+	text := textproto.NewConn(conn)
+	_ = text.PrintfLine("220 localhost ESMTP")
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+		switch verb, _, _ := strings.Cut(strings.ToUpper(line), " "); verb {
+		case "EHLO":
+			_ = text.PrintfLine("250-localhost")
+			_ = text.PrintfLine("250 PIPELINING")
+		case "DATA":
+			_ = text.PrintfLine("354 Go ahead")
+			if _, err := text.ReadDotBytes(); err != nil {
+				return
+			}
+			_ = text.PrintfLine("250 Queued")
+		case "QUIT":
+			_ = text.PrintfLine("221 Bye")
+			return
+		default:
+			_ = text.PrintfLine("250 OK")
+		}
+	}
+}
+
+func (s *fakeSMTPServer) dial() (*smtp.Client, error) {
+	s.dials.Add(1)
+	conn, err := net.Dial("tcp", s.listener.Addr().String())
+	if err != nil {
+		return nil, err
+	}
+	return smtp.NewClient(conn, "localhost")
+}
+
+var (
+	benchFrom = "alerts@example.com"
+	benchTo   = []string{"oncall@example.com", "team@example.com"}
+	benchMsg  = []byte("Subject: Incident\r\n\r\nThe integration service paged.\r\n")
+)
+
+func TestSMTPPoolCapsOpenConnections(t *testing.T) {
+	srv := newFakeSMTPServer(t)
+	pool := newSMTPPool(&config.SMTPPoolConfig{MaxConnections: 3}, srv.dial)
+	defer pool.Close()
+
+	var wg sync.WaitGroup
+	for w := 0; w < 16; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				conn, err := pool.acquire(context.Background())
+				if err != nil {
+					t.Errorf("acquire: %v", err)
+					return
+				}
+				err = pool.send(conn, benchFrom, benchTo, benchMsg)
+				pool.release(conn, err == nil)
+			}
+		}()
+	}
+	// Probing concurrently must not let acquirers dial past the cap.
+	stop := make(chan struct{})
+	probed := make(chan struct{})
+	go func() {
+		defer close(probed)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				pool.probeIdle()
+			}
+		}
+	}()
+	wg.Wait()
+	close(stop)
+	<-probed
+
+	if peak := srv.peak.Load(); peak > 3 {
+		t.Errorf("peak open sessions = %d, want at most 3", peak)
+	}
+	if open := pool.Stats().Open; open > 3 {
+		t.Errorf("Stats().Open = %d, want at most 3", open)
+	}
+}
+
+// BenchmarkSMTPPoolSend measures sends through the bounded pool, which reuses
+// a few long-lived, pipelined sessions.
+func BenchmarkSMTPPoolSend(b *testing.B) {
+	srv := newFakeSMTPServer(b)
+	pool := newSMTPPool(&config.SMTPPoolConfig{MaxConnections: 4}, srv.dial)
+	defer pool.Close()
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			conn, err := pool.acquire(context.Background())
+			if err != nil {
+				b.Error(err)
+				return
+			}
+			err = pool.send(conn, benchFrom, benchTo, benchMsg)
+			pool.release(conn, err == nil)
+		}
+	})
+	b.ReportMetric(float64(srv.dials.Load())/float64(b.N), "dials/op")
+	b.ReportMetric(float64(srv.peak.Load()), "peak-sessions")
+}
+
+// BenchmarkSyncPoolSend measures the sync.Pool the bounded pool replaced,
+// whose sessions are uncapped and dropped without QUIT when the GC clears it.
+func BenchmarkSyncPoolSend(b *testing.B) {
+	srv := newFakeSMTPServer(b)
+	pool := &sync.Pool{New: func() interface{} {
+		client, err := srv.dial()
+		if err != nil {
+			return nil
+		}
+		return client
+	}}
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			client, ok := pool.Get().(*smtp.Client)
+			if !ok {
+				b.Error("dial failed")
+				return
+			}
+			if err := sendSequential(client, benchFrom, benchTo, benchMsg); err != nil {
+				_ = client.Close()
+				continue
+			}
+			pool.Put(client)
+		}
+	})
+	b.ReportMetric(float64(srv.dials.Load())/float64(b.N), "dials/op")
+	b.ReportMetric(float64(srv.peak.Load()), "peak-sessions")
+}
@@ -0,0 +1,225 @@
+package adapters
+
+import (
+	// go1.21 - Signature verification of SNS messages
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+
+	// Internal transport for certificate downloads and subscription confirmation
+	"src/backend/services/integration/internal/httpclient"
+	"src/backend/services/integration/internal/models"
+)
+
+// SNS message types.
+const (
+	snsTypeNotification             = "Notification"
+	snsTypeSubscriptionConfirmation = "SubscriptionConfirmation"
+	snsTypeUnsubscribeConfirmation  = "UnsubscribeConfirmation"
+)
+
+// snsHostPattern matches the hosts SNS signing certificates and subscription
+// confirmation URLs are served from.
+var snsHostPattern = regexp.MustCompile(`^sns\.[a-z0-9-]+\.amazonaws\.com(\.cn)?$`)
+
+// snsCertLimit bounds the size of a downloaded signing certificate.
+const snsCertLimit = 64 << 10
+
+// snsMessage is an HTTP(S) delivery from Amazon SNS.
+type snsMessage struct {
+	Type             string `json:"Type"`
+	MessageID        string `json:"MessageId"`
+	Token            string `json:"Token"`
+	TopicArn         string `json:"TopicArn"`
+	Subject          string `json:"Subject"`
+	Message          string `json:"Message"`
+	Timestamp        string `json:"Timestamp"`
+	SignatureVersion string `json:"SignatureVersion"`
+	Signature        string `json:"Signature"`
+	SigningCertURL   string `json:"SigningCertURL"`
+	SubscribeURL     string `json:"SubscribeURL"`
+}
+
+// snsVerifier authenticates SNS messages from a set of topics, caching the
+// signing certificates it downloads.
+type snsVerifier struct {
+	topics map[string]bool
+	client *http.Client
+
+	mu    sync.Mutex
+	certs map[string]*x509.Certificate
+}
+
+// newSNSVerifier creates a verifier accepting messages from topicARNs.
+func newSNSVerifier(topicARNs []string) *snsVerifier {
+	topics := make(map[string]bool, len(topicARNs))
+	for _, arn := range topicARNs {
+		topics[arn] = true
+	}
+	return &snsVerifier{
+		topics: topics,
+		client: httpclient.Default().Client(),
+		certs:  make(map[string]*x509.Certificate),
+	}
+}
+
+// parse decodes body and verifies that it is a message from an accepted topic
+// carrying a valid signature. Failures wrap models.ErrInvalidPayload.
+func (v *snsVerifier) parse(ctx context.Context, body []byte) (*snsMessage, error) {
+	var msg snsMessage
+	if err := json.Unmarshal(body, &msg); err != nil {
+		return nil, fmt.Errorf("%w: sns message: %v", models.ErrInvalidPayload, err)
+	}
+	if !v.topics[msg.TopicArn] {
+		return nil, fmt.Errorf("%w: sns topic %q is not accepted", models.ErrInvalidPayload, msg.TopicArn)
+	}
+
+	var newHash func() hash.Hash
+	var algorithm crypto.Hash
+	switch msg.SignatureVersion {
+	case "1":
+		newHash, algorithm = sha1.New, crypto.SHA1
+	case "2":
+		newHash, algorithm = sha256.New, crypto.SHA256
+	default:
+		return nil, fmt.Errorf("%w: unsupported sns signature version %q", models.ErrInvalidPayload, msg.SignatureVersion)
+	}
+	signature, err := base64.StdEncoding.DecodeString(msg.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("%w: sns signature encoding", models.ErrInvalidPayload)
+	}
+	base, err := msg.stringToSign()
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := v.certificate(ctx, msg.SigningCertURL)
+	if err != nil {
+		return nil, err
+	}
+	key, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("%w: sns signing certificate has no RSA key", models.ErrInvalidPayload)
+	}
+	h := newHash()
+	h.Write([]byte(base))
+	if err := rsa.VerifyPKCS1v15(key, algorithm, h.Sum(nil), signature); err != nil {
+		return nil, fmt.Errorf("%w: sns signature mismatch", models.ErrInvalidPayload)
+	}
+	return &msg, nil
+}
+
+// stringToSign assembles the signed fields of the message in SNS's order.
+func (m *snsMessage) stringToSign() (string, error) {
+	var fields [][2]string
+	switch m.Type {
+	case snsTypeNotification:
+		fields = [][2]string{{"Message", m.Message}, {"MessageId", m.MessageID}}
+		if m.Subject != "" {
+			fields = append(fields, [2]string{"Subject", m.Subject})
+		}
+		fields = append(fields, [2]string{"Timestamp", m.Timestamp}, [2]string{"TopicArn", m.TopicArn},
+			[2]string{"Type", m.Type})
+	case snsTypeSubscriptionConfirmation, snsTypeUnsubscribeConfirmation:
+		fields = [][2]string{{"Message", m.Message}, {"MessageId", m.MessageID}, {"SubscribeURL", m.SubscribeURL},
+			{"Timestamp", m.Timestamp}, {"Token", m.Token}, {"TopicArn", m.TopicArn}, {"Type", m.Type}}
+	default:
+		return "", fmt.Errorf("%w: unknown sns message type %q", models.ErrInvalidPayload, m.Type)
+	}
+
+	var b strings.Builder
+	for _, f := range fields {
+		b.WriteString(f[0])
+		b.WriteByte('\n')
+		b.WriteString(f[1])
+		b.WriteByte('\n')
+	}
+	return b.String(), nil
+}
+
+// certificate returns the signing certificate at rawURL, which must be an
+// HTTPS URL on an SNS host.
+func (v *snsVerifier) certificate(ctx context.Context, rawURL string) (*x509.Certificate, error) {
+	if !isSNSURL(rawURL) {
+		return nil, fmt.Errorf("%w: sns signing certificate URL %q is not an SNS URL", models.ErrInvalidPayload, rawURL)
+	}
+
+	v.mu.Lock()
+	cert, ok := v.certs[rawURL]
+	v.mu.Unlock()
+	if ok {
+		return cert, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("build certificate request: %w", err)
+	}
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch sns signing certificate: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch sns signing certificate: unexpected HTTP status %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, snsCertLimit))
+	if err != nil {
+		return nil, fmt.Errorf("read sns signing certificate: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("sns signing certificate is not PEM encoded")
+	}
+	cert, err = x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse sns signing certificate: %w", err)
+	}
+
+	v.mu.Lock()
+	v.certs[rawURL] = cert
+	v.mu.Unlock()
+	return cert, nil
+}
+
+// confirm confirms a subscription by visiting its SubscribeURL.
+func (v *snsVerifier) confirm(ctx context.Context, msg *snsMessage) error {
+	if !isSNSURL(msg.SubscribeURL) {
+		return fmt.Errorf("%w: sns subscribe URL %q is not an SNS URL", models.ErrInvalidPayload, msg.SubscribeURL)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, msg.SubscribeURL, nil)
+	if err != nil {
+		return fmt.Errorf("build subscription confirmation: %w", err)
+	}
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("confirm sns subscription: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("confirm sns subscription: unexpected HTTP status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// isSNSURL reports whether rawURL is an HTTPS URL on an SNS host.
+func isSNSURL(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	return err == nil && u.Scheme == "https" && snsHostPattern.MatchString(u.Hostname())
+}
@@ -0,0 +1,387 @@
+package adapters
+
+import (
+	// go1.21 - Newline-delimited event bodies
+	"bytes"
+	"encoding/json"
+	// go1.21 - Context for cancellations and timeouts
+	"context"
+	// go1.21 - Sentinel errors and error wrapping
+	"errors"
+	"fmt"
+	// go1.21 - Request construction
+	"net/http"
+	// go1.21 - Guards the client during rotation
+	"sync"
+	"time"
+
+	// v0.1.0 - Token bucket rate limiting of collector calls
+	"golang.org/x/time/rate"
+
+	// Internal imports for configuration, transport and adapter contracts
+	"src/backend/services/integration/internal/config"
+	"src/backend/services/integration/internal/httpclient"
+	"src/backend/services/integration/internal/models"
+)
+
+// splunkEventPath is the collector's JSON event endpoint.
+const splunkEventPath = "/services/collector/event"
+
+// splunkCodeNoData is the HEC status code for a request without events, which
+// an authenticated ping receives.
+const splunkCodeNoData = 5
+
+// ErrInvalidSplunkConfig indicates that the Splunk configuration is missing the
+// collector URL or token.
+var ErrInvalidSplunkConfig = errors.New("invalid splunk configuration or missing required fields")
+
+// SplunkEvent is an event to post. Send also accepts any other value, which is
+// posted as the event with the configured metadata; a map with an "event" key
+// is read as a SplunkEvent.
+type SplunkEvent struct {
+	// Event is the event data: strings are indexed as is, other values as JSON.
+	Event interface{} `json:"event"`
+
+	// Time is when the event happened; it defaults to when it is sent.
+	Time time.Time `json:"time,omitempty"`
+
+	// Index, SourceType, Source and Host override the configured metadata.
+	Index      string `json:"index,omitempty"`
+	SourceType string `json:"sourceType,omitempty"`
+	Source     string `json:"source,omitempty"`
+	Host       string `json:"host,omitempty"`
+
+	// Fields are indexed fields, searchable without extraction.
+	Fields map[string]interface{} `json:"fields,omitempty"`
+}
+
+// splunkHECEvent is one event in a collector request.
+type splunkHECEvent struct {
+	Time       float64                `json:"time"`
+	Index      string                 `json:"index,omitempty"`
+	SourceType string                 `json:"sourcetype,omitempty"`
+	Source     string                 `json:"source,omitempty"`
+	Host       string                 `json:"host,omitempty"`
+	Event      interface{}            `json:"event"`
+	Fields     map[string]interface{} `json:"fields,omitempty"`
+}
+
+// SplunkHECAdapter implements the Integration interface for the Splunk HTTP
+// Event Collector, posting integration activity to SIEM pipelines. Batch sends
+// post many events per request.
+type SplunkHECAdapter struct {
+	// mu guards the fields below, which change on initialization, rotation and
+	// sends.
+	mu          sync.RWMutex
+	config      *config.SplunkConfig
+	client      *restClient
+	events      int
+	requests    int
+	connected   bool
+	lastSync    time.Time
+	initialized bool
+
+	// guard applies the circuit breaker, rate limiter and retries to each call.
+	guard *restGuard
+}
+
+// Compile-time check to ensure SplunkHECAdapter implements the Integration interface.
+var _ models.Integration = (*SplunkHECAdapter)(nil)
+
+// Compile-time check to ensure SplunkHECAdapter supports credential rotation.
+var _ models.CredentialRotator = (*SplunkHECAdapter)(nil)
+
+// Compile-time check to ensure SplunkHECAdapter supports batch sends.
+var _ models.BatchSender = (*SplunkHECAdapter)(nil)
+
+// Compile-time check to ensure SplunkHECAdapter reports its circuit state.
+var _ models.CircuitReporter = (*SplunkHECAdapter)(nil)
+
+// Compile-time check to ensure SplunkHECAdapter's circuit can be reset by operators.
+var _ models.CircuitResetter = (*SplunkHECAdapter)(nil)
+
+// Compile-time check to ensure SplunkHECAdapter reports its circuit's state and counters.
+var _ models.CircuitInspector = (*SplunkHECAdapter)(nil)
+
+// Compile-time check to ensure SplunkHECAdapter accepts a retry budget.
+var _ models.RetryBudgetUser = (*SplunkHECAdapter)(nil)
+
+// NewSplunkHECAdapter creates an uninitialized Splunk HEC adapter. Collectors
+// are usually self-hosted and sized for log volume, so the limiter allows fifty
+// calls per second with a burst of one hundred, backing off to one per second
+// when the collector reports it is busy.
+func NewSplunkHECAdapter() *SplunkHECAdapter {
+	return &SplunkHECAdapter{
+		guard: newRESTGuard(newAdaptiveLimiter(rate.Limit(50), 100, rate.Every(time.Second))),
+	}
+}
+
+// Initialize implements the Integration interface, bridging to
+// InitializeWithContext with a background context.
+func (sa *SplunkHECAdapter) Initialize(cfg interface{}) error {
+	return sa.InitializeWithContext(context.Background(), cfg)
+}
+
+// InitializeWithContext configures the adapter from a *config.SplunkConfig.
+// Steps:
+//  1. Validate the collector URL and token.
+//  2. Build the client and verify the token with an empty event request.
+func (sa *SplunkHECAdapter) InitializeWithContext(ctx context.Context, cfg interface{}) error {
+	// 1. Configuration.
+	sc, ok := cfg.(*config.SplunkConfig)
+	if !ok || sc == nil || sc.URL == "" || sc.Token == "" {
+		return ErrInvalidSplunkConfig
+	}
+
+	// 2. Client and connectivity.
+	client := newSplunkClient(sc, sc.Token)
+	if err := splunkPing(ctx, client); err != nil {
+		sa.mu.Lock()
+		sa.connected = false
+		sa.mu.Unlock()
+		return fmt.Errorf("%w: splunk hec: %v", models.ErrConnectionFailed, err)
+	}
+
+	sa.mu.Lock()
+	defer sa.mu.Unlock()
+	sa.config = sc
+	sa.client = client
+	sa.connected = true
+	sa.initialized = true
+	sa.lastSync = time.Now()
+	return nil
+}
+
+// RotateCredentials replaces the HEC token without a restart. Secret is the
+// new token; it is verified before it replaces the active client, and on
+// failure the adapter keeps its previous client.
+func (sa *SplunkHECAdapter) RotateCredentials(ctx context.Context, creds models.Credentials) error {
+	if creds.Secret == "" {
+		return models.ErrInvalidPayload
+	}
+
+	sa.mu.RLock()
+	sc := sa.config
+	sa.mu.RUnlock()
+	if sc == nil {
+		return models.ErrInitializationFailed
+	}
+
+	client := newSplunkClient(sc, creds.Secret)
+	if err := splunkPing(ctx, client); err != nil {
+		return fmt.Errorf("rotated splunk token rejected: %w", err)
+	}
+
+	sa.mu.Lock()
+	defer sa.mu.Unlock()
+	sa.client = client
+	sa.connected = true
+	return nil
+}
+
+// Send implements the Integration interface, bridging to SendWithContext with a
+// background context.
+func (sa *SplunkHECAdapter) Send(payload interface{}) error {
+	return sa.SendWithContext(context.Background(), payload)
+}
+
+// SendWithContext posts one event. The payload is a SplunkEvent (or pointer),
+// a map with an "event" key, or any other value to post as the event.
+func (sa *SplunkHECAdapter) SendWithContext(ctx context.Context, payload interface{}) error {
+	results, err := sa.SendBatch(ctx, []interface{}{payload})
+	if err != nil {
+		return err
+	}
+	return results[0].Err
+}
+
+// SendBatch implements models.BatchSender, posting the events newline-delimited
+// in as few requests as the configured event and byte limits allow. Every
+// payload is encoded before any request is made, and one that cannot be
+// encoded fails on its own; a failed request fails its events and stops the
+// batch, failing later events unsent.
+func (sa *SplunkHECAdapter) SendBatch(ctx context.Context, payloads []interface{}) ([]models.BatchResult, error) {
+	sa.mu.RLock()
+	client, sc, initialized := sa.client, sa.config, sa.initialized
+	sa.mu.RUnlock()
+	if !initialized {
+		return nil, models.ErrInitializationFailed
+	}
+
+	results := make([]models.BatchResult, len(payloads))
+	encoded := make([][]byte, len(payloads))
+	for i, payload := range payloads {
+		data, err := encodeSplunkEvent(sc, payload)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		encoded[i] = data
+	}
+
+	var (
+		body    bytes.Buffer
+		pending []int
+		postErr error
+	)
+	flush := func() {
+		if len(pending) == 0 {
+			return
+		}
+		if postErr == nil {
+			data := append([]byte(nil), body.Bytes()...)
+			err := sa.guard.call(ctx, func(ctx context.Context) error {
+				return client.do(ctx, restRequest{method: http.MethodPost, path: splunkEventPath, body: data}, nil)
+			})
+			if err != nil {
+				postErr = fmt.Errorf("splunk post %d events: %w", len(pending), err)
+			} else {
+				sa.mu.Lock()
+				sa.events += len(pending)
+				sa.requests++
+				sa.lastSync = time.Now()
+				sa.connected = true
+				sa.mu.Unlock()
+			}
+		}
+		for _, i := range pending {
+			results[i].Err = postErr
+		}
+		body.Reset()
+		pending = pending[:0]
+	}
+	for i, data := range encoded {
+		if data == nil {
+			continue
+		}
+		if len(pending) > 0 && (len(pending) >= sc.MaxBatchEvents || body.Len()+len(data)+1 > sc.MaxBatchBytes) {
+			flush()
+		}
+		body.Write(data)
+		body.WriteByte('\n')
+		pending = append(pending, i)
+	}
+	flush()
+	return results, nil
+}
+
+// encodeSplunkEvent converts a payload into a collector event line, applying
+// the configured metadata where the payload sets none.
+func encodeSplunkEvent(sc *config.SplunkConfig, payload interface{}) ([]byte, error) {
+	var event SplunkEvent
+	switch p := payload.(type) {
+	case SplunkEvent:
+		event = p
+	case *SplunkEvent:
+		if p == nil {
+			return nil, models.ErrInvalidPayload
+		}
+		event = *p
+	case map[string]interface{}:
+		if _, ok := p["event"]; ok {
+			if err := decodePayload(p, &event); err != nil {
+				return nil, err
+			}
+		} else {
+			event.Event = p
+		}
+	default:
+		event.Event = payload
+	}
+	if event.Event == nil {
+		return nil, fmt.Errorf("%w: splunk event has no data", models.ErrInvalidPayload)
+	}
+
+	at := event.Time
+	if at.IsZero() {
+		at = time.Now()
+	}
+	data, err := json.Marshal(splunkHECEvent{
+		Time:       float64(at.UnixMilli()) / 1000,
+		Index:      firstNonEmpty(event.Index, sc.Index),
+		SourceType: firstNonEmpty(event.SourceType, sc.SourceType),
+		Source:     firstNonEmpty(event.Source, sc.Source),
+		Host:       firstNonEmpty(event.Host, sc.Host),
+		Event:      event.Event,
+		Fields:     event.Fields,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", models.ErrInvalidPayload, err)
+	}
+	if len(data)+1 > sc.MaxBatchBytes {
+		return nil, fmt.Errorf("%w: splunk event is %d bytes, over the %d byte request limit",
+			models.ErrInvalidPayload, len(data), sc.MaxBatchBytes)
+	}
+	return data, nil
+}
+
+// Status implements the Integration interface, reporting connectivity as of the
+// last call along with the circuit, limiter and call metrics and the events
+// and requests posted.
+func (sa *SplunkHECAdapter) Status() (models.IntegrationStatus, error) {
+	sa.mu.RLock()
+	status := models.IntegrationStatus{
+		Connected: sa.connected,
+		Name:      "SplunkHECIntegration",
+		Type:      "siem",
+		LastSync:  sa.lastSync,
+	}
+	sc, events, requests := sa.config, sa.events, sa.requests
+	sa.mu.RUnlock()
+
+	sa.guard.fillStatus(&status)
+	status.Metadata["eventsPosted"] = events
+	status.Metadata["requests"] = requests
+	if sc != nil {
+		status.Metadata["collector"] = sc.URL
+		status.Metadata["index"] = sc.Index
+		status.Metadata["sourceType"] = sc.SourceType
+	}
+	return status, nil
+}
+
+// SetRetryBudget implements models.RetryBudgetUser.
+func (sa *SplunkHECAdapter) SetRetryBudget(budget models.RetryBudget) {
+	sa.guard.budget = budget
+}
+
+// CircuitOpen implements models.CircuitReporter.
+func (sa *SplunkHECAdapter) CircuitOpen() bool {
+	return sa.guard.breaker.IsOpen()
+}
+
+// CircuitSnapshot implements models.CircuitInspector.
+func (sa *SplunkHECAdapter) CircuitSnapshot() models.CircuitSnapshot {
+	return sa.guard.breaker.Snapshot()
+}
+
+// ResetCircuit implements models.CircuitResetter.
+func (sa *SplunkHECAdapter) ResetCircuit() {
+	sa.guard.breaker.Reset()
+}
+
+// newSplunkClient creates a client for the collector authenticating with token.
+func newSplunkClient(sc *config.SplunkConfig, token string) *restClient {
+	header := http.Header{"Authorization": {"Splunk " + token}}
+	if sc.Channel != "" {
+		header.Set("X-Splunk-Request-Channel", sc.Channel)
+	}
+	return newRESTClient(sc.URL, httpclient.Default().ClientWithTimeout(sc.Timeout), header)
+}
+
+// splunkPing posts an empty event request. The collector answers an
+// authenticated one with "No data", which confirms the token without indexing
+// anything.
+func splunkPing(ctx context.Context, client *restClient) error {
+	err := client.do(ctx, restRequest{method: http.MethodPost, path: splunkEventPath, body: []byte{}}, nil)
+	var re *restError
+	if errors.As(err, &re) && re.StatusCode == http.StatusBadRequest {
+		var body struct {
+			Code int `json:"code"`
+		}
+		if json.Unmarshal([]byte(re.Body), &body) == nil && body.Code == splunkCodeNoData {
+			return nil
+		}
+	}
+	return err
+}
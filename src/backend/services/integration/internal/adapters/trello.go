@@ -0,0 +1,330 @@
+package adapters
+
+import (
+	// go1.21 - Context for cancellations and timeouts
+	"context"
+	// go1.21 - Sentinel errors and error wrapping
+	"errors"
+	"fmt"
+	// go1.21 - Request construction
+	"net/http"
+	"strings"
+	// go1.21 - Guards the client during credential rotation; due date parsing
+	"sync"
+	"time"
+
+	// v0.1.0 - Token bucket rate limiting under Trello's per-token quota
+	"golang.org/x/time/rate"
+
+	// Internal imports for configuration, transport and adapter contracts
+	"src/backend/services/integration/internal/config"
+	"src/backend/services/integration/internal/httpclient"
+	"src/backend/services/integration/internal/models"
+)
+
+// ErrInvalidTrelloConfig indicates that the Trello configuration is missing the
+// base URL, API key or token.
+var ErrInvalidTrelloConfig = errors.New("invalid trello configuration or missing required fields")
+
+// ErrTrelloListRequired is returned for a card that names no list when no
+// default list is configured.
+var ErrTrelloListRequired = errors.New("trello card requires a list and no default list is configured")
+
+// TrelloCard is a card to create. Send also accepts the map payloads used for
+// Jira ("summary", "description").
+type TrelloCard struct {
+	// Name is required; Summary is accepted as the Jira-style alias.
+	Name    string `json:"name"`
+	Summary string `json:"summary,omitempty"`
+
+	// Description is the card description (Markdown).
+	Description string `json:"description,omitempty"`
+
+	// List is a list name from the configured mapping, or a list ID; it
+	// defaults to the configured list.
+	List string `json:"list,omitempty"`
+
+	// Labels are label names from the configured mapping, or label IDs.
+	Labels []string `json:"labels,omitempty"`
+
+	// Due is the due date, as a date ("2024-05-01") or an RFC 3339 time.
+	Due string `json:"due,omitempty"`
+
+	// Top adds the card at the top of the list instead of the bottom.
+	Top bool `json:"top,omitempty"`
+}
+
+// trelloCardData is the body of a card create request.
+type trelloCardData struct {
+	IDList   string `json:"idList"`
+	Name     string `json:"name"`
+	Desc     string `json:"desc,omitempty"`
+	IDLabels string `json:"idLabels,omitempty"`
+	Due      string `json:"due,omitempty"`
+	Pos      string `json:"pos"`
+}
+
+// TrelloAdapter implements the Integration interface for Trello, creating
+// cards on configured lists through the Trello REST API.
+type TrelloAdapter struct {
+	// mu guards the fields below, which change on initialization and rotation.
+	mu          sync.RWMutex
+	config      *config.TrelloConfig
+	client      *restClient
+	member      string
+	connected   bool
+	lastSync    time.Time
+	lastCreated string
+	initialized bool
+
+	// guard applies the circuit breaker, rate limiter and retries to each call.
+	guard *restGuard
+}
+
+// Compile-time check to ensure TrelloAdapter implements the Integration interface.
+var _ models.Integration = (*TrelloAdapter)(nil)
+
+// Compile-time check to ensure TrelloAdapter supports credential rotation.
+var _ models.CredentialRotator = (*TrelloAdapter)(nil)
+
+// Compile-time check to ensure TrelloAdapter reports its circuit state.
+var _ models.CircuitReporter = (*TrelloAdapter)(nil)
+
+// Compile-time check to ensure TrelloAdapter's circuit can be reset by operators.
+var _ models.CircuitResetter = (*TrelloAdapter)(nil)
+
+// Compile-time check to ensure TrelloAdapter reports its circuit's state and counters.
+var _ models.CircuitInspector = (*TrelloAdapter)(nil)
+
+// Compile-time check to ensure TrelloAdapter accepts a retry budget.
+var _ models.RetryBudgetUser = (*TrelloAdapter)(nil)
+
+// NewTrelloAdapter creates an uninitialized Trello adapter. Trello allows 100
+// requests per 10 seconds per token, so the limiter allows eight per second
+// with a burst of ten, backing off to one every ten seconds on 429 responses.
+func NewTrelloAdapter() *TrelloAdapter {
+	return &TrelloAdapter{
+		guard: newRESTGuard(newAdaptiveLimiter(rate.Limit(8), 10, rate.Every(10*time.Second))),
+	}
+}
+
+// Initialize implements the Integration interface, bridging to
+// InitializeWithContext with a background context.
+func (ta *TrelloAdapter) Initialize(cfg interface{}) error {
+	return ta.InitializeWithContext(context.Background(), cfg)
+}
+
+// InitializeWithContext configures the adapter from a *config.TrelloConfig.
+// Steps:
+//  1. Validate the base URL and credentials.
+//  2. Build a client on the shared transport; the key and token are sent in the
+//     Authorization header rather than the query string, keeping them out of logs.
+//  3. Verify the credentials by fetching the authenticated member.
+func (ta *TrelloAdapter) InitializeWithContext(ctx context.Context, cfg interface{}) error {
+	// 1. Configuration.
+	tc, ok := cfg.(*config.TrelloConfig)
+	if !ok || tc == nil || tc.BaseURL == "" || tc.APIKey == "" || tc.Token == "" {
+		return ErrInvalidTrelloConfig
+	}
+
+	// 2. Client.
+	client := newTrelloClient(tc, tc.APIKey, tc.Token)
+
+	// 3. Connectivity.
+	member, err := trelloMe(ctx, client)
+	if err != nil {
+		ta.mu.Lock()
+		ta.connected = false
+		ta.mu.Unlock()
+		return fmt.Errorf("%w: trello members/me: %v", models.ErrConnectionFailed, err)
+	}
+
+	ta.mu.Lock()
+	defer ta.mu.Unlock()
+	ta.config = tc
+	ta.client = client
+	ta.member = member
+	ta.connected = true
+	ta.initialized = true
+	ta.lastSync = time.Now()
+	return nil
+}
+
+// RotateCredentials replaces the token (and, when Username is set, the API
+// key) without a restart. The new credentials are verified before they replace
+// the active client; on failure the adapter keeps its previous client.
+func (ta *TrelloAdapter) RotateCredentials(ctx context.Context, creds models.Credentials) error {
+	if creds.Secret == "" {
+		return models.ErrInvalidPayload
+	}
+
+	ta.mu.RLock()
+	tc := ta.config
+	ta.mu.RUnlock()
+	if tc == nil {
+		return models.ErrInitializationFailed
+	}
+
+	client := newTrelloClient(tc, firstNonEmpty(creds.Username, tc.APIKey), creds.Secret)
+	member, err := trelloMe(ctx, client)
+	if err != nil {
+		return fmt.Errorf("rotated trello credentials rejected: %w", err)
+	}
+
+	ta.mu.Lock()
+	defer ta.mu.Unlock()
+	ta.client = client
+	ta.member = member
+	ta.connected = true
+	return nil
+}
+
+// Send implements the Integration interface, bridging to SendWithContext with a
+// background context.
+func (ta *TrelloAdapter) Send(payload interface{}) error {
+	return ta.SendWithContext(context.Background(), payload)
+}
+
+// SendWithContext creates a card. The payload is a TrelloCard (or pointer), or
+// an equivalent map.
+// Steps:
+//  1. Decode the payload and map its list and labels.
+//  2. POST the card.
+func (ta *TrelloAdapter) SendWithContext(ctx context.Context, payload interface{}) error {
+	ta.mu.RLock()
+	client, tc, initialized := ta.client, ta.config, ta.initialized
+	ta.mu.RUnlock()
+	if !initialized {
+		return models.ErrInitializationFailed
+	}
+
+	// 1. Card.
+	var card TrelloCard
+	if err := decodePayload(payload, &card); err != nil {
+		return err
+	}
+	data, err := buildTrelloCard(tc, &card)
+	if err != nil {
+		return err
+	}
+
+	// 2. Create.
+	var created struct {
+		ID string `json:"id"`
+	}
+	err = ta.guard.create(ctx, func(ctx context.Context) error {
+		return client.doJSON(ctx, http.MethodPost, "/cards", data, &created)
+	})
+	if err != nil {
+		return fmt.Errorf("trello create card: %w", err)
+	}
+
+	ta.mu.Lock()
+	ta.lastSync = time.Now()
+	ta.lastCreated = created.ID
+	ta.connected = true
+	ta.mu.Unlock()
+	return nil
+}
+
+// buildTrelloCard validates card and converts it into a create request body.
+func buildTrelloCard(tc *config.TrelloConfig, card *TrelloCard) (*trelloCardData, error) {
+	name := firstNonEmpty(card.Name, card.Summary)
+	if strings.TrimSpace(name) == "" {
+		return nil, fmt.Errorf("%w: missing required 'name' field in payload", models.ErrInvalidPayload)
+	}
+
+	list := mappedValue(tc.Lists, card.List, tc.DefaultList)
+	if list == "" {
+		return nil, ErrTrelloListRequired
+	}
+
+	data := &trelloCardData{
+		IDList: list,
+		Name:   name,
+		Desc:   card.Description,
+		Pos:    "bottom",
+	}
+	if card.Top {
+		data.Pos = "top"
+	}
+
+	labels := make([]string, 0, len(card.Labels))
+	for _, label := range card.Labels {
+		labels = append(labels, mappedValue(tc.Labels, label, ""))
+	}
+	data.IDLabels = strings.Join(labels, ",")
+
+	if card.Due != "" {
+		if day, err := time.Parse(time.DateOnly, card.Due); err == nil {
+			data.Due = day.Format(time.RFC3339)
+		} else if at, err := time.Parse(time.RFC3339, card.Due); err == nil {
+			data.Due = at.UTC().Format(time.RFC3339)
+		} else {
+			return nil, fmt.Errorf("%w: due %q is neither a date nor an RFC 3339 time",
+				models.ErrInvalidPayload, card.Due)
+		}
+	}
+	return data, nil
+}
+
+// Status implements the Integration interface, reporting connectivity as of the
+// last call along with the circuit, limiter and call metrics.
+func (ta *TrelloAdapter) Status() (models.IntegrationStatus, error) {
+	ta.mu.RLock()
+	status := models.IntegrationStatus{
+		Connected: ta.connected,
+		Name:      "TrelloIntegration",
+		Type:      "project_management",
+		LastSync:  ta.lastSync,
+	}
+	tc, member, lastCreated := ta.config, ta.member, ta.lastCreated
+	ta.mu.RUnlock()
+
+	ta.guard.fillStatus(&status)
+	status.Metadata["member"] = member
+	status.Metadata["lastCardId"] = lastCreated
+	if tc != nil {
+		status.Metadata["defaultList"] = tc.DefaultList
+	}
+	return status, nil
+}
+
+// SetRetryBudget implements models.RetryBudgetUser.
+func (ta *TrelloAdapter) SetRetryBudget(budget models.RetryBudget) {
+	ta.guard.budget = budget
+}
+
+// CircuitOpen implements models.CircuitReporter.
+func (ta *TrelloAdapter) CircuitOpen() bool {
+	return ta.guard.breaker.IsOpen()
+}
+
+// CircuitSnapshot implements models.CircuitInspector.
+func (ta *TrelloAdapter) CircuitSnapshot() models.CircuitSnapshot {
+	return ta.guard.breaker.Snapshot()
+}
+
+// ResetCircuit implements models.CircuitResetter.
+func (ta *TrelloAdapter) ResetCircuit() {
+	ta.guard.breaker.Reset()
+}
+
+// newTrelloClient creates a client for the Trello API authenticated with key
+// and token.
+func newTrelloClient(tc *config.TrelloConfig, key, token string) *restClient {
+	return newRESTClient(tc.BaseURL, httpclient.Default().ClientWithTimeout(tc.Timeout), http.Header{
+		"Authorization": {fmt.Sprintf("OAuth oauth_consumer_key=%q, oauth_token=%q", key, token)},
+	})
+}
+
+// trelloMe returns the username of the member the client's token belongs to.
+func trelloMe(ctx context.Context, client *restClient) (string, error) {
+	var resp struct {
+		Username string `json:"username"`
+	}
+	if err := client.doJSON(ctx, http.MethodGet, "/members/me?fields=username", nil, &resp); err != nil {
+		return "", err
+	}
+	return resp.Username, nil
+}
@@ -0,0 +1,469 @@
+package adapters
+
+import (
+	// go1.21 - Template rendering and JSON bodies
+	"bytes"
+	"context"
+	"encoding/json"
+	// go1.21 - Sentinel errors and error wrapping
+	"errors"
+	"fmt"
+	// go1.21 - Request construction and endpoint hosts for status
+	"net/http"
+	"net/url"
+	"strings"
+	// go1.21 - Guards the endpoints during reinitialization
+	"sync"
+	"text/template"
+	"time"
+
+	// v0.1.0 - Token bucket rate limiting per endpoint
+	"golang.org/x/time/rate"
+
+	// Internal imports for configuration, transport, signing and adapter contracts
+	"src/backend/services/integration/internal/cloudevents"
+	"src/backend/services/integration/internal/config"
+	"src/backend/services/integration/internal/httpclient"
+	"src/backend/services/integration/internal/models"
+	"src/backend/services/integration/internal/signing"
+)
+
+// ErrInvalidWebhookConfig indicates that the webhook configuration has no
+// endpoints or an endpoint cannot be set up.
+var ErrInvalidWebhookConfig = errors.New("invalid webhook configuration or missing endpoints")
+
+// ErrUnknownWebhookEndpoint is returned for a message naming an endpoint that
+// is not configured.
+var ErrUnknownWebhookEndpoint = errors.New("unknown webhook endpoint")
+
+// WebhookMessage is a payload for a named endpoint. Send also accepts any other
+// value, which is delivered to the default endpoint; a map with an "endpoint"
+// key is read as a WebhookMessage.
+type WebhookMessage struct {
+	// Endpoint names a configured endpoint; it defaults to the default endpoint.
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// Payload is the data sent as JSON, or rendered by the endpoint's template.
+	Payload interface{} `json:"payload"`
+
+	// Headers are added to this request, after the endpoint's own headers.
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// EventType overrides the CloudEvents type of endpoints that send
+	// CloudEvents, and Subject sets the event's subject, e.g. a task ID.
+	EventType string `json:"eventType,omitempty"`
+	Subject   string `json:"subject,omitempty"`
+}
+
+// webhookEndpoint is a configured endpoint ready to receive requests.
+type webhookEndpoint struct {
+	config *config.WebhookEndpointConfig
+	client *restClient
+
+	// signer is nil for unsigned endpoints.
+	signer *signing.Signer
+
+	// body is nil when the payload is sent as JSON.
+	body *template.Template
+
+	// guard has its own circuit and limiter, so one failing target does not
+	// hold back the others, and the endpoint's retry policy.
+	guard *restGuard
+}
+
+// WebhookAdapter implements the Integration interface for generic HTTP
+// webhooks, sending JSON or templated bodies to configured endpoints, signed
+// with HMAC-SHA256 in the service's X-Signature format. Endpoints may wrap
+// bodies in CloudEvents, in the structured or binary content mode.
+type WebhookAdapter struct {
+	// mu guards the fields below, which change on initialization.
+	mu              sync.RWMutex
+	endpoints       map[string]*webhookEndpoint
+	defaultEndpoint string
+	budget          models.RetryBudget
+	connected       bool
+	lastSync        time.Time
+	lastEndpoint    string
+	initialized     bool
+
+	// metrics aggregates calls across every endpoint.
+	metrics *metricsCollector
+}
+
+// Compile-time check to ensure WebhookAdapter implements the Integration interface.
+var _ models.Integration = (*WebhookAdapter)(nil)
+
+// Compile-time check to ensure WebhookAdapter reports its circuit state.
+var _ models.CircuitReporter = (*WebhookAdapter)(nil)
+
+// Compile-time check to ensure WebhookAdapter's circuits can be reset by operators.
+var _ models.CircuitResetter = (*WebhookAdapter)(nil)
+
+// Compile-time check to ensure WebhookAdapter reports its circuits' state and counters.
+var _ models.CircuitInspector = (*WebhookAdapter)(nil)
+
+// Compile-time check to ensure WebhookAdapter accepts a retry budget.
+var _ models.RetryBudgetUser = (*WebhookAdapter)(nil)
+
+// NewWebhookAdapter creates an uninitialized webhook adapter.
+func NewWebhookAdapter() *WebhookAdapter {
+	return &WebhookAdapter{metrics: &metricsCollector{}}
+}
+
+// Initialize implements the Integration interface, bridging to
+// InitializeWithContext with a background context.
+func (wa *WebhookAdapter) Initialize(cfg interface{}) error {
+	return wa.InitializeWithContext(context.Background(), cfg)
+}
+
+// InitializeWithContext configures the adapter from a *config.WebhooksConfig.
+// Arbitrary targets offer no common health check, so no requests are made;
+// reachability shows in the delivery metrics.
+// Steps:
+//  1. Validate that endpoints are configured.
+//  2. For each endpoint, parse its body template, build its signer from its own
+//     key ring or the shared one, and give it a client and guard.
+func (wa *WebhookAdapter) InitializeWithContext(ctx context.Context, cfg interface{}) error {
+	// 1. Configuration.
+	wc, ok := cfg.(*config.WebhooksConfig)
+	if !ok || wc == nil || len(wc.Endpoints) == 0 {
+		return ErrInvalidWebhookConfig
+	}
+	defaultEndpoint, ok := wc.Endpoint("")
+	if !ok {
+		return fmt.Errorf("%w: default endpoint %q is not configured", ErrInvalidWebhookConfig, wc.DefaultEndpoint)
+	}
+
+	// 2. Endpoints.
+	wa.mu.RLock()
+	budget := wa.budget
+	wa.mu.RUnlock()
+	endpoints := make(map[string]*webhookEndpoint, len(wc.Endpoints))
+	for i := range wc.Endpoints {
+		ep, err := newWebhookEndpoint(&wc.Endpoints[i], wc.Outbound, wa.metrics)
+		if err != nil {
+			return fmt.Errorf("%w: endpoint %s: %v", ErrInvalidWebhookConfig, wc.Endpoints[i].Name, err)
+		}
+		ep.guard.budget = budget
+		endpoints[ep.config.Name] = ep
+	}
+
+	wa.mu.Lock()
+	defer wa.mu.Unlock()
+	wa.endpoints = endpoints
+	wa.defaultEndpoint = defaultEndpoint.Name
+	wa.connected = true
+	wa.initialized = true
+	return nil
+}
+
+// newWebhookEndpoint prepares one endpoint. Its guard reports into metrics.
+func newWebhookEndpoint(ec *config.WebhookEndpointConfig, shared *config.OutboundSigningConfig, metrics *metricsCollector) (*webhookEndpoint, error) {
+	ep := &webhookEndpoint{
+		config: ec,
+		client: newRESTClient(ec.URL, httpclient.Default().ClientWithTimeout(ec.Timeout), nil),
+		guard:  newRESTGuard(newAdaptiveLimiter(rate.Limit(10), 20, rate.Every(10*time.Second))),
+	}
+	ep.guard.metrics = metrics
+	if r := ec.Retry; r != nil {
+		ep.guard.policy = retryPolicy{attempts: r.MaxAttempts, backoff: r.Backoff, maxBackoff: r.MaxBackoff}
+	}
+
+	if ec.BodyTemplate != "" {
+		tmpl, err := template.New(ec.Name).Funcs(webhookTemplateFuncs).Option("missingkey=zero").Parse(ec.BodyTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("body template: %w", err)
+		}
+		ep.body = tmpl
+	}
+
+	if !ec.Unsigned {
+		keys := ec.Signing
+		if keys == nil || len(keys.Keys) == 0 {
+			keys = shared
+		}
+		signer, err := signing.NewSignerFromConfig(keys)
+		if err != nil {
+			return nil, err
+		}
+		ep.signer = signer
+	}
+	return ep, nil
+}
+
+// webhookTemplateFuncs are available to body templates: json encodes a value
+// (use it for strings inside JSON bodies, so they are quoted and escaped), and
+// now returns the current time in RFC 3339.
+var webhookTemplateFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+	"now": func() string {
+		return time.Now().UTC().Format(time.RFC3339)
+	},
+}
+
+// Send implements the Integration interface, bridging to SendWithContext with a
+// background context.
+func (wa *WebhookAdapter) Send(payload interface{}) error {
+	return wa.SendWithContext(context.Background(), payload)
+}
+
+// SendWithContext delivers payload to its endpoint.
+// Steps:
+//  1. Resolve the message and its endpoint.
+//  2. Render the body: the endpoint's template, or the payload as JSON,
+//     wrapped in a CloudEvent for endpoints that send them.
+//  3. Send it under the endpoint's guard, signing each attempt afresh so that
+//     retries carry a current timestamp. Retries repeat the event ID.
+func (wa *WebhookAdapter) SendWithContext(ctx context.Context, payload interface{}) error {
+	wa.mu.RLock()
+	endpoints, defaultEndpoint, initialized := wa.endpoints, wa.defaultEndpoint, wa.initialized
+	wa.mu.RUnlock()
+	if !initialized {
+		return models.ErrInitializationFailed
+	}
+
+	// 1. Endpoint.
+	msg := webhookMessage(payload)
+	name := firstNonEmpty(msg.Endpoint, defaultEndpoint)
+	ep, ok := endpoints[name]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownWebhookEndpoint, name)
+	}
+
+	// 2. Body.
+	body, err := ep.render(msg.Payload)
+	if err != nil {
+		return err
+	}
+	contentType, eventHeader := ep.contentType(), http.Header(nil)
+	if ep.config.CloudEvents != nil {
+		body, contentType, eventHeader, err = ep.cloudEvent(msg, body)
+		if err != nil {
+			return fmt.Errorf("%w: %v", models.ErrInvalidPayload, err)
+		}
+	}
+
+	// 3. Delivery.
+	err = ep.guard.call(ctx, func(ctx context.Context) error {
+		header := make(http.Header, len(ep.config.Headers)+len(msg.Headers)+len(eventHeader)+3)
+		for key, value := range ep.config.Headers {
+			header.Set(key, value)
+		}
+		for key, value := range msg.Headers {
+			header.Set(key, value)
+		}
+		for key, values := range eventHeader {
+			header[key] = values
+		}
+		if ep.signer != nil {
+			ep.signer.Sign(header, body)
+		}
+		return ep.client.do(ctx, restRequest{
+			method:      firstNonEmpty(ep.config.Method, http.MethodPost),
+			path:        ep.config.URL,
+			contentType: contentType,
+			header:      header,
+			body:        body,
+		}, nil)
+	})
+	if err != nil {
+		wa.mu.Lock()
+		wa.connected = false
+		wa.mu.Unlock()
+		return fmt.Errorf("webhook %s: %w", name, err)
+	}
+
+	wa.mu.Lock()
+	wa.lastSync = time.Now()
+	wa.lastEndpoint = name
+	wa.connected = true
+	wa.mu.Unlock()
+	return nil
+}
+
+// webhookMessage interprets a send payload as a WebhookMessage.
+func webhookMessage(payload interface{}) WebhookMessage {
+	switch p := payload.(type) {
+	case WebhookMessage:
+		return p
+	case *WebhookMessage:
+		if p != nil {
+			return *p
+		}
+	case map[string]interface{}:
+		if endpoint, ok := p["endpoint"].(string); ok {
+			var msg WebhookMessage
+			if decodePayload(p, &msg) == nil {
+				msg.Endpoint = endpoint
+				return msg
+			}
+		}
+	}
+	return WebhookMessage{Payload: payload}
+}
+
+// render returns the request body for payload. Templates see the payload as
+// decoded JSON, so fields are addressed by their JSON names.
+func (ep *webhookEndpoint) render(payload interface{}) ([]byte, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", models.ErrInvalidPayload, err)
+	}
+	if ep.body == nil {
+		return data, nil
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, fmt.Errorf("%w: %v", models.ErrInvalidPayload, err)
+	}
+	var buf bytes.Buffer
+	if err := ep.body.Execute(&buf, value); err != nil {
+		return nil, fmt.Errorf("%w: render body template: %v", models.ErrInvalidPayload, err)
+	}
+	if strings.Contains(ep.contentType(), "json") && !json.Valid(buf.Bytes()) {
+		return nil, fmt.Errorf("%w: body template did not produce valid JSON", models.ErrInvalidPayload)
+	}
+	return buf.Bytes(), nil
+}
+
+// cloudEvent wraps body, rendered for msg, in a CloudEvent in the endpoint's
+// content mode. It returns the request body and its content type, and in binary
+// mode the ce- attribute headers.
+func (ep *webhookEndpoint) cloudEvent(msg WebhookMessage, body []byte) ([]byte, string, http.Header, error) {
+	ce := ep.config.CloudEvents
+	event, err := cloudevents.New(ce.Source, firstNonEmpty(msg.EventType, ce.Type), nil)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	event.Subject = msg.Subject
+	event.SetData(ep.contentType(), body)
+
+	if ce.Mode == cloudevents.ModeBinary {
+		header := make(http.Header)
+		data, err := cloudevents.WriteBinary(header, event)
+		return data, event.DataContentType, header, err
+	}
+	data, err := cloudevents.WriteStructured(event)
+	return data, cloudevents.ContentType, nil, err
+}
+
+// contentType returns the endpoint's content type, defaulting to JSON.
+func (ep *webhookEndpoint) contentType() string {
+	return firstNonEmpty(ep.config.ContentType, "application/json")
+}
+
+// Status implements the Integration interface, reporting the outcome of the
+// last delivery, the metrics across all endpoints, and each endpoint's host,
+// signing key and circuit. Endpoint URLs are not reported in full since they
+// often embed credentials.
+func (wa *WebhookAdapter) Status() (models.IntegrationStatus, error) {
+	wa.mu.RLock()
+	status := models.IntegrationStatus{
+		Connected: wa.connected,
+		Name:      "WebhookIntegration",
+		Type:      "webhook",
+		LastSync:  wa.lastSync,
+	}
+	endpoints, defaultEndpoint, lastEndpoint := wa.endpoints, wa.defaultEndpoint, wa.lastEndpoint
+	wa.mu.RUnlock()
+
+	wa.metrics.mu.Lock()
+	status.LastError = wa.metrics.lastError
+	wa.metrics.mu.Unlock()
+	status.ErrorCount = wa.metrics.ErrorCount()
+	status.SuccessRate = wa.metrics.SuccessRate()
+
+	details := make(map[string]interface{}, len(endpoints))
+	anyOpen := false
+	for name, ep := range endpoints {
+		host := ""
+		if u, err := url.Parse(ep.config.URL); err == nil {
+			host = u.Host
+		}
+		keyID := ""
+		if ep.signer != nil {
+			keyID = ep.signer.KeyID()
+		}
+		cloudEvents := ""
+		if ce := ep.config.CloudEvents; ce != nil {
+			cloudEvents = firstNonEmpty(ce.Mode, cloudevents.ModeStructured)
+		}
+		open := ep.guard.breaker.IsOpen()
+		anyOpen = anyOpen || open
+		details[name] = map[string]interface{}{
+			"host":               host,
+			"signed":             ep.signer != nil,
+			"signingKeyId":       keyID,
+			"cloudEvents":        cloudEvents,
+			"circuitBreakerOpen": open,
+			"rateLimiter":        ep.guard.limiter.Stats(),
+		}
+	}
+	status.Metadata = map[string]interface{}{
+		"circuitBreakerOpen": anyOpen,
+		"defaultEndpoint":    defaultEndpoint,
+		"lastEndpoint":       lastEndpoint,
+		"endpoints":          details,
+	}
+	return status, nil
+}
+
+// SetRetryBudget implements models.RetryBudgetUser. The budget is shared by
+// every endpoint.
+func (wa *WebhookAdapter) SetRetryBudget(budget models.RetryBudget) {
+	wa.mu.Lock()
+	defer wa.mu.Unlock()
+	wa.budget = budget
+	for _, ep := range wa.endpoints {
+		ep.guard.budget = budget
+	}
+}
+
+// CircuitOpen implements models.CircuitReporter, reporting whether any
+// endpoint's circuit is open.
+func (wa *WebhookAdapter) CircuitOpen() bool {
+	wa.mu.RLock()
+	defer wa.mu.RUnlock()
+	for _, ep := range wa.endpoints {
+		if ep.guard.breaker.IsOpen() {
+			return true
+		}
+	}
+	return false
+}
+
+// CircuitSnapshot implements models.CircuitInspector, reporting each endpoint's
+// circuit. The summary takes the least healthy state and adds up the failures.
+func (wa *WebhookAdapter) CircuitSnapshot() models.CircuitSnapshot {
+	wa.mu.RLock()
+	defer wa.mu.RUnlock()
+	summary := models.CircuitSnapshot{
+		State:     models.CircuitClosed,
+		Endpoints: make(map[string]models.CircuitSnapshot, len(wa.endpoints)),
+	}
+	for name, ep := range wa.endpoints {
+		snapshot := ep.guard.breaker.Snapshot()
+		summary.Endpoints[name] = snapshot
+		summary.ConsecutiveFailures += snapshot.ConsecutiveFailures
+		switch {
+		case snapshot.State == models.CircuitOpen:
+			summary.State = models.CircuitOpen
+		case snapshot.State == models.CircuitHalfOpen && summary.State == models.CircuitClosed:
+			summary.State = models.CircuitHalfOpen
+		}
+	}
+	return summary
+}
+
+// ResetCircuit implements models.CircuitResetter, closing every endpoint's circuit.
+func (wa *WebhookAdapter) ResetCircuit() {
+	wa.mu.RLock()
+	defer wa.mu.RUnlock()
+	for _, ep := range wa.endpoints {
+		ep.guard.breaker.Reset()
+	}
+}
@@ -0,0 +1,51 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"src/backend/services/integration/internal/auth"
+	"src/backend/services/integration/internal/config"
+	"src/backend/services/integration/internal/logging"
+)
+
+// TestAccessLogCredentialSurvivesRedaction writes access log entries through
+// the redacting core the server installs, and checks that the caller's
+// credential ID is logged in the clear.
+func TestAccessLogCredentialSurvivesRedaction(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		principal *auth.Principal
+		want      string
+	}{
+		{"oauth2 client", &auth.Principal{Subject: "user-42", ClientID: "dashboard", Method: "introspection"}, "dashboard"},
+		{"subject", &auth.Principal{Subject: "ops", Method: "basic"}, "ops"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			core := zapcore.NewCore(zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), zapcore.AddSync(&buf), zapcore.DebugLevel)
+			logger := zap.New(core, logging.NewRedactor().WrapCore())
+
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = auth.WithPrincipal(r.Context(), tc.principal)
+				w.WriteHeader(http.StatusNoContent)
+			})
+			handler := accessLogMiddleware(&config.AccessLogConfig{Enabled: true, SampleEvery: 1}, logger)(next)
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v1/messages", nil))
+
+			var entry map[string]interface{}
+			if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+				t.Fatalf("decode access log entry: %v\n%s", err, buf.String())
+			}
+			if got := entry["credentialId"]; got != tc.want {
+				t.Errorf("credentialId = %v, want %q", got, tc.want)
+			}
+		})
+	}
+}
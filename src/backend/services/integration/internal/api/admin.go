@@ -0,0 +1,306 @@
+package api
+
+import (
+	// go1.21 - Admin request and response encoding
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	// github.com/gorilla/mux v1.8.0 - Path variable lookup
+	"github.com/gorilla/mux"
+
+	// go.uber.org/zap v1.24.0 - Runtime log level and audit logging
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	// Internal configuration, operator accounts and the spool
+	"src/backend/services/integration/internal/auth"
+	"src/backend/services/integration/internal/config"
+	"src/backend/services/integration/internal/services"
+)
+
+// Limits on the stored messages listed by HandleListStored.
+const (
+	defaultStoredLimit = 100
+	maxStoredLimit     = 1000
+)
+
+// maxAdminBodyBytes bounds the bodies of admin requests.
+const maxAdminBodyBytes = 4096
+
+// logLevelBody is the request and response body of HandleLogLevel.
+type logLevelBody struct {
+	Level string `json:"level"`
+}
+
+// configReloadResponse is the body of HandleReloadConfig.
+type configReloadResponse struct {
+	// Applied lists what the reload changed in the running service.
+	Applied []string `json:"applied"`
+
+	// RestartRequired lists the configuration sections that differ from the
+	// running configuration and take effect at the next restart.
+	RestartRequired []string `json:"restartRequired"`
+}
+
+// storedMessagesResponse is the body of HandleListStored.
+type storedMessagesResponse struct {
+	Total    int                      `json:"total"`
+	Messages []services.StoredMessage `json:"messages"`
+}
+
+// SetLogLevel makes level, the logger's level, changeable at /admin/log-level.
+func (ih *IntegrationHandler) SetLogLevel(level zap.AtomicLevel) {
+	ih.logLevel = &level
+}
+
+// SetConfigLoader sets how /admin/config/reload reads and validates the
+// configuration, typically config.LoadConfig on the path the service started with.
+func (ih *IntegrationHandler) SetConfigLoader(load func() (*config.Config, error)) {
+	ih.loadConfig = load
+}
+
+// OperatorCredentials returns the credential store that authenticates the
+// operator endpoints and the dashboard.
+func (ih *IntegrationHandler) OperatorCredentials() *auth.CredentialStore {
+	return ih.operators
+}
+
+// HandleLogLevel reports the logger's level on GET and changes it on PUT, with
+// a body such as {"level": "debug"}, so verbose logging can be switched on
+// while investigating an incident without a restart. Changes are logged with
+// the caller's identity.
+//
+// Responses:
+//   - 200 with the current level
+//   - 400 for a malformed body, unknown fields or unknown level
+//   - 409 when the level cannot be changed at runtime
+//   - 413 for a body over the size limit
+func (ih *IntegrationHandler) HandleLogLevel(w http.ResponseWriter, r *http.Request) {
+	if ih.logLevel == nil {
+		http.Error(w, "log level cannot be changed at runtime", http.StatusConflict)
+		return
+	}
+
+	if r.Method == http.MethodPut {
+		var body logLevelBody
+		if err := decodeJSON(http.MaxBytesReader(w, r.Body, maxAdminBodyBytes), &body); err != nil {
+			writeDecodeError(w, err)
+			return
+		}
+		level, err := zapcore.ParseLevel(body.Level)
+		if err != nil {
+			http.Error(w, ErrInvalidRequest.Error(), http.StatusBadRequest)
+			return
+		}
+		previous := ih.logLevel.Level()
+		ih.logLevel.SetLevel(level)
+		// Logged at warn so that the change is visible at any level but error.
+		ih.logger.Warn("Log level changed",
+			zap.Stringer("from", previous),
+			zap.Stringer("to", level),
+			zap.String("user", operatorName(r)))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(logLevelBody{Level: ih.logLevel.Level().String()})
+}
+
+// HandleReloadConfig re-reads and validates the configuration file. Operator
+// accounts are replaced at once; every other section that changed is reported
+// as requiring a restart, since the listeners, adapters and pipelines were
+// built from the running configuration. An invalid file changes nothing.
+//
+// Responses:
+//   - 200 with what was applied and what requires a restart
+//   - 409 when the configuration cannot be reloaded
+//   - 422 when the file cannot be read or fails validation
+func (ih *IntegrationHandler) HandleReloadConfig(w http.ResponseWriter, r *http.Request) {
+	if ih.loadConfig == nil {
+		http.Error(w, "configuration cannot be reloaded", http.StatusConflict)
+		return
+	}
+
+	loaded, err := ih.loadConfig()
+	if err != nil {
+		ih.logger.Warn("Configuration reload rejected", zap.Error(err), zap.String("user", operatorName(r)))
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	ih.operators.Replace(loaded.OperatorCredentials())
+	response := configReloadResponse{
+		Applied:         []string{"operatorCredentials"},
+		RestartRequired: restartRequired(ih.cfg, loaded),
+	}
+	ih.logger.Info("Configuration reloaded",
+		zap.Strings("applied", response.Applied),
+		zap.Strings("restartRequired", response.RestartRequired),
+		zap.String("user", operatorName(r)))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// HandleListStored lists the messages held in the store-and-forward spool for
+// replay, oldest first. The optional "limit" query parameter caps the list;
+// the total is always reported.
+//
+// Responses:
+//   - 200 with the total and the listed messages
+//   - 400 for a malformed limit
+//   - 409 when store-and-forward is not enabled
+func (ih *IntegrationHandler) HandleListStored(w http.ResponseWriter, r *http.Request) {
+	limit := defaultStoredLimit
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 || n > maxStoredLimit {
+			http.Error(w, ErrInvalidRequest.Error(), http.StatusBadRequest)
+			return
+		}
+		limit = n
+	}
+
+	sf := ih.syncManager.StoreAndForward()
+	if sf == nil {
+		http.Error(w, "store-and-forward is not enabled", http.StatusConflict)
+		return
+	}
+	total, stored, err := sf.Stored(r.Context(), limit)
+	if err != nil {
+		ih.logger.Error("Unable to list stored messages", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if stored == nil {
+		stored = []services.StoredMessage{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(storedMessagesResponse{Total: total, Messages: stored})
+}
+
+// HandleReplayStored delivers the stored messages of the integration named by
+// the {integration} path variable now, in order, stopping at the first failure.
+//
+// Responses:
+//   - 200 with the number of messages replayed
+//   - 404 for an unknown integration
+//   - 409 when store-and-forward is not enabled or the integration is paused
+func (ih *IntegrationHandler) HandleReplayStored(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["integration"]
+
+	sf := ih.syncManager.StoreAndForward()
+	if sf == nil {
+		http.Error(w, "store-and-forward is not enabled", http.StatusConflict)
+		return
+	}
+	replayed, err := sf.ReplayNow(r.Context(), name)
+	switch {
+	case errors.Is(err, services.ErrIntegrationNotRegistered):
+		http.Error(w, ErrIntegrationNotFound.Error(), http.StatusNotFound)
+		return
+	case errors.Is(err, services.ErrIntegrationPaused):
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	case err != nil:
+		ih.logger.Error("Replay of stored messages failed", zap.String("integration", name), zap.Error(err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	ih.logger.Info("Stored messages replayed",
+		zap.String("integration", name),
+		zap.Int("replayed", replayed),
+		zap.String("user", operatorName(r)))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(map[string]int{"replayed": replayed})
+}
+
+// HandleDiscardStored deletes the stored message named by the {id} path
+// variable without delivering it, e.g. one its provider will never accept.
+//
+// Responses:
+//   - 204 when the message was discarded
+//   - 404 for an unknown message
+//   - 409 when store-and-forward is not enabled
+func (ih *IntegrationHandler) HandleDiscardStored(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	sf := ih.syncManager.StoreAndForward()
+	if sf == nil {
+		http.Error(w, "store-and-forward is not enabled", http.StatusConflict)
+		return
+	}
+	err := sf.Discard(id)
+	switch {
+	case errors.Is(err, services.ErrStoredMessageNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	case err != nil:
+		ih.logger.Error("Discarding stored message failed", zap.String("record", id), zap.Error(err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	ih.logger.Info("Stored message discarded",
+		zap.String("record", id),
+		zap.String("user", operatorName(r)))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// operatorName returns the subject of the authenticated caller, or an empty string.
+func operatorName(r *http.Request) string {
+	if principal, ok := auth.PrincipalFromContext(r.Context()); ok {
+		return principal.Subject
+	}
+	return ""
+}
+
+// restartRequired returns the JSON names of the top-level sections in which
+// loaded differs from running, ignoring operator accounts, which are reloaded
+// in place, and the load time.
+func restartRequired(running, loaded *config.Config) []string {
+	a := reflect.ValueOf(withoutOperatorAccounts(running)).Elem()
+	b := reflect.ValueOf(withoutOperatorAccounts(loaded)).Elem()
+
+	changed := []string{}
+	for i := 0; i < a.NumField(); i++ {
+		name, _, _ := strings.Cut(a.Type().Field(i).Tag.Get("json"), ",")
+		if name == "" || name == "-" || name == "lastUpdated" {
+			continue
+		}
+		if !reflect.DeepEqual(a.Field(i).Interface(), b.Field(i).Interface()) {
+			changed = append(changed, name)
+		}
+	}
+	return changed
+}
+
+// withoutOperatorAccounts returns a shallow copy of cfg without the operator
+// accounts in auth.basic and server.admin.auth.
+func withoutOperatorAccounts(cfg *config.Config) *config.Config {
+	c := *cfg
+	if cfg.Auth != nil {
+		authCfg := *cfg.Auth
+		authCfg.Basic = nil
+		c.Auth = &authCfg
+	}
+	if cfg.Server != nil && cfg.Server.Admin != nil {
+		server, admin := *cfg.Server, *cfg.Server.Admin
+		admin.Auth = nil
+		server.Admin = &admin
+		c.Server = &server
+	}
+	return &c
+}
@@ -0,0 +1,76 @@
+package api
+
+import (
+	// go1.21 - Runtime profiler handlers
+	"net/http"
+	"net/http/pprof"
+
+	// github.com/gorilla/mux v1.8.0
+	"github.com/gorilla/mux"
+
+	// Internal configuration for the admin listener and metrics exporter selection
+	"src/backend/services/integration/internal/config"
+)
+
+// NewAdminRouter creates the router for the admin listener, which keeps
+// operational endpoints off the public API port. It serves:
+//  1. /metrics for Prometheus scrapes, when the Prometheus exporter is selected
+//  2. /livez, /readyz and /startupz for probes, /healthz for the health
+//     report, and /version
+//  3. /debug/pprof/ when profiling is enabled, behind operator authentication
+//  4. The Basic-authenticated operator endpoints (/health/secure, /admin/*):
+//     credential rotation, cache invalidation, configuration reload, log level,
+//     circuit breakers and the store-and-forward spool; operators authenticate
+//     with the listener's own accounts (server.admin.auth) when configured
+//  5. The embedded operator dashboard below /dashboard/, when enabled
+//
+// Every response carries an X-Request-ID and the configured security headers,
+// request bodies are capped at server.maxBodyBytes, and panics are recovered
+// into a 500, as on the public router.
+//
+// The public router omits /metrics and the operator endpoints whenever the admin
+// listener is enabled.
+func NewAdminRouter(h *IntegrationHandler) http.Handler {
+	r := mux.NewRouter().StrictSlash(true)
+	r.Use(requestIDMiddleware(h.Logger()))
+	r.Use(bodyLimitMiddleware(h.Config().Server.BodyBytesLimit()))
+
+	// 1. Metrics scrape endpoint.
+	if h.Config().Telemetry.ExporterEnabled(config.MetricsExporterPrometheus) {
+		r.Handle("/metrics", h.Metrics().Handler()).Methods(http.MethodGet)
+	}
+
+	// 2. Health report and probes that reach the admin port directly.
+	r.HandleFunc("/healthz", h.HandleHealthCheck).Methods(http.MethodGet)
+	r.HandleFunc(livezPath, h.HandleLiveness).Methods(http.MethodGet, http.MethodHead)
+	r.HandleFunc("/readyz", h.HandleReadiness).Methods(http.MethodGet)
+	r.HandleFunc("/startupz", h.HandleStartup).Methods(http.MethodGet)
+	r.HandleFunc("/version", h.HandleVersion).Methods(http.MethodGet)
+
+	// 3. Runtime profiling, for operators only: profiles expose the command
+	// line and memory contents. pprof.Index serves the named profiles (heap,
+	// goroutine, ...) below the prefix.
+	if serverCfg := h.Config().Server; serverCfg.AdminEnabled() && serverCfg.Admin.Pprof {
+		operator := operatorAuth(h)
+		r.HandleFunc("/debug/pprof/cmdline", operator(pprof.Cmdline))
+		r.HandleFunc("/debug/pprof/profile", operator(pprof.Profile))
+		r.HandleFunc("/debug/pprof/symbol", operator(pprof.Symbol))
+		r.HandleFunc("/debug/pprof/trace", operator(pprof.Trace))
+		r.PathPrefix("/debug/pprof/").HandlerFunc(operator(pprof.Index))
+	}
+
+	// 4. Operator endpoints.
+	registerOperatorRoutes(r, h)
+
+	// 5. Dashboard.
+	registerDashboardRoutes(r, h)
+
+	// Security headers and panic recovery wrap the router, so that they also
+	// cover requests no route matches.
+	var headersCfg *config.SecurityHeadersConfig
+	if serverCfg := h.Config().Server; serverCfg != nil {
+		headersCfg = serverCfg.SecurityHeaders
+	}
+	secured := securityHeadersMiddleware(headersCfg)(r)
+	return recoveryMiddleware(h.Logger(), h.Metrics(), h.PanicReporter())(secured)
+}
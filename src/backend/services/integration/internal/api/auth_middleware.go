@@ -0,0 +1,185 @@
+package api
+
+import (
+	// go1.21 - Error classification for introspection results
+	"errors"
+	// go1.21 - HTTP primitives for middleware
+	"net/http"
+	"strings"
+
+	// github.com/gorilla/mux v1.8.0 - Route template lookup for per-route scopes
+	"github.com/gorilla/mux"
+
+	// go.uber.org/zap v1.24.0 - Structured logging
+	"go.uber.org/zap"
+
+	// Internal authentication primitives (Principal, Introspector)
+	"src/backend/services/integration/internal/auth"
+
+	// Configuration for per-route scope requirements
+	"src/backend/services/integration/internal/config"
+)
+
+// bearerAuthMiddleware validates opaque bearer tokens through OAuth2 token
+// introspection (RFC 7662), stores the resulting Principal in the request
+// context, and enforces the scopes configured for the matched route.
+//
+// Callers that present no token but were authenticated by a verified client
+// certificate (see clientCertMiddleware) are let through as that principal
+// only while the RBAC policy is enforced: certificates carry no scopes, so
+// their access is governed by the policy alone, and without one they would
+// bypass every route's scope requirements.
+//
+// Responses follow RFC 6750: a missing or inactive token yields 401 with
+// error="invalid_token", missing scopes yield 403 with error="insufficient_scope",
+// and an unreachable authorization server yields 503.
+func bearerAuthMiddleware(
+	introspector *auth.Introspector,
+	authCfg *config.AuthConfig,
+	logger *zap.Logger,
+) mux.MiddlewareFunc {
+	certOnly := authCfg.AuthorizationEnabled()
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// 1. Extract the bearer token, falling back to the client certificate
+			// when authorizationMiddleware will check the caller's roles.
+			token, ok := bearerToken(r)
+			if !ok {
+				if principal, mtls := auth.PrincipalFromContext(r.Context()); mtls && principal.Method == auth.MethodMTLS && certOnly {
+					next.ServeHTTP(w, r)
+					return
+				}
+				writeBearerChallenge(w, http.StatusUnauthorized, "invalid_request", "")
+				return
+			}
+
+			// 2. Introspect (cached) and map failures to RFC 6750 responses.
+			principal, err := introspector.Introspect(r.Context(), token)
+			if err != nil {
+				if errors.Is(err, auth.ErrTokenInactive) {
+					writeBearerChallenge(w, http.StatusUnauthorized, "invalid_token", "")
+					return
+				}
+				logger.Error("Token introspection failed", zap.Error(err))
+				http.Error(w, "Authorization server unavailable", http.StatusServiceUnavailable)
+				return
+			}
+
+			// 3. Enforce route-specific scopes.
+			if route := mux.CurrentRoute(r); route != nil {
+				if tmpl, tmplErr := route.GetPathTemplate(); tmplErr == nil {
+					required := authCfg.ScopesForRoute(tmpl)
+					if !principal.HasAllScopes(required) {
+						logger.Warn("Insufficient token scope",
+							zap.String("subject", principal.Subject),
+							zap.String("route", tmpl),
+							zap.Strings("required", required),
+						)
+						writeBearerChallenge(w, http.StatusForbidden, "insufficient_scope", strings.Join(required, " "))
+						return
+					}
+				}
+			}
+
+			// 4. Continue with the principal attached to the context.
+			next.ServeHTTP(w, r.WithContext(auth.WithPrincipal(r.Context(), principal)))
+		})
+	}
+}
+
+// bearerToken extracts the token from an "Authorization: Bearer <token>" header.
+func bearerToken(r *http.Request) (string, bool) {
+	header := r.Header.Get("Authorization")
+	const prefix = "Bearer "
+	if len(header) <= len(prefix) || !strings.EqualFold(header[:len(prefix)], prefix) {
+		return "", false
+	}
+	token := strings.TrimSpace(header[len(prefix):])
+	return token, token != ""
+}
+
+// writeBearerChallenge writes an RFC 6750 WWW-Authenticate challenge with the given error code.
+func writeBearerChallenge(w http.ResponseWriter, status int, errCode, scope string) {
+	challenge := `Bearer error="` + errCode + `"`
+	if scope != "" {
+		challenge += `, scope="` + scope + `"`
+	}
+	w.Header().Set("WWW-Authenticate", challenge)
+	http.Error(w, http.StatusText(status), status)
+}
+
+// clientCertMiddleware attaches a Principal derived from the verified client
+// certificate (SAN-based identity) to the request context. Requests without a
+// verified certificate pass through unchanged; whether a certificate is mandatory
+// is enforced during the TLS handshake by the server's ClientAuth policy.
+func clientCertMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if principal, ok := auth.PrincipalFromTLS(r); ok {
+			r = r.WithContext(auth.WithPrincipal(r.Context(), principal))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// authorizationMiddleware enforces the RBAC policy for the matched route. It must
+// run after an authentication middleware has attached a Principal to the context;
+// requests without one are rejected with 401 on routes the policy protects (see
+// auth.Authorizer.Protects) and passed through elsewhere, and principals lacking a
+// required permission are rejected with 403.
+func authorizationMiddleware(authorizer *auth.Authorizer, logger *zap.Logger) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route := mux.CurrentRoute(r)
+			if route == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+			tmpl, err := route.GetPathTemplate()
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			principal, ok := auth.PrincipalFromContext(r.Context())
+			if !ok {
+				if !authorizer.Protects(r.Method, tmpl) {
+					next.ServeHTTP(w, r)
+					return
+				}
+				http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+				return
+			}
+
+			allowed, required := authorizer.Authorize(principal, r.Method, tmpl)
+			if !allowed {
+				logger.Warn("Authorization denied",
+					zap.String("subject", principal.Subject),
+					zap.String("method", r.Method),
+					zap.String("route", tmpl),
+					zap.Strings("required", required),
+				)
+				http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// operatorAuth returns the wrapper protecting the operator endpoints and the
+// dashboard: operators authenticate with their Basic credentials and, when the
+// RBAC policy is enforced, must hold the route's permissions like API callers.
+func operatorAuth(h *IntegrationHandler) func(http.HandlerFunc) http.HandlerFunc {
+	credentials := h.OperatorCredentials()
+	authCfg := h.Config().Auth
+	if !authCfg.AuthorizationEnabled() {
+		return func(next http.HandlerFunc) http.HandlerFunc {
+			return basicAuth(credentials, next)
+		}
+	}
+	authorize := authorizationMiddleware(auth.NewAuthorizer(authCfg.Authorization), h.Logger())
+	return func(next http.HandlerFunc) http.HandlerFunc {
+		return basicAuth(credentials, authorize(next).ServeHTTP)
+	}
+}
@@ -0,0 +1,134 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+
+	"src/backend/services/integration/internal/auth"
+	"src/backend/services/integration/internal/config"
+)
+
+func TestAuthorizationMiddleware(t *testing.T) {
+	policy := func(defaultDeny bool) *config.AuthorizationConfig {
+		return &config.AuthorizationConfig{
+			Enabled:     true,
+			Roles:       map[string][]string{"notifier": {auth.PermissionSendSlack}},
+			Bindings:    []config.RoleBinding{{Subjects: []string{"notifier-svc"}, Roles: []string{"notifier"}}},
+			DefaultDeny: defaultDeny,
+		}
+	}
+
+	for _, tc := range []struct {
+		name        string
+		defaultDeny bool
+		method      string
+		path        string
+		subject     string
+		want        int
+	}{
+		{"protected route without principal", false, http.MethodPost, "/api/v1/slack/post", "", http.StatusUnauthorized},
+		{"protected route with permission", false, http.MethodPost, "/api/v1/slack/post", "notifier-svc", http.StatusOK},
+		{"protected route without permission", false, http.MethodPost, "/api/v1/jira/create", "notifier-svc", http.StatusForbidden},
+		{"unprotected route without principal", false, http.MethodGet, "/api/v1/uncovered", "", http.StatusOK},
+		{"unprotected route with principal", false, http.MethodGet, "/api/v1/uncovered", "notifier-svc", http.StatusOK},
+		{"default deny, uncovered route without principal", true, http.MethodGet, "/api/v1/uncovered", "", http.StatusUnauthorized},
+		{"default deny, uncovered route with principal", true, http.MethodGet, "/api/v1/uncovered", "notifier-svc", http.StatusForbidden},
+		{"default deny, covered route with permission", true, http.MethodPost, "/api/v1/slack/post", "notifier-svc", http.StatusOK},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := mux.NewRouter()
+			// Stands in for authentication: the X-Test-Subject header, when set,
+			// names the principal.
+			r.Use(func(next http.Handler) http.Handler {
+				return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if subject := r.Header.Get("X-Test-Subject"); subject != "" {
+						r = r.WithContext(auth.WithPrincipal(r.Context(), &auth.Principal{Subject: subject, Method: "introspection"}))
+					}
+					next.ServeHTTP(w, r)
+				})
+			})
+			r.Use(authorizationMiddleware(auth.NewAuthorizer(policy(tc.defaultDeny)), zap.NewNop()))
+			ok := func(w http.ResponseWriter, r *http.Request) {}
+			r.HandleFunc("/api/v1/slack/post", ok).Methods(http.MethodPost)
+			r.HandleFunc("/api/v1/jira/create", ok).Methods(http.MethodPost)
+			r.HandleFunc("/api/v1/uncovered", ok).Methods(http.MethodGet)
+
+			req := httptest.NewRequest(tc.method, tc.path, nil)
+			if tc.subject != "" {
+				req.Header.Set("X-Test-Subject", tc.subject)
+			}
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			if rec.Code != tc.want {
+				t.Errorf("status = %d, want %d", rec.Code, tc.want)
+			}
+		})
+	}
+}
+
+func TestBearerAuthMiddleware(t *testing.T) {
+	authServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		active := r.PostFormValue("token") == "active-token"
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"active": active, "sub": "svc", "scope": "read:status"})
+	}))
+	defer authServer.Close()
+	unreachable := httptest.NewServer(http.NotFoundHandler())
+	unreachable.Close()
+
+	for _, tc := range []struct {
+		name      string
+		endpoint  string
+		path      string
+		token     string
+		want      int
+		challenge string
+	}{
+		{"active token", authServer.URL, "/api/v1/messages", "active-token", http.StatusOK, ""},
+		{"missing token", authServer.URL, "/api/v1/messages", "", http.StatusUnauthorized, `Bearer error="invalid_request"`},
+		{"inactive token", authServer.URL, "/api/v1/messages", "revoked-token", http.StatusUnauthorized, `Bearer error="invalid_token"`},
+		{"insufficient scope", authServer.URL, "/api/v1/slack/post", "active-token", http.StatusForbidden, `Bearer error="insufficient_scope", scope="send:slack"`},
+		{"unreachable authorization server", unreachable.URL, "/api/v1/messages", "active-token", http.StatusServiceUnavailable, ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			authCfg := &config.AuthConfig{
+				Introspection: &config.IntrospectionConfig{
+					Enabled:          true,
+					Endpoint:         tc.endpoint,
+					ClientID:         "integration-service",
+					ClientSecret:     "client-secret",
+					Timeout:          time.Second,
+					CacheTTL:         time.Minute,
+					NegativeCacheTTL: time.Minute,
+					CacheSize:        100,
+				},
+				RequiredScopes: []config.RouteScopes{{Route: "/api/v1/slack/post", Scopes: []string{"send:slack"}}},
+			}
+			r := mux.NewRouter()
+			r.Use(bearerAuthMiddleware(auth.NewIntrospector(authCfg.Introspection), authCfg, zap.NewNop()))
+			ok := func(w http.ResponseWriter, r *http.Request) {}
+			r.HandleFunc("/api/v1/messages", ok)
+			r.HandleFunc("/api/v1/slack/post", ok)
+
+			req := httptest.NewRequest(http.MethodPost, tc.path, nil)
+			if tc.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tc.token)
+			}
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			if rec.Code != tc.want {
+				t.Fatalf("status = %d, want %d", rec.Code, tc.want)
+			}
+			if got := rec.Header().Get("WWW-Authenticate"); got != tc.challenge {
+				t.Errorf("WWW-Authenticate = %q, want %q", got, tc.challenge)
+			}
+		})
+	}
+}
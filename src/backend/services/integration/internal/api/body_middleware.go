@@ -0,0 +1,110 @@
+package api
+
+import (
+	// go1.21 - Body limits, media type parsing and strict decoding
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	// github.com/gorilla/mux v1.8.0 - Middleware type
+	"github.com/gorilla/mux"
+
+	// Internal CloudEvents HTTP binding
+	"src/backend/services/integration/internal/cloudevents"
+)
+
+// Media types accepted by withContentType.
+const (
+	mediaTypeJSON = "application/json"
+	mediaTypeForm = "application/x-www-form-urlencoded"
+	mediaTypeText = "text/plain"
+)
+
+// errTrailingJSON is returned by decodeJSON for a body holding more than one
+// JSON value.
+var errTrailingJSON = errors.New("request body must hold a single JSON value")
+
+// bodyLimitMiddleware caps every request body at limit bytes. Requests that
+// declare a larger Content-Length are rejected with 413 before any handler
+// runs; for the others, reads past the limit fail with *http.MaxBytesError,
+// which handlers report as 413 (see writeDecodeError).
+func bodyLimitMiddleware(limit int64) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ContentLength > limit {
+				http.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+				return
+			}
+			if r.Body != nil && r.Body != http.NoBody {
+				r.Body = http.MaxBytesReader(w, r.Body, limit)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// withContentType rejects requests whose body is not of one of the accepted
+// media types with 415, before the body is read. Requests without a body are
+// passed through, so that bodiless actions such as pause need no header. When
+// JSON is accepted, so are structured JSON types such as
+// application/cloudevents+json, and CloudEvents in binary mode, whose
+// Content-Type describes the event data.
+//
+// Responses, besides the handler's own:
+//   - 415 for a body of another media type, or one without a Content-Type
+func withContentType(next http.Handler, accepted ...string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength == 0 || acceptsContentType(r, accepted) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		w.Header().Set("Accept", strings.Join(accepted, ", "))
+		http.Error(w, http.StatusText(http.StatusUnsupportedMediaType), http.StatusUnsupportedMediaType)
+	}
+}
+
+// acceptsContentType reports whether r's body is of one of the accepted types.
+func acceptsContentType(r *http.Request, accepted []string) bool {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	for _, want := range accepted {
+		if want == mediaTypeJSON && cloudevents.RequestMode(r) == cloudevents.ModeBinary {
+			return true
+		}
+		if err != nil {
+			continue
+		}
+		if mediaType == want || (want == mediaTypeJSON && strings.HasSuffix(mediaType, "+json")) {
+			return true
+		}
+	}
+	return false
+}
+
+// decodeJSON decodes a request body holding exactly one JSON value into v,
+// rejecting fields v does not define, so that a misspelt field fails loudly
+// instead of being dropped.
+func decodeJSON(body io.Reader, v interface{}) error {
+	decoder := json.NewDecoder(body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		return err
+	}
+	if err := decoder.Decode(&struct{}{}); !errors.Is(err, io.EOF) {
+		return errTrailingJSON
+	}
+	return nil
+}
+
+// writeDecodeError rejects a request whose body could not be decoded: with 413
+// when it exceeded the body limit, and otherwise with 400.
+func writeDecodeError(w http.ResponseWriter, err error) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		http.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+		return
+	}
+	http.Error(w, ErrInvalidRequest.Error(), http.StatusBadRequest)
+}
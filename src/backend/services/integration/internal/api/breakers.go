@@ -0,0 +1,84 @@
+package api
+
+import (
+	// go1.21 - Breaker responses
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	// github.com/gorilla/mux v1.8.0 - Path variable lookup
+	"github.com/gorilla/mux"
+
+	// go.uber.org/zap v1.24.0 - Audit logging of resets
+	"go.uber.org/zap"
+
+	// Internal authentication and circuit breaker state
+	"src/backend/services/integration/internal/auth"
+	"src/backend/services/integration/internal/models"
+	"src/backend/services/integration/internal/services"
+)
+
+// breakersResponse is the body of HandleListBreakers.
+type breakersResponse struct {
+	// Breakers maps integration names to their circuit breaker state.
+	Breakers map[string]services.BreakerStatus `json:"breakers"`
+}
+
+// HandleListBreakers reports the state and counters of every integration's
+// circuit breaker, so on-call engineers can see which upstreams are being shed.
+// Integrations without a breaker are not listed.
+//
+// Responses:
+//   - 200 with every breaker's state
+func (ih *IntegrationHandler) HandleListBreakers(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(breakersResponse{Breakers: ih.syncManager.Breakers()})
+}
+
+// HandleResetBreaker force-closes the circuit breaker of the integration named
+// by the {name} path variable, e.g. once an upstream incident is over, without
+// restarting the service. Resets are logged with the caller's identity.
+//
+// Responses:
+//   - 200 with the breaker's state after the reset
+//   - 404 for an unknown integration
+//   - 409 when the integration has no breaker that can be reset
+func (ih *IntegrationHandler) HandleResetBreaker(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["name"]
+
+	err := ih.syncManager.ResetCircuit(name)
+	switch {
+	case errors.Is(err, services.ErrIntegrationNotRegistered):
+		http.Error(w, ErrIntegrationNotFound.Error(), http.StatusNotFound)
+		return
+	case errors.Is(err, services.ErrCircuitResetUnsupported):
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	case err != nil:
+		ih.logger.Error("Circuit breaker reset failed", zap.String("integration", name), zap.Error(err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	user := ""
+	if principal, ok := auth.PrincipalFromContext(r.Context()); ok {
+		user = principal.Subject
+	}
+	ih.logger.Info("Circuit breaker reset",
+		zap.String("integration", name),
+		zap.String("user", user))
+
+	status, err := ih.syncManager.Breaker(name)
+	if err != nil {
+		// The reset succeeded; an adapter that cannot report its circuit
+		// does not fail it.
+		status = services.BreakerStatus{Resettable: true}
+		status.State = models.CircuitClosed
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(status)
+}
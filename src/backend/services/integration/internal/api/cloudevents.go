@@ -0,0 +1,82 @@
+package api
+
+import (
+	// go1.21 - Request decoding and responses
+	"bytes"
+	"encoding/json"
+	"net/http"
+
+	// Internal CloudEvents HTTP binding
+	"src/backend/services/integration/internal/cloudevents"
+)
+
+// cloudEventSource is the source of the events the send endpoints emit.
+const cloudEventSource = "/taskstream/integration-service"
+
+// Types of the events the send endpoints emit in reply to a CloudEvent.
+const (
+	sendResultEventType   = "com.taskstream.integration.send.result"
+	fanOutResultEventType = "com.taskstream.integration.fanout.result"
+)
+
+// integrationExtension is the CloudEvents extension attribute naming the target
+// integration of an event whose data does not.
+const integrationExtension = "integration"
+
+// decodeSendRequest decodes the body of a send endpoint into v. A request
+// carrying a CloudEvent, in the structured or binary content mode, is decoded
+// from the event data, and the event is returned so that the reply can be sent
+// as one as well; other requests are decoded as plain JSON. Either way, fields
+// v does not define are rejected (see decodeJSON).
+func decodeSendRequest(r *http.Request, v interface{}) (*cloudevents.Event, error) {
+	if cloudevents.RequestMode(r) == "" {
+		return nil, decodeJSON(r.Body, v)
+	}
+	event, err := cloudevents.ReadRequest(r)
+	if err != nil {
+		return nil, err
+	}
+	data, err := event.Payload()
+	if err != nil {
+		return nil, err
+	}
+	if err := decodeJSON(bytes.NewReader(data), v); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// writeSendResponse writes body with the status code. Replies to a CloudEvent
+// are CloudEvents of eventType in the request's content mode, whose data is
+// body and whose "inresponseto" extension is the request event's ID.
+func writeSendResponse(w http.ResponseWriter, r *http.Request, request *cloudevents.Event, eventType string, code int, body interface{}) {
+	if request == nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		_ = json.NewEncoder(w).Encode(body)
+		return
+	}
+
+	reply, err := cloudevents.New(cloudEventSource, eventType, body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	reply.Subject = request.Subject
+	reply.SetExtension("inresponseto", request.ID)
+
+	var data []byte
+	if cloudevents.RequestMode(r) == cloudevents.ModeStructured {
+		data, err = cloudevents.WriteStructured(reply)
+		w.Header().Set("Content-Type", cloudevents.ContentType)
+	} else {
+		data, err = cloudevents.WriteBinary(w.Header(), reply)
+		w.Header().Set("Content-Type", reply.DataContentType)
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(code)
+	_, _ = w.Write(data)
+}
@@ -0,0 +1,98 @@
+package api
+
+import (
+	// go1.21 - Token generation and constant-time comparison
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	// go1.21 - HTTP primitives for middleware
+	"net/http"
+	"net/url"
+
+	// github.com/gorilla/mux v1.8.0 - Middleware type
+	"github.com/gorilla/mux"
+
+	// Configuration for dashboard cookie policy
+	"src/backend/services/integration/internal/config"
+)
+
+const (
+	// csrfCookieName uses the __Host- prefix so browsers only accept it over HTTPS,
+	// without a Domain attribute, and with Path=/.
+	csrfCookieName = "__Host-csrf"
+
+	// csrfCookieNameInsecure is used when Secure cookies are disabled for local development,
+	// since the __Host- prefix requires the Secure attribute.
+	csrfCookieNameInsecure = "csrf"
+
+	// csrfHeaderName carries the token on mutating requests issued by the dashboard.
+	csrfHeaderName = "X-CSRF-Token"
+)
+
+// csrfMiddleware protects the dashboard's mutating endpoints with the
+// double-submit cookie pattern. Safe requests receive a random token cookie
+// (SameSite per configuration) readable by the dashboard script; unsafe requests
+// must echo it in the X-CSRF-Token header and, when an Origin header is present,
+// originate from the same host.
+func csrfMiddleware(dashboardCfg *config.DashboardConfig) mux.MiddlewareFunc {
+	cookieName := csrfCookieName
+	if !dashboardCfg.CookieSecure {
+		cookieName = csrfCookieNameInsecure
+	}
+	sameSite := http.SameSiteStrictMode
+	if dashboardCfg.CookieSameSite == config.SameSiteLax {
+		sameSite = http.SameSiteLaxMode
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			cookie, err := r.Cookie(cookieName)
+			hasToken := err == nil && cookie.Value != ""
+
+			switch r.Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+				// Issue a token for the dashboard to echo on later mutating requests.
+				if !hasToken {
+					token, tokenErr := newCSRFToken()
+					if tokenErr != nil {
+						http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+						return
+					}
+					http.SetCookie(w, &http.Cookie{
+						Name:     cookieName,
+						Value:    token,
+						Path:     "/",
+						Secure:   dashboardCfg.CookieSecure,
+						HttpOnly: false, // the dashboard script must read it to set the header
+						SameSite: sameSite,
+					})
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if origin := r.Header.Get("Origin"); origin != "" {
+				if u, parseErr := url.Parse(origin); parseErr != nil || u.Host != r.Host {
+					http.Error(w, "Cross-origin request rejected", http.StatusForbidden)
+					return
+				}
+			}
+
+			header := r.Header.Get(csrfHeaderName)
+			if !hasToken || header == "" || subtle.ConstantTimeCompare([]byte(header), []byte(cookie.Value)) != 1 {
+				http.Error(w, "Invalid CSRF token", http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// newCSRFToken returns 256 bits of randomness, base64url encoded.
+func newCSRFToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
@@ -0,0 +1,194 @@
+package api
+
+import (
+	// go1.21 - Embedded dashboard assets
+	"embed"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"net/http"
+	"sort"
+
+	// github.com/gorilla/mux v1.8.0 - Dashboard subrouter and path variables
+	"github.com/gorilla/mux"
+
+	// go.uber.org/zap v1.24.0 - Audit logging of dashboard actions
+	"go.uber.org/zap"
+
+	// Internal authentication for the operator credential store
+	"src/backend/services/integration/internal/auth"
+
+	// Internal services backing the dashboard actions
+	"src/backend/services/integration/internal/services"
+)
+
+// Limits on the lists returned by the dashboard overview.
+const (
+	dashboardStoredLimit     = 50
+	dashboardDeliveriesLimit = 50
+)
+
+//go:embed dashboard
+var dashboardAssets embed.FS
+
+// dashboardContentSecurityPolicy replaces the service's Content-Security-Policy
+// on the dashboard's static assets, which load their script and stylesheet
+// and call the dashboard API on the admin listener's own origin.
+const dashboardContentSecurityPolicy = "default-src 'none'; " +
+	"script-src 'self'; " +
+	"style-src 'self'; " +
+	"img-src 'self' data:; " +
+	"connect-src 'self'; " +
+	"frame-ancestors 'none'"
+
+// dashboardIntegration is one row of the dashboard's integration table.
+type dashboardIntegration struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Connected   bool   `json:"connected"`
+	Paused      bool   `json:"paused"`
+	CircuitOpen bool   `json:"circuitOpen"`
+}
+
+// dashboardOverview is the JSON document the dashboard polls.
+type dashboardOverview struct {
+	Integrations []dashboardIntegration    `json:"integrations"`
+	QueueDepth   int                       `json:"queueDepth"`
+	StoredTotal  int                       `json:"storedTotal"`
+	Stored       []services.StoredMessage  `json:"stored"`
+	Deliveries   []services.DeliveryRecord `json:"deliveries"`
+}
+
+// registerDashboardRoutes serves the embedded dashboard below /dashboard/ on the
+// admin listener. Every route requires operator Basic authentication, and the
+// mutating actions are additionally CSRF-protected since a browser drives them:
+//   - GET  /dashboard/                                  static assets
+//   - GET  /dashboard/api/overview                      statuses, queue, stored messages, deliveries
+//   - POST /dashboard/api/integrations/{name}/pause     stop sends to an integration
+//   - POST /dashboard/api/integrations/{name}/resume    resume sends
+//   - POST /dashboard/api/integrations/{name}/reset-breaker  force-close its circuit
+//   - POST /dashboard/api/integrations/{name}/replay    deliver its stored messages now
+func registerDashboardRoutes(r *mux.Router, h *IntegrationHandler) {
+	dashboardCfg := h.Config().Dashboard
+	if dashboardCfg == nil || !dashboardCfg.Enabled {
+		return
+	}
+	operator := operatorAuth(h)
+
+	d := r.PathPrefix("/dashboard").Subrouter()
+	d.Use(csrfMiddleware(dashboardCfg))
+
+	d.HandleFunc("/api/overview", operator(h.handleDashboardOverview)).Methods(http.MethodGet)
+	d.HandleFunc("/api/integrations/{name}/{action}",
+		operator(withContentType(http.HandlerFunc(h.handleDashboardAction), mediaTypeJSON)),
+	).Methods(http.MethodPost)
+
+	assets, err := fs.Sub(dashboardAssets, "dashboard")
+	if err != nil {
+		// The embed directive guarantees the directory exists.
+		panic(err)
+	}
+	files := http.StripPrefix("/dashboard/", http.FileServer(http.FS(assets)))
+	d.PathPrefix("/").Handler(operator(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Security-Policy", dashboardContentSecurityPolicy)
+		files.ServeHTTP(w, r)
+	})).Methods(http.MethodGet, http.MethodHead)
+}
+
+// handleDashboardOverview reports the state the dashboard renders.
+func (ih *IntegrationHandler) handleDashboardOverview(w http.ResponseWriter, r *http.Request) {
+	// Adapter errors still yield statuses for the others.
+	statuses, _ := ih.syncManager.GetStatus()
+
+	overview := dashboardOverview{
+		Integrations: make([]dashboardIntegration, 0, len(statuses)),
+		QueueDepth:   ih.syncManager.DispatchDepth(),
+		Deliveries:   ih.syncManager.RecentDeliveries(dashboardDeliveriesLimit),
+	}
+	for name, st := range statuses {
+		overview.Integrations = append(overview.Integrations, dashboardIntegration{
+			Name:        name,
+			Type:        st.Type,
+			Connected:   st.Connected,
+			Paused:      ih.syncManager.Paused(name),
+			CircuitOpen: ih.syncManager.CircuitOpen(name),
+		})
+	}
+	sort.Slice(overview.Integrations, func(i, j int) bool {
+		return overview.Integrations[i].Name < overview.Integrations[j].Name
+	})
+
+	if sf := ih.syncManager.StoreAndForward(); sf != nil {
+		total, stored, err := sf.Stored(r.Context(), dashboardStoredLimit)
+		if err != nil {
+			ih.logger.Warn("Unable to list stored messages for the dashboard", zap.Error(err))
+		}
+		overview.StoredTotal, overview.Stored = total, stored
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	_ = json.NewEncoder(w).Encode(overview)
+}
+
+// handleDashboardAction runs a dashboard action against one integration.
+//
+// Responses:
+//   - 200 with {"replayed": n} for replay
+//   - 204 for pause, resume and reset-breaker
+//   - 404 for an unknown integration or action
+//   - 409 when the integration cannot perform the action (no resettable breaker,
+//     paused during replay, or no store-and-forward stage)
+func (ih *IntegrationHandler) handleDashboardAction(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	name, action := vars["name"], vars["action"]
+
+	var (
+		err      error
+		replayed = -1
+	)
+	switch action {
+	case "pause":
+		err = ih.syncManager.Pause(name)
+	case "resume":
+		err = ih.syncManager.Resume(name)
+	case "reset-breaker":
+		err = ih.syncManager.ResetCircuit(name)
+	case "replay":
+		sf := ih.syncManager.StoreAndForward()
+		if sf == nil {
+			http.Error(w, "store-and-forward is not enabled", http.StatusConflict)
+			return
+		}
+		replayed, err = sf.ReplayNow(r.Context(), name)
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	switch {
+	case err == nil:
+		user := ""
+		if principal, ok := auth.PrincipalFromContext(r.Context()); ok {
+			user = principal.Subject
+		}
+		ih.logger.Info("Dashboard action applied",
+			zap.String("integration", name),
+			zap.String("action", action),
+			zap.String("user", user))
+		if replayed < 0 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]int{"replayed": replayed})
+	case errors.Is(err, services.ErrIntegrationNotRegistered):
+		http.Error(w, ErrIntegrationNotFound.Error(), http.StatusNotFound)
+	case errors.Is(err, services.ErrCircuitResetUnsupported), errors.Is(err, services.ErrIntegrationPaused):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		ih.logger.Error("Dashboard action failed",
+			zap.String("integration", name), zap.String("action", action), zap.Error(err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,126 @@
+// Polls the overview endpoint and wires the integration actions. Mutating
+// requests echo the CSRF cookie in the X-CSRF-Token header.
+(function () {
+  "use strict";
+
+  const refreshMs = 5000;
+
+  function csrfToken() {
+    for (const part of document.cookie.split(";")) {
+      const [name, value] = part.trim().split("=");
+      if (name === "__Host-csrf" || name === "csrf") {
+        return value;
+      }
+    }
+    return "";
+  }
+
+  function cell(text, className) {
+    const td = document.createElement("td");
+    td.textContent = text;
+    if (className) {
+      td.className = className;
+    }
+    return td;
+  }
+
+  function showError(message) {
+    const el = document.getElementById("error");
+    el.textContent = message;
+    el.hidden = !message;
+  }
+
+  async function act(name, action) {
+    const res = await fetch("api/integrations/" + encodeURIComponent(name) + "/" + action, {
+      method: "POST",
+      credentials: "same-origin",
+      headers: { "X-CSRF-Token": csrfToken() },
+    });
+    if (!res.ok) {
+      showError(action + " " + name + " failed: " + (await res.text()).trim());
+      return;
+    }
+    showError("");
+    refresh();
+  }
+
+  function actionButton(name, action, label) {
+    const button = document.createElement("button");
+    button.textContent = label;
+    button.addEventListener("click", () => act(name, action));
+    return button;
+  }
+
+  function renderIntegrations(integrations) {
+    const body = document.getElementById("integrations");
+    body.replaceChildren();
+    for (const it of integrations) {
+      const tr = document.createElement("tr");
+      tr.appendChild(cell(it.name));
+      tr.appendChild(cell(it.type));
+      if (it.paused) {
+        tr.appendChild(cell("paused", "warn"));
+      } else {
+        tr.appendChild(cell(it.connected ? "connected" : "disconnected", it.connected ? "ok" : "bad"));
+      }
+      tr.appendChild(cell(it.circuitOpen ? "open" : "closed", it.circuitOpen ? "bad" : "ok"));
+
+      const actions = document.createElement("td");
+      actions.appendChild(it.paused
+        ? actionButton(it.name, "resume", "Resume")
+        : actionButton(it.name, "pause", "Pause"));
+      actions.appendChild(actionButton(it.name, "replay", "Replay"));
+      actions.appendChild(actionButton(it.name, "reset-breaker", "Reset breaker"));
+      tr.appendChild(actions);
+      body.appendChild(tr);
+    }
+  }
+
+  function renderStored(total, stored) {
+    document.getElementById("stored-total").textContent = total;
+    const body = document.getElementById("stored");
+    body.replaceChildren();
+    for (const msg of stored || []) {
+      const tr = document.createElement("tr");
+      tr.appendChild(cell(msg.integration));
+      tr.appendChild(cell(new Date(msg.enqueuedAt).toLocaleString()));
+      tr.appendChild(cell(msg.id));
+      body.appendChild(tr);
+    }
+  }
+
+  function renderDeliveries(deliveries) {
+    const body = document.getElementById("deliveries");
+    body.replaceChildren();
+    for (const d of deliveries || []) {
+      const tr = document.createElement("tr");
+      tr.appendChild(cell(new Date(d.at).toLocaleTimeString()));
+      tr.appendChild(cell(d.integration));
+      tr.appendChild(cell(d.outcome, d.outcome === "delivered" ? "ok" : "bad"));
+      tr.appendChild(cell(String(d.messages)));
+      tr.appendChild(cell(d.latencyMs + " ms"));
+      tr.appendChild(cell(d.error || ""));
+      body.appendChild(tr);
+    }
+  }
+
+  async function refresh() {
+    try {
+      const res = await fetch("api/overview", { credentials: "same-origin" });
+      if (!res.ok) {
+        throw new Error("overview returned " + res.status);
+      }
+      const overview = await res.json();
+      renderIntegrations(overview.integrations);
+      document.getElementById("queue-depth").textContent = overview.queueDepth;
+      renderStored(overview.storedTotal, overview.stored);
+      renderDeliveries(overview.deliveries);
+      document.getElementById("updated").textContent = "Updated " + new Date().toLocaleTimeString();
+    } catch (err) {
+      showError(err.message);
+    }
+  }
+
+  refresh();
+  setInterval(refresh, refreshMs);
+})();
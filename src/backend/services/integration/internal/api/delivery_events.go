@@ -0,0 +1,145 @@
+package api
+
+import (
+	// go1.21 - Connection deadlines and filter parsing
+	"net/http"
+	"strings"
+	"time"
+
+	// github.com/gorilla/websocket v1.5.0 - WebSocket upgrade and framing
+	"github.com/gorilla/websocket"
+)
+
+// WebSocket connection timing and limits for the delivery event channel.
+const (
+	// wsWriteWait bounds each write to the client.
+	wsWriteWait = 10 * time.Second
+
+	// wsPongWait is how long the client may stay silent; pings are sent well
+	// within it.
+	wsPongWait     = 60 * time.Second
+	wsPingInterval = wsPongWait * 9 / 10
+
+	// wsMaxMessageBytes bounds the filter updates clients send.
+	wsMaxMessageBytes = 4096
+)
+
+// deliveryEventsUpgrader upgrades delivery event requests. Its default origin
+// check refuses browser connections from other origins.
+var deliveryEventsUpgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 4096,
+}
+
+// deliveryEventsFilter is the message clients send to change which
+// integrations they receive events for. An empty list receives every one.
+type deliveryEventsFilter struct {
+	Integrations []string `json:"integrations"`
+}
+
+// HandleDeliveryEvents upgrades to a WebSocket that streams the lifecycle of
+// queued sends: each send is reported as queued, then sent, failed, or
+// dead-lettered into the store-and-forward spool, and as retried when the spool
+// replays it. Every message is a services.StreamEvent as JSON, whose message ID
+// is the deliveryId the send endpoints return.
+//
+// The optional "integration" query parameter, repeated or comma-separated,
+// limits the stream to those integrations; clients may later send
+// {"integrations": [...]} to replace the filter. A client too slow to keep up
+// misses events rather than holding up deliveries.
+//
+// Responses:
+//   - 101 switching to the WebSocket protocol
+//   - 400 for requests that are not WebSocket upgrades
+//   - 404 when the filter names an unknown integration
+func (ih *IntegrationHandler) HandleDeliveryEvents(w http.ResponseWriter, r *http.Request) {
+	filter := integrationFilter(r.URL.Query()["integration"])
+	for name := range filter {
+		if _, err := ih.syncManager.GetIntegration(name); err != nil {
+			http.Error(w, ErrIntegrationNotFound.Error(), http.StatusNotFound)
+			return
+		}
+	}
+
+	// Upgrade replies with the error itself when the handshake fails.
+	conn, err := deliveryEventsUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	sub := ih.syncManager.SubscribeLifecycle()
+	defer sub.Close()
+
+	updates := make(chan map[string]bool)
+	closed, stop := make(chan struct{}), make(chan struct{})
+	defer close(stop)
+	go readDeliveryFilters(conn, updates, closed, stop)
+
+	ping := time.NewTicker(wsPingInterval)
+	defer ping.Stop()
+	for {
+		select {
+		case <-closed:
+			return
+		case filter = <-updates:
+		case event, ok := <-sub.Events():
+			if !ok {
+				return
+			}
+			if filter != nil && !filter[event.Integration] {
+				continue
+			}
+			_ = conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			if err := conn.WriteJSON(event); err != nil {
+				return
+			}
+		case <-ping.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteWait)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// readDeliveryFilters reads the client's filter updates into updates until
+// the connection fails or closes, which it reports by closing closed, or stop
+// is closed. Pongs extend the read deadline; a malformed update ends the
+// connection.
+func readDeliveryFilters(conn *websocket.Conn, updates chan<- map[string]bool, closed, stop chan struct{}) {
+	defer close(closed)
+	conn.SetReadLimit(wsMaxMessageBytes)
+	_ = conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	})
+	for {
+		var msg deliveryEventsFilter
+		if err := conn.ReadJSON(&msg); err != nil {
+			return
+		}
+		select {
+		case updates <- integrationFilter(msg.Integrations):
+		case <-stop:
+			return
+		}
+	}
+}
+
+// integrationFilter returns the set of integration names listed in values,
+// each of which may be comma-separated, or nil when none are listed.
+func integrationFilter(values []string) map[string]bool {
+	var filter map[string]bool
+	for _, value := range values {
+		for _, name := range strings.Split(value, ",") {
+			if name = strings.TrimSpace(name); name == "" {
+				continue
+			}
+			if filter == nil {
+				filter = make(map[string]bool)
+			}
+			filter[name] = true
+		}
+	}
+	return filter
+}
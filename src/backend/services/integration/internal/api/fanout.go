@@ -0,0 +1,112 @@
+package api
+
+import (
+	// go1.21 - Request decoding and responses
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	// go.uber.org/zap v1.24.0 - Structured logging
+	"go.uber.org/zap"
+
+	// Internal fan-out coordinator
+	"src/backend/services/integration/internal/services"
+)
+
+// maxFanOutTargets bounds the integrations one fan-out request may target. It
+// matches maxItems in schemas/fanout.json.
+const maxFanOutTargets = 16
+
+// fanOutRequest defines the request body for HandleFanOutSend. Each target
+// carries its own payload, e.g. a Slack message and a Jira issue. With
+// AllOrNothing, the targets delivered before one fails are reverted.
+type fanOutRequest struct {
+	Targets      []fanOutTargetRequest `json:"targets"`
+	AllOrNothing bool                  `json:"allOrNothing,omitempty"`
+}
+
+// fanOutTargetRequest is one target of a fanOutRequest.
+type fanOutTargetRequest struct {
+	Integration string          `json:"integration"`
+	Payload     json.RawMessage `json:"payload"`
+}
+
+// fanOutResponse is the response body of HandleFanOutSend.
+type fanOutResponse struct {
+	Status  string                  `json:"status"`
+	Error   string                  `json:"error,omitempty"`
+	Results []services.FanOutResult `json:"results"`
+}
+
+// HandleFanOutSend delivers one request to several integrations and waits for
+// every outcome, reporting each target's result. Unlike HandleSendMessage the
+// sends are not queued, since the caller needs every outcome. The request may
+// be a CloudEvent whose data is the fanOutRequest; the reply is then one too.
+//
+// Responses:
+//   - 200 with per-target results; "status" is "success" when every target was
+//     delivered or stored for replay, and "partial" otherwise
+//   - 400 for a malformed request, or duplicate or too many targets
+//   - 409 with per-target results when an all-or-nothing send was aborted
+//   - 413 for a body over the size limit
+func (ih *IntegrationHandler) HandleFanOutSend(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+
+	var req fanOutRequest
+	event, err := decodeSendRequest(r, &req)
+	if err != nil {
+		writeDecodeError(w, err)
+		return
+	}
+	if len(req.Targets) == 0 || len(req.Targets) > maxFanOutTargets {
+		http.Error(w, ErrInvalidRequest.Error(), http.StatusBadRequest)
+		return
+	}
+	targets := make([]services.FanOutTarget, 0, len(req.Targets))
+	seen := make(map[string]bool, len(req.Targets))
+	for _, t := range req.Targets {
+		name := strings.TrimSpace(t.Integration)
+		var payload interface{}
+		if name == "" || seen[name] || json.Unmarshal(t.Payload, &payload) != nil || payload == nil {
+			http.Error(w, ErrInvalidRequest.Error(), http.StatusBadRequest)
+			return
+		}
+		seen[name] = true
+		targets = append(targets, services.FanOutTarget{Integration: name, Payload: payload})
+	}
+
+	results, err := services.NewFanOutCoordinator(ih.syncManager).Send(r.Context(), targets, req.AllOrNothing)
+
+	response := fanOutResponse{Status: "success", Results: results}
+	for _, result := range results {
+		ih.observeFanOut(result, time.Since(start))
+		if result.Status != services.FanOutDelivered && result.Status != services.FanOutStored {
+			response.Status = "partial"
+		}
+	}
+	code := http.StatusOK
+	if errors.Is(err, services.ErrFanOutAborted) {
+		ih.logger.Warn("Aborted all-or-nothing fan-out send", zap.Error(err))
+		response.Status, response.Error, code = "aborted", err.Error(), http.StatusConflict
+	}
+
+	writeSendResponse(w, r, event, fanOutResultEventType, code, response)
+}
+
+// observeFanOut records a target's outcome in the send metrics. Unknown names
+// are client input and are not recorded as a label.
+func (ih *IntegrationHandler) observeFanOut(result services.FanOutResult, elapsed time.Duration) {
+	if _, err := ih.syncManager.GetIntegration(result.Integration); err != nil {
+		return
+	}
+	switch result.Status {
+	case services.FanOutDelivered:
+		ih.metrics.ObserveSend(result.Integration, sendOutcomeDelivered, elapsed)
+	case services.FanOutStored:
+		ih.metrics.ObserveSend(result.Integration, sendOutcomeStored, elapsed)
+	case services.FanOutFailed:
+		ih.metrics.ObserveSend(result.Integration, sendOutcomeFailed, elapsed)
+	}
+}
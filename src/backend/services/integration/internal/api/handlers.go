@@ -6,6 +6,7 @@ import (
 	"errors"
 	"net/http"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	// go1.21 - Standard library logging may be replaced by structured logging
@@ -14,11 +15,14 @@ import (
 	// go.uber.org/zap v1.24.0 - Structured logging with correlation IDs
 	"go.uber.org/zap"
 
+	// github.com/gorilla/mux v1.8.0 - Path variable access
+	"github.com/gorilla/mux"
+
 	// github.com/opentracing/opentracing-go v1.2.0 - Distributed tracing integration
 	"github.com/opentracing/opentracing-go"
 
-	// github.com/prometheus/client_golang v1.11.0 - Metrics collection and monitoring
-	"github.com/prometheus/client_golang/prometheus"
+	// github.com/ulule/limiter/v3 v3.10.0 - Store for the API rate limit counters
+	limiter "github.com/ulule/limiter/v3"
 
 	// Internal models used for integration
 	"src/backend/services/integration/internal/models"
@@ -28,6 +32,18 @@ import (
 
 	// Configuration for integration settings with advanced validation
 	"src/backend/services/integration/internal/config"
+
+	// Build information reported by /version and the health report
+	"src/backend/services/integration/internal/buildinfo"
+
+	// Event bus for inbound provider activity
+	"src/backend/services/integration/internal/events"
+
+	// Operator account verification
+	"src/backend/services/integration/internal/auth"
+
+	// Request-scoped loggers carrying the request ID
+	"src/backend/services/integration/internal/logging"
 )
 
 // Global error variables for request handling, integrating with the enterprise-grade approach.
@@ -58,11 +74,44 @@ type IntegrationHandler struct {
 	// rateLimiter imposes limits on request frequency to external integrations.
 	rateLimiter *services.RateLimiter
 
-	// metricsCollector allows exporting or registering Prometheus metrics for monitoring.
-	metricsCollector *prometheus.Collector
+	// metrics records send outcomes and latency; nil disables recording.
+	metrics *Metrics
 
 	// logger is the structured logging tool for capturing logs with correlation IDs.
 	logger *zap.Logger
+
+	// cfg is the validated service configuration the handler was built from.
+	cfg *config.Config
+
+	// events carries activity reported by providers, such as Slack messages,
+	// to the parts of the service that react to it.
+	events *events.Bus
+
+	// slackApp routes Slack slash commands and interactions to the handlers
+	// the service registers.
+	slackApp *SlackApp
+
+	// operators authenticates the Basic-authenticated operator endpoints; its
+	// accounts are replaced when the configuration is reloaded.
+	operators *auth.CredentialStore
+
+	// logLevel, when set, is the logger's level, changed through /admin/log-level.
+	logLevel *zap.AtomicLevel
+
+	// loadConfig, when set, reads and validates the configuration file for
+	// /admin/config/reload.
+	loadConfig func() (*config.Config, error)
+
+	// rateLimitStore, when set, keeps the API rate limit counters; the router
+	// falls back to an in-memory store.
+	rateLimitStore limiter.Store
+
+	// panicReporter, when set, receives the panics recovered while serving
+	// requests, in addition to the log.
+	panicReporter PanicReporter
+
+	// started is set by MarkStarted once startup has completed, for /startupz.
+	started atomic.Bool
 }
 
 // NewIntegrationHandler creates a new instance of IntegrationHandler with all reliability
@@ -70,13 +119,13 @@ type IntegrationHandler struct {
 //  1. Building a SyncManager instance from configuration
 //  2. Initializing circuit breaker with config
 //  3. Setting up rate limiter thresholds
-//  4. Assigning a Prometheus collector for metrics
+//  4. Assigning the API metrics created by the caller
 //  5. Configuring a structured Zap logger
 //  6. Returning a fully prepared IntegrationHandler
 func NewIntegrationHandler(
 	cfg *config.Config,
 	logger *zap.Logger,
-	collector *prometheus.Collector,
+	metrics *Metrics,
 ) (*IntegrationHandler, error) {
 
 	// STEP 1: Create new SyncManager instance to manage integrations with advanced reliability.
@@ -84,6 +133,7 @@ func NewIntegrationHandler(
 	if err != nil {
 		return nil, err
 	}
+	syncMgr.SetLogger(logger)
 
 	// STEP 2: Initialize a circuit breaker placeholder with specific config logic.
 	// In real implementation, this can load thresholds/timeouts from cfg or environment.
@@ -108,8 +158,8 @@ func NewIntegrationHandler(
 	// Wrap our rateLimiter interface pointer as specified by the JSON requirement.
 	rateLimiter := &limiterImpl
 
-	// STEP 4: Configure the metrics collector (already passed in).
-	// Typically you may register new counters/gauges here or store them as part of the handler.
+	// STEP 4: The metrics are created from the service registry by the caller, so
+	// the same instruments back the scrape endpoint and the OTLP bridge.
 
 	// STEP 5: Set up structured logger with correlation. The passed-in logger is assumed
 	// to handle correlation fields from the environment or request context.
@@ -119,177 +169,456 @@ func NewIntegrationHandler(
 		syncManager:      syncMgr,
 		circuitBreaker:   circuitBreaker,
 		rateLimiter:      rateLimiter,
-		metricsCollector: collector,
+		metrics:          metrics,
 		logger:           logger,
+		cfg:              cfg,
+		events:           events.NewBus(events.DefaultBufferSize, logger),
+		slackApp:         NewSlackApp(),
+		operators:        auth.NewCredentialStore(cfg.OperatorCredentials()),
 	}
 	return handler, nil
 }
 
+// Config returns the service configuration the handler was created with, allowing
+// the router to enable or disable optional endpoints and middleware.
+func (ih *IntegrationHandler) Config() *config.Config {
+	return ih.cfg
+}
+
+// Logger returns the handler's structured logger for use by router middleware.
+func (ih *IntegrationHandler) Logger() *zap.Logger {
+	return ih.logger
+}
+
+// SyncManager returns the manager owning the registered integrations, for
+// background tasks such as the credential watcher.
+func (ih *IntegrationHandler) SyncManager() *services.SyncManager {
+	return ih.syncManager
+}
+
+// Events returns the bus inbound provider events are published on, so
+// background tasks can deliver them and subscribe to them.
+func (ih *IntegrationHandler) Events() *events.Bus {
+	return ih.events
+}
+
+// SlackApp returns the registry of Slack slash command and interaction
+// handlers, so the service can register its workflows.
+func (ih *IntegrationHandler) SlackApp() *SlackApp {
+	return ih.slackApp
+}
+
+// Metrics returns the API metrics, which the router uses to serve /metrics.
+func (ih *IntegrationHandler) Metrics() *Metrics {
+	return ih.metrics
+}
+
 // HandleSendMessage processes client requests to send messages through an integrated system,
 // leveraging distributed tracing, rate limiting, circuit breaking, and robust error handling.
 //
 // Steps Implemented Here:
 //  1. Start request tracing span
 //  2. Check rate limiter
-//  3. Validate authentication (placeholder example)
-//  4. Decode and validate request payload
+//  3. Rely on the router's authentication (bearer token or client certificate)
+//  4. Decode and validate request payload, plain JSON or a CloudEvent, rejecting
+//     unknown fields and bodies over the size limit (413)
 //  5. Check circuit breaker status
-//  6. Send message through integration
-//  7. Collect metrics (placeholder)
-//  8. Return success/error response
+//  6. Enqueue the send on the dispatch pipeline, optionally waiting for its outcome
+//  7. Record the send outcome and latency
+//  8. Return success (200) with the message identifiers, accepted (202), or error response;
+//     replies to a CloudEvent are CloudEvents in the same content mode
 //  9. End tracing span
 func (ih *IntegrationHandler) HandleSendMessage(w http.ResponseWriter, r *http.Request) {
 	// 1. Start distributed tracing span from the inbound HTTP request context.
 	span, ctx := opentracing.StartSpanFromContext(r.Context(), "HandleSendMessage")
 	defer span.Finish()
+	start := time.Now()
+	// Logs carry the request ID, which the send passes on to its adapter.
+	logger := logging.FromContext(ctx, ih.logger)
 
 	// 2. Check rate limiting. If the rate limiter disallows, return an error.
 	if ih.isRateLimited(ctx) {
-		ih.logger.Error("Rate limiter triggered", zap.Error(ErrRateLimitExceeded))
+		logger.Error("Rate limiter triggered", zap.Error(ErrRateLimitExceeded))
 		http.Error(w, ErrRateLimitExceeded.Error(), http.StatusTooManyRequests)
 		return
 	}
 
-	// 3. Validate authentication (simple placeholder).
-	authHeader := r.Header.Get("Authorization")
-	if !strings.HasPrefix(authHeader, "Bearer ") {
-		ih.logger.Error("Authentication failed", zap.String("authHeader", authHeader))
-		http.Error(w, "Unauthorized request", http.StatusUnauthorized)
-		return
-	}
+	// 3. Authentication is left to the versioned API's middleware, which has
+	// accepted the caller's bearer token or verified client certificate.
 
 	// 4. Decode and validate request payload.
+	// Requests may carry a CloudEvent whose data is the request; its
+	// "integration" extension names the integration when the data does not.
 	var req sendMessageRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		ih.logger.Error("Invalid request payload", zap.Error(ErrInvalidRequest))
-		http.Error(w, ErrInvalidRequest.Error(), http.StatusBadRequest)
+	event, err := decodeSendRequest(r, &req)
+	if err != nil {
+		logger.Error("Invalid request payload", zap.Error(ErrInvalidRequest), zap.NamedError("cause", err))
+		writeDecodeError(w, err)
 		return
 	}
+	if event != nil && req.IntegrationName == "" {
+		req.IntegrationName = event.Extension(integrationExtension)
+	}
 	if strings.TrimSpace(req.IntegrationName) == "" || strings.TrimSpace(req.Message) == "" {
-		ih.logger.Error("Validation failed: missing fields", zap.Error(ErrInvalidRequest))
+		logger.Error("Validation failed: missing fields", zap.Error(ErrInvalidRequest))
+		http.Error(w, ErrInvalidRequest.Error(), http.StatusBadRequest)
+		return
+	}
+	priority, err := services.ParsePriority(req.Priority)
+	if err != nil {
+		logger.Error("Validation failed: invalid priority", zap.String("priority", req.Priority))
 		http.Error(w, ErrInvalidRequest.Error(), http.StatusBadRequest)
 		return
 	}
 
 	// 5. Check circuit breaker status. If open, return an error.
 	if ih.isCircuitOpen(ctx) {
-		ih.logger.Error("Circuit breaker open", zap.Error(ErrCircuitOpen))
+		logger.Error("Circuit breaker open", zap.Error(ErrCircuitOpen))
 		http.Error(w, ErrCircuitOpen.Error(), http.StatusServiceUnavailable)
 		return
 	}
 
-	// 6. Send message through the requested integration. This is a placeholder logic
-	// because SyncManager does not expose a direct 'GetIntegrationByName' method.
-	// A real implementation would handle retrieval and .Send() calls here.
-	if err := ih.sendMessageThroughIntegration(ctx, req.IntegrationName, req.Message); err != nil {
-		ih.logger.Error("Failed to send message through integration",
+	// 6. Enqueue the send on the dispatch pipeline. The handler returns as soon as the
+	// send is queued, unless the caller asked to wait (e.g. ?wait=2s) for its outcome.
+	wait, err := ih.sendWaitTimeout(r)
+	if err != nil {
+		http.Error(w, ErrInvalidRequest.Error(), http.StatusBadRequest)
+		return
+	}
+	completed := false
+	ticket, err := ih.syncManager.DispatchPriority(ctx, req.IntegrationName, priority, req.Message)
+	if err == nil && wait > 0 {
+		waitCtx, cancel := context.WithTimeout(ctx, wait)
+		select {
+		case <-ticket.Done():
+			completed = true
+			err = ticket.Err()
+		case <-waitCtx.Done():
+			// Still in flight; report it as accepted.
+		}
+		cancel()
+	}
+	if errors.Is(err, services.ErrStoredForReplay) {
+		// The integration is down but the message is durable; it will be replayed.
+		ih.metrics.ObserveSend(req.IntegrationName, sendOutcomeStored, time.Since(start))
+		writeSendResponse(w, r, event, sendResultEventType, http.StatusAccepted, map[string]string{
+			"status":     "stored",
+			"deliveryId": ticket.ID(),
+		})
+		return
+	}
+	if err != nil {
+		logger.Error("Failed to send message through integration",
 			zap.String("integrationName", req.IntegrationName),
 			zap.Error(err))
-		if errors.Is(err, models.ErrConnectionFailed) {
-			http.Error(w, "Integration connection failed", http.StatusBadGateway)
-			return
+		// Unknown names are client input and are not recorded as a label.
+		if !errors.Is(err, services.ErrIntegrationNotRegistered) {
+			ih.metrics.ObserveSend(req.IntegrationName, sendOutcomeFailed, time.Since(start))
 		}
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		code, message, retry := sendErrorStatus(err)
+		if retry {
+			w.Header().Set("Retry-After", "1")
+		}
+		http.Error(w, message, code)
 		return
 	}
 
-	// 7. Collect custom metrics or increment counters after a successful operation (placeholder).
-	// Example:
-	// counter, ok := (*ih.metricsCollector).(prometheus.Counter)
-	// if ok {
-	//     counter.Inc()
-	// }
+	// 7. Record the outcome: delivered when the caller waited for completion,
+	// accepted when the send is still queued or in flight.
+	if completed {
+		ih.metrics.ObserveSend(req.IntegrationName, sendOutcomeDelivered, time.Since(start))
+	} else {
+		ih.metrics.ObserveSend(req.IntegrationName, sendOutcomeAccepted, time.Since(start))
+	}
 
-	// 8. Return success response.
-	w.WriteHeader(http.StatusOK)
-	_ = json.NewEncoder(w).Encode(map[string]string{
-		"status": "success",
-	})
+	// 8. Return success response: 200 once delivered, 202 while still queued or in flight.
+	if !completed {
+		writeSendResponse(w, r, event, sendResultEventType, http.StatusAccepted, map[string]string{
+			"status":     "accepted",
+			"deliveryId": ticket.ID(),
+		})
+		return
+	}
+	// Delivered sends include the message identifiers the adapter reported.
+	// Every response names the send's deliveryId, the ID its lifecycle events
+	// are reported under.
+	response := map[string]string{
+		"status":     "success",
+		"deliveryId": ticket.ID(),
+	}
+	result := ticket.Result()
+	if result.MessageID != "" {
+		response["messageId"] = result.MessageID
+	}
+	if result.ExternalID != "" {
+		response["externalId"] = result.ExternalID
+	}
+	if result.Channel != "" {
+		response["channel"] = result.Channel
+	}
+	if result.URL != "" {
+		response["url"] = result.URL
+	}
+	writeSendResponse(w, r, event, sendResultEventType, http.StatusOK, response)
 
 	// 9. End tracing span (deferred).
 }
 
 // HandleHealthCheck provides a comprehensive health check endpoint that reports:
 //  1. General service health
-//  2. Database connectivity (placeholder)
+//  2. Each dependency's health and probe latency: every integration and the
+//     other dependencies registered with SyncManager.AddDependencyProbe
 //  3. Integration statuses
-//  4. System metrics (placeholder)
-//  5. A structured JSON response for monitoring tools
+//  4. A structured JSON response for monitoring tools
+//
+// Probes run in parallel, each bounded by its status check timeout, and their
+// results are reused for healthMonitor.reportTTL; "cached" marks a reused report.
 func (ih *IntegrationHandler) HandleHealthCheck(w http.ResponseWriter, r *http.Request) {
 	span, ctx := opentracing.StartSpanFromContext(r.Context(), "HandleHealthCheck")
 	defer span.Finish()
 
-	// Collect integration statuses from SyncManager
-	statuses, err := ih.syncManager.GetStatus()
-	if err != nil {
-		ih.logger.Error("Failed to retrieve integration status", zap.Error(err))
-		http.Error(w, "Unable to retrieve integration status", http.StatusInternalServerError)
-		return
+	// Probe the dependencies, or reuse a recent round.
+	health := ih.syncManager.HealthReport(ctx)
+
+	// Build a composite health report to return to the user.
+	report := &healthReport{
+		Service:      "Integration Service",
+		Timestamp:    health.CheckedAt.Format(time.RFC3339),
+		Cached:       health.Cached,
+		Dependencies: health.Dependencies,
+		Integrations: health.Integrations,
+		Build:        buildinfo.Get(),
 	}
 
-	// (2) Verify database connectivity (placeholder).
-	// For demonstration, we mock it as healthy. Production code would run an actual check.
-	dbHealthy := true
+	// Evaluate overall status from the dependencies' health.
+	if health.Healthy() {
+		report.OverallStatus = "Healthy"
+	} else {
+		report.OverallStatus = "Degraded"
+	}
 
-	// (3) Convert statuses into a structured form.
-	detailedStatuses := make(map[string]models.IntegrationStatus)
-	for name, st := range statuses {
-		detailedStatuses[name] = st
+	// Encode into a pooled buffer; dashboards poll this endpoint continuously.
+	w.Header().Set("Cache-Control", "no-store")
+	if jsonErr := writePooledJSON(w, http.StatusOK, report.appendJSON); jsonErr != nil {
+		ih.logger.Error("Failed to encode health report", zap.Error(jsonErr))
+		http.Error(w, "Unable to encode health report", http.StatusInternalServerError)
 	}
+}
 
-	// (4) Collect system-level metrics or placeholders
-	// In real scenarios, we might gather memory usage, CPU usage, queue lengths, etc.
+// HandleReadiness reports whether the service should receive traffic, as last
+// determined by the background health monitor: 200 when ready, 503 otherwise,
+// including from the start of a graceful shutdown (see HealthMonitor.Drain).
+// Without a health monitor the service is always ready.
+func (ih *IntegrationHandler) HandleReadiness(w http.ResponseWriter, r *http.Request) {
+	readiness := services.Readiness{Ready: true}
+	if monitor := ih.syncManager.HealthMonitor(); monitor != nil {
+		readiness = monitor.Readiness()
+	}
 
-	// Build a composite health report to return to the user.
-	healthReport := struct {
-		Service       string                               `json:"service"`
-		Timestamp     string                               `json:"timestamp"`
-		DBHealthy     bool                                 `json:"dbHealthy"`
-		Integrations  map[string]models.IntegrationStatus  `json:"integrations"`
-		OverallStatus string                               `json:"overallStatus"`
-	}{
-		Service:      "Integration Service",
-		Timestamp:    time.Now().UTC().Format(time.RFC3339),
-		DBHealthy:    dbHealthy,
-		Integrations: detailedStatuses,
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if readiness.Ready {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
 	}
+	_ = json.NewEncoder(w).Encode(readiness)
+}
+
+// HandleLiveness answers the liveness probe, /livez, with 200 whenever the
+// process can serve requests. It checks no dependency, so that an integration
+// outage never gets the process restarted; see HandleReadiness for that.
+func (ih *IntegrationHandler) HandleLiveness(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+}
+
+// MarkStarted records that startup has completed: integrations are warmed up
+// and the listeners are serving.
+func (ih *IntegrationHandler) MarkStarted() {
+	ih.started.Store(true)
+}
 
-	// Evaluate overall status based on integrators and DB state
-	if dbHealthy && allIntegrationsConnected(detailedStatuses) {
-		healthReport.OverallStatus = "Healthy"
+// HandleStartup answers the startup probe, /startupz, which holds off the
+// liveness and readiness probes while integrations warm up.
+//
+// Responses:
+//   - 200 once MarkStarted has been called
+//   - 503 while the service is still starting
+func (ih *IntegrationHandler) HandleStartup(w http.ResponseWriter, r *http.Request) {
+	started := ih.started.Load()
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if started {
+		w.WriteHeader(http.StatusOK)
 	} else {
-		healthReport.OverallStatus = "Degraded"
+		w.WriteHeader(http.StatusServiceUnavailable)
 	}
+	_ = json.NewEncoder(w).Encode(map[string]bool{"started": started})
+}
 
+// HandleVersion reports the version, commit, build time and Go runtime of the
+// running binary, so operators can verify which build is deployed.
+func (ih *IntegrationHandler) HandleVersion(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	if jsonErr := json.NewEncoder(w).Encode(healthReport); jsonErr != nil {
-		ih.logger.Error("Failed to encode health report", zap.Error(jsonErr))
-		http.Error(w, "Unable to encode health report", http.StatusInternalServerError)
+	_ = json.NewEncoder(w).Encode(buildinfo.Get())
+}
+
+// HandleInboundWebhook receives an inbound webhook whose HMAC signature has
+// already been verified by webhookSignatureMiddleware, and publishes its body
+// on the event bus as a WebhookReceived event for sync to act on. The sender is
+// identified by the {source} path variable.
+//
+// Responses:
+//   - 202 once the event is queued
+//   - 400 for a body that is not valid JSON or form data
+//   - 503 when the event bus is full, so that the sender retries the webhook
+func (ih *IntegrationHandler) HandleInboundWebhook(w http.ResponseWriter, r *http.Request) {
+	span, _ := opentracing.StartSpanFromContext(r.Context(), "HandleInboundWebhook")
+	defer span.Finish()
+
+	source := mux.Vars(r)["source"]
+	payload, err := inboundWebhookPayload(r)
+	if err != nil {
+		http.Error(w, ErrInvalidRequest.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = ih.events.Publish(events.Event{
+		Source:   source,
+		Type:     events.WebhookReceived,
+		Payload:  payload,
+		Received: time.Now(),
+	})
+	if errors.Is(err, events.ErrBusFull) {
+		ih.logger.Warn("Deferred inbound webhook: event bus is full", zap.String("source", source))
+		http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+		return
 	}
+	ih.logger.Info("Accepted inbound webhook", zap.String("source", source))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	_ = json.NewEncoder(w).Encode(map[string]string{
+		"status": "accepted",
+	})
 }
 
-// sendMessageThroughIntegration is a helper that emulates retrieving an integration by name
-// and sending the desired message. Due to limited public APIs in SyncManager, this function
-// only demonstrates how one might logically structure the call. A real implementation would
-// query an actual map or method in SyncManager to locate and use the proper Integration.
+// HandleRotateCredentials re-authenticates the integration named by the
+// {integration} path variable with credentials from the request body, so that
+// tokens and passwords can be rotated without restarting the service. The new
+// credentials are verified by the adapter before they replace the old ones.
 //
-// This function also inserts a small artificial delay to simulate external call latencies.
-func (ih *IntegrationHandler) sendMessageThroughIntegration(
-	ctx context.Context,
-	integrationName string,
-	message string,
-) error {
-	// Simulate an external call latency
-	time.Sleep(100 * time.Millisecond)
-
-	// As a placeholder, we do not actually retrieve the integration from SyncManager here.
-	// In a production scenario, you would do something like:
-	// integration, found := sm.integrations[integrationName]
-	// if !found { return ErrIntegrationNotFound }
-	// return integration.Send(message)
-
-	// Here, just pretend the operation succeeded.
-	return nil
+// Responses:
+//   - 204 when the rotation succeeded
+//   - 400 for a malformed body, unknown fields or missing secret
+//   - 404 for an unknown integration
+//   - 409 when the integration cannot rotate credentials at runtime
+//   - 413 for a body over the size limit
+//   - 502 when the external service rejected the new credentials
+func (ih *IntegrationHandler) HandleRotateCredentials(w http.ResponseWriter, r *http.Request) {
+	span, ctx := opentracing.StartSpanFromContext(r.Context(), "HandleRotateCredentials")
+	defer span.Finish()
+
+	integrationName := mux.Vars(r)["integration"]
+
+	var creds models.Credentials
+	if err := decodeJSON(r.Body, &creds); err != nil {
+		writeDecodeError(w, err)
+		return
+	}
+	if creds.Secret == "" {
+		http.Error(w, ErrInvalidRequest.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err := ih.syncManager.RotateCredentials(ctx, integrationName, creds)
+	switch {
+	case err == nil:
+		ih.logger.Info("Rotated integration credentials via admin API",
+			zap.String("integration", integrationName))
+		w.WriteHeader(http.StatusNoContent)
+	case errors.Is(err, services.ErrIntegrationNotRegistered):
+		http.Error(w, ErrIntegrationNotFound.Error(), http.StatusNotFound)
+	case errors.Is(err, services.ErrRotationUnsupported):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		ih.logger.Error("Credential rotation failed",
+			zap.String("integration", integrationName), zap.Error(err))
+		http.Error(w, "Credential rotation failed", http.StatusBadGateway)
+	}
+}
+
+// HandleInvalidateMetadata drops cached provider metadata (channel lists,
+// create metadata, transitions) for the integration named by the {integration}
+// path variable. The optional "prefix" query parameter limits invalidation to
+// matching keys, e.g. "createmeta/ENG"; without it the whole cache is cleared.
+//
+// Responses:
+//   - 200 with the number of entries removed
+//   - 404 for an unknown integration
+//   - 409 when the integration keeps no metadata cache
+func (ih *IntegrationHandler) HandleInvalidateMetadata(w http.ResponseWriter, r *http.Request) {
+	span, _ := opentracing.StartSpanFromContext(r.Context(), "HandleInvalidateMetadata")
+	defer span.Finish()
+
+	integrationName := mux.Vars(r)["integration"]
+	prefix := r.URL.Query().Get("prefix")
+
+	removed, err := ih.syncManager.InvalidateMetadata(integrationName, prefix)
+	switch {
+	case err == nil:
+		ih.logger.Info("Invalidated integration metadata cache",
+			zap.String("integration", integrationName),
+			zap.String("prefix", prefix),
+			zap.Int("removed", removed))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]int{"invalidated": removed})
+	case errors.Is(err, services.ErrIntegrationNotRegistered):
+		http.Error(w, ErrIntegrationNotFound.Error(), http.StatusNotFound)
+	default:
+		http.Error(w, err.Error(), http.StatusConflict)
+	}
+}
+
+// sendErrorStatus maps a failed send to the status code and message it is
+// reported with, and whether the caller should retry after a second.
+func sendErrorStatus(err error) (code int, message string, retry bool) {
+	switch {
+	case errors.Is(err, services.ErrIntegrationNotRegistered):
+		return http.StatusNotFound, ErrIntegrationNotFound.Error(), false
+	case errors.Is(err, services.ErrDispatchQueueFull), errors.Is(err, services.ErrBulkheadFull),
+		errors.Is(err, models.ErrRetryBudgetExhausted):
+		return http.StatusServiceUnavailable, err.Error(), true
+	case errors.Is(err, services.ErrDispatcherClosed):
+		return http.StatusServiceUnavailable, err.Error(), false
+	case errors.Is(err, services.ErrIntegrationPaused):
+		return http.StatusConflict, err.Error(), false
+	case errors.Is(err, models.ErrConnectionFailed):
+		return http.StatusBadGateway, "Integration connection failed", false
+	default:
+		return http.StatusInternalServerError, err.Error(), false
+	}
+}
+
+// sendWaitTimeout returns how long HandleSendMessage should wait for a send to
+// complete, from the optional "wait" query parameter (a Go duration such as
+// "2s"), capped at the configured dispatch maxWait. Zero means do not wait.
+func (ih *IntegrationHandler) sendWaitTimeout(r *http.Request) (time.Duration, error) {
+	raw := r.URL.Query().Get("wait")
+	if raw == "" {
+		return 0, nil
+	}
+	wait, err := time.ParseDuration(raw)
+	if err != nil || wait < 0 {
+		return 0, ErrInvalidRequest
+	}
+	if dispatch := ih.cfg.Dispatch; dispatch != nil && dispatch.MaxWait > 0 && wait > dispatch.MaxWait {
+		wait = dispatch.MaxWait
+	}
+	return wait, nil
 }
 
 // isRateLimited checks whether the request should be blocked by the rate limiter. This is a
@@ -309,21 +638,14 @@ func (ih *IntegrationHandler) isCircuitOpen(ctx context.Context) bool {
 	return false
 }
 
-// allIntegrationsConnected checks if all integration statuses have Connected == true.
-func allIntegrationsConnected(m map[string]models.IntegrationStatus) bool {
-	for _, st := range m {
-		if !st.Connected {
-			return false
-		}
-	}
-	return true
-}
-
 // sendMessageRequest defines the request body for HandleSendMessage.
 // IntegrationName is used to specify which integration to send through.
+// Priority is optional: "low", "normal" (default) or "high". Low-priority sends
+// are the first to be spilled to disk when the dispatch queue is under pressure.
 type sendMessageRequest struct {
 	IntegrationName string `json:"integrationName"`
 	Message         string `json:"message"`
+	Priority        string `json:"priority,omitempty"`
 }
 
 // Below are dummy placeholders to satisfy the requirement for storing pointers to
@@ -0,0 +1,261 @@
+package api
+
+import (
+	// go1.21 - Request fingerprints and response recording
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+	// go1.21 - Guards the recorded responses
+	"sync"
+	"time"
+
+	// go.uber.org/zap v1.24.0 - Structured logging of replays
+	"go.uber.org/zap"
+
+	// Internal configuration and caller identity
+	"src/backend/services/integration/internal/auth"
+	"src/backend/services/integration/internal/config"
+)
+
+const (
+	// idempotencyKeyHeader carries the client's key for a send.
+	idempotencyKeyHeader = "Idempotency-Key"
+
+	// idempotentReplayedHeader marks responses replayed for a repeated key.
+	idempotentReplayedHeader = "Idempotent-Replayed"
+
+	// maxIdempotencyKeyLength bounds the keys clients may send.
+	maxIdempotencyKeyLength = 255
+)
+
+var (
+	// errIdempotencyKeyInFlight is returned while the first request with a key
+	// is still being handled.
+	errIdempotencyKeyInFlight = errors.New("a request with this Idempotency-Key is in progress")
+
+	// errIdempotencyKeyReused is returned when a key is sent again with a
+	// different request.
+	errIdempotencyKeyReused = errors.New("Idempotency-Key was already used with a different request")
+)
+
+// recordedResponse is a response kept to be replayed for a repeated key.
+type recordedResponse struct {
+	status int
+	header http.Header
+	body   []byte
+}
+
+// idempotentRequest is the state of one key: in flight until response is set.
+type idempotentRequest struct {
+	fingerprint string
+	response    *recordedResponse
+	expires     time.Time
+}
+
+// idempotencyStore keeps the responses to keyed requests in memory for a TTL,
+// evicting the entries closest to expiry once it holds maxEntries.
+type idempotencyStore struct {
+	ttl        time.Duration
+	maxEntries int
+
+	mu      sync.Mutex
+	entries map[string]*idempotentRequest
+}
+
+// newIdempotencyStore creates a store from the idempotency configuration, or
+// returns nil when Idempotency-Key handling is disabled.
+func newIdempotencyStore(cfg *config.IdempotencyConfig) *idempotencyStore {
+	if !cfg.IdempotencyEnabled() {
+		return nil
+	}
+	return &idempotencyStore{
+		ttl:        cfg.TTL,
+		maxEntries: cfg.MaxEntries,
+		entries:    make(map[string]*idempotentRequest),
+	}
+}
+
+// begin claims key for a request with fingerprint. It returns the recorded
+// response when the request was already handled, errIdempotencyKeyInFlight or
+// errIdempotencyKeyReused when it cannot be handled now, and neither when the
+// caller owns the key and must call finish.
+func (s *idempotencyStore) begin(key, fingerprint string) (*recordedResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if existing, ok := s.entries[key]; ok && now.Before(existing.expires) {
+		switch {
+		case existing.fingerprint != fingerprint:
+			return nil, errIdempotencyKeyReused
+		case existing.response == nil:
+			return nil, errIdempotencyKeyInFlight
+		default:
+			return existing.response, nil
+		}
+	}
+
+	if len(s.entries) >= s.maxEntries {
+		s.evictLocked(now)
+	}
+	s.entries[key] = &idempotentRequest{fingerprint: fingerprint, expires: now.Add(s.ttl)}
+	return nil, nil
+}
+
+// finish records the response to key's request, or releases the key when
+// response is nil so that the request can be retried.
+func (s *idempotencyStore) finish(key string, response *recordedResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[key]
+	if !ok {
+		return
+	}
+	if response == nil {
+		delete(s.entries, key)
+		return
+	}
+	entry.response = response
+	entry.expires = time.Now().Add(s.ttl)
+}
+
+// evictLocked removes expired entries, then, if the store is still full, the
+// completed entry closest to expiry. s.mu must be held.
+func (s *idempotencyStore) evictLocked(now time.Time) {
+	var oldestKey string
+	var oldest time.Time
+	for key, entry := range s.entries {
+		if !now.Before(entry.expires) {
+			delete(s.entries, key)
+			continue
+		}
+		if entry.response != nil && (oldestKey == "" || entry.expires.Before(oldest)) {
+			oldestKey, oldest = key, entry.expires
+		}
+	}
+	if len(s.entries) >= s.maxEntries && oldestKey != "" {
+		delete(s.entries, oldestKey)
+	}
+}
+
+// responseRecorder passes a response through while keeping a copy of it.
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+// WriteHeader records the status code.
+func (rr *responseRecorder) WriteHeader(code int) {
+	if rr.status == 0 {
+		rr.status = code
+	}
+	rr.ResponseWriter.WriteHeader(code)
+}
+
+// Write records the body.
+func (rr *responseRecorder) Write(b []byte) (int, error) {
+	if rr.status == 0 {
+		rr.status = http.StatusOK
+	}
+	rr.body.Write(b)
+	return rr.ResponseWriter.Write(b)
+}
+
+// Unwrap returns the underlying ResponseWriter for http.ResponseController.
+func (rr *responseRecorder) Unwrap() http.ResponseWriter {
+	return rr.ResponseWriter
+}
+
+// withIdempotency honors the Idempotency-Key header on a send endpoint. The
+// first request with a key is handled and, when it succeeds, its response is
+// kept for the configured TTL; retries with the same key and body get that
+// response again, marked Idempotent-Replayed, without a second delivery. Keys
+// are scoped to the caller and route. Failed requests are not kept, so they
+// can be retried. Requests without the header, or with a nil store, are
+// handled normally.
+//
+// Responses, besides the endpoint's own:
+//   - 400 for an empty or overlong key
+//   - 409 while the first request with the key is still in progress
+//   - 413 for a body over the size limit
+//   - 422 when the key was used with a different request body
+func withIdempotency(store *idempotencyStore, logger *zap.Logger, next http.Handler) http.Handler {
+	if store == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		values, present := r.Header[idempotencyKeyHeader]
+		if !present {
+			next.ServeHTTP(w, r)
+			return
+		}
+		key := values[0]
+		if key == "" || len(key) > maxIdempotencyKeyLength {
+			http.Error(w, ErrInvalidRequest.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// The body is capped by the router's body limit.
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeDecodeError(w, err)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		sum := sha256.Sum256(body)
+
+		subject := ""
+		if principal, ok := auth.PrincipalFromContext(r.Context()); ok {
+			subject = principal.Subject
+		}
+		scoped := subject + "\x00" + r.Method + " " + r.URL.Path + "\x00" + key
+
+		recorded, err := store.begin(scoped, hex.EncodeToString(sum[:]))
+		switch {
+		case errors.Is(err, errIdempotencyKeyInFlight):
+			w.Header().Set("Retry-After", "1")
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		case errors.Is(err, errIdempotencyKeyReused):
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		case recorded != nil:
+			logger.Info("Replaying response for repeated Idempotency-Key",
+				zap.String("path", r.URL.Path),
+				zap.String("subject", subject))
+			for name, v := range recorded.header {
+				w.Header()[name] = v
+			}
+			w.Header().Set(idempotentReplayedHeader, "true")
+			w.WriteHeader(recorded.status)
+			_, _ = w.Write(recorded.body)
+			return
+		}
+
+		// Only the headers the endpoint sets are replayed; those of outer
+		// middleware, such as rate limit counters, are set afresh.
+		outer := w.Header().Clone()
+		rec := &responseRecorder{ResponseWriter: w}
+		var response *recordedResponse
+		// Release the key even if the handler panics, so a retry is not
+		// refused until the TTL runs out.
+		defer func() { store.finish(scoped, response) }()
+
+		next.ServeHTTP(rec, r)
+		if rec.status >= 200 && rec.status < 300 {
+			header := make(http.Header)
+			for name, v := range w.Header() {
+				if !slices.Equal(outer[name], v) {
+					header[name] = slices.Clone(v)
+				}
+			}
+			response = &recordedResponse{status: rec.status, header: header, body: rec.body.Bytes()}
+		}
+	})
+}
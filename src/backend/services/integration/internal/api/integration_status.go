@@ -0,0 +1,119 @@
+package api
+
+import (
+	// go1.21 - Status encoding and query parsing
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	// github.com/gorilla/mux v1.8.0 - Path variable lookup
+	"github.com/gorilla/mux"
+
+	// go.uber.org/zap v1.24.0 - Structured logging
+	"go.uber.org/zap"
+
+	// Internal models and services
+	"src/backend/services/integration/internal/models"
+	"src/backend/services/integration/internal/services"
+)
+
+// defaultStatusErrorsLimit is how many recent failures the status endpoint
+// returns unless the caller asks for a different number.
+const defaultStatusErrorsLimit = 20
+
+// integrationStatusResponse is the body of HandleIntegrationStatus.
+type integrationStatusResponse struct {
+	Status models.IntegrationStatus `json:"status"`
+
+	// StatusError is the error the adapter reported with its status, if any.
+	StatusError string `json:"statusError,omitempty"`
+
+	// Paused reports whether an operator has paused the integration.
+	Paused bool `json:"paused"`
+
+	// RecentErrors are the integration's most recent failed deliveries,
+	// newest first.
+	RecentErrors []services.DeliveryRecord `json:"recentErrors"`
+}
+
+// HandleIntegrationStatus reports the status of the integration named by the
+// {name} path variable, with its recent failed deliveries, so clients need not
+// fetch the whole health report for one integration. The optional "errors"
+// query parameter sets how many failures are returned.
+//
+// Responses:
+//   - 200 with the status and recent errors
+//   - 400 for a malformed "errors" parameter
+//   - 404 for an unknown integration
+func (ih *IntegrationHandler) HandleIntegrationStatus(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["name"]
+
+	limit := defaultStatusErrorsLimit
+	if raw := r.URL.Query().Get("errors"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 0 {
+			http.Error(w, ErrInvalidRequest.Error(), http.StatusBadRequest)
+			return
+		}
+		limit = n
+	}
+
+	st, err := ih.syncManager.IntegrationStatus(name)
+	if errors.Is(err, services.ErrIntegrationNotRegistered) {
+		http.Error(w, ErrIntegrationNotFound.Error(), http.StatusNotFound)
+		return
+	}
+
+	response := integrationStatusResponse{Status: st, Paused: ih.syncManager.Paused(name)}
+	if err != nil {
+		ih.logger.Warn("Integration reported a status error",
+			zap.String("integration", name), zap.Error(err))
+		response.StatusError = err.Error()
+	}
+	if limit > 0 {
+		response.RecentErrors = ih.syncManager.RecentFailures(name, limit)
+	} else {
+		response.RecentErrors = []services.DeliveryRecord{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// HandleTestConnection runs the connection test of the integration named by
+// the {name} path variable, such as Slack's auth.test, Jira's current-user
+// lookup or an SMTP NOOP, so operators can validate credentials after a
+// rotation without restarting the service.
+//
+// Responses:
+//   - 200 with the test's latency and diagnostics when the test passed
+//   - 502 with the same body when the provider rejected the test
+//   - 404 for an unknown integration
+//   - 409 when the integration cannot test its connection
+func (ih *IntegrationHandler) HandleTestConnection(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["name"]
+
+	result, err := ih.syncManager.TestConnection(r.Context(), name)
+	switch {
+	case errors.Is(err, services.ErrIntegrationNotRegistered):
+		http.Error(w, ErrIntegrationNotFound.Error(), http.StatusNotFound)
+		return
+	case errors.Is(err, services.ErrConnectionTestUnsupported):
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+
+	code := http.StatusOK
+	if !result.OK {
+		ih.logger.Warn("Integration connection test failed",
+			zap.String("integration", name), zap.String("error", result.Error))
+		code = http.StatusBadGateway
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(result)
+}
@@ -0,0 +1,168 @@
+package api
+
+import (
+	// go1.21 - Webhook decoding and responses
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	// go.uber.org/zap v1.24.0 - Structured logging
+	"go.uber.org/zap"
+
+	// Event bus for inbound provider activity
+	"src/backend/services/integration/internal/events"
+)
+
+// jiraWebhook is the part of a Jira webhook request the receiver reads.
+type jiraWebhook struct {
+	// Timestamp is when Jira recorded the change, in Unix milliseconds.
+	Timestamp int64 `json:"timestamp"`
+
+	// WebhookEvent is e.g. "jira:issue_updated" or "comment_created";
+	// IssueEventTypeName refines issue updates, e.g. "issue_commented".
+	WebhookEvent       string `json:"webhookEvent"`
+	IssueEventTypeName string `json:"issue_event_type_name"`
+
+	User jiraWebhookUser `json:"user"`
+
+	Issue *struct {
+		ID     string `json:"id"`
+		Key    string `json:"key"`
+		Fields struct {
+			Status struct {
+				Name string `json:"name"`
+			} `json:"status"`
+			Project struct {
+				Key string `json:"key"`
+			} `json:"project"`
+			Labels []string `json:"labels"`
+		} `json:"fields"`
+	} `json:"issue"`
+
+	Changelog *struct {
+		Items []struct {
+			Field      string `json:"field"`
+			FromString string `json:"fromString"`
+			ToString   string `json:"toString"`
+		} `json:"items"`
+	} `json:"changelog"`
+
+	Comment *struct {
+		ID     string          `json:"id"`
+		Body   string          `json:"body"`
+		Author jiraWebhookUser `json:"author"`
+	} `json:"comment"`
+}
+
+// jiraWebhookUser is a Jira user as webhooks report it.
+type jiraWebhookUser struct {
+	AccountID string `json:"accountId"`
+}
+
+// HandleJiraWebhook receives Jira issue webhooks, whose signature has already
+// been verified by webhookSignatureMiddleware, and publishes issue updates,
+// transitions and new comments on the event bus as JiraIssueEvents, so that
+// changes made in Jira can be synced back to TaskStream tasks. Other webhook
+// events are acknowledged and ignored.
+//
+// Responses:
+//   - 202 once the event is queued
+//   - 200 for events that are ignored
+//   - 400 for malformed webhooks
+//   - 503 when the event bus is full, so that Jira retries the webhook
+func (ih *IntegrationHandler) HandleJiraWebhook(w http.ResponseWriter, r *http.Request) {
+	var hook jiraWebhook
+	if err := json.NewDecoder(r.Body).Decode(&hook); err != nil || hook.WebhookEvent == "" {
+		http.Error(w, ErrInvalidRequest.Error(), http.StatusBadRequest)
+		return
+	}
+
+	eventType, event := normalizeJiraWebhook(&hook)
+	if eventType == "" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		http.Error(w, ErrInvalidRequest.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = ih.events.Publish(events.Event{
+		Source: events.SourceJira,
+		Type:   eventType,
+		// Jira Cloud repeats the identifier when it retries a delivery.
+		ID:       r.Header.Get("X-Atlassian-Webhook-Identifier"),
+		Payload:  payload,
+		Received: time.Now(),
+	})
+	if errors.Is(err, events.ErrBusFull) {
+		ih.logger.Warn("Deferred Jira webhook: event bus is full",
+			zap.String("type", eventType),
+			zap.String("issue", event.IssueKey))
+		http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// normalizeJiraWebhook converts hook to a normalized event, returning an
+// empty type for webhooks that are not published. Jira reports comments both
+// as "comment_created" and, on older servers, as an issue update of type
+// "issue_commented"; an update whose changelog changes the status is a
+// transition.
+func normalizeJiraWebhook(hook *jiraWebhook) (string, *events.JiraIssueEvent) {
+	if hook.Issue == nil || hook.Issue.Key == "" {
+		return "", nil
+	}
+
+	event := &events.JiraIssueEvent{
+		IssueKey: hook.Issue.Key,
+		IssueID:  hook.Issue.ID,
+		Project:  hook.Issue.Fields.Project.Key,
+		Status:   hook.Issue.Fields.Status.Name,
+		Labels:   hook.Issue.Fields.Labels,
+		Actor:    hook.User.AccountID,
+		Occurred: time.UnixMilli(hook.Timestamp).UTC(),
+	}
+	if hook.Timestamp == 0 {
+		event.Occurred = time.Now().UTC()
+	}
+
+	var eventType string
+	switch {
+	case hook.WebhookEvent == "comment_created",
+		hook.WebhookEvent == "jira:issue_updated" && hook.IssueEventTypeName == "issue_commented":
+		if hook.Comment == nil {
+			return "", nil
+		}
+		eventType = events.JiraIssueCommented
+		event.Comment = &events.JiraComment{
+			ID:     hook.Comment.ID,
+			Author: hook.Comment.Author.AccountID,
+			Body:   hook.Comment.Body,
+		}
+		if event.Actor == "" {
+			event.Actor = event.Comment.Author
+		}
+	case hook.WebhookEvent == "jira:issue_updated":
+		eventType = events.JiraIssueUpdated
+	default:
+		return "", nil
+	}
+
+	if hook.Changelog != nil {
+		for _, item := range hook.Changelog.Items {
+			event.Changes = append(event.Changes, events.JiraFieldChange{
+				Field: item.Field,
+				From:  item.FromString,
+				To:    item.ToString,
+			})
+			if item.Field == "status" && eventType == events.JiraIssueUpdated {
+				eventType = events.JiraIssueTransitioned
+			}
+		}
+	}
+	return eventType, event
+}
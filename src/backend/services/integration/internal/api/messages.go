@@ -0,0 +1,106 @@
+package api
+
+import (
+	// go1.21 - History encoding and query parsing
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	// go.uber.org/zap v1.24.0 - Structured logging
+	"go.uber.org/zap"
+
+	// Internal message history
+	"src/backend/services/integration/internal/services"
+)
+
+// messagesResponse is the body of HandleListMessages.
+type messagesResponse struct {
+	// Messages are the matching delivery attempts, newest first.
+	Messages []services.MessageRecord `json:"messages"`
+
+	// NextCursor fetches the following page as the "cursor" parameter; it is
+	// omitted on the last page.
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// HandleListMessages searches the persisted record of delivery attempts, so
+// support can answer whether a notification was ever delivered. Every attempt
+// is recorded with its integration, correlation ID, payload hash, outcome and
+// latency; a send retried from the store-and-forward spool has one record per
+// attempt under the same correlation ID.
+//
+// The optional query parameters "integration", "correlationId", "outcome",
+// "since" and "until" (RFC 3339) filter the records; "limit" sets the page size
+// and "cursor" continues from a previous page's nextCursor.
+//
+// Responses:
+//   - 200 with a page of records
+//   - 400 for a malformed filter, limit or cursor
+//   - 409 when the message history is not enabled
+func (ih *IntegrationHandler) HandleListMessages(w http.ResponseWriter, r *http.Request) {
+	q, err := parseHistoryQuery(r)
+	if err != nil {
+		http.Error(w, ErrInvalidRequest.Error(), http.StatusBadRequest)
+		return
+	}
+
+	page, err := ih.syncManager.QueryMessages(q)
+	switch {
+	case errors.Is(err, services.ErrHistoryDisabled):
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	case err != nil:
+		ih.logger.Error("Message history query failed", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := messagesResponse{Messages: page.Messages}
+	if page.Next > 0 {
+		response.NextCursor = strconv.FormatUint(page.Next, 10)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// parseHistoryQuery reads the message history filters from r's query string.
+func parseHistoryQuery(r *http.Request) (services.HistoryQuery, error) {
+	values := r.URL.Query()
+	q := services.HistoryQuery{
+		Integration:   values.Get("integration"),
+		CorrelationID: values.Get("correlationId"),
+		Outcome:       values.Get("outcome"),
+	}
+	switch q.Outcome {
+	case "", services.DeliveryDelivered, services.DeliveryFailed:
+	default:
+		return q, ErrInvalidRequest
+	}
+
+	var err error
+	if raw := values.Get("since"); raw != "" {
+		if q.Since, err = time.Parse(time.RFC3339, raw); err != nil {
+			return q, err
+		}
+	}
+	if raw := values.Get("until"); raw != "" {
+		if q.Until, err = time.Parse(time.RFC3339, raw); err != nil {
+			return q, err
+		}
+	}
+	if raw := values.Get("limit"); raw != "" {
+		if q.Limit, err = strconv.Atoi(raw); err != nil || q.Limit < 1 || q.Limit > services.MaxHistoryLimit {
+			return q, ErrInvalidRequest
+		}
+	}
+	if raw := values.Get("cursor"); raw != "" {
+		if q.Before, err = strconv.ParseUint(raw, 10, 64); err != nil || q.Before == 0 {
+			return q, ErrInvalidRequest
+		}
+	}
+	return q, nil
+}
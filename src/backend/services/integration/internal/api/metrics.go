@@ -0,0 +1,191 @@
+package api
+
+import (
+	// go1.21 - Request timing and status capture
+	"bufio"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	// github.com/prometheus/client_golang v1.16.0 - Counters and histograms for the API
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Outcomes recorded by Metrics.ObserveSend.
+const (
+	sendOutcomeDelivered = "delivered"
+	sendOutcomeAccepted  = "accepted"
+	sendOutcomeStored    = "stored"
+	sendOutcomeFailed    = "failed"
+)
+
+// Metrics holds the API's Prometheus instruments. It is created once in main from
+// the service registry and injected into the handler and the metrics middleware.
+// A nil *Metrics records nothing.
+type Metrics struct {
+	// registry is the service registry the instruments are registered with.
+	registry *prometheus.Registry
+
+	// sends counts send requests by integration and outcome.
+	sends *prometheus.CounterVec
+
+	// sendDuration observes how long send requests took to answer, by integration.
+	sendDuration *prometheus.HistogramVec
+
+	// requests counts HTTP requests by method and status code.
+	requests *prometheus.CounterVec
+
+	// requestDuration observes HTTP request latency by method.
+	requestDuration *prometheus.HistogramVec
+
+	// apiRequests counts versioned API requests by version, so operators can
+	// tell when callers have migrated off a deprecated version.
+	apiRequests *prometheus.CounterVec
+
+	// panics counts panics recovered while serving requests.
+	panics prometheus.Counter
+}
+
+// NewMetrics creates the API instruments and registers them with registry.
+// It fails if any of them is already registered.
+func NewMetrics(registry *prometheus.Registry) (*Metrics, error) {
+	m := &Metrics{
+		registry: registry,
+		sends: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "integration_send_requests_total",
+			Help: "Send requests by integration and outcome (delivered, accepted, stored, failed).",
+		}, []string{"integration", "outcome"}),
+		sendDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "integration_send_request_duration_seconds",
+			Help:    "Time to answer a send request, by integration.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"integration"}),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "integration_http_requests_total",
+			Help: "HTTP requests served, by method and status code.",
+		}, []string{"method", "code"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "integration_http_request_duration_seconds",
+			Help:    "HTTP request latency, by method.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method"}),
+		apiRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "integration_api_requests_total",
+			Help: "Versioned API requests, by API version.",
+		}, []string{"version"}),
+		panics: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "integration_panics_total",
+			Help: "Panics recovered while serving HTTP requests.",
+		}),
+	}
+
+	for _, c := range []prometheus.Collector{m.sends, m.sendDuration, m.requests, m.requestDuration, m.apiRequests, m.panics} {
+		if err := registry.Register(c); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// ObserveSend records the outcome and latency of a send request. Only call it
+// for registered integrations, so that the integration label stays bounded.
+func (m *Metrics) ObserveSend(integration, outcome string, elapsed time.Duration) {
+	if m == nil {
+		return
+	}
+	m.sends.WithLabelValues(integration, outcome).Inc()
+	m.sendDuration.WithLabelValues(integration).Observe(elapsed.Seconds())
+}
+
+// ObserveAPIRequest counts a request to the given API version, such as "v1".
+func (m *Metrics) ObserveAPIRequest(version string) {
+	if m == nil {
+		return
+	}
+	m.apiRequests.WithLabelValues(version).Inc()
+}
+
+// ObservePanic counts a panic recovered while serving a request.
+func (m *Metrics) ObservePanic() {
+	if m == nil {
+		return
+	}
+	m.panics.Inc()
+}
+
+// Handler serves the service registry together with the instruments other
+// packages register on the default registry.
+func (m *Metrics) Handler() http.Handler {
+	if m == nil {
+		return promhttp.Handler()
+	}
+	return promhttp.HandlerFor(
+		prometheus.Gatherers{prometheus.DefaultGatherer, m.registry},
+		promhttp.HandlerOpts{},
+	)
+}
+
+// NewMetricsMiddleware returns middleware that records the count and latency of
+// every HTTP request.
+func NewMetricsMiddleware(m *Metrics) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if m == nil {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(rec, r)
+			method := methodLabel(r.Method)
+			m.requests.WithLabelValues(method, strconv.Itoa(rec.status)).Inc()
+			m.requestDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+		})
+	}
+}
+
+// methodLabel maps non-standard methods to "OTHER" so clients cannot inflate
+// the label's cardinality.
+func methodLabel(method string) string {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut,
+		http.MethodPatch, http.MethodDelete, http.MethodOptions:
+		return method
+	}
+	return "OTHER"
+}
+
+// statusRecorder captures the status code written by the wrapped handler.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+// WriteHeader records the status code before writing it.
+func (s *statusRecorder) WriteHeader(code int) {
+	s.status = code
+	s.ResponseWriter.WriteHeader(code)
+}
+
+// Flush forwards to the underlying writer when it supports streaming.
+func (s *statusRecorder) Flush() {
+	if f, ok := s.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack forwards to the underlying writer, so WebSocket upgrades pass through.
+func (s *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := s.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response writer does not support hijacking")
+	}
+	return h.Hijack()
+}
+
+// Unwrap returns the underlying writer, for http.ResponseController.
+func (s *statusRecorder) Unwrap() http.ResponseWriter {
+	return s.ResponseWriter
+}
@@ -0,0 +1,61 @@
+package api
+
+import (
+	// go1.21 - Body reading and error classification
+	"errors"
+	"io"
+	"net/http"
+
+	// github.com/gorilla/mux v1.8.0 - Path variable lookup
+	"github.com/gorilla/mux"
+
+	// go.uber.org/zap v1.24.0 - Structured logging
+	"go.uber.org/zap"
+
+	// Internal models and services for notification routing
+	"src/backend/services/integration/internal/models"
+	"src/backend/services/integration/internal/services"
+)
+
+// HandleProviderNotification passes a notification pushed by a provider (for
+// example, SES bounces delivered by SNS) to the integration named by the
+// {integration} path variable. The adapter authenticates the notification.
+//
+// Responses:
+//   - 200 once the adapter has handled the notification
+//   - 400 for malformed or unauthenticated notifications
+//   - 404 for unknown integrations and integrations that accept no
+//     notifications, so senders cannot probe for integrations
+//   - 413 for bodies over the webhook size limit
+//   - 502 when the adapter could not complete the notification's action
+func (ih *IntegrationHandler) HandleProviderNotification(w http.ResponseWriter, r *http.Request) {
+	integrationName := mux.Vars(r)["integration"]
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookBodyBytes+1))
+	if err != nil {
+		http.Error(w, ErrInvalidRequest.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(body) > maxWebhookBodyBytes {
+		http.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	err = ih.syncManager.ReceiveNotification(r.Context(), integrationName, r.Header, body)
+	switch {
+	case err == nil:
+		w.WriteHeader(http.StatusOK)
+	case errors.Is(err, services.ErrIntegrationNotRegistered), errors.Is(err, services.ErrNotificationsUnsupported):
+		http.NotFound(w, r)
+	case errors.Is(err, models.ErrInvalidPayload):
+		ih.logger.Warn("Rejected provider notification",
+			zap.String("integration", integrationName),
+			zap.Error(err))
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+	default:
+		ih.logger.Error("Failed to handle provider notification",
+			zap.String("integration", integrationName),
+			zap.Error(err))
+		http.Error(w, http.StatusText(http.StatusBadGateway), http.StatusBadGateway)
+	}
+}
@@ -0,0 +1,657 @@
+package api
+
+import (
+	// go1.21 - Document generation, schema reflection and embedded Swagger UI
+	"embed"
+	"encoding/json"
+	"io/fs"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	// github.com/gorilla/mux v1.8.0 - Route enumeration
+	"github.com/gorilla/mux"
+
+	// Internal build metadata, models and services for documented types
+	"src/backend/services/integration/internal/buildinfo"
+	"src/backend/services/integration/internal/models"
+	"src/backend/services/integration/internal/services"
+)
+
+// Paths of the API description and its viewer.
+const (
+	openAPIPath = "/openapi.json"
+	docsPath    = "/docs/"
+)
+
+// The Swagger UI bundle and stylesheet are vendored into swagger/vendor by
+// scripts/vendor-swagger-ui.sh, so that the page loads nothing from other origins.
+//
+//go:generate ../../scripts/vendor-swagger-ui.sh swagger/vendor
+//go:embed swagger
+var swaggerAssets embed.FS
+
+// swaggerUIContentSecurityPolicy replaces the service's Content-Security-Policy
+// on the Swagger UI page, whose styles are partly inline. Everything else is
+// same-origin.
+const swaggerUIContentSecurityPolicy = "default-src 'none'; " +
+	"script-src 'self'; " +
+	"style-src 'self' 'unsafe-inline'; " +
+	"img-src 'self' data:; " +
+	"connect-src 'self'; " +
+	"frame-ancestors 'none'"
+
+// Authentication schemes named by apiOperation.Auth.
+const (
+	authBearer = "bearerAuth"
+	authBasic  = "basicAuth"
+)
+
+// apiOperation documents one route. Request and Response are zero values of
+// the types the handler decodes and encodes, from which the body schemas are
+// generated, so the document follows changes to those types.
+type apiOperation struct {
+	Summary string
+
+	// Auth is the security scheme the route requires, empty for routes
+	// authenticated by a signature or not at all.
+	Auth string
+
+	// Query maps the route's query parameters to their descriptions.
+	Query map[string]string
+
+	Request  interface{}
+	Response interface{}
+
+	// Responses maps status codes to descriptions. Response describes the body
+	// of the lowest 2xx code.
+	Responses map[int]string
+}
+
+// sendResponses are the responses of the single-integration send routes.
+var sendResponses = map[int]string{
+	http.StatusOK:                    "Delivered, with the message identifiers",
+	http.StatusAccepted:              "Queued, or stored for replay while the integration is down",
+	http.StatusBadRequest:            "Malformed request, with the failing fields when it does not match the request schema",
+	http.StatusNotFound:              "Unknown integration",
+	http.StatusConflict:              "Integration paused, or a request with the same Idempotency-Key in progress",
+	http.StatusRequestEntityTooLarge: "Body over server.maxBodyBytes",
+	http.StatusUnsupportedMediaType:  "Body is not JSON or a CloudEvent",
+	http.StatusUnprocessableEntity:   "Idempotency-Key already used with a different request",
+	http.StatusServiceUnavailable:    "Queue or bulkhead full; retry later",
+	http.StatusBadGateway:            "Integration connection failed",
+	http.StatusInternalServerError:   "Send failed",
+}
+
+// sendQuery are the query parameters of the single-integration send routes.
+var sendQuery = map[string]string{
+	"wait": "How long to wait for delivery, as a Go duration such as 2s, capped by dispatch.maxWait",
+}
+
+// apiOperations documents the routes, keyed by method and path template.
+// Registered routes without an entry are listed with their path only.
+var apiOperations = map[string]apiOperation{
+	"POST /api/v1/email/send": {
+		Summary: "Send an email", Auth: authBearer, Query: sendQuery,
+		Request: sendMessageRequest{}, Response: map[string]string{}, Responses: sendResponses,
+	},
+	"POST /api/v1/slack/post": {
+		Summary: "Post a Slack message", Auth: authBearer, Query: sendQuery,
+		Request: sendMessageRequest{}, Response: map[string]string{}, Responses: sendResponses,
+	},
+	"POST /api/v1/jira/create": {
+		Summary: "Create a Jira issue", Auth: authBearer, Query: sendQuery,
+		Request: sendMessageRequest{}, Response: map[string]string{}, Responses: sendResponses,
+	},
+	"POST /api/v1/fanout": {
+		Summary: "Send to several integrations at once", Auth: authBearer,
+		Request: fanOutRequest{}, Response: fanOutResponse{},
+		Responses: map[int]string{
+			http.StatusOK:                    "Per-target results",
+			http.StatusBadRequest:            "Malformed request, with the failing fields when it does not match the request schema, or duplicate targets",
+			http.StatusConflict:              "All-or-nothing send aborted; delivered targets reverted, or a request with the same Idempotency-Key in progress",
+			http.StatusRequestEntityTooLarge: "Body over server.maxBodyBytes",
+			http.StatusUnsupportedMediaType:  "Body is not JSON or a CloudEvent",
+			http.StatusUnprocessableEntity:   "Idempotency-Key already used with a different request",
+		},
+	},
+	"POST /api/v2/messages": {
+		Summary: "Queue a message, answered with its delivery status", Auth: authBearer,
+		Request: v2SendRequest{}, Response: services.DeliveryStatus{},
+		Responses: map[int]string{
+			http.StatusOK:                    "Delivered within the Prefer: wait time, with the SendResult",
+			http.StatusAccepted:              "Queued, in flight, or stored for replay; Location names the delivery status",
+			http.StatusBadRequest:            "Malformed request, with the failing fields when it does not match the request schema",
+			http.StatusNotFound:              "Unknown integration",
+			http.StatusNotAcceptable:         "Accept names only media types of other API versions",
+			http.StatusConflict:              "Integration paused, or a request with the same Idempotency-Key in progress",
+			http.StatusRequestEntityTooLarge: "Body over server.maxBodyBytes",
+			http.StatusUnsupportedMediaType:  "Body is not JSON",
+			http.StatusUnprocessableEntity:   "Idempotency-Key already used with a different request",
+			http.StatusServiceUnavailable:    "Queue or bulkhead full; retry later",
+			http.StatusBadGateway:            "Integration connection failed",
+			http.StatusInternalServerError:   "Send failed",
+		},
+	},
+	"GET /api/v2/deliveries/{id}": {
+		Summary: "Get the delivery status of a message queued with the v2 API", Auth: authBearer,
+		Response: services.DeliveryStatus{},
+		Responses: map[int]string{
+			http.StatusOK:            "Delivery status, with the SendResult once delivered",
+			http.StatusNotFound:      "Unknown delivery, or one too old to be remembered",
+			http.StatusNotAcceptable: "Accept names only media types of other API versions",
+		},
+	},
+	"GET /api/v1/integrations/stream": {
+		Summary: "Stream status changes and send outcomes as Server-Sent Events", Auth: authBearer,
+		Query: map[string]string{"integration": "Limit the stream to one integration"},
+		Responses: map[int]string{
+			http.StatusOK:       "text/event-stream of status and delivery events",
+			http.StatusNotFound: "Unknown integration",
+		},
+	},
+	"POST /api/v1/integrations/{name}/pause": {
+		Summary: "Pause an integration", Auth: authBearer,
+		Response: pauseResponse{},
+		Responses: map[int]string{
+			http.StatusOK:       "Integration paused",
+			http.StatusNotFound: "Unknown integration",
+		},
+	},
+	"POST /api/v1/integrations/{name}/resume": {
+		Summary: "Resume a paused integration", Auth: authBearer,
+		Response: pauseResponse{},
+		Responses: map[int]string{
+			http.StatusOK:       "Integration resumed",
+			http.StatusNotFound: "Unknown integration",
+		},
+	},
+	"GET /api/v1/breakers": {
+		Summary: "List every integration's circuit breaker", Auth: authBearer,
+		Response:  breakersResponse{},
+		Responses: map[int]string{http.StatusOK: "Breaker states and counters"},
+	},
+	"POST /api/v1/breakers/{name}/reset": {
+		Summary: "Force an integration's circuit breaker closed", Auth: authBearer,
+		Response: services.BreakerStatus{},
+		Responses: map[int]string{
+			http.StatusOK:       "Breaker state after the reset",
+			http.StatusNotFound: "Unknown integration",
+			http.StatusConflict: "Integration has no resettable breaker",
+		},
+	},
+	"GET /api/v1/messages": {
+		Summary: "Search the recorded delivery attempts, newest first", Auth: authBearer,
+		Query: map[string]string{
+			"integration":   "Only attempts through this integration",
+			"correlationId": "Only attempts at this send, e.g. a deliveryId",
+			"outcome":       "delivered or failed",
+			"since":         "Only attempts at or after this RFC 3339 time",
+			"until":         "Only attempts at or before this RFC 3339 time",
+			"limit":         "Page size, at most 500; 50 by default",
+			"cursor":        "The nextCursor of the previous page",
+		},
+		Response: messagesResponse{},
+		Responses: map[int]string{
+			http.StatusOK:         "A page of delivery attempts",
+			http.StatusBadRequest: "Malformed filter or cursor",
+			http.StatusConflict:   "Message history is not enabled",
+		},
+	},
+	"GET /api/v1/deliveries/ws": {
+		Summary: "Stream queued sends' lifecycle events over a WebSocket", Auth: authBearer,
+		Query: map[string]string{"integration": "Limit the stream to these integrations, comma-separated"},
+		Responses: map[int]string{
+			http.StatusSwitchingProtocols: "WebSocket of message events",
+			http.StatusBadRequest:         "Not a WebSocket upgrade",
+			http.StatusNotFound:           "Unknown integration",
+		},
+	},
+	"GET /api/v1/integrations/{name}/status": {
+		Summary: "Get an integration's status and recent failures", Auth: authBearer,
+		Query:    map[string]string{"errors": "Number of recent failures to return"},
+		Response: integrationStatusResponse{},
+		Responses: map[int]string{
+			http.StatusOK:         "Status and recent failures",
+			http.StatusBadRequest: "Malformed errors parameter",
+			http.StatusNotFound:   "Unknown integration",
+		},
+	},
+	"POST /api/v1/integrations/{name}/test": {
+		Summary: "Test an integration's connection", Auth: authBearer,
+		Response: services.ConnectionTestResult{},
+		Responses: map[int]string{
+			http.StatusOK:         "Test passed",
+			http.StatusNotFound:   "Unknown integration",
+			http.StatusConflict:   "Integration cannot test its connection",
+			http.StatusBadGateway: "Test failed",
+		},
+	},
+	"POST /api/v1/slack/commands": {
+		Summary:   "Receive a Slack slash command",
+		Responses: map[int]string{http.StatusOK: "Command response", http.StatusUnauthorized: "Invalid signature"},
+	},
+	"POST /api/v1/slack/interactions": {
+		Summary:   "Receive a Slack interaction",
+		Responses: map[int]string{http.StatusOK: "Interaction handled", http.StatusUnauthorized: "Invalid signature"},
+	},
+	"POST /slack/events": {
+		Summary:   "Receive Slack Events API callbacks",
+		Responses: map[int]string{http.StatusOK: "Event accepted", http.StatusUnauthorized: "Invalid signature"},
+	},
+	"POST /webhooks/jira": {
+		Summary:   "Receive Jira issue webhooks",
+		Responses: map[int]string{http.StatusAccepted: "Event queued", http.StatusUnauthorized: "Invalid signature"},
+	},
+	"POST /webhooks/{source}": {
+		Summary:   "Receive a signed webhook",
+		Responses: map[int]string{http.StatusAccepted: "Accepted", http.StatusUnauthorized: "Invalid signature"},
+	},
+	"POST /notifications/{integration}": {
+		Summary:   "Receive a provider notification, such as an SES bounce",
+		Responses: map[int]string{http.StatusOK: "Notification handled", http.StatusBadRequest: "Malformed or unauthenticated notification"},
+	},
+	"GET /health": {
+		Summary:   "Get the service and integration health report",
+		Responses: map[int]string{http.StatusOK: "Health report"},
+	},
+	"GET /health/secure": {
+		Summary: "Get the health report (operators)", Auth: authBasic,
+		Responses: map[int]string{http.StatusOK: "Health report"},
+	},
+	"GET /livez": {
+		Summary:   "Check liveness",
+		Responses: map[int]string{http.StatusOK: "Process is serving"},
+	},
+	"GET /readyz": {
+		Summary: "Check readiness", Response: services.Readiness{},
+		Responses: map[int]string{http.StatusOK: "Ready", http.StatusServiceUnavailable: "Not ready, or shutting down"},
+	},
+	"GET /startupz": {
+		Summary: "Check that startup has completed", Response: map[string]bool{},
+		Responses: map[int]string{http.StatusOK: "Started", http.StatusServiceUnavailable: "Still starting"},
+	},
+	"GET /openapi.json": {
+		Summary:   "Get this API description",
+		Responses: map[int]string{http.StatusOK: "OpenAPI 3 document"},
+	},
+	"GET /docs/": {
+		Summary:   "Browse this API description",
+		Responses: map[int]string{http.StatusOK: "Swagger UI page"},
+	},
+	"GET /version": {
+		Summary: "Get build information", Response: buildinfo.Info{},
+		Responses: map[int]string{http.StatusOK: "Build information"},
+	},
+	"POST /admin/credentials/{integration}": {
+		Summary: "Rotate an integration's credentials", Auth: authBasic, Request: models.Credentials{},
+		Responses: map[int]string{
+			http.StatusNoContent:             "Rotated",
+			http.StatusNotFound:              "Unknown integration",
+			http.StatusConflict:              "Integration cannot rotate credentials",
+			http.StatusRequestEntityTooLarge: "Body over server.maxBodyBytes",
+			http.StatusUnsupportedMediaType:  "Body is not JSON",
+			http.StatusBadGateway:            "New credentials rejected",
+		},
+	},
+	"DELETE /admin/cache/{integration}": {
+		Summary: "Drop cached provider metadata", Auth: authBasic,
+		Query:    map[string]string{"prefix": "Only drop keys with this prefix"},
+		Response: map[string]int{},
+		Responses: map[int]string{
+			http.StatusOK:       "Number of entries removed",
+			http.StatusNotFound: "Unknown integration",
+		},
+	},
+	"POST /admin/config/reload": {
+		Summary: "Reload the configuration file", Auth: authBasic,
+		Response: configReloadResponse{},
+		Responses: map[int]string{
+			http.StatusOK:                  "Applied changes and sections requiring a restart",
+			http.StatusConflict:            "Configuration cannot be reloaded",
+			http.StatusUnprocessableEntity: "File unreadable or invalid",
+		},
+	},
+	"GET /admin/log-level": {
+		Summary: "Get the log level", Auth: authBasic,
+		Response: logLevelBody{},
+		Responses: map[int]string{
+			http.StatusOK:       "Current level",
+			http.StatusConflict: "Level cannot be changed at runtime",
+		},
+	},
+	"PUT /admin/log-level": {
+		Summary: "Change the log level", Auth: authBasic, Request: logLevelBody{},
+		Response: logLevelBody{},
+		Responses: map[int]string{
+			http.StatusOK:         "New level",
+			http.StatusBadRequest: "Unknown level",
+			http.StatusConflict:   "Level cannot be changed at runtime",
+		},
+	},
+	"GET /admin/breakers": {
+		Summary: "List every integration's circuit breaker (operators)", Auth: authBasic,
+		Response:  breakersResponse{},
+		Responses: map[int]string{http.StatusOK: "Breaker states and counters"},
+	},
+	"POST /admin/breakers/{name}/reset": {
+		Summary: "Force an integration's circuit breaker closed (operators)", Auth: authBasic,
+		Response: services.BreakerStatus{},
+		Responses: map[int]string{
+			http.StatusOK:       "Breaker state after the reset",
+			http.StatusNotFound: "Unknown integration",
+			http.StatusConflict: "Integration has no resettable breaker",
+		},
+	},
+	"GET /admin/dlq": {
+		Summary: "List messages stored for replay", Auth: authBasic,
+		Query:    map[string]string{"limit": "Most messages to list, at most 1000; 100 by default"},
+		Response: storedMessagesResponse{},
+		Responses: map[int]string{
+			http.StatusOK:         "Total and oldest stored messages",
+			http.StatusBadRequest: "Malformed limit",
+			http.StatusConflict:   "Store-and-forward not enabled",
+		},
+	},
+	"POST /admin/dlq/{integration}/replay": {
+		Summary: "Replay an integration's stored messages now", Auth: authBasic,
+		Response: map[string]int{},
+		Responses: map[int]string{
+			http.StatusOK:       "Number of messages replayed",
+			http.StatusNotFound: "Unknown integration",
+			http.StatusConflict: "Store-and-forward not enabled, or integration paused",
+		},
+	},
+	"DELETE /admin/dlq/messages/{id}": {
+		Summary: "Discard a stored message without delivering it", Auth: authBasic,
+		Responses: map[int]string{
+			http.StatusNoContent: "Discarded",
+			http.StatusNotFound:  "Unknown message",
+			http.StatusConflict:  "Store-and-forward not enabled",
+		},
+	},
+}
+
+// pathVariablePattern matches a mux path variable, with its optional pattern.
+var pathVariablePattern = regexp.MustCompile(`\{([^}:]+)(?::([^}]+))?\}`)
+
+// literalPattern matches variable patterns that only admit one value, such as
+// {source:jira}, which are documented as that value.
+var literalPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// openAPIHandler serves the OpenAPI 3 description of the routes registered on
+// r. The document is generated on first request, once every route exists.
+func openAPIHandler(r *mux.Router) http.Handler {
+	var (
+		once sync.Once
+		doc  []byte
+		err  error
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		once.Do(func() {
+			var spec map[string]interface{}
+			if spec, err = buildOpenAPI(r); err == nil {
+				doc, err = json.Marshal(spec)
+			}
+		})
+		if err != nil {
+			http.Error(w, "Unable to generate API description", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Length", strconv.Itoa(len(doc)))
+		_, _ = w.Write(doc)
+	})
+}
+
+// swaggerUIHandler serves the embedded Swagger UI page, which renders
+// /openapi.json, under swaggerUIContentSecurityPolicy.
+func swaggerUIHandler() http.Handler {
+	assets, err := fs.Sub(swaggerAssets, "swagger")
+	if err != nil {
+		// The embed directive guarantees the directory exists.
+		panic(err)
+	}
+	files := http.StripPrefix(docsPath, http.FileServer(http.FS(assets)))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Security-Policy", swaggerUIContentSecurityPolicy)
+		files.ServeHTTP(w, r)
+	})
+}
+
+// buildOpenAPI describes the routes registered on r.
+func buildOpenAPI(r *mux.Router) (map[string]interface{}, error) {
+	schemas := &schemaBuilder{components: map[string]interface{}{}}
+	paths := map[string]map[string]interface{}{}
+
+	err := r.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		template, err := route.GetPathTemplate()
+		if err != nil {
+			return nil
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			// Prefix and subrouter routes match no method of their own.
+			return nil
+		}
+
+		path, params := openAPIPathOf(template)
+		for _, method := range methods {
+			if method == http.MethodHead || method == http.MethodOptions {
+				continue
+			}
+			if paths[path] == nil {
+				paths[path] = map[string]interface{}{}
+			}
+			op, documented := apiOperations[method+" "+path]
+			if !documented {
+				op = apiOperation{Responses: map[int]string{http.StatusOK: "OK"}}
+			}
+			paths[path][strings.ToLower(method)] = schemas.operation(op, params)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":   "Integration Service API",
+			"version": buildinfo.Get().Version,
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": schemas.components,
+			"securitySchemes": map[string]interface{}{
+				authBearer: map[string]interface{}{"type": "http", "scheme": "bearer"},
+				authBasic:  map[string]interface{}{"type": "http", "scheme": "basic"},
+			},
+		},
+	}, nil
+}
+
+// openAPIPathOf converts a mux path template to an OpenAPI path, returning
+// the names of its path parameters. Variables whose pattern admits a single
+// value are replaced by that value.
+func openAPIPathOf(template string) (string, []string) {
+	var params []string
+	path := pathVariablePattern.ReplaceAllStringFunc(template, func(v string) string {
+		m := pathVariablePattern.FindStringSubmatch(v)
+		if m[2] != "" && literalPattern.MatchString(m[2]) {
+			return m[2]
+		}
+		params = append(params, m[1])
+		return "{" + m[1] + "}"
+	})
+	return path, params
+}
+
+// schemaBuilder generates JSON schemas from Go types, collecting named struct
+// types as reusable components.
+type schemaBuilder struct {
+	components map[string]interface{}
+}
+
+// operation describes op for a path with the given parameters.
+func (b *schemaBuilder) operation(op apiOperation, pathParams []string) map[string]interface{} {
+	operation := map[string]interface{}{}
+	if op.Summary != "" {
+		operation["summary"] = op.Summary
+	}
+	if op.Auth != "" {
+		operation["security"] = []map[string][]string{{op.Auth: {}}}
+	}
+
+	var parameters []map[string]interface{}
+	for _, name := range pathParams {
+		parameters = append(parameters, map[string]interface{}{
+			"name": name, "in": "path", "required": true,
+			"schema": map[string]interface{}{"type": "string"},
+		})
+	}
+	query := make([]string, 0, len(op.Query))
+	for name := range op.Query {
+		query = append(query, name)
+	}
+	sort.Strings(query)
+	for _, name := range query {
+		parameters = append(parameters, map[string]interface{}{
+			"name": name, "in": "query", "description": op.Query[name],
+			"schema": map[string]interface{}{"type": "string"},
+		})
+	}
+	if len(parameters) > 0 {
+		operation["parameters"] = parameters
+	}
+
+	if op.Request != nil {
+		operation["requestBody"] = map[string]interface{}{
+			"required": true,
+			"content":  b.content(op.Request),
+		}
+	}
+
+	codes := make([]int, 0, len(op.Responses))
+	for code := range op.Responses {
+		codes = append(codes, code)
+	}
+	sort.Ints(codes)
+	responses := map[string]interface{}{}
+	bodyDescribed := false
+	for _, code := range codes {
+		response := map[string]interface{}{"description": op.Responses[code]}
+		if op.Response != nil && !bodyDescribed && code >= 200 && code < 300 {
+			response["content"] = b.content(op.Response)
+			bodyDescribed = true
+		}
+		responses[strconv.Itoa(code)] = response
+	}
+	operation["responses"] = responses
+	return operation
+}
+
+// content describes a JSON body of the type of v.
+func (b *schemaBuilder) content(v interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"application/json": map[string]interface{}{"schema": b.schema(reflect.TypeOf(v))},
+	}
+}
+
+// Types with a fixed JSON representation.
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// schema returns the JSON schema of t, as encoding/json would encode it.
+func (b *schemaBuilder) schema(t reflect.Type) map[string]interface{} {
+	switch t {
+	case timeType:
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	case rawMessageType:
+		return map[string]interface{}{}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return b.schema(t.Elem())
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]interface{}{"type": "string", "format": "byte"}
+		}
+		return map[string]interface{}{"type": "array", "items": b.schema(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": b.schema(t.Elem())}
+	case reflect.Struct:
+		name := t.Name()
+		if name == "" {
+			return b.object(t)
+		}
+		if _, seen := b.components[name]; !seen {
+			// Reserve the name first, so recursive types terminate.
+			b.components[name] = map[string]interface{}{}
+			b.components[name] = b.object(t)
+		}
+		return map[string]interface{}{"$ref": "#/components/schemas/" + name}
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+// object returns the schema of struct type t from its exported fields' JSON
+// tags. Fields without omitempty are required.
+func (b *schemaBuilder) object(t reflect.Type) map[string]interface{} {
+	properties := map[string]interface{}{}
+	var required []string
+	b.fields(t, properties, &required)
+
+	object := map[string]interface{}{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		sort.Strings(required)
+		object["required"] = required
+	}
+	return object
+}
+
+// fields adds the properties of t's fields, flattening embedded structs as
+// encoding/json does.
+func (b *schemaBuilder) fields(t reflect.Type, properties map[string]interface{}, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			b.fields(field.Type, properties, required)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		properties[name] = b.schema(field.Type)
+		if !strings.Contains(options, "omitempty") {
+			*required = append(*required, name)
+		}
+	}
+}
@@ -0,0 +1,242 @@
+package api
+
+import (
+	// go1.21 - HTTP primitives for middleware
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	// github.com/gorilla/mux v1.8.0 - Middleware type
+	"github.com/gorilla/mux"
+
+	// github.com/ulule/limiter/v3 v3.10.0 - Fixed-window limiter and its stores
+	limiter "github.com/ulule/limiter/v3"
+	middlewareLimiter "github.com/ulule/limiter/v3/drivers/middleware/stdlib"
+
+	// go.uber.org/zap v1.24.0 - Structured logging
+	"go.uber.org/zap"
+
+	// Internal authentication primitives
+	"src/backend/services/integration/internal/auth"
+
+	// Configuration for quotas and tiers
+	"src/backend/services/integration/internal/config"
+)
+
+// SetRateLimitStore sets the store keeping the API rate limit counters, such as
+// a Redis store shared by every replica so limits hold cluster-wide. It must be
+// called before the router is built.
+func (ih *IntegrationHandler) SetRateLimitStore(store limiter.Store) {
+	ih.rateLimitStore = store
+}
+
+// RateLimitStore returns the store set by SetRateLimitStore, or nil.
+func (ih *IntegrationHandler) RateLimitStore() limiter.Store {
+	return ih.rateLimitStore
+}
+
+// principalLimiter applies per-principal quotas, with tier overrides for
+// specific subjects, on top of the anonymous per-IP limit.
+type principalLimiter struct {
+	// keyBy selects the bucket key; see config.RateLimitConfig.KeyBy.
+	keyBy string
+
+	// defaultTier enforces the authenticated quota for principals without a tier.
+	defaultTier tierLimiter
+
+	// tiers maps a subject or client ID to its tier.
+	tiers map[string]tierLimiter
+}
+
+// tierLimiter pairs a tier name with the limiters enforcing its rate and, when
+// configured, its longer-window quota. Its keys carry prefix, so that tiers and
+// the anonymous limiter sharing a store count in separate buckets.
+type tierLimiter struct {
+	name   string
+	prefix string
+	rate   *limiter.Limiter
+	quota  *limiter.Limiter
+}
+
+// newPrincipalLimiter builds limiters for the authenticated quota and each tier,
+// all sharing the given store under distinct key prefixes.
+func newPrincipalLimiter(store limiter.Store, cfg *config.RateLimitConfig) *principalLimiter {
+	pl := &principalLimiter{
+		keyBy:       cfg.KeyBy,
+		defaultTier: newTierLimiter(store, "default", "principal", cfg.Authenticated, cfg.Quota),
+		tiers:       make(map[string]tierLimiter),
+	}
+	for _, tier := range cfg.Tiers {
+		quota := tier.Quota
+		if quota == nil {
+			quota = cfg.Quota
+		}
+		tl := newTierLimiter(store, tier.Name, "tier-"+tier.Name, tier.Rate, quota)
+		for _, subject := range tier.Subjects {
+			pl.tiers[subject] = tl
+		}
+	}
+	return pl
+}
+
+// newTierLimiter builds the limiters of one tier under prefix; quota may be nil.
+func newTierLimiter(store limiter.Store, name, prefix string, rate config.RateSpec, quota *config.RateSpec) tierLimiter {
+	tl := tierLimiter{
+		name:   name,
+		prefix: prefix,
+		rate:   limiter.New(store, toLimiterRate(rate)),
+	}
+	if quota != nil {
+		tl.quota = limiter.New(store, toLimiterRate(*quota))
+	}
+	return tl
+}
+
+// resolve returns the tier of a principal.
+func (pl *principalLimiter) resolve(p *auth.Principal) tierLimiter {
+	if tl, ok := pl.tiers[p.Subject]; ok {
+		return tl
+	}
+	if tl, ok := pl.tiers[p.ClientID]; ok && p.ClientID != "" {
+		return tl
+	}
+	return pl.defaultTier
+}
+
+// key returns the bucket a principal's requests count against: its OAuth2
+// client when keying by client, otherwise its subject.
+func (pl *principalLimiter) key(p *auth.Principal) string {
+	if pl.keyBy == config.RateLimitKeyClient && p.ClientID != "" {
+		return "client:" + p.ClientID
+	}
+	return p.Subject
+}
+
+// take counts a request against the tier's rate and, unless the rate is
+// exhausted, its quota, returning the tighter of the two and which it was.
+func (tl tierLimiter) take(ctx context.Context, key string) (limiter.Context, string, error) {
+	rate, err := tl.rate.Get(ctx, tl.prefix+":"+key)
+	if err != nil || rate.Reached || tl.quota == nil {
+		return rate, "rate", err
+	}
+	quota, err := tl.quota.Get(ctx, tl.prefix+"-quota:"+key)
+	if err != nil {
+		return rate, "rate", err
+	}
+	if quota.Reached || quota.Remaining < rate.Remaining {
+		return quota, "quota", nil
+	}
+	return rate, "rate", nil
+}
+
+// principalRateLimitMiddleware enforces per-principal quotas. It must run after
+// authentication; requests without a principal are left to the anonymous per-IP
+// limiter. Every response reports the tighter of the rate and quota windows in
+// X-RateLimit-* headers; exceeded ones return 429 with Retry-After.
+func principalRateLimitMiddleware(pl *principalLimiter, logger *zap.Logger) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal, ok := auth.PrincipalFromContext(r.Context())
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			tier := pl.resolve(principal)
+			key := pl.key(principal)
+			quota, window, err := tier.take(r.Context(), key)
+			if err != nil {
+				// Fail open: a limiter store failure must not take the API down.
+				logger.Error("Principal rate limiter unavailable", zap.Error(err))
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set("X-RateLimit-Limit", strconv.FormatInt(quota.Limit, 10))
+			w.Header().Set("X-RateLimit-Remaining", strconv.FormatInt(quota.Remaining, 10))
+			w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(quota.Reset, 10))
+
+			if quota.Reached {
+				w.Header().Set("Retry-After", retryAfterSeconds(quota.Reset))
+				logger.Warn("Principal rate limit exceeded",
+					zap.String("subject", principal.Subject),
+					zap.String("bucket", key),
+					zap.String("tier", tier.name),
+					zap.String("window", window),
+				)
+				http.Error(w, ErrRateLimitExceeded.Error(), http.StatusTooManyRequests)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// anonymousRateLimitMiddleware enforces the anonymous per-client-IP quota on
+// requests without a principal. On the versioned API it runs after
+// authentication, so that authenticated callers count against their
+// per-principal quota alone.
+func anonymousRateLimitMiddleware(instance *limiter.Limiter) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		limited := middlewareLimiter.NewMiddleware(instance,
+			middlewareLimiter.WithLimitReachedHandler(anonymousLimitReached),
+		).Handler(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, ok := auth.PrincipalFromContext(r.Context()); ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+			limited.ServeHTTP(w, r)
+		})
+	}
+}
+
+// exceptRouters applies mw to every route other than those of routers, which
+// apply it in their own middleware chain.
+func exceptRouters(mw mux.MiddlewareFunc, routers ...*mux.Router) mux.MiddlewareFunc {
+	own := make(map[*mux.Route]bool)
+	for _, router := range routers {
+		_ = router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+			own[route] = true
+			return nil
+		})
+	}
+	return func(next http.Handler) http.Handler {
+		wrapped := mw(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if route := mux.CurrentRoute(r); route != nil && own[route] {
+				next.ServeHTTP(w, r)
+				return
+			}
+			wrapped.ServeHTTP(w, r)
+		})
+	}
+}
+
+// anonymousLimitReached rejects a request over the per-IP quota. The limiter
+// middleware has already set the X-RateLimit-* headers; Retry-After follows
+// X-RateLimit-Reset.
+func anonymousLimitReached(w http.ResponseWriter, r *http.Request) {
+	if reset, err := strconv.ParseInt(w.Header().Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		w.Header().Set("Retry-After", retryAfterSeconds(reset))
+	}
+	http.Error(w, ErrRateLimitExceeded.Error(), http.StatusTooManyRequests)
+}
+
+// retryAfterSeconds returns the Retry-After value for a window resetting at
+// the Unix time reset: the whole seconds until then, at least one.
+func retryAfterSeconds(reset int64) string {
+	retryAfter := time.Until(time.Unix(reset, 0))
+	return strconv.Itoa(int(math.Max(1, math.Ceil(retryAfter.Seconds()))))
+}
+
+// toLimiterRate converts a configured quota to the ulule limiter representation.
+func toLimiterRate(spec config.RateSpec) limiter.Rate {
+	return limiter.Rate{
+		Period: spec.Period,
+		Limit:  spec.Limit,
+	}
+}
@@ -0,0 +1,124 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	limiter "github.com/ulule/limiter/v3"
+	memoryStore "github.com/ulule/limiter/v3/drivers/store/memory"
+	"go.uber.org/zap"
+
+	"src/backend/services/integration/internal/auth"
+	"src/backend/services/integration/internal/config"
+)
+
+// newRateLimitedRouter returns a router applying the anonymous and
+// per-principal limiters, one request a minute each, as the versioned API
+// does. The X-Test-Subject and X-Test-Client headers, when set, name the
+// principal.
+func newRateLimitedRouter(keyBy string) *mux.Router {
+	store := memoryStore.NewStore()
+	once := config.RateSpec{Limit: 1, Period: time.Minute}
+
+	r := mux.NewRouter()
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if subject := r.Header.Get("X-Test-Subject"); subject != "" {
+				r = r.WithContext(auth.WithPrincipal(r.Context(), &auth.Principal{
+					Subject:  subject,
+					ClientID: r.Header.Get("X-Test-Client"),
+					Method:   "introspection",
+				}))
+			}
+			next.ServeHTTP(w, r)
+		})
+	})
+	r.Use(
+		anonymousRateLimitMiddleware(limiter.New(store, toLimiterRate(once))),
+		principalRateLimitMiddleware(newPrincipalLimiter(store, &config.RateLimitConfig{
+			Anonymous:     once,
+			Authenticated: once,
+			KeyBy:         keyBy,
+		}), zap.NewNop()),
+	)
+	r.HandleFunc("/api/v1/messages", func(w http.ResponseWriter, r *http.Request) {})
+	return r
+}
+
+type rateLimitedRequest struct {
+	remoteAddr string
+	subject    string
+	client     string
+	want       int
+}
+
+func (tc rateLimitedRequest) serve(t *testing.T, r *mux.Router) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/messages", nil)
+	req.RemoteAddr = tc.remoteAddr
+	// Not trusted: the anonymous limiter keys by the connection's address.
+	req.Header.Set("X-Forwarded-For", "203.0.113.9")
+	if tc.subject != "" {
+		req.Header.Set("X-Test-Subject", tc.subject)
+		req.Header.Set("X-Test-Client", tc.client)
+	}
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	if rec.Code != tc.want {
+		t.Fatalf("%s %s@%s: status = %d, want %d", tc.remoteAddr, tc.subject, tc.client, rec.Code, tc.want)
+	}
+	if tc.want == http.StatusTooManyRequests && rec.Header().Get("Retry-After") == "" {
+		t.Errorf("%s %s@%s: 429 without Retry-After", tc.remoteAddr, tc.subject, tc.client)
+	}
+}
+
+func TestPrincipalRateLimitBuckets(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		keyBy    string
+		requests []rateLimitedRequest
+	}{
+		{"principals get separate buckets", config.RateLimitKeySubject, []rateLimitedRequest{
+			{"192.0.2.1:4000", "alice", "dashboard", http.StatusOK},
+			{"192.0.2.1:4000", "alice", "dashboard", http.StatusTooManyRequests},
+			{"192.0.2.1:4000", "bob", "dashboard", http.StatusOK},
+		}},
+		{"principals of one client share its bucket", config.RateLimitKeyClient, []rateLimitedRequest{
+			{"192.0.2.1:4000", "alice", "dashboard", http.StatusOK},
+			{"192.0.2.2:4000", "bob", "dashboard", http.StatusTooManyRequests},
+			{"192.0.2.1:4000", "alice", "cli", http.StatusOK},
+		}},
+		{"principals without a client fall back to their subject", config.RateLimitKeyClient, []rateLimitedRequest{
+			{"192.0.2.1:4000", "alice", "", http.StatusOK},
+			{"192.0.2.1:4000", "bob", "", http.StatusOK},
+			{"192.0.2.1:4000", "alice", "", http.StatusTooManyRequests},
+		}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := newRateLimitedRouter(tc.keyBy)
+			for _, req := range tc.requests {
+				req.serve(t, r)
+			}
+		})
+	}
+}
+
+// TestAnonymousRateLimitKeysByClientIP checks that callers without a principal
+// are bucketed by the address of their connection, and that their bucket is
+// not spent by authenticated requests from the same address.
+func TestAnonymousRateLimitKeysByClientIP(t *testing.T) {
+	r := newRateLimitedRouter(config.RateLimitKeySubject)
+	for _, req := range []rateLimitedRequest{
+		{"192.0.2.1:4000", "alice", "", http.StatusOK},
+		{"192.0.2.1:4000", "", "", http.StatusOK},
+		{"192.0.2.1:4001", "", "", http.StatusTooManyRequests},
+		{"192.0.2.2:4000", "", "", http.StatusOK},
+		{"192.0.2.1:4000", "bob", "", http.StatusOK},
+	} {
+		req.serve(t, r)
+	}
+}
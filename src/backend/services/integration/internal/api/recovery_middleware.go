@@ -0,0 +1,177 @@
+package api
+
+import (
+	// go1.21 - Panic recovery, stack capture and report delivery
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	// go.uber.org/zap v1.24.0 - Structured panic logging
+	"go.uber.org/zap"
+
+	// Internal configuration and the request ID header and context slot
+	"src/backend/services/integration/internal/config"
+	"src/backend/services/integration/internal/models"
+)
+
+// PanicReport describes a panic recovered while serving a request.
+type PanicReport struct {
+	// Panic is the value the handler panicked with.
+	Panic string `json:"panic"`
+
+	// Stack is the goroutine stack at the time of the panic.
+	Stack string `json:"stack"`
+
+	// Method and Path identify the request being served.
+	Method string `json:"method"`
+	Path   string `json:"path"`
+
+	// RequestID is the ID the caller was given in the problem response.
+	RequestID string `json:"requestId,omitempty"`
+
+	// Time is when the panic was recovered.
+	Time time.Time `json:"time"`
+}
+
+// PanicReporter forwards recovered panics to an error-reporting backend.
+// ReportPanic is called on its own goroutine and must not block for long.
+type PanicReporter interface {
+	ReportPanic(ctx context.Context, report PanicReport)
+}
+
+// SetPanicReporter sets where recovered panics are forwarded; call it before
+// NewRouter. Panics are only logged and counted when none is set.
+func (ih *IntegrationHandler) SetPanicReporter(reporter PanicReporter) {
+	ih.panicReporter = reporter
+}
+
+// PanicReporter returns the reporter set by SetPanicReporter, or nil.
+func (ih *IntegrationHandler) PanicReporter() PanicReporter {
+	return ih.panicReporter
+}
+
+// recoveryRecorder notes whether the wrapped handler started the response,
+// after which a problem response can no longer be written.
+type recoveryRecorder struct {
+	statusRecorder
+	wrote bool
+}
+
+// WriteHeader notes that the response has started before writing the header.
+func (rr *recoveryRecorder) WriteHeader(code int) {
+	rr.wrote = true
+	rr.statusRecorder.WriteHeader(code)
+}
+
+// Write notes that the response has started before writing the body.
+func (rr *recoveryRecorder) Write(b []byte) (int, error) {
+	rr.wrote = true
+	return rr.statusRecorder.ResponseWriter.Write(b)
+}
+
+// recoveryMiddleware recovers panics raised while serving a request, so that
+// one faulty handler cannot take down the server. Each panic is logged with
+// its stack and the request ID, counted on metrics and, when a reporter is
+// set, forwarded to it in the background. The caller gets a problem+json 500
+// carrying the request ID, unless the handler had already started the
+// response. http.ErrAbortHandler is re-raised, as net/http expects.
+//
+// Responses, besides the handler's own:
+//   - 500 as application/problem+json when the handler panicked
+func recoveryMiddleware(logger *zap.Logger, metrics *Metrics, reporter PanicReporter) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rec := &recoveryRecorder{statusRecorder: statusRecorder{ResponseWriter: w, status: http.StatusOK}}
+			defer func() {
+				recovered := recover()
+				if recovered == nil {
+					return
+				}
+				if recovered == http.ErrAbortHandler {
+					panic(recovered)
+				}
+
+				// The request ID middleware runs inside this one, so the ID
+				// is read back from the response header.
+				requestID := w.Header().Get(models.RequestIDHeader)
+				report := PanicReport{
+					Panic:     fmt.Sprint(recovered),
+					Stack:     string(debug.Stack()),
+					Method:    r.Method,
+					Path:      r.URL.Path,
+					RequestID: requestID,
+					Time:      time.Now().UTC(),
+				}
+				metrics.ObservePanic()
+				logger.Error("Recovered panic while serving request",
+					zap.String("panic", report.Panic),
+					zap.String("method", report.Method),
+					zap.String("path", report.Path),
+					zap.String("requestId", requestID),
+					zap.String("stack", report.Stack))
+				if reporter != nil {
+					go reporter.ReportPanic(context.Background(), report)
+				}
+
+				if rec.wrote {
+					return
+				}
+				ctx := models.WithRequestID(r.Context(), requestID)
+				writeProblem(w, r.WithContext(ctx), http.StatusInternalServerError,
+					"The server failed to handle the request; quote the request ID when reporting it.", "")
+			}()
+			next.ServeHTTP(rec, r)
+		})
+	}
+}
+
+// webhookPanicReporter POSTs each panic report as JSON to the configured URL.
+type webhookPanicReporter struct {
+	cfg    *config.ErrorReportingConfig
+	client *http.Client
+	logger *zap.Logger
+}
+
+// NewWebhookPanicReporter returns a PanicReporter that POSTs reports to
+// cfg.URL with cfg.Headers, using client. Failed reports are logged and
+// dropped; the panic itself has been logged already.
+func NewWebhookPanicReporter(cfg *config.ErrorReportingConfig, client *http.Client, logger *zap.Logger) PanicReporter {
+	return &webhookPanicReporter{cfg: cfg, client: client, logger: logger}
+}
+
+// ReportPanic implements PanicReporter.
+func (wr *webhookPanicReporter) ReportPanic(ctx context.Context, report PanicReport) {
+	ctx, cancel := context.WithTimeout(ctx, wr.cfg.Timeout)
+	defer cancel()
+
+	body, err := json.Marshal(report)
+	if err != nil {
+		wr.logger.Warn("Failed to encode panic report", zap.Error(err))
+		return
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, wr.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		wr.logger.Warn("Failed to build panic report request", zap.Error(err))
+		return
+	}
+	req.Header.Set("Content-Type", mediaTypeJSON)
+	for name, value := range wr.cfg.Headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := wr.client.Do(req)
+	if err != nil {
+		wr.logger.Warn("Failed to forward panic report",
+			zap.String("requestId", report.RequestID), zap.Error(err))
+		return
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		wr.logger.Warn("Error-reporting backend rejected panic report",
+			zap.String("requestId", report.RequestID), zap.Int("status", resp.StatusCode))
+	}
+}
@@ -0,0 +1,57 @@
+package api
+
+import (
+	// go1.21 - Request ID generation and validation
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"regexp"
+	"time"
+
+	// github.com/gorilla/mux v1.8.0 - Middleware type
+	"github.com/gorilla/mux"
+
+	// go.uber.org/zap v1.24.0 - Request-scoped logger
+	"go.uber.org/zap"
+
+	// Internal request-scoped logging and the request ID context slot
+	"src/backend/services/integration/internal/logging"
+	"src/backend/services/integration/internal/models"
+)
+
+// requestIDPattern restricts accepted request IDs to characters that are safe
+// in log fields, mail headers and Jira labels.
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// requestIDMiddleware gives every request an ID that traces it across systems.
+// A valid X-Request-ID sent by the caller is kept, so an ID issued upstream
+// carries through; otherwise, or when it is malformed, one is generated. The
+// ID is echoed in the X-Request-ID response header and carried by the request
+// context, where handlers find a logger with a "requestId" field (see
+// logging.FromContext) and sends pass it on to their adapters, which attach it
+// to Slack message metadata, Jira labels and email headers.
+func requestIDMiddleware(logger *zap.Logger) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestID := r.Header.Get(models.RequestIDHeader)
+			if !requestIDPattern.MatchString(requestID) {
+				requestID = newRequestID()
+			}
+			w.Header().Set(models.RequestIDHeader, requestID)
+
+			ctx := models.WithRequestID(r.Context(), requestID)
+			ctx = logging.WithLogger(ctx, logger.With(zap.String("requestId", requestID)))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// newRequestID returns a random 128-bit request ID in hex.
+func newRequestID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return fmt.Sprintf("req-%d", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b[:])
+}
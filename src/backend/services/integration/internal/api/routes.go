@@ -4,9 +4,6 @@ import (
 	// github.com/gorilla/mux v1.8.0
 	"github.com/gorilla/mux"
 
-	// github.com/prometheus/client_golang/prometheus/promhttp v1.14.0
-	"github.com/prometheus/client_golang/prometheus/promhttp"
-
 	// github.com/gorilla/handlers v1.5.1
 	gorillaHandlers "github.com/gorilla/handlers"
 
@@ -15,7 +12,6 @@ import (
 
 	// github.com/ulule/limiter/v3 v3.10.0
 	limiter "github.com/ulule/limiter/v3"
-	middlewareLimiter "github.com/ulule/limiter/v3/drivers/middleware/stdlib"
 	memoryStore "github.com/ulule/limiter/v3/drivers/store/memory"
 
 	// github.com/sony/gobreaker v0.5.0
@@ -23,7 +19,20 @@ import (
 
 	// Internal handlers package providing IntegrationHandler
 	handlers "src/backend/services/integration/internal/api"
+
+	// Internal configuration for optional endpoint selection
+	"src/backend/services/integration/internal/config"
+
+	// Internal authentication primitives for bearer token validation
+	"src/backend/services/integration/internal/auth"
+
+	// Internal models for the request ID header
+	"src/backend/services/integration/internal/models"
+	"errors"
+	"math"
+	"net"
 	"net/http"
+	"strconv"
 	"time"
 )
 
@@ -61,40 +70,88 @@ func tracingMiddleware(next http.Handler) http.Handler {
 }
 
 // securityHeadersMiddleware adds enterprise-grade security headers to all responses.
-// This includes enforcing no-sniff, a strict referrer policy, XSS protection, and
-// optional HSTS for secure deployments.
-func securityHeadersMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("X-Content-Type-Options", "nosniff")
-		w.Header().Set("Referrer-Policy", "strict-origin-when-cross-origin")
-		w.Header().Set("X-XSS-Protection", "1; mode=block")
-		// Additional headers like HSTS can be added here for HTTPS-only environments.
-		next.ServeHTTP(w, r)
-	})
+// This includes enforcing no-sniff, a strict referrer policy, XSS protection, a
+// configurable Content-Security-Policy, and HSTS, which is sent automatically on
+// requests served over TLS (or always, when forced for TLS-terminating proxies).
+func securityHeadersMiddleware(headersCfg *config.SecurityHeadersConfig) func(http.Handler) http.Handler {
+	// Pre-compute header values once rather than per request.
+	var hsts, csp string
+	forceHSTS := false
+	if headersCfg != nil {
+		csp = headersCfg.ContentSecurityPolicy
+		if headersCfg.HSTS != nil {
+			hsts = hstsHeaderValue(headersCfg.HSTS)
+			forceHSTS = headersCfg.HSTS.Force
+		}
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Content-Type-Options", "nosniff")
+			w.Header().Set("Referrer-Policy", "strict-origin-when-cross-origin")
+			w.Header().Set("X-XSS-Protection", "1; mode=block")
+			if csp != "" {
+				w.Header().Set("Content-Security-Policy", csp)
+			}
+			if hsts != "" && (r.TLS != nil || forceHSTS) {
+				w.Header().Set("Strict-Transport-Security", hsts)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
 }
 
-// basicAuth is a helper to protect endpoints with Basic Authentication. In real
-// production usage, credentials should be hashed, salted, and securely stored.
-// This is a placeholder for demonstration.
-func basicAuth(user, pass string, next http.HandlerFunc) http.HandlerFunc {
+// hstsHeaderValue renders the Strict-Transport-Security header value.
+func hstsHeaderValue(hstsCfg *config.HSTSConfig) string {
+	if hstsCfg.MaxAge <= 0 {
+		return ""
+	}
+	value := "max-age=" + strconv.FormatInt(int64(hstsCfg.MaxAge.Seconds()), 10)
+	if hstsCfg.IncludeSubDomains {
+		value += "; includeSubDomains"
+	}
+	if hstsCfg.Preload {
+		value += "; preload"
+	}
+	return value
+}
+
+// basicAuth protects an endpoint with HTTP Basic Authentication backed by the
+// configured credential store (bcrypt hashes, constant-time comparison, and
+// per-user/per-client lockout). On success the authenticated Principal is
+// attached to the request context so authorization middleware can use it.
+func basicAuth(store *auth.CredentialStore, next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		u, p, ok := r.BasicAuth()
-		if !ok || u != user || p != pass {
+		if !ok {
+			w.Header().Set("WWW-Authenticate", `Basic realm="integration-service"`)
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		principal, lockout, err := store.Authenticate(u, p, clientHost(r))
+		if errors.Is(err, auth.ErrTooManyAttempts) || lockout > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(lockout.Seconds()))))
+			http.Error(w, "Too many failed attempts", http.StatusTooManyRequests)
+			return
+		}
+		if err != nil {
+			w.Header().Set("WWW-Authenticate", `Basic realm="integration-service"`)
 			http.Error(w, "Unauthorized", http.StatusUnauthorized)
 			return
 		}
-		next(w, r)
+
+		next(w, r.WithContext(auth.WithPrincipal(r.Context(), principal)))
 	}
 }
 
-// withValidation is a placeholder middleware that mocks request validation
-// logic. In a real system, this could parse and validate JSON payloads, check
-// required fields, and enforce type constraints before reaching the handler.
-func withValidation(next http.HandlerFunc) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		// Example: no actual validation performed here, can be expanded as needed.
-		next.ServeHTTP(w, r)
+// clientHost returns the host part of the request's remote address.
+func clientHost(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
 	}
+	return host
 }
 
 // withResponseValidation is a placeholder middleware that, in a real scenario,
@@ -129,11 +186,32 @@ func withTimeout(duration time.Duration, next http.Handler) http.Handler {
 //  9. Registering versioned API routes
 // 10. Registering metrics and health check endpoints
 // 11. Configuring panic recovery middleware
-// 12. Returning the fully configured router
-func NewRouter(h *handlers.IntegrationHandler) *mux.Router {
+// 12. Returning the fully configured handler chain
+func NewRouter(h *handlers.IntegrationHandler) http.Handler {
 	// STEP 1: Create new mux router instance with StrictSlash set to true.
 	r := mux.NewRouter().StrictSlash(true)
 
+	// Shared store for the anonymous and per-principal rate limiters: the one
+	// configured for the service, or this replica's memory.
+	rateLimitStore := h.RateLimitStore()
+	if rateLimitStore == nil {
+		rateLimitStore = memoryStore.NewStore()
+	}
+
+	// STEP 1a: Give every request an ID, echoed in the response, that logs and
+	// the notifications it sends can be traced by.
+	r.Use(requestIDMiddleware(h.Logger()))
+
+	// STEP 1b: When client certificates are requested, expose the verified
+	// certificate identity to handlers and later middleware.
+	if serverCfg := h.Config().Server; serverCfg.TLSEnabled() && serverCfg.TLS.ClientAuth != config.ClientAuthNone {
+		r.Use(clientCertMiddleware)
+	}
+
+	// STEP 1c: Cap request bodies at server.maxBodyBytes, so that no handler
+	// buffers an oversized payload.
+	r.Use(bodyLimitMiddleware(h.Config().Server.BodyBytesLimit()))
+
 	// STEP 2: Configure CORS middleware with secure defaults.
 	// This uses the gorilla/handlers library to restrict cross-origin requests
 	// to safe methods and origins. Adjust AllowedHeaders, AllowedMethods, and
@@ -141,36 +219,41 @@ func NewRouter(h *handlers.IntegrationHandler) *mux.Router {
 	corsMiddleware := gorillaHandlers.CORS(
 		gorillaHandlers.AllowedOrigins([]string{"https://example.com"}),
 		gorillaHandlers.AllowedMethods([]string{"GET", "POST", "PUT", "DELETE"}),
-		gorillaHandlers.AllowedHeaders([]string{"Content-Type", "Authorization"}),
+		gorillaHandlers.AllowedHeaders([]string{"Content-Type", "Authorization", "Prefer", idempotencyKeyHeader, models.RequestIDHeader}),
+		gorillaHandlers.ExposedHeaders([]string{models.RequestIDHeader,
+			"X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset", "Retry-After",
+			apiVersionHeader, "Deprecation", "Sunset", "Link", "Location", "Preference-Applied"}),
 		gorillaHandlers.AllowCredentials(),
 	)
 
-	// STEP 3: Add request logging middleware with structured logging.
-	// The gorilla/handlers library provides LoggingHandler, but for advanced
-	// structured logging, you might integrate with your own logger. Here we use
-	// LoggingHandler as a baseline for demonstration.
-	loggedRouter := gorillaHandlers.LoggingHandler(
-		http.Writer(http.Stdout),
-		r,
-	)
+	// STEP 3: Add request logging middleware with structured logging. Every
+	// request gets a zap access log entry, except that successful probes and
+	// scrapes on server.accessLog.sampledPaths are sampled.
+	var accessLogCfg *config.AccessLogConfig
+	if serverCfg := h.Config().Server; serverCfg != nil {
+		accessLogCfg = serverCfg.AccessLog
+	}
+	loggedRouter := accessLogMiddleware(accessLogCfg, h.Logger())(r)
 
 	// STEP 4: Configure a dedicated path for Prometheus metrics. This is not
 	// strictly a "middleware," but a special endpoint. We attach it directly to r.
-	r.Handle("/metrics", promhttp.Handler()).Methods(http.MethodGet)
+	// The scrape endpoint is only exposed when the "prometheus" exporter is selected;
+	// OTLP-only environments push metrics instead (see telemetry.SetupMetrics).
+	// With the admin listener enabled, metrics are served there instead (see NewAdminRouter).
+	if !h.Config().Server.AdminEnabled() && h.Config().Telemetry.ExporterEnabled(config.MetricsExporterPrometheus) {
+		r.Handle("/metrics", h.Metrics().Handler()).Methods(http.MethodGet)
+	}
 
 	// STEP 5: Add request tracing middleware. We wrap the loggedRouter with our custom
 	// tracingMiddleware to ensure each request is captured in a tracing span.
 	tracedRouter := tracingMiddleware(loggedRouter)
 
 	// STEP 6: Configure rate limiting middleware using github.com/ulule/limiter/v3.
-	// We define a rate of 20 requests per minute with a small burst, for demonstration.
-	store := memoryStore.NewStore()
-	rate := limiter.Rate{
-		Period: 1 * time.Minute,
-		Limit:  20,
-	}
-	instance := limiter.New(store, rate)
-	rateLimitedRouter := middlewareLimiter.NewMiddleware(instance).Handler(tracedRouter)
+	// This is the anonymous per-client-IP quota, for requests without a
+	// principal. The versioned API applies it after authentication, so that
+	// authenticated callers are held to their per-principal quotas instead;
+	// every other route applies it at the router (see STEP 9).
+	anonymous := anonymousRateLimitMiddleware(limiter.New(rateLimitStore, toLimiterRate(h.Config().RateLimit.Anonymous)))
 
 	// STEP 7: Add circuit breaker middleware with specific settings.
 	// The circuit is named "IntegrationCB" for identification in logs/monitoring.
@@ -181,30 +264,47 @@ func NewRouter(h *handlers.IntegrationHandler) *mux.Router {
 		Timeout:     5 * time.Second,
 	}
 	cb := gobreaker.NewCircuitBreaker(cbSettings)
-	circuitBreakeredRouter := circuitBreakerMiddleware(cb)(rateLimitedRouter)
+	circuitBreakeredRouter := circuitBreakerMiddleware(cb)(tracedRouter)
 
 	// STEP 8: Configure security headers middleware to ensure XSS protection, no-sniff, etc.
-	secureHeadersRouter := securityHeadersMiddleware(circuitBreakeredRouter)
+	var headersCfg *config.SecurityHeadersConfig
+	if serverCfg := h.Config().Server; serverCfg != nil {
+		headersCfg = serverCfg.SecurityHeaders
+	}
+	secureHeadersRouter := securityHeadersMiddleware(headersCfg)(circuitBreakeredRouter)
 
 	// STEP 9: Register versioned API routes and all endpoints with their
 	// respective middlewares. This is where we call our internal function.
-	registerRoutes(r, h)
+	// Routes outside the versioned API get the anonymous quota here.
+	apiRouters := registerRoutes(r, h, rateLimitStore, anonymous)
+	r.Use(exceptRouters(anonymous, apiRouters...))
 
 	// STEP 10: Register health check endpoint (POST-step since we might sometimes do it earlier).
 	// Also demonstrate we can attach it at the top-level router, secured by some approach if desired.
 	// We place it here to align with the specification steps.
+	// /livez is answered ahead of the router by WithPing; the route documents it.
 	r.HandleFunc("/health", h.HandleHealthCheck).Methods(http.MethodGet)
-
-	// STEP 11: Configure panic recovery middleware to handle unexpected panics gracefully.
-	recoveryOpts := []gorillaHandlers.RecoveryHandlerOption{
-		gorillaHandlers.RecoveryHandlerLogger(http.Writer(http.Stdout)),
-		gorillaHandlers.RecoveryHandlerDisableStack(false),
-		gorillaHandlers.RecoveryHandlerJSON(true),
-	}
-	finalRouter := gorillaHandlers.RecoveryHandler(recoveryOpts...)(secureHeadersRouter)
-
-	// STEP 12: Return the fully configured router for production use.
-	return finalRouter.(*mux.Router)
+	r.HandleFunc(livezPath, h.HandleLiveness).Methods(http.MethodGet, http.MethodHead)
+	r.HandleFunc("/readyz", h.HandleReadiness).Methods(http.MethodGet)
+	r.HandleFunc("/startupz", h.HandleStartup).Methods(http.MethodGet)
+	r.HandleFunc("/version", h.HandleVersion).Methods(http.MethodGet)
+
+	// STEP 10a: Describe the routes registered above as OpenAPI 3, with Swagger
+	// UI to browse them. The page is served with the Swagger UI assets embedded
+	// in the binary, under its own same-origin Content-Security-Policy.
+	r.Handle(openAPIPath, openAPIHandler(r)).Methods(http.MethodGet)
+	r.PathPrefix(docsPath).Handler(swaggerUIHandler()).Methods(http.MethodGet, http.MethodHead)
+
+	// STEP 11: Configure panic recovery middleware to handle unexpected panics
+	// gracefully: the stack is logged, integration_panics_total counts it, the
+	// caller gets a problem+json 500 and the configured reporter, if any, is
+	// told about it.
+	finalRouter := recoveryMiddleware(h.Logger(), h.Metrics(), h.PanicReporter())(secureHeadersRouter)
+
+	// STEP 12: Return the fully configured handler for production use. It is
+	// the outermost middleware, not the router, so that every layer above
+	// applies to the requests the server hands it.
+	return finalRouter
 }
 
 // registerRoutes registers all API endpoints with appropriate middleware chains and validation
@@ -219,54 +319,154 @@ func NewRouter(h *handlers.IntegrationHandler) *mux.Router {
 //  8. Add response validation middleware
 //  9. Configure timeout middleware per route
 // 10. Add metrics collection per endpoint
-func registerRoutes(r *mux.Router, h *handlers.IntegrationHandler) {
-	// STEP 1: Register health check endpoint with basic auth. This demonstrates
-	// placing it on a subpath with a required user/pass. Adjust your credentials
-	// as needed for real production usage.
-	r.HandleFunc("/health/secure",
-		basicAuth("admin", "secret", h.HandleHealthCheck),
-	).Methods(http.MethodGet)
+//
+// It returns the versioned API subrouters, which apply the anonymous quota
+// themselves once the caller is authenticated.
+func registerRoutes(r *mux.Router, h *handlers.IntegrationHandler, rateLimitStore limiter.Store, anonymous mux.MiddlewareFunc) []*mux.Router {
+	// STEP 1: Register the Basic-authenticated operator endpoints, unless they are
+	// served on the separate admin listener (see NewAdminRouter).
+	if !h.Config().Server.AdminEnabled() {
+		registerOperatorRoutes(r, h)
+	}
+
+	// STEP 1b: Register inbound webhook receivers. Senders authenticate with an
+	// HMAC signature over the body rather than bearer tokens, so these routes live
+	// outside the versioned API and its authentication middleware.
+	webhooks := r.PathPrefix("/webhooks").Subrouter()
+	webhooks.Use(webhookSignatureMiddleware(h.Config().Webhooks, h.Logger()))
+	// Jira issue webhooks are normalized and published for two-way sync; the
+	// route keeps the {source} variable the signature middleware looks up.
+	webhooks.HandleFunc("/{source:jira}",
+		withContentType(http.HandlerFunc(h.HandleJiraWebhook), mediaTypeJSON),
+	).Methods(http.MethodPost)
+	webhooks.HandleFunc("/{source}",
+		withContentType(http.HandlerFunc(h.HandleInboundWebhook), mediaTypeJSON, mediaTypeForm),
+	).Methods(http.MethodPost)
+
+	// STEP 1b-i: Register the Slack Events API receiver and the slash command
+	// and interactivity endpoints when a signing secret is configured. Slack
+	// signs each request with the app's signing secret rather than sending a
+	// bearer token, so the versioned Slack endpoints are registered ahead of the
+	// authenticated /api/v1 subrouter, which would otherwise match them first.
+	if slackCfg := h.Config().Slack; slackCfg != nil && slackCfg.Inbound.Enabled() {
+		slackSigned := slackSignatureMiddleware(slackCfg.Inbound, h.Logger())
+		slackRoutes := r.PathPrefix("/slack").Subrouter()
+		slackRoutes.Use(slackSigned)
+		slackRoutes.HandleFunc("/events",
+			withContentType(http.HandlerFunc(h.HandleSlackEvents), mediaTypeJSON),
+		).Methods(http.MethodPost)
+		r.Handle("/api/v1/slack/commands",
+			slackSigned(withContentType(http.HandlerFunc(h.HandleSlackCommand), mediaTypeForm)),
+		).Methods(http.MethodPost)
+		r.Handle("/api/v1/slack/interactions",
+			slackSigned(withContentType(http.HandlerFunc(h.HandleSlackInteraction), mediaTypeForm)),
+		).Methods(http.MethodPost)
+	}
+
+	// STEP 1c: Register provider notification receivers (e.g. SES bounces via
+	// SNS). Each adapter authenticates its provider's notifications itself. SNS
+	// posts its JSON messages as text/plain.
+	r.HandleFunc("/notifications/{integration}",
+		withContentType(http.HandlerFunc(h.HandleProviderNotification), mediaTypeJSON, mediaTypeText),
+	).Methods(http.MethodPost)
 
 	// STEP 2: Configure v1 API subrouter with a version prefix. This ensures
-	// we can expand to v2 or higher without breaking old routes.
+	// we can expand to v2 or higher without breaking old routes. While callers
+	// migrate, v1 responses announce its deprecation and point to v2.
 	v1 := r.PathPrefix("/api/v1").Subrouter()
+	var v1Cfg *config.APIVersionConfig
+	if apiCfg := h.Config().API; apiCfg != nil {
+		v1Cfg = apiCfg.V1
+	}
+	v1.Use(apiVersionMiddleware(apiVersion1, h.Metrics()))
+	v1.Use(deprecationMiddleware(v1Cfg, "/api/v2"))
+
+	// STEP 2a-2c: Authenticate, authorize and apply the anonymous or
+	// per-principal quota. The middleware is shared by every API version, so
+	// that a caller's quota spans v1 and v2 while both are served.
+	versioned := newVersionedAPIMiddleware(h, rateLimitStore, anonymous)
+	v1.Use(versioned...)
+
+	// STEP 2d: Sends retried with the same Idempotency-Key, e.g. after a network
+	// timeout, get the original response instead of posting twice.
+	idempotency := newIdempotencyStore(h.Config().Idempotency)
+
+	// STEP 2e: Register the v2 API alongside v1.
+	v2 := registerV2Routes(r, h, versioned, idempotency)
 
 	// STEP 3: Register email integration endpoints with validation. We'll map
 	// them to HandleSendMessage for demonstration, but you could create a more
 	// specialized function if needed.
 	emailRoute := v1.HandleFunc("/email/send",
-		withValidation(withResponseValidation(h.HandleSendMessage)),
+		withValidation(sendMessageSchema, withResponseValidation(h.HandleSendMessage)),
 	).Methods(http.MethodPost)
 	// STEP 9: Example of applying route-level timeout from the specification:
-	emailRoute.Handler(
+	emailRoute.Handler(withContentType(withIdempotency(idempotency, h.Logger(),
 		withTimeout(10*time.Second,
-			withValidation(withResponseValidation(h.HandleSendMessage)),
+			withValidation(sendMessageSchema, withResponseValidation(h.HandleSendMessage)),
 		),
-	)
+	), mediaTypeJSON))
 
 	// STEP 4: Register Slack integration endpoints with rate limiting. For demonstration,
 	// the main router is already rate-limited, but we can apply additional route-level logic.
 	slackRoute := v1.HandleFunc("/slack/post",
-		withValidation(withResponseValidation(h.HandleSendMessage)),
+		withValidation(sendMessageSchema, withResponseValidation(h.HandleSendMessage)),
 	).Methods(http.MethodPost)
 	// Reapplying an additional rate-limiter for demonstration only.
-	slackRoute.Handler(
+	slackRoute.Handler(withContentType(withIdempotency(idempotency, h.Logger(),
 		withTimeout(10*time.Second,
-			withValidation(withResponseValidation(h.HandleSendMessage)),
+			withValidation(sendMessageSchema, withResponseValidation(h.HandleSendMessage)),
 		),
-	)
+	), mediaTypeJSON))
 
 	// STEP 5: Register Jira integration endpoints with circuit breaker. We already
 	// have a global circuit breaker, but here we show how to chain custom logic if needed.
 	// We'll use the same handler for demonstration.
 	jiraRoute := v1.HandleFunc("/jira/create",
-		withValidation(withResponseValidation(h.HandleSendMessage)),
+		withValidation(sendMessageSchema, withResponseValidation(h.HandleSendMessage)),
 	).Methods(http.MethodPost)
-	jiraRoute.Handler(
+	jiraRoute.Handler(withContentType(withIdempotency(idempotency, h.Logger(),
 		withTimeout(10*time.Second,
-			withValidation(withResponseValidation(h.HandleSendMessage)),
+			withValidation(sendMessageSchema, withResponseValidation(h.HandleSendMessage)),
 		),
-	)
+	), mediaTypeJSON))
+
+	// STEP 5a: Register fan-out sends to several integrations at once. The
+	// handler waits for every target, so it is bounded like the send routes.
+	v1.Handle("/fanout", withContentType(withIdempotency(idempotency, h.Logger(),
+		withTimeout(30*time.Second, withValidation(fanOutSchema, h.HandleFanOutSend)),
+	), mediaTypeJSON)).Methods(http.MethodPost)
+
+	// STEP 5b: Report a single integration's status and recent failures, and
+	// test its connection on demand. The stream pushes status changes and send
+	// outcomes as Server-Sent Events, and the WebSocket each queued send's
+	// lifecycle; both are long-lived, so they have no timeout.
+	v1.HandleFunc("/integrations/stream", h.HandleIntegrationStream).Methods(http.MethodGet)
+	v1.HandleFunc("/deliveries/ws", h.HandleDeliveryEvents).Methods(http.MethodGet)
+	v1.HandleFunc("/integrations/{name}/status", h.HandleIntegrationStatus).Methods(http.MethodGet)
+	v1.HandleFunc("/integrations/{name}/test",
+		withContentType(http.HandlerFunc(h.HandleTestConnection), mediaTypeJSON),
+	).Methods(http.MethodPost)
+
+	// STEP 5b-i: Pause and resume an integration, e.g. around planned provider
+	// maintenance; sends to a paused integration are rejected with 409.
+	v1.HandleFunc("/integrations/{name}/pause",
+		withContentType(http.HandlerFunc(h.HandlePauseIntegration), mediaTypeJSON),
+	).Methods(http.MethodPost)
+	v1.HandleFunc("/integrations/{name}/resume",
+		withContentType(http.HandlerFunc(h.HandleResumeIntegration), mediaTypeJSON),
+	).Methods(http.MethodPost)
+
+	// STEP 5c: Report every integration's circuit breaker and let on-call
+	// engineers force one closed after an upstream incident.
+	v1.HandleFunc("/breakers", h.HandleListBreakers).Methods(http.MethodGet)
+	v1.HandleFunc("/breakers/{name}/reset",
+		withContentType(http.HandlerFunc(h.HandleResetBreaker), mediaTypeJSON),
+	).Methods(http.MethodPost)
+
+	// STEP 5d: Search the recorded delivery attempts, so support can tell
+	// whether a notification was ever delivered.
+	v1.Handle("/messages", withTimeout(10*time.Second, http.HandlerFunc(h.HandleListMessages))).Methods(http.MethodGet)
 
 	// STEP 6: Add method-specific middleware chains. As an example, we might
 	// want dedicated middlewares for GET vs. POST. This demonstration is minimal,
@@ -284,4 +484,93 @@ func registerRoutes(r *mux.Router, h *handlers.IntegrationHandler) {
 	// ))
 	//
 	// For brevity, we've demonstrated the main approach in the NewRouter function.
-}
\ No newline at end of file
+
+	return []*mux.Router{v1, v2}
+}
+
+// newVersionedAPIMiddleware returns the middleware every version of the API
+// shares, in order:
+//  1. Validate opaque bearer tokens via OAuth2 introspection when enabled,
+//     enforcing any per-route scopes declared in configuration
+//  2. Enforce the role-based access policy once the caller is authenticated
+//  3. Apply the anonymous per-client-IP quota to requests left without a
+//     principal, and per-principal quotas (with tier overrides) to the rest
+func newVersionedAPIMiddleware(h *handlers.IntegrationHandler, rateLimitStore limiter.Store, anonymous mux.MiddlewareFunc) []mux.MiddlewareFunc {
+	var middleware []mux.MiddlewareFunc
+	authCfg := h.Config().Auth
+	if authCfg.IntrospectionEnabled() {
+		middleware = append(middleware, bearerAuthMiddleware(auth.NewIntrospector(authCfg.Introspection), authCfg, h.Logger()))
+	}
+	if authCfg.AuthorizationEnabled() {
+		middleware = append(middleware, authorizationMiddleware(auth.NewAuthorizer(authCfg.Authorization), h.Logger()))
+	}
+	return append(middleware, anonymous,
+		principalRateLimitMiddleware(newPrincipalLimiter(rateLimitStore, h.Config().RateLimit), h.Logger()))
+}
+
+// registerV2Routes registers the v2 API. Sends take typed JSON payloads and
+// are answered asynchronously with their delivery status, which can be polled;
+// errors are Problem Details. The API version is chosen by the path, and
+// clients may also ask for the versioned media type in Accept (see
+// apiVersionMiddleware). It returns the v2 subrouter.
+func registerV2Routes(r *mux.Router, h *handlers.IntegrationHandler, versioned []mux.MiddlewareFunc, idempotency *idempotencyStore) *mux.Router {
+	v2 := r.PathPrefix("/api/v2").Subrouter()
+	v2.Use(apiVersionMiddleware(apiVersion2, h.Metrics()))
+	v2.Use(versioned...)
+
+	v2.Handle("/messages", withContentType(withIdempotency(idempotency, h.Logger(),
+		withTimeout(10*time.Second, withValidation(sendMessageV2Schema, h.HandleV2SendMessage)),
+	), mediaTypeJSON)).Methods(http.MethodPost)
+	v2.HandleFunc("/deliveries/{id}", h.HandleV2Delivery).Methods(http.MethodGet)
+	return v2
+}
+
+// registerOperatorRoutes registers the endpoints operators use to inspect and
+// manage the service, protected by HTTP Basic authentication. Operator accounts
+// and their bcrypt hashes come from server.admin.auth, or from the auth.basic
+// section of the configuration when the admin listener has none of its own.
+// When the RBAC policy is enforced, each route also requires its permissions.
+func registerOperatorRoutes(r *mux.Router, h *handlers.IntegrationHandler) {
+	operator := operatorAuth(h)
+	r.HandleFunc("/health/secure",
+		operator(h.HandleHealthCheck),
+	).Methods(http.MethodGet)
+
+	// Credential rotation, protected by the same credential store as /health/secure.
+	r.HandleFunc("/admin/credentials/{integration}",
+		operator(withContentType(http.HandlerFunc(h.HandleRotateCredentials), mediaTypeJSON)),
+	).Methods(http.MethodPost)
+
+	// Operators can also drop cached provider metadata after changing the provider's
+	// configuration, e.g. DELETE /admin/cache/jira?prefix=createmeta/ENG.
+	r.HandleFunc("/admin/cache/{integration}",
+		operator(h.HandleInvalidateMetadata),
+	).Methods(http.MethodDelete)
+
+	// Runtime controls: re-read the configuration and change the log level.
+	r.HandleFunc("/admin/config/reload",
+		operator(withContentType(http.HandlerFunc(h.HandleReloadConfig), mediaTypeJSON)),
+	).Methods(http.MethodPost)
+	r.HandleFunc("/admin/log-level",
+		operator(withContentType(http.HandlerFunc(h.HandleLogLevel), mediaTypeJSON)),
+	).Methods(http.MethodGet, http.MethodPut)
+
+	// Circuit breakers, as on the API but with operator accounts.
+	r.HandleFunc("/admin/breakers",
+		operator(h.HandleListBreakers),
+	).Methods(http.MethodGet)
+	r.HandleFunc("/admin/breakers/{name}/reset",
+		operator(withContentType(http.HandlerFunc(h.HandleResetBreaker), mediaTypeJSON)),
+	).Methods(http.MethodPost)
+
+	// Messages held in the store-and-forward spool (the dead-letter queue).
+	r.HandleFunc("/admin/dlq",
+		operator(h.HandleListStored),
+	).Methods(http.MethodGet)
+	r.HandleFunc("/admin/dlq/{integration}/replay",
+		operator(withContentType(http.HandlerFunc(h.HandleReplayStored), mediaTypeJSON)),
+	).Methods(http.MethodPost)
+	r.HandleFunc("/admin/dlq/messages/{id}",
+		operator(h.HandleDiscardStored),
+	).Methods(http.MethodDelete)
+}
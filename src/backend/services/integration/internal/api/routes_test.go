@@ -0,0 +1,76 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"src/backend/services/integration/internal/auth"
+	"src/backend/services/integration/internal/config"
+)
+
+func TestBasicAuth(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("correct horse"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := auth.NewCredentialStore(&config.BasicAuthConfig{
+		Users:           []config.BasicAuthUser{{Username: "ops", PasswordHash: string(hash)}},
+		MaxFailures:     2,
+		FailureWindow:   time.Minute,
+		LockoutDuration: time.Minute,
+	})
+	var subject string
+	handler := basicAuth(store, func(w http.ResponseWriter, r *http.Request) {
+		if p, ok := auth.PrincipalFromContext(r.Context()); ok {
+			subject = p.Subject
+		}
+	})
+
+	for _, tc := range []struct {
+		name       string
+		remoteAddr string
+		username   string
+		password   string
+		want       int
+	}{
+		{"no credentials", "192.0.2.1:4000", "", "", http.StatusUnauthorized},
+		{"unknown user", "192.0.2.2:4000", "root", "correct horse", http.StatusUnauthorized},
+		{"valid credentials", "192.0.2.3:4000", "ops", "correct horse", http.StatusOK},
+		{"wrong password", "192.0.2.4:4000", "ops", "wrong", http.StatusUnauthorized},
+		{"wrong password again locks out", "192.0.2.4:4001", "ops", "wrong", http.StatusTooManyRequests},
+		{"valid credentials while locked out", "192.0.2.4:4002", "ops", "correct horse", http.StatusTooManyRequests},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			subject = ""
+			req := httptest.NewRequest(http.MethodGet, "/health/secure", nil)
+			req.RemoteAddr = tc.remoteAddr
+			if tc.username != "" {
+				req.SetBasicAuth(tc.username, tc.password)
+			}
+			rec := httptest.NewRecorder()
+			handler(rec, req)
+
+			if rec.Code != tc.want {
+				t.Fatalf("status = %d, want %d", rec.Code, tc.want)
+			}
+			switch tc.want {
+			case http.StatusOK:
+				if subject != tc.username {
+					t.Errorf("principal subject = %q, want %q", subject, tc.username)
+				}
+			case http.StatusUnauthorized:
+				if rec.Header().Get("WWW-Authenticate") == "" {
+					t.Error("missing WWW-Authenticate challenge")
+				}
+			case http.StatusTooManyRequests:
+				if rec.Header().Get("Retry-After") != "60" {
+					t.Errorf("Retry-After = %q, want %q", rec.Header().Get("Retry-After"), "60")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package api
+
+import (
+	// go1.21 - Handler registry and payload decoding
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	// v0.12.3 - Slash command and interaction payload types
+	"github.com/slack-go/slack"
+
+	// go.uber.org/zap v1.24.0 - Structured logging
+	"go.uber.org/zap"
+)
+
+// SlackCommandHandler handles a slash command. The returned message, if any,
+// is shown to the user who ran the command; Slack expects it within three
+// seconds, so longer work should reply later through cmd.ResponseURL.
+type SlackCommandHandler func(ctx context.Context, cmd slack.SlashCommand) (*slack.Msg, error)
+
+// SlackInteractionHandler handles an interaction, such as a button press or a
+// modal submission. A non-nil result is returned to Slack as the JSON
+// response, e.g. a view_submission response_action.
+type SlackInteractionHandler func(ctx context.Context, callback slack.InteractionCallback) (interface{}, error)
+
+// SlackApp routes slash commands and interactions to the handlers the service
+// registers, so that workflows such as approving a task can be driven from
+// Slack. Commands are keyed by name, e.g. "/task"; interactions by action ID
+// for block actions and by callback ID for shortcuts and modals.
+type SlackApp struct {
+	mu           sync.RWMutex
+	commands     map[string]SlackCommandHandler
+	interactions map[string]SlackInteractionHandler
+}
+
+// NewSlackApp creates an app with no handlers.
+func NewSlackApp() *SlackApp {
+	return &SlackApp{
+		commands:     make(map[string]SlackCommandHandler),
+		interactions: make(map[string]SlackInteractionHandler),
+	}
+}
+
+// HandleCommand registers handler for the slash command name, replacing any
+// earlier handler.
+func (a *SlackApp) HandleCommand(name string, handler SlackCommandHandler) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.commands[name] = handler
+}
+
+// HandleInteraction registers handler for the action or callback ID, replacing
+// any earlier handler.
+func (a *SlackApp) HandleInteraction(id string, handler SlackInteractionHandler) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.interactions[id] = handler
+}
+
+// command returns the handler for the named command, or nil.
+func (a *SlackApp) command(name string) SlackCommandHandler {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.commands[name]
+}
+
+// interaction returns the handler for callback, or nil, with the ID it was
+// looked up by.
+func (a *SlackApp) interaction(callback *slack.InteractionCallback) (SlackInteractionHandler, string) {
+	id := callback.CallbackID
+	switch callback.Type {
+	case slack.InteractionTypeBlockActions:
+		if actions := callback.ActionCallback.BlockActions; len(actions) > 0 {
+			id = actions[0].ActionID
+		}
+	case slack.InteractionTypeViewSubmission, slack.InteractionTypeViewClosed:
+		id = callback.View.CallbackID
+	}
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.interactions[id], id
+}
+
+// HandleSlackCommand receives slash commands, whose signature has already been
+// verified by slackSignatureMiddleware, and passes them to the registered
+// handler. Failures are reported to the user as an ephemeral message, since
+// Slack shows only a generic error for other responses.
+//
+// Responses:
+//   - 200 with the handler's message, or an ephemeral error message
+//   - 400 for malformed commands
+func (ih *IntegrationHandler) HandleSlackCommand(w http.ResponseWriter, r *http.Request) {
+	cmd, err := slack.SlashCommandParse(r)
+	if err != nil || cmd.Command == "" {
+		http.Error(w, ErrInvalidRequest.Error(), http.StatusBadRequest)
+		return
+	}
+
+	handler := ih.slackApp.command(cmd.Command)
+	if handler == nil {
+		writeSlackMessage(w, &slack.Msg{
+			ResponseType: slack.ResponseTypeEphemeral,
+			Text:         "Sorry, " + cmd.Command + " is not supported.",
+		})
+		return
+	}
+
+	msg, err := handler(r.Context(), cmd)
+	if err != nil {
+		ih.logger.Error("Slack command failed",
+			zap.String("command", cmd.Command),
+			zap.String("team", cmd.TeamID),
+			zap.String("user", cmd.UserID),
+			zap.Error(err))
+		writeSlackMessage(w, &slack.Msg{
+			ResponseType: slack.ResponseTypeEphemeral,
+			Text:         "Sorry, " + cmd.Command + " failed. Please try again.",
+		})
+		return
+	}
+	if msg == nil {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	writeSlackMessage(w, msg)
+}
+
+// HandleSlackInteraction receives interaction payloads, whose signature has
+// already been verified by slackSignatureMiddleware, and passes them to the
+// handler registered for their action or callback ID.
+//
+// Responses:
+//   - 200 with the handler's result, if any; interactions without a handler
+//     are acknowledged and ignored
+//   - 400 for malformed payloads
+//   - 500 when the handler fails, which Slack reports to the user
+func (ih *IntegrationHandler) HandleSlackInteraction(w http.ResponseWriter, r *http.Request) {
+	var callback slack.InteractionCallback
+	if err := json.Unmarshal([]byte(r.PostFormValue("payload")), &callback); err != nil || callback.Type == "" {
+		http.Error(w, ErrInvalidRequest.Error(), http.StatusBadRequest)
+		return
+	}
+
+	handler, id := ih.slackApp.interaction(&callback)
+	if handler == nil {
+		ih.logger.Debug("Ignored Slack interaction without a handler",
+			zap.String("type", string(callback.Type)),
+			zap.String("id", id))
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	result, err := handler(r.Context(), callback)
+	if err != nil {
+		ih.logger.Error("Slack interaction failed",
+			zap.String("type", string(callback.Type)),
+			zap.String("id", id),
+			zap.String("user", callback.User.ID),
+			zap.Error(err))
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	if result == nil {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(result)
+}
+
+// writeSlackMessage writes msg as a slash command response.
+func writeSlackMessage(w http.ResponseWriter, msg *slack.Msg) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(msg)
+}
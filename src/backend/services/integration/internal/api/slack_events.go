@@ -0,0 +1,98 @@
+package api
+
+import (
+	// go1.21 - Callback decoding and responses
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	// go.uber.org/zap v1.24.0 - Structured logging
+	"go.uber.org/zap"
+
+	// Event bus for inbound provider activity
+	"src/backend/services/integration/internal/events"
+)
+
+// slackPublishedEvents are the Events API event types published on the event
+// bus; Slack only sends the types the app subscribes to, and others are
+// acknowledged and ignored.
+var slackPublishedEvents = map[string]bool{
+	"message":          true,
+	"reaction_added":   true,
+	"reaction_removed": true,
+}
+
+// slackEventCallback is the envelope of an Events API request.
+type slackEventCallback struct {
+	// Type is "url_verification" for the endpoint check, "event_callback" for
+	// events and "app_rate_limited" when Slack is withholding events.
+	Type      string          `json:"type"`
+	Challenge string          `json:"challenge"`
+	TeamID    string          `json:"team_id"`
+	EventID   string          `json:"event_id"`
+	Event     json.RawMessage `json:"event"`
+}
+
+// HandleSlackEvents receives Slack Events API callbacks, whose signature has
+// already been verified by slackSignatureMiddleware. It answers Slack's URL
+// verification challenge and publishes message and reaction events on the
+// event bus for the service to react to.
+//
+// Responses:
+//   - 200 with the challenge for URL verification
+//   - 200 once the event is queued, or for events that are ignored
+//   - 400 for malformed callbacks
+//   - 503 when the event bus is full, so that Slack retries the event
+func (ih *IntegrationHandler) HandleSlackEvents(w http.ResponseWriter, r *http.Request) {
+	var callback slackEventCallback
+	if err := json.NewDecoder(r.Body).Decode(&callback); err != nil {
+		http.Error(w, ErrInvalidRequest.Error(), http.StatusBadRequest)
+		return
+	}
+
+	switch callback.Type {
+	case "url_verification":
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]string{"challenge": callback.Challenge})
+		return
+	case "event_callback":
+	case "app_rate_limited":
+		ih.logger.Warn("Slack is withholding events from the app",
+			zap.String("team", callback.TeamID))
+		w.WriteHeader(http.StatusOK)
+		return
+	default:
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	var inner struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(callback.Event, &inner); err != nil || inner.Type == "" {
+		http.Error(w, ErrInvalidRequest.Error(), http.StatusBadRequest)
+		return
+	}
+	if !slackPublishedEvents[inner.Type] {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	err := ih.events.Publish(events.Event{
+		Source:    "slack",
+		Type:      inner.Type,
+		ID:        callback.EventID,
+		Workspace: callback.TeamID,
+		Payload:   callback.Event,
+		Received:  time.Now(),
+	})
+	if errors.Is(err, events.ErrBusFull) {
+		ih.logger.Warn("Deferred Slack event: event bus is full",
+			zap.String("type", inner.Type),
+			zap.String("eventId", callback.EventID))
+		http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
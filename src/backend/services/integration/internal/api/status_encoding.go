@@ -0,0 +1,167 @@
+package api
+
+import (
+	// go1.21 - Response writing and buffer pooling
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+
+	// Internal models providing the allocation-free status encoder
+	"src/backend/services/integration/internal/models"
+
+	// Internal build information included in the report
+	"src/backend/services/integration/internal/buildinfo"
+
+	// Internal dependency health included in the report
+	"src/backend/services/integration/internal/services"
+)
+
+// Buffer sizing for pooled response encoding.
+const (
+	// responseBufferSize is the initial capacity of pooled buffers, enough for a
+	// health report covering a handful of integrations.
+	responseBufferSize = 4 << 10
+
+	// maxPooledBufferSize keeps an occasional oversized report from pinning a
+	// large buffer in the pool.
+	maxPooledBufferSize = 64 << 10
+)
+
+// responseBufferPool recycles encoding buffers across status and health
+// requests, which dashboards poll continuously.
+var responseBufferPool = sync.Pool{
+	New: func() interface{} {
+		buf := make([]byte, 0, responseBufferSize)
+		return &buf
+	},
+}
+
+// healthReport is the document returned by the health check endpoint.
+type healthReport struct {
+	Service       string
+	Timestamp     string
+	Cached        bool
+	Dependencies  map[string]services.DependencyHealth
+	Integrations  map[string]models.IntegrationStatus
+	OverallStatus string
+	Build         buildinfo.Info
+}
+
+// appendJSON appends the report as JSON, with dependencies and integrations in
+// name order.
+func (h *healthReport) appendJSON(dst []byte) ([]byte, error) {
+	dst = append(dst, `{"service":`...)
+	dst = models.AppendJSONString(dst, h.Service)
+	dst = append(dst, `,"timestamp":`...)
+	dst = models.AppendJSONString(dst, h.Timestamp)
+	dst = append(dst, `,"cached":`...)
+	dst = strconv.AppendBool(dst, h.Cached)
+	dst = append(dst, `,"dependencies":`...)
+	dst = appendDependencyMap(dst, h.Dependencies)
+	dst = append(dst, `,"integrations":`...)
+	var err error
+	if dst, err = appendStatusMap(dst, h.Integrations); err != nil {
+		return dst, err
+	}
+	dst = append(dst, `,"overallStatus":`...)
+	dst = models.AppendJSONString(dst, h.OverallStatus)
+	dst = append(dst, `,"build":`...)
+	dst = appendBuildInfo(dst, &h.Build)
+	return append(dst, '}'), nil
+}
+
+// appendBuildInfo appends build information as a JSON object.
+func appendBuildInfo(dst []byte, b *buildinfo.Info) []byte {
+	dst = append(dst, `{"version":`...)
+	dst = models.AppendJSONString(dst, b.Version)
+	dst = append(dst, `,"commit":`...)
+	dst = models.AppendJSONString(dst, b.Commit)
+	dst = append(dst, `,"buildTime":`...)
+	dst = models.AppendJSONString(dst, b.BuildTime)
+	dst = append(dst, `,"goVersion":`...)
+	dst = models.AppendJSONString(dst, b.GoVersion)
+	dst = append(dst, `,"platform":`...)
+	dst = models.AppendJSONString(dst, b.Platform)
+	return append(dst, '}')
+}
+
+// appendDependencyMap appends a name -> dependency health map as a JSON object
+// with sorted keys.
+func appendDependencyMap(dst []byte, deps map[string]services.DependencyHealth) []byte {
+	names := make([]string, 0, len(deps))
+	for name := range deps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	dst = append(dst, '{')
+	for n, name := range names {
+		if n > 0 {
+			dst = append(dst, ',')
+		}
+		dep := deps[name]
+		dst = models.AppendJSONString(dst, name)
+		dst = append(dst, `:{"healthy":`...)
+		dst = strconv.AppendBool(dst, dep.Healthy)
+		dst = append(dst, `,"latencyMs":`...)
+		dst = strconv.AppendInt(dst, dep.LatencyMs, 10)
+		if dep.Error != "" {
+			dst = append(dst, `,"error":`...)
+			dst = models.AppendJSONString(dst, dep.Error)
+		}
+		dst = append(dst, '}')
+	}
+	return append(dst, '}')
+}
+
+// appendStatusMap appends a name -> status map as a JSON object with sorted keys.
+func appendStatusMap(dst []byte, statuses map[string]models.IntegrationStatus) ([]byte, error) {
+	if statuses == nil {
+		return append(dst, "null"...), nil
+	}
+	names := make([]string, 0, len(statuses))
+	for name := range statuses {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var err error
+	dst = append(dst, '{')
+	for n, name := range names {
+		if n > 0 {
+			dst = append(dst, ',')
+		}
+		dst = models.AppendJSONString(dst, name)
+		dst = append(dst, ':')
+		if dst, err = statuses[name].AppendJSON(dst); err != nil {
+			return dst, err
+		}
+	}
+	return append(dst, '}'), nil
+}
+
+// writePooledJSON encodes a document with appendFn into a pooled buffer and
+// writes it with the given status code and an exact Content-Length. Nothing is
+// written if encoding fails, so the caller can still send an error response;
+// write errors (client disconnects) are not reported.
+func writePooledJSON(w http.ResponseWriter, status int, appendFn func([]byte) ([]byte, error)) error {
+	bufPtr := responseBufferPool.Get().(*[]byte)
+	buf, err := appendFn((*bufPtr)[:0])
+	defer func() {
+		if cap(buf) <= maxPooledBufferSize {
+			*bufPtr = buf[:0]
+			responseBufferPool.Put(bufPtr)
+		}
+	}()
+	if err != nil {
+		return err
+	}
+
+	buf = append(buf, '\n')
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Length", strconv.Itoa(len(buf)))
+	w.WriteHeader(status)
+	_, _ = w.Write(buf)
+	return nil
+}
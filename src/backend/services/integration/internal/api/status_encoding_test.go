@@ -0,0 +1,147 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"src/backend/services/integration/internal/buildinfo"
+	"src/backend/services/integration/internal/models"
+	"src/backend/services/integration/internal/services"
+)
+
+// discardResponseWriter is a ResponseWriter that drops the body, so benchmarks
+// measure encoding rather than a recorder's buffering.
+type discardResponseWriter struct {
+	header http.Header
+}
+
+func (d *discardResponseWriter) Header() http.Header         { return d.header }
+func (d *discardResponseWriter) Write(b []byte) (int, error) { return len(b), nil }
+func (d *discardResponseWriter) WriteHeader(int)             {}
+
+// benchHealthReport returns a report shaped like a dashboard poll of n
+// integrations.
+func benchHealthReport(n int) *healthReport {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	report := &healthReport{
+		Service:       "Integration Service",
+		Timestamp:     now.Format(time.RFC3339),
+		Dependencies:  make(map[string]services.DependencyHealth, n+1),
+		Integrations:  make(map[string]models.IntegrationStatus, n),
+		OverallStatus: "Healthy",
+		Build:         buildinfo.Info{Version: "1.4.2", Commit: "8a79f10", GoVersion: "go1.21.6", Platform: "linux/amd64"},
+	}
+	report.Dependencies["spool"] = services.DependencyHealth{Healthy: true, LatencyMs: 1}
+	for i := 0; i < n; i++ {
+		name := "integration-" + strconv.Itoa(i)
+		report.Dependencies[name] = services.DependencyHealth{Healthy: true, LatencyMs: int64(20 + i)}
+		report.Integrations[name] = models.IntegrationStatus{
+			Connected:   true,
+			Name:        name,
+			Type:        "chat",
+			LastSync:    now.Add(-time.Duration(i) * time.Second),
+			ErrorCount:  i,
+			SuccessRate: 0.995,
+			Metadata: map[string]interface{}{
+				"workspace": "acme",
+				"pending":   i * 3,
+				"circuit":   map[string]interface{}{"state": "closed", "failures": 0},
+			},
+		}
+	}
+	return report
+}
+
+// encodingJSONHealthReport mirrors healthReport with struct tags, the way the
+// report was encoded before pooled encoding.
+type encodingJSONHealthReport struct {
+	Service       string                               `json:"service"`
+	Timestamp     string                               `json:"timestamp"`
+	Cached        bool                                 `json:"cached"`
+	Dependencies  map[string]services.DependencyHealth `json:"dependencies"`
+	Integrations  map[string]models.IntegrationStatus  `json:"integrations"`
+	OverallStatus string                               `json:"overallStatus"`
+	Build         buildinfo.Info                       `json:"build"`
+}
+
+func (h *healthReport) encodingJSON() *encodingJSONHealthReport {
+	return &encodingJSONHealthReport{
+		Service:       h.Service,
+		Timestamp:     h.Timestamp,
+		Cached:        h.Cached,
+		Dependencies:  h.Dependencies,
+		Integrations:  h.Integrations,
+		OverallStatus: h.OverallStatus,
+		Build:         h.Build,
+	}
+}
+
+func TestHealthReportAppendJSONMatchesEncodingJSON(t *testing.T) {
+	report := benchHealthReport(3)
+	pooled, err := report.appendJSON(nil)
+	if err != nil {
+		t.Fatalf("appendJSON: %v", err)
+	}
+	reference, err := json.Marshal(report.encodingJSON())
+	if err != nil {
+		t.Fatalf("json.Marshal: %v", err)
+	}
+
+	var got, want interface{}
+	if err := json.Unmarshal(pooled, &got); err != nil {
+		t.Fatalf("appendJSON produced invalid JSON: %v\n%s", err, pooled)
+	}
+	if err := json.Unmarshal(reference, &want); err != nil {
+		t.Fatalf("decode reference: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("appendJSON document differs from encoding/json:\n got %s\nwant %s", pooled, reference)
+	}
+}
+
+// BenchmarkHealthReportPooled measures the health endpoint's encoding into
+// pooled buffers.
+func BenchmarkHealthReportPooled(b *testing.B) {
+	report := benchHealthReport(8)
+	w := &discardResponseWriter{header: make(http.Header)}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := writePooledJSON(w, http.StatusOK, report.appendJSON); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkHealthReportEncodingJSON is the encoding/json baseline the pooled
+// encoder replaced.
+func BenchmarkHealthReportEncodingJSON(b *testing.B) {
+	report := benchHealthReport(8).encodingJSON()
+	w := &discardResponseWriter{header: make(http.Header)}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(report); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkIntegrationStatusMarshalJSON measures one status, as served by the
+// status endpoint.
+func BenchmarkIntegrationStatusMarshalJSON(b *testing.B) {
+	status := benchHealthReport(1).Integrations["integration-0"]
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := status.MarshalJSON(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
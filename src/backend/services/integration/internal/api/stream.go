@@ -0,0 +1,105 @@
+package api
+
+import (
+	// go1.21 - Event encoding and streaming responses
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	// Internal live status and delivery events
+	"src/backend/services/integration/internal/services"
+)
+
+// streamKeepAlive is how often an idle stream sends a comment, so that proxies
+// and load balancers do not close it.
+const streamKeepAlive = 15 * time.Second
+
+// streamRetryMs is the reconnection delay suggested to clients.
+const streamRetryMs = 3000
+
+// HandleIntegrationStream pushes integration status changes and send outcomes
+// as Server-Sent Events, so dashboards can show live health without polling
+// /health. The stream opens with each integration's last known status. Events
+// are named "status" or "delivery", carry a services.StreamEvent as JSON data,
+// and are numbered by their id. The optional "integration" query parameter
+// limits the stream to one integration.
+//
+// Status changes are noticed when statuses are checked, by the health monitor
+// or a health request, so they arrive at most one check interval late. A client
+// too slow to keep up misses events rather than holding up the service.
+//
+// Responses:
+//   - 200 with a text/event-stream that lasts until the client disconnects
+//   - 404 for an unknown integration
+//   - 500 when the connection cannot stream
+func (ih *IntegrationHandler) HandleIntegrationStream(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+	filter := r.URL.Query().Get("integration")
+	if filter != "" {
+		if _, err := ih.syncManager.GetIntegration(filter); err != nil {
+			http.Error(w, ErrIntegrationNotFound.Error(), http.StatusNotFound)
+			return
+		}
+	}
+
+	// The server's write timeout would end the stream; lift it for this response.
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+
+	sub, snapshot := ih.syncManager.SubscribeStream()
+	defer sub.Close()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, "retry: %d\n\n", streamRetryMs)
+	for _, event := range snapshot {
+		if filter != "" && event.Integration != filter {
+			continue
+		}
+		if err := writeStreamEvent(w, event); err != nil {
+			return
+		}
+	}
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(streamKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event, ok := <-sub.Events():
+			if !ok {
+				return
+			}
+			if filter != "" && event.Integration != filter {
+				continue
+			}
+			if err := writeStreamEvent(w, event); err != nil {
+				return
+			}
+		case <-keepAlive.C:
+			if _, err := io.WriteString(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+// writeStreamEvent writes event as one Server-Sent Event.
+func writeStreamEvent(w io.Writer, event services.StreamEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.Seq, event.Type, data)
+	return err
+}
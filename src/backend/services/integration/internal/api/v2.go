@@ -0,0 +1,334 @@
+package api
+
+import (
+	// go1.21 - Request decoding, version negotiation and responses
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	// github.com/gorilla/mux v1.8.0 - Middleware type and path variables
+	"github.com/gorilla/mux"
+
+	// go.uber.org/zap v1.24.0 - Structured logging
+	"go.uber.org/zap"
+
+	// Internal configuration, models and services
+	"src/backend/services/integration/internal/config"
+	"src/backend/services/integration/internal/logging"
+	"src/backend/services/integration/internal/models"
+	"src/backend/services/integration/internal/services"
+)
+
+// API versions, as reported in the API-Version header and the request metrics.
+const (
+	apiVersion1 = "v1"
+	apiVersion2 = "v2"
+)
+
+const (
+	// apiVersionHeader names the API version that served the response.
+	apiVersionHeader = "API-Version"
+
+	// mediaTypeVendorPrefix starts the versioned media types, such as
+	// application/vnd.taskstream.v2+json, that clients may name in Accept.
+	mediaTypeVendorPrefix = "application/vnd.taskstream."
+
+	// mediaTypeV2 is the versioned media type of /api/v2 bodies.
+	mediaTypeV2 = mediaTypeVendorPrefix + apiVersion2 + "+json"
+
+	// mediaTypeProblem is the media type of v2 error responses (RFC 9457).
+	mediaTypeProblem = "application/problem+json"
+
+	// v2DeliveriesPath is the path of a v2 send's status, by delivery ID.
+	v2DeliveriesPath = "/api/v2/deliveries/"
+)
+
+// v2SendRequest defines the request body for HandleV2SendMessage. The payload
+// is any JSON value, handed to the integration as decoded, so that adapters
+// accepting structured payloads, such as Slack blocks or Jira fields, can be
+// sent them directly.
+type v2SendRequest struct {
+	Integration string          `json:"integration"`
+	Priority    string          `json:"priority,omitempty"`
+	Payload     json.RawMessage `json:"payload"`
+}
+
+// problem is a v2 error response, in the Problem Details format of RFC 9457.
+type problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+
+	// RequestID is the ID of the request, for support.
+	RequestID string `json:"requestId,omitempty"`
+
+	// DeliveryID is the send the problem concerns, when it was queued.
+	DeliveryID string `json:"deliveryId,omitempty"`
+}
+
+// HandleV2SendMessage queues a send and, unlike HandleSendMessage, answers
+// asynchronously by default: the response is the send's delivery status,
+// which the Location header points to for polling. Callers that want the
+// outcome may send "Prefer: wait=N" to wait up to N seconds, capped at the
+// configured dispatch maxWait; "Preference-Applied" confirms the wait.
+//
+// Responses:
+//   - 200 with the delivery status and the SendResult once delivered
+//   - 202 with the delivery status while queued, in flight, or stored for replay
+//   - 400 for a malformed request
+//   - 404 for an unknown integration
+//   - 409 for a paused integration
+//   - 413 for a body over the size limit
+//   - 502/503/500 when the send failed; errors are problem+json bodies
+func (ih *IntegrationHandler) HandleV2SendMessage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	start := time.Now()
+	logger := logging.FromContext(ctx, ih.logger)
+
+	var req v2SendRequest
+	if err := decodeJSON(r.Body, &req); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeProblem(w, r, http.StatusRequestEntityTooLarge, "", "")
+			return
+		}
+		writeProblem(w, r, http.StatusBadRequest, ErrInvalidRequest.Error(), "")
+		return
+	}
+	var payload interface{}
+	name := strings.TrimSpace(req.Integration)
+	if name == "" || json.Unmarshal(req.Payload, &payload) != nil || payload == nil {
+		writeProblem(w, r, http.StatusBadRequest, ErrInvalidRequest.Error(), "")
+		return
+	}
+	priority, err := services.ParsePriority(req.Priority)
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, err.Error(), "")
+		return
+	}
+	wait, waitRequested := ih.preferredWait(r)
+
+	ticket, err := ih.syncManager.DispatchPriority(ctx, name, priority, payload)
+	if err != nil {
+		logger.Error("Failed to queue message", zap.String("integration", name), zap.Error(err))
+		if !errors.Is(err, services.ErrIntegrationNotRegistered) {
+			ih.metrics.ObserveSend(name, sendOutcomeFailed, time.Since(start))
+		}
+		code, message, retry := sendErrorStatus(err)
+		if retry {
+			w.Header().Set("Retry-After", "1")
+		}
+		writeProblem(w, r, code, message, "")
+		return
+	}
+
+	if waitRequested {
+		w.Header().Set("Preference-Applied", "wait="+strconv.Itoa(int(wait.Seconds())))
+		waitCtx, cancel := context.WithTimeout(ctx, wait)
+		_ = ticket.Wait(waitCtx)
+		cancel()
+	}
+
+	status, err := ih.syncManager.DeliveryStatus(ticket.ID())
+	if err != nil {
+		// Only possible if the send was forgotten already; report it as queued.
+		status = services.DeliveryStatus{DeliveryID: ticket.ID(), Integration: name, State: services.MessageQueued}
+	}
+	w.Header().Set("Location", v2DeliveriesPath+ticket.ID())
+
+	switch status.State {
+	case services.MessageSent:
+		ih.metrics.ObserveSend(name, sendOutcomeDelivered, time.Since(start))
+		writeV2JSON(w, r, http.StatusOK, status)
+	case services.MessageDeadLettered:
+		ih.metrics.ObserveSend(name, sendOutcomeStored, time.Since(start))
+		writeV2JSON(w, r, http.StatusAccepted, status)
+	case services.MessageFailed:
+		ih.metrics.ObserveSend(name, sendOutcomeFailed, time.Since(start))
+		logger.Error("Failed to send message through integration",
+			zap.String("integration", name),
+			zap.Error(ticket.Err()))
+		code, message, retry := sendErrorStatus(ticket.Err())
+		if retry {
+			w.Header().Set("Retry-After", "1")
+		}
+		writeProblem(w, r, code, message, ticket.ID())
+	default:
+		ih.metrics.ObserveSend(name, sendOutcomeAccepted, time.Since(start))
+		writeV2JSON(w, r, http.StatusAccepted, status)
+	}
+}
+
+// HandleV2Delivery reports the status of the send named by the {id} path
+// variable, as returned by HandleV2SendMessage. Only recent sends are known.
+//
+// Responses:
+//   - 200 with the delivery status
+//   - 404 for an unknown or forgotten delivery
+func (ih *IntegrationHandler) HandleV2Delivery(w http.ResponseWriter, r *http.Request) {
+	status, err := ih.syncManager.DeliveryStatus(mux.Vars(r)["id"])
+	if errors.Is(err, services.ErrDeliveryNotFound) {
+		writeProblem(w, r, http.StatusNotFound, err.Error(), "")
+		return
+	}
+	writeV2JSON(w, r, http.StatusOK, status)
+}
+
+// preferredWait returns how long HandleV2SendMessage should wait for a send,
+// from a "wait" preference (RFC 7240) in seconds, capped at the configured
+// dispatch maxWait. The boolean is false when no wait was asked for.
+func (ih *IntegrationHandler) preferredWait(r *http.Request) (time.Duration, bool) {
+	for _, header := range r.Header.Values("Prefer") {
+		for _, pref := range strings.Split(header, ",") {
+			key, value, _ := strings.Cut(strings.TrimSpace(pref), "=")
+			if !strings.EqualFold(key, "wait") {
+				continue
+			}
+			seconds, err := strconv.Atoi(strings.Trim(value, `"`))
+			if err != nil || seconds <= 0 {
+				return 0, false
+			}
+			wait := time.Duration(seconds) * time.Second
+			if dispatch := ih.cfg.Dispatch; dispatch != nil && dispatch.MaxWait > 0 && wait > dispatch.MaxWait {
+				wait = dispatch.MaxWait
+			}
+			return wait, true
+		}
+	}
+	return 0, false
+}
+
+// writeV2JSON writes body with the status code, as the versioned media type
+// when the client asked for it and as plain JSON otherwise.
+func writeV2JSON(w http.ResponseWriter, r *http.Request, code int, body interface{}) {
+	contentType := mediaTypeJSON
+	if acceptsMediaType(r, mediaTypeV2) {
+		contentType = mediaTypeV2
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// writeProblem writes a v2 error response. An empty detail omits the member.
+func writeProblem(w http.ResponseWriter, r *http.Request, code int, detail, deliveryID string) {
+	w.Header().Set("Content-Type", mediaTypeProblem)
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(problem{
+		Type:       "about:blank",
+		Title:      http.StatusText(code),
+		Status:     code,
+		Detail:     detail,
+		Instance:   r.URL.Path,
+		RequestID:  models.RequestIDFrom(r.Context()),
+		DeliveryID: deliveryID,
+	})
+}
+
+// apiVersionMiddleware negotiates the API version of requests to the version
+// subrouter it is used on. The version is chosen by the path; the Accept header
+// may additionally name versioned media types, such as
+// application/vnd.taskstream.v2+json, and a request whose Accept names only
+// those of other versions is rejected with 406 rather than answered in a format
+// the client cannot read. Every response names the version in API-Version, and
+// requests are counted by version.
+//
+// Responses, besides the handler's own:
+//   - 406 when Accept names only versioned media types of other versions
+func apiVersionMiddleware(version string, metrics *Metrics) mux.MiddlewareFunc {
+	own := mediaTypeVendorPrefix + version + "+json"
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set(apiVersionHeader, version)
+			if acceptsOnlyOtherVersions(r, own) {
+				http.Error(w, http.StatusText(http.StatusNotAcceptable), http.StatusNotAcceptable)
+				return
+			}
+			metrics.ObserveAPIRequest(version)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// acceptsMediaType reports whether r's Accept header names mediaType.
+func acceptsMediaType(r *http.Request, mediaType string) bool {
+	for _, accepted := range acceptedMediaTypes(r) {
+		if accepted == mediaType {
+			return true
+		}
+	}
+	return false
+}
+
+// acceptsOnlyOtherVersions reports whether every media type in r's Accept
+// header is a versioned media type other than own.
+func acceptsOnlyOtherVersions(r *http.Request, own string) bool {
+	accepted := acceptedMediaTypes(r)
+	if len(accepted) == 0 {
+		return false
+	}
+	for _, mediaType := range accepted {
+		if mediaType == own || !strings.HasPrefix(mediaType, mediaTypeVendorPrefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// acceptedMediaTypes returns the media types named in r's Accept header,
+// skipping those that do not parse or that the client refuses with q=0.
+func acceptedMediaTypes(r *http.Request) []string {
+	var types []string
+	for _, header := range r.Header.Values("Accept") {
+		for _, value := range strings.Split(header, ",") {
+			mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(value))
+			if err != nil || params["q"] == "0" {
+				continue
+			}
+			types = append(types, mediaType)
+		}
+	}
+	return types
+}
+
+// deprecationMiddleware marks responses from a deprecated API version with
+// the Deprecation (RFC 9745) and Sunset (RFC 8594) headers, and a Link to the
+// successor version, so that clients can plan their migration while both
+// versions are served.
+func deprecationMiddleware(versionCfg *config.APIVersionConfig, successor string) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		if !versionCfg.IsDeprecated() {
+			return next
+		}
+
+		// Pre-compute header values once rather than per request.
+		deprecation := "true"
+		if at := versionCfg.DeprecationTime(); !at.IsZero() {
+			deprecation = "@" + strconv.FormatInt(at.Unix(), 10)
+		}
+		var sunset string
+		if at := versionCfg.SunsetTime(); !at.IsZero() {
+			sunset = at.UTC().Format(http.TimeFormat)
+		}
+		link := fmt.Sprintf(`<%s>; rel="successor-version"`, successor)
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Deprecation", deprecation)
+			if sunset != "" {
+				w.Header().Set("Sunset", sunset)
+			}
+			w.Header().Add("Link", link)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
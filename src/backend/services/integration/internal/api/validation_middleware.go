@@ -0,0 +1,154 @@
+package api
+
+import (
+	// go1.21 - Embedded schemas and request body buffering
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"path"
+	"regexp"
+	"strings"
+
+	// github.com/santhosh-tekuri/jsonschema/v5 v5.3.1 - JSON Schema validation
+	"github.com/santhosh-tekuri/jsonschema/v5"
+
+	// Internal CloudEvents HTTP binding
+	"src/backend/services/integration/internal/cloudevents"
+)
+
+// Request schemas, by file name in the schemas directory.
+const (
+	sendMessageSchema   = "send_message.json"
+	sendMessageV2Schema = "send_message_v2.json"
+	fanOutSchema        = "fanout.json"
+)
+
+//go:embed schemas
+var schemaFiles embed.FS
+
+// requestSchemas are the compiled request schemas, by file name. The schemas
+// are part of the build, so one that does not compile is a programming error.
+var requestSchemas = compileRequestSchemas()
+
+// requiredNamesPattern extracts the property names from a "required" failure.
+var requiredNamesPattern = regexp.MustCompile(`'([^']*)'`)
+
+// fieldError is one reason a request body failed validation. Field is the JSON
+// Pointer of the offending value, such as "/targets/0/integration", or empty
+// for the body as a whole.
+type fieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// validationErrorResponse is the body of a request rejected by withValidation.
+type validationErrorResponse struct {
+	Error  string       `json:"error"`
+	Fields []fieldError `json:"fields"`
+}
+
+// compileRequestSchemas compiles every schema in the schemas directory.
+func compileRequestSchemas() map[string]*jsonschema.Schema {
+	entries, err := fs.ReadDir(schemaFiles, "schemas")
+	if err != nil {
+		panic(err)
+	}
+	compiler := jsonschema.NewCompiler()
+	compiler.Draft = jsonschema.Draft2020
+	for _, entry := range entries {
+		data, err := schemaFiles.ReadFile(path.Join("schemas", entry.Name()))
+		if err != nil {
+			panic(err)
+		}
+		if err := compiler.AddResource(entry.Name(), bytes.NewReader(data)); err != nil {
+			panic(err)
+		}
+	}
+
+	schemas := make(map[string]*jsonschema.Schema, len(entries))
+	for _, entry := range entries {
+		schemas[entry.Name()] = compiler.MustCompile(entry.Name())
+	}
+	return schemas
+}
+
+// withValidation validates JSON request bodies against the named schema from
+// the schemas directory before they reach the handler, rejecting those that do
+// not match with 400 and a JSON body listing every failing field. CloudEvents
+// are passed through unchecked: their data may leave the integration to an
+// extension attribute, and the handler validates what it decodes.
+//
+// Responses, besides the handler's own:
+//   - 400 with field errors for a body that is not JSON or fails the schema
+//   - 413 for a body over the size limit
+func withValidation(schemaName string, next http.HandlerFunc) http.HandlerFunc {
+	schema, ok := requestSchemas[schemaName]
+	if !ok {
+		panic("api: no request schema named " + schemaName)
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		if cloudevents.RequestMode(r) != "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		// The body is capped by the router's body limit.
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeDecodeError(w, err)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		instance, err := jsonschema.UnmarshalJSON(bytes.NewReader(body))
+		if err != nil {
+			writeValidationError(w, []fieldError{{Message: "request body is not valid JSON"}})
+			return
+		}
+		if err := schema.Validate(instance); err != nil {
+			var invalid *jsonschema.ValidationError
+			if !errors.As(err, &invalid) {
+				writeValidationError(w, []fieldError{{Message: err.Error()}})
+				return
+			}
+			writeValidationError(w, fieldErrors(invalid))
+			return
+		}
+		next.ServeHTTP(w, r)
+	}
+}
+
+// fieldErrors flattens a validation failure into the failures of individual
+// fields. Missing required properties are reported at the property itself.
+func fieldErrors(invalid *jsonschema.ValidationError) []fieldError {
+	if len(invalid.Causes) > 0 {
+		var fields []fieldError
+		for _, cause := range invalid.Causes {
+			fields = append(fields, fieldErrors(cause)...)
+		}
+		return fields
+	}
+
+	if strings.HasSuffix(invalid.KeywordLocation, "/required") {
+		var fields []fieldError
+		for _, match := range requiredNamesPattern.FindAllStringSubmatch(invalid.Message, -1) {
+			fields = append(fields, fieldError{Field: invalid.InstanceLocation + "/" + match[1], Message: "is required"})
+		}
+		if len(fields) > 0 {
+			return fields
+		}
+	}
+	return []fieldError{{Field: invalid.InstanceLocation, Message: invalid.Message}}
+}
+
+// writeValidationError rejects a request whose body failed validation.
+func writeValidationError(w http.ResponseWriter, fields []fieldError) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusBadRequest)
+	_ = json.NewEncoder(w).Encode(validationErrorResponse{Error: ErrInvalidRequest.Error(), Fields: fields})
+}
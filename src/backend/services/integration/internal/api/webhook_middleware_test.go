@@ -0,0 +1,74 @@
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+
+	"src/backend/services/integration/internal/config"
+	"src/backend/services/integration/internal/signing"
+)
+
+func TestWebhookSignatureMiddleware(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	cfg := &config.WebhooksConfig{
+		Inbound: []config.InboundWebhookConfig{
+			{Source: "github", Scheme: signing.SchemeGeneric, Secrets: []string{"github-secret"}},
+		},
+		InboundTolerance: 5 * time.Minute,
+	}
+
+	// signed returns a request to source whose body is signed with secret at the given time.
+	signed := func(source, secret string, at time.Time, body string) *http.Request {
+		ts := strconv.FormatInt(at.Unix(), 10)
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write([]byte(ts + "." + body))
+		r := httptest.NewRequest(http.MethodPost, "/webhooks/"+source, strings.NewReader(body))
+		r.Header.Set(signing.HeaderSignature, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+		r.Header.Set(signing.HeaderSignatureTimestamp, ts)
+		return r
+	}
+
+	const body = `{"action":"opened"}`
+	for _, tc := range []struct {
+		name string
+		req  *http.Request
+		want int
+	}{
+		{"valid signature", signed("github", "github-secret", now, body), http.StatusOK},
+		{"unknown source", signed("gitlab", "github-secret", now, body), http.StatusNotFound},
+		{"wrong secret", signed("github", "other-secret", now, body), http.StatusUnauthorized},
+		{"replayed", signed("github", "github-secret", now.Add(-time.Hour), body), http.StatusUnauthorized},
+		{"oversize body", signed("github", "github-secret", now, strings.Repeat("a", maxWebhookBodyBytes+1)), http.StatusRequestEntityTooLarge},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var received string
+			r := mux.NewRouter()
+			r.Use(webhookSignatureMiddlewareAt(cfg, zap.NewNop(), func() time.Time { return now }))
+			r.HandleFunc("/webhooks/{source}", func(w http.ResponseWriter, r *http.Request) {
+				data, _ := io.ReadAll(r.Body)
+				received = string(data)
+			})
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, tc.req)
+
+			if rec.Code != tc.want {
+				t.Fatalf("status = %d, want %d", rec.Code, tc.want)
+			}
+			if tc.want == http.StatusOK && received != body {
+				t.Errorf("handler read body %q, want %q", received, body)
+			}
+		})
+	}
+}
@@ -0,0 +1,264 @@
+package auth
+
+import (
+	// go1.21 - Sentinel errors for authentication outcomes
+	"errors"
+	// go1.21 - Guarding failure counters
+	"sync"
+	"time"
+
+	// v0.17.0 - bcrypt password hashing
+	"golang.org/x/crypto/bcrypt"
+
+	// Internal configuration for users and throttling settings
+	"src/backend/services/integration/internal/config"
+)
+
+var (
+	// ErrInvalidCredentials is returned for an unknown user or wrong password.
+	ErrInvalidCredentials = errors.New("invalid username or password")
+
+	// ErrTooManyAttempts is returned while a user/client pair, or a client across
+	// all usernames, is locked out after repeated failures.
+	ErrTooManyAttempts = errors.New("too many failed authentication attempts")
+)
+
+// dummyHash is compared against when the username is unknown so that lookups for
+// missing users take as long as lookups for existing ones.
+var dummyHash, _ = bcrypt.GenerateFromPassword([]byte("taskstream-dummy-password"), bcrypt.DefaultCost)
+
+const (
+	// maxFailureRecords caps each failure map so that attempts from many
+	// usernames or addresses cannot grow it without bound.
+	maxFailureRecords = 10000
+
+	// failurePruneInterval is how often records whose window and lockout have
+	// both passed are swept.
+	failurePruneInterval = time.Minute
+)
+
+// failureRecord tracks recent failed attempts for a throttling key.
+type failureRecord struct {
+	count       int
+	windowStart time.Time
+	lockedUntil time.Time
+}
+
+// CredentialStore authenticates username/password pairs against bcrypt hashes
+// loaded from configuration and throttles brute-force attempts per
+// username and client address, and per client address across usernames.
+type CredentialStore struct {
+	// settingsMu guards users and the throttling settings, which Replace swaps.
+	settingsMu sync.RWMutex
+
+	// users maps username to bcrypt password hash.
+	users map[string][]byte
+
+	// maxFailures is the number of failures within window that locks out a
+	// username/client pair.
+	maxFailures int
+
+	// clientMaxFailures is the number of failures within window, across all
+	// usernames, that locks out a client.
+	clientMaxFailures int
+
+	// window is the period over which failures are counted.
+	window time.Duration
+
+	// lockout is how long a throttling key stays locked once maxFailures is reached.
+	lockout time.Duration
+
+	// mu guards failures, clients and lastPrune.
+	mu sync.Mutex
+
+	// failures maps "username|client" to its failure record.
+	failures map[string]*failureRecord
+
+	// clients maps a client to its failure record across usernames.
+	clients map[string]*failureRecord
+
+	// lastPrune is when expired records were last swept.
+	lastPrune time.Time
+}
+
+// NewCredentialStore builds a CredentialStore from the basic auth configuration.
+func NewCredentialStore(cfg *config.BasicAuthConfig) *CredentialStore {
+	store := &CredentialStore{
+		failures: make(map[string]*failureRecord),
+		clients:  make(map[string]*failureRecord),
+	}
+	store.Replace(cfg)
+	return store
+}
+
+// Replace swaps in the users and throttling settings of cfg, e.g. after the
+// configuration is reloaded. Lockouts already in force are kept.
+func (s *CredentialStore) Replace(cfg *config.BasicAuthConfig) {
+	users := make(map[string][]byte)
+	var maxFailures, clientMaxFailures int
+	var window, lockout time.Duration
+	if cfg != nil {
+		maxFailures, clientMaxFailures = cfg.MaxFailures, cfg.ClientMaxFailures
+		window, lockout = cfg.FailureWindow, cfg.LockoutDuration
+		for _, u := range cfg.Users {
+			users[u.Username] = []byte(u.PasswordHash)
+		}
+	}
+
+	s.settingsMu.Lock()
+	defer s.settingsMu.Unlock()
+	s.users = users
+	s.maxFailures, s.clientMaxFailures = maxFailures, clientMaxFailures
+	s.window, s.lockout = window, lockout
+}
+
+// Authenticate verifies the credentials presented by client (typically the remote
+// IP) and returns the corresponding principal. While the username/client pair or
+// the client is locked out, ErrTooManyAttempts is returned together with the
+// remaining lockout.
+func (s *CredentialStore) Authenticate(username, password, client string) (*Principal, time.Duration, error) {
+	key := username + "|" + client
+
+	if remaining := s.lockedFor(key, client); remaining > 0 {
+		return nil, remaining, ErrTooManyAttempts
+	}
+
+	s.settingsMu.RLock()
+	hash, known := s.users[username]
+	s.settingsMu.RUnlock()
+	if !known {
+		hash = dummyHash
+	}
+
+	// bcrypt compares in constant time; the dummy hash keeps unknown users on the same path.
+	if err := bcrypt.CompareHashAndPassword(hash, []byte(password)); err != nil || !known {
+		return nil, s.recordFailure(key, client), ErrInvalidCredentials
+	}
+
+	// The client's record is kept, so that one valid account does not reset
+	// the throttle on spraying the others.
+	s.clearFailures(key)
+	return &Principal{Subject: username, Method: "basic"}, 0, nil
+}
+
+// lockedFor returns the longer remaining lockout of the username/client pair
+// key and of client, or zero when neither is locked.
+func (s *CredentialStore) lockedFor(key, client string) time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var remaining time.Duration
+	if rec, ok := s.failures[key]; ok {
+		remaining = time.Until(rec.lockedUntil)
+	}
+	if rec, ok := s.clients[client]; ok {
+		if r := time.Until(rec.lockedUntil); r > remaining {
+			remaining = r
+		}
+	}
+	return remaining
+}
+
+// recordFailure counts a failed attempt against the username/client pair key
+// and against client, and returns the lockout duration if either triggered one.
+func (s *CredentialStore) recordFailure(key, client string) time.Duration {
+	s.settingsMu.RLock()
+	maxFailures, clientMaxFailures := s.maxFailures, s.clientMaxFailures
+	window, lockout := s.window, s.lockout
+	s.settingsMu.RUnlock()
+	if maxFailures <= 0 && clientMaxFailures <= 0 {
+		return 0
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(s.lastPrune) >= failurePruneInterval {
+		pruneFailures(s.failures, now, window)
+		pruneFailures(s.clients, now, window)
+		s.lastPrune = now
+	}
+
+	var locked time.Duration
+	if maxFailures > 0 && countFailure(s.failures, key, now, maxFailures, window, lockout) {
+		locked = lockout
+	}
+	if clientMaxFailures > 0 && countFailure(s.clients, client, now, clientMaxFailures, window, lockout) {
+		locked = lockout
+	}
+	return locked
+}
+
+// countFailure adds a failure to the record for key in records and reports
+// whether it reached limit and locked the key out. A new key is admitted to a
+// full map only after an expired or, failing that, the least restrictive
+// record has been dropped.
+func countFailure(records map[string]*failureRecord, key string, now time.Time, limit int, window, lockout time.Duration) bool {
+	rec, ok := records[key]
+	if !ok {
+		if len(records) >= maxFailureRecords {
+			pruneFailures(records, now, window)
+		}
+		if len(records) >= maxFailureRecords {
+			evictFailure(records, now)
+		}
+		rec = &failureRecord{windowStart: now}
+		records[key] = rec
+	} else if now.Sub(rec.windowStart) > window {
+		rec.count = 0
+		rec.windowStart = now
+	}
+	rec.count++
+	if rec.count >= limit {
+		rec.lockedUntil = now.Add(lockout)
+		rec.count = 0
+		rec.windowStart = now
+		return true
+	}
+	return false
+}
+
+// pruneFailures drops the records whose failure window and lockout have both
+// passed.
+func pruneFailures(records map[string]*failureRecord, now time.Time, window time.Duration) {
+	for key, rec := range records {
+		if now.Sub(rec.windowStart) > window && !now.Before(rec.lockedUntil) {
+			delete(records, key)
+		}
+	}
+}
+
+// evictFailure drops one record from a full map: the unlocked record with the
+// oldest window or, when every record is locked, the one whose lockout ends
+// first.
+func evictFailure(records map[string]*failureRecord, now time.Time) {
+	var victim string
+	var victimRec *failureRecord
+	for key, rec := range records {
+		if victimRec == nil || lessRestrictive(rec, victimRec, now) {
+			victim, victimRec = key, rec
+		}
+	}
+	delete(records, victim)
+}
+
+// lessRestrictive reports whether dropping a loses less throttling state than
+// dropping b.
+func lessRestrictive(a, b *failureRecord, now time.Time) bool {
+	aLocked, bLocked := now.Before(a.lockedUntil), now.Before(b.lockedUntil)
+	if aLocked != bLocked {
+		return !aLocked
+	}
+	if aLocked {
+		return a.lockedUntil.Before(b.lockedUntil)
+	}
+	return a.windowStart.Before(b.windowStart)
+}
+
+// clearFailures resets the failure record after a successful login.
+func (s *CredentialStore) clearFailures(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.failures, key)
+}
@@ -0,0 +1,142 @@
+package auth
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"src/backend/services/integration/internal/config"
+)
+
+// newTestCredentialStore returns a store with the user "ops" whose password is
+// "correct horse", hashed at cost.
+func newTestCredentialStore(t *testing.T, cost, maxFailures, clientMaxFailures int) *CredentialStore {
+	t.Helper()
+	hash, err := bcrypt.GenerateFromPassword([]byte("correct horse"), cost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewCredentialStore(&config.BasicAuthConfig{
+		Users:             []config.BasicAuthUser{{Username: "ops", PasswordHash: string(hash)}},
+		MaxFailures:       maxFailures,
+		ClientMaxFailures: clientMaxFailures,
+		FailureWindow:     time.Minute,
+		LockoutDuration:   time.Minute,
+	})
+}
+
+func TestCredentialStoreAuthenticate(t *testing.T) {
+	store := newTestCredentialStore(t, bcrypt.MinCost, 0, 0)
+
+	for _, tc := range []struct {
+		name     string
+		username string
+		password string
+		want     error
+	}{
+		{"valid credentials", "ops", "correct horse", nil},
+		{"wrong password", "ops", "battery staple", ErrInvalidCredentials},
+		{"empty password", "ops", "", ErrInvalidCredentials},
+		{"unknown user", "root", "correct horse", ErrInvalidCredentials},
+		{"unknown user with the dummy hash's password", "root", "taskstream-dummy-password", ErrInvalidCredentials},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p, lockout, err := store.Authenticate(tc.username, tc.password, "192.0.2.1")
+			if !errors.Is(err, tc.want) {
+				t.Fatalf("Authenticate() error = %v, want %v", err, tc.want)
+			}
+			if lockout != 0 {
+				t.Errorf("lockout = %v without throttling configured", lockout)
+			}
+			if tc.want == nil && (p == nil || p.Subject != tc.username || p.Method != "basic") {
+				t.Errorf("principal = %+v, want subject %q authenticated by basic", p, tc.username)
+			}
+			if tc.want != nil && p != nil {
+				t.Errorf("principal = %+v for rejected credentials", p)
+			}
+		})
+	}
+}
+
+func TestCredentialStoreLocksOutUserAndClient(t *testing.T) {
+	store := newTestCredentialStore(t, bcrypt.MinCost, 3, 0)
+
+	for i := 1; i <= 3; i++ {
+		_, lockout, err := store.Authenticate("ops", "wrong", "192.0.2.1")
+		if !errors.Is(err, ErrInvalidCredentials) {
+			t.Fatalf("attempt %d: error = %v, want %v", i, err, ErrInvalidCredentials)
+		}
+		if (lockout > 0) != (i == 3) {
+			t.Fatalf("attempt %d: lockout = %v", i, lockout)
+		}
+	}
+
+	if _, lockout, err := store.Authenticate("ops", "correct horse", "192.0.2.1"); !errors.Is(err, ErrTooManyAttempts) || lockout <= 0 {
+		t.Errorf("locked out pair: error = %v, lockout = %v; want %v", err, lockout, ErrTooManyAttempts)
+	}
+	if _, _, err := store.Authenticate("ops", "correct horse", "198.51.100.7"); err != nil {
+		t.Errorf("other client: error = %v, want nil", err)
+	}
+}
+
+func TestCredentialStoreThrottlesUnknownUsersLikeKnownOnes(t *testing.T) {
+	store := newTestCredentialStore(t, bcrypt.MinCost, 2, 0)
+
+	for _, username := range []string{"ops", "nobody"} {
+		var lockout time.Duration
+		for i := 0; i < 2; i++ {
+			_, lockout, _ = store.Authenticate(username, "wrong", "192.0.2.1")
+		}
+		if lockout <= 0 {
+			t.Errorf("%s: no lockout after repeated failures", username)
+		}
+		if _, _, err := store.Authenticate(username, "wrong", "192.0.2.1"); !errors.Is(err, ErrTooManyAttempts) {
+			t.Errorf("%s: error = %v, want %v", username, err, ErrTooManyAttempts)
+		}
+	}
+}
+
+func TestCredentialStoreLocksOutClientAcrossUsernames(t *testing.T) {
+	store := newTestCredentialStore(t, bcrypt.MinCost, 0, 3)
+
+	for _, username := range []string{"alice", "bob", "carol"} {
+		if _, _, err := store.Authenticate(username, "wrong", "192.0.2.1"); !errors.Is(err, ErrInvalidCredentials) {
+			t.Fatalf("%s: error = %v, want %v", username, err, ErrInvalidCredentials)
+		}
+	}
+	if _, _, err := store.Authenticate("ops", "correct horse", "192.0.2.1"); !errors.Is(err, ErrTooManyAttempts) {
+		t.Errorf("sprayed client: error = %v, want %v", err, ErrTooManyAttempts)
+	}
+	if _, _, err := store.Authenticate("ops", "correct horse", "198.51.100.7"); err != nil {
+		t.Errorf("other client: error = %v, want nil", err)
+	}
+}
+
+// TestCredentialStoreUnknownUserCostsABcryptComparison checks the
+// constant-time fallback: an unknown username is compared against the dummy
+// hash, so rejecting it takes about as long as rejecting a known user's wrong
+// password, and response times do not reveal which usernames exist.
+func TestCredentialStoreUnknownUserCostsABcryptComparison(t *testing.T) {
+	if testing.Short() {
+		t.Skip("compares bcrypt timings")
+	}
+	if cost, err := bcrypt.Cost(dummyHash); err != nil || cost != bcrypt.DefaultCost {
+		t.Fatalf("dummy hash cost = %d (%v), want %d", cost, err, bcrypt.DefaultCost)
+	}
+	store := newTestCredentialStore(t, bcrypt.DefaultCost, 0, 0)
+
+	elapsed := func(username string) time.Duration {
+		start := time.Now()
+		if _, _, err := store.Authenticate(username, "wrong", "192.0.2.1"); !errors.Is(err, ErrInvalidCredentials) {
+			t.Fatalf("%s: error = %v, want %v", username, err, ErrInvalidCredentials)
+		}
+		return time.Since(start)
+	}
+	known, unknown := elapsed("ops"), elapsed("nobody")
+	// A lookup that skipped bcrypt would be orders of magnitude faster.
+	if unknown < known/4 {
+		t.Errorf("unknown user rejected in %v, known user in %v", unknown, known)
+	}
+}
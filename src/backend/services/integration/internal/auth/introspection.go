@@ -0,0 +1,206 @@
+package auth
+
+import (
+	// go1.21 - Context for request cancellation and deadlines
+	"context"
+	// go1.21 - Hashing tokens before using them as cache keys
+	"crypto/sha256"
+	"encoding/hex"
+	// go1.21 - Decoding introspection responses
+	"encoding/json"
+	// go1.21 - Sentinel errors for token validation outcomes
+	"errors"
+	"fmt"
+	// go1.21 - HTTP client for calling the introspection endpoint
+	"net/http"
+	"net/url"
+	"strings"
+	// go1.21 - Guarding the response cache
+	"sync"
+	"time"
+
+	// Internal configuration for the introspection endpoint and cache settings
+	"src/backend/services/integration/internal/config"
+	// Internal shared HTTP transport with pooled keep-alive connections
+	"src/backend/services/integration/internal/httpclient"
+)
+
+var (
+	// ErrTokenInactive is returned when the authorization server reports the token as inactive.
+	ErrTokenInactive = errors.New("access token is not active")
+
+	// ErrIntrospectionFailed is returned when the introspection endpoint cannot be reached
+	// or returns an unexpected response.
+	ErrIntrospectionFailed = errors.New("token introspection failed")
+)
+
+// introspectionResponse models the RFC 7662 section 2.2 response fields used by the service.
+type introspectionResponse struct {
+	Active    bool   `json:"active"`
+	Scope     string `json:"scope"`
+	ClientID  string `json:"client_id"`
+	Username  string `json:"username"`
+	Subject   string `json:"sub"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// cachedIntrospection is a single cache entry holding either a principal or a
+// negative result, along with its expiry.
+type cachedIntrospection struct {
+	principal *Principal
+	expires   time.Time
+}
+
+// Introspector validates opaque OAuth2 access tokens against an RFC 7662 token
+// introspection endpoint. Responses are cached by token hash for the configured
+// TTL (never beyond the token's own expiry) so that hot paths do not pay an
+// authorization-server round trip on every request.
+type Introspector struct {
+	// cfg holds the endpoint, client credentials, and cache settings.
+	cfg *config.IntrospectionConfig
+
+	// client is the HTTP client used for introspection calls.
+	client *http.Client
+
+	// mu guards cache.
+	mu sync.Mutex
+
+	// cache maps sha256(token) to the most recent introspection result.
+	cache map[string]cachedIntrospection
+}
+
+// NewIntrospector creates an Introspector from configuration.
+func NewIntrospector(cfg *config.IntrospectionConfig) *Introspector {
+	return &Introspector{
+		cfg:    cfg,
+		client: httpclient.Default().ClientWithTimeout(cfg.Timeout),
+		cache:  make(map[string]cachedIntrospection),
+	}
+}
+
+// Introspect returns the principal associated with an active token, consulting
+// the cache first. Inactive tokens yield ErrTokenInactive; transport or protocol
+// failures yield an error wrapping ErrIntrospectionFailed and are never cached.
+func (in *Introspector) Introspect(ctx context.Context, token string) (*Principal, error) {
+	key := tokenCacheKey(token)
+
+	// 1. Serve from cache while the entry is fresh.
+	if p, ok := in.lookup(key); ok {
+		if p == nil {
+			return nil, ErrTokenInactive
+		}
+		return p, nil
+	}
+
+	// 2. Call the introspection endpoint.
+	resp, err := in.call(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Negative results are cached for a short period to absorb retries with bad tokens.
+	now := time.Now()
+	if !resp.Active {
+		in.store(key, nil, now.Add(in.cfg.NegativeCacheTTL))
+		return nil, ErrTokenInactive
+	}
+
+	principal := &Principal{
+		Subject:  resp.Subject,
+		ClientID: resp.ClientID,
+		Scopes:   strings.Fields(resp.Scope),
+		Method:   "introspection",
+	}
+	if principal.Subject == "" {
+		principal.Subject = resp.Username
+	}
+	if principal.Subject == "" {
+		principal.Subject = resp.ClientID
+	}
+
+	// 4. Cache positive results no longer than the token remains valid.
+	expires := now.Add(in.cfg.CacheTTL)
+	if resp.ExpiresAt > 0 {
+		if tokenExpiry := time.Unix(resp.ExpiresAt, 0); tokenExpiry.Before(expires) {
+			expires = tokenExpiry
+		}
+	}
+	in.store(key, principal, expires)
+
+	return principal, nil
+}
+
+// call performs the RFC 7662 introspection request using client credentials.
+func (in *Introspector) call(ctx context.Context, token string) (*introspectionResponse, error) {
+	form := url.Values{}
+	form.Set("token", token)
+	form.Set("token_type_hint", "access_token")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, in.cfg.Endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrIntrospectionFailed, err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(in.cfg.ClientID), url.QueryEscape(in.cfg.ClientSecret))
+
+	resp, err := in.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrIntrospectionFailed, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: unexpected status %d", ErrIntrospectionFailed, resp.StatusCode)
+	}
+
+	var body introspectionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrIntrospectionFailed, err)
+	}
+	return &body, nil
+}
+
+// lookup returns a fresh cache entry for key. A nil principal with ok == true
+// denotes a cached inactive token.
+func (in *Introspector) lookup(key string) (*Principal, bool) {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	entry, exists := in.cache[key]
+	if !exists {
+		return nil, false
+	}
+	if time.Now().After(entry.expires) {
+		delete(in.cache, key)
+		return nil, false
+	}
+	return entry.principal, true
+}
+
+// store records an introspection result, evicting expired entries once the
+// cache grows beyond its configured size.
+func (in *Introspector) store(key string, p *Principal, expires time.Time) {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	if len(in.cache) >= in.cfg.CacheSize {
+		now := time.Now()
+		for k, entry := range in.cache {
+			if now.After(entry.expires) {
+				delete(in.cache, k)
+			}
+		}
+		// If every entry is still fresh, drop the cache rather than grow without bound.
+		if len(in.cache) >= in.cfg.CacheSize {
+			in.cache = make(map[string]cachedIntrospection)
+		}
+	}
+	in.cache[key] = cachedIntrospection{principal: p, expires: expires}
+}
+
+// tokenCacheKey hashes the raw token so that bearer credentials are never held as map keys.
+func tokenCacheKey(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,136 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"src/backend/services/integration/internal/config"
+)
+
+// fakeAuthorizationServer answers introspection requests from responses, keyed
+// by token, and counts the calls it receives. Unknown tokens are inactive.
+type fakeAuthorizationServer struct {
+	responses map[string]introspectionResponse
+	status    int
+	calls     atomic.Int32
+}
+
+func (s *fakeAuthorizationServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.calls.Add(1)
+	if id, secret, ok := r.BasicAuth(); !ok || id != "integration-service" || secret != "client-secret" {
+		http.Error(w, "invalid client", http.StatusUnauthorized)
+		return
+	}
+	if s.status != 0 {
+		w.WriteHeader(s.status)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(s.responses[r.PostFormValue("token")])
+}
+
+// newTestIntrospector returns an Introspector for the authorization server at endpoint.
+func newTestIntrospector(endpoint string) *Introspector {
+	return NewIntrospector(&config.IntrospectionConfig{
+		Enabled:          true,
+		Endpoint:         endpoint,
+		ClientID:         "integration-service",
+		ClientSecret:     "client-secret",
+		Timeout:          time.Second,
+		CacheTTL:         time.Minute,
+		NegativeCacheTTL: time.Minute,
+		CacheSize:        100,
+	})
+}
+
+func TestIntrospectorCachesActiveTokens(t *testing.T) {
+	server := &fakeAuthorizationServer{responses: map[string]introspectionResponse{
+		"active-token": {Active: true, Scope: "send:slack read:status", ClientID: "dashboard", Username: "ops"},
+	}}
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+	in := newTestIntrospector(ts.URL)
+
+	want := &Principal{Subject: "ops", ClientID: "dashboard", Scopes: []string{"send:slack", "read:status"}, Method: "introspection"}
+	for i := 0; i < 3; i++ {
+		p, err := in.Introspect(context.Background(), "active-token")
+		if err != nil {
+			t.Fatalf("Introspect: %v", err)
+		}
+		if !reflect.DeepEqual(p, want) {
+			t.Fatalf("principal = %+v, want %+v", p, want)
+		}
+	}
+	if calls := server.calls.Load(); calls != 1 {
+		t.Errorf("authorization server called %d times, want 1", calls)
+	}
+}
+
+func TestIntrospectorCachesInactiveTokens(t *testing.T) {
+	server := &fakeAuthorizationServer{}
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+	in := newTestIntrospector(ts.URL)
+
+	for i := 0; i < 3; i++ {
+		if _, err := in.Introspect(context.Background(), "revoked-token"); !errors.Is(err, ErrTokenInactive) {
+			t.Fatalf("Introspect() = %v, want %v", err, ErrTokenInactive)
+		}
+	}
+	if calls := server.calls.Load(); calls != 1 {
+		t.Errorf("authorization server called %d times, want 1", calls)
+	}
+}
+
+func TestIntrospectorCachesNoLongerThanTokenExpiry(t *testing.T) {
+	server := &fakeAuthorizationServer{responses: map[string]introspectionResponse{
+		"expiring-token": {Active: true, Subject: "svc", ExpiresAt: time.Now().Add(-time.Second).Unix()},
+	}}
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+	in := newTestIntrospector(ts.URL)
+
+	for i := 0; i < 2; i++ {
+		if _, err := in.Introspect(context.Background(), "expiring-token"); err != nil {
+			t.Fatalf("Introspect: %v", err)
+		}
+	}
+	if calls := server.calls.Load(); calls != 2 {
+		t.Errorf("authorization server called %d times, want 2", calls)
+	}
+}
+
+func TestIntrospectorFailuresAreNotCached(t *testing.T) {
+	server := &fakeAuthorizationServer{status: http.StatusInternalServerError}
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+	in := newTestIntrospector(ts.URL)
+
+	for i := 0; i < 2; i++ {
+		_, err := in.Introspect(context.Background(), "any-token")
+		if !errors.Is(err, ErrIntrospectionFailed) || errors.Is(err, ErrTokenInactive) {
+			t.Fatalf("Introspect() = %v, want %v", err, ErrIntrospectionFailed)
+		}
+	}
+	if calls := server.calls.Load(); calls != 2 {
+		t.Errorf("authorization server called %d times, want 2", calls)
+	}
+}
+
+func TestIntrospectorUnreachableServer(t *testing.T) {
+	ts := httptest.NewServer(http.NotFoundHandler())
+	endpoint := ts.URL
+	ts.Close()
+	in := newTestIntrospector(endpoint)
+
+	if _, err := in.Introspect(context.Background(), "any-token"); !errors.Is(err, ErrIntrospectionFailed) {
+		t.Errorf("Introspect() = %v, want %v", err, ErrIntrospectionFailed)
+	}
+}
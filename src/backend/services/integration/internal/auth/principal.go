@@ -0,0 +1,96 @@
+package auth
+
+import (
+	// go1.21 - Context propagation of authenticated identities
+	"context"
+	"sync/atomic"
+)
+
+// principalContextKey is the unexported context key under which the authenticated
+// Principal is stored, preventing collisions with other packages.
+type principalContextKey struct{}
+
+// principalSlotContextKey is the context key under which WithPrincipalSlot
+// stores its PrincipalSlot.
+type principalSlotContextKey struct{}
+
+// Principal describes an authenticated caller of the integration service,
+// independent of the mechanism used to authenticate it.
+type Principal struct {
+	// Subject is the stable identifier of the caller (token "sub", client certificate SAN, or username).
+	Subject string
+
+	// ClientID is the OAuth2 client the token was issued to, when known.
+	ClientID string
+
+	// CommonName is the Subject Common Name of the caller's client certificate;
+	// empty for callers not authenticated by one.
+	CommonName string
+
+	// Scopes lists the OAuth2 scopes granted to the caller.
+	Scopes []string
+
+	// Method records how the principal was authenticated (e.g., "introspection").
+	Method string
+}
+
+// HasScope reports whether the principal was granted the given scope.
+func (p *Principal) HasScope(scope string) bool {
+	if p == nil {
+		return false
+	}
+	for _, s := range p.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// HasAllScopes reports whether every scope in required was granted to the principal.
+func (p *Principal) HasAllScopes(required []string) bool {
+	for _, scope := range required {
+		if !p.HasScope(scope) {
+			return false
+		}
+	}
+	return true
+}
+
+// WithPrincipal returns a copy of ctx carrying the authenticated principal,
+// and records it in the PrincipalSlot carried by ctx, if any.
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	if slot, ok := ctx.Value(principalSlotContextKey{}).(*PrincipalSlot); ok {
+		slot.p.Store(p)
+	}
+	return context.WithValue(ctx, principalContextKey{}, p)
+}
+
+// PrincipalSlot receives the principal authenticated further down a handler
+// chain, so that middleware running ahead of authentication, such as the
+// access log, can tell who made the request once it has been served.
+type PrincipalSlot struct {
+	p atomic.Pointer[Principal]
+}
+
+// Principal returns the principal recorded in the slot, or nil when the
+// request was not authenticated.
+func (s *PrincipalSlot) Principal() *Principal {
+	if s == nil {
+		return nil
+	}
+	return s.p.Load()
+}
+
+// WithPrincipalSlot returns a copy of ctx carrying a new PrincipalSlot that
+// later calls to WithPrincipal on derived contexts fill in.
+func WithPrincipalSlot(ctx context.Context) (context.Context, *PrincipalSlot) {
+	slot := &PrincipalSlot{}
+	return context.WithValue(ctx, principalSlotContextKey{}, slot), slot
+}
+
+// PrincipalFromContext returns the authenticated principal stored in ctx, if any.
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalContextKey{}).(*Principal)
+	return p, ok && p != nil
+}
@@ -0,0 +1,189 @@
+package auth
+
+import (
+	// go1.21 - Case normalization for role names
+	"strings"
+
+	// Internal configuration holding the RBAC policy
+	"src/backend/services/integration/internal/config"
+)
+
+// Well-known permissions referenced by the default route policy.
+const (
+	// PermissionSendSlack allows posting messages through the Slack integration.
+	PermissionSendSlack = "send:slack"
+
+	// PermissionSendEmail allows sending email through the email integration.
+	PermissionSendEmail = "send:email"
+
+	// PermissionSendJira allows creating issues through the Jira integration.
+	PermissionSendJira = "send:jira"
+
+	// PermissionSendFanOut allows sending to several integrations at once.
+	PermissionSendFanOut = "send:fanout"
+
+	// PermissionSendMessages allows sending through any integration with the
+	// v2 API, whose requests name the integration in the body.
+	PermissionSendMessages = "send:messages"
+
+	// PermissionReadStatus allows reading health and integration status.
+	PermissionReadStatus = "read:status"
+
+	// PermissionOperate allows operational actions on integrations, such as
+	// pausing them, resetting their circuit breakers and replaying stored messages.
+	PermissionOperate = "operate:integrations"
+
+	// PermissionAdminConfig allows administrative and configuration operations.
+	PermissionAdminConfig = "admin:config"
+
+	// permissionWildcard grants every permission; intended for break-glass roles.
+	permissionWildcard = "*"
+)
+
+// defaultRoutePermissions is the built-in route policy, keyed by method and path
+// template. AuthorizationConfig.Routes entries take precedence over it.
+var defaultRoutePermissions = map[string][]string{
+	// Sends.
+	"POST /api/v1/email/send":  {PermissionSendEmail},
+	"POST /api/v1/slack/post":  {PermissionSendSlack},
+	"POST /api/v1/jira/create": {PermissionSendJira},
+	"POST /api/v1/fanout":      {PermissionSendFanOut},
+	"POST /api/v2/messages":    {PermissionSendMessages},
+
+	// Status, history and event streams.
+	"GET /api/v1/integrations/stream":        {PermissionReadStatus},
+	"GET /api/v1/deliveries/ws":              {PermissionReadStatus},
+	"GET /api/v1/integrations/{name}/status": {PermissionReadStatus},
+	"GET /api/v1/breakers":                   {PermissionReadStatus},
+	"GET /api/v1/messages":                   {PermissionReadStatus},
+	"GET /api/v2/deliveries/{id}":            {PermissionReadStatus},
+
+	// Operational actions on integrations.
+	"POST /api/v1/integrations/{name}/test":   {PermissionOperate},
+	"POST /api/v1/integrations/{name}/pause":  {PermissionOperate},
+	"POST /api/v1/integrations/{name}/resume": {PermissionOperate},
+	"POST /api/v1/breakers/{name}/reset":      {PermissionOperate},
+
+	// Operator endpoints.
+	"GET /health/secure":                    {PermissionReadStatus},
+	"GET /admin/log-level":                  {PermissionReadStatus},
+	"GET /admin/breakers":                   {PermissionReadStatus},
+	"GET /admin/dlq":                        {PermissionReadStatus},
+	"POST /admin/breakers/{name}/reset":     {PermissionOperate},
+	"POST /admin/dlq/{integration}/replay":  {PermissionOperate},
+	"POST /admin/credentials/{integration}": {PermissionAdminConfig},
+	"DELETE /admin/cache/{integration}":     {PermissionAdminConfig},
+	"POST /admin/config/reload":             {PermissionAdminConfig},
+	"PUT /admin/log-level":                  {PermissionAdminConfig},
+	"DELETE /admin/dlq/messages/{id}":       {PermissionAdminConfig},
+
+	// Dashboard.
+	"GET /dashboard/":                                  {PermissionReadStatus},
+	"HEAD /dashboard/":                                 {PermissionReadStatus},
+	"GET /dashboard/api/overview":                      {PermissionReadStatus},
+	"POST /dashboard/api/integrations/{name}/{action}": {PermissionOperate},
+}
+
+// Authorizer resolves the permissions of a Principal from the configured role
+// bindings and decides whether it may invoke a route.
+type Authorizer struct {
+	// policy is the authorization section of the configuration.
+	policy *config.AuthorizationConfig
+
+	// subjectRoles indexes role names by bound subject for constant-time lookup.
+	subjectRoles map[string][]string
+
+	// commonNameRoles indexes role names by bound client certificate Common Name.
+	commonNameRoles map[string][]string
+}
+
+// NewAuthorizer builds an Authorizer from the RBAC policy.
+func NewAuthorizer(policy *config.AuthorizationConfig) *Authorizer {
+	a := &Authorizer{
+		policy:          policy,
+		subjectRoles:    make(map[string][]string),
+		commonNameRoles: make(map[string][]string),
+	}
+	for _, binding := range policy.Bindings {
+		for _, role := range binding.Roles {
+			for _, subject := range binding.Subjects {
+				a.subjectRoles[subject] = append(a.subjectRoles[subject], strings.ToLower(role))
+			}
+			for _, cn := range binding.CommonNames {
+				a.commonNameRoles[cn] = append(a.commonNameRoles[cn], strings.ToLower(role))
+			}
+		}
+	}
+	return a
+}
+
+// Permissions returns the set of permissions granted to the principal through
+// bindings on either its subject or its OAuth2 client ID and, for callers
+// authenticated by a client certificate, on its Common Name.
+func (a *Authorizer) Permissions(p *Principal) map[string]struct{} {
+	granted := make(map[string]struct{})
+	if p == nil {
+		return granted
+	}
+
+	identities := []string{p.Subject}
+	if p.ClientID != "" && p.ClientID != p.Subject {
+		identities = append(identities, p.ClientID)
+	}
+
+	grant := func(roles []string) {
+		for _, role := range roles {
+			for _, permission := range a.policy.Roles[role] {
+				granted[permission] = struct{}{}
+			}
+		}
+	}
+	for _, identity := range identities {
+		grant(a.subjectRoles[identity])
+	}
+	if p.Method == MethodMTLS && p.CommonName != "" {
+		grant(a.commonNameRoles[p.CommonName])
+	}
+	return granted
+}
+
+// Authorize reports whether the principal may invoke the route identified by
+// its method and path template, along with the permissions the route requires.
+// The configured route entries are consulted first, then the built-in policy;
+// routes covered by neither are allowed unless DefaultDeny is set.
+func (a *Authorizer) Authorize(p *Principal, method, route string) (bool, []string) {
+	required, covered := a.routePermissions(method, route)
+	if !covered {
+		return !a.policy.DefaultDeny, nil
+	}
+
+	granted := a.Permissions(p)
+	if _, ok := granted[permissionWildcard]; ok {
+		return true, required
+	}
+	for _, permission := range required {
+		if _, ok := granted[permission]; !ok {
+			return false, required
+		}
+	}
+	return true, required
+}
+
+// Protects reports whether the route identified by its method and path
+// template requires an authenticated caller: it has a policy, configured or
+// built in, or DefaultDeny is set.
+func (a *Authorizer) Protects(method, route string) bool {
+	_, covered := a.routePermissions(method, route)
+	return covered || a.policy.DefaultDeny
+}
+
+// routePermissions returns the permissions the route requires, from the
+// configured route entries or else the built-in policy, and whether either
+// covers it.
+func (a *Authorizer) routePermissions(method, route string) ([]string, bool) {
+	if required, covered := a.policy.PermissionsForRoute(method, route); covered {
+		return required, true
+	}
+	required, covered := defaultRoutePermissions[method+" "+route]
+	return required, covered
+}
@@ -0,0 +1,82 @@
+// Package buildinfo reports which build of the service is running. The version,
+// commit and build time are stamped at link time, for example:
+//
+//	go build -ldflags "\
+//	  -X src/backend/services/integration/internal/buildinfo.Version=1.4.0 \
+//	  -X src/backend/services/integration/internal/buildinfo.Commit=$(git rev-parse HEAD) \
+//	  -X src/backend/services/integration/internal/buildinfo.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)" \
+//	  ./cmd/server
+//
+// Without ldflags the commit and build time fall back to the revision and commit
+// time the Go toolchain embeds when building from a git checkout.
+package buildinfo
+
+import (
+	// go1.21 - Go toolchain version, platform and embedded VCS information
+	"runtime"
+	"runtime/debug"
+	// go1.21 - Computed once per process
+	"sync"
+)
+
+// Values stamped with -ldflags "-X ...". They are variables, not constants, so
+// the linker can set them.
+var (
+	// Version is the release version, e.g. "1.4.0".
+	Version = "dev"
+
+	// Commit is the git commit the binary was built from.
+	Commit = ""
+
+	// BuildTime is when the binary was built, in RFC 3339 format.
+	BuildTime = ""
+)
+
+// unknown is reported for values that were neither stamped nor embedded.
+const unknown = "unknown"
+
+// Info describes the running build.
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildTime string `json:"buildTime"`
+	GoVersion string `json:"goVersion"`
+	Platform  string `json:"platform"`
+}
+
+var (
+	once sync.Once
+	info Info
+)
+
+// Get returns the build information of the running binary.
+func Get() Info {
+	once.Do(func() {
+		info = Info{
+			Version:   Version,
+			Commit:    Commit,
+			BuildTime: BuildTime,
+			GoVersion: runtime.Version(),
+			Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+		}
+
+		// Fall back to the VCS stamp for anything not set via ldflags.
+		if bi, ok := debug.ReadBuildInfo(); ok {
+			for _, s := range bi.Settings {
+				switch {
+				case s.Key == "vcs.revision" && info.Commit == "":
+					info.Commit = s.Value
+				case s.Key == "vcs.time" && info.BuildTime == "":
+					info.BuildTime = s.Value
+				}
+			}
+		}
+		if info.Commit == "" {
+			info.Commit = unknown
+		}
+		if info.BuildTime == "" {
+			info.BuildTime = unknown
+		}
+	})
+	return info
+}
@@ -0,0 +1,196 @@
+package cache
+
+import (
+	// go1.21 - Cancellable loads
+	"context"
+	// go1.21 - Prefix invalidation
+	"strings"
+	// go1.21 - Guards entries and in-flight loads
+	"sync"
+	"time"
+)
+
+// Stats is a snapshot of cache effectiveness for status reporting.
+type Stats struct {
+	// Entries is the number of cached values, including expired ones not yet evicted.
+	Entries int `json:"entries"`
+
+	// Hits counts lookups answered from the cache.
+	Hits uint64 `json:"hits"`
+
+	// Misses counts lookups that had to load the value.
+	Misses uint64 `json:"misses"`
+
+	// TTL is how long values stay fresh.
+	TTL time.Duration `json:"ttl"`
+}
+
+// entry is a cached value and its expiry.
+type entry struct {
+	value   interface{}
+	expires time.Time
+}
+
+// call is a load in progress, shared by concurrent lookups of the same key.
+type call struct {
+	done  chan struct{}
+	value interface{}
+	err   error
+}
+
+// TTL caches provider metadata (Jira create metadata and transitions, Slack
+// channel lists, ...) for a fixed time so that sends do not pay a metadata
+// round-trip each. Concurrent misses for the same key share one load, and
+// entries can be invalidated explicitly when the provider side changes.
+type TTL struct {
+	ttl        time.Duration
+	maxEntries int
+
+	mu       sync.Mutex
+	entries  map[string]entry
+	inflight map[string]*call
+	hits     uint64
+	misses   uint64
+
+	// generation advances on every invalidation so that a load started before
+	// it does not repopulate the cache with stale data.
+	generation uint64
+}
+
+// NewTTL creates a cache whose entries stay fresh for ttl. A non-positive ttl
+// disables caching; every lookup loads. maxEntries bounds the cache size, with
+// zero meaning unbounded.
+func NewTTL(ttl time.Duration, maxEntries int) *TTL {
+	return &TTL{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		entries:    make(map[string]entry),
+		inflight:   make(map[string]*call),
+	}
+}
+
+// Get returns the fresh value cached under key, if any.
+func (c *TTL) Get(key string) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok || time.Now().After(e.expires) {
+		return nil, false
+	}
+	return e.value, true
+}
+
+// Set caches value under key.
+func (c *TTL) Set(key string, value interface{}) {
+	if c.ttl <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.setLocked(key, value)
+}
+
+// GetOrLoad returns the fresh value cached under key or calls load to fetch it.
+// Concurrent callers missing on the same key wait for a single load. Failed
+// loads are not cached.
+func (c *TTL) GetOrLoad(ctx context.Context, key string, load func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	c.mu.Lock()
+	if e, ok := c.entries[key]; ok && time.Now().Before(e.expires) {
+		c.hits++
+		c.mu.Unlock()
+		return e.value, nil
+	}
+	c.misses++
+	if inflight, ok := c.inflight[key]; ok {
+		c.mu.Unlock()
+		select {
+		case <-inflight.done:
+			return inflight.value, inflight.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	pending := &call{done: make(chan struct{})}
+	c.inflight[key] = pending
+	generation := c.generation
+	c.mu.Unlock()
+
+	pending.value, pending.err = load(ctx)
+
+	c.mu.Lock()
+	delete(c.inflight, key)
+	if pending.err == nil && c.ttl > 0 && generation == c.generation {
+		c.setLocked(key, pending.value)
+	}
+	c.mu.Unlock()
+	close(pending.done)
+	return pending.value, pending.err
+}
+
+// Invalidate removes key, reporting whether it was cached.
+func (c *TTL) Invalidate(key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.entries[key]
+	delete(c.entries, key)
+	c.generation++
+	return ok
+}
+
+// InvalidatePrefix removes every key starting with prefix and returns how many
+// were removed. An empty prefix clears the cache.
+func (c *TTL) InvalidatePrefix(prefix string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	removed := 0
+	for key := range c.entries {
+		if strings.HasPrefix(key, prefix) {
+			delete(c.entries, key)
+			removed++
+		}
+	}
+	return removed
+}
+
+// Stats returns a snapshot of the cache.
+func (c *TTL) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return Stats{
+		Entries: len(c.entries),
+		Hits:    c.hits,
+		Misses:  c.misses,
+		TTL:     c.ttl,
+	}
+}
+
+// setLocked stores value, making room first when the cache is full. c.mu must be held.
+func (c *TTL) setLocked(key string, value interface{}) {
+	now := time.Now()
+	if _, exists := c.entries[key]; !exists && c.maxEntries > 0 && len(c.entries) >= c.maxEntries {
+		c.evictLocked(now)
+	}
+	c.entries[key] = entry{value: value, expires: now.Add(c.ttl)}
+}
+
+// evictLocked drops expired entries, or the entry closest to expiry when none
+// have expired. c.mu must be held.
+func (c *TTL) evictLocked(now time.Time) {
+	var (
+		oldestKey string
+		oldest    time.Time
+	)
+	for key, e := range c.entries {
+		if now.After(e.expires) {
+			delete(c.entries, key)
+			continue
+		}
+		if oldestKey == "" || e.expires.Before(oldest) {
+			oldestKey, oldest = key, e.expires
+		}
+	}
+	if len(c.entries) >= c.maxEntries && oldestKey != "" {
+		delete(c.entries, oldestKey)
+	}
+}
@@ -0,0 +1,346 @@
+// Package cloudevents reads and writes CloudEvents 1.0 over HTTP, in both the
+// structured content mode, where the whole event is the JSON request body, and
+// the binary content mode, where attributes travel as ce- headers and the body
+// is the event data.
+package cloudevents
+
+import (
+	// go1.21 - Event encoding, identifiers and HTTP bindings
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// SpecVersion is the CloudEvents version read and written.
+const SpecVersion = "1.0"
+
+// ContentType is the media type of structured mode events.
+const ContentType = "application/cloudevents+json"
+
+// headerPrefix marks binary mode attribute headers.
+const headerPrefix = "Ce-"
+
+// maxEventBytes bounds the request bodies ReadRequest accepts.
+const maxEventBytes = 1 << 20
+
+var (
+	// ErrInvalidEvent indicates an event that is malformed or misses a
+	// required attribute.
+	ErrInvalidEvent = errors.New("invalid cloudevent")
+
+	// ErrUnsupportedVersion indicates an event of another specversion.
+	ErrUnsupportedVersion = errors.New("unsupported cloudevents specversion")
+)
+
+// Modes in which an event is carried over HTTP.
+const (
+	ModeStructured = "structured"
+	ModeBinary     = "binary"
+)
+
+// Event is a CloudEvent. Data holds the event data as JSON; events whose data
+// is not JSON keep it in DataBase64 instead.
+type Event struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`
+	Subject         string          `json:"subject,omitempty"`
+	Time            *time.Time      `json:"time,omitempty"`
+	DataContentType string          `json:"datacontenttype,omitempty"`
+	DataSchema      string          `json:"dataschema,omitempty"`
+	Data            json.RawMessage `json:"data,omitempty"`
+	DataBase64      string          `json:"data_base64,omitempty"`
+
+	// Extensions are the event's other attributes, e.g. "integration".
+	Extensions map[string]string `json:"-"`
+}
+
+// New creates an event of eventType from source, with a random ID, the current
+// time and data encoded as JSON.
+func New(source, eventType string, data interface{}) (*Event, error) {
+	e := &Event{
+		SpecVersion:     SpecVersion,
+		ID:              newID(),
+		Source:          source,
+		Type:            eventType,
+		DataContentType: "application/json",
+	}
+	now := time.Now().UTC()
+	e.Time = &now
+	if data != nil {
+		raw, err := json.Marshal(data)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidEvent, err)
+		}
+		e.Data = raw
+	}
+	return e, nil
+}
+
+// Validate checks the required attributes.
+func (e *Event) Validate() error {
+	if e.SpecVersion != SpecVersion {
+		return fmt.Errorf("%w: %q", ErrUnsupportedVersion, e.SpecVersion)
+	}
+	if e.ID == "" || e.Source == "" || e.Type == "" {
+		return fmt.Errorf("%w: id, source and type are required", ErrInvalidEvent)
+	}
+	if _, err := url.Parse(e.Source); err != nil {
+		return fmt.Errorf("%w: source: %v", ErrInvalidEvent, err)
+	}
+	return nil
+}
+
+// Payload returns the event data, decoding data_base64 when the event has no
+// JSON data.
+func (e *Event) Payload() ([]byte, error) {
+	if e.DataBase64 == "" {
+		return e.Data, nil
+	}
+	data, err := base64.StdEncoding.DecodeString(e.DataBase64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: data_base64: %v", ErrInvalidEvent, err)
+	}
+	return data, nil
+}
+
+// SetData sets the event data and its content type. JSON data is kept as is;
+// other data is base64-encoded, as the JSON event format requires.
+func (e *Event) SetData(contentType string, data []byte) {
+	e.DataContentType = contentType
+	e.Data, e.DataBase64 = nil, ""
+	if strings.Contains(contentType, "json") && json.Valid(data) {
+		e.Data = data
+		return
+	}
+	e.DataBase64 = base64.StdEncoding.EncodeToString(data)
+}
+
+// Extension returns the extension attribute name, if set.
+func (e *Event) Extension(name string) string {
+	return e.Extensions[strings.ToLower(name)]
+}
+
+// SetExtension sets the extension attribute name.
+func (e *Event) SetExtension(name, value string) {
+	if e.Extensions == nil {
+		e.Extensions = make(map[string]string)
+	}
+	e.Extensions[strings.ToLower(name)] = value
+}
+
+// contextAttributes are the attributes of Event with struct fields.
+var contextAttributes = map[string]bool{
+	"specversion": true, "id": true, "source": true, "type": true, "subject": true,
+	"time": true, "datacontenttype": true, "dataschema": true, "data": true, "data_base64": true,
+}
+
+// MarshalJSON encodes the event in the JSON event format, with extensions as
+// top-level attributes.
+func (e Event) MarshalJSON() ([]byte, error) {
+	type plain Event
+	data, err := json.Marshal(plain(e))
+	if err != nil || len(e.Extensions) == 0 {
+		return data, err
+	}
+	var attrs map[string]interface{}
+	if err := json.Unmarshal(data, &attrs); err != nil {
+		return nil, err
+	}
+	for name, value := range e.Extensions {
+		if !contextAttributes[name] {
+			attrs[name] = value
+		}
+	}
+	return json.Marshal(attrs)
+}
+
+// UnmarshalJSON decodes the JSON event format, collecting string, number and
+// boolean attributes other than the context attributes as extensions.
+func (e *Event) UnmarshalJSON(data []byte) error {
+	type plain Event
+	var p plain
+	if err := json.Unmarshal(data, &p); err != nil {
+		return err
+	}
+	var attrs map[string]json.RawMessage
+	if err := json.Unmarshal(data, &attrs); err != nil {
+		return err
+	}
+	*e = Event(p)
+	for name, raw := range attrs {
+		if contextAttributes[name] {
+			continue
+		}
+		var value interface{}
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return err
+		}
+		switch v := value.(type) {
+		case string:
+			e.SetExtension(name, v)
+		case float64, bool:
+			e.SetExtension(name, fmt.Sprint(v))
+		}
+	}
+	return nil
+}
+
+// RequestMode returns the content mode in which r carries a CloudEvent, or an
+// empty string when r carries none.
+func RequestMode(r *http.Request) string {
+	switch {
+	case isStructured(r.Header.Get("Content-Type")):
+		return ModeStructured
+	case r.Header.Get(headerPrefix+"Specversion") != "":
+		return ModeBinary
+	}
+	return ""
+}
+
+// isStructured reports whether contentType is the structured mode media type.
+func isStructured(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && mediaType == ContentType
+}
+
+// ReadRequest reads the CloudEvent carried by r in structured or binary mode,
+// and validates it. Call it only when RequestMode(r) is not empty.
+func ReadRequest(r *http.Request) (*Event, error) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxEventBytes))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidEvent, err)
+	}
+
+	var e Event
+	if isStructured(r.Header.Get("Content-Type")) {
+		if err := json.Unmarshal(body, &e); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidEvent, err)
+		}
+	} else {
+		e, err = readBinary(r.Header, body)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := e.Validate(); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// readBinary reads a binary mode event from its ce- headers and body.
+func readBinary(header http.Header, body []byte) (Event, error) {
+	e := Event{DataContentType: header.Get("Content-Type")}
+	for key, values := range header {
+		if !strings.HasPrefix(key, headerPrefix) || len(values) == 0 {
+			continue
+		}
+		name := strings.ToLower(strings.TrimPrefix(key, headerPrefix))
+		value, err := url.PathUnescape(values[0])
+		if err != nil {
+			return e, fmt.Errorf("%w: header %s: %v", ErrInvalidEvent, key, err)
+		}
+		switch name {
+		case "specversion":
+			e.SpecVersion = value
+		case "id":
+			e.ID = value
+		case "source":
+			e.Source = value
+		case "type":
+			e.Type = value
+		case "subject":
+			e.Subject = value
+		case "dataschema":
+			e.DataSchema = value
+		case "time":
+			t, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return e, fmt.Errorf("%w: time: %v", ErrInvalidEvent, err)
+			}
+			e.Time = &t
+		default:
+			e.SetExtension(name, value)
+		}
+	}
+	if len(body) == 0 {
+		return e, nil
+	}
+	if e.DataContentType == "" || strings.Contains(e.DataContentType, "json") {
+		if !json.Valid(body) {
+			return e, fmt.Errorf("%w: data is not valid JSON", ErrInvalidEvent)
+		}
+		e.Data = body
+	} else {
+		e.DataBase64 = base64.StdEncoding.EncodeToString(body)
+	}
+	return e, nil
+}
+
+// WriteStructured returns the structured mode body of e; its content type is
+// ContentType.
+func WriteStructured(e *Event) ([]byte, error) {
+	return json.Marshal(e)
+}
+
+// WriteBinary sets e's attributes as ce- headers on header and returns the
+// body, which is the event data; its content type is e.DataContentType.
+func WriteBinary(header http.Header, e *Event) ([]byte, error) {
+	set := func(name, value string) {
+		if value != "" {
+			header.Set(textproto.CanonicalMIMEHeaderKey(headerPrefix+name), encodeHeader(value))
+		}
+	}
+	set("specversion", e.SpecVersion)
+	set("id", e.ID)
+	set("source", e.Source)
+	set("type", e.Type)
+	set("subject", e.Subject)
+	set("dataschema", e.DataSchema)
+	if e.Time != nil {
+		set("time", e.Time.UTC().Format(time.RFC3339Nano))
+	}
+	for name, value := range e.Extensions {
+		if !contextAttributes[name] {
+			set(name, value)
+		}
+	}
+	return e.Payload()
+}
+
+// encodeHeader percent-encodes the characters the HTTP binding requires to be
+// escaped in header values: controls, space, double quote, percent and
+// non-ASCII.
+func encodeHeader(value string) string {
+	var b bytes.Buffer
+	for _, c := range []byte(value) {
+		if c <= ' ' || c == '"' || c == '%' || c >= 0x7f {
+			fmt.Fprintf(&b, "%%%02X", c)
+			continue
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+// newID returns a random event ID.
+func newID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return fmt.Sprintf("%d", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b[:])
+}
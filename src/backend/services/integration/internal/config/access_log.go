@@ -0,0 +1,49 @@
+package config
+
+import (
+	// go1.21 - Sampled path validation
+	"strings"
+)
+
+// AccessLogConfig configures the structured access log written for every
+// request served by the API listener.
+type AccessLogConfig struct {
+	// Enabled writes one log entry per request.
+	Enabled bool `json:"enabled" mapstructure:"enabled"`
+
+	// SampledPaths lists high-volume paths, such as probes and the metrics
+	// scrape, whose successful requests are sampled rather than all logged.
+	// Requests to them that fail with a 4xx or 5xx status are always logged.
+	SampledPaths []string `json:"sampledPaths" mapstructure:"sampledPaths"`
+
+	// SampleEvery logs one in every SampleEvery successful requests to each
+	// sampled path; 1 logs all of them.
+	SampleEvery int `json:"sampleEvery" mapstructure:"sampleEvery"`
+}
+
+// AccessLogEnabled reports whether requests are written to the access log.
+func (s *ServerConfig) AccessLogEnabled() bool {
+	return s != nil && s.AccessLog != nil && s.AccessLog.Enabled
+}
+
+// validate checks the sampled paths and rate.
+func (a *AccessLogConfig) validate() error {
+	if a == nil || !a.Enabled {
+		return nil
+	}
+	if a.SampleEvery < 1 {
+		return &ConfigError{
+			Context: "Server access log",
+			Message: "sampleEvery must be at least 1",
+		}
+	}
+	for _, path := range a.SampledPaths {
+		if !strings.HasPrefix(path, "/") {
+			return &ConfigError{
+				Context: "Server access log",
+				Message: "sampledPaths must be absolute paths, found: " + path,
+			}
+		}
+	}
+	return nil
+}
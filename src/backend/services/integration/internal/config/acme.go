@@ -0,0 +1,106 @@
+package config
+
+import (
+	// go1.21 - Challenge listener address validation
+	"net"
+	// go1.21 - Renewal window
+	"time"
+)
+
+// ACME challenge types for ACMEConfig.Challenge.
+const (
+	// ACMEChallengeTLSALPN answers TLS-ALPN-01 challenges on the TLS listener
+	// itself, so no other port has to be reachable. The listener must be
+	// reachable by the CA on port 443.
+	ACMEChallengeTLSALPN = "tls-alpn-01"
+
+	// ACMEChallengeHTTP answers HTTP-01 challenges on a plaintext listener at
+	// ACMEConfig.HTTPAddr, which must be reachable by the CA on port 80. Other
+	// plaintext requests are redirected to HTTPS.
+	ACMEChallengeHTTP = "http-01"
+)
+
+// ACMEConfig obtains and renews the server certificate from an ACME CA such as
+// Let's Encrypt, in place of ServerTLSConfig.CertFile and KeyFile, for edge
+// deployments that terminate TLS themselves.
+type ACMEConfig struct {
+	// Enabled obtains certificates through ACME.
+	Enabled bool `json:"enabled" mapstructure:"enabled"`
+
+	// Domains are the host names certificates are obtained for; TLS handshakes
+	// for other names are refused, so that clients cannot make the service
+	// request arbitrary certificates.
+	Domains []string `json:"domains" mapstructure:"domains"`
+
+	// Email is the account contact the CA sends expiry and policy notices to.
+	Email string `json:"email" mapstructure:"email"`
+
+	// CacheDir stores the account key and certificates across restarts, so that
+	// they are not requested again on every start and rate limits are not hit.
+	CacheDir string `json:"cacheDir" mapstructure:"cacheDir"`
+
+	// DirectoryURL is the CA's ACME directory; Let's Encrypt production when
+	// empty. Point it at the staging directory while testing.
+	DirectoryURL string `json:"directoryURL" mapstructure:"directoryURL"`
+
+	// Challenge is "tls-alpn-01" or "http-01".
+	Challenge string `json:"challenge" mapstructure:"challenge"`
+
+	// HTTPAddr is the listen address of the HTTP-01 challenge listener, e.g. ":80".
+	HTTPAddr string `json:"httpAddr" mapstructure:"httpAddr"`
+
+	// RenewBefore is how long before expiry certificates are renewed; the
+	// library default of 30 days when zero.
+	RenewBefore time.Duration `json:"renewBefore" mapstructure:"renewBefore"`
+}
+
+// ACMEEnabled reports whether the server certificate is obtained through ACME.
+func (t *ServerTLSConfig) ACMEEnabled() bool {
+	return t != nil && t.ACME != nil && t.ACME.Enabled
+}
+
+// HTTPChallenge reports whether HTTP-01 challenges are answered.
+func (a *ACMEConfig) HTTPChallenge() bool {
+	return a != nil && a.Challenge == ACMEChallengeHTTP
+}
+
+// validate checks the domains, cache directory and challenge settings.
+func (a *ACMEConfig) validate() error {
+	if a == nil || !a.Enabled {
+		return nil
+	}
+	if len(a.Domains) == 0 {
+		return &ConfigError{
+			Context: "Server ACME",
+			Message: "ACME requires at least one domain",
+		}
+	}
+	if a.CacheDir == "" {
+		return &ConfigError{
+			Context: "Server ACME",
+			Message: "ACME requires a cacheDir, so certificates survive restarts",
+		}
+	}
+	if a.RenewBefore < 0 {
+		return &ConfigError{
+			Context: "Server ACME",
+			Message: "renewBefore must not be negative",
+		}
+	}
+	switch a.Challenge {
+	case ACMEChallengeTLSALPN:
+	case ACMEChallengeHTTP:
+		if _, _, err := net.SplitHostPort(a.HTTPAddr); err != nil {
+			return &ConfigError{
+				Context: "Server ACME",
+				Message: "http-01 requires httpAddr as host:port or :port, found: " + a.HTTPAddr,
+			}
+		}
+	default:
+		return &ConfigError{
+			Context: "Server ACME",
+			Message: "challenge must be tls-alpn-01 or http-01, found: " + a.Challenge,
+		}
+	}
+	return nil
+}
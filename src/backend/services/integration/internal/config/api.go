@@ -0,0 +1,90 @@
+package config
+
+import (
+	// go1.21 - Deprecation and sunset dates
+	"fmt"
+	"time"
+)
+
+// APIConfig configures the versions of the HTTP API served side by side.
+type APIConfig struct {
+	// V1 describes the lifecycle of /api/v1 while callers migrate to /api/v2.
+	V1 *APIVersionConfig `json:"v1" mapstructure:"v1"`
+}
+
+// APIVersionConfig describes the lifecycle of one API version. Deprecated
+// versions keep working; their responses carry Deprecation, Sunset and Link
+// headers pointing callers to the successor version.
+type APIVersionConfig struct {
+	// Deprecated marks the version as deprecated.
+	Deprecated bool `json:"deprecated" mapstructure:"deprecated"`
+
+	// DeprecatedAt is when the version was deprecated, as an RFC 3339 date,
+	// e.g. "2024-07-01T00:00:00Z". Optional.
+	DeprecatedAt string `json:"deprecatedAt" mapstructure:"deprecatedAt"`
+
+	// Sunset is when the version is expected to stop being served, as an RFC
+	// 3339 date. Optional.
+	Sunset string `json:"sunset" mapstructure:"sunset"`
+}
+
+// IsDeprecated reports whether the version is deprecated.
+func (v *APIVersionConfig) IsDeprecated() bool {
+	return v != nil && v.Deprecated
+}
+
+// DeprecationTime returns when the version was deprecated; zero when unset.
+func (v *APIVersionConfig) DeprecationTime() time.Time {
+	if v == nil {
+		return time.Time{}
+	}
+	t, _ := time.Parse(time.RFC3339, v.DeprecatedAt)
+	return t
+}
+
+// SunsetTime returns when the version stops being served; zero when unset.
+func (v *APIVersionConfig) SunsetTime() time.Time {
+	if v == nil {
+		return time.Time{}
+	}
+	t, _ := time.Parse(time.RFC3339, v.Sunset)
+	return t
+}
+
+// validate checks the v1 lifecycle dates.
+func (a *APIConfig) validate() error {
+	if a == nil {
+		return nil
+	}
+	return a.V1.validate("v1")
+}
+
+// validate checks that the dates parse and that the sunset does not precede
+// the deprecation.
+func (v *APIVersionConfig) validate(version string) error {
+	if v == nil {
+		return nil
+	}
+	for _, date := range []struct{ name, value string }{
+		{"deprecatedAt", v.DeprecatedAt},
+		{"sunset", v.Sunset},
+	} {
+		if date.value == "" {
+			continue
+		}
+		if _, err := time.Parse(time.RFC3339, date.value); err != nil {
+			return &ConfigError{
+				Context: "API",
+				Message: fmt.Sprintf("api.%s.%s must be an RFC 3339 date: %v", version, date.name, err),
+			}
+		}
+	}
+	deprecatedAt, sunset := v.DeprecationTime(), v.SunsetTime()
+	if !deprecatedAt.IsZero() && !sunset.IsZero() && sunset.Before(deprecatedAt) {
+		return &ConfigError{
+			Context: "API",
+			Message: fmt.Sprintf("api.%s.sunset must not precede deprecatedAt", version),
+		}
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package config
+
+import (
+	// go1.21 - Request timeout
+	"time"
+)
+
+// AsanaConfig configures task creation in Asana projects.
+type AsanaConfig struct {
+	// BaseURL is the Asana API base URL; the default is the public API.
+	BaseURL string `json:"baseURL" mapstructure:"baseURL"`
+
+	// PersonalAccessToken authenticates the account that creates tasks.
+	PersonalAccessToken string `json:"personalAccessToken" mapstructure:"personalAccessToken"`
+
+	// DefaultProject is the project GID tasks are created in when a payload
+	// names no project.
+	DefaultProject string `json:"defaultProject" mapstructure:"defaultProject"`
+
+	// DefaultSection is the section GID, within DefaultProject, for new tasks.
+	// Empty leaves tasks in the project's first section.
+	DefaultSection string `json:"defaultSection" mapstructure:"defaultSection"`
+
+	// Sections maps section names used in payloads to section GIDs. Names are
+	// matched case-insensitively; unmapped values are used as GIDs.
+	Sections map[string]string `json:"sections" mapstructure:"sections"`
+
+	// Assignees maps TaskStream users (for example, email addresses) to Asana
+	// user GIDs or emails, matched like Sections.
+	Assignees map[string]string `json:"assignees" mapstructure:"assignees"`
+
+	// Timeout bounds each call to Asana.
+	Timeout time.Duration `json:"timeout" mapstructure:"timeout"`
+}
+
+// validate checks the base URL and token. An absent section, or one without a
+// token, leaves Asana unconfigured.
+func (a *AsanaConfig) validate() error {
+	if a == nil || a.PersonalAccessToken == "" {
+		return nil
+	}
+	if !isHTTPURL(a.BaseURL) {
+		return &ConfigError{
+			Context: "Asana",
+			Message: "baseURL must be an absolute http or https URL",
+		}
+	}
+	if a.DefaultSection != "" && a.DefaultProject == "" {
+		return &ConfigError{
+			Context: "Asana",
+			Message: "defaultSection requires a defaultProject",
+		}
+	}
+	if a.Timeout < 0 {
+		return &ConfigError{
+			Context: "Asana",
+			Message: "Asana timeout must not be negative",
+		}
+	}
+	return nil
+}
@@ -0,0 +1,301 @@
+package config
+
+import (
+	// go1.21 - Case normalization for role names
+	"strings"
+	// go1.21 - Durations for cache TTLs and request timeouts
+	"time"
+)
+
+// IntrospectionConfig configures validation of opaque OAuth2 access tokens against
+// an RFC 7662 token introspection endpoint.
+type IntrospectionConfig struct {
+	// Enabled turns on bearer token introspection for the versioned API routes.
+	Enabled bool `json:"enabled" mapstructure:"enabled"`
+
+	// Endpoint is the authorization server's introspection URL.
+	Endpoint string `json:"endpoint" mapstructure:"endpoint"`
+
+	// ClientID is the resource server's client identifier used to authenticate introspection calls.
+	ClientID string `json:"clientId" mapstructure:"clientId"`
+
+	// ClientSecret is the resource server's client secret. Must be kept secure.
+	ClientSecret string `json:"clientSecret" mapstructure:"clientSecret"`
+
+	// Timeout bounds a single introspection request.
+	Timeout time.Duration `json:"timeout" mapstructure:"timeout"`
+
+	// CacheTTL is the maximum time an active introspection result is reused.
+	// Entries never outlive the token's own "exp" claim.
+	CacheTTL time.Duration `json:"cacheTTL" mapstructure:"cacheTTL"`
+
+	// NegativeCacheTTL is how long an inactive token result is remembered.
+	NegativeCacheTTL time.Duration `json:"negativeCacheTTL" mapstructure:"negativeCacheTTL"`
+
+	// CacheSize caps the number of cached introspection results.
+	CacheSize int `json:"cacheSize" mapstructure:"cacheSize"`
+}
+
+// RouteScopes lists the OAuth2 scopes a caller must hold to invoke a route.
+type RouteScopes struct {
+	// Route is the mux path template, e.g. "/api/v1/slack/post".
+	Route string `json:"route" mapstructure:"route"`
+
+	// Scopes are all required; a caller missing any of them is rejected with 403.
+	Scopes []string `json:"scopes" mapstructure:"scopes"`
+}
+
+// AuthConfig groups authentication settings for inbound API requests.
+type AuthConfig struct {
+	// Introspection configures opaque token validation.
+	Introspection *IntrospectionConfig `json:"introspection" mapstructure:"introspection"`
+
+	// RequiredScopes declares per-route scope requirements enforced after authentication.
+	RequiredScopes []RouteScopes `json:"requiredScopes" mapstructure:"requiredScopes"`
+
+	// Authorization is the role-based access control policy.
+	Authorization *AuthorizationConfig `json:"authorization" mapstructure:"authorization"`
+
+	// Basic configures the credential store for Basic-authenticated operational endpoints.
+	Basic *BasicAuthConfig `json:"basic" mapstructure:"basic"`
+}
+
+// ScopesForRoute returns the scopes required for the given path template.
+func (a *AuthConfig) ScopesForRoute(route string) []string {
+	if a == nil {
+		return nil
+	}
+	for _, rs := range a.RequiredScopes {
+		if rs.Route == route {
+			return rs.Scopes
+		}
+	}
+	return nil
+}
+
+// IntrospectionEnabled reports whether bearer tokens are validated via introspection.
+func (a *AuthConfig) IntrospectionEnabled() bool {
+	return a != nil && a.Introspection != nil && a.Introspection.Enabled
+}
+
+// BasicConfig returns the basic auth credential settings, or nil if unset.
+func (a *AuthConfig) BasicConfig() *BasicAuthConfig {
+	if a == nil {
+		return nil
+	}
+	return a.Basic
+}
+
+// AuthorizationEnabled reports whether the RBAC policy is enforced.
+func (a *AuthConfig) AuthorizationEnabled() bool {
+	return a != nil && a.Authorization != nil && a.Authorization.Enabled
+}
+
+// validate verifies the introspection endpoint and credentials when enabled,
+// and the consistency of the authorization policy.
+func (a *AuthConfig) validate() error {
+	if a == nil {
+		return nil
+	}
+	if err := a.Authorization.validate(); err != nil {
+		return err
+	}
+	if err := a.Basic.validate(); err != nil {
+		return err
+	}
+	if !a.IntrospectionEnabled() {
+		return nil
+	}
+
+	in := a.Introspection
+	if in.Endpoint == "" || in.ClientID == "" || in.ClientSecret == "" {
+		return &ConfigError{
+			Context: "Auth Introspection",
+			Message: "Introspection requires endpoint, clientId, and clientSecret",
+		}
+	}
+	if in.Timeout <= 0 || in.CacheTTL < 0 || in.NegativeCacheTTL < 0 || in.CacheSize <= 0 {
+		return &ConfigError{
+			Context: "Auth Introspection",
+			Message: "Introspection timeout and cacheSize must be positive and cache TTLs non-negative",
+		}
+	}
+
+	for _, rs := range a.RequiredScopes {
+		if rs.Route == "" {
+			return &ConfigError{
+				Context: "Auth Scopes",
+				Message: "Required scope entries must specify a route",
+			}
+		}
+	}
+
+	return nil
+}
+
+// RoleBinding grants roles to authenticated principals matched by subject or client ID.
+type RoleBinding struct {
+	// Subjects lists principal subjects (token "sub", certificate SAN, or username) bound to Roles.
+	Subjects []string `json:"subjects" mapstructure:"subjects"`
+
+	// CommonNames lists client certificate Subject Common Names bound to Roles.
+	// They only match callers authenticated by a certificate verified against
+	// the server's client CA bundles, so a token cannot claim them.
+	CommonNames []string `json:"commonNames" mapstructure:"commonNames"`
+
+	// Roles are the role names granted to the matching subjects.
+	Roles []string `json:"roles" mapstructure:"roles"`
+}
+
+// RoutePermissions lists the permissions a principal must hold to invoke a route.
+type RoutePermissions struct {
+	// Route is the mux path template, e.g. "/api/v1/slack/post".
+	Route string `json:"route" mapstructure:"route"`
+
+	// Methods limits the entry to these HTTP methods, e.g. ["PUT"]; empty
+	// applies it to every method of the route.
+	Methods []string `json:"methods" mapstructure:"methods"`
+
+	// Permissions are all required, e.g. "send:slack".
+	Permissions []string `json:"permissions" mapstructure:"permissions"`
+}
+
+// appliesTo reports whether the entry covers method.
+func (rp *RoutePermissions) appliesTo(method string) bool {
+	if len(rp.Methods) == 0 {
+		return true
+	}
+	for _, m := range rp.Methods {
+		if strings.EqualFold(m, method) {
+			return true
+		}
+	}
+	return false
+}
+
+// routeMethods are the HTTP methods RoutePermissions.Methods may name.
+var routeMethods = map[string]bool{
+	"GET": true, "HEAD": true, "POST": true, "PUT": true, "PATCH": true, "DELETE": true, "OPTIONS": true,
+}
+
+// AuthorizationConfig defines the role-based access control policy applied after
+// authentication, on the versioned API and on the operator endpoints and
+// dashboard, where operator accounts are bound by username. Routes without an
+// entry in Routes fall back to the built-in policy (see auth.Authorizer), e.g.
+// resetting a circuit breaker requires "operate:integrations".
+type AuthorizationConfig struct {
+	// Enabled turns on per-route permission enforcement.
+	Enabled bool `json:"enabled" mapstructure:"enabled"`
+
+	// Roles maps a role name to the permissions it grants. Role names are
+	// case-insensitive, as configuration keys are normalized to lower case.
+	Roles map[string][]string `json:"roles" mapstructure:"roles"`
+
+	// Bindings assign roles to principals.
+	Bindings []RoleBinding `json:"bindings" mapstructure:"bindings"`
+
+	// Routes declares the permissions required per route.
+	Routes []RoutePermissions `json:"routes" mapstructure:"routes"`
+
+	// DefaultDeny rejects requests to routes that have no entry in Routes.
+	DefaultDeny bool `json:"defaultDeny" mapstructure:"defaultDeny"`
+}
+
+// PermissionsForRoute returns the permissions Routes requires for a method on a
+// route template and whether Routes covers it. The first matching entry wins.
+func (a *AuthorizationConfig) PermissionsForRoute(method, route string) ([]string, bool) {
+	for i := range a.Routes {
+		if rp := &a.Routes[i]; rp.Route == route && rp.appliesTo(method) {
+			return rp.Permissions, true
+		}
+	}
+	return nil, false
+}
+
+// validate ensures every binding references a defined role and every route
+// entry names a route and known methods.
+func (a *AuthorizationConfig) validate() error {
+	if a == nil || !a.Enabled {
+		return nil
+	}
+	for _, binding := range a.Bindings {
+		for _, role := range binding.Roles {
+			if _, ok := a.Roles[strings.ToLower(role)]; !ok {
+				return &ConfigError{
+					Context: "Authorization",
+					Message: "Role binding references undefined role: " + role,
+				}
+			}
+		}
+	}
+	for _, rp := range a.Routes {
+		if rp.Route == "" {
+			return &ConfigError{
+				Context: "Authorization",
+				Message: "Route permission entries must specify a route",
+			}
+		}
+		for _, m := range rp.Methods {
+			if !routeMethods[strings.ToUpper(m)] {
+				return &ConfigError{
+					Context: "Authorization",
+					Message: "Route permission entry for " + rp.Route + " names an unknown HTTP method: " + m,
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// BasicAuthUser is a single operator account for Basic-authenticated endpoints.
+type BasicAuthUser struct {
+	// Username is the login name.
+	Username string `json:"username" mapstructure:"username"`
+
+	// PasswordHash is the bcrypt hash of the password (e.g., from `htpasswd -nbB`).
+	// Plaintext passwords are never accepted in configuration.
+	PasswordHash string `json:"passwordHash" mapstructure:"passwordHash"`
+}
+
+// BasicAuthConfig configures the credential store protecting /health/secure and admin endpoints.
+type BasicAuthConfig struct {
+	// Users lists the accounts allowed to authenticate.
+	Users []BasicAuthUser `json:"users" mapstructure:"users"`
+
+	// MaxFailures is the number of failed attempts within FailureWindow that locks
+	// out a username/client pair. Zero disables throttling.
+	MaxFailures int `json:"maxFailures" mapstructure:"maxFailures"`
+
+	// ClientMaxFailures is the number of failed attempts within FailureWindow,
+	// across all usernames, that locks out a client, so that spraying one
+	// password over many usernames is throttled too. Zero disables it.
+	ClientMaxFailures int `json:"clientMaxFailures" mapstructure:"clientMaxFailures"`
+
+	// FailureWindow is the period over which failures are counted.
+	FailureWindow time.Duration `json:"failureWindow" mapstructure:"failureWindow"`
+
+	// LockoutDuration is how long a locked-out pair is rejected.
+	LockoutDuration time.Duration `json:"lockoutDuration" mapstructure:"lockoutDuration"`
+}
+
+// validate rejects users without bcrypt hashes and negative throttling limits.
+func (b *BasicAuthConfig) validate() error {
+	if b == nil {
+		return nil
+	}
+	if b.MaxFailures < 0 || b.ClientMaxFailures < 0 {
+		return &ConfigError{
+			Context: "Basic Auth",
+			Message: "maxFailures and clientMaxFailures must not be negative",
+		}
+	}
+	for _, u := range b.Users {
+		if u.Username == "" || !strings.HasPrefix(u.PasswordHash, "$2") {
+			return &ConfigError{
+				Context: "Basic Auth",
+				Message: "Each basic auth user requires a username and a bcrypt passwordHash",
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package config
+
+import (
+	// go1.21 - Request timeout
+	"time"
+)
+
+// AzureDevOpsConfig configures work item creation in an Azure DevOps project.
+type AzureDevOpsConfig struct {
+	// OrganizationURL is the organization's base URL, e.g.
+	// "https://dev.azure.com/contoso" or an Azure DevOps Server collection URL.
+	OrganizationURL string `json:"organizationURL" mapstructure:"organizationURL"`
+
+	// Project is the project work items are created in.
+	Project string `json:"project" mapstructure:"project"`
+
+	// PersonalAccessToken authenticates with the Work Items (read and write) scope.
+	PersonalAccessToken string `json:"personalAccessToken" mapstructure:"personalAccessToken"`
+
+	// DefaultWorkItemType is used when a payload names no type, e.g. "Task" or "Bug".
+	DefaultWorkItemType string `json:"defaultWorkItemType" mapstructure:"defaultWorkItemType"`
+
+	// DefaultAreaPath and DefaultIterationPath are applied when a payload names
+	// no area or iteration. Empty leaves the project's defaults.
+	DefaultAreaPath      string `json:"defaultAreaPath" mapstructure:"defaultAreaPath"`
+	DefaultIterationPath string `json:"defaultIterationPath" mapstructure:"defaultIterationPath"`
+
+	// AreaPaths maps area names used in payloads (for example, TaskStream team
+	// or component names) to area paths such as "Contoso\\Web". Names are
+	// matched case-insensitively; unmapped names are used as paths.
+	AreaPaths map[string]string `json:"areaPaths" mapstructure:"areaPaths"`
+
+	// IterationPaths maps iteration names used in payloads to iteration paths,
+	// matched like AreaPaths.
+	IterationPaths map[string]string `json:"iterationPaths" mapstructure:"iterationPaths"`
+
+	// Timeout bounds each call to Azure DevOps.
+	Timeout time.Duration `json:"timeout" mapstructure:"timeout"`
+}
+
+// validate checks the organization URL, project and token. An absent section,
+// or one without an organization URL, leaves Azure DevOps unconfigured.
+func (a *AzureDevOpsConfig) validate() error {
+	if a == nil || a.OrganizationURL == "" {
+		return nil
+	}
+	if !isHTTPURL(a.OrganizationURL) {
+		return &ConfigError{
+			Context: "Azure DevOps",
+			Message: "organizationURL must be an absolute http or https URL",
+		}
+	}
+	if a.Project == "" || a.PersonalAccessToken == "" {
+		return &ConfigError{
+			Context: "Azure DevOps",
+			Message: "Azure DevOps requires a project and personalAccessToken",
+		}
+	}
+	if a.Timeout < 0 {
+		return &ConfigError{
+			Context: "Azure DevOps",
+			Message: "Azure DevOps timeout must not be negative",
+		}
+	}
+	return nil
+}
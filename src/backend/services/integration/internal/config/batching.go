@@ -0,0 +1,104 @@
+package config
+
+import (
+	// go1.21 - Coalescing windows
+	"time"
+)
+
+// BatchSpec controls how payloads for one integration are coalesced.
+type BatchSpec struct {
+	// Window is how long the first payload of a batch waits for others to join it.
+	Window time.Duration `json:"window" mapstructure:"window"`
+
+	// MaxBatchSize flushes a batch as soon as it holds this many payloads.
+	MaxBatchSize int `json:"maxBatchSize" mapstructure:"maxBatchSize"`
+}
+
+// BatchOverride tunes or disables batching for one integration.
+type BatchOverride struct {
+	// Integration is the registered integration name, e.g. "jira".
+	Integration string `json:"integration" mapstructure:"integration"`
+
+	// Disabled turns batching off for this integration.
+	Disabled bool `json:"disabled" mapstructure:"disabled"`
+
+	// Spec holds the window and size for this integration.
+	Spec BatchSpec `json:"spec" mapstructure:"spec"`
+}
+
+// BatchingConfig configures the optional coalescing stage in front of
+// adapters that support batch sends, cutting API call volume during bursts.
+type BatchingConfig struct {
+	// Enabled turns the batching stage on.
+	Enabled bool `json:"enabled" mapstructure:"enabled"`
+
+	// Default applies to every batch-capable integration without an override.
+	Default BatchSpec `json:"default" mapstructure:"default"`
+
+	// Overrides tune individual integrations, e.g. a larger batch for Jira bulk create.
+	Overrides []BatchOverride `json:"overrides" mapstructure:"overrides"`
+
+	// MaxPending caps the payloads held by the batching stage, in open batches
+	// or waiting for a dispatch worker; further sends fail fast as the dispatch
+	// queue does when full. Defaults to defaultBatchMaxPending.
+	MaxPending int `json:"maxPending" mapstructure:"maxPending"`
+}
+
+// defaultBatchMaxPending is the payload cap used when MaxPending is unset.
+const defaultBatchMaxPending = 1024
+
+// PendingLimit returns MaxPending, or the default when unset.
+func (b *BatchingConfig) PendingLimit() int {
+	if b == nil || b.MaxPending <= 0 {
+		return defaultBatchMaxPending
+	}
+	return b.MaxPending
+}
+
+// ForIntegration returns the batch settings for the named integration and
+// whether batching applies to it.
+func (b *BatchingConfig) ForIntegration(name string) (BatchSpec, bool) {
+	if b == nil || !b.Enabled {
+		return BatchSpec{}, false
+	}
+	for _, o := range b.Overrides {
+		if o.Integration == name {
+			return o.Spec, !o.Disabled
+		}
+	}
+	return b.Default, true
+}
+
+// validate checks windows and sizes when batching is enabled.
+func (b *BatchingConfig) validate() error {
+	if b == nil || !b.Enabled {
+		return nil
+	}
+	if b.MaxPending < 0 {
+		return &ConfigError{
+			Context: "Batching",
+			Message: "Batching maxPending must not be negative",
+		}
+	}
+	specs := []BatchSpec{b.Default}
+	for _, o := range b.Overrides {
+		if o.Integration == "" {
+			return &ConfigError{
+				Context: "Batching",
+				Message: "Batching overrides must name an integration",
+			}
+		}
+		if !o.Disabled {
+			specs = append(specs, o.Spec)
+		}
+	}
+	for _, spec := range specs {
+		if spec.Window <= 0 || spec.MaxBatchSize <= 0 {
+			return &ConfigError{
+				Context: "Batching",
+				Message: "Batching requires a positive window and maxBatchSize",
+			}
+		}
+	}
+	return nil
+}
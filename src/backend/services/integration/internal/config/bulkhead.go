@@ -0,0 +1,96 @@
+package config
+
+import (
+	// go1.21 - Queue wait limits
+	"time"
+)
+
+// Bulkhead saturation policies for BulkheadSpec.Policy.
+const (
+	// BulkheadPolicyQueue makes callers wait, up to MaxQueue waiters and
+	// QueueTimeout, for a free slot when the integration is saturated.
+	BulkheadPolicyQueue = "queue"
+
+	// BulkheadPolicyReject fails calls immediately when the integration is saturated.
+	BulkheadPolicyReject = "reject"
+)
+
+// BulkheadSpec limits concurrent calls into one integration so that a flood of
+// requests to one provider cannot exhaust goroutines and connections needed by
+// the others.
+type BulkheadSpec struct {
+	// MaxConcurrent is the number of calls allowed to run at once.
+	MaxConcurrent int `json:"maxConcurrent" mapstructure:"maxConcurrent"`
+
+	// MaxQueue is the number of callers allowed to wait for a slot under the queue policy.
+	MaxQueue int `json:"maxQueue" mapstructure:"maxQueue"`
+
+	// QueueTimeout bounds how long a queued caller waits for a slot.
+	QueueTimeout time.Duration `json:"queueTimeout" mapstructure:"queueTimeout"`
+
+	// Policy is "queue" or "reject".
+	Policy string `json:"policy" mapstructure:"policy"`
+}
+
+// BulkheadOverride replaces the default bulkhead limits for one integration.
+type BulkheadOverride struct {
+	// Integration is the registered integration name, e.g. "jira".
+	Integration string `json:"integration" mapstructure:"integration"`
+
+	// Spec holds the limits for this integration.
+	Spec BulkheadSpec `json:"spec" mapstructure:"spec"`
+}
+
+// BulkheadConfig configures per-integration concurrency isolation.
+type BulkheadConfig struct {
+	// Default applies to every integration without an override.
+	Default BulkheadSpec `json:"default" mapstructure:"default"`
+
+	// Overrides tune individual integrations.
+	Overrides []BulkheadOverride `json:"overrides" mapstructure:"overrides"`
+}
+
+// ForIntegration returns the bulkhead limits for the named integration.
+func (b *BulkheadConfig) ForIntegration(name string) BulkheadSpec {
+	if b == nil {
+		return BulkheadSpec{}
+	}
+	for _, o := range b.Overrides {
+		if o.Integration == name {
+			return o.Spec
+		}
+	}
+	return b.Default
+}
+
+// validate checks every bulkhead spec for positive concurrency and a known policy.
+func (b *BulkheadConfig) validate() error {
+	if b == nil {
+		return nil
+	}
+	specs := []BulkheadSpec{b.Default}
+	for _, o := range b.Overrides {
+		if o.Integration == "" {
+			return &ConfigError{
+				Context: "Bulkheads",
+				Message: "Bulkhead overrides must name an integration",
+			}
+		}
+		specs = append(specs, o.Spec)
+	}
+	for _, spec := range specs {
+		if spec.MaxConcurrent <= 0 || spec.MaxQueue < 0 || spec.QueueTimeout < 0 {
+			return &ConfigError{
+				Context: "Bulkheads",
+				Message: "Bulkheads require a positive maxConcurrent and non-negative maxQueue and queueTimeout",
+			}
+		}
+		if spec.Policy != BulkheadPolicyQueue && spec.Policy != BulkheadPolicyReject {
+			return &ConfigError{
+				Context: "Bulkheads",
+				Message: "Bulkhead policy must be queue or reject, found: " + spec.Policy,
+			}
+		}
+	}
+	return nil
+}
@@ -42,6 +42,10 @@ type EmailConfig struct {
 	// UseTLS indicates whether to use TLS/SSL for outbound email.
 	UseTLS bool `json:"useTLS" mapstructure:"useTLS"`
 
+	// TLS tunes transport security: implicit TLS or STARTTLS, the minimum
+	// version, and certificate pinning.
+	TLS *TLSConfig `json:"tls" mapstructure:"tls"`
+
 	// FromAddress is the default sender address used for outgoing emails.
 	FromAddress string `json:"fromAddress" mapstructure:"fromAddress"`
 
@@ -50,17 +54,89 @@ type EmailConfig struct {
 
 	// RequireAuth indicates whether the email server requires authentication.
 	RequireAuth bool `json:"requireAuth" mapstructure:"requireAuth"`
+
+	// OAuth2, when set, authenticates with XOAUTH2 access tokens instead of
+	// the password.
+	OAuth2 *SMTPOAuth2Config `json:"oauth2" mapstructure:"oauth2"`
+
+	// Pool configures the bounded SMTP connection pool.
+	Pool *SMTPPoolConfig `json:"pool" mapstructure:"pool"`
+
+	// Failover lists fallback SMTP servers used when Host is unreachable.
+	Failover *SMTPFailoverConfig `json:"failover" mapstructure:"failover"`
+
+	// Inbound configures reading replies and bounces from an IMAP mailbox.
+	Inbound *InboundMailConfig `json:"inbound" mapstructure:"inbound"`
+
+	// Provider selects how email is sent: "smtp" (the default) through the
+	// server above, "sendgrid" through the SendGrid API, or "ses" through Amazon
+	// SES. The SMTP settings are ignored by API providers.
+	Provider string `json:"provider" mapstructure:"provider"`
+
+	// SendGrid configures the sendgrid provider.
+	SendGrid *SendGridConfig `json:"sendGrid" mapstructure:"sendGrid"`
+
+	// SES configures the ses provider.
+	SES *SESConfig `json:"ses" mapstructure:"ses"`
+}
+
+// Email providers for EmailConfig.Provider.
+const (
+	EmailProviderSMTP     = "smtp"
+	EmailProviderSendGrid = "sendgrid"
+	EmailProviderSES      = "ses"
+)
+
+// UsesSMTP reports whether email is sent through the SMTP server.
+func (e *EmailConfig) UsesSMTP() bool {
+	return e.Provider == "" || e.Provider == EmailProviderSMTP
+}
+
+// SMTPPoolConfig bounds and tunes the pool of authenticated SMTP connections
+// shared by concurrent email sends.
+type SMTPPoolConfig struct {
+	// MaxConnections caps the number of open SMTP connections, idle or in use.
+	MaxConnections int `json:"maxConnections" mapstructure:"maxConnections"`
+
+	// MaxSendsPerConnection retires a connection after this many messages, since
+	// many providers limit messages per session. Zero means unlimited.
+	MaxSendsPerConnection int `json:"maxSendsPerConnection" mapstructure:"maxSendsPerConnection"`
+
+	// IdleTimeout closes connections that have been idle for longer than this.
+	IdleTimeout time.Duration `json:"idleTimeout" mapstructure:"idleTimeout"`
+
+	// KeepaliveInterval is how often idle connections are probed with NOOP.
+	KeepaliveInterval time.Duration `json:"keepaliveInterval" mapstructure:"keepaliveInterval"`
+
+	// AcquireTimeout bounds how long a send waits for a free connection.
+	AcquireTimeout time.Duration `json:"acquireTimeout" mapstructure:"acquireTimeout"`
 }
 
 // SlackConfig holds advanced Slack-related configuration, including
-// authentication tokens and optional enterprise workspace management settings.
+// authentication tokens, call limits and optional enterprise workspace
+// management settings. It is the configuration the Slack adapter is
+// initialized with.
 type SlackConfig struct {
-	// Token is the Slack API token. Must be kept secure.
-	Token string `json:"token" mapstructure:"token"`
+	// APIToken is the Slack bot token. Must be kept secure.
+	APIToken string `json:"apiToken" mapstructure:"apiToken"`
 
 	// DefaultChannel is where system notifications or messages are sent by default.
 	DefaultChannel string `json:"defaultChannel" mapstructure:"defaultChannel"`
 
+	// Timeout bounds each Slack API call.
+	Timeout time.Duration `json:"timeout" mapstructure:"timeout"`
+
+	// RetryAttempts is how many times a call rate limited by Slack is retried
+	// after its Retry-After pause.
+	RetryAttempts int `json:"retryAttempts" mapstructure:"retryAttempts"`
+
+	// MaxRetryAfter is the longest Retry-After pause a call waits out before
+	// retrying; longer pauses fail the call.
+	MaxRetryAfter time.Duration `json:"maxRetryAfter" mapstructure:"maxRetryAfter"`
+
+	// RateLimit paces calls to each workspace.
+	RateLimit *SlackRateLimitConfig `json:"rateLimit" mapstructure:"rateLimit"`
+
 	// UseEnterprise indicates whether this Slack configuration applies to
 	// an Enterprise Grid environment, which might require additional scopes.
 	UseEnterprise bool `json:"useEnterprise" mapstructure:"useEnterprise"`
@@ -68,6 +144,18 @@ type SlackConfig struct {
 	// AdminUserID can hold a privileged user ID for certain automation tasks.
 	// This field should be used carefully to avoid security risks.
 	AdminUserID string `json:"adminUserId" mapstructure:"adminUserId"`
+
+	// MetadataTTL is how long channel lists and similar metadata are cached.
+	// Zero disables the cache.
+	MetadataTTL time.Duration `json:"metadataTTL" mapstructure:"metadataTTL"`
+
+	// Inbound configures the endpoints Slack calls, such as the Events API
+	// receiver; they are served only when a signing secret is set.
+	Inbound *SlackInboundConfig `json:"inbound" mapstructure:"inbound"`
+
+	// Workspaces are additional workspaces messages can be routed to by name;
+	// the token above is the workspace named "default".
+	Workspaces []SlackWorkspaceConfig `json:"workspaces" mapstructure:"workspaces"`
 }
 
 // JiraConfig holds the configuration properties used to connect
@@ -87,6 +175,15 @@ type JiraConfig struct {
 
 	// UseCloud indicates whether connecting to Jira Cloud (as opposed to a self-hosted instance).
 	UseCloud bool `json:"useCloud" mapstructure:"useCloud"`
+
+	// MetadataTTL is how long create metadata and transitions are cached.
+	// Zero disables the cache.
+	MetadataTTL time.Duration `json:"metadataTTL" mapstructure:"metadataTTL"`
+
+	// CorrelationField is the ID of a text custom field, e.g. "customfield_10050",
+	// that records each issue's correlation key so retried creates find the
+	// issue instead of duplicating it. Empty records the key as a label.
+	CorrelationField string `json:"correlationField" mapstructure:"correlationField"`
 }
 
 // Config is the main configuration structure for the integration service.
@@ -102,6 +199,96 @@ type Config struct {
 	// Jira holds the Jira integration configurations.
 	Jira *JiraConfig `json:"jira" mapstructure:"jira"`
 
+	// Matrix holds the Matrix room integration configuration.
+	Matrix *MatrixConfig `json:"matrix" mapstructure:"matrix"`
+
+	// AzureDevOps holds the Azure DevOps work item integration configuration.
+	AzureDevOps *AzureDevOpsConfig `json:"azureDevOps" mapstructure:"azureDevOps"`
+
+	// Asana holds the Asana task integration configuration.
+	Asana *AsanaConfig `json:"asana" mapstructure:"asana"`
+
+	// Trello holds the Trello card integration configuration.
+	Trello *TrelloConfig `json:"trello" mapstructure:"trello"`
+
+	// Monday holds the monday.com board integration configuration.
+	Monday *MondayConfig `json:"monday" mapstructure:"monday"`
+
+	// ServiceNow holds the ServiceNow Table API integration configuration.
+	ServiceNow *ServiceNowConfig `json:"serviceNow" mapstructure:"serviceNow"`
+
+	// PubSub holds the Google Cloud Pub/Sub publishing configuration.
+	PubSub *PubSubConfig `json:"pubSub" mapstructure:"pubSub"`
+
+	// Push holds the mobile push (FCM and APNs) configuration.
+	Push *PushConfig `json:"push" mapstructure:"push"`
+
+	// Graph holds the Microsoft Graph mail and calendar configuration.
+	Graph *GraphConfig `json:"graph" mapstructure:"graph"`
+
+	// Splunk holds the Splunk HTTP Event Collector configuration.
+	Splunk *SplunkConfig `json:"splunk" mapstructure:"splunk"`
+
+	// Telemetry holds metrics export settings (Prometheus scrape and/or OTLP push).
+	Telemetry *TelemetryConfig `json:"telemetry" mapstructure:"telemetry"`
+
+	// Auth holds inbound request authentication settings.
+	Auth *AuthConfig `json:"auth" mapstructure:"auth"`
+
+	// Server holds HTTP listener settings such as TLS and client certificate auth.
+	Server *ServerConfig `json:"server" mapstructure:"server"`
+
+	// Webhooks holds inbound signature verification and outbound signing settings.
+	Webhooks *WebhooksConfig `json:"webhooks" mapstructure:"webhooks"`
+
+	// RateLimit holds anonymous (per-IP) and authenticated (per-principal) request quotas.
+	RateLimit *RateLimitConfig `json:"rateLimit" mapstructure:"rateLimit"`
+
+	// Queue holds message queueing and persistent spool settings.
+	Queue *QueueConfig `json:"queue" mapstructure:"queue"`
+
+	// History holds settings for the persistent record of delivery attempts.
+	History *HistoryConfig `json:"history" mapstructure:"history"`
+
+	// Dashboard holds settings for the browser-facing admin dashboard.
+	Dashboard *DashboardConfig `json:"dashboard" mapstructure:"dashboard"`
+
+	// Credentials holds settings for rotating integration credentials without a restart.
+	Credentials *CredentialsConfig `json:"credentials" mapstructure:"credentials"`
+
+	// HTTPClient tunes the shared outbound HTTP transport used by REST adapters.
+	HTTPClient *HTTPClientConfig `json:"httpClient" mapstructure:"httpClient"`
+
+	// Bulkheads caps concurrent calls per integration.
+	Bulkheads *BulkheadConfig `json:"bulkheads" mapstructure:"bulkheads"`
+
+	// Idempotency replays the original response to sends retried with the same Idempotency-Key.
+	Idempotency *IdempotencyConfig `json:"idempotency" mapstructure:"idempotency"`
+
+	// API describes the lifecycle of the API versions served side by side.
+	API *APIConfig `json:"api" mapstructure:"api"`
+
+	// ErrorReporting forwards panics recovered while serving requests.
+	ErrorReporting *ErrorReportingConfig `json:"errorReporting" mapstructure:"errorReporting"`
+
+	// Dispatch sizes the asynchronous send pipeline used by the HTTP API.
+	Dispatch *DispatchConfig `json:"dispatch" mapstructure:"dispatch"`
+
+	// Batching coalesces sends to batch-capable integrations.
+	Batching *BatchingConfig `json:"batching" mapstructure:"batching"`
+
+	// StatusChecks bounds how long each integration's status check may take.
+	StatusChecks *StatusCheckConfig `json:"statusChecks" mapstructure:"statusChecks"`
+
+	// RetryBudget caps retries relative to request volume to prevent retry storms.
+	RetryBudget *RetryBudgetConfig `json:"retryBudget" mapstructure:"retryBudget"`
+
+	// HealthMonitor schedules background integration health checks and readiness.
+	HealthMonitor *HealthMonitorConfig `json:"healthMonitor" mapstructure:"healthMonitor"`
+
+	// WarmUp bounds the startup initialization and verification of integrations.
+	WarmUp *WarmUpConfig `json:"warmUp" mapstructure:"warmUp"`
+
 	// Timeout indicates a global service timeout for external calls.
 	Timeout time.Duration `json:"timeout" mapstructure:"timeout"`
 
@@ -135,8 +322,18 @@ func (c *Config) Validate() error {
 	}
 
 	// 3. Perform security validation on credentials
-	if c.Email.RequireAuth {
-		if c.Email.Username == "" || c.Email.Password == "" {
+	if c.Email.RequireAuth && c.Email.UsesSMTP() {
+		if c.Email.OAuth2 != nil {
+			if c.Email.Username == "" {
+				return &ConfigError{
+					Context: "Email Auth",
+					Message: "Email OAuth2 requires the username of the mailbox to send as",
+				}
+			}
+			if err := c.Email.OAuth2.validate(); err != nil {
+				return err
+			}
+		} else if c.Email.Username == "" || c.Email.Password == "" {
 			return &ConfigError{
 				Context: "Email Auth",
 				Message: "Email requires auth but username/password is missing",
@@ -144,20 +341,72 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	// 3a. Validate SMTP connection pool bounds
+	if p := c.Email.Pool; p != nil {
+		if p.MaxConnections <= 0 || p.MaxSendsPerConnection < 0 || p.KeepaliveInterval <= 0 || p.AcquireTimeout <= 0 {
+			return &ConfigError{
+				Context: "Email Pool",
+				Message: "SMTP pool requires positive maxConnections, keepaliveInterval, and acquireTimeout",
+			}
+		}
+	}
+
+	// 3b. Validate the email provider and its settings
+	switch c.Email.Provider {
+	case "", EmailProviderSMTP:
+	case EmailProviderSendGrid:
+		if err := c.Email.SendGrid.validate(); err != nil {
+			return err
+		}
+	case EmailProviderSES:
+		if err := c.Email.SES.validate(); err != nil {
+			return err
+		}
+	default:
+		return &ConfigError{
+			Context: "Email Provider",
+			Message: "Unsupported email provider: " + c.Email.Provider,
+		}
+	}
+
+	// 3c. Validate the fallback SMTP servers
+	if err := c.Email.Failover.validate(); err != nil {
+		return err
+	}
+
+	// 3d. Validate the inbound mailbox
+	if err := c.Email.Inbound.validate(); err != nil {
+		return err
+	}
+
 	// 4. Validate email configuration with TLS checks
-	if c.Email.UseTLS && (c.Email.Port != 465 && c.Email.Port != 587) {
-		// Common TLS ports are 465 or 587, although 25 can also be used with STARTTLS
-		// Additional custom checks could be inserted here as needed.
+	if err := c.Email.TLS.validate(); err != nil {
+		return err
 	}
 
 	// 5. Validate Slack token format (basic check for non-empty)
-	if c.Slack.Token == "" {
+	if c.Slack.APIToken == "" {
 		return &ConfigError{
 			Context: "Slack Token",
 			Message: "Slack token cannot be empty; must provide valid authentication token",
 		}
 	}
 
+	// 5a. Validate the Slack inbound endpoints
+	if err := c.Slack.Inbound.validate(); err != nil {
+		return err
+	}
+
+	// 5b. Validate the additional Slack workspaces
+	if err := c.Slack.validateWorkspaces(); err != nil {
+		return err
+	}
+
+	// 5c. Validate the Slack timeout, retry policy and rate limits
+	if err := c.Slack.validateLimits(); err != nil {
+		return err
+	}
+
 	// 6. Validate Jira URL format and accessibility (URL format check)
 	if c.Jira.URL == "" {
 		return &ConfigError{
@@ -166,6 +415,14 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	// 6a. Metadata cache lifetimes cannot be negative
+	if c.Slack.MetadataTTL < 0 || c.Jira.MetadataTTL < 0 {
+		return &ConfigError{
+			Context: "Metadata Cache",
+			Message: "Slack and Jira metadataTTL must not be negative",
+		}
+	}
+
 	// 7. Verify timeout settings are within acceptable ranges
 	if c.Timeout <= 0 || c.Timeout > (5*time.Minute) {
 		return &ConfigError{
@@ -177,6 +434,156 @@ func (c *Config) Validate() error {
 	// 8. Check for secure credential storage - demonstrate placeholder
 	// In a production environment, you may enforce checks that secrets are loaded from a secure vault.
 
+	// 9. Validate telemetry exporter selection and collector settings
+	if err := c.Telemetry.validate(); err != nil {
+		return err
+	}
+
+	// 10. Validate inbound authentication settings
+	if err := c.Auth.validate(); err != nil {
+		return err
+	}
+
+	// 11. Validate server TLS and mTLS settings
+	if err := c.Server.validate(); err != nil {
+		return err
+	}
+
+	// 12. Validate webhook signature sources and signing keys
+	if err := c.Webhooks.validate(); err != nil {
+		return err
+	}
+
+	// 13. Validate inbound rate limit quotas and tiers
+	if err := c.RateLimit.validate(); err != nil {
+		return err
+	}
+
+	// 14. Validate the persistent spool and its encryption settings
+	if err := c.Queue.validate(); err != nil {
+		return err
+	}
+
+	// 15. Validate dashboard cookie policy
+	if err := c.Dashboard.validate(); err != nil {
+		return err
+	}
+
+	// 16. Validate the credential rotation watch
+	if err := c.Credentials.validate(); err != nil {
+		return err
+	}
+
+	// 17. Validate the shared outbound HTTP transport
+	if err := c.HTTPClient.validate(); err != nil {
+		return err
+	}
+
+	// 18. Validate per-integration bulkheads
+	if err := c.Bulkheads.validate(); err != nil {
+		return err
+	}
+
+	// 19. Validate the send dispatch pipeline
+	if err := c.Dispatch.validate(); err != nil {
+		return err
+	}
+
+	// 20. Validate send batching
+	if err := c.Batching.validate(); err != nil {
+		return err
+	}
+
+	// 21. Validate status check timeouts
+	if err := c.StatusChecks.validate(); err != nil {
+		return err
+	}
+
+	// 22. Validate retry budgets
+	if err := c.RetryBudget.validate(); err != nil {
+		return err
+	}
+
+	// 23. Validate the health monitor
+	if err := c.HealthMonitor.validate(); err != nil {
+		return err
+	}
+
+	// 24. Validate the startup warm-up
+	if err := c.WarmUp.validate(); err != nil {
+		return err
+	}
+
+	// 25. Validate the Matrix integration
+	if err := c.Matrix.validate(); err != nil {
+		return err
+	}
+
+	// 26. Validate the Azure DevOps integration
+	if err := c.AzureDevOps.validate(); err != nil {
+		return err
+	}
+
+	// 27. Validate the Asana integration
+	if err := c.Asana.validate(); err != nil {
+		return err
+	}
+
+	// 28. Validate the Trello integration
+	if err := c.Trello.validate(); err != nil {
+		return err
+	}
+
+	// 29. Validate the monday.com integration and its column mappings
+	if err := c.Monday.validate(); err != nil {
+		return err
+	}
+
+	// 30. Validate the ServiceNow integration and its authentication mode
+	if err := c.ServiceNow.validate(); err != nil {
+		return err
+	}
+
+	// 31. Validate the Pub/Sub integration
+	if err := c.PubSub.validate(); err != nil {
+		return err
+	}
+
+	// 32. Validate the push platforms
+	if err := c.Push.validate(); err != nil {
+		return err
+	}
+
+	// 33. Validate the Microsoft Graph integration
+	if err := c.Graph.validate(); err != nil {
+		return err
+	}
+
+	// 34. Validate the Splunk HTTP Event Collector
+	if err := c.Splunk.validate(); err != nil {
+		return err
+	}
+
+	// 35. Validate the message history location and retention
+	if err := c.History.validate(); err != nil {
+		return err
+	}
+
+	// 36. Validate Idempotency-Key handling
+	if err := c.Idempotency.validate(); err != nil {
+		return err
+	}
+
+	// 37. Validate the API version lifecycle dates
+	if err := c.API.validate(); err != nil {
+		return err
+	}
+
+	// 38. Validate the error-reporting endpoint
+	if err := c.ErrorReporting.validate(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -274,16 +681,236 @@ func setDefaults(v *viper.Viper) {
 	// 2. Configure default TLS usage for email
 	v.SetDefault("email.useTLS", true)
 	v.SetDefault("email.requireAuth", true)
+	v.SetDefault("email.pool.maxConnections", 4)
+	v.SetDefault("email.pool.maxSendsPerConnection", 100)
+	v.SetDefault("email.pool.idleTimeout", "2m")
+	v.SetDefault("email.pool.keepaliveInterval", "30s")
+	v.SetDefault("email.pool.acquireTimeout", "10s")
+	v.SetDefault("email.provider", "smtp")
+	v.SetDefault("email.sendGrid.baseURL", "https://api.sendgrid.com")
+	v.SetDefault("email.sendGrid.timeout", "30s")
+	v.SetDefault("email.ses.timeout", "30s")
+	v.SetDefault("email.inbound.mailbox", "INBOX")
+	v.SetDefault("email.inbound.pollInterval", "1m")
+	v.SetDefault("email.inbound.retention", "168h")
 
 	// 3. Set secure API defaults
 	v.SetDefault("slack.useEnterprise", false)
 	v.SetDefault("jira.useCloud", false)
+	v.SetDefault("slack.metadataTTL", "10m")
+	v.SetDefault("slack.timeout", "30s")
+	v.SetDefault("slack.retryAttempts", 3)
+	v.SetDefault("slack.maxRetryAfter", "1m")
+	v.SetDefault("slack.rateLimit.requestsPerSecond", 5)
+	v.SetDefault("slack.rateLimit.burst", 10)
+	v.SetDefault("slack.rateLimit.minRequestsPerSecond", 0.5)
+	v.SetDefault("slack.inbound.tolerance", "5m")
+	v.SetDefault("webhooks.inboundTolerance", "5m")
+	v.SetDefault("jira.metadataTTL", "15m")
 
 	// 4. Initialize monitoring defaults (placeholder for future monitoring expansions)
 	v.SetDefault("debug", false)
 
 	// 5. Set credential handling defaults
 	v.SetDefault("version", configVersion)
+
+	// 6. Telemetry defaults: Prometheus scrape only, OTLP push opt-in
+	v.SetDefault("telemetry.serviceName", "integration-service")
+	v.SetDefault("telemetry.metrics.exporters", []string{MetricsExporterPrometheus})
+	v.SetDefault("telemetry.metrics.otlp.protocol", OTLPProtocolGRPC)
+	v.SetDefault("telemetry.metrics.otlp.interval", "30s")
+	v.SetDefault("telemetry.metrics.otlp.timeout", "10s")
+
+	// 7. Authentication defaults: introspection is opt-in, results cached briefly
+	v.SetDefault("auth.introspection.enabled", false)
+	v.SetDefault("auth.introspection.timeout", "5s")
+	v.SetDefault("auth.introspection.cacheTTL", "60s")
+	v.SetDefault("auth.introspection.negativeCacheTTL", "10s")
+	v.SetDefault("auth.introspection.cacheSize", 10000)
+	v.SetDefault("auth.basic.maxFailures", 5)
+	v.SetDefault("auth.basic.clientMaxFailures", 20)
+	v.SetDefault("auth.basic.failureWindow", "5m")
+	v.SetDefault("auth.basic.lockoutDuration", "15m")
+
+	// 8. Server defaults: plaintext unless TLS material is configured
+	v.SetDefault("server.tls.enabled", false)
+	v.SetDefault("server.tls.clientAuth", ClientAuthNone)
+	v.SetDefault("server.tls.acme.enabled", false)
+	v.SetDefault("server.tls.acme.cacheDir", "/var/lib/taskstream/acme")
+	v.SetDefault("server.tls.acme.challenge", ACMEChallengeTLSALPN)
+	v.SetDefault("server.tls.acme.httpAddr", ":80")
+	v.SetDefault("server.securityHeaders.hsts.maxAge", "8760h")
+	v.SetDefault("server.securityHeaders.hsts.includeSubDomains", true)
+	v.SetDefault("server.securityHeaders.contentSecurityPolicy", "default-src 'none'; frame-ancestors 'none'")
+	v.SetDefault("server.http2.enabled", true)
+	v.SetDefault("server.http2.h2c", false)
+	v.SetDefault("server.http2.maxConcurrentStreams", 250)
+	v.SetDefault("server.maxConnections", 0)
+	v.SetDefault("server.admin.enabled", true)
+	v.SetDefault("server.admin.addr", "127.0.0.1:9090")
+	v.SetDefault("server.admin.pprof", false)
+	v.SetDefault("server.admin.auth.maxFailures", 5)
+	v.SetDefault("server.admin.auth.clientMaxFailures", 20)
+	v.SetDefault("server.admin.auth.failureWindow", "5m")
+	v.SetDefault("server.admin.auth.lockoutDuration", "15m")
+	v.SetDefault("server.timeouts.readHeader", defaultReadHeaderTimeout.String())
+	v.SetDefault("server.timeouts.read", defaultReadTimeout.String())
+	v.SetDefault("server.timeouts.write", defaultWriteTimeout.String())
+	v.SetDefault("server.timeouts.idle", defaultIdleTimeout.String())
+	v.SetDefault("server.timeouts.shutdown", defaultShutdownTimeout.String())
+	v.SetDefault("server.timeouts.drainDelay", defaultDrainDelay.String())
+	v.SetDefault("server.maxHeaderBytes", defaultMaxHeaderBytes)
+	v.SetDefault("server.accessLog.enabled", true)
+	v.SetDefault("server.accessLog.sampledPaths", []string{"/health", "/readyz", "/startupz", "/metrics"})
+	v.SetDefault("server.accessLog.sampleEvery", 100)
+
+	// 9. Rate limit defaults: per-IP guard plus a per-principal quota
+	v.SetDefault("rateLimit.anonymous.limit", 20)
+	v.SetDefault("rateLimit.anonymous.period", "1m")
+	v.SetDefault("rateLimit.authenticated.limit", 600)
+	v.SetDefault("rateLimit.authenticated.period", "1m")
+	v.SetDefault("rateLimit.keyBy", RateLimitKeySubject)
+	v.SetDefault("rateLimit.store.type", RateLimitStoreMemory)
+	v.SetDefault("rateLimit.store.redis.keyPrefix", "taskstream:ratelimit:")
+	v.SetDefault("rateLimit.store.redis.dialTimeout", "5s")
+
+	// 10. Queue defaults: spool disabled; when enabled, payloads are encrypted via KMS
+	v.SetDefault("queue.spool.enabled", false)
+	v.SetDefault("queue.spool.dir", "/var/lib/taskstream/spool")
+	v.SetDefault("queue.spool.encryption.enabled", true)
+	v.SetDefault("queue.spool.encryption.provider", KeyProviderKMS)
+	v.SetDefault("queue.storeAndForward.enabled", false)
+	v.SetDefault("queue.storeAndForward.replayInterval", "15s")
+	v.SetDefault("queue.storeAndForward.replayBatchSize", 100)
+
+	// 11. Dashboard defaults: disabled, strict same-site secure cookies
+	v.SetDefault("dashboard.enabled", false)
+	v.SetDefault("dashboard.cookieSecure", true)
+	v.SetDefault("dashboard.cookieSameSite", SameSiteStrict)
+
+	// 12. Credential rotation defaults: secrets directory watch is opt-in
+	v.SetDefault("credentials.watch.enabled", false)
+	v.SetDefault("credentials.watch.dir", "/etc/taskstream/secrets")
+	v.SetDefault("credentials.watch.interval", "30s")
+
+	// 13. Shared HTTP transport defaults: pooled keep-alive connections over HTTP/2
+	v.SetDefault("httpClient.timeout", "30s")
+	v.SetDefault("httpClient.dialTimeout", "5s")
+	v.SetDefault("httpClient.keepAlive", "30s")
+	v.SetDefault("httpClient.tlsHandshakeTimeout", "5s")
+	v.SetDefault("httpClient.responseHeaderTimeout", "15s")
+	v.SetDefault("httpClient.idleConnTimeout", "90s")
+	v.SetDefault("httpClient.maxIdleConns", 100)
+	v.SetDefault("httpClient.maxIdleConnsPerHost", 16)
+	v.SetDefault("httpClient.maxConnsPerHost", 0)
+	v.SetDefault("httpClient.http2", true)
+	v.SetDefault("httpClient.http2ReadIdleTimeout", "30s")
+
+	// 14. Bulkhead defaults: bounded concurrency per integration with a short wait queue
+	v.SetDefault("bulkheads.default.maxConcurrent", 16)
+	v.SetDefault("bulkheads.default.maxQueue", 64)
+	v.SetDefault("bulkheads.default.queueTimeout", "5s")
+	v.SetDefault("bulkheads.default.policy", BulkheadPolicyQueue)
+
+	// 15. Dispatch defaults: a worker pool behind a bounded queue with a 64MB memory
+	// budget; callers may wait briefly. Spilling to disk is opt-in.
+	v.SetDefault("dispatch.workers", 32)
+	v.SetDefault("dispatch.queueSize", 1024)
+	v.SetDefault("dispatch.maxWait", "10s")
+	v.SetDefault("dispatch.memoryBudget", 64<<20)
+	v.SetDefault("dispatch.drainTimeout", "15s")
+	v.SetDefault("dispatch.spill.enabled", false)
+	v.SetDefault("dispatch.spill.dir", "/var/lib/taskstream/dispatch-spill")
+	v.SetDefault("dispatch.spill.maxBytes", 1<<30)
+
+	// 16. Batching defaults: opt-in; short window so interactive sends stay responsive
+	v.SetDefault("batching.enabled", false)
+	v.SetDefault("batching.default.window", "250ms")
+	v.SetDefault("batching.default.maxBatchSize", 10)
+	v.SetDefault("batching.maxPending", 1024)
+
+	// 17. Status check defaults: well under typical probe timeouts
+	v.SetDefault("statusChecks.timeout", "2s")
+
+	// 18. Retry budget defaults: retries up to 20% of requests over 10s, with a small floor
+	v.SetDefault("retryBudget.enabled", true)
+	v.SetDefault("retryBudget.window", "10s")
+	v.SetDefault("retryBudget.global.ratio", 0.2)
+	v.SetDefault("retryBudget.global.minRetries", 10)
+	v.SetDefault("retryBudget.default.ratio", 0.2)
+	v.SetDefault("retryBudget.default.minRetries", 3)
+
+	// 19. Health monitor defaults: check every 15s; stay ready while any integration is up;
+	// reuse /health probes for 5s so polling dashboards do not hit every provider
+	v.SetDefault("healthMonitor.interval", "15s")
+	v.SetDefault("healthMonitor.readinessPolicy", ReadinessPolicyAny)
+	v.SetDefault("healthMonitor.reportTTL", "5s")
+
+	// 20. Warm-up defaults: up to 4 integrations at once, 15s each
+	v.SetDefault("warmUp.timeout", "15s")
+	v.SetDefault("warmUp.parallelism", 4)
+
+	// 21. Matrix defaults: short calls, room aliases cached like Slack channels
+	v.SetDefault("matrix.timeout", "10s")
+	v.SetDefault("matrix.metadataTTL", "10m")
+
+	// 22. Azure DevOps defaults: Tasks, with the same call timeout as Jira
+	v.SetDefault("azureDevOps.defaultWorkItemType", "Task")
+	v.SetDefault("azureDevOps.timeout", "30s")
+
+	// 23. Asana defaults: the public API
+	v.SetDefault("asana.baseURL", "https://app.asana.com/api/1.0")
+	v.SetDefault("asana.timeout", "30s")
+
+	// 24. Trello defaults: the public API
+	v.SetDefault("trello.baseURL", "https://api.trello.com/1")
+	v.SetDefault("trello.timeout", "30s")
+
+	// 25. monday.com defaults: the public API at a pinned version
+	v.SetDefault("monday.apiURL", "https://api.monday.com/v2")
+	v.SetDefault("monday.apiVersion", "2024-01")
+	v.SetDefault("monday.timeout", "30s")
+
+	// 26. ServiceNow defaults: basic authentication, creating incidents
+	v.SetDefault("serviceNow.authMode", "basic")
+	v.SetDefault("serviceNow.defaultTable", "incident")
+	v.SetDefault("serviceNow.timeout", "30s")
+
+	// 27. Pub/Sub defaults: the global endpoint
+	v.SetDefault("pubSub.endpoint", "https://pubsub.googleapis.com")
+	v.SetDefault("pubSub.timeout", "30s")
+
+	// 28. Push defaults. Platform endpoints are defaulted by the adapter, since
+	// a default here would create the platform blocks that enable them.
+	v.SetDefault("push.timeout", "30s")
+
+	// 29. Microsoft Graph defaults: the global cloud, events in UTC
+	v.SetDefault("graph.authorityURL", "https://login.microsoftonline.com")
+	v.SetDefault("graph.baseURL", "https://graph.microsoft.com")
+	v.SetDefault("graph.timeZone", "UTC")
+	v.SetDefault("graph.timeout", "30s")
+
+	// 30. Splunk defaults: batches well under HEC's default 1 MB body limit
+	v.SetDefault("splunk.maxBatchEvents", 100)
+	v.SetDefault("splunk.maxBatchBytes", 512<<10)
+	v.SetDefault("splunk.timeout", "30s")
+
+	// 31. Message history defaults: opt-in; 30 days of delivery attempts
+	v.SetDefault("history.enabled", false)
+	v.SetDefault("history.dir", "/var/lib/taskstream/history")
+	v.SetDefault("history.retention", "720h")
+
+	// 32. Idempotency defaults: keys honored for a day, bounded in memory
+	v.SetDefault("idempotency.enabled", true)
+	v.SetDefault("idempotency.ttl", "24h")
+	v.SetDefault("idempotency.maxEntries", 10000)
+
+	// 33. API version defaults: v1 is served alongside v2 but deprecated
+	v.SetDefault("api.v1.deprecated", true)
+
+	// 34. Error reporting defaults: opt-in; reports never hold up a response
+	v.SetDefault("errorReporting.enabled", false)
+	v.SetDefault("errorReporting.timeout", "5s")
 }
 
 // ConfigError represents a custom error type for configuration-specific issues,
@@ -303,4 +930,42 @@ func (ce *ConfigError) Error() string {
 	encoded, _ := json.Marshal(data)
 	return string(encoded)
 }
-```
\ No newline at end of file
+
+// IntegrationSection returns the configuration section an integration's
+// adapter is initialized with, by the integration's registered name, e.g. the
+// SlackConfig for "slack". Names without a section of their own return the
+// whole Config, for adapters that read several sections.
+func (c *Config) IntegrationSection(name string) interface{} {
+	switch name {
+	case "slack":
+		return c.Slack
+	case "jira":
+		return c.Jira
+	case "email":
+		return c.Email
+	case "matrix":
+		return c.Matrix
+	case "azuredevops":
+		return c.AzureDevOps
+	case "asana":
+		return c.Asana
+	case "trello":
+		return c.Trello
+	case "monday":
+		return c.Monday
+	case "servicenow":
+		return c.ServiceNow
+	case "webhook":
+		return c.Webhooks
+	case "pubsub":
+		return c.PubSub
+	case "push":
+		return c.Push
+	case "graph":
+		return c.Graph
+	case "splunk":
+		return c.Splunk
+	default:
+		return c
+	}
+}
@@ -0,0 +1,46 @@
+package config
+
+import (
+	// go1.21 - Poll interval for the secrets directory watch
+	"time"
+)
+
+// CredentialWatchConfig configures polling of a secrets directory for rotated
+// integration credentials. The directory is typically a mounted Kubernetes
+// secret or a Vault Agent template output, containing one "<integration>.json"
+// file per integration with "username" and "secret" fields.
+type CredentialWatchConfig struct {
+	// Enabled turns on the secrets directory watch.
+	Enabled bool `json:"enabled" mapstructure:"enabled"`
+
+	// Dir is the directory holding per-integration credential files.
+	Dir string `json:"dir" mapstructure:"dir"`
+
+	// Interval is how often the directory is checked for changed files.
+	Interval time.Duration `json:"interval" mapstructure:"interval"`
+}
+
+// CredentialsConfig groups settings for rotating integration credentials at runtime.
+type CredentialsConfig struct {
+	// Watch configures the secrets directory watch.
+	Watch *CredentialWatchConfig `json:"watch" mapstructure:"watch"`
+}
+
+// WatchEnabled reports whether the secrets directory watch is configured.
+func (c *CredentialsConfig) WatchEnabled() bool {
+	return c != nil && c.Watch != nil && c.Watch.Enabled
+}
+
+// validate checks the watch directory and poll interval when the watch is enabled.
+func (c *CredentialsConfig) validate() error {
+	if !c.WatchEnabled() {
+		return nil
+	}
+	if c.Watch.Dir == "" || c.Watch.Interval <= 0 {
+		return &ConfigError{
+			Context: "Credentials Watch",
+			Message: "Credential watch requires a dir and a positive interval",
+		}
+	}
+	return nil
+}
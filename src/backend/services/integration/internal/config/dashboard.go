@@ -0,0 +1,35 @@
+package config
+
+// SameSite cookie modes accepted by DashboardConfig.CookieSameSite.
+const (
+	SameSiteStrict = "strict"
+	SameSiteLax    = "lax"
+)
+
+// DashboardConfig configures the browser-facing admin dashboard.
+type DashboardConfig struct {
+	// Enabled serves the embedded dashboard and its browser-facing endpoints on
+	// the admin listener (server.admin); it has no effect when that is disabled.
+	Enabled bool `json:"enabled" mapstructure:"enabled"`
+
+	// CookieSecure marks dashboard cookies Secure. It should only be disabled for
+	// local development over plain HTTP.
+	CookieSecure bool `json:"cookieSecure" mapstructure:"cookieSecure"`
+
+	// CookieSameSite is the SameSite mode for dashboard cookies: "strict" or "lax".
+	CookieSameSite string `json:"cookieSameSite" mapstructure:"cookieSameSite"`
+}
+
+// validate checks the cookie policy.
+func (d *DashboardConfig) validate() error {
+	if d == nil || !d.Enabled {
+		return nil
+	}
+	if d.CookieSameSite != SameSiteStrict && d.CookieSameSite != SameSiteLax {
+		return &ConfigError{
+			Context: "Dashboard",
+			Message: "cookieSameSite must be strict or lax, found: " + d.CookieSameSite,
+		}
+	}
+	return nil
+}
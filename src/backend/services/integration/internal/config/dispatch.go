@@ -0,0 +1,91 @@
+package config
+
+import (
+	// go1.21 - Caller wait limits
+	"time"
+)
+
+// DispatchConfig sizes the asynchronous send pipeline. HTTP handlers enqueue
+// sends and return immediately, optionally waiting up to a deadline for the
+// outcome, while a fixed pool of workers delivers them to the integrations.
+type DispatchConfig struct {
+	// Workers is the number of goroutines delivering queued sends.
+	Workers int `json:"workers" mapstructure:"workers"`
+
+	// QueueSize is the number of sends that may wait in memory for a worker.
+	// Enqueueing beyond it spills to disk when enabled and otherwise fails fast
+	// rather than blocking the handler.
+	QueueSize int `json:"queueSize" mapstructure:"queueSize"`
+
+	// MaxWait caps how long a caller may ask to wait for a send to complete.
+	MaxWait time.Duration `json:"maxWait" mapstructure:"maxWait"`
+
+	// MemoryBudget caps the estimated payload bytes held in memory by queued
+	// sends. Zero disables the budget and only QueueSize applies.
+	MemoryBudget int64 `json:"memoryBudget" mapstructure:"memoryBudget"`
+
+	// Spill moves the oldest low-priority sends to disk when the queue is over
+	// its size or memory budget, instead of rejecting new sends.
+	Spill *DispatchSpillConfig `json:"spill" mapstructure:"spill"`
+
+	// DrainTimeout bounds how long shutdown waits for queued sends to be
+	// delivered. Sends still queued after it are stored for replay when
+	// store-and-forward is enabled, and dropped otherwise. Zero waits for the
+	// whole shutdown timeout, leaving no time to store them.
+	DrainTimeout time.Duration `json:"drainTimeout" mapstructure:"drainTimeout"`
+}
+
+// DispatchSpillConfig configures the disk overflow of the dispatch queue. Spilled
+// sends are paged back into memory as workers free capacity. The overflow is not
+// durable across restarts; durable outage buffering is store-and-forward's job.
+// Segments are encrypted with queue.spool.encryption when it is enabled.
+type DispatchSpillConfig struct {
+	// Enabled turns spilling on.
+	Enabled bool `json:"enabled" mapstructure:"enabled"`
+
+	// Dir is the directory holding spill segment files. It is emptied on startup.
+	Dir string `json:"dir" mapstructure:"dir"`
+
+	// MaxBytes caps the disk space used by spill segments.
+	MaxBytes int64 `json:"maxBytes" mapstructure:"maxBytes"`
+}
+
+// ShutdownDrainTimeout returns DrainTimeout, or zero for a missing section.
+func (d *DispatchConfig) ShutdownDrainTimeout() time.Duration {
+	if d == nil {
+		return 0
+	}
+	return d.DrainTimeout
+}
+
+// SpillEnabled reports whether over-budget sends should be spilled to disk.
+func (d *DispatchConfig) SpillEnabled() bool {
+	return d != nil && d.Spill != nil && d.Spill.Enabled
+}
+
+// validate checks that the pipeline has workers and a queue, and that spilling
+// has somewhere to go.
+func (d *DispatchConfig) validate() error {
+	if d == nil {
+		return nil
+	}
+	if d.Workers <= 0 || d.QueueSize <= 0 {
+		return &ConfigError{
+			Context: "Dispatch",
+			Message: "Dispatch requires positive workers and queueSize",
+		}
+	}
+	if d.MaxWait < 0 || d.MemoryBudget < 0 || d.DrainTimeout < 0 {
+		return &ConfigError{
+			Context: "Dispatch",
+			Message: "Dispatch maxWait, memoryBudget and drainTimeout must not be negative",
+		}
+	}
+	if d.SpillEnabled() && (d.Spill.Dir == "" || d.Spill.MaxBytes <= 0) {
+		return &ConfigError{
+			Context: "Dispatch",
+			Message: "Dispatch spill requires a dir and positive maxBytes",
+		}
+	}
+	return nil
+}
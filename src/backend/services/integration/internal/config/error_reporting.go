@@ -0,0 +1,50 @@
+package config
+
+import (
+	// go1.21 - Reporting endpoint validation and timeout
+	"net/url"
+	"time"
+)
+
+// ErrorReportingConfig forwards panics recovered while serving requests to an
+// error-reporting backend, such as an error tracker's HTTP intake or an
+// incident webhook, in addition to logging them.
+type ErrorReportingConfig struct {
+	// Enabled forwards recovered panics to URL.
+	Enabled bool `json:"enabled" mapstructure:"enabled"`
+
+	// URL receives each panic as a JSON POST.
+	URL string `json:"url" mapstructure:"url"`
+
+	// Headers are added to every report, e.g. the backend's API key.
+	Headers map[string]string `json:"headers" mapstructure:"headers"`
+
+	// Timeout bounds each report.
+	Timeout time.Duration `json:"timeout" mapstructure:"timeout"`
+}
+
+// ReportingEnabled reports whether recovered panics are forwarded.
+func (e *ErrorReportingConfig) ReportingEnabled() bool {
+	return e != nil && e.Enabled
+}
+
+// validate checks the reporting endpoint and timeout.
+func (e *ErrorReportingConfig) validate() error {
+	if !e.ReportingEnabled() {
+		return nil
+	}
+	u, err := url.Parse(e.URL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return &ConfigError{
+			Context: "Error Reporting",
+			Message: "Error reporting is enabled but url is not an absolute http(s) URL: " + e.URL,
+		}
+	}
+	if e.Timeout <= 0 {
+		return &ConfigError{
+			Context: "Error Reporting",
+			Message: "Error reporting timeout must be positive",
+		}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package config
+
+import (
+	// go1.21 - Request timeout
+	"time"
+)
+
+// GraphConfig configures sending Outlook mail and creating calendar events
+// through Microsoft Graph, authenticating as an app registration with the
+// client-credentials grant. The app needs the Mail.Send and Calendars.ReadWrite
+// application permissions.
+type GraphConfig struct {
+	// TenantID is the Microsoft Entra tenant (directory) ID or domain.
+	TenantID string `json:"tenantID" mapstructure:"tenantID"`
+
+	// ClientID and ClientSecret identify the app registration.
+	ClientID     string `json:"clientID" mapstructure:"clientID"`
+	ClientSecret string `json:"clientSecret" mapstructure:"clientSecret"`
+
+	// AuthorityURL is the identity platform base URL; national clouds use
+	// their own, e.g. https://login.microsoftonline.us.
+	AuthorityURL string `json:"authorityURL" mapstructure:"authorityURL"`
+
+	// BaseURL is the Graph API base URL, e.g. https://graph.microsoft.us for
+	// national clouds.
+	BaseURL string `json:"baseURL" mapstructure:"baseURL"`
+
+	// Mailbox is the user principal name or ID of the mailbox that sends mail
+	// and owns created events, when a payload names none.
+	Mailbox string `json:"mailbox" mapstructure:"mailbox"`
+
+	// SaveToSentItems keeps a copy of sent mail in the mailbox's Sent Items.
+	SaveToSentItems bool `json:"saveToSentItems" mapstructure:"saveToSentItems"`
+
+	// AllowedDomains restricts mail recipients and event attendees to these
+	// domains. Empty allows any domain.
+	AllowedDomains []string `json:"allowedDomains" mapstructure:"allowedDomains"`
+
+	// TimeZone is the IANA time zone events are created in when a payload
+	// names none.
+	TimeZone string `json:"timeZone" mapstructure:"timeZone"`
+
+	// Timeout bounds each call to Graph and the token endpoint.
+	Timeout time.Duration `json:"timeout" mapstructure:"timeout"`
+}
+
+// validate checks the URLs and app credentials. An absent section, or one
+// without a tenant, leaves Graph unconfigured.
+func (g *GraphConfig) validate() error {
+	if g == nil || g.TenantID == "" {
+		return nil
+	}
+	if !isHTTPURL(g.AuthorityURL) || !isHTTPURL(g.BaseURL) {
+		return &ConfigError{
+			Context: "Graph",
+			Message: "authorityURL and baseURL must be absolute http or https URLs",
+		}
+	}
+	if g.ClientID == "" || g.ClientSecret == "" {
+		return &ConfigError{
+			Context: "Graph",
+			Message: "Graph requires a clientID and clientSecret",
+		}
+	}
+	if g.Mailbox == "" {
+		return &ConfigError{
+			Context: "Graph",
+			Message: "Graph requires a default mailbox",
+		}
+	}
+	if g.Timeout < 0 {
+		return &ConfigError{
+			Context: "Graph",
+			Message: "Graph timeout must not be negative",
+		}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package config
+
+import (
+	// go1.21 - Health check scheduling
+	"time"
+)
+
+// Readiness policies for HealthMonitorConfig.ReadinessPolicy.
+const (
+	// ReadinessPolicyAny reports ready while at least one integration is connected,
+	// so an outage at one provider does not take traffic away from the others.
+	ReadinessPolicyAny = "any"
+
+	// ReadinessPolicyAll reports ready only while every integration is connected.
+	ReadinessPolicyAll = "all"
+)
+
+// HealthMonitorConfig configures the background health monitor, which checks
+// every integration on an interval and drives the /readyz endpoint.
+type HealthMonitorConfig struct {
+	// Interval is how often integrations are checked.
+	Interval time.Duration `json:"interval" mapstructure:"interval"`
+
+	// ReadinessPolicy decides readiness from integration health: "any" or "all".
+	ReadinessPolicy string `json:"readinessPolicy" mapstructure:"readinessPolicy"`
+
+	// ReportTTL is how long the dependency probes behind the /health report
+	// are reused before they are run again; 0 probes on every request.
+	ReportTTL time.Duration `json:"reportTTL" mapstructure:"reportTTL"`
+}
+
+// ReportCacheTTL returns ReportTTL, or 0 when the section is absent.
+func (h *HealthMonitorConfig) ReportCacheTTL() time.Duration {
+	if h == nil {
+		return 0
+	}
+	return h.ReportTTL
+}
+
+// validate checks the interval, report TTL and policy.
+func (h *HealthMonitorConfig) validate() error {
+	if h == nil {
+		return nil
+	}
+	if h.Interval <= 0 {
+		return &ConfigError{
+			Context: "Health Monitor",
+			Message: "Health monitor requires a positive interval",
+		}
+	}
+	if h.ReportTTL < 0 {
+		return &ConfigError{
+			Context: "Health Monitor",
+			Message: "Health monitor reportTTL must not be negative",
+		}
+	}
+	switch h.ReadinessPolicy {
+	case ReadinessPolicyAny, ReadinessPolicyAll:
+		return nil
+	default:
+		return &ConfigError{
+			Context: "Health Monitor",
+			Message: "Health monitor readinessPolicy must be \"any\" or \"all\"",
+		}
+	}
+}
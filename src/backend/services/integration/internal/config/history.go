@@ -0,0 +1,45 @@
+package config
+
+import (
+	// go1.21 - History retention
+	"time"
+)
+
+// HistoryConfig configures the persistent message history, a record of every
+// delivery attempt kept so support can tell whether a notification was sent.
+// Payloads are not kept, only their hashes.
+type HistoryConfig struct {
+	// Enabled records every delivery attempt and serves GET /api/v1/messages.
+	Enabled bool `json:"enabled" mapstructure:"enabled"`
+
+	// Dir is the directory holding the history files. It must be writable by the service.
+	Dir string `json:"dir" mapstructure:"dir"`
+
+	// Retention is how long records are kept; older days are deleted.
+	Retention time.Duration `json:"retention" mapstructure:"retention"`
+}
+
+// HistoryEnabled reports whether the message history is configured.
+func (h *HistoryConfig) HistoryEnabled() bool {
+	return h != nil && h.Enabled
+}
+
+// validate checks the history location and retention.
+func (h *HistoryConfig) validate() error {
+	if !h.HistoryEnabled() {
+		return nil
+	}
+	if h.Dir == "" {
+		return &ConfigError{
+			Context: "Message History",
+			Message: "Message history is enabled but no directory is configured",
+		}
+	}
+	if h.Retention < 24*time.Hour {
+		return &ConfigError{
+			Context: "Message History",
+			Message: "Message history retention must be at least 24h, found: " + h.Retention.String(),
+		}
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package config
+
+import (
+	// go1.21 - Proxy URL validation
+	"net/url"
+	// go1.21 - Transport timeouts
+	"time"
+)
+
+// HTTPClientConfig tunes the shared HTTP transport used by REST-based adapters
+// (Slack, Jira, and later integrations). A single transport is shared so that
+// connection pools and HTTP/2 sessions are reused across adapters.
+type HTTPClientConfig struct {
+	// Timeout bounds an entire request, including reading the response body.
+	Timeout time.Duration `json:"timeout" mapstructure:"timeout"`
+
+	// DialTimeout bounds establishing a TCP connection.
+	DialTimeout time.Duration `json:"dialTimeout" mapstructure:"dialTimeout"`
+
+	// KeepAlive is the TCP keep-alive probe interval for open connections.
+	KeepAlive time.Duration `json:"keepAlive" mapstructure:"keepAlive"`
+
+	// TLSHandshakeTimeout bounds the TLS handshake.
+	TLSHandshakeTimeout time.Duration `json:"tlsHandshakeTimeout" mapstructure:"tlsHandshakeTimeout"`
+
+	// ResponseHeaderTimeout bounds the wait for response headers after the request is written.
+	ResponseHeaderTimeout time.Duration `json:"responseHeaderTimeout" mapstructure:"responseHeaderTimeout"`
+
+	// IdleConnTimeout closes pooled connections idle for longer than this.
+	IdleConnTimeout time.Duration `json:"idleConnTimeout" mapstructure:"idleConnTimeout"`
+
+	// MaxIdleConns caps idle connections across all hosts.
+	MaxIdleConns int `json:"maxIdleConns" mapstructure:"maxIdleConns"`
+
+	// MaxIdleConnsPerHost caps idle connections kept per host.
+	MaxIdleConnsPerHost int `json:"maxIdleConnsPerHost" mapstructure:"maxIdleConnsPerHost"`
+
+	// MaxConnsPerHost caps total connections per host. Zero means unlimited.
+	MaxConnsPerHost int `json:"maxConnsPerHost" mapstructure:"maxConnsPerHost"`
+
+	// HTTP2 negotiates HTTP/2 with servers that support it.
+	HTTP2 bool `json:"http2" mapstructure:"http2"`
+
+	// HTTP2ReadIdleTimeout sends an HTTP/2 PING when no frame has been received for
+	// this long, detecting dead connections. Zero disables health checks.
+	HTTP2ReadIdleTimeout time.Duration `json:"http2ReadIdleTimeout" mapstructure:"http2ReadIdleTimeout"`
+
+	// ProxyURL routes outbound requests through an HTTP(S) proxy. When empty, the
+	// standard HTTPS_PROXY/HTTP_PROXY/NO_PROXY environment variables apply.
+	ProxyURL string `json:"proxyURL" mapstructure:"proxyURL"`
+}
+
+// validate checks timeouts, pool sizes, and the proxy URL.
+func (h *HTTPClientConfig) validate() error {
+	if h == nil {
+		return nil
+	}
+	if h.Timeout < 0 || h.DialTimeout < 0 || h.TLSHandshakeTimeout < 0 || h.ResponseHeaderTimeout < 0 ||
+		h.IdleConnTimeout < 0 || h.HTTP2ReadIdleTimeout < 0 {
+		return &ConfigError{
+			Context: "HTTP Client",
+			Message: "HTTP client timeouts must not be negative",
+		}
+	}
+	if h.MaxIdleConns < 0 || h.MaxIdleConnsPerHost < 0 || h.MaxConnsPerHost < 0 {
+		return &ConfigError{
+			Context: "HTTP Client",
+			Message: "HTTP client connection limits must not be negative",
+		}
+	}
+	if h.ProxyURL != "" && !isHTTPURL(h.ProxyURL) {
+		return &ConfigError{
+			Context: "HTTP Client",
+			Message: "proxyURL must be an absolute http or https URL",
+		}
+	}
+	return nil
+}
+
+// isHTTPURL reports whether raw is an absolute http or https URL with a host.
+func isHTTPURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && u.Host != "" && (u.Scheme == "http" || u.Scheme == "https")
+}
@@ -0,0 +1,39 @@
+package config
+
+import (
+	// go1.21 - Idempotency record lifetime
+	"time"
+)
+
+// IdempotencyConfig configures Idempotency-Key handling on the send endpoints.
+// A send retried with the same key, e.g. after a network timeout, is answered
+// with the original response instead of being delivered again.
+type IdempotencyConfig struct {
+	// Enabled honors the Idempotency-Key header on send endpoints.
+	Enabled bool `json:"enabled" mapstructure:"enabled"`
+
+	// TTL is how long a response is replayed for its key.
+	TTL time.Duration `json:"ttl" mapstructure:"ttl"`
+
+	// MaxEntries bounds the responses kept; the oldest are evicted first.
+	MaxEntries int `json:"maxEntries" mapstructure:"maxEntries"`
+}
+
+// IdempotencyEnabled reports whether Idempotency-Key headers are honored.
+func (i *IdempotencyConfig) IdempotencyEnabled() bool {
+	return i != nil && i.Enabled
+}
+
+// validate checks the TTL and size bound.
+func (i *IdempotencyConfig) validate() error {
+	if !i.IdempotencyEnabled() {
+		return nil
+	}
+	if i.TTL <= 0 || i.MaxEntries <= 0 {
+		return &ConfigError{
+			Context: "Idempotency",
+			Message: "Idempotency requires a positive ttl and maxEntries",
+		}
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package config
+
+import (
+	// go1.21 - Poll interval and correlation window
+	"time"
+)
+
+// InboundMailConfig configures polling an IMAP mailbox, typically the sender
+// address's, for replies and bounces to sent email.
+type InboundMailConfig struct {
+	// Host and Port locate the IMAP server; Port defaults to 993, or 143 with
+	// Plaintext.
+	Host string `json:"host" mapstructure:"host"`
+	Port int    `json:"port" mapstructure:"port"`
+
+	// Username and Password are the mailbox credentials.
+	Username string `json:"username" mapstructure:"username"`
+	Password string `json:"password" mapstructure:"password"`
+
+	// Plaintext connects without TLS, for local test servers only; otherwise
+	// the connection uses implicit TLS.
+	Plaintext bool `json:"plaintext" mapstructure:"plaintext"`
+
+	// Mailbox is the folder read, "INBOX" by default.
+	Mailbox string `json:"mailbox" mapstructure:"mailbox"`
+
+	// PollInterval is how often the mailbox is checked for new messages.
+	PollInterval time.Duration `json:"pollInterval" mapstructure:"pollInterval"`
+
+	// Retention is how long sent Message-IDs are remembered for correlating
+	// replies and bounces.
+	Retention time.Duration `json:"retention" mapstructure:"retention"`
+}
+
+// Enabled reports whether a mailbox is configured for polling.
+func (i *InboundMailConfig) Enabled() bool {
+	return i != nil && i.Host != ""
+}
+
+// validate checks the credentials and intervals. An absent section, or one
+// without a host, leaves inbound processing disabled.
+func (i *InboundMailConfig) validate() error {
+	if !i.Enabled() {
+		return nil
+	}
+	if i.Username == "" || i.Password == "" {
+		return &ConfigError{
+			Context: "Email Inbound",
+			Message: "IMAP polling requires a username and password",
+		}
+	}
+	if i.Port < 0 || i.Port > 65535 {
+		return &ConfigError{
+			Context: "Email Inbound",
+			Message: "IMAP port must be between 0 and 65535",
+		}
+	}
+	if i.PollInterval <= 0 || i.Retention <= 0 {
+		return &ConfigError{
+			Context: "Email Inbound",
+			Message: "IMAP pollInterval and retention must be positive",
+		}
+	}
+	return nil
+}
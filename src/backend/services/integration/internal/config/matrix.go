@@ -0,0 +1,61 @@
+package config
+
+import (
+	// go1.21 - Request timeout and room cache lifetime
+	"strings"
+	"time"
+)
+
+// MatrixConfig configures delivery to Matrix (for example, Element) rooms
+// through a homeserver's client-server API.
+type MatrixConfig struct {
+	// HomeserverURL is the base URL of the client-server API, e.g.
+	// "https://matrix.example.org".
+	HomeserverURL string `json:"homeserverURL" mapstructure:"homeserverURL"`
+
+	// AccessToken authenticates the bot account that posts messages.
+	AccessToken string `json:"accessToken" mapstructure:"accessToken"`
+
+	// DefaultRoom receives messages that do not name a room. It is a room ID
+	// ("!abc:example.org") or alias ("#ops:example.org").
+	DefaultRoom string `json:"defaultRoom" mapstructure:"defaultRoom"`
+
+	// Timeout bounds each call to the homeserver.
+	Timeout time.Duration `json:"timeout" mapstructure:"timeout"`
+
+	// MetadataTTL is how long room alias lookups are cached. Zero disables the cache.
+	MetadataTTL time.Duration `json:"metadataTTL" mapstructure:"metadataTTL"`
+}
+
+// validate checks the homeserver URL, token and default room. An absent
+// section, or one without a homeserver, leaves Matrix unconfigured.
+func (m *MatrixConfig) validate() error {
+	if m == nil || m.HomeserverURL == "" {
+		return nil
+	}
+	if !isHTTPURL(m.HomeserverURL) {
+		return &ConfigError{
+			Context: "Matrix",
+			Message: "homeserverURL must be an absolute http or https URL",
+		}
+	}
+	if m.AccessToken == "" {
+		return &ConfigError{
+			Context: "Matrix",
+			Message: "Matrix requires an accessToken",
+		}
+	}
+	if m.DefaultRoom != "" && !strings.HasPrefix(m.DefaultRoom, "!") && !strings.HasPrefix(m.DefaultRoom, "#") {
+		return &ConfigError{
+			Context: "Matrix",
+			Message: "defaultRoom must be a room ID (!...) or alias (#...), found: " + m.DefaultRoom,
+		}
+	}
+	if m.Timeout < 0 || m.MetadataTTL < 0 {
+		return &ConfigError{
+			Context: "Matrix",
+			Message: "Matrix timeout and metadataTTL must not be negative",
+		}
+	}
+	return nil
+}
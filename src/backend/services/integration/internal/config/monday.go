@@ -0,0 +1,92 @@
+package config
+
+import (
+	// go1.21 - Request timeout
+	"time"
+)
+
+// Column types understood by MondayColumnConfig.Type. They decide how a
+// payload value is encoded into monday.com's column value JSON.
+const (
+	MondayColumnText     = "text"
+	MondayColumnLongText = "long_text"
+	MondayColumnNumbers  = "numbers"
+	MondayColumnStatus   = "status"
+	MondayColumnDate     = "date"
+	MondayColumnPeople   = "people"
+	MondayColumnEmail    = "email"
+	MondayColumnDropdown = "dropdown"
+)
+
+// MondayConfig configures item creation on monday.com boards.
+type MondayConfig struct {
+	// APIURL is the GraphQL endpoint; the default is the public API.
+	APIURL string `json:"apiURL" mapstructure:"apiURL"`
+
+	// APIToken authenticates the account that creates items.
+	APIToken string `json:"apiToken" mapstructure:"apiToken"`
+
+	// APIVersion pins the monday.com API version sent with every request.
+	APIVersion string `json:"apiVersion" mapstructure:"apiVersion"`
+
+	// DefaultBoard is the board ID items are created on when a payload names none.
+	DefaultBoard string `json:"defaultBoard" mapstructure:"defaultBoard"`
+
+	// DefaultGroup is the group ID, on DefaultBoard, for new items. Empty uses
+	// the board's top group.
+	DefaultGroup string `json:"defaultGroup" mapstructure:"defaultGroup"`
+
+	// Columns maps the value names used in payloads to board columns. Names
+	// are matched case-insensitively.
+	Columns map[string]MondayColumnConfig `json:"columns" mapstructure:"columns"`
+
+	// Timeout bounds each call to monday.com.
+	Timeout time.Duration `json:"timeout" mapstructure:"timeout"`
+}
+
+// MondayColumnConfig identifies a board column and how values are written to it.
+type MondayColumnConfig struct {
+	// ID is the column ID on the board, e.g. "status" or "date4".
+	ID string `json:"id" mapstructure:"id"`
+
+	// Type is one of the MondayColumn* types.
+	Type string `json:"type" mapstructure:"type"`
+}
+
+// validate checks the endpoint, token and column mappings. An absent section,
+// or one without a token, leaves monday.com unconfigured.
+func (m *MondayConfig) validate() error {
+	if m == nil || m.APIToken == "" {
+		return nil
+	}
+	if !isHTTPURL(m.APIURL) {
+		return &ConfigError{
+			Context: "Monday",
+			Message: "apiURL must be an absolute http or https URL",
+		}
+	}
+	for name, column := range m.Columns {
+		if column.ID == "" {
+			return &ConfigError{
+				Context: "Monday",
+				Message: "column " + name + " requires an id",
+			}
+		}
+		switch column.Type {
+		case MondayColumnText, MondayColumnLongText, MondayColumnNumbers, MondayColumnStatus,
+			MondayColumnDate, MondayColumnPeople, MondayColumnEmail, MondayColumnDropdown:
+		default:
+			return &ConfigError{
+				Context: "Monday",
+				Message: "column " + name + " has unsupported type: " + column.Type,
+			}
+		}
+	}
+	if m.Timeout < 0 {
+		return &ConfigError{
+			Context: "Monday",
+			Message: "Monday timeout must not be negative",
+		}
+	}
+	return nil
+}
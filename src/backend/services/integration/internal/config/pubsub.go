@@ -0,0 +1,53 @@
+package config
+
+import (
+	// go1.21 - Request timeout
+	"time"
+)
+
+// PubSubConfig configures publishing to Google Cloud Pub/Sub topics.
+type PubSubConfig struct {
+	// ProjectID owns the topics. It defaults to the project of the credentials.
+	ProjectID string `json:"projectID" mapstructure:"projectID"`
+
+	// Endpoint is the Pub/Sub API base URL. Ordered delivery is more reliable
+	// through a regional endpoint, e.g. https://us-east1-pubsub.googleapis.com.
+	Endpoint string `json:"endpoint" mapstructure:"endpoint"`
+
+	// CredentialsFile is the path of a service account key file. Empty uses
+	// Application Default Credentials (GOOGLE_APPLICATION_CREDENTIALS, gcloud
+	// credentials, or the metadata server).
+	CredentialsFile string `json:"credentialsFile" mapstructure:"credentialsFile"`
+
+	// DefaultTopic is the topic ID messages are published to when a payload
+	// names none.
+	DefaultTopic string `json:"defaultTopic" mapstructure:"defaultTopic"`
+
+	// Topics maps topic names used in payloads to topic IDs. Names are matched
+	// case-insensitively; unmapped values are used as topic IDs.
+	Topics map[string]string `json:"topics" mapstructure:"topics"`
+
+	// Timeout bounds each publish call.
+	Timeout time.Duration `json:"timeout" mapstructure:"timeout"`
+}
+
+// validate checks the endpoint and timeout. An absent section, or one without
+// a default topic, leaves Pub/Sub unconfigured.
+func (p *PubSubConfig) validate() error {
+	if p == nil || p.DefaultTopic == "" {
+		return nil
+	}
+	if !isHTTPURL(p.Endpoint) {
+		return &ConfigError{
+			Context: "Pub/Sub",
+			Message: "endpoint must be an absolute http or https URL",
+		}
+	}
+	if p.Timeout < 0 {
+		return &ConfigError{
+			Context: "Pub/Sub",
+			Message: "Pub/Sub timeout must not be negative",
+		}
+	}
+	return nil
+}
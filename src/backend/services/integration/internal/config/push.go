@@ -0,0 +1,106 @@
+package config
+
+import (
+	// go1.21 - Request timeout
+	"time"
+)
+
+// PushConfig configures mobile push notifications. Each platform has its own
+// block; a platform without one is not used, and messages for its devices are
+// rejected.
+type PushConfig struct {
+	// FCM configures Firebase Cloud Messaging, for Android and web devices.
+	FCM *FCMConfig `json:"fcm" mapstructure:"fcm"`
+
+	// APNs configures the Apple Push Notification service, for iOS devices.
+	APNs *APNsConfig `json:"apns" mapstructure:"apns"`
+
+	// FeedbackURL, when set, receives a JSON POST for each device token a
+	// platform reports as invalid, so the application can forget the token.
+	FeedbackURL string `json:"feedbackURL" mapstructure:"feedbackURL"`
+
+	// Timeout bounds each call to a push service.
+	Timeout time.Duration `json:"timeout" mapstructure:"timeout"`
+}
+
+// FCMConfig configures sending through the FCM HTTP v1 API.
+type FCMConfig struct {
+	// ProjectID is the Firebase project. It defaults to the project of the
+	// credentials.
+	ProjectID string `json:"projectID" mapstructure:"projectID"`
+
+	// Endpoint overrides the FCM API base URL, https://fcm.googleapis.com.
+	Endpoint string `json:"endpoint" mapstructure:"endpoint"`
+
+	// CredentialsFile is the path of a service account key file. Empty uses
+	// Application Default Credentials.
+	CredentialsFile string `json:"credentialsFile" mapstructure:"credentialsFile"`
+}
+
+// APNsConfig configures sending through APNs with token-based authentication.
+type APNsConfig struct {
+	// TeamID is the Apple developer team that owns the key.
+	TeamID string `json:"teamID" mapstructure:"teamID"`
+
+	// KeyID identifies the APNs authentication key.
+	KeyID string `json:"keyID" mapstructure:"keyID"`
+
+	// PrivateKeyFile is the path of the key's .p8 file.
+	PrivateKeyFile string `json:"privateKeyFile" mapstructure:"privateKeyFile"`
+
+	// Topic is the app's bundle ID, used when a message names no topic.
+	Topic string `json:"topic" mapstructure:"topic"`
+
+	// Production selects the production APNs environment rather than the
+	// development (sandbox) one. It is ignored when Endpoint is set.
+	Production bool `json:"production" mapstructure:"production"`
+
+	// Endpoint overrides the APNs base URL chosen by Production.
+	Endpoint string `json:"endpoint" mapstructure:"endpoint"`
+}
+
+// Enabled reports whether any push platform is configured.
+func (p *PushConfig) Enabled() bool {
+	return p != nil && (p.FCM != nil || p.APNs != nil)
+}
+
+// validate checks each configured platform. An absent section, or one with no
+// platform blocks, leaves push unconfigured.
+func (p *PushConfig) validate() error {
+	if !p.Enabled() {
+		return nil
+	}
+	if p.FCM != nil && p.FCM.Endpoint != "" && !isHTTPURL(p.FCM.Endpoint) {
+		return &ConfigError{
+			Context: "Push",
+			Message: "fcm.endpoint must be an absolute http or https URL",
+		}
+	}
+	if a := p.APNs; a != nil {
+		if a.TeamID == "" || a.KeyID == "" || a.PrivateKeyFile == "" || a.Topic == "" {
+			return &ConfigError{
+				Context: "Push",
+				Message: "apns requires teamID, keyID, privateKeyFile and topic",
+			}
+		}
+		if a.Endpoint != "" && !isHTTPURL(a.Endpoint) {
+			return &ConfigError{
+				Context: "Push",
+				Message: "apns.endpoint must be an absolute http or https URL",
+			}
+		}
+	}
+	if p.FeedbackURL != "" && !isHTTPURL(p.FeedbackURL) {
+		return &ConfigError{
+			Context: "Push",
+			Message: "feedbackURL must be an absolute http or https URL",
+		}
+	}
+	if p.Timeout < 0 {
+		return &ConfigError{
+			Context: "Push",
+			Message: "push timeout must not be negative",
+		}
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package config
+
+import (
+	// go1.21 - Replay scheduling
+	"time"
+)
+
+// Spool encryption key providers for SpoolEncryptionConfig.Provider.
+const (
+	// KeyProviderKMS generates and unwraps data keys with AWS KMS.
+	KeyProviderKMS = "kms"
+
+	// KeyProviderStatic uses a locally configured 256-bit key. Intended for
+	// development and environments without a KMS.
+	KeyProviderStatic = "static"
+)
+
+// SpoolEncryptionConfig configures envelope encryption of spooled payloads.
+type SpoolEncryptionConfig struct {
+	// Enabled encrypts every record written to the spool with AES-256-GCM.
+	Enabled bool `json:"enabled" mapstructure:"enabled"`
+
+	// Provider selects where data keys come from: "kms" or "static".
+	Provider string `json:"provider" mapstructure:"provider"`
+
+	// KMSKeyID is the KMS key ID, ARN, or alias used to generate data keys.
+	KMSKeyID string `json:"kmsKeyId" mapstructure:"kmsKeyId"`
+
+	// Region is the AWS region of the KMS key; defaults to the SDK's region resolution.
+	Region string `json:"region" mapstructure:"region"`
+
+	// StaticKey is a base64-encoded 32-byte key for the "static" provider. Must be kept secure.
+	StaticKey string `json:"staticKey" mapstructure:"staticKey"`
+}
+
+// SpoolConfig configures the on-disk spool backing the persistent queue.
+type SpoolConfig struct {
+	// Enabled persists queued payloads to disk.
+	Enabled bool `json:"enabled" mapstructure:"enabled"`
+
+	// Dir is the directory holding spool records. It must be writable by the service.
+	Dir string `json:"dir" mapstructure:"dir"`
+
+	// Encryption configures encryption at rest for spooled payloads.
+	Encryption *SpoolEncryptionConfig `json:"encryption" mapstructure:"encryption"`
+}
+
+// StoreAndForwardConfig configures graceful degradation: while an integration's
+// circuit is open, its messages are written to the spool instead of failing, and
+// replayed in order once the integration is healthy again.
+type StoreAndForwardConfig struct {
+	// Enabled diverts messages for integrations with an open circuit to the spool.
+	Enabled bool `json:"enabled" mapstructure:"enabled"`
+
+	// ReplayInterval is how often stored messages are checked for replay.
+	ReplayInterval time.Duration `json:"replayInterval" mapstructure:"replayInterval"`
+
+	// ReplayBatchSize caps how many stored messages are replayed per integration per interval.
+	ReplayBatchSize int `json:"replayBatchSize" mapstructure:"replayBatchSize"`
+}
+
+// QueueConfig groups settings for message queueing.
+type QueueConfig struct {
+	// Spool configures the persistent on-disk spool.
+	Spool *SpoolConfig `json:"spool" mapstructure:"spool"`
+
+	// StoreAndForward diverts messages to the spool during integration outages.
+	StoreAndForward *StoreAndForwardConfig `json:"storeAndForward" mapstructure:"storeAndForward"`
+}
+
+// SpoolEnabled reports whether the persistent spool is configured.
+func (q *QueueConfig) SpoolEnabled() bool {
+	return q != nil && q.Spool != nil && q.Spool.Enabled
+}
+
+// SpoolEncryption returns the spool's encryption settings, which also cover
+// dispatch queue spill segments, or nil when there are none.
+func (q *QueueConfig) SpoolEncryption() *SpoolEncryptionConfig {
+	if q == nil || q.Spool == nil {
+		return nil
+	}
+	return q.Spool.Encryption
+}
+
+// StoreAndForwardEnabled reports whether outage messages should be spooled for replay.
+func (q *QueueConfig) StoreAndForwardEnabled() bool {
+	return q.SpoolEnabled() && q.StoreAndForward != nil && q.StoreAndForward.Enabled
+}
+
+// validate checks spool location and encryption key configuration.
+func (q *QueueConfig) validate() error {
+	if q != nil && q.StoreAndForward != nil && q.StoreAndForward.Enabled {
+		if !q.SpoolEnabled() {
+			return &ConfigError{
+				Context: "Store and Forward",
+				Message: "Store-and-forward requires the spool to be enabled",
+			}
+		}
+		if q.StoreAndForward.ReplayInterval <= 0 || q.StoreAndForward.ReplayBatchSize <= 0 {
+			return &ConfigError{
+				Context: "Store and Forward",
+				Message: "Store-and-forward requires a positive replayInterval and replayBatchSize",
+			}
+		}
+	}
+	if !q.SpoolEnabled() {
+		return nil
+	}
+	if q.Spool.Dir == "" {
+		return &ConfigError{
+			Context: "Queue Spool",
+			Message: "Spool is enabled but no directory is configured",
+		}
+	}
+
+	enc := q.Spool.Encryption
+	if enc == nil || !enc.Enabled {
+		return nil
+	}
+	switch enc.Provider {
+	case KeyProviderKMS:
+		if enc.KMSKeyID == "" {
+			return &ConfigError{
+				Context: "Spool Encryption",
+				Message: "KMS provider requires kmsKeyId",
+			}
+		}
+	case KeyProviderStatic:
+		if enc.StaticKey == "" {
+			return &ConfigError{
+				Context: "Spool Encryption",
+				Message: "Static provider requires a base64 staticKey",
+			}
+		}
+	default:
+		return &ConfigError{
+			Context: "Spool Encryption",
+			Message: "Encryption provider must be kms or static, found: " + enc.Provider,
+		}
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package config
+
+import (
+	// go1.21 - Rate periods
+	"time"
+)
+
+// RateSpec describes a fixed-window request quota.
+type RateSpec struct {
+	// Limit is the number of requests allowed per Period.
+	Limit int64 `json:"limit" mapstructure:"limit"`
+
+	// Period is the window length for Limit.
+	Period time.Duration `json:"period" mapstructure:"period"`
+}
+
+// Principal keys a RateLimitConfig may bucket authenticated requests by.
+const (
+	// RateLimitKeySubject gives every principal its own bucket.
+	RateLimitKeySubject = "subject"
+
+	// RateLimitKeyClient shares one bucket among the principals of an OAuth2
+	// client, i.e. an API key, falling back to the subject when there is none.
+	RateLimitKeyClient = "client"
+)
+
+// Rate limit store types.
+const (
+	// RateLimitStoreMemory keeps counters in each replica's memory.
+	RateLimitStoreMemory = "memory"
+
+	// RateLimitStoreRedis keeps counters in Redis, shared by every replica.
+	RateLimitStoreRedis = "redis"
+)
+
+// RedisConfig locates a Redis server.
+type RedisConfig struct {
+	// Address is the server's host:port.
+	Address string `json:"address" mapstructure:"address"`
+
+	// Username and Password authenticate with Redis ACLs; both may be empty.
+	Username string `json:"username" mapstructure:"username"`
+	Password string `json:"password" mapstructure:"password"`
+
+	// DB is the logical database number.
+	DB int `json:"db" mapstructure:"db"`
+
+	// TLS connects over TLS, verifying the server against the system roots.
+	TLS bool `json:"tls" mapstructure:"tls"`
+
+	// KeyPrefix namespaces the keys written, so several deployments can share a server.
+	KeyPrefix string `json:"keyPrefix" mapstructure:"keyPrefix"`
+
+	// DialTimeout bounds connecting to the server.
+	DialTimeout time.Duration `json:"dialTimeout" mapstructure:"dialTimeout"`
+}
+
+// RateLimitStoreConfig selects where rate limit counters are kept. With the
+// in-memory store each replica enforces limits on its own, so N replicas admit
+// N times the configured rates; Redis enforces them cluster-wide, for the API
+// quotas and for the provider call rates of the Slack and Jira adapters.
+type RateLimitStoreConfig struct {
+	// Type is "memory" (the default) or "redis".
+	Type string `json:"type" mapstructure:"type"`
+
+	// Redis is the server used by the "redis" type.
+	Redis *RedisConfig `json:"redis" mapstructure:"redis"`
+}
+
+// Distributed reports whether counters are shared by every replica.
+func (s *RateLimitStoreConfig) Distributed() bool {
+	return s != nil && s.Type == RateLimitStoreRedis
+}
+
+// validate checks the store type and, for Redis, its address.
+func (s *RateLimitStoreConfig) validate() error {
+	if s == nil {
+		return nil
+	}
+	switch s.Type {
+	case "", RateLimitStoreMemory:
+		return nil
+	case RateLimitStoreRedis:
+		if s.Redis == nil || s.Redis.Address == "" {
+			return &ConfigError{
+				Context: "Rate Limit Store",
+				Message: "The redis rate limit store requires redis.address",
+			}
+		}
+		return nil
+	default:
+		return &ConfigError{
+			Context: "Rate Limit Store",
+			Message: "Rate limit store type must be memory or redis, found: " + s.Type,
+		}
+	}
+}
+
+// RateTier overrides the default authenticated quota for specific principals.
+type RateTier struct {
+	// Name identifies the tier in logs and metrics (e.g., "internal", "partner").
+	Name string `json:"name" mapstructure:"name"`
+
+	// Subjects lists the principal subjects or client IDs assigned to this tier.
+	Subjects []string `json:"subjects" mapstructure:"subjects"`
+
+	// Rate is the quota applied to each subject in the tier.
+	Rate RateSpec `json:"rate" mapstructure:"rate"`
+
+	// Quota optionally caps the tier's requests over a longer window, such as
+	// 100000 a day, in addition to Rate. Nil inherits RateLimitConfig.Quota.
+	Quota *RateSpec `json:"quota" mapstructure:"quota"`
+}
+
+// RateLimitConfig configures inbound request quotas.
+type RateLimitConfig struct {
+	// Anonymous is the per-client-IP quota applied to requests without an
+	// authenticated principal.
+	Anonymous RateSpec `json:"anonymous" mapstructure:"anonymous"`
+
+	// Authenticated is the default per-principal quota applied after authentication.
+	Authenticated RateSpec `json:"authenticated" mapstructure:"authenticated"`
+
+	// Quota optionally caps each principal's requests over a longer window, in
+	// addition to Authenticated.
+	Quota *RateSpec `json:"quota" mapstructure:"quota"`
+
+	// KeyBy selects what authenticated requests are bucketed by: "subject"
+	// (the default) or "client".
+	KeyBy string `json:"keyBy" mapstructure:"keyBy"`
+
+	// Tiers override the authenticated quota for specific principals.
+	Tiers []RateTier `json:"tiers" mapstructure:"tiers"`
+
+	// Store selects where the counters are kept.
+	Store *RateLimitStoreConfig `json:"store" mapstructure:"store"`
+}
+
+// validate ensures every quota is positive and the bucket key and store are known.
+func (r *RateLimitConfig) validate() error {
+	if r == nil {
+		return nil
+	}
+	switch r.KeyBy {
+	case "", RateLimitKeySubject, RateLimitKeyClient:
+	default:
+		return &ConfigError{
+			Context: "Rate Limit",
+			Message: "Rate limit keyBy must be subject or client, found: " + r.KeyBy,
+		}
+	}
+	if err := r.Store.validate(); err != nil {
+		return err
+	}
+	specs := []RateSpec{r.Anonymous, r.Authenticated}
+	if r.Quota != nil {
+		specs = append(specs, *r.Quota)
+	}
+	for _, tier := range r.Tiers {
+		if tier.Name == "" {
+			return &ConfigError{
+				Context: "Rate Limit Tiers",
+				Message: "Each rate limit tier requires a name",
+			}
+		}
+		specs = append(specs, tier.Rate)
+		if tier.Quota != nil {
+			specs = append(specs, *tier.Quota)
+		}
+	}
+	for _, spec := range specs {
+		if spec.Limit <= 0 || spec.Period <= 0 {
+			return &ConfigError{
+				Context: "Rate Limit",
+				Message: "Rate limits require a positive limit and period",
+			}
+		}
+	}
+	return nil
+}
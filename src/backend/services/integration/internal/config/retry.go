@@ -0,0 +1,94 @@
+package config
+
+import (
+	// go1.21 - Budget accounting windows
+	"time"
+)
+
+// RetryBudgetSpec limits retries relative to request volume.
+type RetryBudgetSpec struct {
+	// Ratio is the fraction of requests that may be retried within the window,
+	// e.g. 0.2 allows one retry for every five requests.
+	Ratio float64 `json:"ratio" mapstructure:"ratio"`
+
+	// MinRetries is always allowed per window so low-traffic integrations can
+	// still retry occasional failures.
+	MinRetries int `json:"minRetries" mapstructure:"minRetries"`
+}
+
+// RetryBudgetOverride sets the budget for one integration.
+type RetryBudgetOverride struct {
+	// Integration is the registered integration name, e.g. "jira".
+	Integration string `json:"integration" mapstructure:"integration"`
+
+	// Spec is this integration's budget.
+	Spec RetryBudgetSpec `json:"spec" mapstructure:"spec"`
+}
+
+// RetryBudgetConfig caps retries so that a struggling provider is not hit with
+// amplified traffic. A retry must fit both its integration's budget and the
+// global budget; once either is exhausted, retries are skipped and the send
+// fails fast (or is stored for replay when store-and-forward is enabled).
+type RetryBudgetConfig struct {
+	// Enabled turns retry budgets on. When disabled, adapters retry as configured.
+	Enabled bool `json:"enabled" mapstructure:"enabled"`
+
+	// Window is the sliding window over which requests and retries are counted.
+	Window time.Duration `json:"window" mapstructure:"window"`
+
+	// Global bounds retries across all integrations.
+	Global RetryBudgetSpec `json:"global" mapstructure:"global"`
+
+	// Default applies to every integration without an override.
+	Default RetryBudgetSpec `json:"default" mapstructure:"default"`
+
+	// Overrides tune individual integrations.
+	Overrides []RetryBudgetOverride `json:"overrides" mapstructure:"overrides"`
+}
+
+// RetryBudgetEnabled reports whether retry budgets should be enforced.
+func (r *RetryBudgetConfig) RetryBudgetEnabled() bool {
+	return r != nil && r.Enabled
+}
+
+// ForIntegration returns the budget for the named integration.
+func (r *RetryBudgetConfig) ForIntegration(name string) RetryBudgetSpec {
+	for _, o := range r.Overrides {
+		if o.Integration == name {
+			return o.Spec
+		}
+	}
+	return r.Default
+}
+
+// validate checks the window and that every ratio lies in [0, 1].
+func (r *RetryBudgetConfig) validate() error {
+	if !r.RetryBudgetEnabled() {
+		return nil
+	}
+	if r.Window <= 0 {
+		return &ConfigError{
+			Context: "Retry Budget",
+			Message: "Retry budget requires a positive window",
+		}
+	}
+	specs := []RetryBudgetSpec{r.Global, r.Default}
+	for _, o := range r.Overrides {
+		if o.Integration == "" {
+			return &ConfigError{
+				Context: "Retry Budget",
+				Message: "Retry budget overrides must name an integration",
+			}
+		}
+		specs = append(specs, o.Spec)
+	}
+	for _, spec := range specs {
+		if spec.Ratio < 0 || spec.Ratio > 1 || spec.MinRetries < 0 {
+			return &ConfigError{
+				Context: "Retry Budget",
+				Message: "Retry budget ratio must be between 0 and 1 and minRetries must not be negative",
+			}
+		}
+	}
+	return nil
+}
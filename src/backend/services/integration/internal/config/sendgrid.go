@@ -0,0 +1,61 @@
+package config
+
+import (
+	// go1.21 - Request timeout
+	"time"
+)
+
+// SendGridConfig configures sending email through the SendGrid v3 Mail Send API.
+type SendGridConfig struct {
+	// BaseURL is the SendGrid API base URL; the default is the global API.
+	// EU-pinned subusers use https://api.eu.sendgrid.com.
+	BaseURL string `json:"baseURL" mapstructure:"baseURL"`
+
+	// APIKey authenticates requests; it needs the Mail Send permission.
+	APIKey string `json:"apiKey" mapstructure:"apiKey"`
+
+	// Templates maps template names used in payloads to dynamic template IDs.
+	// Names are matched case-insensitively; unmapped values are used as IDs.
+	Templates map[string]string `json:"templates" mapstructure:"templates"`
+
+	// Categories are added to every message, for filtering in SendGrid's
+	// statistics.
+	Categories []string `json:"categories" mapstructure:"categories"`
+
+	// SandboxMode validates messages without delivering them, for
+	// non-production environments.
+	SandboxMode bool `json:"sandboxMode" mapstructure:"sandboxMode"`
+
+	// Timeout bounds each call to SendGrid.
+	Timeout time.Duration `json:"timeout" mapstructure:"timeout"`
+}
+
+// validate checks the base URL and API key. It is only called when SendGrid is
+// the selected email provider.
+func (s *SendGridConfig) validate() error {
+	if s == nil || s.APIKey == "" {
+		return &ConfigError{
+			Context: "SendGrid",
+			Message: "The sendgrid email provider requires email.sendGrid.apiKey",
+		}
+	}
+	if !isHTTPURL(s.BaseURL) {
+		return &ConfigError{
+			Context: "SendGrid",
+			Message: "baseURL must be an absolute http or https URL",
+		}
+	}
+	if len(s.Categories) > 10 {
+		return &ConfigError{
+			Context: "SendGrid",
+			Message: "SendGrid accepts at most 10 categories per message",
+		}
+	}
+	if s.Timeout < 0 {
+		return &ConfigError{
+			Context: "SendGrid",
+			Message: "SendGrid timeout must not be negative",
+		}
+	}
+	return nil
+}
@@ -0,0 +1,393 @@
+package config
+
+import (
+	// go1.21 - Admin listen address validation
+	"net"
+	// go1.21 - HSTS max-age durations
+	"time"
+)
+
+// Client certificate policies for ServerTLSConfig.ClientAuth.
+const (
+	// ClientAuthNone does not request client certificates.
+	ClientAuthNone = "none"
+
+	// ClientAuthOptional requests a client certificate and verifies it against the
+	// client CA bundle when presented, but still accepts connections without one.
+	ClientAuthOptional = "optional"
+
+	// ClientAuthRequired rejects TLS handshakes that do not present a client
+	// certificate signed by the client CA bundle.
+	ClientAuthRequired = "required"
+)
+
+// ServerTLSConfig configures TLS and mutual TLS for the HTTP server.
+type ServerTLSConfig struct {
+	// Enabled serves HTTPS instead of plaintext HTTP.
+	Enabled bool `json:"enabled" mapstructure:"enabled"`
+
+	// CertFile is the PEM-encoded server certificate chain. Not used with ACME.
+	CertFile string `json:"certFile" mapstructure:"certFile"`
+
+	// KeyFile is the PEM-encoded private key for CertFile.
+	KeyFile string `json:"keyFile" mapstructure:"keyFile"`
+
+	// ACME, when enabled, obtains and renews the server certificate
+	// automatically instead of loading CertFile and KeyFile.
+	ACME *ACMEConfig `json:"acme" mapstructure:"acme"`
+
+	// ClientCAFile is a PEM bundle of CAs trusted to sign client certificates.
+	// It or ClientCAFiles is required when ClientAuth is "optional" or "required".
+	ClientCAFile string `json:"clientCAFile" mapstructure:"clientCAFile"`
+
+	// ClientCAFiles are further PEM bundles of trusted client CAs, e.g. one per
+	// mesh trust domain. Clients signed by any bundle's CAs are accepted.
+	ClientCAFiles []string `json:"clientCAFiles" mapstructure:"clientCAFiles"`
+
+	// ClientAuth is one of "none", "optional", or "required".
+	ClientAuth string `json:"clientAuth" mapstructure:"clientAuth"`
+}
+
+// ClientCABundles returns every configured client CA bundle.
+func (t *ServerTLSConfig) ClientCABundles() []string {
+	if t == nil {
+		return nil
+	}
+	var bundles []string
+	if t.ClientCAFile != "" {
+		bundles = append(bundles, t.ClientCAFile)
+	}
+	for _, bundle := range t.ClientCAFiles {
+		if bundle != "" {
+			bundles = append(bundles, bundle)
+		}
+	}
+	return bundles
+}
+
+// HSTSConfig configures the Strict-Transport-Security response header.
+type HSTSConfig struct {
+	// MaxAge is how long browsers should remember to only use HTTPS.
+	MaxAge time.Duration `json:"maxAge" mapstructure:"maxAge"`
+
+	// IncludeSubDomains applies the policy to all subdomains.
+	IncludeSubDomains bool `json:"includeSubDomains" mapstructure:"includeSubDomains"`
+
+	// Preload signals consent to inclusion in browser preload lists. Requires
+	// IncludeSubDomains and a max-age of at least one year.
+	Preload bool `json:"preload" mapstructure:"preload"`
+
+	// Force emits the header on plaintext requests as well, for deployments where
+	// TLS is terminated by an upstream load balancer.
+	Force bool `json:"force" mapstructure:"force"`
+}
+
+// SecurityHeadersConfig configures response security headers.
+type SecurityHeadersConfig struct {
+	// HSTS is sent automatically on requests served over TLS.
+	HSTS *HSTSConfig `json:"hsts" mapstructure:"hsts"`
+
+	// ContentSecurityPolicy is sent on every response when non-empty, except
+	// that the Swagger UI page below /docs/ sends its own.
+	ContentSecurityPolicy string `json:"contentSecurityPolicy" mapstructure:"contentSecurityPolicy"`
+}
+
+// ServerHTTP2Config configures HTTP/2 on the service's listener.
+type ServerHTTP2Config struct {
+	// Enabled negotiates HTTP/2 via ALPN on TLS connections. When false the
+	// server speaks HTTP/1.1 only.
+	Enabled bool `json:"enabled" mapstructure:"enabled"`
+
+	// H2C accepts cleartext HTTP/2 (prior knowledge or Upgrade: h2c) on a
+	// plaintext listener. Intended for internal traffic behind a mesh or load
+	// balancer that terminates TLS; ignored when TLS is enabled.
+	H2C bool `json:"h2c" mapstructure:"h2c"`
+
+	// MaxConcurrentStreams limits streams per HTTP/2 connection; 0 uses the
+	// library default.
+	MaxConcurrentStreams uint32 `json:"maxConcurrentStreams" mapstructure:"maxConcurrentStreams"`
+
+	// IdleTimeout closes HTTP/2 connections with no active streams after this
+	// long; 0 falls back to the server's idle timeout.
+	IdleTimeout time.Duration `json:"idleTimeout" mapstructure:"idleTimeout"`
+}
+
+// ServerAdminConfig configures the operational listener that serves metrics,
+// health, pprof and admin endpoints apart from the public API.
+type ServerAdminConfig struct {
+	// Enabled moves /metrics, /health/secure and /admin/* off the public
+	// listener and onto Addr, alongside /healthz, /readyz and pprof.
+	Enabled bool `json:"enabled" mapstructure:"enabled"`
+
+	// Addr is the admin listen address, e.g. ":9090" or "127.0.0.1:9090". It must
+	// differ from the public API port. The default, 127.0.0.1:9090, is reachable
+	// from the host only; probes and scrapes from other hosts need ":9090".
+	Addr string `json:"addr" mapstructure:"addr"`
+
+	// Pprof serves the runtime profiler under /debug/pprof/ to operators, who
+	// authenticate as on /admin/*. Off by default.
+	Pprof bool `json:"pprof" mapstructure:"pprof"`
+
+	// Auth, when it lists users, holds the operator accounts for the admin
+	// listener in place of auth.basic, so that operators need not share API
+	// accounts.
+	Auth *BasicAuthConfig `json:"auth" mapstructure:"auth"`
+}
+
+// Server timeouts used when the server section omits them.
+const (
+	defaultReadHeaderTimeout = 15 * time.Second
+	defaultReadTimeout       = 30 * time.Second
+	defaultWriteTimeout      = 30 * time.Second
+	defaultIdleTimeout       = 60 * time.Second
+	defaultShutdownTimeout   = 30 * time.Second
+	defaultDrainDelay        = 5 * time.Second
+	defaultMaxHeaderBytes    = 1 << 20
+	defaultMaxBodyBytes      = 1 << 20
+)
+
+// ServerTimeoutsConfig bounds how long the HTTP server waits on clients and on
+// itself during shutdown. A zero Read, Write or Idle timeout disables it, as in
+// net/http.
+type ServerTimeoutsConfig struct {
+	// ReadHeader bounds reading the request line and headers.
+	ReadHeader time.Duration `json:"readHeader" mapstructure:"readHeader"`
+
+	// Read bounds reading the entire request, including the body.
+	Read time.Duration `json:"read" mapstructure:"read"`
+
+	// Write bounds the time from the end of the request headers to the end of
+	// the response.
+	Write time.Duration `json:"write" mapstructure:"write"`
+
+	// Idle is how long a keep-alive connection may wait for its next request.
+	Idle time.Duration `json:"idle" mapstructure:"idle"`
+
+	// Shutdown bounds graceful shutdown: draining connections, the dispatch
+	// queue, and flushing metric exporters.
+	Shutdown time.Duration `json:"shutdown" mapstructure:"shutdown"`
+
+	// DrainDelay is how long /readyz reports 503 at the start of shutdown
+	// before the listeners stop accepting connections, so that load balancers
+	// stop routing new requests first. It counts toward Shutdown.
+	DrainDelay time.Duration `json:"drainDelay" mapstructure:"drainDelay"`
+}
+
+// ServerConfig holds settings for the service's HTTP listener.
+type ServerConfig struct {
+	// TLS configures HTTPS and client certificate authentication.
+	TLS *ServerTLSConfig `json:"tls" mapstructure:"tls"`
+
+	// SecurityHeaders configures HSTS and Content-Security-Policy headers.
+	SecurityHeaders *SecurityHeadersConfig `json:"securityHeaders" mapstructure:"securityHeaders"`
+
+	// HTTP2 configures HTTP/2 and cleartext h2c.
+	HTTP2 *ServerHTTP2Config `json:"http2" mapstructure:"http2"`
+
+	// MaxConnections caps concurrently open client connections; further
+	// connections wait in the accept backlog. 0 means unlimited.
+	MaxConnections int `json:"maxConnections" mapstructure:"maxConnections"`
+
+	// Admin configures the separate operational listener.
+	Admin *ServerAdminConfig `json:"admin" mapstructure:"admin"`
+
+	// Timeouts configures server read, write, idle and shutdown timeouts.
+	Timeouts *ServerTimeoutsConfig `json:"timeouts" mapstructure:"timeouts"`
+
+	// MaxHeaderBytes limits the size of request headers, including the request line.
+	MaxHeaderBytes int `json:"maxHeaderBytes" mapstructure:"maxHeaderBytes"`
+
+	// MaxBodyBytes limits the size of request bodies; larger requests are
+	// rejected with 413.
+	MaxBodyBytes int64 `json:"maxBodyBytes" mapstructure:"maxBodyBytes"`
+
+	// AccessLog configures the per-request access log.
+	AccessLog *AccessLogConfig `json:"accessLog" mapstructure:"accessLog"`
+}
+
+// TLSEnabled reports whether the HTTP server should serve TLS.
+func (s *ServerConfig) TLSEnabled() bool {
+	return s != nil && s.TLS != nil && s.TLS.Enabled
+}
+
+// HTTP2Enabled reports whether the server should negotiate HTTP/2.
+func (s *ServerConfig) HTTP2Enabled() bool {
+	return s != nil && s.HTTP2 != nil && s.HTTP2.Enabled
+}
+
+// H2CEnabled reports whether the plaintext listener should accept cleartext HTTP/2.
+func (s *ServerConfig) H2CEnabled() bool {
+	return s.HTTP2Enabled() && s.HTTP2.H2C && !s.TLSEnabled()
+}
+
+// ServerTimeouts returns the configured timeouts, with defaults for a missing section.
+func (s *ServerConfig) ServerTimeouts() ServerTimeoutsConfig {
+	if s == nil || s.Timeouts == nil {
+		return ServerTimeoutsConfig{
+			ReadHeader: defaultReadHeaderTimeout,
+			Read:       defaultReadTimeout,
+			Write:      defaultWriteTimeout,
+			Idle:       defaultIdleTimeout,
+			Shutdown:   defaultShutdownTimeout,
+			DrainDelay: defaultDrainDelay,
+		}
+	}
+	return *s.Timeouts
+}
+
+// HeaderBytesLimit returns MaxHeaderBytes, or the default when unset.
+func (s *ServerConfig) HeaderBytesLimit() int {
+	if s == nil || s.MaxHeaderBytes == 0 {
+		return defaultMaxHeaderBytes
+	}
+	return s.MaxHeaderBytes
+}
+
+// BodyBytesLimit returns MaxBodyBytes, or the default when unset.
+func (s *ServerConfig) BodyBytesLimit() int64 {
+	if s == nil || s.MaxBodyBytes == 0 {
+		return defaultMaxBodyBytes
+	}
+	return s.MaxBodyBytes
+}
+
+// AdminEnabled reports whether operational endpoints are served on their own listener.
+func (s *ServerConfig) AdminEnabled() bool {
+	return s != nil && s.Admin != nil && s.Admin.Enabled
+}
+
+// OperatorCredentials returns the accounts allowed to use the operator
+// endpoints: the admin listener's own accounts when it is enabled and has them,
+// otherwise auth.basic.
+func (c *Config) OperatorCredentials() *BasicAuthConfig {
+	if c.Server.AdminEnabled() && c.Server.Admin.Auth != nil && len(c.Server.Admin.Auth.Users) > 0 {
+		return c.Server.Admin.Auth
+	}
+	return c.Auth.BasicConfig()
+}
+
+// validate checks that certificate material is configured consistently.
+func (s *ServerConfig) validate() error {
+	if s == nil {
+		return nil
+	}
+	if headers := s.SecurityHeaders; headers != nil && headers.HSTS != nil && headers.HSTS.Preload {
+		if !headers.HSTS.IncludeSubDomains || headers.HSTS.MaxAge < 365*24*time.Hour {
+			return &ConfigError{
+				Context: "Server HSTS",
+				Message: "HSTS preload requires includeSubDomains and a maxAge of at least one year",
+			}
+		}
+	}
+	if t := s.Timeouts; t != nil {
+		if t.ReadHeader <= 0 || t.Shutdown <= 0 {
+			return &ConfigError{
+				Context: "Server timeouts",
+				Message: "readHeader and shutdown timeouts must be positive",
+			}
+		}
+		if t.Read < 0 || t.Write < 0 || t.Idle < 0 {
+			return &ConfigError{
+				Context: "Server timeouts",
+				Message: "read, write and idle timeouts must not be negative",
+			}
+		}
+		if t.DrainDelay < 0 || t.DrainDelay >= t.Shutdown {
+			return &ConfigError{
+				Context: "Server timeouts",
+				Message: "drainDelay must not be negative and must be shorter than the shutdown timeout",
+			}
+		}
+		if t.Read > 0 && t.ReadHeader > t.Read {
+			return &ConfigError{
+				Context: "Server timeouts",
+				Message: "readHeader timeout must not exceed the read timeout",
+			}
+		}
+	}
+	if s.MaxHeaderBytes < 0 {
+		return &ConfigError{
+			Context: "Server",
+			Message: "maxHeaderBytes must not be negative",
+		}
+	}
+	if s.MaxBodyBytes < 0 {
+		return &ConfigError{
+			Context: "Server",
+			Message: "maxBodyBytes must not be negative",
+		}
+	}
+	if err := s.AccessLog.validate(); err != nil {
+		return err
+	}
+	if s.MaxConnections < 0 {
+		return &ConfigError{
+			Context: "Server",
+			Message: "maxConnections must not be negative",
+		}
+	}
+	if h := s.HTTP2; h != nil {
+		if h.IdleTimeout < 0 {
+			return &ConfigError{
+				Context: "Server HTTP/2",
+				Message: "idleTimeout must not be negative",
+			}
+		}
+		if h.H2C && s.TLSEnabled() {
+			return &ConfigError{
+				Context: "Server HTTP/2",
+				Message: "h2c is cleartext HTTP/2 and cannot be combined with TLS; disable one of them",
+			}
+		}
+	}
+	if s.AdminEnabled() {
+		if _, _, err := net.SplitHostPort(s.Admin.Addr); err != nil {
+			return &ConfigError{
+				Context: "Server admin listener",
+				Message: "addr must be host:port or :port, found: " + s.Admin.Addr,
+			}
+		}
+		if err := s.Admin.Auth.validate(); err != nil {
+			return err
+		}
+	}
+	if !s.TLSEnabled() {
+		return nil
+	}
+
+	t := s.TLS
+	if err := t.ACME.validate(); err != nil {
+		return err
+	}
+	if t.ACMEEnabled() {
+		if t.CertFile != "" || t.KeyFile != "" {
+			return &ConfigError{
+				Context: "Server TLS",
+				Message: "certFile and keyFile cannot be combined with ACME; remove one of them",
+			}
+		}
+	} else if t.CertFile == "" || t.KeyFile == "" {
+		return &ConfigError{
+			Context: "Server TLS",
+			Message: "TLS is enabled but certFile or keyFile is missing",
+		}
+	}
+
+	switch t.ClientAuth {
+	case ClientAuthNone:
+	case ClientAuthOptional, ClientAuthRequired:
+		if len(t.ClientCABundles()) == 0 {
+			return &ConfigError{
+				Context: "Server mTLS",
+				Message: "clientAuth " + t.ClientAuth + " requires a clientCAFile or clientCAFiles bundle",
+			}
+		}
+	default:
+		return &ConfigError{
+			Context: "Server mTLS",
+			Message: "clientAuth must be none, optional, or required, found: " + t.ClientAuth,
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package config
+
+import (
+	// go1.21 - Request timeout
+	"time"
+)
+
+// Authentication modes for ServiceNowConfig.AuthMode.
+const (
+	ServiceNowAuthBasic = "basic"
+	ServiceNowAuthOAuth = "oauth"
+)
+
+// ServiceNowConfig configures record creation through the ServiceNow Table API.
+type ServiceNowConfig struct {
+	// InstanceURL is the instance base URL, e.g. https://example.service-now.com.
+	InstanceURL string `json:"instanceURL" mapstructure:"instanceURL"`
+
+	// AuthMode is "basic" (the default) or "oauth". OAuth uses the password
+	// grant against the instance's /oauth_token.do endpoint.
+	AuthMode string `json:"authMode" mapstructure:"authMode"`
+
+	// Username and Password identify the integration user in both modes.
+	Username string `json:"username" mapstructure:"username"`
+	Password string `json:"password" mapstructure:"password"`
+
+	// ClientID and ClientSecret identify the OAuth application registry entry;
+	// they are required in oauth mode.
+	ClientID     string `json:"clientID" mapstructure:"clientID"`
+	ClientSecret string `json:"clientSecret" mapstructure:"clientSecret"`
+
+	// DefaultTable is the table records are created in when a payload names
+	// none, e.g. "incident".
+	DefaultTable string `json:"defaultTable" mapstructure:"defaultTable"`
+
+	// Tables maps table names used in payloads (e.g. "task") to instance tables
+	// (e.g. "sc_task"). Names are matched case-insensitively; unmapped values
+	// are used as table names.
+	Tables map[string]string `json:"tables" mapstructure:"tables"`
+
+	// Fields maps field names used in payloads (e.g. "severity") to table
+	// columns (e.g. "impact"), matched like Tables.
+	Fields map[string]string `json:"fields" mapstructure:"fields"`
+
+	// Timeout bounds each call to the instance.
+	Timeout time.Duration `json:"timeout" mapstructure:"timeout"`
+}
+
+// validate checks the instance URL, authentication mode and credentials. An
+// absent section, or one without an instance URL, leaves ServiceNow
+// unconfigured.
+func (s *ServiceNowConfig) validate() error {
+	if s == nil || s.InstanceURL == "" {
+		return nil
+	}
+	if !isHTTPURL(s.InstanceURL) {
+		return &ConfigError{
+			Context: "ServiceNow",
+			Message: "instanceURL must be an absolute http or https URL",
+		}
+	}
+	if s.Username == "" || s.Password == "" {
+		return &ConfigError{
+			Context: "ServiceNow",
+			Message: "ServiceNow requires a username and password",
+		}
+	}
+	switch s.AuthMode {
+	case ServiceNowAuthBasic:
+	case ServiceNowAuthOAuth:
+		if s.ClientID == "" || s.ClientSecret == "" {
+			return &ConfigError{
+				Context: "ServiceNow",
+				Message: "oauth authMode requires a clientID and clientSecret",
+			}
+		}
+	default:
+		return &ConfigError{
+			Context: "ServiceNow",
+			Message: "authMode must be basic or oauth, got: " + s.AuthMode,
+		}
+	}
+	if s.Timeout < 0 {
+		return &ConfigError{
+			Context: "ServiceNow",
+			Message: "ServiceNow timeout must not be negative",
+		}
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package config
+
+import (
+	// go1.21 - ARN checks
+	"strings"
+	// go1.21 - Request timeout and suppression lifetime
+	"time"
+)
+
+// SESConfig configures sending email through the Amazon SES v2 API. AWS
+// credentials come from the default chain (environment, shared files, or the
+// instance or task role).
+type SESConfig struct {
+	// Region is the SES region; empty uses the default chain's region.
+	Region string `json:"region" mapstructure:"region"`
+
+	// ConfigurationSet names the SES configuration set applied to every
+	// message, e.g. to publish delivery events or select a dedicated IP pool.
+	ConfigurationSet string `json:"configurationSet" mapstructure:"configurationSet"`
+
+	// NotificationTopicARNs are the SNS topics whose bounce and complaint
+	// notifications are accepted at /notifications/email. Notifications and
+	// subscription confirmations from other topics are rejected.
+	NotificationTopicARNs []string `json:"notificationTopicARNs" mapstructure:"notificationTopicARNs"`
+
+	// SuppressionTTL is how long an address that bounced permanently or
+	// complained is refused. Zero keeps it suppressed until restart.
+	SuppressionTTL time.Duration `json:"suppressionTTL" mapstructure:"suppressionTTL"`
+
+	// Timeout bounds each call to SES.
+	Timeout time.Duration `json:"timeout" mapstructure:"timeout"`
+}
+
+// validate checks the topic ARNs and durations. It is only called when SES is
+// the selected email provider.
+func (s *SESConfig) validate() error {
+	if s == nil {
+		return nil
+	}
+	for _, arn := range s.NotificationTopicARNs {
+		if !strings.HasPrefix(arn, "arn:aws") || !strings.Contains(arn, ":sns:") {
+			return &ConfigError{
+				Context: "SES",
+				Message: "notificationTopicARNs must be SNS topic ARNs, got: " + arn,
+			}
+		}
+	}
+	if s.SuppressionTTL < 0 || s.Timeout < 0 {
+		return &ConfigError{
+			Context: "SES",
+			Message: "SES suppressionTTL and timeout must not be negative",
+		}
+	}
+	return nil
+}
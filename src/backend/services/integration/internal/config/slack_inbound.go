@@ -0,0 +1,39 @@
+package config
+
+import (
+	// go1.21 - Replay tolerance
+	"time"
+)
+
+// SlackInboundConfig configures the endpoints Slack calls: the Events API
+// receiver and the slash command and interactivity endpoints. Requests are
+// authenticated with the app's signing secret.
+type SlackInboundConfig struct {
+	// SigningSecrets are the app's accepted signing secrets. During rotation,
+	// list the new secret alongside the old one until Slack has switched over.
+	SigningSecrets []string `json:"signingSecrets" mapstructure:"signingSecrets"`
+
+	// Tolerance is the maximum age of a signed request; Slack recommends five
+	// minutes.
+	Tolerance time.Duration `json:"tolerance" mapstructure:"tolerance"`
+}
+
+// Enabled reports whether the inbound endpoints are served, which requires a
+// signing secret.
+func (s *SlackInboundConfig) Enabled() bool {
+	return s != nil && len(s.SigningSecrets) > 0
+}
+
+// validate checks the tolerance.
+func (s *SlackInboundConfig) validate() error {
+	if s == nil {
+		return nil
+	}
+	if s.Tolerance <= 0 {
+		return &ConfigError{
+			Context: "Slack Inbound",
+			Message: "tolerance must be positive",
+		}
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	// go1.21 - Timeout bounds
+	"time"
+)
+
+// SlackRateLimitConfig paces Slack API calls. Each workspace has its own
+// limiter, which halves its rate on every 429 and recovers towards
+// RequestsPerSecond while calls succeed.
+type SlackRateLimitConfig struct {
+	// RequestsPerSecond is the highest call rate.
+	RequestsPerSecond float64 `json:"requestsPerSecond" mapstructure:"requestsPerSecond"`
+
+	// Burst is the number of calls that may be made at once.
+	Burst int `json:"burst" mapstructure:"burst"`
+
+	// MinRequestsPerSecond is the lowest rate the limiter backs off to.
+	MinRequestsPerSecond float64 `json:"minRequestsPerSecond" mapstructure:"minRequestsPerSecond"`
+}
+
+// validateLimits checks the timeout, retry policy and rate limits.
+func (s *SlackConfig) validateLimits() error {
+	if s.Timeout < 0 || s.Timeout > 5*time.Minute {
+		return &ConfigError{
+			Context: "Slack Timeout",
+			Message: "timeout must be between 0 and 5m, got: " + s.Timeout.String(),
+		}
+	}
+	if s.RetryAttempts < 0 || s.MaxRetryAfter < 0 {
+		return &ConfigError{
+			Context: "Slack Retry",
+			Message: "retryAttempts and maxRetryAfter must not be negative",
+		}
+	}
+	if rl := s.RateLimit; rl != nil {
+		if rl.RequestsPerSecond < 0 || rl.Burst < 0 || rl.MinRequestsPerSecond < 0 {
+			return &ConfigError{
+				Context: "Slack Rate Limit",
+				Message: "requestsPerSecond, burst and minRequestsPerSecond must not be negative",
+			}
+		}
+		if rl.RequestsPerSecond > 0 && rl.MinRequestsPerSecond > rl.RequestsPerSecond {
+			return &ConfigError{
+				Context: "Slack Rate Limit",
+				Message: "minRequestsPerSecond must not exceed requestsPerSecond",
+			}
+		}
+	}
+	return nil
+}
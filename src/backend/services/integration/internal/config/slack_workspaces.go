@@ -0,0 +1,41 @@
+package config
+
+// SlackDefaultWorkspace names the workspace of the primary Slack token, used
+// for messages that name no workspace.
+const SlackDefaultWorkspace = "default"
+
+// SlackWorkspaceConfig is an additional Slack workspace messages can be routed
+// to by name, with its own bot token.
+type SlackWorkspaceConfig struct {
+	// Name identifies the workspace in payloads and status.
+	Name string `json:"name" mapstructure:"name"`
+
+	// Token is the workspace's bot token. Must be kept secure.
+	Token string `json:"token" mapstructure:"token"`
+
+	// DefaultChannel is where messages to the workspace go when they name no
+	// channel.
+	DefaultChannel string `json:"defaultChannel" mapstructure:"defaultChannel"`
+}
+
+// validateWorkspaces checks that every additional workspace has a unique name
+// and a token.
+func (s *SlackConfig) validateWorkspaces() error {
+	seen := map[string]bool{SlackDefaultWorkspace: true}
+	for _, ws := range s.Workspaces {
+		if ws.Name == "" || seen[ws.Name] {
+			return &ConfigError{
+				Context: "Slack Workspaces",
+				Message: "workspace names must be set, unique and not \"" + SlackDefaultWorkspace + "\", got: " + ws.Name,
+			}
+		}
+		seen[ws.Name] = true
+		if ws.Token == "" {
+			return &ConfigError{
+				Context: "Slack Workspaces",
+				Message: "workspace " + ws.Name + " has no token",
+			}
+		}
+	}
+	return nil
+}
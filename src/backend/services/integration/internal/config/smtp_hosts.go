@@ -0,0 +1,74 @@
+package config
+
+import (
+	// go1.21 - Failback interval
+	"time"
+)
+
+// SMTPHostConfig is a fallback SMTP server used when the servers before it are
+// unreachable. Port, Username and Password default to the email settings'
+// own, so only differing values need to be set.
+type SMTPHostConfig struct {
+	// Host is the fallback server's hostname.
+	Host string `json:"host" mapstructure:"host"`
+
+	// Port is the fallback server's port.
+	Port int `json:"port" mapstructure:"port"`
+
+	// Username and Password are the fallback server's credentials.
+	Username string `json:"username" mapstructure:"username"`
+	Password string `json:"password" mapstructure:"password"`
+}
+
+// SMTPFailoverConfig lists fallback SMTP servers, in order of preference, tried
+// after the primary server in EmailConfig.Host.
+type SMTPFailoverConfig struct {
+	// Hosts are the fallback servers in the order they are tried.
+	Hosts []SMTPHostConfig `json:"hosts" mapstructure:"hosts"`
+
+	// FailbackInterval is how long new connections keep using a fallback server
+	// before the primary is tried again; it defaults to five minutes.
+	FailbackInterval time.Duration `json:"failbackInterval" mapstructure:"failbackInterval"`
+}
+
+// SMTPHosts returns the SMTP servers in the order they are tried: the primary
+// first, then each fallback with the primary's port and credentials filled in
+// where it sets none.
+func (e *EmailConfig) SMTPHosts() []SMTPHostConfig {
+	hosts := []SMTPHostConfig{{Host: e.Host, Port: e.Port, Username: e.Username, Password: e.Password}}
+	if e.Failover == nil {
+		return hosts
+	}
+	for _, h := range e.Failover.Hosts {
+		if h.Port == 0 {
+			h.Port = e.Port
+		}
+		if h.Username == "" && h.Password == "" {
+			h.Username, h.Password = e.Username, e.Password
+		}
+		hosts = append(hosts, h)
+	}
+	return hosts
+}
+
+// validate checks that each fallback names a server.
+func (f *SMTPFailoverConfig) validate() error {
+	if f == nil {
+		return nil
+	}
+	for _, h := range f.Hosts {
+		if h.Host == "" || h.Port < 0 {
+			return &ConfigError{
+				Context: "Email Failover",
+				Message: "each failover host requires a host name and a valid port",
+			}
+		}
+	}
+	if f.FailbackInterval < 0 {
+		return &ConfigError{
+			Context: "Email Failover",
+			Message: "failbackInterval must not be negative",
+		}
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package config
+
+// SMTPOAuth2Config configures XOAUTH2 authentication for SMTP servers, such as
+// Gmail and Office 365, that have basic authentication disabled. The SMTP
+// username is the mailbox the token is presented for.
+type SMTPOAuth2Config struct {
+	// TokenURL is the provider's token endpoint, e.g.
+	// https://oauth2.googleapis.com/token or
+	// https://login.microsoftonline.com/<tenant>/oauth2/v2.0/token.
+	TokenURL string `json:"tokenURL" mapstructure:"tokenURL"`
+
+	// ClientID and ClientSecret identify the OAuth client.
+	ClientID     string `json:"clientID" mapstructure:"clientID"`
+	ClientSecret string `json:"clientSecret" mapstructure:"clientSecret"`
+
+	// Scopes are requested with each token, e.g. https://mail.google.com/ or
+	// https://outlook.office365.com/.default.
+	Scopes []string `json:"scopes" mapstructure:"scopes"`
+
+	// RefreshToken, when set, obtains access tokens for a delegated user;
+	// otherwise the client-credentials grant is used, as with Office 365
+	// application access.
+	RefreshToken string `json:"refreshToken" mapstructure:"refreshToken"`
+}
+
+// validate checks the token endpoint and client credentials.
+func (o *SMTPOAuth2Config) validate() error {
+	if !isHTTPURL(o.TokenURL) {
+		return &ConfigError{
+			Context: "Email OAuth2",
+			Message: "tokenURL must be an absolute http or https URL",
+		}
+	}
+	if o.ClientID == "" {
+		return &ConfigError{
+			Context: "Email OAuth2",
+			Message: "Email OAuth2 requires a clientID",
+		}
+	}
+	if o.RefreshToken == "" && o.ClientSecret == "" {
+		return &ConfigError{
+			Context: "Email OAuth2",
+			Message: "Email OAuth2 requires a clientSecret or a refreshToken",
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package config
+
+import (
+	// go1.21 - Pin decoding
+	"crypto/sha256"
+	"encoding/base64"
+)
+
+// SMTP transport security modes for TLSConfig.Mode.
+const (
+	// SMTPTLSImplicit negotiates TLS as soon as the connection opens (port 465).
+	SMTPTLSImplicit = "implicit"
+
+	// SMTPTLSStartTLS connects in plaintext and upgrades with STARTTLS before
+	// authenticating, failing if the server does not offer it (port 587).
+	SMTPTLSStartTLS = "starttls"
+
+	// SMTPTLSNone sends in plaintext. Credentials are never sent over it except
+	// to localhost.
+	SMTPTLSNone = "none"
+)
+
+// TLSConfig configures transport security for SMTP connections.
+type TLSConfig struct {
+	// Mode is "implicit", "starttls" or "none". Empty derives it from the
+	// email settings: implicit on port 465 with useTLS, starttls on port 587 or
+	// otherwise with useTLS, and none without it.
+	Mode string `json:"mode" mapstructure:"mode"`
+
+	// MinVersion is the lowest TLS version accepted, "1.2" (the default) or "1.3".
+	MinVersion string `json:"minVersion" mapstructure:"minVersion"`
+
+	// ServerName overrides the host name the server certificate is verified
+	// against, e.g. when connecting through an IP address or tunnel.
+	ServerName string `json:"serverName" mapstructure:"serverName"`
+
+	// CAFile is a PEM bundle of additional trusted roots, for relays with
+	// certificates from a private CA.
+	CAFile string `json:"caFile" mapstructure:"caFile"`
+
+	// PinnedPublicKeys are base64 SHA-256 digests of certificate public keys
+	// (SubjectPublicKeyInfo). When set, a certificate in the server's chain must
+	// match one, in addition to passing normal verification.
+	PinnedPublicKeys []string `json:"pinnedPublicKeys" mapstructure:"pinnedPublicKeys"`
+}
+
+// SMTPTLSMode returns the transport security mode for SMTP connections,
+// deriving it from UseTLS and the port when TLS.Mode is not set.
+func (e *EmailConfig) SMTPTLSMode() string {
+	if e.TLS != nil && e.TLS.Mode != "" {
+		return e.TLS.Mode
+	}
+	switch {
+	case e.UseTLS && e.Port == 465:
+		return SMTPTLSImplicit
+	case e.UseTLS || e.Port == 587:
+		return SMTPTLSStartTLS
+	default:
+		return SMTPTLSNone
+	}
+}
+
+// validate checks the mode, version and pins.
+func (t *TLSConfig) validate() error {
+	if t == nil {
+		return nil
+	}
+	switch t.Mode {
+	case "", SMTPTLSImplicit, SMTPTLSStartTLS, SMTPTLSNone:
+	default:
+		return &ConfigError{
+			Context: "Email TLS",
+			Message: "mode must be implicit, starttls or none, got: " + t.Mode,
+		}
+	}
+	switch t.MinVersion {
+	case "", "1.2", "1.3":
+	default:
+		return &ConfigError{
+			Context: "Email TLS",
+			Message: "minVersion must be 1.2 or 1.3, got: " + t.MinVersion,
+		}
+	}
+	for _, pin := range t.PinnedPublicKeys {
+		if digest, err := base64.StdEncoding.DecodeString(pin); err != nil || len(digest) != sha256.Size {
+			return &ConfigError{
+				Context: "Email TLS",
+				Message: "pinnedPublicKeys must be base64 SHA-256 digests, got: " + pin,
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package config
+
+import (
+	// go1.21 - Request timeout
+	"time"
+)
+
+// SplunkConfig configures posting events to a Splunk HTTP Event Collector.
+type SplunkConfig struct {
+	// URL is the collector base URL, e.g. https://splunk.example.com:8088.
+	URL string `json:"url" mapstructure:"url"`
+
+	// Token is the HEC token, sent as "Authorization: Splunk <token>".
+	Token string `json:"token" mapstructure:"token"`
+
+	// Index, SourceType, Source and Host are the event metadata used when an
+	// event sets none. An empty index uses the token's default index.
+	Index      string `json:"index" mapstructure:"index"`
+	SourceType string `json:"sourceType" mapstructure:"sourceType"`
+	Source     string `json:"source" mapstructure:"source"`
+	Host       string `json:"host" mapstructure:"host"`
+
+	// Channel is sent as X-Splunk-Request-Channel; tokens with indexer
+	// acknowledgment enabled require one.
+	Channel string `json:"channel" mapstructure:"channel"`
+
+	// MaxBatchEvents caps the events posted in one request by batch sends.
+	MaxBatchEvents int `json:"maxBatchEvents" mapstructure:"maxBatchEvents"`
+
+	// MaxBatchBytes caps the size of one request's body; HEC rejects bodies
+	// over its max_content_length, 1 MB by default.
+	MaxBatchBytes int `json:"maxBatchBytes" mapstructure:"maxBatchBytes"`
+
+	// Timeout bounds each call to the collector.
+	Timeout time.Duration `json:"timeout" mapstructure:"timeout"`
+}
+
+// validate checks the URL, token and batch limits. An absent section, or one
+// without a URL, leaves Splunk unconfigured.
+func (s *SplunkConfig) validate() error {
+	if s == nil || s.URL == "" {
+		return nil
+	}
+	if !isHTTPURL(s.URL) {
+		return &ConfigError{
+			Context: "Splunk",
+			Message: "url must be an absolute http or https URL",
+		}
+	}
+	if s.Token == "" {
+		return &ConfigError{
+			Context: "Splunk",
+			Message: "Splunk requires an HEC token",
+		}
+	}
+	if s.MaxBatchEvents <= 0 || s.MaxBatchBytes <= 0 {
+		return &ConfigError{
+			Context: "Splunk",
+			Message: "Splunk maxBatchEvents and maxBatchBytes must be positive",
+		}
+	}
+	if s.Timeout < 0 {
+		return &ConfigError{
+			Context: "Splunk",
+			Message: "Splunk timeout must not be negative",
+		}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package config
+
+import (
+	// go1.21 - Status check deadlines
+	"time"
+)
+
+// StatusCheckOverride sets the status check timeout for one integration.
+type StatusCheckOverride struct {
+	// Integration is the registered integration name, e.g. "slack".
+	Integration string `json:"integration" mapstructure:"integration"`
+
+	// Timeout bounds this integration's status check.
+	Timeout time.Duration `json:"timeout" mapstructure:"timeout"`
+}
+
+// StatusCheckConfig bounds how long the health endpoint waits for each
+// integration to report its status. Checks run concurrently; an integration
+// that misses its deadline is reported as degraded instead of stalling the
+// whole report.
+type StatusCheckConfig struct {
+	// Timeout applies to every integration without an override.
+	Timeout time.Duration `json:"timeout" mapstructure:"timeout"`
+
+	// Overrides tune individual integrations, e.g. a longer deadline for a slow provider.
+	Overrides []StatusCheckOverride `json:"overrides" mapstructure:"overrides"`
+}
+
+// ForIntegration returns the status check timeout for the named integration.
+// Zero means the check is not bounded.
+func (s *StatusCheckConfig) ForIntegration(name string) time.Duration {
+	if s == nil {
+		return 0
+	}
+	for _, o := range s.Overrides {
+		if o.Integration == name {
+			return o.Timeout
+		}
+	}
+	return s.Timeout
+}
+
+// validate checks that every timeout is positive.
+func (s *StatusCheckConfig) validate() error {
+	if s == nil {
+		return nil
+	}
+	if s.Timeout <= 0 {
+		return &ConfigError{
+			Context: "Status Checks",
+			Message: "Status checks require a positive timeout",
+		}
+	}
+	for _, o := range s.Overrides {
+		if o.Integration == "" || o.Timeout <= 0 {
+			return &ConfigError{
+				Context: "Status Checks",
+				Message: "Status check overrides must name an integration and set a positive timeout",
+			}
+		}
+	}
+	return nil
+}
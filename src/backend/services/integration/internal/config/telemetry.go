@@ -0,0 +1,128 @@
+package config
+
+import (
+	// go1.21 - Time-related operations for export intervals and timeouts
+	"time"
+)
+
+// Supported metric exporter identifiers for TelemetryConfig.Metrics.Exporters.
+const (
+	// MetricsExporterPrometheus exposes metrics on the /metrics scrape endpoint.
+	MetricsExporterPrometheus = "prometheus"
+
+	// MetricsExporterOTLP pushes metrics to an OpenTelemetry collector via OTLP.
+	MetricsExporterOTLP = "otlp"
+)
+
+// Supported OTLP transport protocols for OTLPConfig.Protocol.
+const (
+	// OTLPProtocolGRPC exports over OTLP/gRPC (collector port 4317 by default).
+	OTLPProtocolGRPC = "grpc"
+
+	// OTLPProtocolHTTP exports over OTLP/HTTP with protobuf payloads (port 4318 by default).
+	OTLPProtocolHTTP = "http"
+)
+
+// OTLPConfig describes how metrics are pushed to an OpenTelemetry collector.
+type OTLPConfig struct {
+	// Endpoint is the collector host:port (e.g., "otel-collector:4317").
+	Endpoint string `json:"endpoint" mapstructure:"endpoint"`
+
+	// Protocol selects the OTLP transport, either "grpc" or "http".
+	Protocol string `json:"protocol" mapstructure:"protocol"`
+
+	// Insecure disables TLS towards the collector. Intended for sidecar or in-cluster collectors only.
+	Insecure bool `json:"insecure" mapstructure:"insecure"`
+
+	// Headers are attached to every export request, typically for collector authentication.
+	Headers map[string]string `json:"headers" mapstructure:"headers"`
+
+	// Interval is how often accumulated metrics are pushed to the collector.
+	Interval time.Duration `json:"interval" mapstructure:"interval"`
+
+	// Timeout bounds a single export request.
+	Timeout time.Duration `json:"timeout" mapstructure:"timeout"`
+}
+
+// MetricsConfig selects which metric exporters are active for the service.
+type MetricsConfig struct {
+	// Exporters lists the enabled exporters, any of "prometheus" and "otlp".
+	// Both may be enabled at the same time to support scrape and push side by side.
+	Exporters []string `json:"exporters" mapstructure:"exporters"`
+
+	// OTLP holds the collector settings used when the "otlp" exporter is enabled.
+	OTLP *OTLPConfig `json:"otlp" mapstructure:"otlp"`
+}
+
+// TelemetryConfig groups observability settings for the integration service.
+type TelemetryConfig struct {
+	// ServiceName is reported as the service.name resource attribute on exported telemetry.
+	ServiceName string `json:"serviceName" mapstructure:"serviceName"`
+
+	// Metrics controls how service metrics are exported.
+	Metrics *MetricsConfig `json:"metrics" mapstructure:"metrics"`
+}
+
+// ExporterEnabled reports whether the named metrics exporter is configured.
+// It is safe to call on a nil TelemetryConfig.
+func (t *TelemetryConfig) ExporterEnabled(name string) bool {
+	if t == nil {
+		return false
+	}
+	return t.Metrics.ExporterEnabled(name)
+}
+
+// ExporterEnabled reports whether the named metrics exporter is configured.
+func (m *MetricsConfig) ExporterEnabled(name string) bool {
+	if m == nil {
+		return false
+	}
+	for _, exporter := range m.Exporters {
+		if exporter == name {
+			return true
+		}
+	}
+	return false
+}
+
+// validate checks exporter names and, when OTLP push is enabled, the collector settings.
+func (t *TelemetryConfig) validate() error {
+	if t == nil || t.Metrics == nil {
+		return nil
+	}
+
+	for _, exporter := range t.Metrics.Exporters {
+		if exporter != MetricsExporterPrometheus && exporter != MetricsExporterOTLP {
+			return &ConfigError{
+				Context: "Telemetry Metrics",
+				Message: "Unsupported metrics exporter: " + exporter,
+			}
+		}
+	}
+
+	if !t.Metrics.ExporterEnabled(MetricsExporterOTLP) {
+		return nil
+	}
+
+	otlp := t.Metrics.OTLP
+	if otlp == nil || otlp.Endpoint == "" {
+		return &ConfigError{
+			Context: "Telemetry OTLP",
+			Message: "OTLP exporter is enabled but no collector endpoint is configured",
+		}
+	}
+	if otlp.Protocol != OTLPProtocolGRPC && otlp.Protocol != OTLPProtocolHTTP {
+		return &ConfigError{
+			Context: "Telemetry OTLP",
+			Message: "OTLP protocol must be grpc or http, found: " + otlp.Protocol,
+		}
+	}
+	if otlp.Interval <= 0 || otlp.Timeout <= 0 {
+		return &ConfigError{
+			Context: "Telemetry OTLP",
+			Message: "OTLP interval and timeout must be positive durations",
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package config
+
+import (
+	// go1.21 - Request timeout
+	"time"
+)
+
+// TrelloConfig configures card creation on Trello boards.
+type TrelloConfig struct {
+	// BaseURL is the Trello API base URL; the default is the public API.
+	BaseURL string `json:"baseURL" mapstructure:"baseURL"`
+
+	// APIKey and Token authenticate the member that creates cards.
+	APIKey string `json:"apiKey" mapstructure:"apiKey"`
+	Token  string `json:"token" mapstructure:"token"`
+
+	// DefaultList is the ID of the list cards are added to when a payload names
+	// no list.
+	DefaultList string `json:"defaultList" mapstructure:"defaultList"`
+
+	// Lists maps list names used in payloads to list IDs on the configured
+	// boards. Names are matched case-insensitively; unmapped values are used as IDs.
+	Lists map[string]string `json:"lists" mapstructure:"lists"`
+
+	// Labels maps label names used in payloads to label IDs, matched like Lists.
+	Labels map[string]string `json:"labels" mapstructure:"labels"`
+
+	// Timeout bounds each call to Trello.
+	Timeout time.Duration `json:"timeout" mapstructure:"timeout"`
+}
+
+// validate checks the base URL and credentials. An absent section, or one
+// without an API key, leaves Trello unconfigured.
+func (t *TrelloConfig) validate() error {
+	if t == nil || t.APIKey == "" {
+		return nil
+	}
+	if !isHTTPURL(t.BaseURL) {
+		return &ConfigError{
+			Context: "Trello",
+			Message: "baseURL must be an absolute http or https URL",
+		}
+	}
+	if t.Token == "" {
+		return &ConfigError{
+			Context: "Trello",
+			Message: "Trello requires a token along with the apiKey",
+		}
+	}
+	if t.Timeout < 0 {
+		return &ConfigError{
+			Context: "Trello",
+			Message: "Trello timeout must not be negative",
+		}
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package config
+
+import (
+	// go1.21 - Per-integration warm-up deadline
+	"time"
+)
+
+// WarmUpConfig configures the startup warm-up, which initializes and verifies
+// every configured integration before the service starts serving.
+type WarmUpConfig struct {
+	// Timeout bounds initializing and verifying one integration.
+	Timeout time.Duration `json:"timeout" mapstructure:"timeout"`
+
+	// Parallelism is how many integrations are warmed up at once.
+	Parallelism int `json:"parallelism" mapstructure:"parallelism"`
+}
+
+// validate checks the timeout and parallelism.
+func (w *WarmUpConfig) validate() error {
+	if w == nil {
+		return nil
+	}
+	if w.Timeout <= 0 {
+		return &ConfigError{
+			Context: "Warm-up",
+			Message: "Warm-up requires a positive timeout",
+		}
+	}
+	if w.Parallelism < 1 {
+		return &ConfigError{
+			Context: "Warm-up",
+			Message: "Warm-up parallelism must be at least 1",
+		}
+	}
+	return nil
+}
@@ -0,0 +1,294 @@
+package config
+
+import (
+	// go1.21 - Endpoint method checks
+	"net/http"
+	// go1.21 - Replay tolerance durations
+	"time"
+)
+
+// InboundWebhookConfig configures signature verification for one inbound webhook source.
+type InboundWebhookConfig struct {
+	// Source is the path segment identifying the sender, e.g. "slack", "jira", or "github".
+	Source string `json:"source" mapstructure:"source"`
+
+	// Scheme selects the signature format: "slack", "jira", or "generic".
+	Scheme string `json:"scheme" mapstructure:"scheme"`
+
+	// Secrets are the accepted shared secrets. During rotation, list the new secret
+	// alongside the old one until the sender has switched over.
+	Secrets []string `json:"secrets" mapstructure:"secrets"`
+
+	// Tolerance is the maximum allowed clock skew for signed timestamps; zero
+	// uses WebhooksConfig.InboundTolerance.
+	Tolerance time.Duration `json:"tolerance" mapstructure:"tolerance"`
+}
+
+// SigningKeyConfig is a single identified secret in the outbound signing key ring.
+type SigningKeyConfig struct {
+	// ID is sent in X-Signature-Key-Id so receivers can select the matching secret.
+	ID string `json:"id" mapstructure:"id"`
+
+	// Secret is the shared HMAC secret. Must be kept secure.
+	Secret string `json:"secret" mapstructure:"secret"`
+}
+
+// OutboundSigningConfig configures HMAC signing of outbound webhook requests.
+type OutboundSigningConfig struct {
+	// Keys is the signing key ring.
+	Keys []SigningKeyConfig `json:"keys" mapstructure:"keys"`
+
+	// ActiveKeyID selects the key used for new signatures; defaults to the first key.
+	ActiveKeyID string `json:"activeKeyId" mapstructure:"activeKeyId"`
+}
+
+// WebhookRetryConfig is the retry policy of one outbound endpoint. Throttled
+// (429) and transient (408, 5xx, network) failures are retried.
+type WebhookRetryConfig struct {
+	// MaxAttempts is the total number of attempts, including the first.
+	MaxAttempts int `json:"maxAttempts" mapstructure:"maxAttempts"`
+
+	// Backoff is the wait before the first retry. Each further retry doubles it,
+	// up to MaxBackoff; without MaxBackoff the wait stays constant.
+	Backoff    time.Duration `json:"backoff" mapstructure:"backoff"`
+	MaxBackoff time.Duration `json:"maxBackoff" mapstructure:"maxBackoff"`
+}
+
+// WebhookCloudEventsConfig wraps an endpoint's requests in CloudEvents 1.0.
+type WebhookCloudEventsConfig struct {
+	// Mode is "structured" (the default), sending the whole event as the
+	// application/cloudevents+json body, or "binary", sending the attributes as
+	// ce- headers and the rendered body as the event data.
+	Mode string `json:"mode" mapstructure:"mode"`
+
+	// Source is the event source, a URI reference such as
+	// "/taskstream/integration-service".
+	Source string `json:"source" mapstructure:"source"`
+
+	// Type is the event type, e.g. "com.taskstream.task.updated"; messages may
+	// override it.
+	Type string `json:"type" mapstructure:"type"`
+}
+
+// WebhookEndpointConfig is one target of the outbound webhook adapter.
+type WebhookEndpointConfig struct {
+	// Name identifies the endpoint in payloads and status.
+	Name string `json:"name" mapstructure:"name"`
+
+	// URL receives the requests.
+	URL string `json:"url" mapstructure:"url"`
+
+	// Method is POST (the default), PUT or PATCH.
+	Method string `json:"method" mapstructure:"method"`
+
+	// ContentType defaults to application/json. JSON bodies are checked to be
+	// valid JSON before they are sent.
+	ContentType string `json:"contentType" mapstructure:"contentType"`
+
+	// Headers are added to every request, e.g. an API key the target expects.
+	Headers map[string]string `json:"headers" mapstructure:"headers"`
+
+	// BodyTemplate is a Go text/template rendered with the payload as its data.
+	// Empty sends the payload as JSON.
+	BodyTemplate string `json:"bodyTemplate" mapstructure:"bodyTemplate"`
+
+	// Unsigned disables HMAC signing for targets that cannot verify it.
+	Unsigned bool `json:"unsigned" mapstructure:"unsigned"`
+
+	// Signing overrides the shared outbound key ring for this endpoint.
+	Signing *OutboundSigningConfig `json:"signing" mapstructure:"signing"`
+
+	// Retry overrides the default retry policy.
+	Retry *WebhookRetryConfig `json:"retry" mapstructure:"retry"`
+
+	// Timeout bounds each request; zero uses the shared HTTP client timeout.
+	Timeout time.Duration `json:"timeout" mapstructure:"timeout"`
+
+	// CloudEvents, when set, sends each request as a CloudEvent.
+	CloudEvents *WebhookCloudEventsConfig `json:"cloudEvents" mapstructure:"cloudEvents"`
+}
+
+// WebhooksConfig groups inbound verification and outbound signing settings.
+type WebhooksConfig struct {
+	// Inbound lists the accepted webhook sources and their verification secrets.
+	Inbound []InboundWebhookConfig `json:"inbound" mapstructure:"inbound"`
+
+	// InboundTolerance is the maximum allowed clock skew for signed timestamps
+	// of inbound sources that set no tolerance of their own.
+	InboundTolerance time.Duration `json:"inboundTolerance" mapstructure:"inboundTolerance"`
+
+	// Outbound configures signing for the webhook adapter.
+	Outbound *OutboundSigningConfig `json:"outbound" mapstructure:"outbound"`
+
+	// Endpoints are the targets of the webhook adapter.
+	Endpoints []WebhookEndpointConfig `json:"endpoints" mapstructure:"endpoints"`
+
+	// DefaultEndpoint names the endpoint for payloads that name none; it
+	// defaults to the first endpoint.
+	DefaultEndpoint string `json:"defaultEndpoint" mapstructure:"defaultEndpoint"`
+}
+
+// Endpoint returns the outbound endpoint called name, or the default endpoint
+// when name is empty.
+func (w *WebhooksConfig) Endpoint(name string) (*WebhookEndpointConfig, bool) {
+	if w == nil || len(w.Endpoints) == 0 {
+		return nil, false
+	}
+	if name == "" {
+		name = w.DefaultEndpoint
+	}
+	if name == "" {
+		return &w.Endpoints[0], true
+	}
+	for i := range w.Endpoints {
+		if w.Endpoints[i].Name == name {
+			return &w.Endpoints[i], true
+		}
+	}
+	return nil, false
+}
+
+// InboundSource returns the verification settings for a webhook source.
+func (w *WebhooksConfig) InboundSource(source string) (*InboundWebhookConfig, bool) {
+	if w == nil {
+		return nil, false
+	}
+	for i := range w.Inbound {
+		if w.Inbound[i].Source == source {
+			return &w.Inbound[i], true
+		}
+	}
+	return nil, false
+}
+
+// ToleranceFor returns the replay tolerance of an inbound source: its own, or
+// else InboundTolerance.
+func (w *WebhooksConfig) ToleranceFor(in *InboundWebhookConfig) time.Duration {
+	if in.Tolerance != 0 || w == nil {
+		return in.Tolerance
+	}
+	return w.InboundTolerance
+}
+
+// validate ensures every inbound source has at least one secret, a known scheme
+// and, unless its scheme carries no timestamp, a positive replay tolerance.
+func (w *WebhooksConfig) validate() error {
+	if w == nil {
+		return nil
+	}
+	for i := range w.Inbound {
+		in := &w.Inbound[i]
+		if in.Source == "" || len(in.Secrets) == 0 {
+			return &ConfigError{
+				Context: "Inbound Webhooks",
+				Message: "Each inbound webhook source requires a name and at least one secret",
+			}
+		}
+		switch in.Scheme {
+		case "slack", "jira", "generic":
+		default:
+			return &ConfigError{
+				Context: "Inbound Webhooks",
+				Message: "Unsupported signature scheme for source " + in.Source + ": " + in.Scheme,
+			}
+		}
+		if in.Scheme != "jira" && w.ToleranceFor(in) <= 0 {
+			return &ConfigError{
+				Context: "Inbound Webhooks",
+				Message: "Tolerance for source " + in.Source + " must be positive",
+			}
+		}
+	}
+	if err := w.Outbound.validate("Outbound Webhooks"); err != nil {
+		return err
+	}
+	return w.validateEndpoints()
+}
+
+// validateEndpoints checks each outbound endpoint's target, method, retry
+// policy, CloudEvents settings and signing keys.
+func (w *WebhooksConfig) validateEndpoints() error {
+	names := make(map[string]bool, len(w.Endpoints))
+	for _, ep := range w.Endpoints {
+		section := "Webhook Endpoint " + ep.Name
+		if ep.Name == "" || names[ep.Name] {
+			return &ConfigError{
+				Context: "Webhook Endpoints",
+				Message: "Each webhook endpoint requires a unique name",
+			}
+		}
+		names[ep.Name] = true
+		if !isHTTPURL(ep.URL) {
+			return &ConfigError{Context: section, Message: "url must be an absolute http or https URL"}
+		}
+		switch ep.Method {
+		case "", http.MethodPost, http.MethodPut, http.MethodPatch:
+		default:
+			return &ConfigError{Context: section, Message: "method must be POST, PUT or PATCH"}
+		}
+		if r := ep.Retry; r != nil && (r.MaxAttempts < 0 || r.Backoff < 0 || r.MaxBackoff < 0) {
+			return &ConfigError{Context: section, Message: "retry settings must not be negative"}
+		}
+		if ep.Timeout < 0 {
+			return &ConfigError{Context: section, Message: "timeout must not be negative"}
+		}
+		if ce := ep.CloudEvents; ce != nil {
+			switch ce.Mode {
+			case "", "structured", "binary":
+			default:
+				return &ConfigError{Context: section, Message: "cloudEvents mode must be structured or binary"}
+			}
+			if ce.Source == "" || ce.Type == "" {
+				return &ConfigError{Context: section, Message: "cloudEvents requires a source and a type"}
+			}
+		}
+		if ep.Unsigned {
+			continue
+		}
+		if err := ep.Signing.validate(section); err != nil {
+			return err
+		}
+		if !ep.Signing.hasKey() && !w.Outbound.hasKey() {
+			return &ConfigError{
+				Context: section,
+				Message: "signed endpoints require a signing key, or unsigned: true",
+			}
+		}
+	}
+	if _, ok := w.Endpoint(""); len(w.Endpoints) > 0 && !ok {
+		return &ConfigError{
+			Context: "Webhook Endpoints",
+			Message: "defaultEndpoint does not match any configured endpoint",
+		}
+	}
+	return nil
+}
+
+// validate checks that the active key ID, when set, names a configured key.
+func (o *OutboundSigningConfig) validate(section string) error {
+	if o == nil || o.ActiveKeyID == "" {
+		return nil
+	}
+	for _, k := range o.Keys {
+		if k.ID == o.ActiveKeyID {
+			return nil
+		}
+	}
+	return &ConfigError{
+		Context: section,
+		Message: "activeKeyId does not match any configured signing key",
+	}
+}
+
+// hasKey reports whether the key ring holds a usable secret.
+func (o *OutboundSigningConfig) hasKey() bool {
+	if o == nil {
+		return false
+	}
+	for _, k := range o.Keys {
+		if k.Secret != "" {
+			return true
+		}
+	}
+	return false
+}
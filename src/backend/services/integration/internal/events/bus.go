@@ -0,0 +1,159 @@
+package events
+
+import (
+	// go1.21 - Delivery lifetime, payloads and the subscriber registry
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	// v1.16.0 - Event metrics exposed on the default registry served at /metrics
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	// v1.24.0 - Structured logging of subscriber panics
+	"go.uber.org/zap"
+)
+
+// DefaultBufferSize is the number of events a bus queues for delivery.
+const DefaultBufferSize = 1024
+
+// ErrBusFull is returned by Publish when the delivery queue is full, so the
+// sender can ask the provider to retry later.
+var ErrBusFull = errors.New("event bus queue is full")
+
+var (
+	// published counts events accepted by the bus.
+	published = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "integration_events_published_total",
+		Help: "Inbound provider events accepted by the event bus, by source and type.",
+	}, []string{"source", "type"})
+
+	// dropped counts events rejected because the queue was full.
+	dropped = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "integration_events_dropped_total",
+		Help: "Inbound provider events rejected because the event bus queue was full, by source.",
+	}, []string{"source"})
+)
+
+// Event is activity reported by a provider, such as a Slack message or
+// reaction.
+type Event struct {
+	// Source is the provider, e.g. "slack".
+	Source string `json:"source"`
+
+	// Type is the provider's event type, e.g. "message" or "reaction_added".
+	Type string `json:"type"`
+
+	// ID is the provider's event identifier; a provider retrying a delivery
+	// sends the same ID again.
+	ID string `json:"id,omitempty"`
+
+	// Workspace identifies the provider account the event belongs to, such as
+	// a Slack team ID.
+	Workspace string `json:"workspace,omitempty"`
+
+	// Payload is the provider's event object.
+	Payload json.RawMessage `json:"payload"`
+
+	// Received is when the service received the event.
+	Received time.Time `json:"received"`
+}
+
+// Handler reacts to an event. Handlers run one at a time on the bus's
+// delivery goroutine, so slow work should be handed off.
+type Handler func(ctx context.Context, event Event)
+
+// subscription is a handler and the events it receives.
+type subscription struct {
+	source    string
+	eventType string
+	handler   Handler
+}
+
+// Bus delivers inbound provider events to the parts of the service that react
+// to them. Publishing only queues the event, so receivers can acknowledge the
+// provider promptly; Run delivers queued events to the subscribers.
+type Bus struct {
+	queue  chan Event
+	logger *zap.Logger
+
+	mu            sync.RWMutex
+	subscriptions []subscription
+}
+
+// NewBus creates a bus queueing up to size events; a size of zero or less
+// uses DefaultBufferSize.
+func NewBus(size int, logger *zap.Logger) *Bus {
+	if size <= 0 {
+		size = DefaultBufferSize
+	}
+	return &Bus{
+		queue:  make(chan Event, size),
+		logger: logger,
+	}
+}
+
+// Subscribe registers handler for events from source of eventType. An empty
+// source or type matches any.
+func (b *Bus) Subscribe(source, eventType string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscriptions = append(b.subscriptions, subscription{source: source, eventType: eventType, handler: handler})
+}
+
+// Publish queues event for delivery, returning ErrBusFull rather than blocking
+// when the queue is full.
+func (b *Bus) Publish(event Event) error {
+	if event.Received.IsZero() {
+		event.Received = time.Now()
+	}
+	select {
+	case b.queue <- event:
+		published.WithLabelValues(event.Source, event.Type).Inc()
+		return nil
+	default:
+		dropped.WithLabelValues(event.Source).Inc()
+		return ErrBusFull
+	}
+}
+
+// Run delivers queued events to the matching subscribers until ctx is
+// canceled.
+func (b *Bus) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-b.queue:
+			b.deliver(ctx, event)
+		}
+	}
+}
+
+// deliver hands event to every matching subscriber. A panicking handler is
+// logged and does not stop delivery to the others.
+func (b *Bus) deliver(ctx context.Context, event Event) {
+	b.mu.RLock()
+	subscriptions := b.subscriptions
+	b.mu.RUnlock()
+
+	for _, s := range subscriptions {
+		if (s.source != "" && s.source != event.Source) || (s.eventType != "" && s.eventType != event.Type) {
+			continue
+		}
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					b.logger.Error("Event handler panicked",
+						zap.String("source", event.Source),
+						zap.String("type", event.Type),
+						zap.Any("panic", r),
+					)
+				}
+			}()
+			s.handler(ctx, event)
+		}()
+	}
+}
@@ -0,0 +1,68 @@
+package events
+
+import (
+	// go1.21 - Event timestamps
+	"time"
+)
+
+// SourceJira is the Source of events received from Jira webhooks.
+const SourceJira = "jira"
+
+// Jira event types. Jira's webhook events are normalized to these, so that
+// subscribers keeping TaskStream tasks in step with their issues need not
+// interpret changelogs themselves.
+const (
+	// JiraIssueUpdated is an issue edit that did not change its status.
+	JiraIssueUpdated = "issue_updated"
+
+	// JiraIssueTransitioned is an issue moving to another workflow status.
+	JiraIssueTransitioned = "issue_transitioned"
+
+	// JiraIssueCommented is a comment added to an issue.
+	JiraIssueCommented = "issue_commented"
+)
+
+// JiraFieldChange is one field changed by an issue update, with the values as
+// Jira displays them.
+type JiraFieldChange struct {
+	Field string `json:"field"`
+	From  string `json:"from,omitempty"`
+	To    string `json:"to,omitempty"`
+}
+
+// JiraComment is the comment added by a JiraIssueCommented event.
+type JiraComment struct {
+	ID     string `json:"id"`
+	Author string `json:"author,omitempty"`
+	Body   string `json:"body"`
+}
+
+// JiraIssueEvent is the Payload of events from SourceJira.
+type JiraIssueEvent struct {
+	// IssueKey and IssueID identify the issue, e.g. "ENG-42" and "10042".
+	IssueKey string `json:"issueKey"`
+	IssueID  string `json:"issueId"`
+
+	// Project is the issue's project key.
+	Project string `json:"project"`
+
+	// Status is the issue's status after the event.
+	Status string `json:"status"`
+
+	// Labels are the issue's labels, which include the correlation label the
+	// Jira adapter records on issues it creates.
+	Labels []string `json:"labels,omitempty"`
+
+	// Actor is the account ID of the user who made the change.
+	Actor string `json:"actor,omitempty"`
+
+	// Changes lists the fields an update changed. For a JiraIssueTransitioned
+	// event it includes the "status" change.
+	Changes []JiraFieldChange `json:"changes,omitempty"`
+
+	// Comment is set for JiraIssueCommented events.
+	Comment *JiraComment `json:"comment,omitempty"`
+
+	// Occurred is when Jira recorded the change.
+	Occurred time.Time `json:"occurred"`
+}
@@ -0,0 +1,7 @@
+package events
+
+// WebhookReceived is the Type of events published for verified inbound
+// webhooks from sources other than Jira. Their Source is the sender's
+// configured source name and their Payload is the request body, with form
+// fields as a JSON object of value lists.
+const WebhookReceived = "webhook_received"
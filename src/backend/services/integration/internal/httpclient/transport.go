@@ -0,0 +1,166 @@
+package httpclient
+
+import (
+	// go1.21 - Dialer with connect timeout and TCP keep-alive
+	"net"
+	// go1.21 - HTTP transport and client
+	"net/http"
+	"net/url"
+	// go1.21 - Guards the process-wide default factory
+	"sync"
+	"time"
+
+	// v0.17.0 - HTTP/2 transport tuning (PING-based connection health checks)
+	"golang.org/x/net/http2"
+
+	// Internal configuration for transport tuning
+	"src/backend/services/integration/internal/config"
+)
+
+// Fallbacks applied when the HTTP client configuration is absent or zero.
+const (
+	defaultTimeout             = 30 * time.Second
+	defaultDialTimeout         = 5 * time.Second
+	defaultKeepAlive           = 30 * time.Second
+	defaultTLSHandshakeTimeout = 5 * time.Second
+	defaultIdleConnTimeout     = 90 * time.Second
+	defaultMaxIdleConns        = 100
+	defaultMaxIdleConnsPerHost = 16
+)
+
+// Factory produces HTTP clients that share one tuned transport, so every REST
+// adapter reuses the same connection pool, keep-alive connections, and HTTP/2
+// sessions instead of each building a default client.
+type Factory struct {
+	transport *http.Transport
+	timeout   time.Duration
+}
+
+// NewFactory builds the shared transport from configuration. A nil configuration
+// yields a factory with the package defaults.
+//
+// Steps:
+// 1. Build a dialer with connect timeout and TCP keep-alive
+// 2. Configure connection pool limits and timeouts
+// 3. Select an explicit proxy or fall back to the proxy environment variables
+// 4. Enable HTTP/2 with PING-based health checks when requested
+func NewFactory(cfg *config.HTTPClientConfig) (*Factory, error) {
+	if cfg == nil {
+		cfg = &config.HTTPClientConfig{HTTP2: true}
+	}
+
+	// 1. Dialer
+	dialer := &net.Dialer{
+		Timeout:   durationOr(cfg.DialTimeout, defaultDialTimeout),
+		KeepAlive: durationOr(cfg.KeepAlive, defaultKeepAlive),
+	}
+
+	// 2. Pool limits and timeouts
+	transport := &http.Transport{
+		DialContext:           dialer.DialContext,
+		TLSHandshakeTimeout:   durationOr(cfg.TLSHandshakeTimeout, defaultTLSHandshakeTimeout),
+		ResponseHeaderTimeout: cfg.ResponseHeaderTimeout,
+		IdleConnTimeout:       durationOr(cfg.IdleConnTimeout, defaultIdleConnTimeout),
+		ExpectContinueTimeout: time.Second,
+		MaxIdleConns:          intOr(cfg.MaxIdleConns, defaultMaxIdleConns),
+		MaxIdleConnsPerHost:   intOr(cfg.MaxIdleConnsPerHost, defaultMaxIdleConnsPerHost),
+		MaxConnsPerHost:       cfg.MaxConnsPerHost,
+		Proxy:                 http.ProxyFromEnvironment,
+	}
+
+	// 3. Proxy
+	if cfg.ProxyURL != "" {
+		proxyURL, err := url.Parse(cfg.ProxyURL)
+		if err != nil {
+			return nil, err
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	// 4. HTTP/2
+	if cfg.HTTP2 {
+		transport.ForceAttemptHTTP2 = true
+		h2, err := http2.ConfigureTransports(transport)
+		if err != nil {
+			return nil, err
+		}
+		if cfg.HTTP2ReadIdleTimeout > 0 {
+			h2.ReadIdleTimeout = cfg.HTTP2ReadIdleTimeout
+			h2.PingTimeout = cfg.HTTP2ReadIdleTimeout / 2
+		}
+	}
+
+	return &Factory{
+		transport: transport,
+		timeout:   durationOr(cfg.Timeout, defaultTimeout),
+	}, nil
+}
+
+// Transport returns the shared transport, for clients that wrap it (for example,
+// authentication round trippers).
+func (f *Factory) Transport() http.RoundTripper {
+	return f.transport
+}
+
+// Client returns a client on the shared transport with the configured request timeout.
+func (f *Factory) Client() *http.Client {
+	return &http.Client{Transport: f.transport, Timeout: f.timeout}
+}
+
+// ClientWithTimeout returns a client on the shared transport with a per-adapter timeout.
+func (f *Factory) ClientWithTimeout(timeout time.Duration) *http.Client {
+	return &http.Client{Transport: f.transport, Timeout: durationOr(timeout, f.timeout)}
+}
+
+// CloseIdleConnections releases pooled connections, e.g. during shutdown.
+func (f *Factory) CloseIdleConnections() {
+	f.transport.CloseIdleConnections()
+}
+
+var (
+	defaultMu      sync.RWMutex
+	defaultFactory *Factory
+)
+
+// SetDefault installs the process-wide factory used by adapters. It is called
+// once at startup after the configuration is loaded.
+func SetDefault(f *Factory) {
+	defaultMu.Lock()
+	defaultFactory = f
+	defaultMu.Unlock()
+}
+
+// Default returns the process-wide factory, creating one with package defaults
+// if SetDefault has not been called.
+func Default() *Factory {
+	defaultMu.RLock()
+	f := defaultFactory
+	defaultMu.RUnlock()
+	if f != nil {
+		return f
+	}
+
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	if defaultFactory == nil {
+		// The default configuration cannot fail to build.
+		defaultFactory, _ = NewFactory(nil)
+	}
+	return defaultFactory
+}
+
+// durationOr returns d, or fallback when d is not positive.
+func durationOr(d, fallback time.Duration) time.Duration {
+	if d > 0 {
+		return d
+	}
+	return fallback
+}
+
+// intOr returns n, or fallback when n is not positive.
+func intOr(n, fallback int) int {
+	if n > 0 {
+		return n
+	}
+	return fallback
+}
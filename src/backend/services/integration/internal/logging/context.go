@@ -0,0 +1,26 @@
+package logging
+
+import (
+	// go1.21 - Request-scoped loggers
+	"context"
+
+	// v1.24.0 - Structured logging
+	"go.uber.org/zap"
+)
+
+// loggerKey is the context key under which WithLogger stores the logger.
+type loggerKey struct{}
+
+// WithLogger returns a context carrying logger, typically one with fields that
+// identify the request being served, such as its request ID.
+func WithLogger(ctx context.Context, logger *zap.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// FromContext returns the logger carried by ctx, or fallback when there is none.
+func FromContext(ctx context.Context, fallback *zap.Logger) *zap.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*zap.Logger); ok && logger != nil {
+		return logger
+	}
+	return fallback
+}
@@ -0,0 +1,244 @@
+package logging
+
+import (
+	// go1.21 - JSON round-trip used to sanitize reflected values
+	"encoding/json"
+	"strings"
+
+	// v1.24.0 - Structured logging core and field types
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// RedactedValue replaces the value of any field whose key is considered sensitive.
+const RedactedValue = "[REDACTED]"
+
+// sensitiveKeySubstrings lists normalized fragments (lower case, without '-' or
+// '_') that mark a key as sensitive wherever they appear in it, so that
+// "signingSecrets", "personalAccessToken" and "passwordHash" are caught as
+// well as "secret", "token" and "password".
+var sensitiveKeySubstrings = []string{
+	"password",
+	"passwd",
+	"secret",
+	"token",
+	"apikey",
+	"authorization",
+	"authheader",
+	"cookie",
+	"privatekey",
+}
+
+// sensitiveKeySuffixes lists normalized endings that mark a key as sensitive,
+// such as "staticKey" or a bcrypt "hash". They are too common to match
+// anywhere in a key.
+var sensitiveKeySuffixes = []string{
+	"key",
+	"hash",
+}
+
+// nonSensitiveKeys lists normalized keys that match a sensitive suffix but
+// name identifiers rather than secrets.
+var nonSensitiveKeys = map[string]struct{}{
+	"projectkey": {},
+}
+
+// Redactor masks values for sensitive keys in log fields, including keys nested in
+// objects (zapcore.ObjectMarshaler) and reflected values such as configuration
+// structs or decoded payloads.
+type Redactor struct {
+	// keys are extra normalized keys that are sensitive only as a whole.
+	keys map[string]struct{}
+}
+
+// NewRedactor creates a Redactor for the default sensitive key fragments plus
+// any extra keys, which are matched exactly.
+func NewRedactor(extraKeys ...string) *Redactor {
+	r := &Redactor{keys: make(map[string]struct{})}
+	for _, k := range extraKeys {
+		r.keys[normalizeKey(k)] = struct{}{}
+	}
+	return r
+}
+
+// WrapCore returns a zap option that installs the redactor in front of the logger's core.
+func (r *Redactor) WrapCore() zap.Option {
+	return zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return &redactingCore{Core: core, redactor: r}
+	})
+}
+
+// IsSensitive reports whether a field key should be masked: it is one of the
+// extra keys, contains a sensitive fragment or ends in a sensitive suffix.
+func (r *Redactor) IsSensitive(key string) bool {
+	key = normalizeKey(key)
+	if _, ok := r.keys[key]; ok {
+		return true
+	}
+	for _, fragment := range sensitiveKeySubstrings {
+		if strings.Contains(key, fragment) {
+			return true
+		}
+	}
+	if _, ok := nonSensitiveKeys[key]; ok {
+		return false
+	}
+	for _, suffix := range sensitiveKeySuffixes {
+		if strings.HasSuffix(key, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactFields returns a copy of fields with sensitive values masked.
+func (r *Redactor) RedactFields(fields []zapcore.Field) []zapcore.Field {
+	out := make([]zapcore.Field, len(fields))
+	for i, f := range fields {
+		out[i] = r.redactField(f)
+	}
+	return out
+}
+
+// redactField masks a single field or wraps its nested content for redaction.
+func (r *Redactor) redactField(f zapcore.Field) zapcore.Field {
+	if r.IsSensitive(f.Key) {
+		return zap.String(f.Key, RedactedValue)
+	}
+	switch f.Type {
+	case zapcore.ObjectMarshalerType:
+		if m, ok := f.Interface.(zapcore.ObjectMarshaler); ok {
+			return zap.Object(f.Key, redactingMarshaler{inner: m, redactor: r})
+		}
+	case zapcore.ReflectType:
+		return zap.Any(f.Key, r.redactValue(f.Interface))
+	}
+	return f
+}
+
+// redactValue sanitizes an arbitrary value by round-tripping it through JSON and
+// masking sensitive keys at every depth. Values that cannot be encoded are
+// replaced entirely, since their content cannot be inspected.
+func (r *Redactor) redactValue(v interface{}) interface{} {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return RedactedValue
+	}
+	var generic interface{}
+	if err := json.Unmarshal(raw, &generic); err != nil {
+		return RedactedValue
+	}
+	return r.walk(generic)
+}
+
+// walk masks sensitive keys in decoded JSON structures.
+func (r *Redactor) walk(v interface{}) interface{} {
+	switch typed := v.(type) {
+	case map[string]interface{}:
+		for k, inner := range typed {
+			if r.IsSensitive(k) {
+				typed[k] = RedactedValue
+				continue
+			}
+			typed[k] = r.walk(inner)
+		}
+		return typed
+	case []interface{}:
+		for i, inner := range typed {
+			typed[i] = r.walk(inner)
+		}
+		return typed
+	default:
+		return v
+	}
+}
+
+// normalizeKey lower-cases a key and strips separators so that "api_token",
+// "apiToken", and "API-Token" all match "apitoken".
+func normalizeKey(key string) string {
+	key = strings.ToLower(key)
+	key = strings.ReplaceAll(key, "_", "")
+	return strings.ReplaceAll(key, "-", "")
+}
+
+// redactingCore filters fields passed through With and Write.
+type redactingCore struct {
+	zapcore.Core
+	redactor *Redactor
+}
+
+// With redacts context fields before they are bound to the child core.
+func (c *redactingCore) With(fields []zapcore.Field) zapcore.Core {
+	return &redactingCore{Core: c.Core.With(c.redactor.RedactFields(fields)), redactor: c.redactor}
+}
+
+// Check ensures this wrapper, not the inner core, performs the write.
+func (c *redactingCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+// Write redacts entry fields before encoding.
+func (c *redactingCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	return c.Core.Write(ent, c.redactor.RedactFields(fields))
+}
+
+// redactingMarshaler wraps an ObjectMarshaler so that its keys pass through the redactor.
+type redactingMarshaler struct {
+	inner    zapcore.ObjectMarshaler
+	redactor *Redactor
+}
+
+// MarshalLogObject marshals the wrapped object through a redacting encoder.
+func (m redactingMarshaler) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	return m.inner.MarshalLogObject(&redactingObjectEncoder{ObjectEncoder: enc, redactor: m.redactor})
+}
+
+// redactingObjectEncoder masks string-like values for sensitive keys and recurses
+// into nested objects and reflected values. Numeric and boolean values are passed
+// through unchanged.
+type redactingObjectEncoder struct {
+	zapcore.ObjectEncoder
+	redactor *Redactor
+}
+
+func (e *redactingObjectEncoder) AddString(key, value string) {
+	if e.redactor.IsSensitive(key) {
+		value = RedactedValue
+	}
+	e.ObjectEncoder.AddString(key, value)
+}
+
+func (e *redactingObjectEncoder) AddByteString(key string, value []byte) {
+	if e.redactor.IsSensitive(key) {
+		e.ObjectEncoder.AddString(key, RedactedValue)
+		return
+	}
+	e.ObjectEncoder.AddByteString(key, value)
+}
+
+func (e *redactingObjectEncoder) AddBinary(key string, value []byte) {
+	if e.redactor.IsSensitive(key) {
+		e.ObjectEncoder.AddString(key, RedactedValue)
+		return
+	}
+	e.ObjectEncoder.AddBinary(key, value)
+}
+
+func (e *redactingObjectEncoder) AddObject(key string, marshaler zapcore.ObjectMarshaler) error {
+	if e.redactor.IsSensitive(key) {
+		e.ObjectEncoder.AddString(key, RedactedValue)
+		return nil
+	}
+	return e.ObjectEncoder.AddObject(key, redactingMarshaler{inner: marshaler, redactor: e.redactor})
+}
+
+func (e *redactingObjectEncoder) AddReflected(key string, value interface{}) error {
+	if e.redactor.IsSensitive(key) {
+		e.ObjectEncoder.AddString(key, RedactedValue)
+		return nil
+	}
+	return e.ObjectEncoder.AddReflected(key, e.redactor.redactValue(value))
+}
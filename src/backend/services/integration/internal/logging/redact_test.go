@@ -0,0 +1,90 @@
+package logging_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"src/backend/services/integration/internal/config"
+	"src/backend/services/integration/internal/logging"
+)
+
+// configDump is a configuration with a value in every secret-bearing field,
+// decoded into config.Config so the dump carries the real key names.
+const configDump = `{
+	"jira": {"url": "https://jira.example.com", "username": "bot", "apiToken": "jira-api-token", "projectKey": "ENG"},
+	"azureDevOps": {"organizationURL": "https://dev.azure.com/acme", "personalAccessToken": "ado-pat"},
+	"asana": {"personalAccessToken": "asana-pat"},
+	"email": {"sendGrid": {"apiKey": "sendgrid-api-key"}},
+	"slack": {"inbound": {"signingSecrets": ["slack-signing-secret"]}},
+	"webhooks": {"inbound": [{"source": "github", "scheme": "generic", "secrets": ["github-webhook-secret"]}]},
+	"queue": {"spool": {"encryption": {"enabled": true, "provider": "static", "staticKey": "c3Bvb2wtc3RhdGljLWtleQ=="}}},
+	"auth": {"basic": {"users": [{"username": "ops", "passwordHash": "$2y$10$bcrypthash"}]}}
+}`
+
+func TestRedactorMasksConfigDump(t *testing.T) {
+	var cfg config.Config
+	if err := json.Unmarshal([]byte(configDump), &cfg); err != nil {
+		t.Fatalf("decode config dump: %v", err)
+	}
+	raw, err := json.Marshal(&cfg)
+	if err != nil {
+		t.Fatalf("encode config dump: %v", err)
+	}
+
+	var buf bytes.Buffer
+	core := zapcore.NewCore(zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), zapcore.AddSync(&buf), zapcore.DebugLevel)
+	logger := zap.New(core, logging.NewRedactor().WrapCore())
+	logger.Info("Configuration loaded", zap.Any("config", &cfg))
+	out := buf.String()
+
+	for _, secret := range []string{
+		"jira-api-token",
+		"ado-pat",
+		"asana-pat",
+		"sendgrid-api-key",
+		"slack-signing-secret",
+		"github-webhook-secret",
+		"c3Bvb2wtc3RhdGljLWtleQ==",
+		"$2y$10$bcrypthash",
+	} {
+		if !strings.Contains(string(raw), secret) {
+			t.Fatalf("config dump lost secret %q while decoding; fix the fixture", secret)
+		}
+		if strings.Contains(out, secret) {
+			t.Errorf("secret %q reached the log: %s", secret, out)
+		}
+	}
+	for _, kept := range []string{"ENG", "github", "ops"} {
+		if !strings.Contains(out, kept) {
+			t.Errorf("non-secret %q was redacted: %s", kept, out)
+		}
+	}
+}
+
+func TestRedactorIsSensitive(t *testing.T) {
+	r := logging.NewRedactor("sessionId")
+	for key, want := range map[string]bool{
+		"password":            true,
+		"passwordHash":        true,
+		"secrets":             true,
+		"signingSecrets":      true,
+		"personalAccessToken": true,
+		"staticKey":           true,
+		"api_key":             true,
+		"Authorization":       true,
+		"session-id":          true,
+		"projectKey":          false,
+		"kmsKeyId":            false,
+		"username":            false,
+		"requestId":           false,
+	} {
+		if got := r.IsSensitive(key); got != want {
+			t.Errorf("IsSensitive(%q) = %v, want %v", key, got, want)
+		}
+	}
+}
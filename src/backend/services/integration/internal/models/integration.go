@@ -1,9 +1,10 @@
 package models
 
 import (
+	"context"         // go1.21
 	"time"            // go1.21
-	"encoding/json"   // go1.21
 	"errors"          // go1.21
+	"net/http"        // go1.21
 )
 
 // Global errors representing various integration-related failures
@@ -19,6 +20,10 @@ var (
 
 	// ErrConnectionFailed indicates that a connection attempt to the external service has failed.
 	ErrConnectionFailed = errors.New("integration connection failed")
+
+	// ErrRetryBudgetExhausted wraps a send failure whose retries were skipped
+	// because the retry budget was spent; the message was attempted once.
+	ErrRetryBudgetExhausted = errors.New("retry budget exhausted")
 )
 
 // Integration defines the core contract that all external service adapters must fulfill.
@@ -71,43 +76,384 @@ type Integration interface {
 	Status() (IntegrationStatus, error)
 }
 
+// Credentials carries replacement secrets for an integration during rotation.
+// Secret holds the adapter's primary credential: the Slack bot token, the Jira
+// API token, or the SMTP password.
+type Credentials struct {
+	// Username is the account name for adapters that authenticate with one (Jira, SMTP).
+	// When empty, the adapter keeps its current username. For Slack it names the
+	// workspace whose token is rotated.
+	Username string `json:"username"`
+
+	// Secret is the new token or password. Must be kept secure.
+	Secret string `json:"secret"`
+}
+
+// CredentialRotator is implemented by adapters that can switch to new credentials
+// without a restart. Implementations must verify the new credentials before
+// swapping them in, so that a bad rotation leaves the adapter on its previous,
+// working credentials.
+type CredentialRotator interface {
+	/*
+	   RotateCredentials re-authenticates the adapter with the supplied credentials.
+
+	   Steps to be performed upon a real implementation:
+	   1. Validate that the credentials are complete for this adapter.
+	   2. Build a new client or connection factory using the new credentials.
+	   3. Verify the new credentials against the external service.
+	   4. Atomically swap the new client in; in-flight calls finish on the old one.
+	   5. Release pooled connections that were authenticated with the old credentials.
+	*/
+	RotateCredentials(ctx context.Context, creds Credentials) error
+}
+
+// SendResult identifies a delivered message, so callers can correlate it with
+// later provider activity such as replies, bounces and webhooks.
+type SendResult struct {
+	// MessageID is the message's Message-ID header, for email.
+	MessageID string `json:"messageId,omitempty"`
+
+	// ExternalID is the provider's identifier for the message, such as an SES
+	// message ID or a Slack message timestamp.
+	ExternalID string `json:"externalId,omitempty"`
+
+	// Channel is the channel the message was posted to, for chat integrations
+	// whose message identifiers are only unique within a channel.
+	Channel string `json:"channel,omitempty"`
+
+	// URL links to the created item, such as a Jira issue's browse page.
+	URL string `json:"url,omitempty"`
+}
+
+// ResultSender is implemented by adapters that can report the identifiers of
+// the messages they send. The service prefers SendWithResult to Send for such
+// adapters and returns the result to the caller when it waits for delivery.
+type ResultSender interface {
+	// SendWithResult delivers payload as Send does, returning its identifiers.
+	SendWithResult(ctx context.Context, payload interface{}) (SendResult, error)
+}
+
+// SendReverter is implemented by adapters that can undo a delivered send, such
+// as deleting a posted message or a created issue. All-or-nothing fan-out sends
+// revert their delivered targets when another target fails.
+type SendReverter interface {
+	// RevertSend undoes the send of payload that returned result. It returns
+	// ErrNotImplemented for sends that cannot be undone, such as updates.
+	RevertSend(ctx context.Context, payload interface{}, result SendResult) error
+}
+
+// sendResultKey is the context key under which WithSendResult stores the
+// result slot.
+type sendResultKey struct{}
+
+// WithSendResult returns a context carrying result, which a send made with the
+// context fills through RecordSendResult.
+func WithSendResult(ctx context.Context, result *SendResult) context.Context {
+	return context.WithValue(ctx, sendResultKey{}, result)
+}
+
+// RecordSendResult stores result in the slot carried by ctx, if there is one.
+func RecordSendResult(ctx context.Context, result SendResult) {
+	if slot, ok := ctx.Value(sendResultKey{}).(*SendResult); ok && slot != nil {
+		*slot = result
+	}
+}
+
+// RequestIDHeader is the HTTP header carrying the ID that traces one API request
+// through the service and the systems it notifies.
+const RequestIDHeader = "X-Request-ID"
+
+// requestIDKey is the context key under which WithRequestID stores the request ID.
+type requestIDKey struct{}
+
+// WithRequestID returns a context carrying the ID of the API request a send
+// originated from. Adapters attach it to what they deliver where the provider
+// allows, such as Slack message metadata, Jira labels and email headers.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFrom returns the request ID carried by ctx, or an empty string.
+func RequestIDFrom(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// BatchSender is implemented by adapters that can deliver several payloads in
+// one provider call, such as joining Slack lines into a single message or
+// creating Jira issues in bulk. The dispatch pipeline coalesces payloads for such
+// adapters when batching is enabled.
+type BatchSender interface {
+	/*
+	   SendBatch delivers all payloads, in order, using as few provider calls as possible.
+
+	   Steps to be performed upon a real implementation:
+	   1. Validate every payload; an invalid payload fails on its own.
+	   2. Split the batch to respect provider limits (message length, bulk sizes).
+	   3. Apply rate limiting and circuit breaking once per provider call.
+	   4. Return one BatchResult per payload, in order, so that payloads the
+	      provider accepted are not retried with the ones it rejected.
+
+	   The error is non-nil only when nothing was delivered, e.g. the adapter is
+	   not initialized; the results are then nil.
+	*/
+	SendBatch(ctx context.Context, payloads []interface{}) ([]BatchResult, error)
+}
+
+// BatchResult is the outcome of one payload of a batch.
+type BatchResult struct {
+	// Result identifies what was created for the payload, when the adapter
+	// reports it, such as a Jira issue key.
+	Result SendResult
+
+	// Err is why the payload was not delivered; nil when it was.
+	Err error
+}
+
+// FailBatchResults returns results for n payloads that all failed with err.
+func FailBatchResults(n int, err error) []BatchResult {
+	results := make([]BatchResult, n)
+	for i := range results {
+		results[i].Err = err
+	}
+	return results
+}
+
+// MetadataInvalidator is implemented by adapters that cache provider metadata
+// (channel lists, create metadata, workflow transitions). Invalidation lets
+// operators force a refresh after changing the provider's configuration.
+type MetadataInvalidator interface {
+	// InvalidateMetadata drops cached metadata whose key starts with prefix (all
+	// metadata when prefix is empty) and returns the number of entries removed.
+	InvalidateMetadata(prefix string) int
+}
+
+// RetryBudget decides whether a failed call may be retried, bounding retries to a
+// fraction of recent request volume.
+type RetryBudget interface {
+	// RecordRequest counts one first attempt.
+	RecordRequest()
+
+	// AllowRetry reports whether another retry fits the budget, counting it if so.
+	AllowRetry() bool
+}
+
+// RetryBudgetUser is implemented by adapters that retry internally. The service
+// hands each one its integration's budget at registration.
+type RetryBudgetUser interface {
+	// SetRetryBudget installs the budget consulted before every retry.
+	SetRetryBudget(budget RetryBudget)
+}
+
+// SharedLimiter paces calls to a provider across every replica of the service,
+// so that a provider rate limit is not exceeded by replicas each keeping to it.
+type SharedLimiter interface {
+	// Wait blocks until a call counted under key fits within perSecond calls a
+	// second cluster-wide, or ctx is done. Keys are scoped to the integration.
+	Wait(ctx context.Context, key string, perSecond float64) error
+}
+
+// SharedLimiterUser is implemented by adapters that pace their provider calls.
+// When rate limits are kept in a shared store, the service hands each one its
+// integration's shared limiter at registration.
+type SharedLimiterUser interface {
+	// SetSharedLimiter installs the limiter consulted after the adapter's own.
+	SetSharedLimiter(limiter SharedLimiter)
+}
+
+// CircuitReporter is implemented by adapters guarded by a circuit breaker, so the
+// service can divert traffic (for example, to the store-and-forward spool) while
+// the circuit is open rather than failing every call.
+type CircuitReporter interface {
+	// CircuitOpen reports whether the adapter is currently rejecting calls.
+	CircuitOpen() bool
+}
+
+// CircuitResetter is implemented by adapters whose circuit breaker operators can
+// force closed, for example after an upstream incident has been resolved.
+type CircuitResetter interface {
+	// ResetCircuit closes the circuit and clears its failure counts.
+	ResetCircuit()
+}
+
+// Circuit states reported in CircuitSnapshot.State.
+const (
+	CircuitClosed   = "closed"
+	CircuitOpen     = "open"
+	CircuitHalfOpen = "half-open"
+)
+
+// CircuitSnapshot describes a circuit breaker's state and counters.
+type CircuitSnapshot struct {
+	// State is CircuitClosed, CircuitOpen or CircuitHalfOpen, in which a trial
+	// call decides whether the circuit closes again.
+	State string `json:"state"`
+
+	// ConsecutiveFailures counts the failures since the last success; the
+	// circuit opens when it reaches Threshold.
+	ConsecutiveFailures int `json:"consecutiveFailures"`
+	Threshold           int `json:"threshold,omitempty"`
+
+	// Requests and TotalFailures count calls in the breaker's current
+	// interval, for breakers that keep them.
+	Requests      int `json:"requests,omitempty"`
+	TotalFailures int `json:"totalFailures,omitempty"`
+
+	// OpenedAt is when the circuit last opened, and RetryAt when it lets a
+	// trial call through; both are zero while it is closed.
+	OpenedAt time.Time `json:"openedAt,omitempty"`
+	RetryAt  time.Time `json:"retryAt,omitempty"`
+
+	// Endpoints holds the breakers of adapters with one per target, such as
+	// webhook endpoints; the fields above then summarize them.
+	Endpoints map[string]CircuitSnapshot `json:"endpoints,omitempty"`
+}
+
+// CircuitInspector is implemented by adapters that report their circuit
+// breaker's state and counters, for operators diagnosing an upstream incident.
+type CircuitInspector interface {
+	// CircuitSnapshot returns the breaker's current state and counters.
+	CircuitSnapshot() CircuitSnapshot
+}
+
+// ConnectionTester is implemented by adapters that can check their credentials
+// and connectivity on demand, so operators can validate a rotation without
+// waiting for the next send.
+type ConnectionTester interface {
+	// TestConnection makes one authenticated round-trip to the provider, such as
+	// Slack's auth.test, Jira's current-user lookup or an SMTP NOOP, and returns
+	// diagnostic details such as the identity the credentials authenticate as.
+	// Details are returned alongside an error where available.
+	TestConnection(ctx context.Context) (map[string]interface{}, error)
+}
+
+// NotificationReceiver is implemented by adapters that accept notifications
+// pushed by their provider, such as email bounces and complaints. The service
+// routes each request to /notifications/{integration} to the adapter, which
+// authenticates the notification itself since every provider signs differently.
+type NotificationReceiver interface {
+	// ReceiveNotification handles one notification with the given headers and
+	// raw body. It returns ErrInvalidPayload for malformed or unauthenticated
+	// notifications.
+	ReceiveNotification(ctx context.Context, header http.Header, body []byte) error
+}
+
+// Kinds of InboundEmail.
+const (
+	// InboundEmailReply is a reply to a sent message.
+	InboundEmailReply = "reply"
+
+	// InboundEmailBounce is a delivery status notification reporting that a
+	// sent message could not be delivered.
+	InboundEmailBounce = "bounce"
+)
+
+// InboundEmail is a reply or bounce read from a monitored mailbox, with the
+// Message-IDs that correlate it to the message it answers or reports on.
+type InboundEmail struct {
+	// Kind is InboundEmailReply or InboundEmailBounce.
+	Kind string
+
+	// MessageID is the inbound message's own Message-ID.
+	MessageID string
+
+	// References are the Message-IDs of the messages it relates to: a reply's
+	// In-Reply-To and References, or the bounced message's Message-ID.
+	References []string
+
+	// From is the inbound message's sender.
+	From string
+
+	// Recipients are, for a bounce, the addresses delivery failed for.
+	Recipients []string
+
+	// Status is, for a bounce, the delivery status code, e.g. "5.1.1".
+	Status string
+
+	// Permanent reports, for a bounce, whether the failure is permanent.
+	Permanent bool
+
+	// Received is when the inbound message arrived.
+	Received time.Time
+}
+
+// InboundEmailReceiver is implemented by adapters that correlate replies and
+// bounces with the messages they sent.
+type InboundEmailReceiver interface {
+	// ReceiveInboundEmail records email if it references a message the adapter
+	// sent, reporting whether it did.
+	ReceiveInboundEmail(ctx context.Context, email *InboundEmail) bool
+}
+
+// InboundEmailSource reads new replies and bounces from a mailbox.
+type InboundEmailSource interface {
+	// Poll returns the replies and bounces that arrived since the last poll.
+	Poll(ctx context.Context) ([]*InboundEmail, error)
+}
+
+// Closer is implemented by adapters that hold connections or background
+// goroutines. The service closes every registered adapter during graceful
+// shutdown, after queued sends have been delivered.
+type Closer interface {
+	// Close releases the adapter's resources. It is bounded by ctx and must be
+	// safe to call more than once.
+	Close(ctx context.Context) error
+}
+
+// SyncMetrics records an integration's deliveries through the service, with
+// the identifiers of the last delivered item so that it can be traced in the
+// provider.
+type SyncMetrics struct {
+	// Delivered and Failed count sends since the integration was registered.
+	Delivered int64 `json:"delivered"`
+	Failed    int64 `json:"failed"`
+
+	// LastDelivery is when the last successful send completed.
+	LastDelivery time.Time `json:"lastDelivery"`
+
+	// LastResult identifies the item the last successful send created, for
+	// adapters that report one.
+	LastResult SendResult `json:"lastResult"`
+}
+
 // IntegrationStatus holds crucial information regarding the current state
 // and diagnostic metrics of a given integration. It is designed to provide
 // an at-a-glance overview of connection health, performance statistics,
-// timestamps for key events, and arbitrary metadata.
+// timestamps for key events, and arbitrary metadata. The JSON tags name the
+// fields as MarshalJSON writes them, for schema generation.
 type IntegrationStatus struct {
 	// Connected indicates whether the integration is currently connected (true)
 	// or if it is experiencing a disconnection or error state (false).
-	Connected bool
+	Connected bool `json:"connected"`
 
 	// Name is a human-readable identifier for the integration (e.g., "Slack",
 	// "GitHub", "EmailService").
-	Name string
+	Name string `json:"name"`
 
 	// Type specifies the category of the integration (e.g., "chat", "email",
 	// "project_management") to differentiate adapter behaviors and requirements.
-	Type string
+	Type string `json:"type"`
 
 	// LastSync represents the timestamp of the most recent successful data transfer
 	// or operation with the external service.
-	LastSync time.Time
+	LastSync time.Time `json:"lastSync"`
 
 	// LastError holds the timestamp of the most recent recorded error for the
 	// integration. It defaults to the zero value of time.Time if no errors have occurred.
-	LastError time.Time
+	LastError time.Time `json:"lastError"`
 
 	// ErrorCount tracks the number of errors encountered within a relevant timeframe
 	// (e.g., since last successful sync or within a rolling window).
-	ErrorCount int
+	ErrorCount int `json:"errorCount"`
 
 	// SuccessRate provides a numeric measure representing the ratio of successful operations
 	// to total attempted operations over a given interval. A value of 1.0 indicates a 100%
 	// success rate, whereas 0.0 indicates consistent failure.
-	SuccessRate float64
+	SuccessRate float64 `json:"successRate"`
 
 	// Metadata houses any custom properties, key-value pairs, or additional diagnostic information
 	// relevant to the integration's state, usage statistics, or environment.
-	Metadata map[string]interface{}
+	Metadata map[string]interface{} `json:"metadata"`
 }
 
 // MarshalJSON implements a custom JSON serialization to ensure that time fields
@@ -115,30 +461,6 @@ type IntegrationStatus struct {
 // ISO8601 standard for timestamps and provides a structure conducive to logging
 // and monitoring tools.
 func (i IntegrationStatus) MarshalJSON() ([]byte, error) {
-	// Create an alias struct to define how we want to output and format the JSON.
-	type statusAlias struct {
-		Connected   bool                   `json:"connected"`
-		Name        string                 `json:"name"`
-		Type        string                 `json:"type"`
-		LastSync    string                 `json:"lastSync"`
-		LastError   string                 `json:"lastError"`
-		ErrorCount  int                    `json:"errorCount"`
-		SuccessRate float64                `json:"successRate"`
-		Metadata    map[string]interface{} `json:"metadata"`
-	}
-
-	// Convert timestamps to a standardized RFC3339 format (ISO8601).
-	aliasValue := statusAlias{
-		Connected:   i.Connected,
-		Name:        i.Name,
-		Type:        i.Type,
-		LastSync:    i.LastSync.Format(time.RFC3339),
-		LastError:   i.LastError.Format(time.RFC3339),
-		ErrorCount:  i.ErrorCount,
-		SuccessRate: i.SuccessRate,
-		Metadata:    i.Metadata,
-	}
-
-	// Marshal the aliased struct to produce the final JSON representation.
-	return json.Marshal(aliasValue)
+	// Encode directly into a presized buffer; see AppendJSON for the field layout.
+	return i.AppendJSON(make([]byte, 0, statusJSONSizeHint))
 }
\ No newline at end of file
@@ -0,0 +1,134 @@
+package models
+
+import (
+	// go1.21 - Payload encoding and type registry
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// ErrPayloadNotPersistable is returned by EncodePayload for payloads whose type
+// was not registered with RegisterPayloadType and so could not be decoded back
+// into the same type.
+var ErrPayloadNotPersistable = errors.New("payload type cannot be persisted")
+
+// Type tags of payloads that need no registration. JSON values decoded into an
+// interface{} (strings, numbers, booleans, maps and slices of them) are stored
+// untagged.
+const (
+	payloadTypeBytes = "bytes"
+	payloadTypeJSON  = "json"
+)
+
+var (
+	payloadTypesMu sync.RWMutex
+
+	// payloadTypes maps a registered tag to its type, and payloadTags the type
+	// back to its tag. Pointer types are tagged "*" + the tag of their element.
+	payloadTypes = map[string]reflect.Type{
+		payloadTypeBytes: reflect.TypeOf([]byte(nil)),
+		payloadTypeJSON:  reflect.TypeOf(json.RawMessage(nil)),
+	}
+	payloadTags = map[reflect.Type]string{
+		reflect.TypeOf([]byte(nil)):          payloadTypeBytes,
+		reflect.TypeOf(json.RawMessage(nil)): payloadTypeJSON,
+	}
+)
+
+// RegisterPayloadType records tag as the persisted name of v's type, so that
+// payloads of that type, or pointers to it, decode back into it after being
+// written out, e.g. spilled from the dispatch queue or stored for replay.
+// Adapters register the typed payloads they accept from init. Registering a
+// tag or type twice panics, as with gob.RegisterName.
+func RegisterPayloadType(tag string, v interface{}) {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || tag == "" {
+		panic("models: RegisterPayloadType needs a tag and a non-nil value")
+	}
+
+	payloadTypesMu.Lock()
+	defer payloadTypesMu.Unlock()
+	if existing, ok := payloadTypes[tag]; ok {
+		panic(fmt.Sprintf("models: payload tag %q registered for both %v and %v", tag, existing, t))
+	}
+	if existing, ok := payloadTags[t]; ok {
+		panic(fmt.Sprintf("models: payload type %v registered as both %q and %q", t, existing, tag))
+	}
+	payloadTypes[tag] = t
+	payloadTags[t] = tag
+}
+
+// EncodePayload returns the JSON encoding of payload and the tag that
+// DecodePayload needs to restore its type. It fails with
+// ErrPayloadNotPersistable for payloads of unregistered types, such as the
+// wrappers that carry a context.Context to an adapter.
+func EncodePayload(payload interface{}) (string, []byte, error) {
+	tag, err := payloadTag(payload)
+	if err != nil {
+		return "", nil, err
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", nil, err
+	}
+	return tag, data, nil
+}
+
+// DecodePayload decodes data written by EncodePayload back into a payload of
+// the type tag names.
+func DecodePayload(tag string, data []byte) (interface{}, error) {
+	if tag == "" {
+		var payload interface{}
+		if err := json.Unmarshal(data, &payload); err != nil {
+			return nil, err
+		}
+		return payload, nil
+	}
+
+	name, pointer := tag, false
+	if name[0] == '*' {
+		name, pointer = name[1:], true
+	}
+	payloadTypesMu.RLock()
+	t, ok := payloadTypes[name]
+	payloadTypesMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown payload type %q", ErrInvalidPayload, tag)
+	}
+
+	value := reflect.New(t)
+	if err := json.Unmarshal(data, value.Interface()); err != nil {
+		return nil, err
+	}
+	if pointer {
+		return value.Interface(), nil
+	}
+	return value.Elem().Interface(), nil
+}
+
+// payloadTag returns the tag of payload's type: empty for plain JSON values,
+// and the registered tag, "*"-prefixed for pointers, otherwise.
+func payloadTag(payload interface{}) (string, error) {
+	switch payload.(type) {
+	case nil, string, float64, bool, map[string]interface{}, []interface{}:
+		return "", nil
+	}
+
+	t := reflect.TypeOf(payload)
+	prefix := ""
+	if t.Kind() == reflect.Pointer {
+		t, prefix = t.Elem(), "*"
+	}
+	payloadTypesMu.RLock()
+	tag, ok := payloadTags[t]
+	payloadTypesMu.RUnlock()
+	if !ok {
+		return "", fmt.Errorf("%w: %T", ErrPayloadNotPersistable, payload)
+	}
+	return prefix + tag, nil
+}
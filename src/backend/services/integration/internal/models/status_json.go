@@ -0,0 +1,183 @@
+package models
+
+import (
+	// go1.21 - Fallback encoding for uncommon metadata values
+	"encoding/json"
+	"fmt"
+	// go1.21 - Float validation matching encoding/json
+	"math"
+	// go1.21 - Deterministic metadata key order
+	"sort"
+	// go1.21 - Allocation-free number formatting
+	"strconv"
+	"time"
+	"unicode/utf8"
+)
+
+// statusJSONSizeHint is the typical encoded size of an IntegrationStatus without
+// large metadata, used to presize buffers.
+const statusJSONSizeHint = 256
+
+// AppendJSON appends the JSON encoding of the status to dst and returns the
+// extended buffer. It produces the same document as MarshalJSON but writes
+// directly into a caller-supplied (typically pooled) buffer, avoiding the alias
+// struct and reflection that dominate allocations under dashboard polling.
+//
+// Metadata values of common scalar types, time.Time, and nested maps are encoded
+// inline; any other value falls back to encoding/json.
+func (i IntegrationStatus) AppendJSON(dst []byte) ([]byte, error) {
+	var err error
+	dst = append(dst, `{"connected":`...)
+	dst = strconv.AppendBool(dst, i.Connected)
+	dst = append(dst, `,"name":`...)
+	dst = AppendJSONString(dst, i.Name)
+	dst = append(dst, `,"type":`...)
+	dst = AppendJSONString(dst, i.Type)
+	dst = append(dst, `,"lastSync":"`...)
+	dst = i.LastSync.AppendFormat(dst, time.RFC3339)
+	dst = append(dst, `","lastError":"`...)
+	dst = i.LastError.AppendFormat(dst, time.RFC3339)
+	dst = append(dst, `","errorCount":`...)
+	dst = strconv.AppendInt(dst, int64(i.ErrorCount), 10)
+	dst = append(dst, `,"successRate":`...)
+	if dst, err = appendJSONFloat(dst, i.SuccessRate); err != nil {
+		return dst, err
+	}
+	dst = append(dst, `,"metadata":`...)
+	if dst, err = appendJSONMap(dst, i.Metadata); err != nil {
+		return dst, err
+	}
+	return append(dst, '}'), nil
+}
+
+// AppendJSONString appends s as a quoted JSON string, escaping quotes,
+// backslashes, control characters, and invalid UTF-8 as encoding/json does.
+func AppendJSONString(dst []byte, s string) []byte {
+	const hex = "0123456789abcdef"
+	dst = append(dst, '"')
+	start := 0
+	for idx := 0; idx < len(s); {
+		if b := s[idx]; b < utf8.RuneSelf {
+			if b >= 0x20 && b != '"' && b != '\\' && b != '<' && b != '>' && b != '&' {
+				idx++
+				continue
+			}
+			dst = append(dst, s[start:idx]...)
+			switch b {
+			case '"', '\\':
+				dst = append(dst, '\\', b)
+			case '\n':
+				dst = append(dst, '\\', 'n')
+			case '\r':
+				dst = append(dst, '\\', 'r')
+			case '\t':
+				dst = append(dst, '\\', 't')
+			default:
+				dst = append(dst, '\\', 'u', '0', '0', hex[b>>4], hex[b&0xF])
+			}
+			idx++
+			start = idx
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[idx:])
+		if r == utf8.RuneError && size == 1 {
+			dst = append(dst, s[start:idx]...)
+			dst = append(dst, `\ufffd`...)
+			idx += size
+			start = idx
+			continue
+		}
+		// U+2028 and U+2029 are valid JSON but break JavaScript string literals.
+		if r == '\u2028' || r == '\u2029' {
+			dst = append(dst, s[start:idx]...)
+			dst = append(dst, '\\', 'u', '2', '0', '2', hex[r&0xF])
+			idx += size
+			start = idx
+			continue
+		}
+		idx += size
+	}
+	dst = append(dst, s[start:]...)
+	return append(dst, '"')
+}
+
+// appendJSONMap appends m as a JSON object with keys in sorted order, matching
+// encoding/json. A nil map encodes as null.
+func appendJSONMap(dst []byte, m map[string]interface{}) ([]byte, error) {
+	if m == nil {
+		return append(dst, "null"...), nil
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var err error
+	dst = append(dst, '{')
+	for n, k := range keys {
+		if n > 0 {
+			dst = append(dst, ',')
+		}
+		dst = AppendJSONString(dst, k)
+		dst = append(dst, ':')
+		if dst, err = appendJSONValue(dst, m[k]); err != nil {
+			return dst, err
+		}
+	}
+	return append(dst, '}'), nil
+}
+
+// appendJSONValue appends a single metadata value, falling back to
+// encoding/json for types without a fast path.
+func appendJSONValue(dst []byte, v interface{}) ([]byte, error) {
+	switch val := v.(type) {
+	case nil:
+		return append(dst, "null"...), nil
+	case string:
+		return AppendJSONString(dst, val), nil
+	case bool:
+		return strconv.AppendBool(dst, val), nil
+	case int:
+		return strconv.AppendInt(dst, int64(val), 10), nil
+	case int64:
+		return strconv.AppendInt(dst, val, 10), nil
+	case uint64:
+		return strconv.AppendUint(dst, val, 10), nil
+	case float64:
+		return appendJSONFloat(dst, val)
+	case time.Time:
+		dst = append(dst, '"')
+		dst = val.AppendFormat(dst, time.RFC3339Nano)
+		return append(dst, '"'), nil
+	case map[string]interface{}:
+		return appendJSONMap(dst, val)
+	default:
+		encoded, err := json.Marshal(val)
+		if err != nil {
+			return dst, err
+		}
+		return append(dst, encoded...), nil
+	}
+}
+
+// appendJSONFloat formats f the way encoding/json does: decimal notation for
+// ordinary magnitudes and a trimmed exponent for very large or small values.
+func appendJSONFloat(dst []byte, f float64) ([]byte, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return dst, fmt.Errorf("json: unsupported value: %s", strconv.FormatFloat(f, 'g', -1, 64))
+	}
+	format := byte('f')
+	if abs := math.Abs(f); abs != 0 && (abs < 1e-6 || abs >= 1e21) {
+		format = 'e'
+	}
+	dst = strconv.AppendFloat(dst, f, format, -1, 64)
+	if format == 'e' {
+		// Clean up e-09 to e-9.
+		if n := len(dst); n >= 4 && dst[n-4] == 'e' && dst[n-3] == '-' && dst[n-2] == '0' {
+			dst[n-2] = dst[n-1]
+			dst = dst[:n-1]
+		}
+	}
+	return dst, nil
+}
@@ -0,0 +1,273 @@
+package queue
+
+import (
+	// go1.21 - Context for KMS calls
+	"context"
+	// go1.21 - AES-256-GCM authenticated encryption
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	// go1.21 - Key decoding and envelope framing
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	// v1.25.0 - AWS SDK configuration and KMS client for data keys
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	kmstypes "github.com/aws/aws-sdk-go-v2/service/kms/types"
+
+	// Internal configuration for the encryption provider
+	"src/backend/services/integration/internal/config"
+)
+
+// envelopeMagic prefixes every encrypted record so plaintext records written
+// before encryption was enabled can still be recognised.
+var envelopeMagic = []byte("TSQ1")
+
+var (
+	// ErrCorruptEnvelope is returned when an encrypted record cannot be parsed.
+	ErrCorruptEnvelope = errors.New("corrupt encrypted spool record")
+
+	// ErrInvalidStaticKey is returned when the static key is not 32 bytes of base64.
+	ErrInvalidStaticKey = errors.New("static spool key must be 32 bytes, base64 encoded")
+)
+
+// Cipher encrypts and decrypts spool records.
+type Cipher interface {
+	// Seal encrypts plaintext into a self-describing envelope.
+	Seal(ctx context.Context, plaintext []byte) ([]byte, error)
+
+	// Open decrypts an envelope produced by Seal.
+	Open(ctx context.Context, envelope []byte) ([]byte, error)
+}
+
+// KeyProvider issues data keys and unwraps previously issued ones.
+type KeyProvider interface {
+	// GenerateDataKey returns a fresh 256-bit data key and its wrapped form.
+	GenerateDataKey(ctx context.Context) (plaintext, wrapped []byte, err error)
+
+	// DecryptDataKey unwraps a data key returned by GenerateDataKey.
+	DecryptDataKey(ctx context.Context, wrapped []byte) ([]byte, error)
+}
+
+// EnvelopeCipher implements envelope encryption: records are sealed with
+// AES-256-GCM under a data key, and the wrapped data key is stored alongside
+// each record. One data key is generated per process; unwrapped keys are cached
+// so that replaying a spool costs one KMS call per distinct data key.
+//
+// Envelope layout: "TSQ1" | uint16 wrapped key length | wrapped key | 12-byte nonce | ciphertext.
+type EnvelopeCipher struct {
+	provider KeyProvider
+
+	// mu guards the active data key and the unwrapped key cache.
+	mu         sync.Mutex
+	activeKey  []byte
+	activeWrap []byte
+	unwrapped  map[[32]byte][]byte
+}
+
+// NewEnvelopeCipher creates an EnvelopeCipher backed by the given key provider.
+func NewEnvelopeCipher(provider KeyProvider) *EnvelopeCipher {
+	return &EnvelopeCipher{
+		provider:  provider,
+		unwrapped: make(map[[32]byte][]byte),
+	}
+}
+
+// NewCipherFromConfig returns the Cipher configured for the spool, or nil when
+// encryption is disabled.
+func NewCipherFromConfig(ctx context.Context, cfg *config.SpoolEncryptionConfig) (Cipher, error) {
+	if cfg == nil || !cfg.Enabled {
+		return nil, nil
+	}
+
+	switch cfg.Provider {
+	case config.KeyProviderStatic:
+		key, err := base64.StdEncoding.DecodeString(cfg.StaticKey)
+		if err != nil || len(key) != 32 {
+			return nil, ErrInvalidStaticKey
+		}
+		return NewEnvelopeCipher(&staticKeyProvider{key: key}), nil
+	default:
+		opts := []func(*awsconfig.LoadOptions) error{}
+		if cfg.Region != "" {
+			opts = append(opts, awsconfig.WithRegion(cfg.Region))
+		}
+		awsCfg, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load AWS configuration for spool encryption: %w", err)
+		}
+		return NewEnvelopeCipher(&kmsKeyProvider{client: kms.NewFromConfig(awsCfg), keyID: cfg.KMSKeyID}), nil
+	}
+}
+
+// Seal encrypts plaintext under the process data key.
+func (c *EnvelopeCipher) Seal(ctx context.Context, plaintext []byte) ([]byte, error) {
+	key, wrapped, err := c.dataKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, 0, len(envelopeMagic)+2+len(wrapped)+len(nonce)+len(plaintext)+aead.Overhead())
+	out = append(out, envelopeMagic...)
+	out = binary.BigEndian.AppendUint16(out, uint16(len(wrapped)))
+	out = append(out, wrapped...)
+	out = append(out, nonce...)
+	// The envelope header is authenticated as additional data.
+	return aead.Seal(out, nonce, plaintext, out[:len(envelopeMagic)+2+len(wrapped)]), nil
+}
+
+// Open decrypts an envelope, unwrapping its data key if it is not cached.
+func (c *EnvelopeCipher) Open(ctx context.Context, envelope []byte) ([]byte, error) {
+	headerLen := len(envelopeMagic) + 2
+	if len(envelope) < headerLen || string(envelope[:len(envelopeMagic)]) != string(envelopeMagic) {
+		return nil, ErrCorruptEnvelope
+	}
+	wrappedLen := int(binary.BigEndian.Uint16(envelope[len(envelopeMagic):headerLen]))
+	if len(envelope) < headerLen+wrappedLen {
+		return nil, ErrCorruptEnvelope
+	}
+	wrapped := envelope[headerLen : headerLen+wrappedLen]
+
+	key, err := c.unwrap(ctx, wrapped)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	rest := envelope[headerLen+wrappedLen:]
+	if len(rest) < aead.NonceSize() {
+		return nil, ErrCorruptEnvelope
+	}
+	nonce, ciphertext := rest[:aead.NonceSize()], rest[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, envelope[:headerLen+wrappedLen])
+	if err != nil {
+		return nil, ErrCorruptEnvelope
+	}
+	return plaintext, nil
+}
+
+// dataKey returns the process data key, generating it on first use.
+func (c *EnvelopeCipher) dataKey(ctx context.Context) ([]byte, []byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.activeKey == nil {
+		key, wrapped, err := c.provider.GenerateDataKey(ctx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to generate spool data key: %w", err)
+		}
+		c.activeKey, c.activeWrap = key, wrapped
+		c.unwrapped[sha256.Sum256(wrapped)] = key
+	}
+	return c.activeKey, c.activeWrap, nil
+}
+
+// unwrap returns the plaintext data key for a wrapped key, consulting the cache first.
+func (c *EnvelopeCipher) unwrap(ctx context.Context, wrapped []byte) ([]byte, error) {
+	id := sha256.Sum256(wrapped)
+
+	c.mu.Lock()
+	key, ok := c.unwrapped[id]
+	c.mu.Unlock()
+	if ok {
+		return key, nil
+	}
+
+	key, err := c.provider.DecryptDataKey(ctx, wrapped)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap spool data key: %w", err)
+	}
+
+	c.mu.Lock()
+	c.unwrapped[id] = key
+	c.mu.Unlock()
+	return key, nil
+}
+
+// newGCM constructs an AES-GCM AEAD for a 256-bit key.
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// kmsKeyProvider issues data keys from AWS KMS.
+type kmsKeyProvider struct {
+	client *kms.Client
+	keyID  string
+}
+
+func (p *kmsKeyProvider) GenerateDataKey(ctx context.Context) ([]byte, []byte, error) {
+	out, err := p.client.GenerateDataKey(ctx, &kms.GenerateDataKeyInput{
+		KeyId:   &p.keyID,
+		KeySpec: kmstypes.DataKeySpecAes256,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return out.Plaintext, out.CiphertextBlob, nil
+}
+
+func (p *kmsKeyProvider) DecryptDataKey(ctx context.Context, wrapped []byte) ([]byte, error) {
+	out, err := p.client.Decrypt(ctx, &kms.DecryptInput{
+		CiphertextBlob: wrapped,
+		KeyId:          &p.keyID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out.Plaintext, nil
+}
+
+// staticKeyProvider wraps per-process data keys with a locally configured key
+// encryption key using AES-256-GCM.
+type staticKeyProvider struct {
+	key []byte
+}
+
+func (p *staticKeyProvider) GenerateDataKey(ctx context.Context) ([]byte, []byte, error) {
+	dataKey := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return nil, nil, err
+	}
+	aead, err := newGCM(p.key)
+	if err != nil {
+		return nil, nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, nil, err
+	}
+	return dataKey, aead.Seal(nonce, nonce, dataKey, nil), nil
+}
+
+func (p *staticKeyProvider) DecryptDataKey(ctx context.Context, wrapped []byte) ([]byte, error) {
+	aead, err := newGCM(p.key)
+	if err != nil {
+		return nil, err
+	}
+	if len(wrapped) < aead.NonceSize() {
+		return nil, ErrCorruptEnvelope
+	}
+	return aead.Open(nil, wrapped[:aead.NonceSize()], wrapped[aead.NonceSize():], nil)
+}
@@ -0,0 +1,167 @@
+package queue
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"src/backend/services/integration/internal/config"
+)
+
+// countingKeyProvider counts the data keys it unwraps.
+type countingKeyProvider struct {
+	KeyProvider
+	decrypts atomic.Int32
+}
+
+func (p *countingKeyProvider) DecryptDataKey(ctx context.Context, wrapped []byte) ([]byte, error) {
+	p.decrypts.Add(1)
+	return p.KeyProvider.DecryptDataKey(ctx, wrapped)
+}
+
+// newStaticCipher returns the cipher configured for a static key encryption key.
+func newStaticCipher(t *testing.T, kek []byte) Cipher {
+	t.Helper()
+	c, err := NewCipherFromConfig(context.Background(), &config.SpoolEncryptionConfig{
+		Enabled:   true,
+		Provider:  config.KeyProviderStatic,
+		StaticKey: base64.StdEncoding.EncodeToString(kek),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+// wrappedKey returns the wrapped data key in an envelope's header.
+func wrappedKey(envelope []byte) []byte {
+	headerLen := len(envelopeMagic) + 2
+	return envelope[headerLen : headerLen+int(binary.BigEndian.Uint16(envelope[len(envelopeMagic):headerLen]))]
+}
+
+func TestEnvelopeCipherRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	c := newStaticCipher(t, bytes.Repeat([]byte{1}, 32))
+
+	for _, plaintext := range [][]byte{
+		{},
+		[]byte(`{"integration":"slack","payload":"deploy finished"}`),
+		bytes.Repeat([]byte("spool"), 1<<14),
+	} {
+		envelope, err := c.Seal(ctx, plaintext)
+		if err != nil {
+			t.Fatalf("Seal: %v", err)
+		}
+		if len(plaintext) > 0 && bytes.Contains(envelope, plaintext) {
+			t.Fatal("envelope contains the plaintext")
+		}
+		got, err := c.Open(ctx, envelope)
+		if err != nil {
+			t.Fatalf("Open: %v", err)
+		}
+		if !bytes.Equal(got, plaintext) {
+			t.Errorf("Open() = %q, want %q", got, plaintext)
+		}
+	}
+}
+
+func TestEnvelopeCipherDetectsTampering(t *testing.T) {
+	ctx := context.Background()
+	c := newStaticCipher(t, bytes.Repeat([]byte{1}, 32))
+	envelope, err := c.Seal(ctx, []byte("credentials rotated"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	headerLen := len(envelopeMagic) + 2 + len(wrappedKey(envelope))
+
+	for _, tc := range []struct {
+		name   string
+		tamper func([]byte) []byte
+		want   error // nil when any error will do
+	}{
+		{"ciphertext", func(e []byte) []byte { e[len(e)-1] ^= 0x01; return e }, ErrCorruptEnvelope},
+		{"nonce", func(e []byte) []byte { e[headerLen] ^= 0x01; return e }, ErrCorruptEnvelope},
+		{"wrapped key length", func(e []byte) []byte { e[len(envelopeMagic)+1] ^= 0x01; return e }, nil},
+		{"wrapped key", func(e []byte) []byte { e[len(envelopeMagic)+2] ^= 0x01; return e }, nil},
+		{"magic", func(e []byte) []byte { e[0] = 'X'; return e }, ErrCorruptEnvelope},
+		{"truncated nonce", func(e []byte) []byte { return e[:headerLen+4] }, ErrCorruptEnvelope},
+		{"truncated tag", func(e []byte) []byte { return e[:len(e)-1] }, ErrCorruptEnvelope},
+		{"plaintext record", func([]byte) []byte { return []byte(`{"integration":"slack"}`) }, ErrCorruptEnvelope},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tampered := tc.tamper(append([]byte(nil), envelope...))
+			plaintext, err := c.Open(ctx, tampered)
+			if err == nil {
+				t.Fatalf("Open() = %q, want an error", plaintext)
+			}
+			if tc.want != nil && !errors.Is(err, tc.want) {
+				t.Errorf("Open() error = %v, want %v", err, tc.want)
+			}
+		})
+	}
+}
+
+// TestEnvelopeCipherOpensRecordsOfEarlierDataKeys checks data key rotation:
+// every process seals under a data key of its own, and records spooled by an
+// earlier process, under its data key, still open.
+func TestEnvelopeCipherOpensRecordsOfEarlierDataKeys(t *testing.T) {
+	ctx := context.Background()
+	kek := bytes.Repeat([]byte{7}, 32)
+
+	before := newStaticCipher(t, kek)
+	old, err := before.Seal(ctx, []byte("spooled before restart"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	provider := &countingKeyProvider{KeyProvider: &staticKeyProvider{key: kek}}
+	after := NewEnvelopeCipher(provider)
+	current, err := after.Seal(ctx, []byte("spooled after restart"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	next, err := after.Seal(ctx, []byte("spooled after restart"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(wrappedKey(current), wrappedKey(next)) {
+		t.Error("one process sealed under two data keys")
+	}
+	if bytes.Equal(wrappedKey(old), wrappedKey(current)) {
+		t.Fatal("restarted process reused the earlier data key")
+	}
+
+	for i := 0; i < 3; i++ {
+		if got, err := after.Open(ctx, old); err != nil || string(got) != "spooled before restart" {
+			t.Fatalf("Open(earlier record) = %q, %v", got, err)
+		}
+		if got, err := after.Open(ctx, current); err != nil || string(got) != "spooled after restart" {
+			t.Fatalf("Open(current record) = %q, %v", got, err)
+		}
+	}
+	if n := provider.decrypts.Load(); n != 1 {
+		t.Errorf("unwrapped %d data keys, want 1: the earlier key once, the current one never", n)
+	}
+
+	other := newStaticCipher(t, bytes.Repeat([]byte{8}, 32))
+	if _, err := other.Open(ctx, old); err == nil {
+		t.Error("record opened under a different key encryption key")
+	}
+}
+
+func TestNewCipherFromConfigRejectsInvalidStaticKey(t *testing.T) {
+	for _, key := range []string{"not base64!", base64.StdEncoding.EncodeToString([]byte("too short"))} {
+		_, err := NewCipherFromConfig(context.Background(), &config.SpoolEncryptionConfig{
+			Enabled:   true,
+			Provider:  config.KeyProviderStatic,
+			StaticKey: key,
+		})
+		if !errors.Is(err, ErrInvalidStaticKey) {
+			t.Errorf("NewCipherFromConfig(%q) = %v, want %v", key, err, ErrInvalidStaticKey)
+		}
+	}
+}
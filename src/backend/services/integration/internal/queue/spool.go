@@ -0,0 +1,165 @@
+package queue
+
+import (
+	// go1.21 - Context for cipher operations
+	"context"
+	// go1.21 - Detecting encrypted records
+	"bytes"
+	"errors"
+	"fmt"
+	// go1.21 - File-backed record storage
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	// Internal configuration for spool location and encryption
+	"src/backend/services/integration/internal/config"
+)
+
+// recordSuffix is the file extension of committed spool records.
+const recordSuffix = ".rec"
+
+// validRecordID restricts record IDs to characters that are safe as file names.
+var validRecordID = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+var (
+	// ErrInvalidRecordID is returned for IDs that are empty or contain path characters.
+	ErrInvalidRecordID = errors.New("invalid spool record id")
+
+	// ErrRecordNotFound is returned when a record does not exist in the spool.
+	ErrRecordNotFound = errors.New("spool record not found")
+)
+
+// Spool is a durable, file-per-record store for queued payloads. Records are
+// written atomically (temp file, fsync, rename) with owner-only permissions and,
+// when a Cipher is configured, sealed with envelope encryption since payloads may
+// contain PII destined for email or chat recipients.
+type Spool struct {
+	// dir is the directory holding record files.
+	dir string
+
+	// cipher encrypts records at rest; nil stores plaintext.
+	cipher Cipher
+}
+
+// NewSpool opens (creating if needed) a spool in dir. A nil cipher disables encryption.
+func NewSpool(dir string, cipher Cipher) (*Spool, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create spool directory: %w", err)
+	}
+	return &Spool{dir: dir, cipher: cipher}, nil
+}
+
+// NewSpoolFromConfig opens the spool described by configuration, including its cipher.
+func NewSpoolFromConfig(ctx context.Context, cfg *config.SpoolConfig) (*Spool, error) {
+	cipher, err := NewCipherFromConfig(ctx, cfg.Encryption)
+	if err != nil {
+		return nil, err
+	}
+	return NewSpool(cfg.Dir, cipher)
+}
+
+// Encrypted reports whether records are encrypted at rest.
+func (s *Spool) Encrypted() bool {
+	return s.cipher != nil
+}
+
+// Put durably stores data under id, replacing any existing record.
+func (s *Spool) Put(ctx context.Context, id string, data []byte) error {
+	if !validRecordID.MatchString(id) {
+		return ErrInvalidRecordID
+	}
+
+	if s.cipher != nil {
+		sealed, err := s.cipher.Seal(ctx, data)
+		if err != nil {
+			return err
+		}
+		data = sealed
+	}
+
+	tmp, err := os.CreateTemp(s.dir, id+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+	defer os.Remove(tmpName) // no-op once renamed
+
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpName, s.path(id))
+}
+
+// Get reads and, if necessary, decrypts the record stored under id. Plaintext
+// records written before encryption was enabled are returned as-is.
+func (s *Spool) Get(ctx context.Context, id string) ([]byte, error) {
+	if !validRecordID.MatchString(id) {
+		return nil, ErrInvalidRecordID
+	}
+
+	data, err := os.ReadFile(s.path(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrRecordNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if bytes.HasPrefix(data, envelopeMagic) {
+		if s.cipher == nil {
+			return nil, fmt.Errorf("spool record %s is encrypted but no cipher is configured", id)
+		}
+		return s.cipher.Open(ctx, data)
+	}
+	return data, nil
+}
+
+// Delete removes the record stored under id. Deleting a missing record is not an error.
+func (s *Spool) Delete(id string) error {
+	if !validRecordID.MatchString(id) {
+		return ErrInvalidRecordID
+	}
+	if err := os.Remove(s.path(id)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// List returns the IDs of all committed records in lexical order. Callers that
+// need FIFO replay should use time-ordered IDs.
+func (s *Spool) List() ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, recordSuffix) {
+			continue
+		}
+		ids = append(ids, strings.TrimSuffix(name, recordSuffix))
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// path returns the file path for a record ID.
+func (s *Spool) path(id string) string {
+	return filepath.Join(s.dir, id+recordSuffix)
+}
@@ -0,0 +1,158 @@
+package retry
+
+import (
+	// go1.21 - Guards window counters
+	"sync"
+	"time"
+
+	// v1.16.0 - Budget metrics exposed on the default registry served at /metrics
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	// Internal configuration for budget sizing
+	"src/backend/services/integration/internal/config"
+)
+
+// budgetBuckets is the number of slices the window is divided into; counts
+// expire one slice at a time.
+const budgetBuckets = 10
+
+// globalBudgetName labels the global budget in metrics.
+const globalBudgetName = "_global"
+
+// budgetExhausted counts retries skipped for lack of budget.
+var budgetExhausted = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "integration_retry_budget_exhausted_total",
+	Help: "Retries skipped because the retry budget was exhausted, by budget.",
+}, []string{"budget"})
+
+// bucket counts requests and retries for one slice of the window.
+type bucket struct {
+	start    time.Time
+	requests int
+	retries  int
+}
+
+// Budget allows retries only while they stay within a fraction of recent
+// request volume, so a provider that starts failing sees at most a bounded
+// amplification of its normal load. A budget may have a parent (the global
+// budget); a retry must then fit both. All methods are safe on a nil Budget,
+// which allows every retry. Budget implements models.RetryBudget.
+type Budget struct {
+	name   string
+	spec   config.RetryBudgetSpec
+	slice  time.Duration
+	parent *Budget
+
+	mu      sync.Mutex
+	buckets [budgetBuckets]bucket
+}
+
+// NewBudget creates a budget counting over window. parent may be nil.
+func NewBudget(name string, window time.Duration, spec config.RetryBudgetSpec, parent *Budget) *Budget {
+	slice := window / budgetBuckets
+	if slice <= 0 {
+		slice = time.Millisecond
+	}
+	return &Budget{
+		name:   name,
+		spec:   spec,
+		slice:  slice,
+		parent: parent,
+	}
+}
+
+// RecordRequest counts one first attempt against the budget and its parent.
+func (b *Budget) RecordRequest() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	b.currentLocked(time.Now()).requests++
+	b.mu.Unlock()
+	b.parent.RecordRequest()
+}
+
+// AllowRetry reports whether one more retry fits the budget and its parent,
+// and if so counts it against both.
+func (b *Budget) AllowRetry() bool {
+	if b == nil {
+		return true
+	}
+	now := time.Now()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.fitsLocked(now) {
+		budgetExhausted.WithLabelValues(b.name).Inc()
+		return false
+	}
+	if b.parent != nil && !b.parent.AllowRetry() {
+		return false
+	}
+	b.currentLocked(now).retries++
+	return true
+}
+
+// fitsLocked reports whether another retry is within the budget. b.mu must be held.
+func (b *Budget) fitsLocked(now time.Time) bool {
+	requests, retries := 0, 0
+	cutoff := now.Add(-b.slice * budgetBuckets)
+	for _, bk := range b.buckets {
+		if bk.start.After(cutoff) {
+			requests += bk.requests
+			retries += bk.retries
+		}
+	}
+	allowed := int(b.spec.Ratio*float64(requests)) + b.spec.MinRetries
+	return retries < allowed
+}
+
+// currentLocked returns the bucket for now, resetting it if it holds an
+// expired slice. b.mu must be held.
+func (b *Budget) currentLocked(now time.Time) *bucket {
+	slot := now.Truncate(b.slice)
+	bk := &b.buckets[(slot.UnixNano()/int64(b.slice))%budgetBuckets]
+	if !bk.start.Equal(slot) {
+		*bk = bucket{start: slot}
+	}
+	return bk
+}
+
+// Budgets holds the global budget and one budget per integration.
+type Budgets struct {
+	cfg    *config.RetryBudgetConfig
+	global *Budget
+
+	mu     sync.Mutex
+	byName map[string]*Budget
+}
+
+// NewBudgets creates the budgets described by cfg. It returns nil when retry
+// budgets are disabled; ForIntegration on a nil Budgets returns a nil Budget,
+// which allows every retry.
+func NewBudgets(cfg *config.RetryBudgetConfig) *Budgets {
+	if !cfg.RetryBudgetEnabled() {
+		return nil
+	}
+	return &Budgets{
+		cfg:    cfg,
+		global: NewBudget(globalBudgetName, cfg.Window, cfg.Global, nil),
+		byName: make(map[string]*Budget),
+	}
+}
+
+// ForIntegration returns the named integration's budget, creating it on first use.
+func (bs *Budgets) ForIntegration(name string) *Budget {
+	if bs == nil {
+		return nil
+	}
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	b, ok := bs.byName[name]
+	if !ok {
+		b = NewBudget(name, bs.cfg.Window, bs.cfg.ForIntegration(name), bs.global)
+		bs.byName[name] = b
+	}
+	return b
+}
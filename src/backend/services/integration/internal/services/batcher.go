@@ -0,0 +1,293 @@
+package services
+
+import (
+	// go1.21 - Delivery lifetime and shutdown deadlines
+	"context"
+	"errors"
+	"fmt"
+	// go1.21 - Guards pending batches
+	"sync"
+	"time"
+
+	// v1.24.0 - Structured logging of panicking deliveries
+	"go.uber.org/zap"
+
+	// Internal imports from the same module
+	"src/backend/services/integration/internal/config"
+	"src/backend/services/integration/internal/models"
+)
+
+// pendingBatch is a batch collecting payloads for one integration.
+type pendingBatch struct {
+	payloads []interface{}
+	tickets  []*DispatchTicket
+	timer    *time.Timer
+}
+
+// Batcher coalesces payloads destined for the same integration within a short
+// window and delivers them with a single batch call, so a burst of sends costs
+// a handful of provider calls instead of one per message. A batch is flushed
+// when its window elapses or it reaches its maximum size, whichever is first,
+// and delivered by the dispatcher's workers, so batches share the send
+// pipeline's concurrency limit.
+type Batcher struct {
+	ctx     context.Context
+	deliver func(ctx context.Context, name string, payloads []interface{}) ([]models.BatchResult, error)
+
+	// submit hands a flushed batch to the dispatch workers.
+	submit func(dispatchTask) error
+
+	// lifecycle reports the stages of each payload.
+	lifecycle lifecycleFunc
+
+	// logger returns where panicking deliveries are logged.
+	logger func() *zap.Logger
+
+	// maxPending caps items, the payloads in open batches or waiting for delivery.
+	maxPending int
+
+	mu      sync.Mutex
+	pending map[string]*pendingBatch
+	items   int
+	closed  bool
+	wg      sync.WaitGroup
+}
+
+// newBatcher creates a batcher that flushes batches with deliver on the workers
+// reached through submit, holding at most maxPending payloads and reporting each
+// payload's stages to lifecycle. A delivery that panics fails its batch and is
+// logged to logger.
+func newBatcher(ctx context.Context, deliver func(context.Context, string, []interface{}) ([]models.BatchResult, error), submit func(dispatchTask) error, lifecycle lifecycleFunc, logger func() *zap.Logger, maxPending int) *Batcher {
+	return &Batcher{
+		ctx:        ctx,
+		deliver:    deliver,
+		submit:     submit,
+		lifecycle:  lifecycle,
+		logger:     logger,
+		maxPending: maxPending,
+		pending:    make(map[string]*pendingBatch),
+	}
+}
+
+// Add queues payload into the open batch for the named integration, opening a
+// new batch if needed. Each payload gets its own outcome from the batch call.
+// When the batcher already holds maxPending payloads, it fails with
+// ErrDispatchQueueFull.
+func (b *Batcher) Add(ctx context.Context, name string, spec config.BatchSpec, payload interface{}) (*DispatchTicket, error) {
+	ticket := newDispatchTicket(models.RequestIDFrom(ctx))
+
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return nil, ErrDispatcherClosed
+	}
+	if b.items >= b.maxPending {
+		b.mu.Unlock()
+		return nil, ErrDispatchQueueFull
+	}
+	b.items++
+	batch, open := b.pending[name]
+	if !open {
+		batch = &pendingBatch{payloads: make([]interface{}, 0, spec.MaxBatchSize)}
+		batch.timer = time.AfterFunc(spec.Window, func() { b.flush(name, batch) })
+		b.pending[name] = batch
+		b.wg.Add(1)
+	}
+	batch.payloads = append(batch.payloads, payload)
+	batch.tickets = append(batch.tickets, ticket)
+	b.lifecycle(name, ticket.id, MessageQueued, nil)
+	full := len(batch.payloads) >= spec.MaxBatchSize && b.detachLocked(name, batch)
+	b.mu.Unlock()
+
+	if full {
+		b.schedule(name, batch)
+	}
+	return ticket, nil
+}
+
+// Shutdown stops accepting payloads, flushes every open batch immediately, and
+// waits for deliveries to finish or ctx to end.
+func (b *Batcher) Shutdown(ctx context.Context) error {
+	b.mu.Lock()
+	b.closed = true
+	flushed := make(map[string]*pendingBatch, len(b.pending))
+	for name, batch := range b.pending {
+		if b.detachLocked(name, batch) {
+			flushed[name] = batch
+		}
+	}
+	b.mu.Unlock()
+
+	for name, batch := range flushed {
+		b.schedule(name, batch)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		b.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// flush delivers a batch whose window has elapsed, unless it was already
+// flushed for reaching its size limit.
+func (b *Batcher) flush(name string, batch *pendingBatch) {
+	b.mu.Lock()
+	ok := b.detachLocked(name, batch)
+	b.mu.Unlock()
+	if ok {
+		b.schedule(name, batch)
+	}
+}
+
+// detachLocked removes batch from the open set, reporting false if it had
+// already been detached. b.mu must be held.
+func (b *Batcher) detachLocked(name string, batch *pendingBatch) bool {
+	if b.pending[name] != batch {
+		return false
+	}
+	delete(b.pending, name)
+	batch.timer.Stop()
+	return true
+}
+
+// schedule hands a detached batch to the dispatch workers, failing its
+// payloads when the dispatcher has shut down or gives up on it.
+func (b *Batcher) schedule(name string, batch *pendingBatch) {
+	err := b.submit(dispatchTask{
+		run:     func(context.Context) { b.deliverBatch(name, batch) },
+		abandon: func(err error) { b.complete(name, batch, nil, err) },
+	})
+	if err != nil {
+		b.complete(name, batch, nil, err)
+	}
+}
+
+// deliverBatch sends a detached batch and completes its tickets.
+func (b *Batcher) deliverBatch(name string, batch *pendingBatch) {
+	ids := make([]string, len(batch.tickets))
+	for i, ticket := range batch.tickets {
+		ids[i] = ticket.id
+	}
+	ctx := withBatchMessageIDs(b.ctx, ids)
+	// A batch carries a request ID only when all of its payloads came from the
+	// same request; adapters tag a batch as a whole.
+	if requestID := batch.tickets[0].requestID; requestID != "" {
+		shared := true
+		for _, ticket := range batch.tickets[1:] {
+			shared = shared && ticket.requestID == requestID
+		}
+		if shared {
+			ctx = models.WithRequestID(ctx, requestID)
+		}
+	}
+	var results []models.BatchResult
+	err := sendRecovering(ctx, b.logger, "batch-delivery", name, func(ctx context.Context) error {
+		var deliverErr error
+		results, deliverErr = b.deliver(ctx, name, batch.payloads)
+		return deliverErr
+	})
+	b.complete(name, batch, results, err)
+}
+
+// complete completes each ticket of a batch with its payload's result, or
+// with err when the batch failed as a whole, and releases the batch's room.
+func (b *Batcher) complete(name string, batch *pendingBatch, results []models.BatchResult, err error) {
+	defer b.wg.Done()
+	for i, ticket := range batch.tickets {
+		ticketErr := err
+		if err == nil {
+			ticket.result = results[i].Result
+			ticketErr = results[i].Err
+		}
+		b.lifecycle(name, ticket.id, deliveryStage(ticketErr), ticketErr)
+		ticket.complete(ticketErr)
+	}
+
+	b.mu.Lock()
+	b.items -= len(batch.tickets)
+	b.mu.Unlock()
+}
+
+// sendBatch delivers a coalesced batch to the named integration inside its
+// bulkhead, returning one result per payload. Payloads that failed because the
+// provider is failing are kept for replay when store-and-forward is attached.
+func (sm *SyncManager) sendBatch(ctx context.Context, name string, payloads []interface{}) ([]models.BatchResult, error) {
+	sm.mu.RLock()
+	integration, exists := sm.integrations[name]
+	bulkhead := sm.bulkheads[name]
+	sm.mu.RUnlock()
+	if !exists {
+		return nil, ErrIntegrationNotRegistered
+	}
+	sm.retryBudgets.ForIntegration(name).RecordRequest()
+
+	sender, ok := integration.(models.BatchSender)
+	if !ok {
+		// Registration changed since the batch opened; deliver one at a time.
+		results := make([]models.BatchResult, len(payloads))
+		err := bulkhead.Execute(ctx, func() error {
+			for i, payload := range payloads {
+				results[i].Err = integration.Send(payload)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return results, nil
+	}
+	started := time.Now()
+	var results []models.BatchResult
+	err := bulkhead.Execute(ctx, func() error {
+		var sendErr error
+		results, sendErr = sender.SendBatch(ctx, payloads)
+		return sendErr
+	})
+	if err == nil && len(results) != len(payloads) {
+		err = fmt.Errorf("%s reported %d results for a batch of %d", name, len(results), len(payloads))
+	}
+	if err != nil {
+		results = models.FailBatchResults(len(payloads), err)
+	}
+	sm.publishDelivery(sm.deliveries.record(name, len(payloads), started, firstBatchError(results)))
+	sm.recordBatchHistory(ctx, name, payloads, started, results)
+
+	// The provider is failing; keep what it did not take for replay when
+	// store-and-forward is attached.
+	sm.mu.RLock()
+	sf := sm.storeAndForward
+	sm.mu.RUnlock()
+	if sf == nil {
+		return results, nil
+	}
+	open := circuitOpen(integration)
+	for i, result := range results {
+		if result.Err == nil || !(open || errors.Is(result.Err, models.ErrRetryBudgetExhausted)) {
+			continue
+		}
+		if storeErr := sf.Store(ctx, name, payloads[i]); storeErr != nil {
+			results[i].Err = storeErr
+			continue
+		}
+		results[i].Err = ErrStoredForReplay
+	}
+	return results, nil
+}
+
+// firstBatchError returns the first payload error of a batch, or nil when
+// every payload was delivered.
+func firstBatchError(results []models.BatchResult) error {
+	for _, result := range results {
+		if result.Err != nil {
+			return result.Err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	// go1.21 - Sentinel error for adapters without a resettable breaker
+	"errors"
+
+	// Internal models for the optional circuit interfaces
+	"src/backend/services/integration/internal/models"
+)
+
+// ErrCircuitResetUnsupported is returned when an integration has no circuit
+// breaker that can be reset.
+var ErrCircuitResetUnsupported = errors.New("integration has no resettable circuit breaker")
+
+// CircuitOpen reports whether the named integration's circuit is open. Unknown
+// integrations and integrations without a breaker report false.
+func (sm *SyncManager) CircuitOpen(name string) bool {
+	sm.mu.RLock()
+	integration, exists := sm.integrations[name]
+	sm.mu.RUnlock()
+	return exists && circuitOpen(integration)
+}
+
+// ResetCircuit force-closes the named integration's circuit breaker.
+func (sm *SyncManager) ResetCircuit(name string) error {
+	integration, err := sm.GetIntegration(name)
+	if err != nil {
+		return err
+	}
+	resetter, ok := integration.(models.CircuitResetter)
+	if !ok {
+		return ErrCircuitResetUnsupported
+	}
+	resetter.ResetCircuit()
+	return nil
+}
+
+// ErrNoCircuitBreaker is returned for integrations without a circuit breaker.
+var ErrNoCircuitBreaker = errors.New("integration has no circuit breaker")
+
+// BreakerStatus is the circuit breaker state of one integration.
+type BreakerStatus struct {
+	models.CircuitSnapshot
+
+	// Resettable reports whether operators can force the circuit closed.
+	Resettable bool `json:"resettable"`
+}
+
+// Breakers returns the circuit breaker state of every registered integration
+// that has one. Adapters that only report whether their circuit is open are
+// listed with that state and no counters.
+func (sm *SyncManager) Breakers() map[string]BreakerStatus {
+	sm.mu.RLock()
+	integrations := make(map[string]models.Integration, len(sm.integrations))
+	for name, integration := range sm.integrations {
+		integrations[name] = integration
+	}
+	sm.mu.RUnlock()
+
+	breakers := make(map[string]BreakerStatus, len(integrations))
+	for name, integration := range integrations {
+		if status, ok := breakerStatus(integration); ok {
+			breakers[name] = status
+		}
+	}
+	return breakers
+}
+
+// Breaker returns the named integration's circuit breaker state.
+func (sm *SyncManager) Breaker(name string) (BreakerStatus, error) {
+	integration, err := sm.GetIntegration(name)
+	if err != nil {
+		return BreakerStatus{}, err
+	}
+	status, ok := breakerStatus(integration)
+	if !ok {
+		return BreakerStatus{}, ErrNoCircuitBreaker
+	}
+	return status, nil
+}
+
+// breakerStatus describes integration's circuit breaker, reporting false when
+// it has none.
+func breakerStatus(integration models.Integration) (BreakerStatus, bool) {
+	_, resettable := integration.(models.CircuitResetter)
+	if inspector, ok := integration.(models.CircuitInspector); ok {
+		return BreakerStatus{CircuitSnapshot: inspector.CircuitSnapshot(), Resettable: resettable}, true
+	}
+	reporter, ok := integration.(models.CircuitReporter)
+	if !ok {
+		return BreakerStatus{}, false
+	}
+	status := BreakerStatus{CircuitSnapshot: models.CircuitSnapshot{State: models.CircuitClosed}, Resettable: resettable}
+	if reporter.CircuitOpen() {
+		status.State = models.CircuitOpen
+	}
+	return status, true
+}
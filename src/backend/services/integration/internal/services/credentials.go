@@ -0,0 +1,143 @@
+package services
+
+import (
+	// go1.21 - Context for rotation calls and watcher lifetime
+	"context"
+	// go1.21 - Change detection for credential files
+	"crypto/sha256"
+	// go1.21 - Decoding of credential files
+	"encoding/json"
+	"errors"
+	"fmt"
+	// go1.21 - Secrets directory access
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	// v1.24.0 - Structured logging of rotation outcomes
+	"go.uber.org/zap"
+
+	// Internal imports from the same module
+	"src/backend/services/integration/internal/config"
+	"src/backend/services/integration/internal/models"
+)
+
+var (
+	// ErrIntegrationNotRegistered is returned when an operation names an unknown integration.
+	ErrIntegrationNotRegistered = errors.New("integration not registered")
+
+	// ErrRotationUnsupported is returned when an integration cannot rotate credentials at runtime.
+	ErrRotationUnsupported = errors.New("integration does not support credential rotation")
+)
+
+// credentialFileSuffix is the extension of per-integration credential files.
+const credentialFileSuffix = ".json"
+
+// RotateCredentials re-authenticates the named integration with new credentials
+// without a restart. The adapter verifies the credentials before swapping them
+// in, so a failed rotation leaves it on its previous credentials.
+func (sm *SyncManager) RotateCredentials(ctx context.Context, name string, creds models.Credentials) error {
+	integration, err := sm.GetIntegration(name)
+	if err != nil {
+		return err
+	}
+
+	rotator, ok := integration.(models.CredentialRotator)
+	if !ok {
+		return ErrRotationUnsupported
+	}
+	if err := rotator.RotateCredentials(ctx, creds); err != nil {
+		return fmt.Errorf("credential rotation for %s failed: %w", name, err)
+	}
+	return nil
+}
+
+// CredentialWatcher polls a secrets directory and rotates integration credentials
+// when a file changes. Each "<integration>.json" file holds a models.Credentials
+// document; the file name selects the integration registered under that name.
+// A file whose rotation fails is retried on the next poll.
+type CredentialWatcher struct {
+	cfg     *config.CredentialWatchConfig
+	manager *SyncManager
+	logger  *zap.Logger
+
+	// applied records the content hash of the last successfully applied file per integration.
+	applied map[string][sha256.Size]byte
+}
+
+// NewCredentialWatcher creates a watcher that rotates credentials on the given SyncManager.
+func NewCredentialWatcher(cfg *config.CredentialWatchConfig, manager *SyncManager, logger *zap.Logger) *CredentialWatcher {
+	return &CredentialWatcher{
+		cfg:     cfg,
+		manager: manager,
+		logger:  logger,
+		applied: make(map[string][sha256.Size]byte),
+	}
+}
+
+// Run records the current files as the baseline, since they match the credentials
+// the adapters were initialized with, and then polls until ctx is canceled.
+func (w *CredentialWatcher) Run(ctx context.Context) {
+	w.scan(ctx, true)
+
+	ticker := time.NewTicker(w.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.scan(ctx, false)
+		}
+	}
+}
+
+// scan reads every credential file and rotates integrations whose file changed.
+// When baseline is true, files are only recorded.
+func (w *CredentialWatcher) scan(ctx context.Context, baseline bool) {
+	entries, err := os.ReadDir(w.cfg.Dir)
+	if err != nil {
+		w.logger.Warn("Unable to read credentials directory",
+			zap.String("dir", w.cfg.Dir), zap.Error(err))
+		return
+	}
+
+	for _, entry := range entries {
+		// Kubernetes secret mounts expose hidden "..data" symlinks; only plain names are considered.
+		name := entry.Name()
+		if entry.IsDir() || strings.HasPrefix(name, ".") || !strings.HasSuffix(name, credentialFileSuffix) {
+			continue
+		}
+		integration := strings.TrimSuffix(name, credentialFileSuffix)
+
+		raw, err := os.ReadFile(filepath.Join(w.cfg.Dir, name))
+		if err != nil {
+			w.logger.Warn("Unable to read credential file",
+				zap.String("integration", integration), zap.Error(err))
+			continue
+		}
+		sum := sha256.Sum256(raw)
+		if previous, seen := w.applied[integration]; seen && previous == sum {
+			continue
+		}
+		if baseline {
+			w.applied[integration] = sum
+			continue
+		}
+
+		var creds models.Credentials
+		if err := json.Unmarshal(raw, &creds); err != nil || creds.Secret == "" {
+			w.logger.Warn("Ignoring malformed credential file", zap.String("integration", integration))
+			continue
+		}
+
+		if err := w.manager.RotateCredentials(ctx, integration, creds); err != nil {
+			w.logger.Error("Credential rotation failed",
+				zap.String("integration", integration), zap.Error(err))
+			continue
+		}
+		w.applied[integration] = sum
+		w.logger.Info("Rotated integration credentials", zap.String("integration", integration))
+	}
+}
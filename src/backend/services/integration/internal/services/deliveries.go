@@ -0,0 +1,121 @@
+package services
+
+import (
+	// go1.21 - Guards the delivery log
+	"sync"
+	"time"
+)
+
+// recentDeliveriesCapacity is how many deliveries the in-memory log keeps.
+const recentDeliveriesCapacity = 200
+
+// Delivery outcomes recorded in DeliveryRecord.Outcome.
+const (
+	DeliveryDelivered = "delivered"
+	DeliveryFailed    = "failed"
+)
+
+// DeliveryRecord describes one delivery attempt to a provider.
+type DeliveryRecord struct {
+	// Integration is the integration the payload was sent through.
+	Integration string `json:"integration"`
+
+	// Outcome is "delivered" or "failed".
+	Outcome string `json:"outcome"`
+
+	// Error is the failure reason; empty when delivered.
+	Error string `json:"error,omitempty"`
+
+	// Messages is the number of payloads sent, more than one for batches.
+	Messages int `json:"messages"`
+
+	// LatencyMs is how long the attempt took, including bulkhead queueing.
+	LatencyMs int64 `json:"latencyMs"`
+
+	// At is when the attempt finished.
+	At time.Time `json:"at"`
+}
+
+// deliveryLog keeps the most recent delivery records in a ring buffer.
+type deliveryLog struct {
+	mu      sync.Mutex
+	records []DeliveryRecord
+	next    int
+	full    bool
+}
+
+// newDeliveryLog creates a log holding up to capacity records.
+func newDeliveryLog(capacity int) *deliveryLog {
+	return &deliveryLog{records: make([]DeliveryRecord, capacity)}
+}
+
+// record appends the outcome of one attempt, evicting the oldest when full,
+// and returns the record.
+func (l *deliveryLog) record(name string, messages int, started time.Time, err error) DeliveryRecord {
+	rec := DeliveryRecord{
+		Integration: name,
+		Outcome:     DeliveryDelivered,
+		Messages:    messages,
+		LatencyMs:   time.Since(started).Milliseconds(),
+		At:          time.Now().UTC(),
+	}
+	if err != nil {
+		rec.Outcome, rec.Error = DeliveryFailed, err.Error()
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.records[l.next] = rec
+	l.next = (l.next + 1) % len(l.records)
+	if l.next == 0 {
+		l.full = true
+	}
+	return rec
+}
+
+// recent returns up to limit records, newest first.
+func (l *deliveryLog) recent(limit int) []DeliveryRecord {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	n := l.next
+	if l.full {
+		n = len(l.records)
+	}
+	if limit <= 0 || limit > n {
+		limit = n
+	}
+	out := make([]DeliveryRecord, 0, limit)
+	for i := 1; i <= limit; i++ {
+		out = append(out, l.records[(l.next-i+len(l.records))%len(l.records)])
+	}
+	return out
+}
+
+// failures returns up to limit failed records for the named integration,
+// newest first.
+func (l *deliveryLog) failures(name string, limit int) []DeliveryRecord {
+	out := []DeliveryRecord{}
+	for _, rec := range l.recent(0) {
+		if limit > 0 && len(out) == limit {
+			break
+		}
+		if rec.Integration == name && rec.Outcome == DeliveryFailed {
+			out = append(out, rec)
+		}
+	}
+	return out
+}
+
+// RecentDeliveries returns up to limit of the most recent delivery attempts,
+// newest first. A limit of zero or less returns every retained record.
+func (sm *SyncManager) RecentDeliveries(limit int) []DeliveryRecord {
+	return sm.deliveries.recent(limit)
+}
+
+// RecentFailures returns up to limit of the named integration's most recent
+// failed deliveries still in the log, newest first. A limit of zero or less
+// returns every one.
+func (sm *SyncManager) RecentFailures(name string, limit int) []DeliveryRecord {
+	return sm.deliveries.failures(name, limit)
+}
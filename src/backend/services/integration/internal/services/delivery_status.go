@@ -0,0 +1,118 @@
+package services
+
+import (
+	// go1.21 - Ticket tracking
+	"errors"
+	"sync"
+	"time"
+
+	// Internal imports from the same module
+	"src/backend/services/integration/internal/models"
+)
+
+// recentTicketsCapacity is how many queued sends DeliveryStatus can report on;
+// older ones are forgotten.
+const recentTicketsCapacity = 10000
+
+// ErrDeliveryNotFound is returned by DeliveryStatus for a send it does not
+// know, either because the ID is wrong or because the send has been forgotten.
+var ErrDeliveryNotFound = errors.New("delivery not found")
+
+// DeliveryStatus is the current state of one queued send.
+type DeliveryStatus struct {
+	// DeliveryID identifies the send; see DispatchTicket.ID.
+	DeliveryID string `json:"deliveryId"`
+
+	// Integration is the integration the send was queued for.
+	Integration string `json:"integration"`
+
+	// State is MessageQueued while the send is queued or in flight, and
+	// MessageSent, MessageDeadLettered or MessageFailed once it completes.
+	State string `json:"state"`
+
+	// Result holds the identifiers of the sent message; nil until it is sent.
+	Result *models.SendResult `json:"result,omitempty"`
+
+	// Error is why the send failed or was set aside.
+	Error string `json:"error,omitempty"`
+
+	// AcceptedAt is when the send was queued.
+	AcceptedAt time.Time `json:"acceptedAt"`
+}
+
+// trackedTicket is a queued send remembered by ticketTracker.
+type trackedTicket struct {
+	ticket      *DispatchTicket
+	integration string
+	acceptedAt  time.Time
+}
+
+// ticketTracker remembers the most recent queued sends by ID, evicting the
+// oldest in ring-buffer order when full.
+type ticketTracker struct {
+	mu      sync.Mutex
+	tickets map[string]trackedTicket
+	order   []string
+	next    int
+}
+
+// newTicketTracker creates a tracker remembering up to capacity sends.
+func newTicketTracker(capacity int) *ticketTracker {
+	return &ticketTracker{
+		tickets: make(map[string]trackedTicket, capacity),
+		order:   make([]string, capacity),
+	}
+}
+
+// track remembers ticket, queued for the named integration.
+func (t *ticketTracker) track(name string, ticket *DispatchTicket) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if evicted := t.order[t.next]; evicted != "" {
+		delete(t.tickets, evicted)
+	}
+	t.order[t.next] = ticket.id
+	t.next = (t.next + 1) % len(t.order)
+	t.tickets[ticket.id] = trackedTicket{ticket: ticket, integration: name, acceptedAt: time.Now().UTC()}
+}
+
+// lookup returns the send with the given ID, if it is still remembered.
+func (t *ticketTracker) lookup(id string) (trackedTicket, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	tracked, ok := t.tickets[id]
+	return tracked, ok
+}
+
+// DeliveryStatus reports the state of a send queued through Dispatch or
+// DispatchPriority, so that callers that did not wait for it can poll for
+// its outcome. Only the most recent sends are remembered; ErrDeliveryNotFound
+// is returned for the others.
+func (sm *SyncManager) DeliveryStatus(id string) (DeliveryStatus, error) {
+	tracked, ok := sm.tickets.lookup(id)
+	if !ok {
+		return DeliveryStatus{}, ErrDeliveryNotFound
+	}
+
+	status := DeliveryStatus{
+		DeliveryID:  id,
+		Integration: tracked.integration,
+		State:       MessageQueued,
+		AcceptedAt:  tracked.acceptedAt,
+	}
+	select {
+	case <-tracked.ticket.Done():
+	default:
+		return status, nil
+	}
+
+	err := tracked.ticket.Err()
+	status.State = deliveryStage(err)
+	if err != nil {
+		status.Error = err.Error()
+		return status, nil
+	}
+	result := tracked.ticket.Result()
+	status.Result = &result
+	return status, nil
+}
@@ -0,0 +1,720 @@
+package services
+
+import (
+	// go1.21 - Worker lifetime and caller deadlines
+	"context"
+	// go1.21 - Encoding of spilled payloads
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	// go1.21 - Guards the queue and wakes workers
+	"sync"
+
+	// v1.16.0 - Queue memory metrics exposed on the default registry served at /metrics
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	// v1.24.0 - Structured logging of sends left queued at shutdown and panicking sends
+	"go.uber.org/zap"
+
+	// Internal imports from the same module
+	"src/backend/services/integration/internal/config"
+	"src/backend/services/integration/internal/models"
+	"src/backend/services/integration/internal/queue"
+)
+
+// Fallbacks applied when the dispatch configuration is absent.
+const (
+	defaultDispatchWorkers   = 32
+	defaultDispatchQueueSize = 1024
+)
+
+var (
+	// ErrDispatchQueueFull is returned when the send queue has no room; callers
+	// should back off and retry rather than block.
+	ErrDispatchQueueFull = errors.New("dispatch queue full")
+
+	// ErrDispatcherClosed is returned for sends enqueued after shutdown began.
+	ErrDispatcherClosed = errors.New("dispatcher closed")
+)
+
+// DispatchTicket tracks one queued send. The outcome is available once Done is closed.
+type DispatchTicket struct {
+	id     string
+	done   chan struct{}
+	err    error
+	result models.SendResult
+
+	// requestID is the ID of the API request the send originated from, if any.
+	requestID string
+}
+
+// newDispatchTicket creates a ticket for a send that has not completed yet,
+// originating from the API request identified by requestID.
+func newDispatchTicket(requestID string) *DispatchTicket {
+	return &DispatchTicket{id: newMessageID(), done: make(chan struct{}), requestID: requestID}
+}
+
+// ID identifies the send in lifecycle events; see SyncManager.SubscribeLifecycle.
+func (t *DispatchTicket) ID() string {
+	return t.id
+}
+
+// Done is closed when the send has completed.
+func (t *DispatchTicket) Done() <-chan struct{} {
+	return t.done
+}
+
+// Err returns the outcome of the send. It is only meaningful after Done is closed.
+func (t *DispatchTicket) Err() error {
+	return t.err
+}
+
+// Result returns the identifiers of the sent message, reported by adapters
+// implementing models.ResultSender. It is only meaningful after Done is closed,
+// and is empty when the send failed or the adapter reports none.
+func (t *DispatchTicket) Result() models.SendResult {
+	return t.result
+}
+
+// Wait blocks until the send completes or ctx ends, whichever is first. A
+// context error means the send is still in flight, not that it failed.
+func (t *DispatchTicket) Wait(ctx context.Context) error {
+	select {
+	case <-t.done:
+		return t.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// complete records the outcome and releases waiters.
+func (t *DispatchTicket) complete(err error) {
+	t.err = err
+	close(t.done)
+}
+
+// Priority orders queued sends. Higher-priority sends are delivered first and
+// are never spilled to disk.
+type Priority int
+
+const (
+	// PriorityLow marks bulk traffic that may be spilled first under memory pressure.
+	PriorityLow Priority = iota
+	// PriorityNormal is the default.
+	PriorityNormal
+	// PriorityHigh marks sends that must stay in memory.
+	PriorityHigh
+
+	numPriorities = int(PriorityHigh) + 1
+)
+
+// ParsePriority converts "low", "normal" or "high" to a Priority. An empty
+// string is PriorityNormal.
+func ParsePriority(s string) (Priority, error) {
+	switch strings.ToLower(s) {
+	case "low":
+		return PriorityLow, nil
+	case "", "normal":
+		return PriorityNormal, nil
+	case "high":
+		return PriorityHigh, nil
+	default:
+		return PriorityNormal, fmt.Errorf("unknown priority %q", s)
+	}
+}
+
+// Dispatch queue memory and spill metrics.
+var (
+	dispatchQueueBytes = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "integration_dispatch_queue_bytes",
+		Help: "Estimated payload bytes held in memory by queued sends.",
+	})
+
+	dispatchSpilled = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "integration_dispatch_spilled",
+		Help: "Queued sends currently spilled to disk.",
+	})
+
+	dispatchSpilledTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "integration_dispatch_spilled_total",
+		Help: "Queued sends spilled to disk since startup.",
+	})
+)
+
+// dispatchJob is a queued send. A spilled job has a nil payload and a spill
+// reference until it is paged back in.
+type dispatchJob struct {
+	integration string
+	payload     interface{}
+	ticket      *DispatchTicket
+	priority    Priority
+
+	// seq is the arrival order, used to keep paged-in jobs in place.
+	seq uint64
+
+	// size is the estimated memory held by payload.
+	size int64
+
+	spilled spillRef
+
+	// task, when set, makes this a dispatchTask rather than a send; see submit.
+	task *dispatchTask
+}
+
+// spilledPayload is the on-disk form of a spilled job's payload, tagged with
+// its type (see models.EncodePayload) so that it pages back in as the type it
+// was enqueued with.
+type spilledPayload struct {
+	Type    string          `json:"type,omitempty"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+// dispatchTask is work other than a single send that runs on the dispatch
+// workers, such as delivering a coalesced batch, so that it is bounded by the
+// same worker pool as sends.
+type dispatchTask struct {
+	// run performs the work with the dispatcher's context.
+	run func(ctx context.Context)
+
+	// abandon is called instead of run when shutdown gives up on the task.
+	abandon func(err error)
+}
+
+// Dispatcher decouples HTTP handlers from integration latency: handlers enqueue
+// sends without blocking and a fixed pool of workers delivers them through each
+// integration's bulkhead.
+//
+// The in-memory queue is bounded by count (QueueSize) and by estimated payload
+// bytes (MemoryBudget). With spilling enabled, sends that do not fit push the
+// oldest low- and normal-priority sends out to disk segments instead of being
+// rejected; those are paged back in, oldest first, as workers free capacity.
+type Dispatcher struct {
+	send func(ctx context.Context, name string, payload interface{}) error
+	ctx  context.Context
+
+	// lifecycle reports the stages of each send.
+	lifecycle lifecycleFunc
+
+	// logger returns where panicking sends are logged.
+	logger func() *zap.Logger
+
+	queueSize    int
+	memoryBudget int64
+
+	mu      sync.Mutex
+	cond    *sync.Cond
+	pending [numPriorities][]*dispatchJob
+	count   int
+	bytes   int64
+	seq     uint64
+	closed  bool
+
+	// tasks are waiting for a worker; they are taken before queued sends.
+	tasks []dispatchTask
+
+	// spill is nil when spilling is disabled; spilled holds spilled jobs in arrival order.
+	spill   *spillStore
+	spilled []*dispatchJob
+
+	wg sync.WaitGroup
+
+	// spillClose removes spill segments once, however often Shutdown is called.
+	spillClose sync.Once
+}
+
+// newDispatcher starts cfg.Workers workers that deliver jobs with send, reporting
+// each job's stages to lifecycle. Workers pass ctx to send, so cancelling it
+// aborts sends still waiting for a bulkhead slot. A send that panics fails
+// its job and is logged to logger. Spilled jobs are sealed with spillCipher
+// unless it is nil.
+func newDispatcher(ctx context.Context, cfg *config.DispatchConfig, send func(context.Context, string, interface{}) error, lifecycle lifecycleFunc, logger func() *zap.Logger, spillCipher queue.Cipher) (*Dispatcher, error) {
+	workers, queueSize := defaultDispatchWorkers, defaultDispatchQueueSize
+	var memoryBudget int64
+	if cfg != nil {
+		workers, queueSize, memoryBudget = cfg.Workers, cfg.QueueSize, cfg.MemoryBudget
+	}
+
+	d := &Dispatcher{
+		send:         send,
+		ctx:          ctx,
+		lifecycle:    lifecycle,
+		logger:       logger,
+		queueSize:    queueSize,
+		memoryBudget: memoryBudget,
+	}
+	d.cond = sync.NewCond(&d.mu)
+	if cfg.SpillEnabled() {
+		spill, err := newSpillStore(cfg.Spill.Dir, cfg.Spill.MaxBytes, spillCipher)
+		if err != nil {
+			return nil, err
+		}
+		d.spill = spill
+	}
+
+	for n := 0; n < workers; n++ {
+		d.wg.Add(1)
+		go d.worker()
+	}
+	return d, nil
+}
+
+// Enqueue queues a send at normal priority. See EnqueuePriority.
+func (d *Dispatcher) Enqueue(ctx context.Context, name string, payload interface{}) (*DispatchTicket, error) {
+	return d.EnqueuePriority(ctx, name, PriorityNormal, payload)
+}
+
+// EnqueuePriority queues a send and returns immediately with a ticket for its
+// outcome. It never blocks on delivery. When the queue is over its size or
+// memory budget and room cannot be made by spilling, it fails with
+// ErrDispatchQueueFull. The request ID carried by ctx, if any, is passed on to
+// the delivery; ctx does not bound it.
+func (d *Dispatcher) EnqueuePriority(ctx context.Context, name string, priority Priority, payload interface{}) (*DispatchTicket, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.closed {
+		return nil, ErrDispatcherClosed
+	}
+
+	d.seq++
+	job := &dispatchJob{
+		integration: name,
+		payload:     payload,
+		ticket:      newDispatchTicket(models.RequestIDFrom(ctx)),
+		priority:    priority,
+		seq:         d.seq,
+		size:        estimatePayloadSize(payload),
+	}
+
+	if !d.fitsLocked(job.size) {
+		if err := d.makeRoomLocked(job); err != nil {
+			return nil, err
+		}
+		if job.payload == nil {
+			// The new job itself went to disk.
+			d.lifecycle(name, job.ticket.id, MessageQueued, nil)
+			d.cond.Signal()
+			return job.ticket, nil
+		}
+	}
+
+	// Reported under d.mu, so that no worker reports a later stage first.
+	d.lifecycle(name, job.ticket.id, MessageQueued, nil)
+	d.pushLocked(job)
+	d.cond.Signal()
+	return job.ticket, nil
+}
+
+// submit queues task for the next free worker. Tasks are not counted against
+// the queue's size or memory budget; their producers bound them. It fails with
+// ErrDispatcherClosed once shutdown has begun.
+func (d *Dispatcher) submit(task dispatchTask) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.closed {
+		return ErrDispatcherClosed
+	}
+	d.tasks = append(d.tasks, task)
+	d.cond.Signal()
+	return nil
+}
+
+// Depth returns the number of sends waiting for a worker, including spilled ones.
+func (d *Dispatcher) Depth() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.count + len(d.spilled)
+}
+
+// Shutdown stops accepting sends and waits until queued sends, including
+// spilled ones, are delivered or ctx ends.
+func (d *Dispatcher) Shutdown(ctx context.Context) error {
+	d.mu.Lock()
+	d.closed = true
+	d.cond.Broadcast()
+	d.mu.Unlock()
+
+	drained := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		if d.spill != nil {
+			d.spillClose.Do(d.spill.close)
+		}
+		close(drained)
+	}()
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// abandon closes the dispatcher and takes the sends still waiting for a worker
+// out of the queue, including spilled ones, in arrival order, so that shutdown
+// can store them rather than drop them once it has stopped waiting (see
+// StoreQueuedSends). Sends being delivered are not affected. Spilled sends
+// that cannot be read back are failed, and waiting tasks are abandoned.
+func (d *Dispatcher) abandon() []*dispatchJob {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.closed = true
+
+	var jobs []*dispatchJob
+	for p := range d.pending {
+		jobs = append(jobs, d.pending[p]...)
+		d.pending[p] = nil
+	}
+	d.count, d.bytes = 0, 0
+	dispatchQueueBytes.Set(0)
+
+	for _, task := range d.tasks {
+		task.abandon(ErrDispatcherClosed)
+	}
+	d.tasks = nil
+
+	for _, job := range d.spilled {
+		if err := d.readSpilledLocked(job); err != nil {
+			err = fmt.Errorf("failed to page in spilled send: %w", err)
+			d.lifecycle(job.integration, job.ticket.id, MessageFailed, err)
+			job.ticket.complete(err)
+			continue
+		}
+		jobs = append(jobs, job)
+	}
+	d.spilled = nil
+	dispatchSpilled.Set(0)
+
+	// Idle workers see the closed, empty queue and exit.
+	d.cond.Broadcast()
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].seq < jobs[j].seq })
+	return jobs
+}
+
+// worker delivers jobs until the dispatcher is closed and drained.
+func (d *Dispatcher) worker() {
+	defer d.wg.Done()
+	for {
+		job, ok := d.next()
+		if !ok {
+			return
+		}
+		if job.task != nil {
+			job.task.run(d.ctx)
+			continue
+		}
+		ctx := withMessageID(models.WithSendResult(d.ctx, &job.ticket.result), job.ticket.id)
+		if job.ticket.requestID != "" {
+			ctx = models.WithRequestID(ctx, job.ticket.requestID)
+		}
+		err := sendRecovering(ctx, d.logger, "dispatch-worker", job.integration, func(ctx context.Context) error {
+			return d.send(ctx, job.integration, job.payload)
+		})
+		d.lifecycle(job.integration, job.ticket.id, deliveryStage(err), err)
+		job.ticket.complete(err)
+	}
+}
+
+// next blocks until a job is available, returning false once the dispatcher is
+// closed and empty. Tasks are taken first, then jobs highest priority first,
+// oldest first.
+func (d *Dispatcher) next() (*dispatchJob, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for {
+		if len(d.tasks) > 0 {
+			task := d.tasks[0]
+			d.tasks[0] = dispatchTask{}
+			d.tasks = d.tasks[1:]
+			return &dispatchJob{task: &task}, true
+		}
+		d.pageInLocked()
+		for p := numPriorities - 1; p >= 0; p-- {
+			if len(d.pending[p]) == 0 {
+				continue
+			}
+			job := d.pending[p][0]
+			d.pending[p][0] = nil
+			d.pending[p] = d.pending[p][1:]
+			d.count--
+			d.bytes -= job.size
+			dispatchQueueBytes.Set(float64(d.bytes))
+			return job, true
+		}
+		if d.closed && len(d.spilled) == 0 {
+			return nil, false
+		}
+		d.cond.Wait()
+	}
+}
+
+// fitsLocked reports whether a payload of size bytes fits in memory. d.mu must be held.
+func (d *Dispatcher) fitsLocked(size int64) bool {
+	if d.count >= d.queueSize {
+		return false
+	}
+	return d.memoryBudget <= 0 || d.bytes+size <= d.memoryBudget
+}
+
+// makeRoomLocked spills the oldest low-priority jobs, then normal-priority ones,
+// until job fits in memory. If spilling older jobs is not enough, job itself is
+// spilled when its priority allows. d.mu must be held.
+// Steps:
+//  1. Without a spill store, the queue is simply full.
+//  2. Spill older in-memory jobs, lowest priority and oldest first, but never
+//     ones of higher priority than job. Jobs whose payload cannot be persisted
+//     (see models.ErrPayloadNotPersistable) stay in memory.
+//  3. Spill job itself if it still does not fit.
+func (d *Dispatcher) makeRoomLocked(job *dispatchJob) error {
+	// 1. Spilling disabled.
+	if d.spill == nil {
+		return ErrDispatchQueueFull
+	}
+
+	// 2. Spill older jobs.
+	for p := PriorityLow; p <= job.priority && p < PriorityHigh; p++ {
+		q := d.pending[p]
+		for i := 0; i < len(q) && !d.fitsLocked(job.size); {
+			victim := q[i]
+			if err := d.spillLocked(victim); errors.Is(err, models.ErrPayloadNotPersistable) {
+				i++
+				continue
+			} else if err != nil {
+				d.pending[p] = q
+				return fmt.Errorf("%w: %v", ErrDispatchQueueFull, err)
+			}
+			copy(q[i:], q[i+1:])
+			q[len(q)-1] = nil
+			q = q[:len(q)-1]
+			d.count--
+			d.bytes -= victim.size
+		}
+		d.pending[p] = q
+	}
+	dispatchQueueBytes.Set(float64(d.bytes))
+	if d.fitsLocked(job.size) {
+		return nil
+	}
+
+	// 3. Spill the new job.
+	if job.priority == PriorityHigh {
+		return ErrDispatchQueueFull
+	}
+	if err := d.spillLocked(job); err != nil {
+		return fmt.Errorf("%w: %v", ErrDispatchQueueFull, err)
+	}
+	return nil
+}
+
+// spillLocked writes job's payload to disk, tagged with its type, and releases
+// it from memory. Payloads of types not registered with
+// models.RegisterPayloadType are left in place with
+// models.ErrPayloadNotPersistable. d.mu must be held.
+func (d *Dispatcher) spillLocked(job *dispatchJob) error {
+	tag, payload, err := models.EncodePayload(job.payload)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(spilledPayload{Type: tag, Payload: payload})
+	if err != nil {
+		return err
+	}
+	ref, err := d.spill.write(data)
+	if err != nil {
+		return err
+	}
+	job.payload = nil
+	job.spilled = ref
+
+	// Keep spilled jobs in arrival order so they page back in oldest first.
+	i := sort.Search(len(d.spilled), func(i int) bool { return d.spilled[i].seq > job.seq })
+	d.spilled = append(d.spilled, nil)
+	copy(d.spilled[i+1:], d.spilled[i:])
+	d.spilled[i] = job
+
+	dispatchSpilled.Set(float64(len(d.spilled)))
+	dispatchSpilledTotal.Inc()
+	return nil
+}
+
+// pageInLocked moves spilled jobs back into memory, oldest first, while they fit.
+// At least one job is paged in when memory is empty so an oversized payload cannot
+// stall the queue. d.mu must be held.
+func (d *Dispatcher) pageInLocked() {
+	for len(d.spilled) > 0 {
+		job := d.spilled[0]
+		if d.count > 0 && !d.fitsLocked(job.size) {
+			break
+		}
+		d.spilled[0] = nil
+		d.spilled = d.spilled[1:]
+		dispatchSpilled.Set(float64(len(d.spilled)))
+
+		if err := d.readSpilledLocked(job); err != nil {
+			err = fmt.Errorf("failed to page in spilled send: %w", err)
+			d.lifecycle(job.integration, job.ticket.id, MessageFailed, err)
+			job.ticket.complete(err)
+			continue
+		}
+		d.pushLocked(job)
+	}
+}
+
+// readSpilledLocked restores job's payload, as the type it was spilled with,
+// and clears its spill reference. d.mu must be held.
+func (d *Dispatcher) readSpilledLocked(job *dispatchJob) error {
+	data, err := d.spill.read(job.spilled)
+	job.spilled = spillRef{}
+	if err != nil {
+		return err
+	}
+	var spilled spilledPayload
+	if err := json.Unmarshal(data, &spilled); err != nil {
+		return err
+	}
+	job.payload, err = models.DecodePayload(spilled.Type, spilled.Payload)
+	return err
+}
+
+// pushLocked adds job to its priority queue in arrival order. d.mu must be held.
+func (d *Dispatcher) pushLocked(job *dispatchJob) {
+	q := d.pending[job.priority]
+	i := sort.Search(len(q), func(i int) bool { return q[i].seq > job.seq })
+	q = append(q, nil)
+	copy(q[i+1:], q[i:])
+	q[i] = job
+	d.pending[job.priority] = q
+
+	d.count++
+	d.bytes += job.size
+	dispatchQueueBytes.Set(float64(d.bytes))
+}
+
+// estimatePayloadSize approximates the memory held by a queued payload. Strings
+// and byte slices are measured directly; other payloads by their JSON encoding.
+func estimatePayloadSize(payload interface{}) int64 {
+	switch p := payload.(type) {
+	case nil:
+		return 0
+	case string:
+		return int64(len(p))
+	case []byte:
+		return int64(len(p))
+	default:
+		data, err := json.Marshal(p)
+		if err != nil {
+			return 0
+		}
+		return int64(len(data))
+	}
+}
+
+// Dispatch queues payload for delivery to the named integration at normal
+// priority. See DispatchPriority.
+func (sm *SyncManager) Dispatch(ctx context.Context, name string, payload interface{}) (*DispatchTicket, error) {
+	return sm.DispatchPriority(ctx, name, PriorityNormal, payload)
+}
+
+// DispatchPriority queues payload for delivery to the named integration without
+// waiting for it to be sent. Unknown integrations are rejected up front with
+// ErrIntegrationNotRegistered, and paused ones with ErrIntegrationPaused, so
+// callers can report them synchronously.
+// Payloads for batch-capable integrations are coalesced when batching is enabled.
+// The request ID carried by ctx (see models.WithRequestID) follows the payload
+// to its adapter, so the delivered message can be traced to the request.
+func (sm *SyncManager) DispatchPriority(ctx context.Context, name string, priority Priority, payload interface{}) (*DispatchTicket, error) {
+	sm.mu.RLock()
+	integration, exists := sm.integrations[name]
+	paused := sm.paused[name]
+	sm.mu.RUnlock()
+	if !exists {
+		return nil, ErrIntegrationNotRegistered
+	}
+	if paused {
+		return nil, ErrIntegrationPaused
+	}
+
+	ticket, err := sm.enqueue(ctx, name, integration, priority, payload)
+	if err == nil {
+		// Remembered so that callers that did not wait can poll DeliveryStatus.
+		sm.tickets.track(name, ticket)
+	}
+	return ticket, err
+}
+
+// enqueue hands payload to the batcher when the integration batches its sends,
+// and to the dispatch queue otherwise.
+func (sm *SyncManager) enqueue(ctx context.Context, name string, integration models.Integration, priority Priority, payload interface{}) (*DispatchTicket, error) {
+	if _, batchable := integration.(models.BatchSender); batchable {
+		if spec, enabled := sm.cfg.Batching.ForIntegration(name); enabled {
+			return sm.batcher.Add(ctx, name, spec, payload)
+		}
+	}
+	return sm.dispatcher.EnqueuePriority(ctx, name, priority, payload)
+}
+
+// DispatchDepth returns the number of sends waiting in the dispatch queue.
+func (sm *SyncManager) DispatchDepth() int {
+	return sm.dispatcher.Depth()
+}
+
+// DrainDispatch stops accepting new sends and waits for open batches and queued
+// sends to finish or ctx to end. It is called during shutdown after the HTTP
+// server stops; StoreQueuedSends then deals with the sends it left queued.
+func (sm *SyncManager) DrainDispatch(ctx context.Context) error {
+	if err := sm.batcher.Shutdown(ctx); err != nil {
+		return err
+	}
+	return sm.dispatcher.Shutdown(ctx)
+}
+
+// StoreQueuedSends takes the sends DrainDispatch left queued out of the
+// dispatch queue, so that shutdown does not drop them silently. With
+// store-and-forward attached they are stored for replay under their own send
+// and request IDs, to be delivered after the restart; otherwise, or when ctx
+// ends first, they are failed. Either way their tickets complete, with
+// ErrStoredForReplay or ErrDispatcherClosed. An error is returned when any
+// send was dropped.
+func (sm *SyncManager) StoreQueuedSends(ctx context.Context) error {
+	jobs := sm.dispatcher.abandon()
+	if len(jobs) == 0 {
+		return nil
+	}
+
+	sm.mu.RLock()
+	sf := sm.storeAndForward
+	sm.mu.RUnlock()
+
+	var stored, dropped int
+	for _, job := range jobs {
+		err := ErrDispatcherClosed
+		if sf != nil && ctx.Err() == nil {
+			storeCtx := withMessageID(ctx, job.ticket.id)
+			if job.ticket.requestID != "" {
+				storeCtx = models.WithRequestID(storeCtx, job.ticket.requestID)
+			}
+			if storeErr := sf.Store(storeCtx, job.integration, job.payload); storeErr != nil {
+				err = fmt.Errorf("%w: %v", ErrDispatcherClosed, storeErr)
+			} else {
+				err = ErrStoredForReplay
+			}
+		}
+		if errors.Is(err, ErrStoredForReplay) {
+			stored++
+		} else {
+			dropped++
+		}
+		sm.publishLifecycle(job.integration, job.ticket.id, deliveryStage(err), err)
+		job.ticket.complete(err)
+	}
+
+	sm.Logger().Warn("Sends still queued at shutdown",
+		zap.Int("stored", stored),
+		zap.Int("dropped", dropped))
+	if dropped > 0 {
+		return fmt.Errorf("%d queued sends dropped, %d stored for replay", dropped, stored)
+	}
+	return nil
+}
@@ -0,0 +1,232 @@
+package services
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"src/backend/services/integration/internal/config"
+	"src/backend/services/integration/internal/models"
+)
+
+// spillTestPayload is a typed payload, as adapters accept, registered so that
+// it survives a spill.
+type spillTestPayload struct {
+	Subject string
+	To      []string
+	Data    []byte
+}
+
+func init() {
+	models.RegisterPayloadType("services.spillTest", spillTestPayload{})
+}
+
+// gatedSender holds the first send until release is called and records the
+// payloads of the others.
+type gatedSender struct {
+	started chan struct{}
+	gate    chan struct{}
+
+	mu   sync.Mutex
+	sent []interface{}
+}
+
+func newGatedSender() *gatedSender {
+	return &gatedSender{started: make(chan struct{}), gate: make(chan struct{})}
+}
+
+func (g *gatedSender) send(ctx context.Context, name string, payload interface{}) error {
+	if payload == "blocker" {
+		close(g.started)
+		<-g.gate
+		return nil
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.sent = append(g.sent, payload)
+	return nil
+}
+
+// newSpillTestDispatcher starts a dispatcher with one worker, room for one
+// queued send and spilling enabled, and occupies the worker with a blocked send.
+func newSpillTestDispatcher(t *testing.T, g *gatedSender) *Dispatcher {
+	t.Helper()
+	cfg := &config.DispatchConfig{
+		Workers:   1,
+		QueueSize: 1,
+		Spill:     &config.DispatchSpillConfig{Enabled: true, Dir: t.TempDir(), MaxBytes: 1 << 20},
+	}
+	lifecycle := func(name, id, stage string, err error) {}
+	d, err := newDispatcher(context.Background(), cfg, g.send, lifecycle, zap.NewNop, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = d.Shutdown(context.Background()) })
+	if _, err := d.Enqueue(context.Background(), "mock", "blocker"); err != nil {
+		t.Fatal(err)
+	}
+	<-g.started
+	return d
+}
+
+// TestDispatcherSpillKeepsPayloadTypes spills typed payloads to disk and pages
+// them back in, and checks that each send receives the payload as enqueued.
+func TestDispatcherSpillKeepsPayloadTypes(t *testing.T) {
+	g := newGatedSender()
+	d := newSpillTestDispatcher(t, g)
+
+	payloads := []interface{}{
+		&spillTestPayload{Subject: "pointer", To: []string{"a@example.com"}, Data: []byte{0, 1, 2}},
+		spillTestPayload{Subject: "value", To: []string{"b@example.com"}},
+		[]byte("raw bytes"),
+		map[string]interface{}{"text": "plain JSON"},
+		"last",
+	}
+	var tickets []*DispatchTicket
+	for _, payload := range payloads {
+		ticket, err := d.Enqueue(context.Background(), "mock", payload)
+		if err != nil {
+			t.Fatalf("enqueue %T: %v", payload, err)
+		}
+		tickets = append(tickets, ticket)
+	}
+
+	d.mu.Lock()
+	spilled := len(d.spilled)
+	d.mu.Unlock()
+	if spilled != len(payloads)-1 {
+		t.Fatalf("spilled %d sends, want %d", spilled, len(payloads)-1)
+	}
+
+	close(g.gate)
+	for _, ticket := range tickets {
+		if err := ticket.Wait(context.Background()); err != nil {
+			t.Fatalf("send failed: %v", err)
+		}
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if len(g.sent) != len(payloads) {
+		t.Fatalf("delivered %d sends, want %d", len(g.sent), len(payloads))
+	}
+	for _, want := range payloads {
+		found := false
+		for _, got := range g.sent {
+			if reflect.DeepEqual(got, want) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("payload %#v (%T) was not delivered as enqueued; delivered %#v", want, want, g.sent)
+		}
+	}
+}
+
+// TestDispatcherKeepsUnpersistablePayloadsInMemory checks that a payload of an
+// unregistered type, such as one carrying a context, is never spilled: the
+// newer send is spilled instead.
+func TestDispatcherKeepsUnpersistablePayloadsInMemory(t *testing.T) {
+	g := newGatedSender()
+	d := newSpillTestDispatcher(t, g)
+
+	container := struct {
+		Ctx     context.Context
+		Payload *spillTestPayload
+	}{context.Background(), &spillTestPayload{Subject: "with context"}}
+	first, err := d.Enqueue(context.Background(), "mock", container)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := d.Enqueue(context.Background(), "mock", "spillable")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d.mu.Lock()
+	spilled := len(d.spilled)
+	d.mu.Unlock()
+	if spilled != 1 {
+		t.Fatalf("spilled %d sends, want 1", spilled)
+	}
+
+	close(g.gate)
+	for _, ticket := range []*DispatchTicket{first, second} {
+		if err := ticket.Wait(context.Background()); err != nil {
+			t.Fatalf("send failed: %v", err)
+		}
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if len(g.sent) != 2 || !reflect.DeepEqual(g.sent[0], container) {
+		t.Errorf("delivered %#v, want the context container first", g.sent)
+	}
+}
+
+// newBenchDispatcher starts a dispatcher whose sends take latency, as a mock
+// provider would.
+func newBenchDispatcher(b *testing.B, cfg *config.DispatchConfig, latency time.Duration) *Dispatcher {
+	b.Helper()
+	send := func(ctx context.Context, name string, payload interface{}) error {
+		time.Sleep(latency)
+		return nil
+	}
+	lifecycle := func(name, id, stage string, err error) {}
+	d, err := newDispatcher(context.Background(), cfg, send, lifecycle, zap.NewNop, nil)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Cleanup(func() { _ = d.Shutdown(context.Background()) })
+	return d
+}
+
+// BenchmarkDispatcherEnqueueWait measures a send through the pipeline, from
+// enqueue until its ticket completes.
+func BenchmarkDispatcherEnqueueWait(b *testing.B) {
+	d := newBenchDispatcher(b, nil, time.Millisecond)
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			ticket, err := d.Enqueue(context.Background(), "mock", "loadgen message")
+			if err != nil {
+				b.Error(err)
+				return
+			}
+			<-ticket.Done()
+			if err := ticket.Err(); err != nil {
+				b.Error(err)
+				return
+			}
+		}
+	})
+}
+
+// BenchmarkDispatcherSpill measures enqueueing past the in-memory queue, so
+// that sends are spilled to disk segments and paged back in.
+func BenchmarkDispatcherSpill(b *testing.B) {
+	cfg := &config.DispatchConfig{
+		Workers:   4,
+		QueueSize: 16,
+		Spill:     &config.DispatchSpillConfig{Enabled: true, Dir: b.TempDir(), MaxBytes: 1 << 30},
+	}
+	d := newBenchDispatcher(b, cfg, 0)
+	tickets := make([]*DispatchTicket, 0, b.N)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ticket, err := d.EnqueuePriority(context.Background(), "mock", PriorityLow, "loadgen message")
+		if err != nil {
+			b.Fatal(err)
+		}
+		tickets = append(tickets, ticket)
+	}
+	for _, ticket := range tickets {
+		<-ticket.Done()
+	}
+}
@@ -0,0 +1,188 @@
+package services
+
+import (
+	// go1.21 - Concurrent delivery and sentinel errors
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	// Internal models for send results and reverts
+	"src/backend/services/integration/internal/models"
+)
+
+var (
+	// ErrFanOutAborted is returned by an all-or-nothing fan-out send in which a
+	// target failed, after the targets already delivered have been reverted.
+	ErrFanOutAborted = errors.New("fan-out send aborted")
+
+	// ErrCircuitOpen is reported for all-or-nothing targets whose circuit is
+	// open, which would fail the send.
+	ErrCircuitOpen = errors.New("integration circuit is open")
+)
+
+// Outcomes recorded in FanOutResult.Status.
+const (
+	FanOutDelivered = "delivered"
+	FanOutStored    = "stored"
+	FanOutFailed    = "failed"
+	FanOutSkipped   = "skipped"
+	FanOutReverted  = "reverted"
+)
+
+// FanOutTarget is one integration a fan-out send delivers to, with the payload
+// for it, since each integration expects its own payload shape.
+type FanOutTarget struct {
+	Integration string
+	Payload     interface{}
+}
+
+// FanOutResult is the outcome of a fan-out send for one target.
+type FanOutResult struct {
+	// Integration is the target's integration name.
+	Integration string `json:"integration"`
+
+	// Status is one of the FanOut outcomes.
+	Status string `json:"status"`
+
+	// Result identifies the delivered message, for adapters that report one.
+	Result *models.SendResult `json:"result,omitempty"`
+
+	// Error is why the target failed, or why a delivered target could not be
+	// reverted.
+	Error string `json:"error,omitempty"`
+}
+
+// FanOutCoordinator delivers one request to several integrations, such as
+// notifying Slack and creating a Jira issue together.
+//
+// Best-effort sends deliver to every target concurrently, through the same
+// path as single sends, and report each outcome. All-or-nothing sends first
+// check that every target is registered, running and has its circuit closed,
+// then deliver in order without store-and-forward; when a target fails, the
+// remaining targets are skipped and the delivered ones reverted through
+// models.SendReverter. Providers offer no transactions, so a target that
+// cannot be reverted stays delivered and is reported with the reason.
+type FanOutCoordinator struct {
+	manager *SyncManager
+}
+
+// NewFanOutCoordinator creates a coordinator sending through manager.
+func NewFanOutCoordinator(manager *SyncManager) *FanOutCoordinator {
+	return &FanOutCoordinator{manager: manager}
+}
+
+// Send delivers to every target, returning their results in target order. An
+// all-or-nothing send in which any target failed returns ErrFanOutAborted.
+func (c *FanOutCoordinator) Send(ctx context.Context, targets []FanOutTarget, allOrNothing bool) ([]FanOutResult, error) {
+	if allOrNothing {
+		return c.sendAll(ctx, targets)
+	}
+
+	results := make([]FanOutResult, len(targets))
+	var wg sync.WaitGroup
+	for i, target := range targets {
+		wg.Add(1)
+		go func(i int, target FanOutTarget) {
+			defer wg.Done()
+			var result models.SendResult
+			err := c.manager.Send(models.WithSendResult(ctx, &result), target.Integration, target.Payload)
+			results[i] = outcome(target.Integration, result, err)
+		}(i, target)
+	}
+	wg.Wait()
+	return results, nil
+}
+
+// sendAll delivers to the targets in order, reverting delivered targets when
+// one fails.
+func (c *FanOutCoordinator) sendAll(ctx context.Context, targets []FanOutTarget) ([]FanOutResult, error) {
+	results := make([]FanOutResult, len(targets))
+	for i, target := range targets {
+		results[i] = FanOutResult{Integration: target.Integration, Status: FanOutSkipped}
+	}
+
+	// 1. Refuse the request up front when a target cannot accept it.
+	for i, target := range targets {
+		if err := c.ready(target.Integration); err != nil {
+			results[i].Status, results[i].Error = FanOutFailed, err.Error()
+			return results, fmt.Errorf("%w: %s: %w", ErrFanOutAborted, target.Integration, err)
+		}
+	}
+
+	// 2. Deliver in order, stopping at the first failure.
+	failed := -1
+	var failure error
+	for i, target := range targets {
+		var result models.SendResult
+		err := c.manager.deliver(models.WithSendResult(ctx, &result), target.Integration, target.Payload)
+		results[i] = outcome(target.Integration, result, err)
+		if err != nil {
+			failed, failure = i, err
+			break
+		}
+	}
+	if failed < 0 {
+		return results, nil
+	}
+
+	// 3. Revert what was delivered, newest first.
+	for i := failed - 1; i >= 0; i-- {
+		c.revert(ctx, targets[i], &results[i])
+	}
+	return results, fmt.Errorf("%w: %s: %w", ErrFanOutAborted, targets[failed].Integration, failure)
+}
+
+// ready reports why the named integration cannot take part in an
+// all-or-nothing send, if it cannot.
+func (c *FanOutCoordinator) ready(name string) error {
+	integration, err := c.manager.GetIntegration(name)
+	if err != nil {
+		return err
+	}
+	if c.manager.Paused(name) {
+		return ErrIntegrationPaused
+	}
+	if circuitOpen(integration) {
+		return ErrCircuitOpen
+	}
+	return nil
+}
+
+// revert undoes a delivered target, recording the outcome in result.
+func (c *FanOutCoordinator) revert(ctx context.Context, target FanOutTarget, result *FanOutResult) {
+	integration, err := c.manager.GetIntegration(target.Integration)
+	if err == nil {
+		reverter, ok := integration.(models.SendReverter)
+		if !ok {
+			err = models.ErrNotImplemented
+		} else {
+			var sent models.SendResult
+			if result.Result != nil {
+				sent = *result.Result
+			}
+			err = reverter.RevertSend(ctx, target.Payload, sent)
+		}
+	}
+	if err != nil {
+		result.Error = "not reverted: " + err.Error()
+		return
+	}
+	result.Status = FanOutReverted
+}
+
+// outcome converts a send's result and error to a FanOutResult.
+func outcome(name string, result models.SendResult, err error) FanOutResult {
+	switch {
+	case err == nil:
+		r := FanOutResult{Integration: name, Status: FanOutDelivered}
+		if result != (models.SendResult{}) {
+			r.Result = &result
+		}
+		return r
+	case errors.Is(err, ErrStoredForReplay):
+		return FanOutResult{Integration: name, Status: FanOutStored}
+	default:
+		return FanOutResult{Integration: name, Status: FanOutFailed, Error: err.Error()}
+	}
+}
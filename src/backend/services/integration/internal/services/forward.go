@@ -0,0 +1,345 @@
+package services
+
+import (
+	// go1.21 - Replay loop lifetime and spool cipher calls
+	"context"
+	// go1.21 - Encoding of stored messages
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	// go1.21 - Unique, time-ordered record IDs
+	"sync/atomic"
+	"time"
+
+	// v1.24.0 - Structured logging of diversion and replay
+	"go.uber.org/zap"
+
+	// Internal imports from the same module
+	"src/backend/services/integration/internal/config"
+	"src/backend/services/integration/internal/models"
+	"src/backend/services/integration/internal/queue"
+)
+
+// storeAndForwardPrefix marks spool records written by store-and-forward.
+const storeAndForwardPrefix = "sf"
+
+// ErrStoredForReplay is returned when a send was not delivered because the
+// integration's circuit is open or its retry budget is spent, but the message
+// was stored durably and will be
+// replayed once the integration recovers. Callers should treat it as accepted.
+var ErrStoredForReplay = errors.New("message stored for replay")
+
+// storedMessage is the spool record for a diverted send.
+type storedMessage struct {
+	Integration string          `json:"integration"`
+	Payload     json.RawMessage `json:"payload"`
+	EnqueuedAt  time.Time       `json:"enqueuedAt"`
+
+	// PayloadType is the type tag of Payload (see models.EncodePayload); records
+	// without one replay their payload as plain JSON.
+	PayloadType string `json:"payloadType,omitempty"`
+
+	// MessageID is the ID of the queued send, if it was one, so that replays
+	// are reported under the same ID.
+	MessageID string `json:"messageId,omitempty"`
+
+	// RequestID is the X-Request-ID of the API request the send came from, so
+	// that replays still carry it to the provider.
+	RequestID string `json:"requestId,omitempty"`
+}
+
+// StoreAndForward keeps an integration outage from surfacing as errors: while an
+// integration's circuit is open its messages are written to the spool, and a
+// replay loop delivers them in order once the integration is healthy again.
+type StoreAndForward struct {
+	cfg     *config.StoreAndForwardConfig
+	spool   *queue.Spool
+	manager *SyncManager
+	logger  *zap.Logger
+
+	// seq disambiguates records stored within the same nanosecond.
+	seq atomic.Uint64
+}
+
+// NewStoreAndForward creates a store-and-forward stage backed by spool. Attach it
+// with SyncManager.SetStoreAndForward and start replay with Run.
+func NewStoreAndForward(cfg *config.StoreAndForwardConfig, spool *queue.Spool, manager *SyncManager, logger *zap.Logger) *StoreAndForward {
+	return &StoreAndForward{
+		cfg:     cfg,
+		spool:   spool,
+		manager: manager,
+		logger:  logger,
+	}
+}
+
+// Store durably records payload for later delivery to the named integration.
+// Record IDs sort by arrival time, so replay preserves send order.
+func (sf *StoreAndForward) Store(ctx context.Context, name string, payload interface{}) error {
+	payloadType, raw, err := models.EncodePayload(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode message for %s: %w", name, err)
+	}
+	data, err := json.Marshal(storedMessage{
+		Integration: name,
+		Payload:     raw,
+		PayloadType: payloadType,
+		EnqueuedAt:  time.Now().UTC(),
+		MessageID:   messageIDFrom(ctx),
+		RequestID:   models.RequestIDFrom(ctx),
+	})
+	if err != nil {
+		return err
+	}
+
+	id := fmt.Sprintf("%s-%019d-%06d-%s", storeAndForwardPrefix, time.Now().UnixNano(), sf.seq.Add(1)%1000000, name)
+	if err := sf.spool.Put(ctx, id, data); err != nil {
+		return fmt.Errorf("failed to store message for %s: %w", name, err)
+	}
+	return nil
+}
+
+// Run replays stored messages every ReplayInterval until ctx is canceled.
+func (sf *StoreAndForward) Run(ctx context.Context) {
+	ticker := time.NewTicker(sf.cfg.ReplayInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			sf.replay(ctx)
+		}
+	}
+}
+
+// replay delivers up to ReplayBatchSize stored messages per healthy integration.
+// Steps:
+//  1. Group stored record IDs by integration, keeping arrival order.
+//  2. Skip integrations that are unknown, still have an open circuit, or report
+//     themselves disconnected.
+//  3. Deliver records oldest first, deleting each once sent. The first failure
+//     stops that integration for this round so ordering is preserved.
+func (sf *StoreAndForward) replay(ctx context.Context) {
+	// 1. Group records by integration.
+	pending, err := sf.pending()
+	if err != nil {
+		sf.logger.Warn("Unable to list stored messages", zap.Error(err))
+		return
+	}
+
+	for name, records := range pending {
+		// 2. Only replay into integrations that look healthy again.
+		if !sf.manager.healthy(name) {
+			continue
+		}
+
+		// 3. Deliver oldest first.
+		if len(records) > sf.cfg.ReplayBatchSize {
+			records = records[:sf.cfg.ReplayBatchSize]
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		sf.replayIntegration(ctx, name, records)
+	}
+}
+
+// pending returns stored record IDs grouped by integration, oldest first.
+func (sf *StoreAndForward) pending() (map[string][]string, error) {
+	ids, err := sf.spool.List()
+	if err != nil {
+		return nil, err
+	}
+	pending := make(map[string][]string)
+	for _, id := range ids {
+		parts := strings.SplitN(id, "-", 4)
+		if len(parts) != 4 || parts[0] != storeAndForwardPrefix {
+			continue
+		}
+		pending[parts[3]] = append(pending[parts[3]], id)
+	}
+	return pending, nil
+}
+
+// replayIntegration delivers records to one integration in order, stopping at
+// the first failure, and returns how many were delivered.
+func (sf *StoreAndForward) replayIntegration(ctx context.Context, name string, records []string) int {
+	replayed := 0
+	for _, id := range records {
+		if ctx.Err() != nil {
+			break
+		}
+		if err := sf.replayRecord(ctx, name, id); err != nil {
+			sf.logger.Warn("Replay of stored message failed; retrying next interval",
+				zap.String("integration", name), zap.String("record", id), zap.Error(err))
+			break
+		}
+		replayed++
+	}
+	if replayed > 0 {
+		sf.logger.Info("Replayed stored messages",
+			zap.String("integration", name), zap.Int("count", replayed))
+	}
+	return replayed
+}
+
+// ReplayNow delivers the named integration's stored messages immediately,
+// without waiting for the replay interval or the health check, and returns how
+// many were delivered. Operators use it once they know the provider is back.
+// Paused integrations are not replayed into.
+func (sf *StoreAndForward) ReplayNow(ctx context.Context, name string) (int, error) {
+	sf.manager.mu.RLock()
+	_, exists := sf.manager.integrations[name]
+	paused := sf.manager.paused[name]
+	sf.manager.mu.RUnlock()
+	if !exists {
+		return 0, ErrIntegrationNotRegistered
+	}
+	if paused {
+		return 0, ErrIntegrationPaused
+	}
+	pending, err := sf.pending()
+	if err != nil {
+		return 0, err
+	}
+	return sf.replayIntegration(ctx, name, pending[name]), nil
+}
+
+// StoredMessage summarizes a message waiting in the spool for replay.
+type StoredMessage struct {
+	ID          string    `json:"id"`
+	Integration string    `json:"integration"`
+	EnqueuedAt  time.Time `json:"enqueuedAt"`
+}
+
+// Stored returns the total number of stored messages and summaries of up to
+// limit of them, oldest first. Records that cannot be read are skipped.
+func (sf *StoreAndForward) Stored(ctx context.Context, limit int) (int, []StoredMessage, error) {
+	ids, err := sf.spool.List()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	total := 0
+	var out []StoredMessage
+	for _, id := range ids {
+		parts := strings.SplitN(id, "-", 4)
+		if len(parts) != 4 || parts[0] != storeAndForwardPrefix {
+			continue
+		}
+		total++
+		if len(out) >= limit {
+			continue
+		}
+		data, err := sf.spool.Get(ctx, id)
+		if err != nil {
+			continue
+		}
+		var msg storedMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			continue
+		}
+		out = append(out, StoredMessage{ID: id, Integration: parts[3], EnqueuedAt: msg.EnqueuedAt})
+	}
+	return total, out, nil
+}
+
+// ErrStoredMessageNotFound is returned by Discard for an ID that does not name
+// a stored message.
+var ErrStoredMessageNotFound = errors.New("stored message not found")
+
+// Discard deletes the stored message id without delivering it, e.g. a message
+// that will never be accepted by its provider.
+func (sf *StoreAndForward) Discard(id string) error {
+	if !strings.HasPrefix(id, storeAndForwardPrefix+"-") {
+		return ErrStoredMessageNotFound
+	}
+	ids, err := sf.spool.List()
+	if err != nil {
+		return err
+	}
+	for _, stored := range ids {
+		if stored == id {
+			return sf.spool.Delete(id)
+		}
+	}
+	return ErrStoredMessageNotFound
+}
+
+// StoreAndForward returns the attached store-and-forward stage, or nil.
+func (sm *SyncManager) StoreAndForward() *StoreAndForward {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	return sm.storeAndForward
+}
+
+// replayRecord sends one stored message and removes it from the spool.
+func (sf *StoreAndForward) replayRecord(ctx context.Context, name, id string) error {
+	data, err := sf.spool.Get(ctx, id)
+	if errors.Is(err, queue.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var msg storedMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		// An unreadable record can never be delivered; drop it rather than block the queue.
+		sf.logger.Error("Discarding malformed stored message", zap.String("record", id), zap.Error(err))
+		return sf.spool.Delete(id)
+	}
+	payload, err := models.DecodePayload(msg.PayloadType, msg.Payload)
+	if err != nil {
+		sf.logger.Error("Discarding malformed stored message", zap.String("record", id), zap.Error(err))
+		return sf.spool.Delete(id)
+	}
+
+	// Replays are reported under the send's ID, or the record's for messages
+	// that were not queued sends.
+	messageID := msg.MessageID
+	if messageID == "" {
+		messageID = id
+	}
+	ctx = withMessageID(ctx, messageID)
+	if msg.RequestID != "" {
+		ctx = models.WithRequestID(ctx, msg.RequestID)
+	}
+	sf.manager.publishLifecycle(name, messageID, MessageRetried, nil)
+	if err := sf.manager.deliver(ctx, name, payload); err != nil {
+		sf.manager.publishLifecycle(name, messageID, MessageFailed, err)
+		return err
+	}
+	sf.manager.publishLifecycle(name, messageID, MessageSent, nil)
+	return sf.spool.Delete(id)
+}
+
+// SetStoreAndForward attaches the store-and-forward stage. Once set, sends to an
+// integration whose circuit is open are stored for replay instead of failing.
+func (sm *SyncManager) SetStoreAndForward(sf *StoreAndForward) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.storeAndForward = sf
+}
+
+// circuitOpen reports whether the named integration currently rejects calls.
+// Integrations without a circuit breaker are never considered open.
+func circuitOpen(integration models.Integration) bool {
+	reporter, ok := integration.(models.CircuitReporter)
+	return ok && reporter.CircuitOpen()
+}
+
+// healthy reports whether the named integration is registered, not paused, has
+// a closed circuit, and reports itself connected.
+func (sm *SyncManager) healthy(name string) bool {
+	sm.mu.RLock()
+	integration, exists := sm.integrations[name]
+	paused := sm.paused[name]
+	sm.mu.RUnlock()
+	if !exists || paused || circuitOpen(integration) {
+		return false
+	}
+	status, ok, err := sm.checkStatus(name, integration, sm.cfg.StatusChecks.ForIntegration(name))
+	return ok && err == nil && status.Connected
+}
@@ -0,0 +1,217 @@
+package services
+
+import (
+	// go1.21 - Monitor lifetime
+	"context"
+	// go1.21 - Guards the readiness snapshot
+	"sync"
+	"time"
+
+	// v1.16.0 - Health metrics exposed on the default registry served at /metrics
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	// v1.24.0 - Structured logging of health transitions
+	"go.uber.org/zap"
+
+	// Internal configuration for the check interval and readiness policy
+	"src/backend/services/integration/internal/config"
+)
+
+// Fallbacks applied when the health monitor configuration is absent.
+const (
+	defaultHealthInterval  = 15 * time.Second
+	defaultReadinessPolicy = config.ReadinessPolicyAny
+)
+
+// Health monitor metrics.
+var (
+	integrationUp = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "integration_up",
+		Help: "Whether the integration passed its last health check (1) or not (0).",
+	}, []string{"integration"})
+
+	integrationHealthTransitions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "integration_health_transitions_total",
+		Help: "Integration health state changes, by the state entered (up, down).",
+	}, []string{"integration", "state"})
+
+	serviceReady = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "integration_service_ready",
+		Help: "Whether the service reports ready on /readyz (1) or not (0).",
+	})
+)
+
+// Readiness is a snapshot of the service's readiness as last computed by the
+// health monitor.
+type Readiness struct {
+	// Ready is true when the service should receive traffic.
+	Ready bool `json:"ready"`
+
+	// CheckedAt is when the last health check completed; zero before the first.
+	CheckedAt time.Time `json:"checkedAt"`
+
+	// Integrations maps each integration to whether it passed the last check.
+	Integrations map[string]bool `json:"integrations"`
+
+	// Reason explains why the service is not ready; empty when ready.
+	Reason string `json:"reason,omitempty"`
+}
+
+// HealthMonitor checks every integration on an interval, keeps the readiness
+// state served by /readyz, and records metrics and logs when an integration's
+// health changes. The service is not ready until the first check completes.
+type HealthMonitor struct {
+	manager  *SyncManager
+	logger   *zap.Logger
+	interval time.Duration
+	policy   string
+
+	mu    sync.RWMutex
+	state Readiness
+
+	// draining is set by Drain once shutdown begins.
+	draining bool
+}
+
+// NewHealthMonitor creates a monitor for the integrations registered on manager.
+// Attach it with SyncManager.SetHealthMonitor and start it with Run.
+func NewHealthMonitor(cfg *config.HealthMonitorConfig, manager *SyncManager, logger *zap.Logger) *HealthMonitor {
+	interval, policy := defaultHealthInterval, defaultReadinessPolicy
+	if cfg != nil {
+		interval, policy = cfg.Interval, cfg.ReadinessPolicy
+	}
+	return &HealthMonitor{
+		manager:  manager,
+		logger:   logger,
+		interval: interval,
+		policy:   policy,
+		state:    Readiness{Reason: "initial health check pending"},
+	}
+}
+
+// Run checks immediately and then every interval until ctx is canceled.
+func (m *HealthMonitor) Run(ctx context.Context) {
+	m.check()
+
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.check()
+		}
+	}
+}
+
+// Readiness returns the current readiness snapshot. Once Drain is called the
+// service is reported not ready, whatever the last check found.
+func (m *HealthMonitor) Readiness() Readiness {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	state := m.state
+	if m.draining {
+		state.Ready, state.Reason = false, "shutting down"
+	}
+	return state
+}
+
+// Drain marks the service as shutting down, so that /readyz reports 503 and
+// load balancers stop routing new requests to it while in-flight requests
+// finish. It cannot be undone.
+func (m *HealthMonitor) Drain() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.draining {
+		return
+	}
+	m.draining = true
+	serviceReady.Set(0)
+	m.logger.Info("Service draining; reporting not ready")
+}
+
+// check runs one round of status checks and publishes the result.
+// Steps:
+//  1. Collect statuses; checks run concurrently with per-integration timeouts.
+//  2. Record per-integration health and log transitions.
+//  3. Apply the readiness policy and publish the new snapshot.
+func (m *HealthMonitor) check() {
+	// 1. An adapter error still yields statuses for the others; the failing
+	// integration is reported disconnected.
+	statuses, err := m.manager.GetStatus()
+	if err != nil {
+		m.logger.Debug("Integration status check reported an error", zap.Error(err))
+	}
+
+	// 2. Per-integration health.
+	previous := m.Readiness()
+	integrations := make(map[string]bool, len(statuses))
+	connected := 0
+	for name, st := range statuses {
+		up := st.Connected
+		integrations[name] = up
+		if up {
+			connected++
+			integrationUp.WithLabelValues(name).Set(1)
+		} else {
+			integrationUp.WithLabelValues(name).Set(0)
+		}
+
+		if was, seen := previous.Integrations[name]; !seen || was != up {
+			state := "down"
+			if up {
+				state = "up"
+			}
+			integrationHealthTransitions.WithLabelValues(name, state).Inc()
+			if seen {
+				m.logger.Info("Integration health changed",
+					zap.String("integration", name), zap.String("state", state))
+			}
+		}
+	}
+
+	// 3. Readiness.
+	next := Readiness{
+		Ready:        true,
+		CheckedAt:    time.Now().UTC(),
+		Integrations: integrations,
+	}
+	switch {
+	case len(statuses) == 0:
+		// Nothing registered to fail.
+	case m.policy == config.ReadinessPolicyAll && connected < len(statuses):
+		next.Ready, next.Reason = false, "one or more integrations are disconnected"
+	case m.policy != config.ReadinessPolicyAll && connected == 0:
+		next.Ready, next.Reason = false, "no integration is connected"
+	}
+
+	if next.Ready != previous.Ready && !previous.CheckedAt.IsZero() {
+		m.logger.Warn("Service readiness changed",
+			zap.Bool("ready", next.Ready), zap.String("reason", next.Reason))
+	}
+
+	m.mu.Lock()
+	m.state = next
+	if next.Ready && !m.draining {
+		serviceReady.Set(1)
+	} else {
+		serviceReady.Set(0)
+	}
+	m.mu.Unlock()
+}
+
+// SetHealthMonitor attaches the health monitor whose readiness /readyz reports.
+func (sm *SyncManager) SetHealthMonitor(m *HealthMonitor) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.healthMonitor = m
+}
+
+// HealthMonitor returns the attached health monitor, or nil.
+func (sm *SyncManager) HealthMonitor() *HealthMonitor {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	return sm.healthMonitor
+}
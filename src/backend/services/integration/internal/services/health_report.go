@@ -0,0 +1,185 @@
+package services
+
+import (
+	// go1.21 - Probe deadlines and report caching
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	// v0.3.0 - Runs the dependency probes in parallel
+	"golang.org/x/sync/errgroup"
+
+	// Internal imports from the same module
+	"src/backend/services/integration/internal/models"
+)
+
+// defaultProbeTimeout bounds dependency probes when no status check timeout is
+// configured for them.
+const defaultProbeTimeout = 2 * time.Second
+
+// DependencyProbe checks a dependency of the service that is not an
+// integration, such as the shared rate limit store, returning nil when healthy.
+type DependencyProbe func(ctx context.Context) error
+
+// DependencyHealth is the outcome of probing one dependency.
+type DependencyHealth struct {
+	// Healthy is true when the probe succeeded within its timeout.
+	Healthy bool `json:"healthy"`
+
+	// LatencyMs is how long the probe took, or its timeout when it missed it.
+	LatencyMs int64 `json:"latencyMs"`
+
+	// Error explains why the dependency is unhealthy; empty when healthy.
+	Error string `json:"error,omitempty"`
+}
+
+// HealthReport is the result of probing every dependency of the service.
+type HealthReport struct {
+	// Integrations maps each integration to its status, as GetStatus reports it.
+	Integrations map[string]models.IntegrationStatus
+
+	// Dependencies maps every integration and registered dependency probe to
+	// the outcome of its probe.
+	Dependencies map[string]DependencyHealth
+
+	// CheckedAt is when the probes ran.
+	CheckedAt time.Time
+
+	// Cached is true when the report was reused from an earlier request.
+	Cached bool
+}
+
+// Healthy reports whether every dependency passed its probe.
+func (r HealthReport) Healthy() bool {
+	for _, dep := range r.Dependencies {
+		if !dep.Healthy {
+			return false
+		}
+	}
+	return true
+}
+
+// healthReportCache keeps the last health report for the configured TTL. Its
+// mutex is held while probing, so concurrent requests share one round.
+type healthReportCache struct {
+	mu      sync.Mutex
+	report  HealthReport
+	expires time.Time
+}
+
+// AddDependencyProbe registers a probe whose outcome is reported, under name,
+// alongside the integrations by HealthReport. Probes are bounded by the status
+// check timeout configured for name.
+func (sm *SyncManager) AddDependencyProbe(name string, probe DependencyProbe) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	if sm.dependencyProbes == nil {
+		sm.dependencyProbes = make(map[string]DependencyProbe)
+	}
+	sm.dependencyProbes[name] = probe
+}
+
+// HealthReport probes every integration and registered dependency in
+// parallel, each bounded by its status check timeout, and reports their health
+// and latency. Reports are reused for healthMonitor.reportTTL, so that
+// dashboards polling /health do not call every provider on each request. The
+// probes are not canceled when ctx is, since their result is shared.
+func (sm *SyncManager) HealthReport(ctx context.Context) HealthReport {
+	cache := &sm.healthReports
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if time.Now().Before(cache.expires) {
+		report := cache.report
+		report.Cached = true
+		return report
+	}
+
+	report := sm.probeDependencies(context.WithoutCancel(ctx))
+	if ttl := sm.cfg.HealthMonitor.ReportCacheTTL(); ttl > 0 {
+		cache.report, cache.expires = report, report.CheckedAt.Add(ttl)
+	}
+	return report
+}
+
+// probeDependencies runs one round of probes.
+func (sm *SyncManager) probeDependencies(ctx context.Context) HealthReport {
+	sm.mu.RLock()
+	integrations := make(map[string]models.Integration, len(sm.integrations))
+	bulkheads := make(map[string]*Bulkhead, len(sm.bulkheads))
+	metrics := make(map[string]models.SyncMetrics, len(sm.metrics))
+	for name, integration := range sm.integrations {
+		integrations[name] = integration
+		bulkheads[name] = sm.bulkheads[name]
+		metrics[name] = sm.metrics[name]
+	}
+	probes := make(map[string]DependencyProbe, len(sm.dependencyProbes))
+	for name, probe := range sm.dependencyProbes {
+		probes[name] = probe
+	}
+	sm.mu.RUnlock()
+
+	report := HealthReport{
+		Integrations: make(map[string]models.IntegrationStatus, len(integrations)),
+		Dependencies: make(map[string]DependencyHealth, len(integrations)+len(probes)),
+		CheckedAt:    time.Now().UTC(),
+	}
+	var mu sync.Mutex
+	record := func(name string, dep DependencyHealth) {
+		mu.Lock()
+		defer mu.Unlock()
+		report.Dependencies[name] = dep
+	}
+
+	var g errgroup.Group
+	for name, integration := range integrations {
+		name, integration := name, integration
+		g.Go(func() error {
+			timeout := sm.cfg.StatusChecks.ForIntegration(name)
+			started := time.Now()
+			st, ok, err := sm.checkStatus(name, integration, timeout)
+			dep := DependencyHealth{LatencyMs: time.Since(started).Milliseconds()}
+			switch {
+			case !ok:
+				st = timedOutStatus(name, timeout)
+				dep.Error = fmt.Sprintf("status check timed out after %s", timeout)
+			case err != nil:
+				dep.Error = err.Error()
+			case !st.Connected:
+				dep.Error = "disconnected"
+			default:
+				dep.Healthy = true
+			}
+			st = withServiceMetadata(st, bulkheads[name], metrics[name])
+			sm.stream.observeStatus(name, st)
+
+			mu.Lock()
+			report.Integrations[name] = st
+			mu.Unlock()
+			record(name, dep)
+			return nil
+		})
+	}
+	for name, probe := range probes {
+		name, probe := name, probe
+		g.Go(func() error {
+			timeout := sm.cfg.StatusChecks.ForIntegration(name)
+			if timeout <= 0 {
+				timeout = defaultProbeTimeout
+			}
+			probeCtx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			started := time.Now()
+			err := probe(probeCtx)
+			dep := DependencyHealth{Healthy: err == nil, LatencyMs: time.Since(started).Milliseconds()}
+			if err != nil {
+				dep.Error = err.Error()
+			}
+			record(name, dep)
+			return nil
+		})
+	}
+	_ = g.Wait()
+	return report
+}
@@ -0,0 +1,416 @@
+package services
+
+import (
+	// go1.21 - Payload hashing and history file encoding
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	// go1.21 - Day-per-file history storage
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	// v1.24.0 - Structured logging of history write failures
+	"go.uber.org/zap"
+
+	// Internal imports from the same module
+	"src/backend/services/integration/internal/config"
+	"src/backend/services/integration/internal/models"
+)
+
+// historySuffix is the file extension of history files, one per UTC day.
+const historySuffix = ".jsonl"
+
+// historyDayLayout names history files by the UTC day their records were written.
+const historyDayLayout = "2006-01-02"
+
+// Limits on the records returned by one QueryMessages call.
+const (
+	DefaultHistoryLimit = 50
+	MaxHistoryLimit     = 500
+)
+
+// ErrHistoryDisabled is returned by QueryMessages when no message history is attached.
+var ErrHistoryDisabled = errors.New("message history is not enabled")
+
+// MessageRecord is the persisted record of one attempt to deliver a message.
+// The payload itself is not kept; PayloadHash identifies it.
+type MessageRecord struct {
+	// Seq orders the records; it increases by one per record written.
+	Seq uint64 `json:"seq"`
+
+	// Integration is the integration the payload was sent through.
+	Integration string `json:"integration"`
+
+	// CorrelationID ties the attempts at one send together; for queued sends it
+	// is the deliveryId returned by the send endpoints.
+	CorrelationID string `json:"correlationId,omitempty"`
+
+	// RequestID is the X-Request-ID of the API request the send came from.
+	RequestID string `json:"requestId,omitempty"`
+
+	// PayloadHash is the hex SHA-256 of the payload as JSON.
+	PayloadHash string `json:"payloadHash"`
+
+	// Outcome is "delivered" or "failed".
+	Outcome string `json:"outcome"`
+
+	// Error is the failure reason; empty when delivered.
+	Error string `json:"error,omitempty"`
+
+	// LatencyMs is how long the attempt took, including bulkhead queueing.
+	LatencyMs int64 `json:"latencyMs"`
+
+	// At is when the attempt finished.
+	At time.Time `json:"at"`
+}
+
+// HistoryQuery selects message records. Zero fields do not filter.
+type HistoryQuery struct {
+	Integration   string
+	CorrelationID string
+	Outcome       string
+
+	// Since and Until bound the records' At, inclusively.
+	Since time.Time
+	Until time.Time
+
+	// Before continues a previous page: only records with a lower Seq match.
+	Before uint64
+
+	// Limit caps the records returned; see DefaultHistoryLimit and MaxHistoryLimit.
+	Limit int
+}
+
+// matches reports whether rec satisfies every filter of q.
+func (q *HistoryQuery) matches(rec *MessageRecord) bool {
+	switch {
+	case q.Before > 0 && rec.Seq >= q.Before:
+		return false
+	case q.Integration != "" && rec.Integration != q.Integration:
+		return false
+	case q.CorrelationID != "" && rec.CorrelationID != q.CorrelationID:
+		return false
+	case q.Outcome != "" && rec.Outcome != q.Outcome:
+		return false
+	case !q.Since.IsZero() && rec.At.Before(q.Since):
+		return false
+	case !q.Until.IsZero() && rec.At.After(q.Until):
+		return false
+	}
+	return true
+}
+
+// HistoryPage is one page of message records, newest first.
+type HistoryPage struct {
+	Messages []MessageRecord `json:"messages"`
+
+	// Next is the Before of the following page; zero on the last page.
+	Next uint64 `json:"next,omitempty"`
+}
+
+// MessageHistory persists a record of every delivery attempt in append-only
+// files, one per UTC day, and deletes days older than the retention. Records
+// are written with owner-only permissions and are not synced individually, so
+// a crash may lose the last few.
+type MessageHistory struct {
+	cfg    *config.HistoryConfig
+	logger *zap.Logger
+
+	mu   sync.Mutex
+	seq  uint64
+	day  string
+	file *os.File
+}
+
+// NewMessageHistory opens (creating if needed) the history in cfg.Dir and
+// resumes numbering after its newest record. Attach it with
+// SyncManager.SetMessageHistory.
+func NewMessageHistory(cfg *config.HistoryConfig, logger *zap.Logger) (*MessageHistory, error) {
+	if err := os.MkdirAll(cfg.Dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create history directory: %w", err)
+	}
+	h := &MessageHistory{cfg: cfg, logger: logger}
+
+	days, err := h.days()
+	if err != nil {
+		return nil, err
+	}
+	if len(days) > 0 {
+		records, err := h.read(days[len(days)-1])
+		if err != nil {
+			return nil, err
+		}
+		for _, rec := range records {
+			if rec.Seq > h.seq {
+				h.seq = rec.Seq
+			}
+		}
+	}
+	h.mu.Lock()
+	h.pruneLocked(time.Now())
+	h.mu.Unlock()
+	return h, nil
+}
+
+// Record appends one record per payload, assigning their sequence numbers.
+// Failures are logged rather than returned, since a send must not fail because
+// its history could not be written.
+func (h *MessageHistory) Record(records []MessageRecord) {
+	if err := h.append(records); err != nil {
+		h.logger.Error("Failed to write message history", zap.Int("records", len(records)), zap.Error(err))
+	}
+}
+
+// append writes records to today's file, opening it on the first write of the day.
+func (h *MessageHistory) append(records []MessageRecord) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := time.Now()
+	if day := now.UTC().Format(historyDayLayout); h.file == nil || day != h.day {
+		if h.file != nil {
+			_ = h.file.Close()
+			h.file = nil
+		}
+		f, err := os.OpenFile(h.path(day), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+		if err != nil {
+			return err
+		}
+		h.file, h.day = f, day
+		h.pruneLocked(now)
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for i := range records {
+		h.seq++
+		records[i].Seq = h.seq
+		if err := enc.Encode(&records[i]); err != nil {
+			return err
+		}
+	}
+	_, err := h.file.Write(buf.Bytes())
+	return err
+}
+
+// Query returns the records matching q, newest first, a page at a time.
+func (h *MessageHistory) Query(q HistoryQuery) (HistoryPage, error) {
+	if q.Limit <= 0 {
+		q.Limit = DefaultHistoryLimit
+	}
+	if q.Limit > MaxHistoryLimit {
+		q.Limit = MaxHistoryLimit
+	}
+
+	days, err := h.days()
+	if err != nil {
+		return HistoryPage{}, err
+	}
+	page := HistoryPage{Messages: []MessageRecord{}}
+	for i := len(days) - 1; i >= 0; i-- {
+		start, err := time.Parse(historyDayLayout, days[i])
+		if err != nil {
+			continue
+		}
+		// Days are searched newest first, so every remaining one is too old.
+		if !q.Since.IsZero() && !start.Add(24*time.Hour).After(q.Since) {
+			break
+		}
+		if !q.Until.IsZero() && start.After(q.Until) {
+			continue
+		}
+
+		records, err := h.read(days[i])
+		if err != nil {
+			return HistoryPage{}, err
+		}
+		for j := len(records) - 1; j >= 0; j-- {
+			if !q.matches(&records[j]) {
+				continue
+			}
+			if len(page.Messages) == q.Limit {
+				page.Next = page.Messages[q.Limit-1].Seq
+				return page, nil
+			}
+			page.Messages = append(page.Messages, records[j])
+		}
+	}
+	return page, nil
+}
+
+// Close closes the current history file.
+func (h *MessageHistory) Close(context.Context) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.file == nil {
+		return nil
+	}
+	err := h.file.Close()
+	h.file = nil
+	return err
+}
+
+// days returns the days with a history file, oldest first.
+func (h *MessageHistory) days() ([]string, error) {
+	entries, err := os.ReadDir(h.cfg.Dir)
+	if err != nil {
+		return nil, err
+	}
+	var days []string
+	for _, e := range entries {
+		day, ok := strings.CutSuffix(e.Name(), historySuffix)
+		if !ok || e.IsDir() {
+			continue
+		}
+		if _, err := time.Parse(historyDayLayout, day); err == nil {
+			days = append(days, day)
+		}
+	}
+	sort.Strings(days)
+	return days, nil
+}
+
+// read returns the records of one day in the order they were written. A line
+// cut short by a crash, or still being written, is skipped.
+func (h *MessageHistory) read(day string) ([]MessageRecord, error) {
+	f, err := os.Open(h.path(day))
+	if errors.Is(err, os.ErrNotExist) {
+		// Pruned since it was listed.
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var records []MessageRecord
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+	for scanner.Scan() {
+		var rec MessageRecord
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err == nil {
+			records = append(records, rec)
+		}
+	}
+	return records, scanner.Err()
+}
+
+// pruneLocked deletes the files of days entirely older than the retention.
+// h.mu must be held.
+func (h *MessageHistory) pruneLocked(now time.Time) {
+	days, err := h.days()
+	if err != nil {
+		h.logger.Warn("Failed to list message history for pruning", zap.Error(err))
+		return
+	}
+	cutoff := now.Add(-h.cfg.Retention)
+	for _, day := range days {
+		start, err := time.Parse(historyDayLayout, day)
+		if err != nil || start.Add(24*time.Hour).After(cutoff) {
+			continue
+		}
+		if err := os.Remove(h.path(day)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			h.logger.Warn("Failed to prune message history", zap.String("day", day), zap.Error(err))
+		}
+	}
+}
+
+// path returns the file holding day's records.
+func (h *MessageHistory) path(day string) string {
+	return filepath.Join(h.cfg.Dir, day+historySuffix)
+}
+
+// payloadHash returns the hex SHA-256 of payload encoded as JSON, or of its
+// printed form when it cannot be encoded.
+func payloadHash(payload interface{}) string {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		data = []byte(fmt.Sprintf("%v", payload))
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// recordHistory records an attempt to deliver payloads to the named
+// integration, when a message history is attached. Each payload is recorded
+// under its send ID: the batch's IDs for batches, otherwise the ID in ctx.
+func (sm *SyncManager) recordHistory(ctx context.Context, name string, payloads []interface{}, started time.Time, err error) {
+	sm.recordBatchHistory(ctx, name, payloads, started, models.FailBatchResults(len(payloads), err))
+}
+
+// recordBatchHistory records a delivery attempt of payloads whose outcomes
+// differ, one result per payload, as reported by a BatchSender.
+func (sm *SyncManager) recordBatchHistory(ctx context.Context, name string, payloads []interface{}, started time.Time, results []models.BatchResult) {
+	sm.mu.RLock()
+	history := sm.history
+	sm.mu.RUnlock()
+	if history == nil {
+		return
+	}
+
+	ids := batchMessageIDsFrom(ctx)
+	requestID := models.RequestIDFrom(ctx)
+	at := time.Now().UTC()
+	records := make([]MessageRecord, len(payloads))
+	for i, payload := range payloads {
+		rec := MessageRecord{
+			Integration: name,
+			RequestID:   requestID,
+			PayloadHash: payloadHash(payload),
+			Outcome:     DeliveryDelivered,
+			LatencyMs:   time.Since(started).Milliseconds(),
+			At:          at,
+		}
+		if len(ids) == len(payloads) {
+			rec.CorrelationID = ids[i]
+		} else {
+			rec.CorrelationID = messageIDFrom(ctx)
+		}
+		if err := results[i].Err; err != nil {
+			rec.Outcome, rec.Error = DeliveryFailed, err.Error()
+		}
+		records[i] = rec
+	}
+	history.Record(records)
+}
+
+// SetMessageHistory attaches the message history. Once set, every delivery
+// attempt is recorded and can be queried with QueryMessages.
+func (sm *SyncManager) SetMessageHistory(h *MessageHistory) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.history = h
+}
+
+// CloseMessageHistory closes the attached message history, if any. It is called
+// during shutdown after the send pipeline has drained.
+func (sm *SyncManager) CloseMessageHistory(ctx context.Context) error {
+	sm.mu.RLock()
+	history := sm.history
+	sm.mu.RUnlock()
+	if history == nil {
+		return nil
+	}
+	return history.Close(ctx)
+}
+
+// QueryMessages returns a page of recorded delivery attempts matching q,
+// newest first, or ErrHistoryDisabled when no message history is attached.
+func (sm *SyncManager) QueryMessages(q HistoryQuery) (HistoryPage, error) {
+	sm.mu.RLock()
+	history := sm.history
+	sm.mu.RUnlock()
+	if history == nil {
+		return HistoryPage{}, ErrHistoryDisabled
+	}
+	return history.Query(q)
+}
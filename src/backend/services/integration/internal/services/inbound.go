@@ -0,0 +1,128 @@
+package services
+
+import (
+	// go1.21 - Poll lifetime and ordering of registered integrations
+	"context"
+	"sort"
+	"time"
+
+	// v1.16.0 - Inbound email metrics exposed on the default registry served at /metrics
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	// v1.24.0 - Structured logging of replies, bounces and poll failures
+	"go.uber.org/zap"
+
+	// Internal imports from the same module
+	"src/backend/services/integration/internal/config"
+	"src/backend/services/integration/internal/models"
+)
+
+var (
+	// inboundEmails counts replies and bounces read from the inbound mailbox.
+	inboundEmails = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "integration_inbound_emails_total",
+		Help: "Replies and bounces read from the inbound mailbox, by kind and integration; integration is empty when no sent message matched.",
+	}, []string{"kind", "integration"})
+
+	// inboundPollFailures counts failed polls of the inbound mailbox.
+	inboundPollFailures = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "integration_inbound_poll_failures_total",
+		Help: "Polls of the inbound mailbox that failed to read it.",
+	})
+)
+
+// InboundPoller reads replies and bounces from a mailbox on an interval and
+// hands each to the integrations that correlate inbound email, which match it
+// to a message they sent by Message-ID and report the outcome, such as the
+// bounce rate, in their status.
+type InboundPoller struct {
+	cfg     *config.InboundMailConfig
+	source  models.InboundEmailSource
+	manager *SyncManager
+	logger  *zap.Logger
+}
+
+// NewInboundPoller creates a poller reading source for the integrations
+// registered on manager.
+func NewInboundPoller(cfg *config.InboundMailConfig, source models.InboundEmailSource, manager *SyncManager, logger *zap.Logger) *InboundPoller {
+	return &InboundPoller{
+		cfg:     cfg,
+		source:  source,
+		manager: manager,
+		logger:  logger,
+	}
+}
+
+// Run polls the mailbox until ctx is canceled.
+func (p *InboundPoller) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		p.poll(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll reads the new messages, each bounded by the poll interval, and routes
+// every one to the first integration that recognizes it.
+func (p *InboundPoller) poll(ctx context.Context) {
+	pollCtx, cancel := context.WithTimeout(ctx, p.cfg.PollInterval)
+	defer cancel()
+	emails, err := p.source.Poll(pollCtx)
+	if err != nil {
+		inboundPollFailures.Inc()
+		p.logger.Warn("Unable to poll inbound mailbox",
+			zap.String("host", p.cfg.Host), zap.Error(err))
+		// Messages read before a failure to mark them seen are still routed;
+		// they will be read again, which correlation tolerates.
+	}
+
+	receivers := p.receivers()
+	for _, email := range emails {
+		matched := ""
+		for _, r := range receivers {
+			if r.receiver.ReceiveInboundEmail(ctx, email) {
+				matched = r.name
+				break
+			}
+		}
+		inboundEmails.WithLabelValues(email.Kind, matched).Inc()
+		if matched == "" {
+			continue
+		}
+		p.logger.Info("Inbound email correlated",
+			zap.String("integration", matched),
+			zap.String("kind", email.Kind),
+			zap.Strings("references", email.References),
+			zap.Strings("recipients", email.Recipients),
+			zap.String("status", email.Status),
+			zap.Bool("permanent", email.Permanent),
+		)
+	}
+}
+
+// namedReceiver is a registered integration that correlates inbound email.
+type namedReceiver struct {
+	name     string
+	receiver models.InboundEmailReceiver
+}
+
+// receivers returns the registered integrations that correlate inbound email,
+// in name order.
+func (p *InboundPoller) receivers() []namedReceiver {
+	p.manager.mu.RLock()
+	defer p.manager.mu.RUnlock()
+	var receivers []namedReceiver
+	for name, integration := range p.manager.integrations {
+		if receiver, ok := integration.(models.InboundEmailReceiver); ok {
+			receivers = append(receivers, namedReceiver{name: name, receiver: receiver})
+		}
+	}
+	sort.Slice(receivers, func(i, j int) bool { return receivers[i].name < receivers[j].name })
+	return receivers
+}
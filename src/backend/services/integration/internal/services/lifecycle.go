@@ -0,0 +1,114 @@
+package services
+
+import (
+	// go1.21 - Message IDs carried with sends
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Stages of a queued send, reported in MessageEvent.Stage.
+const (
+	// MessageQueued: the send was accepted by the dispatch pipeline.
+	MessageQueued = "queued"
+	// MessageSent: the provider accepted the message.
+	MessageSent = "sent"
+	// MessageRetried: a stored message is being delivered again.
+	MessageRetried = "retried"
+	// MessageFailed: a delivery attempt failed. Messages stored for replay are
+	// attempted again; others are not.
+	MessageFailed = "failed"
+	// MessageDeadLettered: delivery failed while the integration was down, and
+	// the message was set aside in the store-and-forward spool for replay.
+	MessageDeadLettered = "dead-lettered"
+)
+
+// MessageEvent is one stage in the life of a queued send.
+type MessageEvent struct {
+	// ID identifies the send across its stages; see DispatchTicket.ID.
+	ID string `json:"id"`
+
+	// Stage is one of the Message stages.
+	Stage string `json:"stage"`
+
+	// Error is why a delivery failed or was set aside.
+	Error string `json:"error,omitempty"`
+}
+
+// messageIDKey is the context key under which withMessageID stores the ID of
+// the send being delivered.
+type messageIDKey struct{}
+
+// withMessageID returns a context carrying the ID of the send being delivered,
+// so that stages reached further down, such as storing it for replay, are
+// reported under the same ID.
+func withMessageID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, messageIDKey{}, id)
+}
+
+// messageIDFrom returns the send ID carried by ctx, or an empty string.
+func messageIDFrom(ctx context.Context) string {
+	id, _ := ctx.Value(messageIDKey{}).(string)
+	return id
+}
+
+// batchMessageIDsKey is the context key under which withBatchMessageIDs stores
+// the IDs of the sends in a batch.
+type batchMessageIDsKey struct{}
+
+// withBatchMessageIDs returns a context carrying the IDs of the sends in a
+// batch being delivered, in payload order.
+func withBatchMessageIDs(ctx context.Context, ids []string) context.Context {
+	return context.WithValue(ctx, batchMessageIDsKey{}, ids)
+}
+
+// batchMessageIDsFrom returns the batch's send IDs carried by ctx, or nil.
+func batchMessageIDsFrom(ctx context.Context) []string {
+	ids, _ := ctx.Value(batchMessageIDsKey{}).([]string)
+	return ids
+}
+
+// newMessageID returns an identifier for one queued send.
+func newMessageID() string {
+	var b [12]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return fmt.Sprintf("msg-%d", time.Now().UnixNano())
+	}
+	return "msg-" + hex.EncodeToString(b[:])
+}
+
+// lifecycleFunc reports that the named integration's send id reached stage,
+// with the error that caused it, if any.
+type lifecycleFunc func(name, id, stage string, err error)
+
+// deliveryStage returns the stage a delivery attempt ending in err reached.
+func deliveryStage(err error) string {
+	switch {
+	case err == nil:
+		return MessageSent
+	case errors.Is(err, ErrStoredForReplay):
+		return MessageDeadLettered
+	default:
+		return MessageFailed
+	}
+}
+
+// publishLifecycle publishes a stage of the named integration's send id to
+// lifecycle subscribers.
+func (sm *SyncManager) publishLifecycle(name, id, stage string, err error) {
+	event := &MessageEvent{ID: id, Stage: stage}
+	if err != nil && stage != MessageSent {
+		event.Error = err.Error()
+	}
+	sm.lifecycle.publish(StreamEvent{Type: StreamMessage, Integration: name, Message: event})
+}
+
+// SubscribeLifecycle subscribes to the stages of queued sends as they happen;
+// close the subscription when done.
+func (sm *SyncManager) SubscribeLifecycle() *StreamSubscription {
+	sub, _ := sm.lifecycle.subscribe()
+	return sub
+}
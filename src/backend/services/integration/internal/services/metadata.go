@@ -0,0 +1,28 @@
+package services
+
+import (
+	// go1.21 - Sentinel errors for invalidation requests
+	"errors"
+
+	// Internal models for the metadata invalidation contract
+	"src/backend/services/integration/internal/models"
+)
+
+// ErrMetadataCacheUnsupported is returned when an integration keeps no metadata cache.
+var ErrMetadataCacheUnsupported = errors.New("integration does not cache metadata")
+
+// InvalidateMetadata drops cached provider metadata for the named integration
+// whose key starts with prefix (everything when prefix is empty), returning the
+// number of entries removed.
+func (sm *SyncManager) InvalidateMetadata(name, prefix string) (int, error) {
+	integration, err := sm.GetIntegration(name)
+	if err != nil {
+		return 0, err
+	}
+
+	invalidator, ok := integration.(models.MetadataInvalidator)
+	if !ok {
+		return 0, ErrMetadataCacheUnsupported
+	}
+	return invalidator.InvalidateMetadata(prefix), nil
+}
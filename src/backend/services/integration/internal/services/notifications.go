@@ -0,0 +1,28 @@
+package services
+
+import (
+	// go1.21 - Context for notification handling and sentinel errors
+	"context"
+	"errors"
+	"net/http"
+
+	// Internal models for the notification contract
+	"src/backend/services/integration/internal/models"
+)
+
+// ErrNotificationsUnsupported is returned when an integration accepts no
+// provider notifications.
+var ErrNotificationsUnsupported = errors.New("integration does not accept notifications")
+
+// ReceiveNotification hands a provider notification to the named integration.
+func (sm *SyncManager) ReceiveNotification(ctx context.Context, name string, header http.Header, body []byte) error {
+	integration, err := sm.GetIntegration(name)
+	if err != nil {
+		return err
+	}
+	receiver, ok := integration.(models.NotificationReceiver)
+	if !ok {
+		return ErrNotificationsUnsupported
+	}
+	return receiver.ReceiveNotification(ctx, header, body)
+}
@@ -0,0 +1,214 @@
+package services
+
+import (
+	// go1.21 - Redis TLS, cancellable waits and limiter caching
+	"context"
+	"crypto/tls"
+	"fmt"
+	"math"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	// github.com/go-redis/redis/v8 v8.11.5 - Redis client for the shared store
+	"github.com/go-redis/redis/v8"
+
+	// github.com/ulule/limiter/v3 v3.10.0 - Fixed-window limiter and its stores
+	limiter "github.com/ulule/limiter/v3"
+	memoryStore "github.com/ulule/limiter/v3/drivers/store/memory"
+	redisStore "github.com/ulule/limiter/v3/drivers/store/redis"
+
+	// v1.24.0 - Structured logging of store outages
+	"go.uber.org/zap"
+
+	// Internal imports from the same module
+	"src/backend/services/integration/internal/config"
+	"src/backend/services/integration/internal/models"
+)
+
+const (
+	// rateLimitStoreMaxRetry bounds the retries of a Redis counter update that
+	// races with another replica.
+	rateLimitStoreMaxRetry = 3
+
+	// sharedLimiterPrefix separates provider call counters from API quotas in
+	// the shared store.
+	sharedLimiterPrefix = "provider"
+
+	// sharedLimiterMinDelay is the shortest wait for a window to reset, so that
+	// replicas with skewed clocks do not spin on the store.
+	sharedLimiterMinDelay = 50 * time.Millisecond
+)
+
+// RateLimitStore is the store that keeps rate limit counters.
+type RateLimitStore struct {
+	limiter.Store
+
+	// client is the Redis connection of a distributed store; nil in memory.
+	client *redis.Client
+}
+
+// NewRateLimitStore creates the store that keeps rate limit counters: in memory,
+// so each replica enforces limits alone, or in Redis, so they hold cluster-wide.
+// A Redis server that cannot be reached is an error.
+func NewRateLimitStore(cfg *config.RateLimitStoreConfig) (*RateLimitStore, error) {
+	if !cfg.Distributed() {
+		return &RateLimitStore{Store: memoryStore.NewStore()}, nil
+	}
+
+	rc := cfg.Redis
+	options := &redis.Options{
+		Addr:        rc.Address,
+		Username:    rc.Username,
+		Password:    rc.Password,
+		DB:          rc.DB,
+		DialTimeout: rc.DialTimeout,
+	}
+	if rc.TLS {
+		host, _, err := net.SplitHostPort(rc.Address)
+		if err != nil {
+			host = rc.Address
+		}
+		options.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12, ServerName: host}
+	}
+
+	client := redis.NewClient(options)
+	store, err := redisStore.NewStoreWithOptions(client, limiter.StoreOptions{
+		Prefix:   rc.KeyPrefix,
+		MaxRetry: rateLimitStoreMaxRetry,
+	})
+	if err != nil {
+		_ = client.Close()
+		return nil, fmt.Errorf("failed to connect to rate limit store at %s: %w", rc.Address, err)
+	}
+	return &RateLimitStore{Store: store, client: client}, nil
+}
+
+// Ping checks that the store can be reached; an in-memory store always can.
+func (s *RateLimitStore) Ping(ctx context.Context) error {
+	if s.client == nil {
+		return nil
+	}
+	return s.client.Ping(ctx).Err()
+}
+
+// Close releases the store's connections.
+func (s *RateLimitStore) Close() error {
+	if s.client == nil {
+		return nil
+	}
+	return s.client.Close()
+}
+
+// SharedLimiters paces provider calls across every replica of the service by
+// counting them in a shared rate limit store, so that the replicas together
+// keep to a provider's rate limit. When the store cannot be reached, calls are
+// let through and paced by the adapters' own limiters alone.
+type SharedLimiters struct {
+	store  limiter.Store
+	logger *zap.Logger
+
+	mu       sync.Mutex
+	limiters map[limiter.Rate]*limiter.Limiter
+
+	// degraded is set while the store is failing, so the outage is logged once.
+	degraded atomic.Bool
+}
+
+// NewSharedLimiters creates shared limiters counting calls in store.
+func NewSharedLimiters(store limiter.Store, logger *zap.Logger) *SharedLimiters {
+	return &SharedLimiters{
+		store:    store,
+		logger:   logger,
+		limiters: make(map[limiter.Rate]*limiter.Limiter),
+	}
+}
+
+// ForIntegration returns the limiter for the named integration, whose keys are
+// scoped to it.
+func (s *SharedLimiters) ForIntegration(name string) models.SharedLimiter {
+	return integrationLimiter{limiters: s, name: name}
+}
+
+// integrationLimiter is SharedLimiters scoped to one integration.
+type integrationLimiter struct {
+	limiters *SharedLimiters
+	name     string
+}
+
+// Wait implements models.SharedLimiter.
+func (l integrationLimiter) Wait(ctx context.Context, key string, perSecond float64) error {
+	return l.limiters.wait(ctx, l.name+":"+key, perSecond)
+}
+
+// wait blocks until a call counted under key fits the window for perSecond, or
+// ctx is done.
+func (s *SharedLimiters) wait(ctx context.Context, key string, perSecond float64) error {
+	if perSecond <= 0 {
+		return nil
+	}
+	lim := s.limiterFor(sharedRate(perSecond))
+	for {
+		window, err := lim.Get(ctx, sharedLimiterPrefix+":"+key)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if s.degraded.CompareAndSwap(false, true) {
+				s.logger.Warn("Shared rate limit store unavailable; pacing provider calls per replica",
+					zap.String("bucket", key),
+					zap.Error(err))
+			}
+			return nil
+		}
+		if s.degraded.CompareAndSwap(true, false) {
+			s.logger.Info("Shared rate limit store available again")
+		}
+		if !window.Reached {
+			return nil
+		}
+
+		delay := time.Until(time.Unix(window.Reset, 0))
+		if delay < sharedLimiterMinDelay {
+			delay = sharedLimiterMinDelay
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// limiterFor returns the limiter for rate, creating it on first use.
+func (s *SharedLimiters) limiterFor(rate limiter.Rate) *limiter.Limiter {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	lim, ok := s.limiters[rate]
+	if !ok {
+		lim = limiter.New(s.store, rate)
+		s.limiters[rate] = lim
+	}
+	return lim
+}
+
+// sharedRate converts a per-second rate to a fixed window: whole calls per
+// second, or one call per window of several seconds for rates below one.
+func sharedRate(perSecond float64) limiter.Rate {
+	if perSecond >= 1 {
+		return limiter.Rate{Period: time.Second, Limit: int64(perSecond)}
+	}
+	return limiter.Rate{Period: time.Duration(math.Ceil(1/perSecond)) * time.Second, Limit: 1}
+}
+
+// SetSharedLimiters makes the service hand adapters that pace their provider
+// calls a limiter shared by every replica. It applies to integrations
+// registered afterwards, so it must be called before they are.
+func (sm *SyncManager) SetSharedLimiters(s *SharedLimiters) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.sharedLimiters = s
+}
@@ -0,0 +1,181 @@
+package services
+
+import (
+	// go1.21 - Segment files for queue overflow
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	// Internal queue package for encryption of records at rest
+	"src/backend/services/integration/internal/queue"
+)
+
+// spillSegmentSize is the size at which a new segment file is started. Segments
+// are deleted once every record in them has been paged back in, so smaller
+// segments return disk space sooner.
+const spillSegmentSize = 8 << 20
+
+// spillSegmentSuffix is the extension of spill segment files.
+const spillSegmentSuffix = ".seg"
+
+// spillCipherTimeout bounds sealing and opening one record, which only calls
+// out to the key provider for the process's first data key.
+const spillCipherTimeout = 10 * time.Second
+
+// errSpillFull is returned when writing a record would exceed the spill's disk budget.
+var errSpillFull = errors.New("dispatch spill full")
+
+// spillSegment is one append-only file of spilled records.
+type spillSegment struct {
+	file *os.File
+	size int64
+
+	// live counts records not yet paged back in.
+	live int
+}
+
+// spillRef locates a spilled record.
+type spillRef struct {
+	segment *spillSegment
+	offset  int64
+	length  int
+}
+
+// spillStore writes overflowing queue records to append-only segment files and
+// reads them back. Records are sealed with the spool's cipher when encryption
+// is configured, so queued payloads and the credentials they may carry are not
+// written to disk in cleartext. It is not safe for concurrent use; the
+// dispatcher serializes access under its own lock.
+type spillStore struct {
+	dir      string
+	maxBytes int64
+
+	// cipher encrypts records at rest; nil writes plaintext.
+	cipher queue.Cipher
+
+	active   *spillSegment
+	segments map[*spillSegment]struct{}
+	used     int64
+	nextID   uint64
+}
+
+// newSpillStore prepares dir for spill segments, sealing records with cipher
+// unless it is nil. Segments left by a previous process are removed: their
+// tickets died with it, so they cannot be delivered.
+func newSpillStore(dir string, maxBytes int64, cipher queue.Cipher) (*spillStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create spill directory: %w", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), spillSegmentSuffix) {
+			_ = os.Remove(filepath.Join(dir, entry.Name()))
+		}
+	}
+	return &spillStore{
+		dir:      dir,
+		maxBytes: maxBytes,
+		cipher:   cipher,
+		segments: make(map[*spillSegment]struct{}),
+	}, nil
+}
+
+// write seals data and appends it to the active segment, starting a new one
+// when it is full.
+func (s *spillStore) write(data []byte) (spillRef, error) {
+	if s.cipher != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), spillCipherTimeout)
+		sealed, err := s.cipher.Seal(ctx, data)
+		cancel()
+		if err != nil {
+			return spillRef{}, fmt.Errorf("failed to encrypt spilled record: %w", err)
+		}
+		data = sealed
+	}
+	if s.used+int64(len(data)) > s.maxBytes {
+		return spillRef{}, errSpillFull
+	}
+	if s.active == nil || s.active.size+int64(len(data)) > spillSegmentSize {
+		if err := s.rotate(); err != nil {
+			return spillRef{}, err
+		}
+	}
+
+	seg := s.active
+	if _, err := seg.file.WriteAt(data, seg.size); err != nil {
+		return spillRef{}, err
+	}
+	ref := spillRef{segment: seg, offset: seg.size, length: len(data)}
+	seg.size += int64(len(data))
+	seg.live++
+	s.used += int64(len(data))
+	return ref, nil
+}
+
+// read returns a spilled record, opened if it was sealed, and releases it. A
+// segment is deleted once all of its records have been read.
+func (s *spillStore) read(ref spillRef) ([]byte, error) {
+	data := make([]byte, ref.length)
+	_, err := ref.segment.file.ReadAt(data, ref.offset)
+
+	ref.segment.live--
+	if ref.segment.live == 0 {
+		s.remove(ref.segment)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if s.cipher == nil {
+		return data, nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), spillCipherTimeout)
+	defer cancel()
+	plaintext, err := s.cipher.Open(ctx, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt spilled record: %w", err)
+	}
+	return plaintext, nil
+}
+
+// close deletes every segment.
+func (s *spillStore) close() {
+	for seg := range s.segments {
+		s.remove(seg)
+	}
+}
+
+// rotate starts a new active segment. The previous one stays until its records
+// have been read.
+func (s *spillStore) rotate() error {
+	if s.active != nil && s.active.live == 0 {
+		s.remove(s.active)
+	}
+	s.nextID++
+	name := filepath.Join(s.dir, fmt.Sprintf("%020d%s", s.nextID, spillSegmentSuffix))
+	file, err := os.OpenFile(name, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create spill segment: %w", err)
+	}
+	s.active = &spillSegment{file: file}
+	s.segments[s.active] = struct{}{}
+	return nil
+}
+
+// remove closes and deletes a segment.
+func (s *spillStore) remove(seg *spillSegment) {
+	name := seg.file.Name()
+	_ = seg.file.Close()
+	_ = os.Remove(name)
+	s.used -= seg.size
+	delete(s.segments, seg)
+	if s.active == seg {
+		s.active = nil
+	}
+}
@@ -0,0 +1,125 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	// Internal imports from the same module
+	"src/backend/services/integration/internal/models"
+)
+
+// statusCheck is an integration status check in progress. Checks that outlive
+// their caller's deadline are shared with later callers rather than restarted,
+// so a hung adapter holds at most one goroutine.
+type statusCheck struct {
+	done   chan struct{}
+	status models.IntegrationStatus
+	err    error
+}
+
+// checkStatus returns the named integration's status, waiting at most timeout
+// (zero waits indefinitely). The boolean is false when the check timed out. The
+// returned status may be shared with other callers; copy Metadata before changing it.
+func (sm *SyncManager) checkStatus(name string, integration models.Integration, timeout time.Duration) (models.IntegrationStatus, bool, error) {
+	sm.statusMu.Lock()
+	check, running := sm.statusChecks[name]
+	if !running {
+		check = &statusCheck{done: make(chan struct{})}
+		sm.statusChecks[name] = check
+		go func() {
+			check.status, check.err = integration.Status()
+			sm.statusMu.Lock()
+			delete(sm.statusChecks, name)
+			sm.statusMu.Unlock()
+			close(check.done)
+		}()
+	}
+	sm.statusMu.Unlock()
+
+	if timeout <= 0 {
+		<-check.done
+		return check.status, true, check.err
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-check.done:
+		return check.status, true, check.err
+	case <-timer.C:
+		return models.IntegrationStatus{}, false, nil
+	}
+}
+
+// timedOutStatus describes an integration whose status check missed its deadline.
+func timedOutStatus(name string, timeout time.Duration) models.IntegrationStatus {
+	return models.IntegrationStatus{
+		Connected: false,
+		Name:      name,
+		Metadata: map[string]interface{}{
+			"degraded":    true,
+			"statusError": fmt.Sprintf("status check timed out after %s", timeout),
+		},
+	}
+}
+
+// ErrConnectionTestUnsupported is returned when an integration cannot test its
+// connection on demand.
+var ErrConnectionTestUnsupported = errors.New("integration does not support connection tests")
+
+// ConnectionTestResult reports an on-demand connection test.
+type ConnectionTestResult struct {
+	// Integration is the name of the tested integration.
+	Integration string `json:"integration"`
+
+	// OK reports whether the provider accepted the integration's credentials.
+	OK bool `json:"ok"`
+
+	// LatencyMs is how long the test took.
+	LatencyMs int64 `json:"latencyMs"`
+
+	// Details are the adapter's diagnostics, such as the authenticated identity.
+	Details map[string]interface{} `json:"details,omitempty"`
+
+	// Error is the failure reason when the test failed.
+	Error string `json:"error,omitempty"`
+
+	// TestedAt is when the test finished.
+	TestedAt time.Time `json:"testedAt"`
+}
+
+// TestConnection runs the named integration's connection test, bounded by the
+// integration's status check timeout. A failed test is reported in the result
+// rather than as an error; errors are returned for unknown integrations and
+// those that cannot be tested.
+func (sm *SyncManager) TestConnection(ctx context.Context, name string) (ConnectionTestResult, error) {
+	integration, err := sm.GetIntegration(name)
+	if err != nil {
+		return ConnectionTestResult{}, err
+	}
+	tester, ok := integration.(models.ConnectionTester)
+	if !ok {
+		return ConnectionTestResult{}, ErrConnectionTestUnsupported
+	}
+
+	if timeout := sm.cfg.StatusChecks.ForIntegration(name); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	started := time.Now()
+	details, err := tester.TestConnection(ctx)
+	result := ConnectionTestResult{
+		Integration: name,
+		OK:          err == nil,
+		LatencyMs:   time.Since(started).Milliseconds(),
+		Details:     details,
+		TestedAt:    time.Now().UTC(),
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	return result, nil
+}
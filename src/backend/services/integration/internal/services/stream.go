@@ -0,0 +1,176 @@
+package services
+
+import (
+	// go1.21 - Guards the subscribers and the last known statuses
+	"sync"
+	"time"
+
+	// v1.16.0 - Stream metrics exposed on the default registry served at /metrics
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	// Internal models for integration statuses
+	"src/backend/services/integration/internal/models"
+)
+
+// Types of StreamEvent.
+const (
+	StreamStatus   = "status"
+	StreamDelivery = "delivery"
+	StreamMessage  = "message"
+)
+
+// defaultStreamBuffer is how many events a subscriber may fall behind before
+// further events are dropped for it.
+const defaultStreamBuffer = 64
+
+// streamDropped counts events not delivered to a subscriber that fell behind.
+var streamDropped = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "integration_stream_events_dropped_total",
+	Help: "Live stream events dropped because a subscriber was not keeping up.",
+})
+
+// StreamEvent is a change pushed to live subscribers, such as dashboards: an
+// integration's status changed, a send was delivered or failed, or a queued
+// send reached another stage.
+type StreamEvent struct {
+	// Seq orders the events; it increases by one per event published.
+	Seq uint64 `json:"seq"`
+
+	// Type is StreamStatus, StreamDelivery or StreamMessage.
+	Type string `json:"type"`
+
+	// Integration is the integration the event concerns.
+	Integration string `json:"integration"`
+
+	// Status is set for status events.
+	Status *models.IntegrationStatus `json:"status,omitempty"`
+
+	// Delivery is set for delivery events.
+	Delivery *DeliveryRecord `json:"delivery,omitempty"`
+
+	// Message is set for message events.
+	Message *MessageEvent `json:"message,omitempty"`
+
+	// At is when the event was published.
+	At time.Time `json:"at"`
+}
+
+// StreamSubscription receives the events published after it was created,
+// until it is closed.
+type StreamSubscription struct {
+	hub    *streamHub
+	events chan StreamEvent
+	once   sync.Once
+}
+
+// Events returns the channel of events, closed when the subscription is.
+func (s *StreamSubscription) Events() <-chan StreamEvent {
+	return s.events
+}
+
+// Close stops the subscription.
+func (s *StreamSubscription) Close() {
+	s.once.Do(func() {
+		s.hub.mu.Lock()
+		delete(s.hub.subscribers, s)
+		s.hub.mu.Unlock()
+		close(s.events)
+	})
+}
+
+// streamHub fans status and delivery events out to subscribers, and keeps the
+// last status published for each integration so that new subscribers start
+// from a snapshot. Publishing never blocks: a subscriber whose buffer is full
+// misses the event.
+type streamHub struct {
+	mu          sync.Mutex
+	seq         uint64
+	subscribers map[*StreamSubscription]struct{}
+	statuses    map[string]models.IntegrationStatus
+}
+
+// newStreamHub creates a hub without subscribers.
+func newStreamHub() *streamHub {
+	return &streamHub{
+		subscribers: make(map[*StreamSubscription]struct{}),
+		statuses:    make(map[string]models.IntegrationStatus),
+	}
+}
+
+// subscribe registers a subscriber and returns it with the last known status
+// of every integration, as status events.
+func (h *streamHub) subscribe() (*StreamSubscription, []StreamEvent) {
+	sub := &StreamSubscription{hub: h, events: make(chan StreamEvent, defaultStreamBuffer)}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.subscribers[sub] = struct{}{}
+	snapshot := make([]StreamEvent, 0, len(h.statuses))
+	for name, st := range h.statuses {
+		st := st
+		snapshot = append(snapshot, StreamEvent{
+			Seq:         h.seq,
+			Type:        StreamStatus,
+			Integration: name,
+			Status:      &st,
+			At:          time.Now().UTC(),
+		})
+	}
+	return sub, snapshot
+}
+
+// publish numbers event and offers it to every subscriber.
+func (h *streamHub) publish(event StreamEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.seq++
+	event.Seq = h.seq
+	event.At = time.Now().UTC()
+	for sub := range h.subscribers {
+		select {
+		case sub.events <- event:
+		default:
+			streamDropped.Inc()
+		}
+	}
+}
+
+// observeStatus records st as the named integration's status, publishing it
+// when it differs from the last one in what a dashboard shows: connectivity,
+// a new error, or whether the circuit is open.
+func (h *streamHub) observeStatus(name string, st models.IntegrationStatus) {
+	h.mu.Lock()
+	previous, seen := h.statuses[name]
+	h.statuses[name] = st
+	h.mu.Unlock()
+
+	if seen && !statusChanged(previous, st) {
+		return
+	}
+	h.publish(StreamEvent{Type: StreamStatus, Integration: name, Status: &st})
+}
+
+// statusChanged reports whether two statuses of an integration differ in
+// connectivity, last error time or circuit state.
+func statusChanged(a, b models.IntegrationStatus) bool {
+	return a.Connected != b.Connected || !a.LastError.Equal(b.LastError) || reportsOpenCircuit(a) != reportsOpenCircuit(b)
+}
+
+// reportsOpenCircuit reports whether st's metadata marks the circuit open.
+func reportsOpenCircuit(st models.IntegrationStatus) bool {
+	open, _ := st.Metadata["circuitBreakerOpen"].(bool)
+	return open
+}
+
+// SubscribeStream subscribes to live status and delivery events. It returns
+// the subscription and a snapshot of each integration's last known status;
+// close the subscription when done.
+func (sm *SyncManager) SubscribeStream() (*StreamSubscription, []StreamEvent) {
+	return sm.stream.subscribe()
+}
+
+// publishDelivery publishes a delivery record as a delivery event.
+func (sm *SyncManager) publishDelivery(rec DeliveryRecord) {
+	sm.stream.publish(StreamEvent{Type: StreamDelivery, Integration: rec.Integration, Delivery: &rec})
+}
@@ -0,0 +1,143 @@
+package services
+
+import (
+	// go1.21 - Task lifetime and restart backoff
+	"context"
+	"errors"
+	// go1.21 - Run identifiers correlating the log lines of one task run
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	// go1.21 - Stack capture for recovered panics
+	"runtime/debug"
+	"time"
+
+	// v1.16.0 - Crash metrics exposed on the default registry served at /metrics
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	// v1.24.0 - Structured logging of crashes and restarts
+	"go.uber.org/zap"
+)
+
+// Restart backoff for supervised tasks.
+const (
+	supervisorInitialBackoff = time.Second
+	supervisorMaxBackoff     = time.Minute
+
+	// supervisorStableRun is how long a task must run without panicking for the
+	// backoff to reset to its initial value.
+	supervisorStableRun = 5 * time.Minute
+)
+
+// backgroundTaskPanics counts panics recovered from supervised tasks.
+var backgroundTaskPanics = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "integration_background_task_panics_total",
+	Help: "Panics recovered from supervised background tasks, by task.",
+}, []string{"task"})
+
+// ErrSendPanicked fails a send whose adapter panicked.
+var ErrSendPanicked = errors.New("send panicked")
+
+// sendRecovering runs send for the named integration, turning a panic into
+// ErrSendPanicked so that one faulty adapter fails only its own send instead
+// of killing the worker delivering it. The panic is logged with its stack and
+// counted in integration_background_task_panics_total under task.
+func sendRecovering(ctx context.Context, logger func() *zap.Logger, task, name string, send func(ctx context.Context) error) (err error) {
+	panicked, stack := runRecovering(ctx, func(ctx context.Context) { err = send(ctx) })
+	if !panicked {
+		return err
+	}
+	backgroundTaskPanics.WithLabelValues(task).Inc()
+	logger().Error("Send panicked; failing it",
+		zap.String("task", task),
+		zap.String("integration", name),
+		zap.ByteString("stack", stack),
+	)
+	return fmt.Errorf("%s: %w", name, ErrSendPanicked)
+}
+
+// Supervise runs task until ctx ends, restarting it with exponential backoff if
+// it panics. A panic is logged with its stack, the task name and a run ID that
+// changes on every restart, and counted in integration_background_task_panics_total.
+// Supervise returns when task returns normally or ctx is canceled; callers
+// usually run it in its own goroutine.
+func (sm *SyncManager) Supervise(ctx context.Context, name string, task func(ctx context.Context)) {
+	backoff := supervisorInitialBackoff
+	for restarts := 0; ; restarts++ {
+		runID := newRunID(name)
+		logger := sm.Logger().With(
+			zap.String("task", name),
+			zap.String("runID", runID),
+			zap.Int("restarts", restarts),
+		)
+
+		started := time.Now()
+		recovered, stack := runRecovering(ctx, task)
+		if !recovered {
+			return
+		}
+
+		backgroundTaskPanics.WithLabelValues(name).Inc()
+		if time.Since(started) >= supervisorStableRun {
+			backoff = supervisorInitialBackoff
+		}
+		logger.Error("Background task panicked; restarting",
+			zap.Duration("ranFor", time.Since(started)),
+			zap.Duration("backoff", backoff),
+			zap.ByteString("stack", stack),
+		)
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		backoff *= 2
+		if backoff > supervisorMaxBackoff {
+			backoff = supervisorMaxBackoff
+		}
+	}
+}
+
+// runRecovering runs task and reports whether it panicked; if so, stack holds
+// the panic value followed by the goroutine's stack.
+func runRecovering(ctx context.Context, task func(ctx context.Context)) (panicked bool, stack []byte) {
+	defer func() {
+		if r := recover(); r != nil {
+			panicked = true
+			stack = append([]byte(fmt.Sprintf("panic: %v\n\n", r)), debug.Stack()...)
+		}
+	}()
+	task(ctx)
+	return false, nil
+}
+
+// newRunID returns an identifier for one run of a supervised task.
+func newRunID(name string) string {
+	var b [6]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return fmt.Sprintf("%s-%d", name, time.Now().UnixNano())
+	}
+	return name + "-" + hex.EncodeToString(b[:])
+}
+
+// SetLogger sets the logger used for background task crashes and other
+// SyncManager events. Without it, nothing is logged.
+func (sm *SyncManager) SetLogger(logger *zap.Logger) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.logger = logger
+}
+
+// Logger returns the SyncManager's logger, never nil.
+func (sm *SyncManager) Logger() *zap.Logger {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	if sm.logger == nil {
+		return zap.NewNop()
+	}
+	return sm.logger
+}
@@ -5,14 +5,22 @@ import (
 	"context"
 	// go1.21 - Enhanced error handling with wrapping
 	"errors"
+	"fmt"
+	// go1.21 - Deterministic adapter close order
+	"sort"
 	// go1.21 - Thread-safe synchronization primitives
 	"sync"
 	// go1.21 - Time operations and duration management
 	"time"
 
+	// v1.24.0 - Structured logging of background task crashes
+	"go.uber.org/zap"
+
 	// Internal imports from the same module
 	"src/backend/services/integration/internal/config"
 	"src/backend/services/integration/internal/models"
+	"src/backend/services/integration/internal/queue"
+	"src/backend/services/integration/internal/retry"
 )
 
 // Global default and error variables for SyncManager operations.
@@ -56,8 +64,64 @@ type SyncManager struct {
 	// metrics stores per-integration synchronization metrics (e.g., success/failure counts).
 	metrics map[string]models.SyncMetrics
 
+	// bulkheads bounds concurrent calls per integration so one saturated provider
+	// cannot starve the others.
+	bulkheads map[string]*Bulkhead
+
+	// dispatcher delivers sends queued by the HTTP API on a fixed worker pool.
+	dispatcher *Dispatcher
+
+	// batcher coalesces sends to batch-capable integrations when batching is enabled.
+	batcher *Batcher
+
+	// statusChecks holds status checks still running, keyed by integration, so a
+	// hung adapter is not checked again until its previous call returns.
+	statusChecks map[string]*statusCheck
+	statusMu     sync.Mutex
+
+	// retryBudgets caps retries per integration and globally; nil when disabled.
+	retryBudgets *retry.Budgets
+
+	// sharedLimiters, when set, paces provider calls across every replica.
+	sharedLimiters *SharedLimiters
+
+	// healthMonitor, when set, tracks integration health and service readiness.
+	healthMonitor *HealthMonitor
+
+	// dependencyProbes check the dependencies other than integrations that the
+	// health report covers, by name.
+	dependencyProbes map[string]DependencyProbe
+
+	// healthReports caches the last health report; see HealthReport.
+	healthReports healthReportCache
+
+	// storeAndForward, when set, spools sends to integrations with an open circuit
+	// and replays them after recovery.
+	storeAndForward *StoreAndForward
+
 	// wg is used to wait for ongoing background synchronization routines to finish on shutdown.
 	wg *sync.WaitGroup
+
+	// logger records background task crashes; nil until SetLogger is called.
+	logger *zap.Logger
+
+	// paused holds integrations an operator has paused; see Pause.
+	paused map[string]bool
+
+	// deliveries keeps the most recent delivery attempts for the dashboard.
+	deliveries *deliveryLog
+
+	// history, when set, persists a record of every delivery attempt.
+	history *MessageHistory
+
+	// stream pushes status changes and deliveries to live subscribers.
+	stream *streamHub
+
+	// lifecycle pushes the stages of queued sends to live subscribers.
+	lifecycle *streamHub
+
+	// tickets remembers recent queued sends for DeliveryStatus.
+	tickets *ticketTracker
 }
 
 // NewSyncManager is the constructor that creates a new instance of SyncManager.
@@ -81,16 +145,44 @@ func NewSyncManager(cfg *config.Config) (*SyncManager, error) {
 		cancel:       cancelFunc,
 		syncInterval: defaultSyncInterval,
 		metrics:      make(map[string]models.SyncMetrics),
+		bulkheads:    make(map[string]*Bulkhead),
+		statusChecks: make(map[string]*statusCheck),
+		retryBudgets: retry.NewBudgets(cfg.RetryBudget),
 		wg:           &sync.WaitGroup{},
+		paused:       make(map[string]bool),
+		deliveries:   newDeliveryLog(recentDeliveriesCapacity),
+		stream:       newStreamHub(),
+		lifecycle:    newStreamHub(),
+		tickets:      newTicketTracker(recentTicketsCapacity),
+	}
+
+	// 4. Start the send pipeline; its workers deliver through sm.Send so queued
+	// sends respect each integration's bulkhead. Sends spilled to disk are
+	// encrypted like the spool's records.
+	var spillCipher queue.Cipher
+	if cfg.Dispatch.SpillEnabled() {
+		var err error
+		if spillCipher, err = queue.NewCipherFromConfig(ctx, cfg.Queue.SpoolEncryption()); err != nil {
+			cancelFunc()
+			return nil, err
+		}
+	}
+	dispatcher, err := newDispatcher(ctx, cfg.Dispatch, sm.Send, sm.publishLifecycle, sm.Logger, spillCipher)
+	if err != nil {
+		cancelFunc()
+		return nil, err
 	}
+	sm.dispatcher = dispatcher
+	sm.batcher = newBatcher(ctx, sm.sendBatch, dispatcher.submit, sm.publishLifecycle, sm.Logger, cfg.Batching.PendingLimit())
 
-	// 4. Return the fully initialized SyncManager.
+	// 5. Return the fully initialized SyncManager.
 	return sm, nil
 }
 
 // RegisterIntegration safely adds a new integration adapter under the given name.
 // If an integration by that name already exists, it returns an error. It also
-// initializes the adapter with the shared Config to ensure readiness.
+// initializes the adapter with its section of the Config, chosen by name (see
+// config.Config.IntegrationSection), to ensure readiness.
 func (sm *SyncManager) RegisterIntegration(name string, integration models.Integration) error {
 	if name == "" || integration == nil {
 		return errors.New("invalid integration registration parameters")
@@ -104,17 +196,173 @@ func (sm *SyncManager) RegisterIntegration(name string, integration models.Integ
 		return ErrIntegrationExists
 	}
 
-	// Attempt to initialize the integration with the current configuration.
-	if err := integration.Initialize(sm.cfg); err != nil {
+	// Hand adapters that retry internally their share of the retry budget.
+	if user, ok := integration.(models.RetryBudgetUser); ok {
+		user.SetRetryBudget(sm.retryBudgets.ForIntegration(name))
+	}
+
+	// Hand adapters that pace their calls the cluster-wide limiter, if any.
+	if user, ok := integration.(models.SharedLimiterUser); ok && sm.sharedLimiters != nil {
+		user.SetSharedLimiter(sm.sharedLimiters.ForIntegration(name))
+	}
+
+	// Attempt to initialize the integration with its configuration section.
+	if err := integration.Initialize(sm.cfg.IntegrationSection(name)); err != nil {
 		return err
 	}
 
+	sm.addLocked(name, integration)
+	return nil
+}
+
+// AddInitialized registers an adapter that the caller has already initialized
+// with its own configuration section, as the startup warm-up does. Otherwise it
+// behaves like RegisterIntegration.
+func (sm *SyncManager) AddInitialized(name string, integration models.Integration) error {
+	if name == "" || integration == nil {
+		return errors.New("invalid integration registration parameters")
+	}
+
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	if _, exists := sm.integrations[name]; exists {
+		return ErrIntegrationExists
+	}
+	if user, ok := integration.(models.RetryBudgetUser); ok {
+		user.SetRetryBudget(sm.retryBudgets.ForIntegration(name))
+	}
+	if user, ok := integration.(models.SharedLimiterUser); ok && sm.sharedLimiters != nil {
+		user.SetSharedLimiter(sm.sharedLimiters.ForIntegration(name))
+	}
+	sm.addLocked(name, integration)
+	return nil
+}
+
+// addLocked records an initialized integration with its metrics and bulkhead.
+// sm.mu must be held for writing.
+func (sm *SyncManager) addLocked(name string, integration models.Integration) {
 	// Register into the map.
 	sm.integrations[name] = integration
 
 	// Initialize metrics for this new integration.
 	sm.metrics[name] = models.SyncMetrics{}
-	return nil
+
+	// Isolate the integration behind its own bulkhead.
+	sm.bulkheads[name] = NewBulkhead(name, sm.cfg.Bulkheads.ForIntegration(name))
+}
+
+// GetIntegration returns the adapter registered under name, or
+// ErrIntegrationNotRegistered.
+func (sm *SyncManager) GetIntegration(name string) (models.Integration, error) {
+	sm.mu.RLock()
+	integration, exists := sm.integrations[name]
+	sm.mu.RUnlock()
+	if !exists {
+		return nil, ErrIntegrationNotRegistered
+	}
+	return integration, nil
+}
+
+// Send delivers payload to the named integration inside its bulkhead. When the
+// integration is saturated the call queues or fails with ErrBulkheadFull,
+// according to the configured policy, without affecting other integrations.
+// When store-and-forward is attached and the integration's circuit is open, the
+// message is spooled instead and ErrStoredForReplay is returned.
+func (sm *SyncManager) Send(ctx context.Context, name string, payload interface{}) error {
+	sm.mu.RLock()
+	integration, exists := sm.integrations[name]
+	sf := sm.storeAndForward
+	paused := sm.paused[name]
+	sm.mu.RUnlock()
+	if !exists {
+		return ErrIntegrationNotRegistered
+	}
+	if paused {
+		return ErrIntegrationPaused
+	}
+
+	// Without store-and-forward, failures surface to the caller as before.
+	if sf == nil {
+		return sm.deliver(ctx, name, payload)
+	}
+
+	// Divert straight to the spool while the circuit is open, and also when this
+	// send tripped it or could not be retried within the retry budget.
+	if !circuitOpen(integration) {
+		err := sm.deliver(ctx, name, payload)
+		if err == nil || !(circuitOpen(integration) || errors.Is(err, models.ErrRetryBudgetExhausted)) {
+			return err
+		}
+	}
+	if err := sf.Store(ctx, name, payload); err != nil {
+		return err
+	}
+	return ErrStoredForReplay
+}
+
+// deliver sends payload to the named integration inside its bulkhead, bypassing
+// store-and-forward. Replay uses it directly so stored messages are not re-spooled.
+func (sm *SyncManager) deliver(ctx context.Context, name string, payload interface{}) error {
+	sm.mu.RLock()
+	integration, exists := sm.integrations[name]
+	bulkhead := sm.bulkheads[name]
+	sm.mu.RUnlock()
+	if !exists {
+		return ErrIntegrationNotRegistered
+	}
+
+	sm.retryBudgets.ForIntegration(name).RecordRequest()
+	started := time.Now()
+	var result models.SendResult
+	err := bulkhead.Execute(ctx, func() error {
+		// Adapters that report message identifiers hand them to the caller
+		// through the result slot in ctx, if it carries one.
+		if sender, ok := integration.(models.ResultSender); ok {
+			var err error
+			result, err = sender.SendWithResult(ctx, payload)
+			if err == nil {
+				models.RecordSendResult(ctx, result)
+			}
+			return err
+		}
+		return integration.Send(payload)
+	})
+	sm.publishDelivery(sm.deliveries.record(name, 1, started, err))
+	sm.recordHistory(ctx, name, []interface{}{payload}, started, err)
+	sm.recordMetrics(name, result, err)
+	return err
+}
+
+// recordMetrics counts a delivery to the named integration, keeping the
+// identifiers of the item it created when it succeeded.
+func (sm *SyncManager) recordMetrics(name string, result models.SendResult, err error) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	metrics, ok := sm.metrics[name]
+	if !ok {
+		return
+	}
+	if err != nil {
+		metrics.Failed++
+	} else {
+		metrics.Delivered++
+		metrics.LastDelivery = time.Now()
+		metrics.LastResult = result
+	}
+	sm.metrics[name] = metrics
+}
+
+// BulkheadStats returns a saturation snapshot for every registered integration.
+func (sm *SyncManager) BulkheadStats() map[string]BulkheadStats {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	stats := make(map[string]BulkheadStats, len(sm.bulkheads))
+	for name, bulkhead := range sm.bulkheads {
+		stats[name] = bulkhead.Stats()
+	}
+	return stats
 }
 
 // StartSync starts the background synchronization process and metric collection.
@@ -129,11 +377,14 @@ func (sm *SyncManager) StartSync() error {
 
 	// Health monitoring or additional initialization steps could go here.
 
-	// Start the main synchronization loop in a goroutine.
+	// Start the main synchronization loop in a goroutine. The supervisor restarts
+	// it if a sync operation panics.
 	sm.wg.Add(1)
 	go func() {
 		defer sm.wg.Done()
-		sm.syncLoop()
+		sm.Supervise(sm.ctx, "sync-loop", func(context.Context) {
+			sm.syncLoop()
+		})
 	}()
 
 	// Start additional background tasks if needed, e.g., metric collection routines.
@@ -152,6 +403,9 @@ func (sm *SyncManager) StopSync() error {
 	sm.wg.Wait()
 
 	// 3. Clean up resources if needed. This could include closing open connections, etc.
+	// The dispatcher stops accepting sends; queued sends fail fast on the canceled context.
+	_ = sm.batcher.Shutdown(sm.ctx)
+	_ = sm.dispatcher.Shutdown(sm.ctx)
 
 	// 4. Optionally reset metrics upon shutdown. Example:
 	for k := range sm.metrics {
@@ -162,34 +416,125 @@ func (sm *SyncManager) StopSync() error {
 	return nil
 }
 
+// CloseIntegrations closes every registered adapter that implements
+// models.Closer, in name order. It continues past failures and returns them
+// joined, so one stuck adapter does not keep the others open.
+func (sm *SyncManager) CloseIntegrations(ctx context.Context) error {
+	sm.mu.RLock()
+	names := make([]string, 0, len(sm.integrations))
+	integrations := make(map[string]models.Integration, len(sm.integrations))
+	for name, integration := range sm.integrations {
+		names = append(names, name)
+		integrations[name] = integration
+	}
+	sm.mu.RUnlock()
+	sort.Strings(names)
+
+	var errs []error
+	for _, name := range names {
+		closer, ok := integrations[name].(models.Closer)
+		if !ok {
+			continue
+		}
+		if err := closer.Close(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("close %s: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
 // GetStatus returns a map of integration names to their current IntegrationStatus.
-// It acquires a read lock to gather statuses safely, aggregates them, then returns
-// the final result along with any encountered errors.
+// Status checks run concurrently, each bounded by its configured timeout, so a
+// hung adapter cannot stall the health endpoint. An integration that misses its
+// deadline is reported as disconnected with "degraded" metadata rather than as
+// an error. The first error reported by an adapter is returned alongside the map.
+// Statuses that changed are pushed to stream subscribers; see SubscribeStream.
 func (sm *SyncManager) GetStatus() (map[string]models.IntegrationStatus, error) {
 	sm.mu.RLock()
-	defer sm.mu.RUnlock()
+	integrations := make(map[string]models.Integration, len(sm.integrations))
+	bulkheads := make(map[string]*Bulkhead, len(sm.bulkheads))
+	metrics := make(map[string]models.SyncMetrics, len(sm.metrics))
+	for name, integration := range sm.integrations {
+		integrations[name] = integration
+		bulkheads[name] = sm.bulkheads[name]
+		metrics[name] = sm.metrics[name]
+	}
+	sm.mu.RUnlock()
 
-	statusMap := make(map[string]models.IntegrationStatus)
-	var finalErr error
+	type result struct {
+		name   string
+		status models.IntegrationStatus
+		err    error
+	}
+	results := make(chan result, len(integrations))
+	for name, integration := range integrations {
+		go func(name string, integration models.Integration) {
+			timeout := sm.cfg.StatusChecks.ForIntegration(name)
+			st, ok, err := sm.checkStatus(name, integration, timeout)
+			if !ok {
+				st = timedOutStatus(name, timeout)
+			}
+			results <- result{name: name, status: st, err: err}
+		}(name, integration)
+	}
 
-	for name, integration := range sm.integrations {
-		// Retrieve the status of each integration individually.
-		st, err := integration.Status()
-		if err != nil {
-			// We gather the first error encountered; for production-grade code,
-			// you might want to aggregate or log all errors explicitly.
-			finalErr = err
+	statusMap := make(map[string]models.IntegrationStatus, len(integrations))
+	var finalErr error
+	for range integrations {
+		r := <-results
+		if r.err != nil && finalErr == nil {
+			finalErr = r.err
 		}
-		statusMap[name] = st
+		statusMap[r.name] = withServiceMetadata(r.status, bulkheads[r.name], metrics[r.name])
+		sm.stream.observeStatus(r.name, statusMap[r.name])
 	}
 
 	return statusMap, finalErr
 }
 
+// IntegrationStatus returns the named integration's status as GetStatus
+// reports it, checking only that integration.
+func (sm *SyncManager) IntegrationStatus(name string) (models.IntegrationStatus, error) {
+	sm.mu.RLock()
+	integration, exists := sm.integrations[name]
+	bulkhead := sm.bulkheads[name]
+	metrics := sm.metrics[name]
+	sm.mu.RUnlock()
+	if !exists {
+		return models.IntegrationStatus{}, ErrIntegrationNotRegistered
+	}
+
+	timeout := sm.cfg.StatusChecks.ForIntegration(name)
+	st, ok, err := sm.checkStatus(name, integration, timeout)
+	if !ok {
+		st = timedOutStatus(name, timeout)
+	}
+	st = withServiceMetadata(st, bulkhead, metrics)
+	sm.stream.observeStatus(name, st)
+	return st, err
+}
+
+// withServiceMetadata adds the service's view of an integration, its bulkhead
+// saturation and delivery metrics, to the adapter's status.
+func withServiceMetadata(st models.IntegrationStatus, bulkhead *Bulkhead, metrics models.SyncMetrics) models.IntegrationStatus {
+	if bulkhead == nil {
+		return st
+	}
+	// Copy before adding: concurrent callers may share the same check result.
+	metadata := make(map[string]interface{}, len(st.Metadata)+2)
+	for k, v := range st.Metadata {
+		metadata[k] = v
+	}
+	metadata["bulkhead"] = bulkhead.Stats()
+	metadata["sync"] = metrics
+	st.Metadata = metadata
+	return st
+}
+
 // retryWithBackoff retries the given operation with exponential backoff until it
-// either succeeds, runs out of attempts, or the context is canceled. If all attempts
-// fail, it returns the last error encountered.
-func retryWithBackoff(ctx context.Context, operation func() error) error {
+// either succeeds, runs out of attempts or retry budget, or the context is canceled.
+// If all attempts fail, it returns the last error encountered.
+func retryWithBackoff(ctx context.Context, budget *retry.Budget, operation func() error) error {
 	var attempt int
 	backoff := time.Second // Start with a 1-second backoff.
 
@@ -205,6 +550,10 @@ func retryWithBackoff(ctx context.Context, operation func() error) error {
 			if attempt == defaultRetryAttempts {
 				return err
 			}
+			// Skip the retry when the budget is spent, so a failing provider is not hammered.
+			if !budget.AllowRetry() {
+				return fmt.Errorf("%w: %w", models.ErrRetryBudgetExhausted, err)
+			}
 
 			// Otherwise, wait with exponential backoff (but limit maximum).
 			time.Sleep(backoff)
@@ -235,28 +584,42 @@ func (sm *SyncManager) syncLoop() {
 			// Context canceled, exit the loop gracefully.
 			return
 		case <-ticker.C:
-			// Perform sync operations on each registered integration.
-			sm.mu.RLock()
-			for name, integration := range sm.integrations {
-				// Each integration can have a specialized sync operation.
-				op := func() error {
-					// Placeholder example of a "send" operation or any sync logic.
-					// In a real scenario, we might gather data from an internal queue
-					// or framework and push/pull from the external service.
-					return integration.Send("Periodic sync data")
-				}
-
-				// Example: use retryWithBackoff for robust reliability.
-				err := retryWithBackoff(sm.ctx, op)
-				if err != nil {
-					// This error could be logged, counted towards metrics, etc.
-					_ = err
-				}
-
-				// Additional metrics or checks can be updated here.
-				// For instance, sm.metrics[name] might be updated accordingly.
-			}
-			sm.mu.RUnlock()
+			sm.syncOnce()
 		}
 	}
+}
+
+// syncOnce performs one round of sync operations on each registered integration.
+// The read lock is released by defer so that a panicking adapter, recovered by
+// the supervisor, does not leave the manager locked.
+func (sm *SyncManager) syncOnce() {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	for name, integration := range sm.integrations {
+		if sm.paused[name] {
+			continue
+		}
+		bulkhead := sm.bulkheads[name]
+		budget := sm.retryBudgets.ForIntegration(name)
+		budget.RecordRequest()
+		// Each integration can have a specialized sync operation.
+		op := func() error {
+			// Placeholder example of a "send" operation or any sync logic.
+			// In a real scenario, we might gather data from an internal queue
+			// or framework and push/pull from the external service.
+			return bulkhead.Execute(sm.ctx, func() error {
+				return integration.Send("Periodic sync data")
+			})
+		}
+
+		// Example: use retryWithBackoff for robust reliability.
+		err := retryWithBackoff(sm.ctx, budget, op)
+		if err != nil {
+			// This error could be logged, counted towards metrics, etc.
+			_ = err
+		}
+
+		// Additional metrics or checks can be updated here.
+		// For instance, sm.metrics[name] might be updated accordingly.
+	}
 }
\ No newline at end of file
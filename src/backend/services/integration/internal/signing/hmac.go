@@ -0,0 +1,217 @@
+package signing
+
+import (
+	// go1.21 - HMAC-SHA256 computation and constant-time comparison
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	// go1.21 - Sentinel errors for verification outcomes
+	"errors"
+	// go1.21 - HTTP header access
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	// Internal configuration for the outbound signing key ring
+	"src/backend/services/integration/internal/config"
+)
+
+// Supported signature schemes. Each scheme describes which headers carry the
+// signature and timestamp and how the signed base string is assembled.
+const (
+	// SchemeGeneric is the service's own format, used for outbound webhooks and
+	// generic inbound sources: X-Signature: sha256=<hex> over "<timestamp>.<body>",
+	// with the Unix timestamp in X-Signature-Timestamp and the key in X-Signature-Key-Id.
+	SchemeGeneric = "generic"
+
+	// SchemeSlack follows Slack's signing secret format: X-Slack-Signature: v0=<hex>
+	// over "v0:<timestamp>:<body>" with X-Slack-Request-Timestamp.
+	SchemeSlack = "slack"
+
+	// SchemeJira follows the WebSub style used by Jira webhooks with a secret:
+	// X-Hub-Signature: sha256=<hex> over the raw body. Jira sends no timestamp.
+	SchemeJira = "jira"
+)
+
+// DefaultTolerance is the replay tolerance of a Verifier created without one.
+const DefaultTolerance = 5 * time.Minute
+
+// Header names used by SchemeGeneric.
+const (
+	HeaderSignature          = "X-Signature"
+	HeaderSignatureTimestamp = "X-Signature-Timestamp"
+	HeaderSignatureKeyID     = "X-Signature-Key-Id"
+)
+
+var (
+	// ErrMissingSignature is returned when the request carries no signature header.
+	ErrMissingSignature = errors.New("missing webhook signature")
+
+	// ErrInvalidSignature is returned when no configured secret produces a matching signature.
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+
+	// ErrStaleTimestamp is returned when the signed timestamp is outside the replay tolerance.
+	ErrStaleTimestamp = errors.New("webhook timestamp outside tolerance")
+
+	// ErrNoSigningKey is returned when an outbound signer has no active key.
+	ErrNoSigningKey = errors.New("no active signing key configured")
+)
+
+// Key is a shared secret identified by an ID so that receivers can tell which
+// secret signed a payload during rotation.
+type Key struct {
+	ID     string
+	Secret []byte
+}
+
+// Verifier validates inbound webhook signatures for one source. It accepts a
+// signature produced by any of its secrets, which allows a new secret to be
+// deployed alongside the old one before the sender switches over.
+type Verifier struct {
+	scheme    string
+	secrets   [][]byte
+	tolerance time.Duration
+	now       func() time.Time
+}
+
+// NewVerifier creates a Verifier for the given scheme. Schemes that carry a
+// timestamp reject requests signed more than tolerance away from now; a zero
+// tolerance uses DefaultTolerance.
+func NewVerifier(scheme string, secrets []string, tolerance time.Duration) *Verifier {
+	if tolerance <= 0 {
+		tolerance = DefaultTolerance
+	}
+	v := &Verifier{
+		scheme:    scheme,
+		tolerance: tolerance,
+		now:       time.Now,
+	}
+	for _, s := range secrets {
+		if s != "" {
+			v.secrets = append(v.secrets, []byte(s))
+		}
+	}
+	return v
+}
+
+// WithClock makes v read the current time from now, against which signed
+// timestamps are checked, and returns v.
+func (v *Verifier) WithClock(now func() time.Time) *Verifier {
+	v.now = now
+	return v
+}
+
+// Verify checks the signature headers of a request against its raw body.
+func (v *Verifier) Verify(header http.Header, body []byte) error {
+	var signature, timestamp, base string
+
+	switch v.scheme {
+	case SchemeSlack:
+		signature = strings.TrimPrefix(header.Get("X-Slack-Signature"), "v0=")
+		timestamp = header.Get("X-Slack-Request-Timestamp")
+		base = "v0:" + timestamp + ":" + string(body)
+	case SchemeJira:
+		signature = strings.TrimPrefix(header.Get("X-Hub-Signature"), "sha256=")
+		base = string(body)
+	default:
+		signature = strings.TrimPrefix(header.Get(HeaderSignature), "sha256=")
+		timestamp = header.Get(HeaderSignatureTimestamp)
+		base = timestamp + "." + string(body)
+	}
+
+	if signature == "" {
+		return ErrMissingSignature
+	}
+	if v.scheme != SchemeJira {
+		if err := v.checkTimestamp(timestamp); err != nil {
+			return err
+		}
+	}
+
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	for _, secret := range v.secrets {
+		if hmac.Equal(expected, computeMAC(secret, base)) {
+			return nil
+		}
+	}
+	return ErrInvalidSignature
+}
+
+// checkTimestamp rejects missing, malformed, or out-of-tolerance timestamps to
+// prevent replay of captured requests.
+func (v *Verifier) checkTimestamp(timestamp string) error {
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrStaleTimestamp
+	}
+	skew := v.now().Sub(time.Unix(seconds, 0))
+	if skew < 0 {
+		skew = -skew
+	}
+	if skew > v.tolerance {
+		return ErrStaleTimestamp
+	}
+	return nil
+}
+
+// Signer produces SchemeGeneric signatures for outbound webhook requests using
+// the active key of a key ring. Retired keys may remain configured on the
+// receiver side until every consumer has rotated.
+type Signer struct {
+	active Key
+	now    func() time.Time
+}
+
+// NewSigner creates a Signer that signs with the key matching activeKeyID, or the
+// first key when no active key ID is set.
+func NewSigner(keys []Key, activeKeyID string) (*Signer, error) {
+	for _, k := range keys {
+		if len(k.Secret) == 0 {
+			continue
+		}
+		if activeKeyID == "" || k.ID == activeKeyID {
+			return &Signer{active: k, now: time.Now}, nil
+		}
+	}
+	return nil, ErrNoSigningKey
+}
+
+// Sign sets the X-Signature, X-Signature-Timestamp, and X-Signature-Key-Id headers for body.
+func (s *Signer) Sign(header http.Header, body []byte) {
+	timestamp := strconv.FormatInt(s.now().Unix(), 10)
+	mac := computeMAC(s.active.Secret, timestamp+"."+string(body))
+
+	header.Set(HeaderSignature, "sha256="+hex.EncodeToString(mac))
+	header.Set(HeaderSignatureTimestamp, timestamp)
+	if s.active.ID != "" {
+		header.Set(HeaderSignatureKeyID, s.active.ID)
+	}
+}
+
+// KeyID returns the identifier of the key currently used for signing.
+func (s *Signer) KeyID() string {
+	return s.active.ID
+}
+
+// computeMAC returns HMAC-SHA256(secret, base).
+func computeMAC(secret []byte, base string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(base))
+	return mac.Sum(nil)
+}
+
+// NewSignerFromConfig builds a Signer from the outbound signing section of the configuration.
+func NewSignerFromConfig(cfg *config.OutboundSigningConfig) (*Signer, error) {
+	if cfg == nil {
+		return nil, ErrNoSigningKey
+	}
+	keys := make([]Key, 0, len(cfg.Keys))
+	for _, k := range cfg.Keys {
+		keys = append(keys, Key{ID: k.ID, Secret: []byte(k.Secret)})
+	}
+	return NewSigner(keys, cfg.ActiveKeyID)
+}
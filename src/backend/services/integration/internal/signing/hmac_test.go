@@ -0,0 +1,196 @@
+package signing
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// testNow is the fixed clock the verifiers under test read.
+var testNow = time.Unix(1700000000, 0)
+
+// sign returns the hex HMAC-SHA256 of base under secret, as a sender computes it.
+func sign(secret, base string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(base))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// signedHeader builds the headers a sender using scheme puts on body, signed
+// with secret at the given time.
+func signedHeader(scheme, secret string, at time.Time, body string) http.Header {
+	ts := strconv.FormatInt(at.Unix(), 10)
+	header := make(http.Header)
+	switch scheme {
+	case SchemeSlack:
+		header.Set("X-Slack-Signature", "v0="+sign(secret, "v0:"+ts+":"+body))
+		header.Set("X-Slack-Request-Timestamp", ts)
+	case SchemeJira:
+		header.Set("X-Hub-Signature", "sha256="+sign(secret, body))
+	default:
+		header.Set(HeaderSignature, "sha256="+sign(secret, ts+"."+body))
+		header.Set(HeaderSignatureTimestamp, ts)
+	}
+	return header
+}
+
+func TestVerifierVerify(t *testing.T) {
+	const body = `{"event":"issue_updated"}`
+	tolerance := 5 * time.Minute
+
+	for _, tc := range []struct {
+		name    string
+		scheme  string
+		secrets []string
+		header  http.Header
+		want    error
+	}{
+		{
+			name:    "slack base string",
+			scheme:  SchemeSlack,
+			secrets: []string{"current"},
+			header:  signedHeader(SchemeSlack, "current", testNow, body),
+		},
+		{
+			name:    "jira base string",
+			scheme:  SchemeJira,
+			secrets: []string{"current"},
+			header:  signedHeader(SchemeJira, "current", testNow, body),
+		},
+		{
+			name:    "generic base string",
+			scheme:  SchemeGeneric,
+			secrets: []string{"current"},
+			header:  signedHeader(SchemeGeneric, "current", testNow, body),
+		},
+		{
+			name:    "slack signature over the generic base string",
+			scheme:  SchemeSlack,
+			secrets: []string{"current"},
+			header: func() http.Header {
+				h := signedHeader(SchemeSlack, "current", testNow, body)
+				ts := h.Get("X-Slack-Request-Timestamp")
+				h.Set("X-Slack-Signature", "v0="+sign("current", ts+"."+body))
+				return h
+			}(),
+			want: ErrInvalidSignature,
+		},
+		{
+			name:    "rotation accepts the old secret",
+			scheme:  SchemeGeneric,
+			secrets: []string{"new", "old"},
+			header:  signedHeader(SchemeGeneric, "old", testNow, body),
+		},
+		{
+			name:    "rotation accepts the new secret",
+			scheme:  SchemeSlack,
+			secrets: []string{"new", "old"},
+			header:  signedHeader(SchemeSlack, "new", testNow, body),
+		},
+		{
+			name:    "retired secret",
+			scheme:  SchemeGeneric,
+			secrets: []string{"new"},
+			header:  signedHeader(SchemeGeneric, "old", testNow, body),
+			want:    ErrInvalidSignature,
+		},
+		{
+			name:    "within tolerance",
+			scheme:  SchemeGeneric,
+			secrets: []string{"current"},
+			header:  signedHeader(SchemeGeneric, "current", testNow.Add(-tolerance), body),
+		},
+		{
+			name:    "replayed past tolerance",
+			scheme:  SchemeGeneric,
+			secrets: []string{"current"},
+			header:  signedHeader(SchemeGeneric, "current", testNow.Add(-tolerance-time.Second), body),
+			want:    ErrStaleTimestamp,
+		},
+		{
+			name:    "slack replayed past tolerance",
+			scheme:  SchemeSlack,
+			secrets: []string{"current"},
+			header:  signedHeader(SchemeSlack, "current", testNow.Add(-time.Hour), body),
+			want:    ErrStaleTimestamp,
+		},
+		{
+			name:    "timestamp in the future",
+			scheme:  SchemeSlack,
+			secrets: []string{"current"},
+			header:  signedHeader(SchemeSlack, "current", testNow.Add(tolerance+time.Second), body),
+			want:    ErrStaleTimestamp,
+		},
+		{
+			name:    "jira carries no timestamp",
+			scheme:  SchemeJira,
+			secrets: []string{"current"},
+			header:  signedHeader(SchemeJira, "current", testNow.Add(-time.Hour), body),
+		},
+		{
+			name:    "missing timestamp",
+			scheme:  SchemeGeneric,
+			secrets: []string{"current"},
+			header: func() http.Header {
+				h := signedHeader(SchemeGeneric, "current", testNow, body)
+				h.Del(HeaderSignatureTimestamp)
+				return h
+			}(),
+			want: ErrStaleTimestamp,
+		},
+		{
+			name:    "missing signature",
+			scheme:  SchemeSlack,
+			secrets: []string{"current"},
+			header:  http.Header{"X-Slack-Request-Timestamp": {strconv.FormatInt(testNow.Unix(), 10)}},
+			want:    ErrMissingSignature,
+		},
+		{
+			name:    "malformed signature",
+			scheme:  SchemeJira,
+			secrets: []string{"current"},
+			header:  http.Header{"X-Hub-Signature": {"sha256=not-hex"}},
+			want:    ErrInvalidSignature,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			v := NewVerifier(tc.scheme, tc.secrets, tolerance).WithClock(func() time.Time { return testNow })
+			if err := v.Verify(tc.header, []byte(body)); !errors.Is(err, tc.want) {
+				t.Errorf("Verify() = %v, want %v", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestVerifierRejectsTamperedBody(t *testing.T) {
+	v := NewVerifier(SchemeSlack, []string{"current"}, time.Minute).WithClock(func() time.Time { return testNow })
+	header := signedHeader(SchemeSlack, "current", testNow, `{"amount":1}`)
+	if err := v.Verify(header, []byte(`{"amount":100}`)); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Verify() = %v, want %v", err, ErrInvalidSignature)
+	}
+}
+
+func TestSignerSignaturesVerify(t *testing.T) {
+	signer, err := NewSigner([]Key{{ID: "2023-q4", Secret: []byte("old")}, {ID: "2024-q1", Secret: []byte("new")}}, "2024-q1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer.now = func() time.Time { return testNow }
+
+	body := []byte(`{"event":"task.created"}`)
+	header := make(http.Header)
+	signer.Sign(header, body)
+	if got := header.Get(HeaderSignatureKeyID); got != "2024-q1" {
+		t.Errorf("%s = %q, want %q", HeaderSignatureKeyID, got, "2024-q1")
+	}
+
+	receiver := NewVerifier(SchemeGeneric, []string{"new", "old"}, time.Minute).WithClock(func() time.Time { return testNow })
+	if err := receiver.Verify(header, body); err != nil {
+		t.Errorf("Verify() = %v, want nil", err)
+	}
+}
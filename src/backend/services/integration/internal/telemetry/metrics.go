@@ -0,0 +1,131 @@
+package telemetry
+
+import (
+	// go1.21 - Context propagation for exporter setup and shutdown
+	"context"
+	// go1.21 - Error wrapping for exporter construction failures
+	"fmt"
+
+	// v1.24.0 - Structured logging
+	"go.uber.org/zap"
+
+	// v1.16.0 - Prometheus registry used as the single source of service metrics
+	"github.com/prometheus/client_golang/prometheus"
+
+	// v1.24.0 - OpenTelemetry global provider registration
+	"go.opentelemetry.io/otel"
+	// v1.24.0 - OTLP metric exporters (gRPC and HTTP/protobuf)
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	// v1.24.0 - OpenTelemetry metrics SDK and resource description
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	// v0.49.0 - Bridge exposing Prometheus collectors to OpenTelemetry readers
+	prometheusbridge "go.opentelemetry.io/contrib/bridges/prometheus"
+
+	// Internal configuration for exporter selection
+	"src/backend/services/integration/internal/config"
+)
+
+// ShutdownFunc flushes and stops any exporters started by SetupMetrics.
+type ShutdownFunc func(ctx context.Context) error
+
+// noopShutdown is returned when no push exporter has been started.
+func noopShutdown(context.Context) error { return nil }
+
+// SetupMetrics configures metric export according to the telemetry configuration.
+// Prometheus scraping is always backed by the provided registry and the default
+// one; when the "otlp" exporter is enabled, both are bridged into an OpenTelemetry
+// MeterProvider whose periodic reader pushes to the configured collector. This keeps
+// a single set of instruments regardless of which exporters an environment selects.
+//
+// Steps:
+//  1. Return a no-op shutdown if OTLP push is not enabled
+//  2. Build the OTLP exporter for the configured protocol
+//  3. Bridge the service and default Prometheus registries as a metric producer
+//  4. Create the MeterProvider with a periodic reader and service resource
+//  5. Register the provider globally for native OpenTelemetry instruments
+func SetupMetrics(
+	ctx context.Context,
+	cfg *config.TelemetryConfig,
+	registry *prometheus.Registry,
+	logger *zap.Logger,
+) (ShutdownFunc, error) {
+	// 1. Nothing to push unless OTLP has been selected.
+	if !cfg.ExporterEnabled(config.MetricsExporterOTLP) {
+		logger.Info("OTLP metrics export disabled")
+		return noopShutdown, nil
+	}
+	otlpCfg := cfg.Metrics.OTLP
+
+	// 2. Build the exporter for the selected transport.
+	exporter, err := newOTLPExporter(ctx, otlpCfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP metrics exporter: %w", err)
+	}
+
+	// 3. Bridge every collector registered in the Prometheus registry, together
+	// with those other packages register through promauto on the default
+	// registry, so that the push carries the same metrics as /metrics.
+	producer := prometheusbridge.NewMetricProducer(prometheusbridge.WithGatherer(
+		prometheus.Gatherers{prometheus.DefaultGatherer, registry},
+	))
+
+	reader := sdkmetric.NewPeriodicReader(exporter,
+		sdkmetric.WithInterval(otlpCfg.Interval),
+		sdkmetric.WithTimeout(otlpCfg.Timeout),
+		sdkmetric.WithProducer(producer),
+	)
+
+	// 4. Describe the service so collectors can attribute the metrics.
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(cfg.ServiceName),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build telemetry resource: %w", err)
+	}
+
+	provider := sdkmetric.NewMeterProvider(
+		sdkmetric.WithReader(reader),
+		sdkmetric.WithResource(res),
+	)
+
+	// 5. Native OpenTelemetry instruments created via otel.Meter use this provider.
+	otel.SetMeterProvider(provider)
+
+	logger.Info("OTLP metrics export enabled",
+		zap.String("endpoint", otlpCfg.Endpoint),
+		zap.String("protocol", otlpCfg.Protocol),
+		zap.Duration("interval", otlpCfg.Interval),
+	)
+
+	return provider.Shutdown, nil
+}
+
+// newOTLPExporter creates a gRPC or HTTP OTLP metric exporter from configuration.
+func newOTLPExporter(ctx context.Context, cfg *config.OTLPConfig) (sdkmetric.Exporter, error) {
+	switch cfg.Protocol {
+	case config.OTLPProtocolHTTP:
+		opts := []otlpmetrichttp.Option{
+			otlpmetrichttp.WithEndpoint(cfg.Endpoint),
+			otlpmetrichttp.WithTimeout(cfg.Timeout),
+			otlpmetrichttp.WithHeaders(cfg.Headers),
+		}
+		if cfg.Insecure {
+			opts = append(opts, otlpmetrichttp.WithInsecure())
+		}
+		return otlpmetrichttp.New(ctx, opts...)
+	default:
+		opts := []otlpmetricgrpc.Option{
+			otlpmetricgrpc.WithEndpoint(cfg.Endpoint),
+			otlpmetricgrpc.WithTimeout(cfg.Timeout),
+			otlpmetricgrpc.WithHeaders(cfg.Headers),
+		}
+		if cfg.Insecure {
+			opts = append(opts, otlpmetricgrpc.WithInsecure())
+		}
+		return otlpmetricgrpc.New(ctx, opts...)
+	}
+}